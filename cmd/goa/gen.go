@@ -26,9 +26,26 @@ type Generator struct {
 	// DesignPath is the Go import path to the design package.
 	DesignPath string
 
+	// Imports lists the Go import paths of additional design packages
+	// imported by the generator, e.g. packages defining services that
+	// are not imported by the design package.
+	Imports []string
+
 	// Output is the absolute path to the output directory.
 	Output string
 
+	// Profile is the name of the design profile applied before generating
+	// the code if any.
+	Profile string
+
+	// API is the name of the API used to generate the code if the design
+	// defines multiple APIs.
+	API string
+
+	// Languages lists the languages of the clients generated by the
+	// client command.
+	Languages []string
+
 	// DesignVersion is the major component of the Goa version used by the design DSL.
 	// DesignVersion is either 2 or 3.
 	DesignVersion int
@@ -119,6 +136,9 @@ func (g *Generator) Write(debug bool) error {
 			"Command":       g.Command,
 			"CleanupDirs":   cleanupDirs(g.Command, g.Output),
 			"DesignVersion": g.DesignVersion,
+			"Profile":       g.Profile,
+			"API":           g.API,
+			"Languages":     g.Languages,
 		}
 		ver := ""
 		if g.DesignVersion > 2 {
@@ -138,6 +158,18 @@ func (g *Generator) Write(debug bool) error {
 			codegen.NewImport("goa", "goa.design/goa/"+ver+"pkg"),
 			codegen.NewImport("_", g.DesignPath),
 		}
+		for _, p := range g.Imports {
+			imports = append(imports, codegen.NewImport("_", p))
+		}
+		if g.Profile != "" && g.DesignVersion < 3 {
+			return fmt.Errorf("design profiles require Goa v3 or above")
+		}
+		if g.API != "" && g.DesignVersion < 3 {
+			return fmt.Errorf("multiple APIs require Goa v3 or above")
+		}
+		if g.Profile != "" || g.API != "" {
+			imports = append(imports, codegen.SimpleImport("goa.design/goa/"+ver+"expr"))
+		}
 		sections = []*codegen.SectionTemplate{
 			codegen.Header("Code Generator", "main", imports),
 			{
@@ -311,6 +343,12 @@ const mainT = `func main() {
 	if err := eval.Context.Errors; err != nil {
 		fail(err.Error())
 	}
+{{- if .Profile }}
+	expr.Root.Profile = {{ printf "%q" .Profile }}
+{{- end }}
+{{- if .API }}
+	expr.Root.APIName = {{ printf "%q" .API }}
+{{- end }}
 	if err := eval.RunDSL(); err != nil {
 		fail(err.Error())
 	}
@@ -321,6 +359,9 @@ const mainT = `func main() {
 {{- end }}
 {{- if gt .DesignVersion 2 }}
 	codegen.DesignVersion = ver
+{{- end }}
+{{- if and .Languages (gt .DesignVersion 2) }}
+	generator.ClientLanguages = []string{ {{- range $i, $l := .Languages }}{{ if $i }}, {{ end }}{{ printf "%q" $l }}{{ end }} }
 {{- end }}
 	outputs, err := generator.Generate(*out, {{ printf "%q" .Command }})
 	if err != nil {
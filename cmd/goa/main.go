@@ -13,9 +13,10 @@ import (
 
 func main() {
 	var (
-		cmd    string
-		path   string
-		offset int
+		cmd     string
+		path    string
+		imports []string
+		offset  int
 	)
 	{
 		if len(os.Args) == 1 {
@@ -26,28 +27,41 @@ func main() {
 		case "version":
 			fmt.Println("Goa version " + goa.Version())
 			os.Exit(0)
-		case "gen", "example":
+		case "replay":
+			replay(os.Args[2:])
+			os.Exit(0)
+		case "gen", "example", "client", "admin":
 			if len(os.Args) == 2 {
 				usage()
 			}
 			cmd = os.Args[1]
 			path = os.Args[2]
 			offset = 2
+			for len(os.Args) > offset+1 && !strings.HasPrefix(os.Args[offset+1], "-") {
+				imports = append(imports, os.Args[offset+1])
+				offset++
+			}
 		default:
 			usage()
 		}
 	}
 
 	var (
-		output = "."
-		debug  bool
+		output  = "."
+		profile string
+		api     string
+		langs   []string
+		debug   bool
 	)
 	if len(os.Args) > offset+1 {
 		var (
 			fset = flag.NewFlagSet("default", flag.ExitOnError)
 			o    = fset.String("o", "", "output `directory`")
 			out  = fset.String("output", output, "output `directory`")
+			lang = fset.String("lang", "", "comma separated list of client `languages`")
 		)
+		fset.StringVar(&profile, "profile", "", "design profile `name`")
+		fset.StringVar(&api, "api", "", "API `name`")
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
 
 		fset.Usage = usage
@@ -57,9 +71,14 @@ func main() {
 		if output == "" {
 			output = *out
 		}
+		if *lang != "" {
+			for _, l := range strings.Split(*lang, ",") {
+				langs = append(langs, strings.TrimSpace(l))
+			}
+		}
 	}
 
-	gen(cmd, path, output, debug)
+	gen(cmd, path, imports, output, profile, api, langs, debug)
 }
 
 // help with tests
@@ -68,18 +87,24 @@ var (
 	gen   = generate
 )
 
-func generate(cmd, path, output string, debug bool) {
+func generate(cmd, path string, imports []string, output, profile, api string, langs []string, debug bool) {
 	var (
 		files []string
 		err   error
 		tmp   *Generator
 	)
 
-	if _, err = build.Import(path, ".", 0); err != nil {
-		goto fail
+	for _, p := range append([]string{path}, imports...) {
+		if _, err = build.Import(p, ".", 0); err != nil {
+			goto fail
+		}
 	}
 
 	tmp = NewGenerator(cmd, path, output)
+	tmp.Imports = imports
+	tmp.Profile = profile
+	tmp.API = api
+	tmp.Languages = langs
 	if !debug {
 		defer tmp.Remove()
 	}
@@ -111,8 +136,11 @@ func help() {
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--output DIRECTORY] [--debug]
-  goa example PACKAGE [--output DIRECTORY] [--debug]
+  goa gen PACKAGE [PACKAGE...] [--output DIRECTORY] [--profile NAME] [--api NAME] [--debug]
+  goa example PACKAGE [PACKAGE...] [--output DIRECTORY] [--profile NAME] [--api NAME] [--debug]
+  goa client PACKAGE [PACKAGE...] [--lang LANGUAGES] [--output DIRECTORY] [--profile NAME] [--api NAME] [--debug]
+  goa admin PACKAGE [PACKAGE...] [--output DIRECTORY] [--profile NAME] [--api NAME] [--debug]
+  goa replay DIRECTORY --target URL [--header HEADER...] [--ignore FIELDS] [--timeout DURATION]
   goa version
 
 Commands:
@@ -120,23 +148,51 @@ Commands:
         Generate service interfaces, endpoints, transport code and OpenAPI spec.
   example
         Generate example server and client tool.
+  client
+        Generate the API clients in the languages listed with --lang.
+  admin
+        Generate an admin server for each example server that serves the
+        health, metrics, configuration dump, feature flag and maintenance
+        endpoints on a separate internal address.
+  replay
+        Send the requests recorded by the Record HTTP middleware in
+        DIRECTORY to the service at URL and report the responses that
+        differ from the recorded responses.
   version
         Print version information.
 
 Args:
   PACKAGE
-        Go import path to design package
+        Go import path to design package, additional packages are imported
+        by the generator so that designs may be split across multiple
+        packages (e.g. shared types in one package and services in others)
 
 Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
 
+  -profile NAME
+        name of the design profile used to generate the code, see the Profile DSL
+
+  -api NAME
+        name of the API used to generate the code when the design defines
+        multiple APIs, defaults to the first API
+
+  -lang LANGUAGES
+        comma separated list of the languages of the clients generated by
+        the client command: go (Go service and transport client packages),
+        ts (TypeScript), react (TypeScript client and React Query hooks),
+        angular (TypeScript client and Angular services), python, java,
+        swift and kotlin (models only), defaults to go
+
   -debug
         Print debug information (mainly intended for Goa developers)
 
 Example:
 
   goa gen goa.design/examples/cellar/design -o gendir
+  goa client goa.design/examples/cellar/design --lang go,ts
+  goa replay ./recordings --target http://staging.internal:8080 --ignore id,created_at
 
 `)
 	os.Exit(1)
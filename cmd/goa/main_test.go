@@ -15,11 +15,17 @@ func TestCmdLine(t *testing.T) {
 		usageCalled  bool
 		cmd          string
 		path, output string
+		imports      []string
+		profile      string
+		api          string
+		langs        []string
 		debug        bool
 	)
 
 	usage = func() { usageCalled = true }
-	gen = func(c string, p, o string, d bool) { cmd, path, output, debug = c, p, o, d }
+	gen = func(c, p string, i []string, o, pr, a string, l []string, d bool) {
+		cmd, path, imports, output, profile, api, langs, debug = c, p, i, o, pr, a, l, d
+	}
 	defer func() {
 		usage = help
 		gen = generate
@@ -30,19 +36,35 @@ func TestCmdLine(t *testing.T) {
 		ExpectedUsage   bool
 		ExpectedCommand string
 		ExpectedPath    string
+		ExpectedImports string
 		ExpectedOutput  string
+		ExpectedProfile string
+		ExpectedAPI     string
+		ExpectedLangs   string
 		ExpectedDebug   bool
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen": {"gen " + testPkg, false, "gen", testPkg, "", ".", "", "", "", false},
+
+		"invalid":     {"invalid " + testPkg, true, "", "", "", ".", "", "", "", false},
+		"empty":       {"", true, "", "", "", ".", "", "", "", false},
+		"invalid gen": {"invalid gen" + testPkg, true, "", "", "", ".", "", "", "", false},
+
+		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, "", testOutput, "", "", "", false},
+		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, "", testOutput, "", "", "", false},
+
+		"profile": {"gen " + testPkg + " -profile staging", false, "gen", testPkg, "", ".", "staging", "", "", false},
+
+		"api": {"gen " + testPkg + " -api admin", false, "gen", testPkg, "", ".", "", "admin", "", false},
 
-		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
-		"empty":       {"", true, "", "", ".", false},
-		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false},
+		"client":      {"client " + testPkg, false, "client", testPkg, "", ".", "", "", "", false},
+		"client lang": {"client " + testPkg + " -lang go,ts", false, "client", testPkg, "", ".", "", "", "go,ts", false},
 
-		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false},
-		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
+		"admin": {"admin " + testPkg, false, "admin", testPkg, "", ".", "", "", "", false},
 
-		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, "", ".", "", "", "", true},
+
+		"imports":             {"gen " + testPkg + " /types /admin", false, "gen", testPkg, "/types,/admin", ".", "", "", "", false},
+		"imports with output": {"gen " + testPkg + " /types -o " + testOutput, false, "gen", testPkg, "/types", testOutput, "", "", "", false},
 	}
 
 	for k, c := range cases {
@@ -52,7 +74,11 @@ func TestCmdLine(t *testing.T) {
 			usageCalled = false
 			cmd = ""
 			path = ""
+			imports = nil
 			output = ""
+			profile = ""
+			api = ""
+			langs = nil
 			debug = false
 		}
 
@@ -67,9 +93,21 @@ func TestCmdLine(t *testing.T) {
 		if path != c.ExpectedPath {
 			t.Errorf("%s: Expected path to be %s but got %s", k, c.ExpectedPath, path)
 		}
+		if strings.Join(imports, ",") != c.ExpectedImports {
+			t.Errorf("%s: Expected imports to be %s but got %v", k, c.ExpectedImports, imports)
+		}
 		if output != c.ExpectedOutput {
 			t.Errorf("%s: Expected output to be %s but got %s", k, c.ExpectedOutput, output)
 		}
+		if profile != c.ExpectedProfile {
+			t.Errorf("%s: Expected profile to be %s but got %s", k, c.ExpectedProfile, profile)
+		}
+		if api != c.ExpectedAPI {
+			t.Errorf("%s: Expected API to be %s but got %s", k, c.ExpectedAPI, api)
+		}
+		if strings.Join(langs, ",") != c.ExpectedLangs {
+			t.Errorf("%s: Expected languages to be %s but got %v", k, c.ExpectedLangs, langs)
+		}
 		if debug != c.ExpectedDebug {
 			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
 		}
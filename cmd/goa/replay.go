@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"goa.design/goa/v3/http/middleware"
+)
+
+type (
+	// replayer sends the requests recorded by the Record middleware to a
+	// target and compares the responses with the recorded responses.
+	replayer struct {
+		client *http.Client
+		target *url.URL
+		header http.Header
+		ignore []string
+	}
+
+	// headerFlags collects the values of the repeated -header flag.
+	headerFlags []string
+)
+
+// replay implements the replay command.
+func replay(args []string) {
+	var (
+		fset    = flag.NewFlagSet("replay", flag.ExitOnError)
+		target  = fset.String("target", "", "base `URL` of the service the requests are sent to")
+		ignore  = fset.String("ignore", "", "comma separated list of JSON `fields` ignored when comparing bodies")
+		timeout = fset.Duration("timeout", 30*time.Second, "request `timeout`")
+		headers headerFlags
+	)
+	fset.Var(&headers, "header", "`header` added to the requests, e.g. \"Authorization: Bearer xxx\"")
+	fset.Usage = usage
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		usage()
+	}
+	dir := args[0]
+	fset.Parse(args[1:])
+	if *target == "" {
+		usage()
+	}
+	r, err := newReplayer(&http.Client{Timeout: *timeout}, *target, headers, *ignore)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	diffs, err := r.ReplayDir(dir, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+	if diffs > 0 {
+		os.Exit(1)
+	}
+}
+
+// newReplayer returns a replayer that sends the requests to target with the
+// given additional headers.
+func newReplayer(c *http.Client, target string, headers []string, ignore string) (*replayer, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid target %q", target)
+	}
+	h := make(http.Header)
+	for _, hdr := range headers {
+		elems := strings.SplitN(hdr, ":", 2)
+		if len(elems) != 2 {
+			return nil, fmt.Errorf("invalid header %q, must be of the form \"Name: value\"", hdr)
+		}
+		h.Add(strings.TrimSpace(elems[0]), strings.TrimSpace(elems[1]))
+	}
+	var fields []string
+	for _, f := range strings.Split(ignore, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return &replayer{client: c, target: u, header: h, ignore: fields}, nil
+}
+
+// ReplayDir replays the recordings stored in dir in the order they were
+// recorded, writes a report to w and returns the number of responses that
+// differ from the recorded responses.
+func (r *replayer) ReplayDir(dir string, w io.Writer) (int, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	sort.Strings(files)
+	var total, differ, skipped int
+	for _, f := range files {
+		rec, err := middleware.ReadRecording(f)
+		if err != nil {
+			return differ, err
+		}
+		name := filepath.Base(f)
+		if rec.Request.Truncated {
+			fmt.Fprintf(w, "SKIP %s %s (%s): request body truncated\n", rec.Request.Method, rec.Request.URL, name)
+			skipped++
+			continue
+		}
+		total++
+		diffs, err := r.Replay(rec)
+		if err != nil {
+			diffs = []string{err.Error()}
+		}
+		if len(diffs) == 0 {
+			fmt.Fprintf(w, "OK   %s %s (%s)\n", rec.Request.Method, rec.Request.URL, name)
+			continue
+		}
+		differ++
+		fmt.Fprintf(w, "DIFF %s %s (%s)\n", rec.Request.Method, rec.Request.URL, name)
+		for _, d := range diffs {
+			fmt.Fprintf(w, "    %s\n", d)
+		}
+	}
+	fmt.Fprintf(w, "%d replayed, %d differ, %d skipped\n", total, differ, skipped)
+	return differ, nil
+}
+
+// Replay sends the recorded request to the target and returns the differences
+// between the response and the recorded response.
+func (r *replayer) Replay(rec *middleware.Recording) ([]string, error) {
+	u, err := url.Parse(rec.Request.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded URL %q: %w", rec.Request.URL, err)
+	}
+	target := *r.target
+	target.Path = strings.TrimSuffix(target.Path, "/") + u.Path
+	target.RawPath = ""
+	target.RawQuery = u.RawQuery
+	req, err := http.NewRequest(rec.Request.Method, target.String(), bytes.NewReader([]byte(rec.Request.Body)))
+	if err != nil {
+		return nil, err
+	}
+	for n, vals := range rec.Request.Header {
+		if n == "Content-Length" || (len(vals) == 1 && vals[0] == middleware.Redacted) {
+			continue
+		}
+		req.Header[n] = vals
+	}
+	for n, vals := range r.header {
+		req.Header[n] = vals
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return rec.Diff(resp.StatusCode, body, r.ignore...), nil
+}
+
+// String returns the headers.
+func (h *headerFlags) String() string {
+	return strings.Join(*h, ", ")
+}
+
+// Set adds a header.
+func (h *headerFlags) Set(v string) error {
+	*h = append(*h, v)
+	return nil
+}
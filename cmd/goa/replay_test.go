@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/http/middleware"
+)
+
+func TestReplay(t *testing.T) {
+	dir := t.TempDir()
+	record := middleware.Record(dir)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"path":"` + r.URL.Path + `","version":1}`)) // nolint: errcheck
+	}))
+	for _, req := range []*http.Request{
+		httptest.NewRequest("GET", "/users/1", nil),
+		httptest.NewRequest("POST", "/users", strings.NewReader(`{"name":"joe"}`)),
+	} {
+		req.Header.Set("Authorization", "Bearer secret")
+		record.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	var auth, body string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if r.Method == "POST" {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write([]byte(`{"path":"` + strings.TrimPrefix(r.URL.Path, "/v2") + `","version":2}`)) // nolint: errcheck
+	}))
+	defer target.Close()
+
+	cases := map[string]struct {
+		ignore   string
+		diffs    int
+		expected string
+	}{
+		"diff":    {"", 2, "DIFF GET /users/1"},
+		"ignored": {"version", 0, "OK   GET /users/1"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			r, err := newReplayer(target.Client(), target.URL+"/v2", []string{"Authorization: Bearer staging"}, c.ignore)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			diffs, err := r.ReplayDir(dir, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diffs != c.diffs {
+				t.Errorf("got %d diffs, expected %d:\n%s", diffs, c.diffs, out.String())
+			}
+			if !strings.Contains(out.String(), c.expected) {
+				t.Errorf("got report:\n%s\nexpected it to contain %q", out.String(), c.expected)
+			}
+			if auth != "Bearer staging" {
+				t.Errorf("got Authorization header %q, expected %q", auth, "Bearer staging")
+			}
+			if body != `{"name":"joe"}` {
+				t.Errorf("got body %q, expected %q", body, `{"name":"joe"}`)
+			}
+		})
+	}
+}
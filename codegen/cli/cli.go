@@ -403,8 +403,10 @@ func flagType(tname string) string {
 	switch tname {
 	case boolN, intN, int32N, int64N, uintN, uint32N, uint64N, float32N, float64N, stringN:
 		return strings.ToUpper(tname)
-	case bytesN:
+	case bytesN, timeN, dateN, uuidN, decN:
 		return "STRING"
+	case durN:
+		return "INT64"
 	default: // Any, Array, Map, Object, User
 		return "JSON"
 	}
@@ -464,6 +466,11 @@ var (
 	float64N = codegen.GoNativeTypeName(expr.Float64)
 	stringN  = codegen.GoNativeTypeName(expr.String)
 	bytesN   = codegen.GoNativeTypeName(expr.Bytes)
+	timeN    = codegen.GoNativeTypeName(expr.DateTime)
+	dateN    = codegen.GoNativeTypeName(expr.Date)
+	durN     = codegen.GoNativeTypeName(expr.Duration)
+	uuidN    = codegen.GoNativeTypeName(expr.UUID)
+	decN     = codegen.GoNativeTypeName(expr.Decimal)
 )
 
 // conversionCode produces the code that converts the string contained in the
@@ -525,6 +532,21 @@ func conversionCode(from, to, typeName string, pointer bool) (string, bool, bool
 		parse = fmt.Sprintf("%s %s= []byte(%s)", target, decl, from)
 		declErr = false
 		checkErr = false
+	case timeN:
+		parse = fmt.Sprintf("%s, err %s= time.Parse(time.RFC3339, %s)", target, decl, from)
+		declErr = decl == ""
+	case dateN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseDate(%s)", target, decl, from)
+		declErr = decl == ""
+	case durN:
+		parse = fmt.Sprintf("var v int64\nv, err = strconv.ParseInt(%s, 10, 64)", from)
+		cast = fmt.Sprintf("%s %s= time.Duration(v)", target, decl)
+	case uuidN:
+		parse = fmt.Sprintf("%s, err %s= uuid.Parse(%s)", target, decl, from)
+		declErr = decl == ""
+	case decN:
+		parse = fmt.Sprintf("%s, err %s= goa.ParseDecimal(%s)", target, decl, from)
+		declErr = decl == ""
 	default:
 		parse = fmt.Sprintf("err = json.Unmarshal([]byte(%s), &%s)", from, target)
 	}
@@ -0,0 +1,50 @@
+package example
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example/testdata"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+func TestDockerFiles(t *testing.T) {
+	service.Services = make(service.ServicesData)
+	Servers = make(ServersData)
+	codegen.RunDSL(t, testdata.SingleServerSingleHostDSL)
+	if fs := DockerFiles(expr.Root); fs != nil {
+		t.Fatalf("got %d files, expected none", len(fs))
+	}
+
+	service.Services = make(service.ServicesData)
+	Servers = make(ServersData)
+	codegen.RunDSL(t, testdata.DockerDSL)
+	fs := DockerFiles(expr.Root)
+	cases := []struct {
+		Path string
+		Code string
+	}{
+		{"Dockerfile", testdata.DockerfileCode},
+		{"docker-compose.yaml", testdata.DockerComposeCode},
+	}
+	if len(fs) != len(cases) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(cases))
+	}
+	for i, c := range cases {
+		if fs[i].Path != c.Path {
+			t.Errorf("got path %q, expected %q", fs[i].Path, c.Path)
+		}
+		var buf bytes.Buffer
+		for _, s := range fs[i].SectionTemplates {
+			if err := s.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		code := buf.String()
+		if code != c.Code {
+			t.Errorf("%s: invalid code, got:\n%s\ngot vs. expected:\n%s", c.Path, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+}
@@ -37,7 +37,9 @@ func exampleCLIMain(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *c
 		{Path: "fmt"},
 		{Path: "net/url"},
 		{Path: "os"},
+		{Path: "os/signal"},
 		{Path: "strings"},
+		{Path: "time"},
 		codegen.GoaImport(""),
 	}
 	sections := []*codegen.SectionTemplate{
@@ -191,7 +193,14 @@ const (
 `
 
 	cliMainEndT = `
-	data, err := endpoint(context.Background(), payload)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancelTimeout()
+	}
+	data, err := endpoint(ctx, payload)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
@@ -0,0 +1,334 @@
+package example
+
+import (
+	"bytes"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// k8sObject is a Kubernetes object manifest.
+	k8sObject struct {
+		APIVersion string      `yaml:"apiVersion"`
+		Kind       string      `yaml:"kind"`
+		Metadata   k8sMetadata `yaml:"metadata"`
+		Spec       interface{} `yaml:"spec"`
+	}
+
+	// k8sMetadata is the metadata of a Kubernetes object.
+	k8sMetadata struct {
+		Name   string            `yaml:"name,omitempty"`
+		Labels map[string]string `yaml:"labels,omitempty"`
+	}
+
+	// k8sDeploymentSpec is the spec of a Deployment.
+	k8sDeploymentSpec struct {
+		Replicas int                `yaml:"replicas"`
+		Selector k8sSelector        `yaml:"selector"`
+		Template k8sPodTemplateSpec `yaml:"template"`
+	}
+
+	// k8sSelector is a label selector.
+	k8sSelector struct {
+		MatchLabels map[string]string `yaml:"matchLabels"`
+	}
+
+	// k8sPodTemplateSpec is the pod template of a Deployment.
+	k8sPodTemplateSpec struct {
+		Metadata k8sMetadata `yaml:"metadata"`
+		Spec     k8sPodSpec  `yaml:"spec"`
+	}
+
+	// k8sPodSpec is the spec of a pod.
+	k8sPodSpec struct {
+		Containers []*k8sContainer `yaml:"containers"`
+	}
+
+	// k8sContainer is a pod container.
+	k8sContainer struct {
+		Name           string              `yaml:"name"`
+		Image          string              `yaml:"image"`
+		Args           []string            `yaml:"args,omitempty"`
+		Env            []*k8sEnvVar        `yaml:"env,omitempty"`
+		Ports          []*k8sContainerPort `yaml:"ports,omitempty"`
+		Resources      *k8sResources       `yaml:"resources,omitempty"`
+		LivenessProbe  *k8sProbe           `yaml:"livenessProbe,omitempty"`
+		ReadinessProbe *k8sProbe           `yaml:"readinessProbe,omitempty"`
+		StartupProbe   *k8sProbe           `yaml:"startupProbe,omitempty"`
+	}
+
+	// k8sEnvVar is a container environment variable.
+	k8sEnvVar struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	}
+
+	// k8sContainerPort is a port exposed by a container.
+	k8sContainerPort struct {
+		Name          string `yaml:"name"`
+		ContainerPort int    `yaml:"containerPort"`
+	}
+
+	// k8sResources describes the compute resources of a container.
+	k8sResources struct {
+		Requests k8sResourceList `yaml:"requests"`
+	}
+
+	// k8sResourceList lists compute resource quantities.
+	k8sResourceList struct {
+		CPU    string `yaml:"cpu,omitempty"`
+		Memory string `yaml:"memory,omitempty"`
+	}
+
+	// k8sProbe is a container probe.
+	k8sProbe struct {
+		HTTPGet k8sHTTPGetAction `yaml:"httpGet"`
+	}
+
+	// k8sHTTPGetAction is the HTTP request sent by a probe.
+	k8sHTTPGetAction struct {
+		Path string `yaml:"path"`
+		Port string `yaml:"port"`
+	}
+
+	// k8sServiceSpec is the spec of a Service.
+	k8sServiceSpec struct {
+		Selector map[string]string `yaml:"selector"`
+		Ports    []*k8sServicePort `yaml:"ports"`
+	}
+
+	// k8sServicePort is a port exposed by a Service.
+	k8sServicePort struct {
+		Name       string `yaml:"name"`
+		Port       int    `yaml:"port"`
+		TargetPort string `yaml:"targetPort"`
+	}
+
+	// k8sHPASpec is the spec of a HorizontalPodAutoscaler.
+	k8sHPASpec struct {
+		ScaleTargetRef k8sScaleTargetRef `yaml:"scaleTargetRef"`
+		MinReplicas    int               `yaml:"minReplicas"`
+		MaxReplicas    int               `yaml:"maxReplicas"`
+		Metrics        []interface{}     `yaml:"metrics"`
+	}
+
+	// k8sScaleTargetRef identifies the object scaled by a
+	// HorizontalPodAutoscaler.
+	k8sScaleTargetRef struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+		Name       string `yaml:"name"`
+	}
+)
+
+// DeployFiles returns the Kubernetes Deployment, Service and (if autoscaling
+// is enabled) HorizontalPodAutoscaler manifests for every server expression in
+// the design. DeployFiles returns nil if the API does not define the
+// "k8s:deploy" meta. The manifests run the example server main generated for
+// the server and configure its ports and host variables via environment
+// variables.
+func DeployFiles(root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["k8s:deploy"]; !ok {
+		return nil
+	}
+	replicas := 1
+	if r, ok := root.API.Meta.Last("k8s:deploy:replicas"); ok {
+		if n, err := strconv.Atoi(r); err == nil && n > 0 {
+			replicas = n
+		}
+	}
+	resources := deployResources(root.API.Meta)
+	var fw []*codegen.File
+	for _, svr := range root.API.Servers {
+		svrdata := Servers.Get(svr)
+		name := strings.ReplaceAll(svrdata.Dir, "_", "-")
+		image, _ := root.API.Meta.Last("k8s:deploy")
+		if image == "" {
+			image = name
+		}
+		labels := map[string]string{"app": name}
+		container := &k8sContainer{
+			Name:      name,
+			Image:     image,
+			Args:      []string{"-domain=0.0.0.0"},
+			Resources: resources,
+		}
+		if h := svrdata.DefaultHost(); h != nil {
+			container.Args = append(container.Args, "-host="+h.Name)
+		}
+		for _, u := range svrdata.DefaultURIs() {
+			t := string(u.Transport.Type)
+			port, _ := strconv.Atoi(u.ListenPort())
+			env := strings.ToUpper(t) + "_PORT"
+			container.Args = append(container.Args, "-"+t+"-port=$("+env+")")
+			container.Env = append(container.Env, &k8sEnvVar{Name: env, Value: strconv.Itoa(port)})
+			container.Ports = append(container.Ports, &k8sContainerPort{Name: t, ContainerPort: port})
+		}
+		for _, v := range svrdata.Variables {
+			env := strings.ToUpper(codegen.SnakeCase(v.VarName))
+			container.Args = append(container.Args, "-"+v.Name+"=$("+env+")")
+			container.Env = append(container.Env, &k8sEnvVar{Name: env, Value: v.DefaultValue})
+		}
+		if hasHTTPPort(container) {
+			addProbes(container, svr)
+		}
+		dir := filepath.Join("deploy", "k8s", svrdata.Dir)
+		deployment := &k8sObject{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Metadata:   k8sMetadata{Name: name, Labels: labels},
+			Spec: &k8sDeploymentSpec{
+				Replicas: replicas,
+				Selector: k8sSelector{MatchLabels: labels},
+				Template: k8sPodTemplateSpec{
+					Metadata: k8sMetadata{Labels: labels},
+					Spec:     k8sPodSpec{Containers: []*k8sContainer{container}},
+				},
+			},
+		}
+		fw = append(fw, k8sManifestFile(filepath.Join(dir, "deployment.yaml"), deployment))
+		svcSpec := &k8sServiceSpec{Selector: labels}
+		for _, p := range container.Ports {
+			svcSpec.Ports = append(svcSpec.Ports, &k8sServicePort{Name: p.Name, Port: p.ContainerPort, TargetPort: p.Name})
+		}
+		service := &k8sObject{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Metadata:   k8sMetadata{Name: name, Labels: labels},
+			Spec:       svcSpec,
+		}
+		fw = append(fw, k8sManifestFile(filepath.Join(dir, "service.yaml"), service))
+		if as, ok := root.API.Meta["k8s:deploy:autoscale"]; ok && len(as) > 1 {
+			min, err := strconv.Atoi(as[0])
+			if err != nil {
+				continue
+			}
+			max, err := strconv.Atoi(as[1])
+			if err != nil {
+				continue
+			}
+			cpu := 80
+			if len(as) > 2 {
+				if n, err := strconv.Atoi(as[2]); err == nil {
+					cpu = n
+				}
+			}
+			hpa := &k8sObject{
+				APIVersion: "autoscaling/v2",
+				Kind:       "HorizontalPodAutoscaler",
+				Metadata:   k8sMetadata{Name: name, Labels: labels},
+				Spec: &k8sHPASpec{
+					ScaleTargetRef: k8sScaleTargetRef{APIVersion: "apps/v1", Kind: "Deployment", Name: name},
+					MinReplicas:    min,
+					MaxReplicas:    max,
+					Metrics: []interface{}{map[string]interface{}{
+						"type": "Resource",
+						"resource": map[string]interface{}{
+							"name":   "cpu",
+							"target": map[string]interface{}{"type": "Utilization", "averageUtilization": cpu},
+						},
+					}},
+				},
+			}
+			fw = append(fw, k8sManifestFile(filepath.Join(dir, "hpa.yaml"), hpa))
+		}
+	}
+	return fw
+}
+
+// deployResources returns the compute resources requested by the containers
+// given the API meta. The CPU and memory requests are set with the
+// "k8s:deploy:resources" meta and default to 100m and 128Mi when autoscaling
+// is enabled as the HorizontalPodAutoscaler computes the CPU utilization
+// relative to the requests. deployResources returns nil if neither meta is
+// set.
+func deployResources(meta expr.MetaExpr) *k8sResources {
+	res, ok := meta["k8s:deploy:resources"]
+	if _, autoscale := meta["k8s:deploy:autoscale"]; !ok && !autoscale {
+		return nil
+	}
+	requests := k8sResourceList{CPU: "100m", Memory: "128Mi"}
+	if len(res) > 0 && res[0] != "" {
+		requests.CPU = res[0]
+	}
+	if len(res) > 1 && res[1] != "" {
+		requests.Memory = res[1]
+	}
+	return &k8sResources{Requests: requests}
+}
+
+// addProbes sets the container probes using the HTTP endpoints of the methods
+// hosted by the server that define the "k8s:probe" meta.
+func addProbes(c *k8sContainer, svr *expr.ServerExpr) {
+	for _, name := range svr.Services {
+		hs := expr.Root.API.HTTP.Service(name)
+		if hs == nil {
+			continue
+		}
+		for _, e := range hs.HTTPEndpoints {
+			probes, ok := e.MethodExpr.Meta["k8s:probe"]
+			if !ok || len(e.Routes) == 0 {
+				continue
+			}
+			probe := &k8sProbe{HTTPGet: k8sHTTPGetAction{Path: e.Routes[0].FullPaths()[0], Port: string(TransportHTTP)}}
+			for _, p := range probes {
+				switch p {
+				case "liveness":
+					if c.LivenessProbe == nil {
+						c.LivenessProbe = probe
+					}
+				case "readiness":
+					if c.ReadinessProbe == nil {
+						c.ReadinessProbe = probe
+					}
+				case "startup":
+					if c.StartupProbe == nil {
+						c.StartupProbe = probe
+					}
+				}
+			}
+		}
+	}
+}
+
+// hasHTTPPort returns true if the container exposes a HTTP port.
+func hasHTTPPort(c *k8sContainer) bool {
+	for _, p := range c.Ports {
+		if p.Name == string(TransportHTTP) {
+			return true
+		}
+	}
+	return false
+}
+
+// k8sManifestFile returns the file that contains the YAML manifest of the
+// given Kubernetes object.
+func k8sManifestFile(path string, obj *k8sObject) *codegen.File {
+	return &codegen.File{
+		Path: path,
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:    "k8s-manifest",
+			Source:  "{{ k8sManifest . }}",
+			Data:    obj,
+			FuncMap: map[string]interface{}{"k8sManifest": k8sManifest},
+		}},
+		SkipExist: true,
+	}
+}
+
+// k8sManifest renders the YAML manifest of the given Kubernetes object.
+func k8sManifest(obj *k8sObject) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
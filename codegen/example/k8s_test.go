@@ -0,0 +1,72 @@
+package example
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example/testdata"
+	"goa.design/goa/v3/codegen/service"
+	"goa.design/goa/v3/expr"
+)
+
+func TestDeployFiles(t *testing.T) {
+	service.Services = make(service.ServicesData)
+	Servers = make(ServersData)
+	codegen.RunDSL(t, testdata.SingleServerSingleHostDSL)
+	if fs := DeployFiles(expr.Root); fs != nil {
+		t.Fatalf("got %d files, expected none", len(fs))
+	}
+
+	service.Services = make(service.ServicesData)
+	Servers = make(ServersData)
+	codegen.RunDSL(t, testdata.DeployDSL)
+	fs := DeployFiles(expr.Root)
+	cases := []struct {
+		Path string
+		Code string
+	}{
+		{"deploy/k8s/deploy_server/deployment.yaml", testdata.DeployDeploymentCode},
+		{"deploy/k8s/deploy_server/service.yaml", testdata.DeployServiceCode},
+		{"deploy/k8s/deploy_server/hpa.yaml", testdata.DeployHPACode},
+	}
+	if len(fs) != len(cases) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(cases))
+	}
+	for i, c := range cases {
+		if fs[i].Path != c.Path {
+			t.Errorf("got path %q, expected %q", fs[i].Path, c.Path)
+		}
+		var buf bytes.Buffer
+		for _, s := range fs[i].SectionTemplates {
+			if err := s.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		code := buf.String()
+		if code != c.Code {
+			t.Errorf("%s: invalid code, got:\n%s\ngot vs. expected:\n%s", c.Path, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+}
+
+func TestDeployResources(t *testing.T) {
+	cases := map[string]struct {
+		Meta     expr.MetaExpr
+		Expected *k8sResources
+	}{
+		"none":      {expr.MetaExpr{"k8s:deploy": {"image"}}, nil},
+		"autoscale": {expr.MetaExpr{"k8s:deploy:autoscale": {"2", "10"}}, &k8sResources{Requests: k8sResourceList{CPU: "100m", Memory: "128Mi"}}},
+		"cpu":       {expr.MetaExpr{"k8s:deploy:resources": {"250m"}}, &k8sResources{Requests: k8sResourceList{CPU: "250m", Memory: "128Mi"}}},
+		"both":      {expr.MetaExpr{"k8s:deploy:autoscale": {"2", "10"}, "k8s:deploy:resources": {"250m", "256Mi"}}, &k8sResources{Requests: k8sResourceList{CPU: "250m", Memory: "256Mi"}}},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			got := deployResources(c.Meta)
+			if !reflect.DeepEqual(got, c.Expected) {
+				t.Errorf("got %+v, expected %+v", got, c.Expected)
+			}
+		})
+	}
+}
@@ -169,6 +169,41 @@ func (h *HostData) DefaultURL(transport Transport) string {
 	return ""
 }
 
+// DefaultURIs returns the first URI defined in the default host for each
+// transport supported by the server.
+func (s *Data) DefaultURIs() []*URIData {
+	h := s.DefaultHost()
+	if h == nil {
+		return nil
+	}
+	var uris []*URIData
+	for _, t := range s.Transports {
+		for _, u := range h.URIs {
+			if u.Transport.Type == t.Type {
+				uris = append(uris, u)
+				break
+			}
+		}
+	}
+	return uris
+}
+
+// ListenPort returns the port defined in the URL, the default port for the
+// URL scheme if the URL does not define one.
+func (u *URIData) ListenPort() string {
+	host := u.URL
+	if idx := strings.Index(host, "://"); idx >= 0 {
+		host = host[idx+3:]
+	}
+	if idx := strings.Index(host, "/"); idx >= 0 {
+		host = host[:idx]
+	}
+	if idx := strings.LastIndex(host, ":"); idx >= 0 {
+		return host[idx+1:]
+	}
+	return u.Port
+}
+
 // buildServerData builds the server data for the given server expression.
 func buildServerData(svr *expr.ServerExpr) *Data {
 	var (
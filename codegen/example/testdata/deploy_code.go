@@ -0,0 +1,92 @@
+package testdata
+
+const DeployDeploymentCode = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deploy-server
+  labels:
+    app: deploy-server
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: deploy-server
+  template:
+    metadata:
+      labels:
+        app: deploy-server
+    spec:
+      containers:
+        - name: deploy-server
+          image: registry.example.com/deploy:v1
+          args:
+            - -domain=0.0.0.0
+            - -host=dev
+            - -http-port=$(HTTP_PORT)
+            - -grpc-port=$(GRPC_PORT)
+            - -version=$(VERSION)
+          env:
+            - name: HTTP_PORT
+              value: "8090"
+            - name: GRPC_PORT
+              value: "8091"
+            - name: VERSION
+              value: v1
+          ports:
+            - name: http
+              containerPort: 8090
+            - name: grpc
+              containerPort: 8091
+          resources:
+            requests:
+              cpu: 100m
+              memory: 128Mi
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: http
+          readinessProbe:
+            httpGet:
+              path: /healthz
+              port: http
+`
+
+const DeployServiceCode = `apiVersion: v1
+kind: Service
+metadata:
+  name: deploy-server
+  labels:
+    app: deploy-server
+spec:
+  selector:
+    app: deploy-server
+  ports:
+    - name: http
+      port: 8090
+      targetPort: http
+    - name: grpc
+      port: 8091
+      targetPort: grpc
+`
+
+const DeployHPACode = `apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: deploy-server
+  labels:
+    app: deploy-server
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: deploy-server
+  minReplicas: 2
+  maxReplicas: 10
+  metrics:
+    - resource:
+        name: cpu
+        target:
+          averageUtilization: 75
+          type: Utilization
+      type: Resource
+`
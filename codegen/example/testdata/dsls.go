@@ -281,3 +281,58 @@ var ConflictWithAPINameAndServiceNamesIncludingMultipartDSL = func() {
 		})
 	})
 }
+
+var DeployDSL = func() {
+	API("Deploy", func() {
+		Meta("k8s:deploy", "registry.example.com/deploy:v1")
+		Meta("k8s:deploy:replicas", "2")
+		Meta("k8s:deploy:autoscale", "2", "10", "75")
+		Server("Deploy Server", func() {
+			Services("Service")
+			Host("dev", func() {
+				URI("http://localhost:8090/{version}")
+				URI("grpc://localhost:8091")
+				Variable("version", String, func() {
+					Default("v1")
+				})
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Health", func() {
+			Meta("k8s:probe", "liveness", "readiness")
+			HTTP(func() {
+				GET("/healthz")
+			})
+		})
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+			GRPC(func() {})
+		})
+	})
+}
+
+var DockerDSL = func() {
+	API("Docker", func() {
+		Meta("docker:compose", "postgres", "redis:6", "jaeger")
+		Server("Docker Server", func() {
+			Services("Service")
+			Host("dev", func() {
+				URI("http://localhost:8090")
+				URI("grpc://localhost:8091")
+			})
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+			GRPC(func() {})
+		})
+	})
+}
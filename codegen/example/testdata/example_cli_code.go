@@ -70,7 +70,14 @@ const (
 		os.Exit(1)
 	}
 
-	data, err := endpoint(context.Background(), payload)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancelTimeout()
+	}
+	data, err := endpoint(ctx, payload)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
@@ -180,7 +187,14 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	data, err := endpoint(context.Background(), payload)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancelTimeout()
+	}
+	data, err := endpoint(ctx, payload)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
@@ -306,7 +320,14 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	data, err := endpoint(context.Background(), payload)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancelTimeout()
+	}
+	data, err := endpoint(ctx, payload)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
@@ -425,7 +446,14 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	data, err := endpoint(context.Background(), payload)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancelTimeout()
+	}
+	data, err := endpoint(ctx, payload)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
@@ -554,7 +582,14 @@ func indent(s string) string {
 		os.Exit(1)
 	}
 
-	data, err := endpoint(context.Background(), payload)
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancelTimeout()
+	}
+	data, err := endpoint(ctx, payload)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		os.Exit(1)
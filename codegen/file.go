@@ -34,6 +34,13 @@ type (
 		// SkipExist indicates whether the file should be skipped if one
 		// already exists at the given path.
 		SkipExist bool
+		// BuildTags is the build constraint expression written in a
+		// "//go:build" line at the top of Go files, e.g. "metrics" or
+		// "linux && !nopprof". The file is only compiled when the
+		// constraint is satisfied which makes it possible to exclude
+		// optional features and their dependencies from builds without
+		// editing the generated code.
+		BuildTags string
 		// FinalizeFunc is called after the file has been generated. It
 		// is given the absolute path to the file as argument.
 		FinalizeFunc func(string) error
@@ -94,6 +101,11 @@ func (f *File) Render(dir string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if f.BuildTags != "" && filepath.Ext(path) == ".go" {
+		if _, err := fmt.Fprintf(file, "//go:build %s\n\n", f.BuildTags); err != nil {
+			return "", err
+		}
+	}
 	for _, s := range f.SectionTemplates {
 		if err := s.Write(file); err != nil {
 			return "", err
@@ -0,0 +1,28 @@
+package codegen
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileRenderBuildTags(t *testing.T) {
+	dir := t.TempDir()
+	f := &File{
+		Path:             "feature.go",
+		SectionTemplates: []*SectionTemplate{Header("", "main", nil), {Source: "var x = 1\n"}},
+		BuildTags:        "metrics && !nometrics",
+	}
+	path, err := f.Render(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = "//go:build metrics && !nometrics\n\npackage main\n"
+	if !strings.HasPrefix(string(b), expected) {
+		t.Errorf("got\n%s\nexpected prefix\n%s", b, expected)
+	}
+}
@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	httpcodegen "goa.design/goa/v3/http/codegen"
+)
+
+// Admin iterates through the roots and returns the files that implement the
+// admin servers which serve the operational endpoints of the example servers
+// on a separate listener.
+func Admin(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue // could be a plugin root expression
+		}
+		files = append(files, httpcodegen.AdminFiles(genpkg, r)...)
+	}
+	return files, nil
+}
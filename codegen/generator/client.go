@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/client"
+)
+
+// ClientLanguages lists the languages of the clients generated by the "client"
+// command. "go" selects the Go service and transport client packages, the
+// other languages must have a generator registered with client.Register.
+var ClientLanguages = []string{"go"}
+
+// Client iterates through the roots and returns the files that implement the
+// clients in the languages listed in ClientLanguages. The clients in languages
+// other than Go are generated from the language independent description of the
+// HTTP API built by client.NewAPI.
+func Client(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, root := range roots {
+		r, ok := root.(*expr.RootExpr)
+		if !ok {
+			continue // could be a plugin root expression
+		}
+		var api *client.API
+		for _, lang := range ClientLanguages {
+			if lang == "go" {
+				fs, err := goClientFiles(genpkg, roots)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, fs...)
+				continue
+			}
+			gen, ok := client.Lookup(lang)
+			if !ok {
+				return nil, fmt.Errorf("unknown client language %q, supported languages are go, %s", lang, strings.Join(client.Languages(), ", "))
+			}
+			if r.API.HTTP == nil || len(r.API.HTTP.Services) == 0 {
+				return nil, fmt.Errorf("%s client requires the design to define HTTP services", lang)
+			}
+			if api == nil {
+				api = client.NewAPI(r)
+			}
+			fs, err := gen(genpkg, api)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, fs...)
+		}
+	}
+	return dedupeFiles(files), nil
+}
+
+// dedupeFiles removes the files whose path is the path of a previous file,
+// e.g. the TypeScript client generated by both the "ts" and "react" languages.
+func dedupeFiles(files []*codegen.File) []*codegen.File {
+	seen := make(map[string]bool)
+	res := files[:0]
+	for _, f := range files {
+		if seen[f.Path] {
+			continue
+		}
+		seen[f.Path] = true
+		res = append(res, f)
+	}
+	return res
+}
+
+// goClientFiles returns the files generated by the Service and Transport
+// generators except for the server packages.
+func goClientFiles(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	var files []*codegen.File
+	for _, gen := range []Genfunc{Service, Transport} {
+		fs, err := gen(genpkg, roots)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fs {
+			if isServerFile(f.Path) {
+				continue
+			}
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// isServerFile returns true if the file at the given path belongs to a server
+// package.
+func isServerFile(path string) bool {
+	for _, dir := range strings.Split(filepath.Dir(path), string(filepath.Separator)) {
+		if dir == "server" {
+			return true
+		}
+	}
+	return false
+}
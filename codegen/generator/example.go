@@ -30,6 +30,16 @@ func Example(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			files = append(files, fs...)
 		}
 
+		// Kubernetes manifests
+		if fs := example.DeployFiles(r); len(fs) != 0 {
+			files = append(files, fs...)
+		}
+
+		// Docker files
+		if fs := example.DockerFiles(r); len(fs) != 0 {
+			files = append(files, fs...)
+		}
+
 		// CLI main
 		if fs := example.CLIFiles(genpkg, r); len(fs) != 0 {
 			files = append(files, fs...)
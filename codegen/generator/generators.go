@@ -24,6 +24,10 @@ func generators(cmd string) ([]Genfunc, error) {
 		return []Genfunc{Service, Transport, OpenAPI}, nil
 	case "example":
 		return []Genfunc{Example}, nil
+	case "client":
+		return []Genfunc{Client}, nil
+	case "admin":
+		return []Genfunc{Admin}, nil
 	default:
 		return nil, fmt.Errorf("unknown command %q", cmd)
 	}
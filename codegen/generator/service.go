@@ -25,11 +25,21 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 				if f := service.ViewsFile(genpkg, s); f != nil {
 					files = append(files, f)
 				}
+				if f := service.ComputedFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.SecurityConfigFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
+				if f := service.FakeFile(genpkg, s); f != nil {
+					files = append(files, f)
+				}
 				for _, f := range files {
 					if len(f.SectionTemplates) > 0 {
 						service.AddServiceDataMetaTypeImports(f.SectionTemplates[0], s)
 					}
 				}
+				files = append(files, service.K8sFiles(genpkg, s)...)
 				f, err := service.ConvertFile(r, s)
 				if err != nil {
 					return nil, err
@@ -22,11 +22,16 @@ func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 
 		// HTTP
 		files = append(files, httpcodegen.ServerFiles(genpkg, r)...)
+		files = append(files, httpcodegen.ServerFuzzFiles(genpkg, r)...)
 		files = append(files, httpcodegen.ClientFiles(genpkg, r)...)
 		files = append(files, httpcodegen.ServerTypeFiles(genpkg, r)...)
 		files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
 		files = append(files, httpcodegen.PathFiles(r)...)
 		files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
+		files = append(files, httpcodegen.TerraformFiles(genpkg, r)...)
+		files = append(files, httpcodegen.SOAPFiles(genpkg, r)...)
+		files = append(files, httpcodegen.LoadTestFiles(r)...)
+		files = append(files, httpcodegen.GatewayFiles(r)...)
 
 		// GRPC
 		files = append(files, grpccodegen.ProtoFiles(genpkg, r)...)
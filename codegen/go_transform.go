@@ -9,7 +9,7 @@ import (
 	"goa.design/goa/v3/expr"
 )
 
-var transformGoArrayT, transformGoMapT, transformGoUnionT, transformGoUnionToObjectT, transformGoObjectToUnionT *template.Template
+var transformGoArrayT, transformGoMapT, transformGoUnionT, transformGoUnionToObjectT, transformGoObjectToUnionT, transformGoUnionToDiscriminatedObjectT, transformGoDiscriminatedObjectToUnionT *template.Template
 
 // NOTE: can't initialize inline because https://github.com/golang/go/issues/1817
 func init() {
@@ -19,6 +19,8 @@ func init() {
 	transformGoUnionT = template.Must(template.New("transformGoUnion").Funcs(fm).Parse(transformGoUnionTmpl))
 	transformGoUnionToObjectT = template.Must(template.New("transformGoUnionToObject").Funcs(fm).Parse(transformGoUnionToObjectTmpl))
 	transformGoObjectToUnionT = template.Must(template.New("transformGoObjectToUnion").Funcs(fm).Parse(transformGoObjectToUnionTmpl))
+	transformGoUnionToDiscriminatedObjectT = template.Must(template.New("transformGoUnionToDiscriminatedObject").Funcs(fm).Parse(transformGoUnionToDiscriminatedObjectTmpl))
+	transformGoDiscriminatedObjectToUnionT = template.Must(template.New("transformGoDiscriminatedObjectToUnion").Funcs(fm).Parse(transformGoDiscriminatedObjectToUnionTmpl))
 }
 
 // GoTransform produces Go code that initializes the data structure defined
@@ -38,7 +40,9 @@ func init() {
 // "Type" which is of type string and contains the value type name (union types
 // are otherwise implemented as a struct containing a single field: the current
 // value - however having the kind explicitly stored is required to serialize to
-// JSON for example).
+// JSON for example). Union types with a discriminator map instead to objects
+// whose first attribute is the discriminator followed by the attributes of all
+// the union types.
 //
 // source and target are the attributes used in the transformation
 //
@@ -126,6 +130,10 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 			return transformObjectToUnion(source, target, sourceVar, targetVar, newVar, ta)
 		}
 
+		// fields promoted from embedded structs cannot be set in the
+		// composite literal
+		promoted := EmbeddedFields(target.Type)
+
 		// walk through primitives first to initialize the struct
 		walkMatches(source, target, func(srcMatt, tgtMatt *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
 			if !expr.IsPrimitive(srcc.Type) {
@@ -181,6 +189,10 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 					exp = srcField
 				}
 			}
+			if _, ok := promoted[tgtField]; ok {
+				postInitCode += fmt.Sprintf("%s.%s = %s\n", targetVar, tgtField, exp)
+				return
+			}
 			initCode += fmt.Sprintf("\n%s: %s,", tgtField, exp)
 		})
 		if initCode != "" {
@@ -394,6 +406,9 @@ func transformUnion(source, target *expr.AttributeExpr, sourceVar, targetVar str
 }
 
 func transformUnionToObject(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	if expr.AsUnion(source.Type).Discriminator != "" {
+		return transformUnionToDiscriminatedObject(source, target, sourceVar, targetVar, newVar, ta)
+	}
 	obj := expr.AsObject(target.Type)
 	if (*obj)[0].Attribute.Type != expr.String {
 		return "", fmt.Errorf("union to object transform requires first field to be string")
@@ -425,6 +440,9 @@ func transformUnionToObject(source, target *expr.AttributeExpr, sourceVar, targe
 }
 
 func transformObjectToUnion(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	if expr.AsUnion(target.Type).Discriminator != "" {
+		return transformDiscriminatedObjectToUnion(source, target, sourceVar, targetVar, newVar, ta)
+	}
 	obj := expr.AsObject(source.Type)
 	if (*obj)[0].Attribute.Type != expr.String {
 		return "", fmt.Errorf("union to object transform requires first field to be string")
@@ -462,6 +480,81 @@ func transformObjectToUnion(source, target *expr.AttributeExpr, sourceVar, targe
 	return buf.String(), nil
 }
 
+// transformUnionToDiscriminatedObject generates Go code to transform source
+// union to the target object holding the discriminator and the fields of all
+// the union types.
+func transformUnionToDiscriminatedObject(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	srcUnion := expr.AsUnion(source.Type)
+	tgtMatt := expr.NewMappedAttributeExpr(target)
+	disc := expr.AsObject(tgtMatt.Type).Attribute(srcUnion.Discriminator)
+	if disc == nil {
+		return "", fmt.Errorf("union to object transform requires discriminator attribute %q", srcUnion.Discriminator)
+	}
+	field := targetVar + "." + GoifyAtt(disc, tgtMatt.ElemName(srcUnion.Discriminator), true)
+	sourceTypeRefs := make([]string, len(srcUnion.Values))
+	discriminators := make([]string, len(srcUnion.Values))
+	for i, st := range srcUnion.Values {
+		sourceTypeRefs[i] = ta.SourceCtx.Scope.Ref(st.Attribute, ta.SourceCtx.Pkg(st.Attribute))
+		if ta.TargetCtx.IsPrimitivePointer(srcUnion.Discriminator, tgtMatt.AttributeExpr) {
+			discriminators[i] = fmt.Sprintf("kind := %q\n%s = &kind", st.Name, field)
+		} else {
+			discriminators[i] = fmt.Sprintf("%s = %q", field, st.Name)
+		}
+	}
+	data := map[string]interface{}{
+		"NewVar":         newVar,
+		"TargetVar":      targetVar,
+		"TypeRef":        ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
+		"SourceVar":      sourceVar,
+		"SourceTypes":    srcUnion.Values,
+		"SourceTypeRefs": sourceTypeRefs,
+		"Target":         target,
+		"Discriminators": discriminators,
+		"TransformAttrs": ta,
+	}
+	var buf bytes.Buffer
+	if err := transformGoUnionToDiscriminatedObjectT.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// transformDiscriminatedObjectToUnion generates Go code to transform the
+// source object holding the discriminator and the fields of all the union
+// types to target union.
+func transformDiscriminatedObjectToUnion(source, target *expr.AttributeExpr, sourceVar, targetVar string, newVar bool, ta *TransformAttrs) (string, error) {
+	tgtUnion := expr.AsUnion(target.Type)
+	srcMatt := expr.NewMappedAttributeExpr(source)
+	disc := expr.AsObject(srcMatt.Type).Attribute(tgtUnion.Discriminator)
+	if disc == nil {
+		return "", fmt.Errorf("object to union transform requires discriminator attribute %q", tgtUnion.Discriminator)
+	}
+	field := sourceVar + "." + GoifyAtt(disc, srcMatt.ElemName(tgtUnion.Discriminator), true)
+	if ta.SourceCtx.IsPrimitivePointer(tgtUnion.Discriminator, srcMatt.AttributeExpr) {
+		field = "*" + field
+	}
+	unionTypes := make([]string, len(tgtUnion.Values))
+	for i, tt := range tgtUnion.Values {
+		unionTypes[i] = tt.Name
+	}
+	data := map[string]interface{}{
+		"NewVar":         newVar,
+		"TargetVar":      targetVar,
+		"TypeRef":        ta.TargetCtx.Scope.Ref(target, ta.TargetCtx.Pkg(target)),
+		"Source":         source,
+		"SourceVar":      sourceVar,
+		"Discriminator":  field,
+		"UnionTypes":     unionTypes,
+		"TargetTypes":    tgtUnion.Values,
+		"TransformAttrs": ta,
+	}
+	var buf bytes.Buffer
+	if err := transformGoDiscriminatedObjectToUnionT.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
 // transformAttributeHelpers returns the Go transform functions and their definitions
 // that may be used in code produced by Transform. It returns an error if source and
 // target are incompatible (different types, fields of different type etc).
@@ -494,6 +587,14 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *Transform
 	case expr.IsUnion(source.Type):
 		tt := expr.AsUnion(target.Type)
 		if tt == nil {
+			if su := expr.AsUnion(source.Type); su.Discriminator != "" {
+				for _, st := range su.Values {
+					if other, err = collectDiscriminatedHelpers(st.Attribute, target, ta, seen); err != nil {
+						return
+					}
+					helpers = append(helpers, other...)
+				}
+			}
 			return
 		}
 		for i, st := range expr.AsUnion(source.Type).Values {
@@ -502,7 +603,15 @@ func transformAttributeHelpers(source, target *expr.AttributeExpr, ta *Transform
 			}
 		}
 	case expr.IsObject(source.Type):
-		if expr.IsUnion(target.Type) {
+		if tu := expr.AsUnion(target.Type); tu != nil {
+			if tu.Discriminator != "" {
+				for _, tt := range tu.Values {
+					if other, err = collectDiscriminatedHelpers(source, tt.Attribute, ta, seen); err != nil {
+						return
+					}
+					helpers = append(helpers, other...)
+				}
+			}
 			return
 		}
 		walkMatches(source, target, func(srcMatt, _ *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
@@ -550,6 +659,14 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	case expr.IsUnion(source.Type):
 		tt := expr.AsUnion(target.Type)
 		if tt == nil {
+			if su := expr.AsUnion(source.Type); su.Discriminator != "" {
+				for _, st := range su.Values {
+					if other, err = collectDiscriminatedHelpers(st.Attribute, target, ta, seen); err != nil {
+						return
+					}
+					helpers = append(helpers, other...)
+				}
+			}
 			return
 		}
 		for i, st := range expr.AsUnion(source.Type).Values {
@@ -558,7 +675,15 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 			}
 		}
 	case expr.IsObject(source.Type):
-		if expr.IsUnion(target.Type) {
+		if tu := expr.AsUnion(target.Type); tu != nil {
+			if tu.Discriminator != "" {
+				for _, tt := range tu.Values {
+					if other, err = collectDiscriminatedHelpers(source, tt.Attribute, ta, seen); err != nil {
+						return
+					}
+					helpers = append(helpers, other...)
+				}
+			}
 			return
 		}
 		walkMatches(source, target, func(srcMatt, _ *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
@@ -573,6 +698,22 @@ func collectHelpers(source, target *expr.AttributeExpr, req bool, ta *TransformA
 	return
 }
 
+// collectDiscriminatedHelpers returns the transform helper functions required
+// to transform the fields of a union type with a discriminator from or to the
+// fields of the corresponding object.
+func collectDiscriminatedHelpers(source, target *expr.AttributeExpr, ta *TransformAttrs, seen map[string]*TransformFunctionData) (helpers []*TransformFunctionData, err error) {
+	walkMatches(source, target, func(srcMatt, _ *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
+		if err != nil {
+			return
+		}
+		var other []*TransformFunctionData
+		if other, err = collectHelpers(srcc, tgtc, srcMatt.IsRequired(n), ta, seen); err == nil {
+			helpers = append(helpers, other...)
+		}
+	})
+	return
+}
+
 // generateHelper generates the code that transform instances of source into
 // target. Both source and targe must be user types or generateHelper panics.
 // generateHelper returns nil if a helper has already been generated for the
@@ -683,6 +824,26 @@ switch {{ .SourceVar }}.(type) {
 	Type: name,
 	Value: string(js),
 }
+`
+
+	transformGoUnionToDiscriminatedObjectTmpl = `{{ if .NewVar }}var {{ .TargetVar }} {{ .TypeRef }}
+{{ end }}switch actual := {{ .SourceVar }}.(type) {
+	{{- range $i, $ref := .SourceTypeRefs }}
+	case {{ $ref }}:
+		{{ transformAttribute (index $.SourceTypes $i).Attribute $.Target "actual" $.TargetVar false $.TransformAttrs -}}
+		{{ index $.Discriminators $i }}
+	{{- end }}
+}
+`
+
+	transformGoDiscriminatedObjectToUnionTmpl = `{{ if .NewVar }}var {{ .TargetVar }} {{ .TypeRef }}
+{{ end }}switch {{ .Discriminator }} {
+	{{- range $i, $name := .UnionTypes }}
+	case {{ printf "%q" $name }}:
+		{{ transformAttribute $.Source (index $.TargetTypes $i).Attribute $.SourceVar "val" true $.TransformAttrs -}}
+		{{ $.TargetVar }} = val
+	{{- end }}
+}
 `
 
 	transformGoObjectToUnionTmpl = `{{ if .NewVar }}var {{ .TargetVar }} {{ .TypeRef }}
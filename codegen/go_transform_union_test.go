@@ -18,7 +18,9 @@ func TestGoTransformUnion(t *testing.T) {
 		unionStringInt  = root.UserType("Container").Attribute().Find("UnionStringInt").Find("UnionStringInt")
 		unionStringInt2 = root.UserType("Container").Attribute().Find("UnionStringInt2").Find("UnionStringInt2")
 		unionSomeType   = root.UserType("Container").Attribute().Find("UnionSomeType").Find("UnionSomeType")
+		unionDiscrim    = root.UserType("Container").Attribute().Find("UnionDiscriminated").Find("UnionDiscriminated")
 		userType        = &expr.AttributeExpr{Type: root.UserType("UnionUserType")}
+		discrimType     = &expr.AttributeExpr{Type: root.UserType("DiscriminatedUserType")}
 		defaultCtx      = NewAttributeContext(false, false, true, "", scope)
 	)
 	tc := []struct {
@@ -37,6 +39,9 @@ func TestGoTransformUnion(t *testing.T) {
 		{"User Type to UnionString", userType, unionString, userTypeToUnionStringCode},
 		{"User Type to UnionStringInt", userType, unionStringInt, userTypeToUnionStringIntCode},
 		{"User Type to UnionSomeType", userType, unionSomeType, userTypeToUnionSomeTypeCode},
+
+		{"UnionDiscriminated to User Type", unionDiscrim, discrimType, unionDiscriminatedToUserTypeCode},
+		{"User Type to UnionDiscriminated", discrimType, unionDiscrim, userTypeToUnionDiscriminatedCode},
 	}
 	for _, c := range tc {
 		t.Run(c.Name, func(t *testing.T) {
@@ -193,3 +198,41 @@ const userTypeToUnionSomeTypeCode = `func transform() {
 	}
 }
 `
+
+const unionDiscriminatedToUserTypeCode = `func transform() {
+	var target *DiscriminatedUserType
+	switch actual := source.(type) {
+	case *SomeType:
+		target = &DiscriminatedUserType{
+			SomeField: actual.SomeField,
+		}
+		target.Kind = "some"
+	case *OtherType:
+		target = &DiscriminatedUserType{
+			SomeField:  actual.SomeField,
+			OtherField: &actual.OtherField,
+		}
+		target.Kind = "other"
+	}
+}
+`
+
+const userTypeToUnionDiscriminatedCode = `func transform() {
+	var target *UnionDiscriminated
+	switch source.Kind {
+	case "some":
+		val := &SomeType{
+			SomeField: source.SomeField,
+		}
+		target = val
+	case "other":
+		val := &OtherType{
+			SomeField: source.SomeField,
+		}
+		if source.OtherField != nil {
+			val.OtherField = *source.OtherField
+		}
+		target = val
+	}
+}
+`
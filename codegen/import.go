@@ -100,7 +100,8 @@ func (loc *Location) PackageName() string {
 }
 
 // GetMetaType retrieves the type and package defined by the struct:field:type
-// metadata if any.
+// metadata if any. It also returns the Go type and package of the DateTime,
+// Date, Duration, UUID and Decimal primitive types.
 func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec) {
 	if att == nil {
 		return
@@ -115,11 +116,25 @@ func GetMetaType(att *expr.AttributeExpr) (typeName string, importS *ImportSpec)
 		if len(args) > 2 {
 			importS.Name = args[2]
 		}
+		return
+	}
+	switch att.Type {
+	case expr.DateTime:
+		return "time.Time", &ImportSpec{Path: "time"}
+	case expr.Date:
+		return "goa.Date", GoaImport("")
+	case expr.Duration:
+		return "time.Duration", &ImportSpec{Path: "time"}
+	case expr.UUID:
+		return "uuid.UUID", &ImportSpec{Path: "github.com/google/uuid"}
+	case expr.Decimal:
+		return "goa.Decimal", GoaImport("")
 	}
 	return
 }
 
 // GetMetaTypeImports parses the attribute for all user defined imports
+// including the packages of custom validation functions.
 func GetMetaTypeImports(att *expr.AttributeExpr) []*ImportSpec {
 	return safelyGetMetaTypeImports(att, nil)
 }
@@ -176,6 +191,13 @@ func safelyGetMetaTypeImports(att *expr.AttributeExpr, seen map[string]struct{})
 	if im != nil {
 		uniqueImports[*im] = struct{}{}
 	}
+	if att.Validation != nil {
+		for _, f := range att.Validation.Funcs {
+			if f.Path != "" {
+				uniqueImports[ImportSpec{Path: f.Path}] = struct{}{}
+			}
+		}
+	}
 	for imp := range uniqueImports {
 		// Copy loop variable into body so next iteration doesn't overwrite its address https://stackoverflow.com/questions/27610039/golang-appending-leaves-only-last-element
 		copy := imp
@@ -104,6 +104,24 @@ func TestGetMetaTypeImports(t *testing.T) {
 				"package/array",
 			},
 		},
+		{
+			name: "payload-validation-func",
+			dsl: func() {
+				dsl.Method("m", func() {
+					dsl.Payload(func() {
+						dsl.Attribute("a", dsl.Int, func() {
+							dsl.ValidateWith("validators.ValidateA", "package/validators")
+						})
+						dsl.Attribute("b", dsl.String, func() {
+							dsl.ValidateWith("ValidateB")
+						})
+					})
+				})
+			},
+			want: []string{
+				"package/validators",
+			},
+		},
 		{
 			name: "result",
 			dsl: func() {
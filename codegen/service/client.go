@@ -24,6 +24,7 @@ func ClientFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		imports := []*codegen.ImportSpec{
 			{Path: "context"},
 			{Path: "io"},
+			{Path: "time"},
 			codegen.GoaImport(""),
 		}
 		imports = append(imports, svc.UserTypeImports...)
@@ -41,11 +42,30 @@ func ClientFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
 		sections = []*codegen.SectionTemplate{header, def, init}
 		for _, m := range data.Methods {
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "client-method",
-				Source: serviceClientMethodT,
-				Data:   m,
+				Name:    "client-method",
+				Source:  serviceClientMethodT,
+				Data:    m,
+				FuncMap: map[string]interface{}{"durationCode": codegen.DurationCode},
 			})
 		}
+		for _, m := range data.Methods {
+			if m.LongRunning != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-wait",
+					Source: serviceClientWaitT,
+					Data:   m,
+				})
+			}
+		}
+		for _, m := range data.Methods {
+			if m.Pagination != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-iterator",
+					Source: serviceClientIteratorT,
+					Data:   m,
+				})
+			}
+		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
@@ -81,11 +101,18 @@ const serviceClientMethodT = `
 	{{- end }}
 //	- error: internal error
 {{- end }}
+{{- if .Timeout }}
+{{ printf "%s cancels the request if it does not complete within %s." .VarName .Timeout | comment }}
+{{- end }}
 {{- $resultType := .ResultRef }}
 {{- if .ClientStream }}
 	{{- $resultType = .ClientStream.Interface }}
 {{- end }}
 func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}{{ end }}{{ if .MethodData.SkipRequestBodyEncodeDecode}}, req io.ReadCloser{{ end }}) ({{ if $resultType }}res {{ $resultType }}, {{ end }}{{ if .MethodData.SkipResponseBodyEncodeDecode }}resp io.ReadCloser, {{ end }}err error) {
+	{{- if .Timeout }}
+	ctx, cancel := context.WithTimeout(ctx, {{ durationCode .Timeout }})
+	defer cancel()
+	{{- end }}
 	{{- if or $resultType .MethodData.SkipResponseBodyEncodeDecode }}
 	var ires interface{}
 	{{- end }}
@@ -105,3 +132,94 @@ func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .Payloa
 	{{- end }}
 }
 `
+
+// input: endpointMethodData
+const serviceClientIteratorT = `{{ printf "%s iterates over the items of the pages returned by the %q endpoint of the %q service." .Pagination.IteratorName .Name .ServiceName | comment }}
+type {{ .Pagination.IteratorName }} struct {
+	client  *{{ .ClientVarName }}
+	ctx     context.Context
+	payload {{ .Pagination.PayloadName }}
+	items   []{{ .Pagination.ItemRef }}
+	item    {{ .Pagination.ItemRef }}
+	done    bool
+	err     error
+}
+
+{{ printf "%s returns an iterator over the items of the pages returned by the %q endpoint of the %q service. The iterator retrieves the pages lazily starting with the page identified by the cursor of p." .Pagination.IterName .Name .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) {{ .Pagination.IterName }}(ctx context.Context, p {{ .PayloadRef }}) *{{ .Pagination.IteratorName }} {
+	it := &{{ .Pagination.IteratorName }}{client: c, ctx: ctx}
+	if p != nil {
+		it.payload = *p
+	}
+	return it
+}
+
+// Next advances the iterator to the next item, retrieving the next page if
+// needed. Next returns false once all the pages have been retrieved or if an
+// error occurred, use Err to tell the two cases apart.
+func (it *{{ .Pagination.IteratorName }}) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		var res {{ .ResultRef }}
+		res, it.err = it.client.{{ .VarName }}(it.ctx, &it.payload)
+		if it.err != nil {
+			return false
+		}
+		it.items = res.{{ .Pagination.ItemsField }}
+	{{- if .Pagination.NextCursorPointer }}
+		if res.{{ .Pagination.NextCursorField }} == nil || *res.{{ .Pagination.NextCursorField }} == {{ .Pagination.CursorZero }} {
+			it.done = true
+		} else {
+			it.payload.{{ .Pagination.CursorField }} = {{ if not .Pagination.CursorPointer }}*{{ end }}res.{{ .Pagination.NextCursorField }}
+		}
+	{{- else }}
+		if res.{{ .Pagination.NextCursorField }} == {{ .Pagination.CursorZero }} {
+			it.done = true
+		} else {
+			{{- if .Pagination.CursorPointer }}
+			next := res.{{ .Pagination.NextCursorField }}
+			it.payload.{{ .Pagination.CursorField }} = &next
+			{{- else }}
+			it.payload.{{ .Pagination.CursorField }} = res.{{ .Pagination.NextCursorField }}
+			{{- end }}
+		}
+	{{- end }}
+	}
+	it.item = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *{{ .Pagination.IteratorName }}) Item() {{ .Pagination.ItemRef }} {
+	return it.item
+}
+
+// Err returns the error that caused Next to return false if any.
+func (it *{{ .Pagination.IteratorName }}) Err() error {
+	return it.err
+}
+`
+
+// input: endpointMethodData
+const serviceClientWaitT = `{{ printf "%s calls the %q endpoint of the %q service and polls the status of the operation it starts every interval until the operation completes. %s returns the status of the completed operation." .LongRunning.WaitName .Name .ServiceName .LongRunning.WaitName | comment }}
+func (c *{{ .ClientVarName }}) {{ .LongRunning.WaitName }}(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}, {{ end }}interval time.Duration) (res {{ .ResultRef }}, err error) {
+	res, err = c.{{ .VarName }}(ctx{{ if .PayloadRef }}, p{{ end }})
+	for err == nil && {{ if .LongRunning.DonePointer }}(res.{{ .LongRunning.DoneField }} == nil || !*res.{{ .LongRunning.DoneField }}){{ else }}!res.{{ .LongRunning.DoneField }}{{ end }} {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		res, err = c.{{ .LongRunning.StatusVarName }}(ctx, &{{ .LongRunning.StatusPayloadName }}{
+			{{ .LongRunning.IDField }}: res.{{ .LongRunning.IDField }},
+		{{- range .LongRunning.Fields }}
+			{{ . }}: p.{{ . }},
+		{{- end }}
+		})
+	}
+	return
+}
+`
@@ -29,6 +29,10 @@ func TestClient(t *testing.T) {
 		{"streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodClient},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingMethodDSL, testdata.BidirectionalStreamingMethodClient},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodClient},
+		{"timeout", testdata.TimeoutMethodDSL, testdata.TimeoutMethodClient},
+		{"paginated", testdata.PaginatedMethodDSL, testdata.PaginatedMethodClient},
+		{"paginated-required-cursor", testdata.PaginatedRequiredCursorMethodDSL, testdata.PaginatedRequiredCursorMethodClient},
+		{"long-running", testdata.LongRunningMethodDSL, testdata.LongRunningMethodClient},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
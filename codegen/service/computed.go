@@ -0,0 +1,65 @@
+package service
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// ComputedFile returns the file that defines the hooks used to compute the
+// attributes defined with Computed in the service types, nil if there are
+// none.
+func ComputedFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	if len(svc.ComputedTypes) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, svc.PathName, "computed.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" computed attributes", svc.PkgName, nil),
+	}
+	for _, t := range svc.ComputedTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "computed-type",
+			Source:  computedTypeT,
+			Data:    t,
+			FuncMap: map[string]interface{}{"quoteNames": quoteNames},
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// quoteNames returns the quoted names joined with commas and "and".
+func quoteNames(names []string) string {
+	var res string
+	for i, n := range names {
+		switch {
+		case i == 0:
+		case i == len(names)-1:
+			res += " and "
+		default:
+			res += ", "
+		}
+		res += `"` + n + `"`
+	}
+	return res
+}
+
+// input: ComputedTypeData
+const computedTypeT = `{{- range .Fields }}
+{{ printf "%s computes the %q attribute of %s from the %s attributes. Compute sets the attribute using the function if not nil." .HookName .Name $.VarName (quoteNames .From) | comment }}
+var {{ .HookName }} func(*{{ $.VarName }}) {{ .TypeRef }}
+{{ end }}
+{{ printf "Compute sets the computed attributes of %s using the corresponding hooks. The generated endpoints call Compute on the method results." .VarName | comment }}
+func (t *{{ .VarName }}) Compute() {
+	if t == nil {
+		return
+	}
+{{- range .Fields }}
+	if {{ .HookName }} != nil {
+		t.{{ .FieldName }} = {{ .HookName }}(t)
+	}
+{{- end }}
+}
+`
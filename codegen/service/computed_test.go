@@ -0,0 +1,45 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestComputedFile(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleEndpointDSL)
+	if f := ComputedFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+		t.Fatalf("got file %s, expected nil", f.Path)
+	}
+
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"computed", testdata.ComputedEndpointDSL, testdata.ComputedCode},
+		{"computed-viewed", testdata.ComputedViewedEndpointDSL, testdata.ComputedViewedCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			f := ComputedFile("goa.design/goa/example", expr.Root.Services[0])
+			if f == nil {
+				t.Fatal("got nil file, expected not nil")
+			}
+			var buf bytes.Buffer
+			for _, s := range f.SectionTemplates[1:] {
+				if err := s.Write(&buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
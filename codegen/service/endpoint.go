@@ -337,6 +337,15 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		if err != nil {
 			return nil, err
 		}
+{{- end }}
+		ctx, err {{ if not .Requirements }}:{{ end }}= goa.RunAuthHooks(ctx, {{ if .PayloadRef }}{{ $payload }}{{ else }}nil{{ end }})
+		if err != nil {
+			return nil, err
+		}
+{{- if .Authorize }}
+		if err := s.Authorize{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }}); err != nil {
+			return nil, err
+		}
 {{- end }}
 {{- if .ServerStream }}
 	return nil, s.{{ .VarName }}(ctx, {{ if .PayloadRef }}{{ $payload }}, {{ end }}ep.Stream)
@@ -362,6 +371,9 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 	if err != nil {
 		return nil, err
 	}
+	{{- if .Computed }}
+	res.Compute()
+	{{- end }}
 	vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
 	return vres, nil
 {{- else if .SkipResponseBodyEncodeDecode }}
@@ -370,6 +382,13 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		return nil, err
 	}
 	return &{{ .ResponseStruct }}{ {{ if .ResultRef }}Result: res, {{ end }}Body: body }, nil
+{{- else if .Computed }}
+	res, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+	if err != nil {
+		return nil, err
+	}
+	res.Compute()
+	return res, nil
 {{- else }}
 	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
 {{- end }}
@@ -31,6 +31,9 @@ func TestEndpoint(t *testing.T) {
 		{"streaming-payload-no-result", testdata.StreamingPayloadNoResultMethodDSL, testdata.StreamingPayloadNoResultMethodEndpoint},
 		{"bidirectional-streaming", testdata.BidirectionalStreamingEndpointDSL, testdata.BidirectionalStreamingMethodEndpoint},
 		{"bidirectional-streaming-no-payload", testdata.BidirectionalStreamingNoPayloadMethodDSL, testdata.BidirectionalStreamingNoPayloadMethodEndpoint},
+		{"computed", testdata.ComputedEndpointDSL, testdata.ComputedEndpoint},
+		{"computed-viewed", testdata.ComputedViewedEndpointDSL, testdata.ComputedViewedEndpoint},
+		{"authorize", testdata.AuthorizeEndpointDSL, testdata.AuthorizeEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
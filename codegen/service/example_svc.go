@@ -88,6 +88,12 @@ func exampleServiceFile(genpkg string, root *expr.RootExpr, svc *expr.ServiceExp
 	}
 	for _, m := range svc.Methods {
 		sections = append(sections, basicEndpointSection(m, data))
+		if m.Authorize {
+			sec := basicEndpointSection(m, data)
+			sec.Name = "basic-authorizer"
+			sec.Source = authorizerT
+			sections = append(sections, sec)
+		}
 	}
 
 	return &codegen.File{
@@ -175,5 +181,21 @@ func (s *{{ .ServiceVarName }}srvc) {{ .VarName }}(ctx context.Context{{ if .Pay
 	s.logger.Print("{{ .ServiceVarName }}.{{ .Name }}")
 	return
 }
+`
+
+	// input: basicEndpointData
+	authorizerT = `{{ printf "Authorize%s authorizes the calls made to the %q method." .VarName .Name | comment }}
+func (s *{{ .ServiceVarName }}srvc) Authorize{{ .VarName }}(ctx context.Context{{ if .PayloadFullRef }}, p {{ .PayloadFullRef }}{{ end }}) error {
+	//
+	// TBD: add object-level authorization logic.
+	//
+	// In case of authorization failure this function should return
+	// an error, e.g.:
+	//
+	//    return goa.PermanentError(goa.PermissionDenied, "access denied")
+	//
+	s.logger.Print("{{ .ServiceVarName }}.Authorize{{ .VarName }}")
+	return nil
+}
 `
 )
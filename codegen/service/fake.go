@@ -0,0 +1,371 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// fakeRange is the size of the range of the generated numbers and the
+	// maximum number of characters added to the minimum length of the
+	// generated strings when the design does not define a maximum.
+	fakeRange = 1000
+	// fakeLen is the default maximum length of the generated strings.
+	fakeLen = 10
+	// fakeItems is the default maximum length of the generated arrays and
+	// maps.
+	fakeItems = 3
+)
+
+type (
+	// fakeTypeData describes the function that generates random values of
+	// a user type.
+	fakeTypeData struct {
+		// Name is the user type name.
+		Name string
+		// FuncName is the name of the exported function.
+		FuncName string
+		// InternalName is the name of the function that takes the
+		// depth of the generated value.
+		InternalName string
+		// Ref is the reference to the Go type including the package.
+		Ref string
+		// Code is the body of the internal function.
+		Code string
+	}
+
+	// faker computes the code that generates random values for the user
+	// types of a service.
+	faker struct {
+		scope *codegen.NameScope
+		pkg   string
+	}
+)
+
+// FakeFile returns the file that defines functions producing random values of
+// the service user types that satisfy the validations defined in the design.
+// The functions are intended for property-based tests and fake service
+// implementations. FakeFile returns nil unless the service or the API define
+// the "fake:generate" meta.
+func FakeFile(genpkg string, svc *expr.ServiceExpr) *codegen.File {
+	_, ok := svc.Meta["fake:generate"]
+	if !ok {
+		_, ok = expr.Root.API.Meta["fake:generate"]
+	}
+	if !ok {
+		return nil
+	}
+	data := Services.Get(svc.Name)
+	f := &faker{scope: data.Scope, pkg: data.PkgName}
+	var uts []expr.UserType
+	for _, m := range svc.Methods {
+		for _, att := range []*expr.AttributeExpr{m.Payload, m.Result} {
+			if ut, ok := att.Type.(expr.UserType); ok {
+				uts = append(uts, ut)
+			}
+		}
+	}
+	for _, ut := range append(data.userTypes, data.errorTypes...) {
+		uts = append(uts, ut.Type)
+	}
+	var (
+		types []*fakeTypeData
+		seen  = make(map[string]struct{})
+	)
+	for _, ut := range uts {
+		if _, ok := seen[ut.ID()]; ok || ut == expr.ErrorResult || ut == expr.Empty {
+			continue
+		}
+		seen[ut.ID()] = struct{}{}
+		code := f.typeCode(ut)
+		if code == "" {
+			continue
+		}
+		name := f.scope.GoTypeName(&expr.AttributeExpr{Type: ut})
+		types = append(types, &fakeTypeData{
+			Name:         ut.Name(),
+			FuncName:     "New" + name,
+			InternalName: "new" + name,
+			Ref:          f.scope.GoFullTypeRef(&expr.AttributeExpr{Type: ut}, f.pkg),
+			Code:         code,
+		})
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, data.PathName, "fake", "fake.go")
+	imports := append([]*codegen.ImportSpec{
+		{Path: "math/rand"},
+		codegen.GoaNamedImport("fake", "goafake"),
+		{Path: genpkg + "/" + data.PathName, Name: data.PkgName},
+	}, data.UserTypeImports...)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name+" service random value generators", "fake", imports),
+		{Name: "fake-doc", Source: fakeDocT, Data: data},
+	}
+	for _, t := range types {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "fake-type",
+			Source: fakeTypeT,
+			Data:   t,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// typeCode returns the body of the function that generates random values of
+// the given user type, the empty string if the type is not supported.
+func (f *faker) typeCode(ut expr.UserType) string {
+	att := ut.Attribute()
+	obj, ok := att.Type.(*expr.Object)
+	if !ok {
+		val := f.value(att)
+		if val == "" {
+			return ""
+		}
+		return fmt.Sprintf("return %s(%s)", f.scope.GoFullTypeRef(&expr.AttributeExpr{Type: ut}, f.pkg), val)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "v := &%s{}\n", f.scope.GoFullTypeName(&expr.AttributeExpr{Type: ut}, f.pkg))
+	for _, nat := range *obj {
+		if t, _ := codegen.GetMetaType(nat.Attribute); t != "" {
+			continue
+		}
+		field := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		if u, ok := nat.Attribute.Type.(*expr.Union); ok {
+			b.WriteString(f.unionCode(u, field, att.IsRequired(nat.Name)))
+			continue
+		}
+		val := f.value(nat.Attribute)
+		if val == "" {
+			continue
+		}
+		switch {
+		case att.IsPrimitivePointer(nat.Name, true):
+			fmt.Fprintf(&b, "if goafake.Bool(r) {\n\tx := %s\n\tv.%s = &x\n}\n", val, field)
+		case att.IsRequired(nat.Name) || att.HasDefaultValue(nat.Name) || expr.IsPrimitive(nat.Attribute.Type):
+			fmt.Fprintf(&b, "v.%s = %s\n", field, val)
+		default:
+			fmt.Fprintf(&b, "if depth < maxDepth && goafake.Bool(r) {\n\tv.%s = %s\n}\n", field, val)
+		}
+	}
+	b.WriteString("return v")
+	return b.String()
+}
+
+// unionCode returns the code that sets the given union field to a random
+// value, the empty string if the union has values that are not user types.
+func (f *faker) unionCode(u *expr.Union, field string, required bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "switch r.Intn(%d) {\n", len(u.Values))
+	for i, nat := range u.Values {
+		if _, ok := nat.Attribute.Type.(expr.UserType); !ok {
+			return ""
+		}
+		fmt.Fprintf(&b, "case %d:\n\tv.%s = %s\n", i, field, f.value(nat.Attribute))
+	}
+	b.WriteString("}\n")
+	if required {
+		return b.String()
+	}
+	return "if depth < maxDepth && goafake.Bool(r) {\n" + b.String() + "}\n"
+}
+
+// value returns the expression that generates a random value for the given
+// attribute, the empty string if the attribute type is not supported (inline
+// objects and unions which are handled by unionCode).
+func (f *faker) value(att *expr.AttributeExpr) string {
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		return "new" + f.scope.GoTypeName(&expr.AttributeExpr{Type: dt}) + "(r, depth+1)"
+	case expr.Primitive:
+		return f.primitive(att, dt)
+	case *expr.Array:
+		elem := f.value(dt.ElemType)
+		if elem == "" {
+			return ""
+		}
+		min, max := lengths(att, fakeItems)
+		return fmt.Sprintf("func() %s {\n\tn := goafake.Len(r, %d, %d)\n\tif depth >= maxDepth {\n\t\tn = %d\n\t}\n\ts := make(%s, n)\n\tfor i := range s {\n\t\ts[i] = %s\n\t}\n\treturn s\n}()",
+			f.ref(att), min, max, min, f.ref(att), elem)
+	case *expr.Map:
+		key, elem := f.value(dt.KeyType), f.value(dt.ElemType)
+		if key == "" || elem == "" {
+			return ""
+		}
+		min, max := lengths(att, fakeItems)
+		return fmt.Sprintf("func() %s {\n\tn := goafake.Len(r, %d, %d)\n\tif depth >= maxDepth {\n\t\tn = %d\n\t}\n\tm := make(%s, n)\n\tfor i := 0; i < n; i++ {\n\t\tm[%s] = %s\n\t}\n\treturn m\n}()",
+			f.ref(att), min, max, min, f.ref(att), key, elem)
+	}
+	return ""
+}
+
+// primitive returns the expression that generates a random value for the
+// given primitive attribute.
+func (f *faker) primitive(att *expr.AttributeExpr, p expr.Primitive) string {
+	ref := f.ref(att)
+	if v := att.Validation; v != nil && len(v.Values) > 0 {
+		vals := make([]string, len(v.Values))
+		for i, val := range v.Values {
+			vals[i] = fmt.Sprintf("%#v", val)
+		}
+		return fmt.Sprintf("[]%s{%s}[r.Intn(%d)]", ref, strings.Join(vals, ", "), len(vals))
+	}
+	switch p.Kind() {
+	case expr.BooleanKind:
+		return "goafake.Bool(r)"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		min, max := intRange(att, p.Kind())
+		return fmt.Sprintf("%s(goafake.Int(r, %d, %d))", ref, min, max)
+	case expr.Float32Kind, expr.Float64Kind:
+		min, max := floatRange(att)
+		return fmt.Sprintf("%s(goafake.Float(r, %s, %s))", ref, strconv.FormatFloat(min, 'g', -1, 64), strconv.FormatFloat(max, 'g', -1, 64))
+	case expr.StringKind:
+		min, max := lengths(att, fakeLen)
+		if v := att.Validation; v != nil && v.Format != "" {
+			return fmt.Sprintf("goafake.Format(r, %q)", string(v.Format))
+		}
+		if v := att.Validation; v != nil && v.Pattern != "" {
+			return fmt.Sprintf("goafake.Pattern(r, %q, %d, %d)", v.Pattern, min, max)
+		}
+		return fmt.Sprintf("goafake.String(r, %d, %d)", min, max)
+	case expr.BytesKind:
+		min, max := lengths(att, fakeLen)
+		return fmt.Sprintf("goafake.Bytes(r, %d, %d)", min, max)
+	}
+	return "nil"
+}
+
+// ref returns the reference to the Go type of the attribute.
+func (f *faker) ref(att *expr.AttributeExpr) string {
+	return f.scope.GoFullTypeRef(att, f.pkg)
+}
+
+// lengths returns the range of the lengths of the values generated for the
+// given string, bytes, array or map attribute.
+func lengths(att *expr.AttributeExpr, def int) (int, int) {
+	min, max := 0, -1
+	if v := att.Validation; v != nil {
+		if v.MinLength != nil {
+			min = *v.MinLength
+		}
+		if v.MaxLength != nil {
+			max = *v.MaxLength
+		}
+	}
+	if max < 0 {
+		max = min + def
+	}
+	return min, max
+}
+
+// intRange returns the range of the values generated for the given integer
+// attribute.
+func intRange(att *expr.AttributeExpr, kind expr.Kind) (int64, int64) {
+	lo, hi := float64(-fakeRange), float64(fakeRange)
+	switch kind {
+	case expr.Int32Kind:
+		lo, hi = math.MinInt32, math.MaxInt32
+	case expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		lo = 0
+		if kind == expr.UInt32Kind {
+			hi = math.MaxUint32
+		}
+	}
+	min, max := bounds(att.Validation, 1)
+	if min != nil {
+		lo = math.Max(lo, math.Ceil(*min))
+	}
+	if max != nil {
+		hi = math.Min(hi, math.Floor(*max))
+	}
+	hi = math.Min(hi, 1<<62)
+	switch {
+	case min != nil && max == nil:
+		hi = math.Min(hi, lo+fakeRange)
+	case max != nil && min == nil:
+		lo = math.Max(lo, hi-fakeRange)
+	case min == nil && max == nil:
+		lo, hi = math.Max(lo, -fakeRange), math.Min(hi, fakeRange)
+	}
+	return int64(lo), int64(hi)
+}
+
+// floatRange returns the range of the values generated for the given floating
+// point number attribute.
+func floatRange(att *expr.AttributeExpr) (float64, float64) {
+	min, max := bounds(att.Validation, 0)
+	lo, hi := float64(-fakeRange), float64(fakeRange)
+	switch {
+	case min != nil && max != nil:
+		lo, hi = *min, *max
+	case min != nil:
+		lo, hi = *min, *min+fakeRange
+	case max != nil:
+		lo, hi = *max-fakeRange, *max
+	}
+	return lo, hi
+}
+
+// bounds returns the inclusive bounds defined by the validation, nil if there
+// is none. step is added to the exclusive minimum and subtracted from the
+// exclusive maximum, the next representable number is used if step is 0.
+func bounds(v *expr.ValidationExpr, step float64) (min, max *float64) {
+	if v == nil {
+		return nil, nil
+	}
+	min, max = v.Minimum, v.Maximum
+	if v.ExclusiveMinimum != nil {
+		m := *v.ExclusiveMinimum + step
+		if step == 0 {
+			m = math.Nextafter(*v.ExclusiveMinimum, math.Inf(1))
+		}
+		min = &m
+	}
+	if v.ExclusiveMaximum != nil {
+		m := *v.ExclusiveMaximum - step
+		if step == 0 {
+			m = math.Nextafter(*v.ExclusiveMaximum, math.Inf(-1))
+		}
+		max = &m
+	}
+	return min, max
+}
+
+// input: *Data
+const fakeDocT = `// maxDepth is the depth of the generated values after which optional fields
+// are left empty and arrays and maps have their minimum length.
+const maxDepth = 3
+
+// The functions below return random values of the {{ .Name }} service types
+// that satisfy the validations defined in the design. They can be used with
+// the property-based testing libraries, for example with rapid:
+//
+//	rapid.Custom(func(t *rapid.T) *{{ .PkgName }}.Type {
+//		return fake.NewType(rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed"))))
+//	})
+//
+// or with gopter:
+//
+//	func(p *gopter.GenParameters) *gopter.GenResult {
+//		return gopter.NewGenResult(fake.NewType(p.Rng), gopter.NoShrinker)
+//	}
+
+`
+
+// input: *fakeTypeData
+const fakeTypeT = `{{ printf "%s returns a random %s value." .FuncName .Name | comment }}
+func {{ .FuncName }}(r *rand.Rand) {{ .Ref }} {
+	return {{ .InternalName }}(r, 0)
+}
+
+func {{ .InternalName }}(r *rand.Rand, depth int) {{ .Ref }} {
+	{{ .Code }}
+}
+`
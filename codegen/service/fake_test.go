@@ -0,0 +1,39 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestFakeFile(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleEndpointDSL)
+	if f := FakeFile("goa.design/goa/example", expr.Root.Services[0]); f != nil {
+		t.Fatalf("got file %q, expected none", f.Path)
+	}
+
+	codegen.RunDSL(t, testdata.FakeDSL)
+	svc := expr.Root.Services[0]
+	Services = make(ServicesData)
+	Files("goa.design/goa/example", svc, make(map[string][]string))
+	f := FakeFile("goa.design/goa/example", svc)
+	if f == nil {
+		t.Fatal("got no file")
+	}
+	if f.Path != "gen/service_fake/fake/fake.go" {
+		t.Errorf("got path %q, expected %q", f.Path, "gen/service_fake/fake/fake.go")
+	}
+	var buf bytes.Buffer
+	for _, s := range f.SectionTemplates[1:] {
+		if err := s.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+	if code != testdata.FakeCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.FakeCode))
+	}
+}
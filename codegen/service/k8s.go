@@ -0,0 +1,564 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// CRDData describes a Kubernetes custom resource mapped from a method
+	// payload type that defines the "k8s:crd" meta.
+	CRDData struct {
+		// Kind is the custom resource kind.
+		Kind string
+		// Group is the custom resource API group.
+		Group string
+		// Version is the custom resource API version.
+		Version string
+		// Plural is the plural name of the custom resource.
+		Plural string
+		// Description is the type description.
+		Description string
+		// MethodName is the name of the service client method called by
+		// the reconciler.
+		MethodName string
+		// PayloadRef is the reference to the method payload type.
+		PayloadRef string
+		// HasResult is true if the method returns a result.
+		HasResult bool
+		// Fields lists the spec fields.
+		Fields []*CRDFieldData
+		// Schema is the OpenAPI v3 schema of the spec.
+		Schema map[string]interface{}
+	}
+
+	// CRDFieldData describes a field of a custom resource spec.
+	CRDFieldData struct {
+		// Name is the field name.
+		Name string
+		// JSONName is the name of the field in the custom resource.
+		JSONName string
+		// TypeRef is the Go type of the field.
+		TypeRef string
+		// Pointer is true if the field is a pointer.
+		Pointer bool
+		// Slice is true if the field is a slice.
+		Slice bool
+		// ElemRef is the Go type of the slice elements if Slice is true.
+		ElemRef string
+		// Convert is the statement that sets the payload field from the
+		// spec field.
+		Convert string
+	}
+
+	// crdManifest is the Kubernetes CustomResourceDefinition manifest.
+	crdManifest struct {
+		APIVersion string          `yaml:"apiVersion"`
+		Kind       string          `yaml:"kind"`
+		Metadata   crdMetadata     `yaml:"metadata"`
+		Spec       crdManifestSpec `yaml:"spec"`
+	}
+
+	// crdMetadata is the metadata of a CustomResourceDefinition manifest.
+	crdMetadata struct {
+		Name string `yaml:"name"`
+	}
+
+	// crdManifestSpec is the spec of a CustomResourceDefinition manifest.
+	crdManifestSpec struct {
+		Group    string               `yaml:"group"`
+		Names    map[string]string    `yaml:"names"`
+		Scope    string               `yaml:"scope"`
+		Versions []crdManifestVersion `yaml:"versions"`
+	}
+
+	// crdManifestVersion is a version of a CustomResourceDefinition manifest.
+	crdManifestVersion struct {
+		Name         string                 `yaml:"name"`
+		Served       bool                   `yaml:"served"`
+		Storage      bool                   `yaml:"storage"`
+		Schema       map[string]interface{} `yaml:"schema"`
+		Subresources map[string]interface{} `yaml:"subresources"`
+	}
+)
+
+// crdFormats lists the string formats supported by the Kubernetes API server
+// in custom resource schemas.
+var crdFormats = map[expr.ValidationFormat]bool{
+	expr.FormatDate:     true,
+	expr.FormatDateTime: true,
+	expr.FormatUUID:     true,
+	expr.FormatEmail:    true,
+	expr.FormatHostname: true,
+	expr.FormatIPv4:     true,
+	expr.FormatIPv6:     true,
+	expr.FormatURI:      true,
+	expr.FormatMAC:      true,
+	expr.FormatCIDR:     true,
+}
+
+// K8sFiles returns the Kubernetes custom resource definitions and the
+// controller-runtime types and reconcilers generated for the method payload
+// types of the service that define the "k8s:crd" meta, nil if there are none.
+// The reconcilers call the method using the service client.
+func K8sFiles(genpkg string, service *expr.ServiceExpr) []*codegen.File {
+	svc := Services.Get(service.Name)
+	var crds []*CRDData
+	seen := make(map[string]bool)
+	for _, m := range service.Methods {
+		ut, ok := m.Payload.Type.(expr.UserType)
+		if !ok || seen[ut.ID()] {
+			continue
+		}
+		if _, ok := ut.Attribute().Meta["k8s:crd"]; !ok {
+			continue
+		}
+		seen[ut.ID()] = true
+		crds = append(crds, buildCRDData(m, ut, svc))
+	}
+	if len(crds) == 0 {
+		return nil
+	}
+	dir := filepath.Join(codegen.Gendir, svc.PathName, "k8s")
+	var files []*codegen.File
+	for _, crd := range crds {
+		files = append(files, &codegen.File{
+			Path: filepath.Join(dir, "crds", crd.Plural+"."+crd.Group+".yaml"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "crd-manifest",
+				Source:  "{{ crdManifest . }}",
+				Data:    crd,
+				FuncMap: map[string]interface{}{"crdManifest": crdManifestYAML},
+			}},
+		})
+	}
+	types := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" Kubernetes custom resources", "k8s", []*codegen.ImportSpec{
+			{Path: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "metav1"},
+			{Path: "k8s.io/apimachinery/pkg/runtime"},
+			{Path: "k8s.io/apimachinery/pkg/runtime/schema"},
+		}),
+		{Name: "crd-scheme", Source: crdSchemeT, Data: crds},
+	}
+	reconcilers := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" Kubernetes reconcilers", "k8s", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: genpkg + "/" + svc.PathName, Name: svc.PkgName},
+			{Path: "sigs.k8s.io/controller-runtime", Name: "ctrl"},
+			{Path: "sigs.k8s.io/controller-runtime/pkg/client"},
+		}),
+	}
+	for _, crd := range crds {
+		types = append(types, &codegen.SectionTemplate{Name: "crd-types", Source: crdTypesT, Data: crd})
+		reconcilers = append(reconcilers, &codegen.SectionTemplate{
+			Name:   "crd-reconciler",
+			Source: crdReconcilerT,
+			Data:   map[string]interface{}{"CRD": crd, "Service": svc},
+		})
+	}
+	return append(files,
+		&codegen.File{Path: filepath.Join(dir, "types.go"), SectionTemplates: types},
+		&codegen.File{Path: filepath.Join(dir, "reconcilers.go"), SectionTemplates: reconcilers},
+	)
+}
+
+// buildCRDData computes the custom resource data for the given payload type
+// of method m.
+func buildCRDData(m *expr.MethodExpr, ut expr.UserType, svc *Data) *CRDData {
+	att := ut.Attribute()
+	kind := codegen.Goify(ut.Name(), true)
+	group, _ := att.Meta.Last("k8s:crd")
+	version, ok := att.Meta.Last("k8s:crd:version")
+	if !ok {
+		version = "v1alpha1"
+	}
+	plural, ok := att.Meta.Last("k8s:crd:plural")
+	if !ok {
+		plural = strings.ToLower(kind) + "s"
+	}
+	md := svc.Method(m.Name)
+	crd := &CRDData{
+		Kind:        kind,
+		Group:       group,
+		Version:     version,
+		Plural:      plural,
+		Description: att.Description,
+		MethodName:  md.VarName,
+		PayloadRef:  svc.Scope.GoFullTypeName(m.Payload, svc.PkgName),
+		HasResult:   m.Result.Type != expr.Empty,
+	}
+	var (
+		props    = make(map[string]interface{})
+		required []string
+	)
+	for _, nat := range *expr.AsObject(ut) {
+		f, schema := crdField(att, nat, svc)
+		if f == nil {
+			continue
+		}
+		crd.Fields = append(crd.Fields, f)
+		props[nat.Name] = schema
+		if att.IsRequired(nat.Name) {
+			required = append(required, nat.Name)
+		}
+	}
+	crd.Schema = map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		crd.Schema["required"] = required
+	}
+	if crd.Description != "" {
+		crd.Schema["description"] = crd.Description
+	}
+	return crd
+}
+
+// crdField returns the spec field and schema that correspond to the given
+// attribute of the parent payload type, nil if the attribute is not a
+// primitive or an array of primitives.
+func crdField(parent *expr.AttributeExpr, nat *expr.NamedAttributeExpr, svc *Data) (*CRDFieldData, map[string]interface{}) {
+	att := nat.Attribute
+	for k := range att.Meta {
+		if strings.HasPrefix(k, "security:") {
+			return nil, nil
+		}
+	}
+	f := &CRDFieldData{
+		Name:     codegen.GoifyAtt(att, nat.Name, true),
+		JSONName: nat.Name,
+	}
+	ref := svc.Scope.GoFullTypeRef(att, svc.PkgName)
+	switch {
+	case expr.IsPrimitive(att.Type) && crdType(att.Type) != "":
+		f.TypeRef = codegen.GoNativeTypeName(att.Type)
+		f.Pointer = parent.IsPrimitivePointer(nat.Name, true)
+		switch {
+		case ref == f.TypeRef:
+			f.Convert = fmt.Sprintf("p.%s = obj.Spec.%s", f.Name, f.Name)
+		case f.Pointer:
+			f.Convert = fmt.Sprintf("if obj.Spec.%s != nil {\n\tv := %s(*obj.Spec.%s)\n\tp.%s = &v\n}", f.Name, ref[1:], f.Name, f.Name)
+		default:
+			f.Convert = fmt.Sprintf("p.%s = %s(obj.Spec.%s)", f.Name, ref, f.Name)
+		}
+	case expr.IsArray(att.Type):
+		elem := expr.AsArray(att.Type).ElemType
+		if !expr.IsPrimitive(elem.Type) || crdType(elem.Type) == "" {
+			return nil, nil
+		}
+		f.Slice = true
+		f.ElemRef = codegen.GoNativeTypeName(elem.Type)
+		f.TypeRef = "[]" + f.ElemRef
+		if ref != f.TypeRef {
+			return nil, nil
+		}
+		f.Convert = fmt.Sprintf("p.%s = obj.Spec.%s", f.Name, f.Name)
+	default:
+		return nil, nil
+	}
+	return f, crdSchema(att)
+}
+
+// crdType returns the OpenAPI type of the given primitive type, "" if the type
+// cannot be used in a custom resource.
+func crdType(dt expr.DataType) string {
+	switch dt.Kind() {
+	case expr.BooleanKind:
+		return "boolean"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return "integer"
+	case expr.Float32Kind, expr.Float64Kind:
+		return "number"
+	case expr.StringKind:
+		return "string"
+	}
+	return ""
+}
+
+// crdSchema returns the OpenAPI v3 schema of the given attribute derived from
+// its type and validations.
+func crdSchema(att *expr.AttributeExpr) map[string]interface{} {
+	s := make(map[string]interface{})
+	if arr := expr.AsArray(att.Type); arr != nil {
+		s["type"] = "array"
+		s["items"] = crdSchema(arr.ElemType)
+	} else {
+		s["type"] = crdType(att.Type)
+		switch att.Type.Kind() {
+		case expr.Int32Kind, expr.UInt32Kind:
+			s["format"] = "int32"
+		case expr.Int64Kind, expr.UInt64Kind:
+			s["format"] = "int64"
+		}
+	}
+	if att.Description != "" {
+		s["description"] = att.Description
+	}
+	if att.DefaultValue != nil {
+		s["default"] = att.DefaultValue
+	}
+	v := att.Validation
+	if v == nil {
+		return s
+	}
+	if len(v.Values) > 0 {
+		s["enum"] = v.Values
+	}
+	if crdFormats[v.Format] {
+		s["format"] = string(v.Format)
+	}
+	if v.Pattern != "" {
+		s["pattern"] = v.Pattern
+	}
+	if v.Minimum != nil {
+		s["minimum"] = *v.Minimum
+	}
+	if v.ExclusiveMinimum != nil {
+		s["minimum"] = *v.ExclusiveMinimum
+		s["exclusiveMinimum"] = true
+	}
+	if v.Maximum != nil {
+		s["maximum"] = *v.Maximum
+	}
+	if v.ExclusiveMaximum != nil {
+		s["maximum"] = *v.ExclusiveMaximum
+		s["exclusiveMaximum"] = true
+	}
+	minKey, maxKey := "minLength", "maxLength"
+	if expr.IsArray(att.Type) {
+		minKey, maxKey = "minItems", "maxItems"
+	}
+	if v.MinLength != nil {
+		s[minKey] = *v.MinLength
+	}
+	if v.MaxLength != nil {
+		s[maxKey] = *v.MaxLength
+	}
+	if v.UniqueItems {
+		s["uniqueItems"] = true
+	}
+	return s
+}
+
+// crdManifestYAML renders the CustomResourceDefinition manifest of the given
+// custom resource.
+func crdManifestYAML(crd *CRDData) (string, error) {
+	status := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"observedGeneration": map[string]interface{}{"type": "integer", "format": "int64"},
+			"error":              map[string]interface{}{"type": "string"},
+		},
+	}
+	m := crdManifest{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+		Metadata:   crdMetadata{Name: crd.Plural + "." + crd.Group},
+		Spec: crdManifestSpec{
+			Group: crd.Group,
+			Names: map[string]string{
+				"kind":     crd.Kind,
+				"listKind": crd.Kind + "List",
+				"plural":   crd.Plural,
+				"singular": strings.ToLower(crd.Kind),
+			},
+			Scope: "Namespaced",
+			Versions: []crdManifestVersion{{
+				Name:    crd.Version,
+				Served:  true,
+				Storage: true,
+				Schema: map[string]interface{}{
+					"openAPIV3Schema": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"apiVersion": map[string]interface{}{"type": "string"},
+							"kind":       map[string]interface{}{"type": "string"},
+							"metadata":   map[string]interface{}{"type": "object"},
+							"spec":       crd.Schema,
+							"status":     status,
+						},
+					},
+				},
+				Subresources: map[string]interface{}{"status": map[string]interface{}{}},
+			}},
+		},
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// input: []*CRDData
+const crdSchemeT = `var (
+{{- range . }}
+	// {{ .Kind }}GroupVersion is the API group and version of the {{ .Kind }}
+	// custom resource.
+	{{ .Kind }}GroupVersion = schema.GroupVersion{Group: {{ printf "%q" .Group }}, Version: {{ printf "%q" .Version }}}
+{{- end }}
+
+	// SchemeBuilder registers the custom resource types with a scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+	// AddToScheme adds the custom resource types to a scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// addKnownTypes adds the custom resource types to the scheme.
+func addKnownTypes(s *runtime.Scheme) error {
+{{- range . }}
+	s.AddKnownTypes({{ .Kind }}GroupVersion, &{{ .Kind }}{}, &{{ .Kind }}List{})
+	metav1.AddToGroupVersion(s, {{ .Kind }}GroupVersion)
+{{- end }}
+	return nil
+}
+`
+
+// input: *CRDData
+const crdTypesT = `{{ printf "%s is the %s custom resource." .Kind .Kind | comment }}
+type {{ .Kind }} struct {
+	metav1.TypeMeta   ` + "`" + `json:",inline"` + "`" + `
+	metav1.ObjectMeta ` + "`" + `json:"metadata,omitempty"` + "`" + `
+
+	Spec   {{ .Kind }}Spec   ` + "`" + `json:"spec,omitempty"` + "`" + `
+	Status {{ .Kind }}Status ` + "`" + `json:"status,omitempty"` + "`" + `
+}
+
+{{ if .Description }}{{ printf "%sSpec is the desired state of a %s. %s" .Kind .Kind .Description | comment }}{{ else }}{{ printf "%sSpec is the desired state of a %s." .Kind .Kind | comment }}{{ end }}
+type {{ .Kind }}Spec struct {
+{{- range .Fields }}
+	{{ .Name }} {{ if .Pointer }}*{{ end }}{{ .TypeRef }} ` + "`" + `json:"{{ .JSONName }}{{ if or .Pointer .Slice }},omitempty{{ end }}"` + "`" + `
+{{- end }}
+}
+
+{{ printf "%sStatus is the observed state of a %s." .Kind .Kind | comment }}
+type {{ .Kind }}Status struct {
+	// ObservedGeneration is the last generation successfully reconciled.
+	ObservedGeneration int64 ` + "`" + `json:"observedGeneration,omitempty"` + "`" + `
+	// Error is the error returned by the last reconciliation if any.
+	Error string ` + "`" + `json:"error,omitempty"` + "`" + `
+}
+
+{{ printf "%sList is a list of %s custom resources." .Kind .Kind | comment }}
+type {{ .Kind }}List struct {
+	metav1.TypeMeta ` + "`" + `json:",inline"` + "`" + `
+	metav1.ListMeta ` + "`" + `json:"metadata,omitempty"` + "`" + `
+
+	Items []{{ .Kind }} ` + "`" + `json:"items"` + "`" + `
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *{{ .Kind }}) DeepCopyInto(out *{{ .Kind }}) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *{{ .Kind }}) DeepCopy() *{{ .Kind }} {
+	if in == nil {
+		return nil
+	}
+	out := new({{ .Kind }})
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the receiver as a runtime.Object.
+func (in *{{ .Kind }}) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *{{ .Kind }}Spec) DeepCopyInto(out *{{ .Kind }}Spec) {
+	*out = *in
+{{- range .Fields }}
+	{{- if .Pointer }}
+	if in.{{ .Name }} != nil {
+		v := *in.{{ .Name }}
+		out.{{ .Name }} = &v
+	}
+	{{- else if .Slice }}
+	if in.{{ .Name }} != nil {
+		out.{{ .Name }} = make({{ .TypeRef }}, len(in.{{ .Name }}))
+		copy(out.{{ .Name }}, in.{{ .Name }})
+	}
+	{{- end }}
+{{- end }}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *{{ .Kind }}List) DeepCopyInto(out *{{ .Kind }}List) {
+	*out = *in
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]{{ .Kind }}, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *{{ .Kind }}List) DeepCopy() *{{ .Kind }}List {
+	if in == nil {
+		return nil
+	}
+	out := new({{ .Kind }}List)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the receiver as a runtime.Object.
+func (in *{{ .Kind }}List) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+`
+
+// input: map[string]interface{}{"CRD": *CRDData, "Service": *Data}
+const crdReconcilerT = `{{ with .CRD }}{{ printf "%sReconciler reconciles %s custom resources by calling the %s method of the %s service with the resource spec." .Kind .Kind .MethodName $.Service.Name | comment }}
+type {{ .Kind }}Reconciler struct {
+	client.Client
+	// Service is the {{ $.Service.Name }} service client.
+	Service *{{ $.Service.PkgName }}.Client
+}
+
+// Reconcile calls the service method with the spec of the custom resource
+// identified by req and records the outcome in the resource status.
+func (r *{{ .Kind }}Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var obj {{ .Kind }}
+	if err := r.Get(ctx, req.NamespacedName, &obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !obj.DeletionTimestamp.IsZero() || obj.Status.ObservedGeneration == obj.Generation {
+		return ctrl.Result{}, nil
+	}
+	p := &{{ .PayloadRef }}{}
+{{- range .Fields }}
+	{{ .Convert }}
+{{- end }}
+	if {{ if .HasResult }}_, {{ end }}err := r.Service.{{ .MethodName }}(ctx, p); err != nil {
+		obj.Status.Error = err.Error()
+		if uerr := r.Status().Update(ctx, &obj); uerr != nil {
+			return ctrl.Result{}, uerr
+		}
+		return ctrl.Result{}, err
+	}
+	obj.Status.Error = ""
+	obj.Status.ObservedGeneration = obj.Generation
+	return ctrl.Result{}, r.Status().Update(ctx, &obj)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *{{ .Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).For(&{{ .Kind }}{}).Complete(r)
+}
+{{ end }}`
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/service/testdata"
+	"goa.design/goa/v3/expr"
+)
+
+func TestK8sFiles(t *testing.T) {
+	codegen.RunDSL(t, testdata.SingleEndpointDSL)
+	if fs := K8sFiles("goa.design/goa/example", expr.Root.Services[0]); fs != nil {
+		t.Fatalf("got %d files, expected none", len(fs))
+	}
+
+	codegen.RunDSL(t, testdata.CRDDSL)
+	svc := expr.Root.Services[0]
+	Services = make(ServicesData)
+	Files("goa.design/goa/example", svc, make(map[string][]string))
+	fs := K8sFiles("goa.design/goa/example", svc)
+	if len(fs) != 3 {
+		t.Fatalf("got %d files, expected 3", len(fs))
+	}
+	cases := []struct {
+		Path string
+		Code string
+	}{
+		{"gen/deployer/k8s/crds/widgets.example.com.yaml", testdata.CRDManifestCode},
+		{"gen/deployer/k8s/types.go", testdata.CRDTypesCode},
+		{"gen/deployer/k8s/reconcilers.go", testdata.CRDReconcilerCode},
+	}
+	for i, c := range cases {
+		f := fs[i]
+		if f.Path != c.Path {
+			t.Errorf("got path %q, expected %q", f.Path, c.Path)
+		}
+		var buf bytes.Buffer
+		sections := f.SectionTemplates
+		if i > 0 {
+			sections = sections[1:]
+		}
+		for _, s := range sections {
+			if err := s.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		code := buf.String()
+		if i > 0 {
+			code = codegen.FormatTestCode(t, "package foo\n"+code)
+		}
+		if code != c.Code {
+			t.Errorf("%s: invalid code, got:\n%s\ngot vs. expected:\n%s", c.Path, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// SecurityConfigFieldData describes a field of the generated security
+	// configuration struct.
+	SecurityConfigFieldData struct {
+		// Name is the field name.
+		Name string
+		// TypeRef is the field type.
+		TypeRef string
+		// Description is the field description.
+		Description string
+		// Env is the name of the environment variable that holds the
+		// field value.
+		Env string
+		// Init is the code that loads the field value.
+		Init string
+	}
+)
+
+// SecurityConfigFile returns the file that defines the SecurityConfig struct
+// holding the secrets used to implement the security schemes of the service
+// methods together with the LoadSecurityConfig function that loads them from
+// environment variables or files. SecurityConfigFile returns nil if the
+// service methods are not secured.
+func SecurityConfigFile(genpkg string, service *expr.ServiceExpr) *codegen.File {
+	svc := Services.Get(service.Name)
+	var fields []*SecurityConfigFieldData
+	seen := make(map[string]bool)
+	for _, m := range service.Methods {
+		reqs := m.Requirements
+		if len(reqs) == 0 {
+			reqs = service.Requirements
+		}
+		if len(reqs) == 0 && expr.Root != nil && expr.Root.API != nil {
+			reqs = expr.Root.API.Requirements
+		}
+		for _, r := range reqs {
+			for _, s := range r.Schemes {
+				if s.Kind == expr.NoKind || seen[s.SchemeName] {
+					continue
+				}
+				seen[s.SchemeName] = true
+				fields = append(fields, securityConfigFields(s)...)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, svc.PathName, "security_config.go")
+	return &codegen.File{
+		Path: path,
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(service.Name+" security configuration", svc.PkgName, []*codegen.ImportSpec{
+				codegen.GoaImport("security"),
+			}),
+			{
+				Name:   "security-config",
+				Source: securityConfigT,
+				Data:   map[string]interface{}{"Service": svc, "Fields": fields},
+			},
+		},
+	}
+}
+
+// securityConfigFields returns the configuration fields that hold the secrets
+// of the given security scheme.
+func securityConfigFields(s *expr.SchemeExpr) []*SecurityConfigFieldData {
+	prefix := codegen.Goify(s.SchemeName, true)
+	env := strings.ToUpper(codegen.SnakeCase(codegen.Goify(s.SchemeName, false)))
+	field := func(suffix, typ, desc, loader string) *SecurityConfigFieldData {
+		name := env + "_" + strings.ToUpper(codegen.SnakeCase(suffix))
+		init := fmt.Sprintf("l.%s(%q)", loader, name)
+		if typ == "[]byte" {
+			init = "[]byte(" + init + ")"
+		}
+		return &SecurityConfigFieldData{
+			Name:        prefix + suffix,
+			TypeRef:     typ,
+			Description: fmt.Sprintf("%s %s %q security scheme.", desc, s.Kind.String(), s.SchemeName),
+			Env:         name,
+			Init:        init,
+		}
+	}
+	switch s.Kind {
+	case expr.BasicAuthKind:
+		return []*SecurityConfigFieldData{
+			field("Username", "string", "is the username accepted by the", "Required"),
+			field("Password", "string", "is the password accepted by the", "Required"),
+		}
+	case expr.APIKeyKind:
+		return []*SecurityConfigFieldData{
+			field("Keys", "[]string", "lists the keys accepted by the", "List"),
+		}
+	case expr.JWTKind:
+		return []*SecurityConfigFieldData{
+			field("Key", "[]byte", "is the key used to verify the tokens of the", "Required"),
+		}
+	case expr.OAuth2Kind:
+		return []*SecurityConfigFieldData{
+			field("ClientID", "string", "is the client ID registered with the authorization server of the", "Required"),
+			field("ClientSecret", "string", "is the client secret registered with the authorization server of the", "Required"),
+		}
+	case expr.SignatureKind:
+		return []*SecurityConfigFieldData{
+			field("Secret", "[]byte", "is the shared secret used to verify the request signatures of the", "Required"),
+		}
+	case expr.MutualTLSKind:
+		return []*SecurityConfigFieldData{
+			field("ClientCA", "[]byte", "is the PEM encoded CA certificate used to verify the client certificates of the", "Required"),
+		}
+	}
+	return nil
+}
+
+// input: map[string]interface{}{"Service": *Data, "Fields": []*SecurityConfigFieldData}
+const securityConfigT = `{{ printf "SecurityConfig holds the secrets used to implement the security schemes of the %s service." .Service.Name | comment }}
+type SecurityConfig struct {
+{{- range .Fields }}
+	{{ printf "%s %s" .Name .Description | comment }}
+	{{ .Name }} {{ .TypeRef }}
+{{- end }}
+}
+
+// LoadSecurityConfig loads the security configuration from the environment.
+// Each value is read from the corresponding environment variable or, if not
+// set, from the file whose path is given by the environment variable with the
+// _FILE suffix. LoadSecurityConfig returns an error listing all the missing
+// values. The variables are:
+//
+{{- range .Fields }}
+//   - {{ .Env }}
+{{- end }}
+func LoadSecurityConfig() (*SecurityConfig, error) {
+	var l security.SecretLoader
+	c := &SecurityConfig{
+{{- range .Fields }}
+		{{ .Name }}: {{ .Init }},
+{{- end }}
+	}
+	if err := l.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+`
@@ -18,6 +18,7 @@ func TestSecureEndpointInit(t *testing.T) {
 		{"endpoints-with-requirements", testdata.EndpointsWithRequirementsDSL, testdata.EndpointInitWithRequirementsCode},
 		{"endpoints-with-service-requirements", testdata.EndpointsWithServiceRequirementsDSL, testdata.EndpointInitWithServiceRequirementsCode},
 		{"endpoints-no-security", testdata.EndpointNoSecurityDSL, testdata.EndpointInitNoSecurityCode},
+		{"endpoint-with-signature", testdata.EndpointWithSignatureDSL, testdata.EndpointInitWithSignatureCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -97,3 +98,34 @@ func TestSecureWithSkipRequestBodyEncodeDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestSecurityConfigFile(t *testing.T) {
+	codegen.RunDSL(t, testdata.EndpointWithoutRequirementDSL)
+	if f := SecurityConfigFile("", expr.Root.Services[0]); f != nil {
+		t.Fatalf("got file %s, expected nil", f.Path)
+	}
+
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"basic-and-jwt", testdata.EndpointsWithRequirementsDSL, testdata.SecurityConfigBasicJWTCode},
+		{"api-key", testdata.SingleServiceDSL, testdata.SecurityConfigAPIKeyCode},
+		{"oauth2", testdata.EndpointWithOAuth2DSL, testdata.SecurityConfigOAuth2Code},
+		{"signature-and-mtls", testdata.EndpointWithSignatureDSL, testdata.SecurityConfigSignatureCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			f := SecurityConfigFile("", expr.Root.Services[0])
+			if f == nil {
+				t.Fatal("got nil file, expected not nil")
+			}
+			code := codegen.SectionCode(t, f.SectionTemplates[1])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
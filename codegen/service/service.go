@@ -92,6 +92,25 @@ func Files(genpkg string, service *expr.ServiceExpr, userTypePkgs map[string][]s
 		}
 	}
 
+	for _, ut := range svc.userTypes {
+		if len(ut.EnumValues) == 0 {
+			continue
+		}
+		addTypeDefSection(pathWithDefault(ut.Loc, svcPath), "~"+ut.VarName+".IsValid", &codegen.SectionTemplate{
+			Name:   "service-enum-values",
+			Source: enumValuesT,
+			Data:   ut,
+		})
+	}
+
+	for _, e := range svc.EmbedTypes {
+		addTypeDefSection(svcPath, "~"+e.VarName+".As"+e.EmbeddedName, &codegen.SectionTemplate{
+			Name:   "service-embed-methods",
+			Source: embedMethodsT,
+			Data:   e,
+		})
+	}
+
 	for _, m := range svc.unionValueMethods {
 		addTypeDefSection(pathWithDefault(m.Loc, svcPath), "~"+m.TypeRef+"."+m.Name, &codegen.SectionTemplate{
 			Name:   "service-union-value-method",
@@ -317,8 +336,24 @@ type Service interface {
 		{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, io.ReadCloser{{ end }}) ({{ if .Result }}res {{ .ResultRef }}, {{ end }}{{ if .SkipResponseBodyEncodeDecode }}body io.ReadCloser, {{ end }}{{ if .Result }}{{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}view string, {{ end }}{{ end }}{{ end }}err error)
 	{{- end }}
 {{- end }}
+{{- range .Methods }}
+	{{- if .Authorize }}
+	{{ .VarName }}Authorizer
+	{{- end }}
+{{- end }}
 }
 
+{{- range .Methods }}
+	{{- if .Authorize }}
+
+{{ printf "%sAuthorizer defines the authorization hook of the %q method." .VarName .Name | comment }}
+type {{ .VarName }}Authorizer interface {
+	{{ printf "Authorize%s returns an error if the caller is not allowed to make the call. It is called once the payload has been decoded and validated and before %s." .VarName .VarName | comment }}
+	Authorize{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}) error
+}
+	{{- end }}
+{{- end }}
+
 {{- if .Schemes }}
 // Auther defines the authorization functions to be implemented by the service.
 type Auther interface {
@@ -338,6 +373,36 @@ const ServiceName = {{ printf "%q" .Name }}
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
 var MethodNames = [{{ len .Methods }}]string{ {{ range .Methods }}{{ printf "%q" .Name }}, {{ end }} }
+{{- if .HasMethodMeta }}
+
+// MethodMeta lists the metadata defined in the design on the service methods
+// indexed by method name. Middlewares such as middleware.Audit use it to
+// configure their behavior per method.
+var MethodMeta = map[string]map[string][]string{
+{{- range .Methods }}
+	{{- if .Meta }}
+	{{ printf "%q" .Name }}: {
+		{{- range $k, $v := .Meta }}
+		{{ printf "%q" $k }}: { {{- range $v }}{{ printf "%q" . }}, {{ end }} },
+		{{- end }}
+	},
+	{{- end }}
+{{- end }}
+}
+{{- end }}
+{{- if .SensitiveFields }}
+
+// SensitiveFields lists the names of the payload and result attributes marked
+// as sensitive in the design. Logging middlewares such as
+// middleware.LogBodies use it to redact the corresponding values.
+var SensitiveFields = []string{ {{- range .SensitiveFields }}{{ printf "%q" . }}, {{ end }} }
+{{- end }}
+{{- if .DiscoveryTags }}
+
+// DiscoveryTags lists the tags used to register the service with service
+// discovery systems, see the goa discovery package.
+var DiscoveryTags = []string{ {{- range .DiscoveryTags }}{{ printf "%q" . }}, {{ end }} }
+{{- end }}
 {{- range .Methods }}
 	{{- if .ServerStream }}
 		{{ template "stream_interface" (streamInterfaceFor "server" . .ServerStream) }}
@@ -384,6 +449,47 @@ const userTypeT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
 `
 
+const enumValuesT = `// Values of {{ .VarName }}.
+const (
+{{- range .EnumValues }}
+	{{ .Name }} {{ $.VarName }} = {{ .Value }}
+{{- end }}
+)
+
+// IsValid returns true if v is one of the {{ .VarName }} values.
+func (v {{ .VarName }}) IsValid() bool {
+	switch v {
+	case {{ range $i, $v := .EnumValues }}{{ if $i }}, {{ end }}{{ .Name }}{{ end }}:
+		return true
+	}
+	return false
+}
+`
+
+// input: EmbedTypeData
+const embedMethodsT = `{{ printf "As%s returns the values of the %s attributes embedded in %s." .EmbeddedName .EmbeddedName .VarName | comment }}
+func (t *{{ .VarName }}) As{{ .EmbeddedName }}() *{{ .EmbeddedName }} {
+	if t == nil {
+		return nil
+	}
+	v := &{{ .EmbeddedName }}{}
+{{- range .Fields }}
+	{{ .ToCode }}
+{{- end }}
+	return v
+}
+
+{{ printf "Set%s sets the values of the %s attributes embedded in %s." .EmbeddedName .EmbeddedName .VarName | comment }}
+func (t *{{ .VarName }}) Set{{ .EmbeddedName }}(v *{{ .EmbeddedName }}) {
+	if v == nil {
+		return
+	}
+{{- range .Fields }}
+	{{ .FromCode }}
+{{- end }}
+}
+`
+
 const errorT = `// Error returns an error description.
 func (e {{ .Ref }}) Error() string {
 	return {{ printf "%q" .Description }}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -67,6 +68,17 @@ type (
 		// ProtoImports lists the import specifications for the custom
 		// proto types used by the service.
 		ProtoImports []*codegen.ImportSpec
+		// SensitiveFields lists the names of the payload, result and error
+		// attributes marked with the "sensitive" metadata.
+		SensitiveFields []string
+		// DiscoveryTags lists the tags defined with the "discovery:tags"
+		// metadata of the API and the service.
+		DiscoveryTags []string
+		// ComputedTypes lists the service types that define computed
+		// attributes.
+		ComputedTypes []*ComputedTypeData
+		// EmbedTypes lists the service types that embed other types.
+		EmbedTypes []*EmbedTypeData
 
 		// userTypes lists the type definitions that the service depends on.
 		userTypes []*UserTypeData
@@ -198,6 +210,123 @@ type (
 		// result and response body reader when SkipResponseBodyEncodeDecode is
 		// used.
 		ResponseStruct string
+		// Meta is the method metadata defined in the design.
+		Meta expr.MetaExpr
+		// Pagination contains the data needed to render the client page
+		// iterator if the method is paginated.
+		Pagination *PaginationData
+		// Timeout is the maximum duration of the client requests if any.
+		Timeout time.Duration
+		// LongRunning contains the data needed to render the client
+		// helper that waits for the completion of the operation if the
+		// method is long running.
+		LongRunning *LongRunningData
+		// Computed is true if the method result type defines computed
+		// attributes in which case the endpoint calls the result Compute
+		// method.
+		Computed bool
+		// Authorize is true if the endpoint calls the method
+		// authorization hook implemented by the service.
+		Authorize bool
+	}
+
+	// PaginationData is the data used to generate the client iterator that
+	// retrieves the pages of a paginated method.
+	PaginationData struct {
+		// IteratorName is the name of the iterator struct.
+		IteratorName string
+		// IterName is the name of the client method that creates the
+		// iterator.
+		IterName string
+		// PayloadName is the name of the payload struct.
+		PayloadName string
+		// CursorField is the name of the payload cursor field.
+		CursorField string
+		// CursorPointer is true if the payload cursor field is a pointer.
+		CursorPointer bool
+		// NextCursorField is the name of the result next cursor field.
+		NextCursorField string
+		// NextCursorPointer is true if the result next cursor field is a
+		// pointer.
+		NextCursorPointer bool
+		// CursorZero is the zero value of the cursor type.
+		CursorZero string
+		// ItemsField is the name of the result items field.
+		ItemsField string
+		// ItemRef is the reference to the item type.
+		ItemRef string
+	}
+
+	// LongRunningData is the data used to generate the client helper that
+	// polls the status of a long running operation until it completes.
+	LongRunningData struct {
+		// WaitName is the name of the client method that waits for the
+		// operation to complete.
+		WaitName string
+		// StatusVarName is the name of the client method that returns the
+		// status of the operation.
+		StatusVarName string
+		// StatusPayloadName is the name of the status method payload
+		// struct.
+		StatusPayloadName string
+		// IDField is the name of the status ID field.
+		IDField string
+		// DoneField is the name of the status completion field.
+		DoneField string
+		// DonePointer is true if the status completion field is a
+		// pointer.
+		DonePointer bool
+		// Fields lists the names of the payload fields copied to the
+		// status method payload.
+		Fields []string
+	}
+
+	// ComputedTypeData describes a service type that defines computed
+	// attributes.
+	ComputedTypeData struct {
+		// VarName is the Go type name.
+		VarName string
+		// Fields lists the computed attributes.
+		Fields []*ComputedFieldData
+	}
+
+	// ComputedFieldData describes a computed attribute.
+	ComputedFieldData struct {
+		// Name is the attribute name.
+		Name string
+		// FieldName is the name of the corresponding struct field.
+		FieldName string
+		// TypeRef is the reference to the field type.
+		TypeRef string
+		// HookName is the name of the variable holding the function that
+		// computes the attribute.
+		HookName string
+		// From lists the names of the attributes the attribute is derived
+		// from.
+		From []string
+	}
+
+	// EmbedTypeData describes a service type that embeds another type, see
+	// dsl.Embed.
+	EmbedTypeData struct {
+		// VarName is the Go type name of the embedding type.
+		VarName string
+		// EmbeddedName is the Go type name of the embedded type.
+		EmbeddedName string
+		// Fields lists the fields corresponding to the embedded attributes.
+		Fields []*EmbedFieldData
+	}
+
+	// EmbedFieldData describes a field corresponding to an embedded attribute.
+	EmbedFieldData struct {
+		// FieldName is the name of the struct field.
+		FieldName string
+		// ToCode is the code that copies the field of the embedding type
+		// value t to the embedded type value v.
+		ToCode string
+		// FromCode is the code that copies the field of the embedded type
+		// value v to the embedding type value t.
+		FromCode string
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -262,6 +391,17 @@ type (
 		Loc *codegen.Location
 		// Type is the underlying type.
 		Type expr.UserType
+		// EnumValues lists the constants defined for the type if it was
+		// declared with the "enum:type" meta.
+		EnumValues []*EnumValueData
+	}
+
+	// EnumValueData describes a constant defined for an enum value.
+	EnumValueData struct {
+		// Name is the constant name.
+		Name string
+		// Value is the Go literal of the enum value.
+		Value string
 	}
 
 	// SchemeData describes a single security scheme.
@@ -485,6 +625,17 @@ func (d *Data) initUserTypeImports(genpkg string) {
 	d.UserTypeImports = imports
 }
 
+// HasMethodMeta returns true if at least one of the service methods defines
+// metadata in the design.
+func (d *Data) HasMethodMeta() bool {
+	for _, m := range d.Methods {
+		if len(m.Meta) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // Scheme returns the scheme data with the given scheme name.
 func (r RequirementsData) Scheme(name string) *SchemeData {
 	for _, req := range r {
@@ -651,6 +802,39 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		types = append(types, collectTypes(att, scope, seen)...)
 	}
 
+	// Add embedded types
+	embedding := func(att *expr.AttributeExpr) []expr.UserType {
+		ut, ok := att.Type.(expr.UserType)
+		if !ok {
+			return nil
+		}
+		var res []expr.UserType
+		for _, n := range ut.Attribute().Meta["type:embed"] {
+			if et := expr.Root.UserType(n); et != nil {
+				res = append(res, et)
+			}
+		}
+		return res
+	}
+	{
+		embedders := make([]*expr.AttributeExpr, 0, len(types))
+		for _, m := range service.Methods {
+			embedders = append(embedders, m.Payload, m.StreamingPayload, m.Result)
+		}
+		for i := 0; i < len(types); i++ {
+			embedders = append(embedders, &expr.AttributeExpr{Type: types[i].Type})
+		}
+		for i := 0; i < len(embedders); i++ {
+			for _, et := range embedding(embedders[i]) {
+				ets := collectTypes(&expr.AttributeExpr{Type: et}, scope, seen)
+				types = append(types, ets...)
+				for _, t := range ets {
+					embedders = append(embedders, &expr.AttributeExpr{Type: t.Type})
+				}
+			}
+		}
+	}
+
 	var (
 		methods []*MethodData
 		schemes SchemesData
@@ -691,6 +875,17 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 			m.ViewedResult = vrt
 			seenViewed[vrt.Name+"::"+view] = vrt
 		}
+		for i, e := range service.Methods {
+			if e.LongRunning == nil {
+				continue
+			}
+			for j, sm := range service.Methods {
+				if sm == e.LongRunning.StatusMethod {
+					initLongRunningData(methods[i], e, methods[j])
+					break
+				}
+			}
+		}
 	}
 
 	var (
@@ -717,6 +912,77 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		})
 	}
 
+	var computed []*ComputedTypeData
+	{
+		seen := make(map[string]struct{})
+		add := func(att *expr.AttributeExpr) *ComputedTypeData {
+			ut, ok := att.Type.(expr.UserType)
+			if !ok || codegen.UserTypeLocation(ut) != nil {
+				return nil
+			}
+			name := scope.GoTypeName(att)
+			if _, ok := seen[name]; ok {
+				for _, c := range computed {
+					if c.VarName == name {
+						return c
+					}
+				}
+				return nil
+			}
+			seen[name] = struct{}{}
+			if c := buildComputedTypeData(ut, name, scope); c != nil {
+				computed = append(computed, c)
+				return c
+			}
+			return nil
+		}
+		for i, m := range service.Methods {
+			if !m.IsStreaming() && add(m.Result) != nil && !methods[i].SkipRequestBodyEncodeDecode && !methods[i].SkipResponseBodyEncodeDecode {
+				methods[i].Computed = true
+			}
+		}
+		for _, t := range types {
+			add(&expr.AttributeExpr{Type: t.Type})
+		}
+	}
+
+	var embeds []*EmbedTypeData
+	{
+		seen := make(map[string]struct{})
+		add := func(att *expr.AttributeExpr) {
+			ut, ok := att.Type.(expr.UserType)
+			if !ok || codegen.UserTypeLocation(ut) != nil {
+				return
+			}
+			name := scope.GoTypeName(att)
+			if _, ok := seen[name]; ok {
+				return
+			}
+			seen[name] = struct{}{}
+			embedded := codegen.EmbeddedTypes(ut)
+		types:
+			for _, et := range embedding(att) {
+				if codegen.UserTypeLocation(et) != nil {
+					continue
+				}
+				for _, e := range embedded {
+					if e == et {
+						continue types
+					}
+				}
+				embeds = append(embeds, buildEmbedTypeData(ut, et, name, scope))
+			}
+		}
+		for _, m := range service.Methods {
+			add(m.Payload)
+			add(m.StreamingPayload)
+			add(m.Result)
+		}
+		for _, t := range types {
+			add(&expr.AttributeExpr{Type: t.Type})
+		}
+	}
+
 	var (
 		desc string
 	)
@@ -747,12 +1013,148 @@ func (d ServicesData) analyze(service *expr.ServiceExpr) *Data {
 		viewedUnionMethods: viewedUnionMeths,
 		viewedResultTypes:  viewedRTs,
 		unionValueMethods:  ms,
+		SensitiveFields:    collectSensitiveFields(service),
+		DiscoveryTags:      discoveryTags(service),
+		ComputedTypes:      computed,
+		EmbedTypes:         embeds,
 	}
 	d[service.Name] = data
 
 	return data
 }
 
+// buildComputedTypeData returns the data describing the computed attributes of
+// the user type ut or nil if it does not define any.
+func buildComputedTypeData(ut expr.UserType, name string, scope *codegen.NameScope) *ComputedTypeData {
+	obj := expr.AsObject(ut)
+	if obj == nil {
+		return nil
+	}
+	var fields []*ComputedFieldData
+	for _, nat := range *obj {
+		from, ok := nat.Attribute.ComputedFrom()
+		if !ok {
+			continue
+		}
+		fieldName := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		ref := scope.GoTypeRef(nat.Attribute)
+		if ut.Attribute().IsPrimitivePointer(nat.Name, true) {
+			ref = "*" + ref
+		}
+		fields = append(fields, &ComputedFieldData{
+			Name:      nat.Name,
+			FieldName: fieldName,
+			TypeRef:   ref,
+			HookName:  "Compute" + name + fieldName,
+			From:      from,
+		})
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ComputedTypeData{VarName: name, Fields: fields}
+}
+
+// goTypeDef returns the definition of the Go struct generated for the service
+// user type ut. The struct embeds the types returned by codegen.EmbeddedTypes
+// in place of the corresponding fields.
+func goTypeDef(ut expr.UserType, scope *codegen.NameScope) string {
+	ets := codegen.EmbeddedTypes(ut)
+	if len(ets) == 0 {
+		return scope.GoTypeDef(ut.Attribute(), false, true)
+	}
+	promoted := codegen.EmbeddedFields(ut)
+	var obj expr.Object
+	for _, nat := range *expr.AsObject(ut) {
+		if _, ok := promoted[codegen.GoifyAtt(nat.Attribute, nat.Name, true)]; !ok {
+			obj = append(obj, nat)
+		}
+	}
+	att := ut.Attribute()
+	def := scope.GoTypeDef(&expr.AttributeExpr{Type: &obj, Validation: att.Validation, Meta: att.Meta}, false, true)
+	def = strings.TrimSuffix(def, "}")
+	for _, et := range ets {
+		def += "\t" + scope.GoTypeName(&expr.AttributeExpr{Type: et}) + "\n"
+	}
+	return def + "}"
+}
+
+// buildEmbedTypeData builds the data needed to render the methods that convert
+// between the service type ut named name and the embedded type et.
+func buildEmbedTypeData(ut, et expr.UserType, name string, scope *codegen.NameScope) *EmbedTypeData {
+	var fields []*EmbedFieldData
+	for _, nat := range *expr.AsObject(et) {
+		if expr.AsObject(ut).Attribute(nat.Name) == nil {
+			continue
+		}
+		var (
+			fn   = codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+			tptr = ut.Attribute().IsPrimitivePointer(nat.Name, true)
+			eptr = et.Attribute().IsPrimitivePointer(nat.Name, true)
+			to   = fmt.Sprintf("v.%s = t.%s", fn, fn)
+			from = fmt.Sprintf("t.%s = v.%s", fn, fn)
+		)
+		switch {
+		case tptr && !eptr:
+			to = fmt.Sprintf("if t.%s != nil {\n\tv.%s = *t.%s\n}", fn, fn, fn)
+			from = fmt.Sprintf("t.%s = &v.%s", fn, fn)
+		case !tptr && eptr:
+			to = fmt.Sprintf("v.%s = &t.%s", fn, fn)
+			from = fmt.Sprintf("if v.%s != nil {\n\tt.%s = *v.%s\n}", fn, fn, fn)
+		}
+		fields = append(fields, &EmbedFieldData{FieldName: fn, ToCode: to, FromCode: from})
+	}
+	return &EmbedTypeData{
+		VarName:      name,
+		EmbeddedName: scope.GoTypeName(&expr.AttributeExpr{Type: et}),
+		Fields:       fields,
+	}
+}
+
+// discoveryTags returns the tags defined with the "discovery:tags" metadata of
+// the API followed by the tags defined on the service.
+func discoveryTags(service *expr.ServiceExpr) []string {
+	var tags []string
+	if expr.Root != nil && expr.Root.API != nil {
+		tags = append(tags, expr.Root.API.Meta["discovery:tags"]...)
+	}
+	return append(tags, service.Meta["discovery:tags"]...)
+}
+
+// collectSensitiveFields returns the sorted wire names of the attributes
+// marked with the "sensitive" metadata in the service method payloads, results
+// and errors.
+func collectSensitiveFields(service *expr.ServiceExpr) []string {
+	seen := make(map[string]struct{})
+	walker := func(at *expr.AttributeExpr) error {
+		if o := expr.AsObject(at.Type); o != nil {
+			for _, nat := range *o {
+				if _, ok := nat.Attribute.Meta["sensitive"]; ok {
+					seen[at.WireName(nat.Name)] = struct{}{}
+				}
+			}
+		}
+		return nil
+	}
+	for _, m := range service.Methods {
+		codegen.Walk(m.Payload, walker)          // nolint: errcheck
+		codegen.Walk(m.StreamingPayload, walker) // nolint: errcheck
+		codegen.Walk(m.Result, walker)           // nolint: errcheck
+		for _, e := range m.Errors {
+			codegen.Walk(e.AttributeExpr, walker) // nolint: errcheck
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // typeContext returns a contextual attribute for service types. Service types
 // are Go types and uses non-pointers to hold attributes having default values.
 func typeContext(pkg string, scope *codegen.NameScope) *codegen.AttributeContext {
@@ -778,14 +1180,16 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 		if _, ok := seen[dt.ID()]; ok {
 			return nil
 		}
+		varName := scope.GoTypeName(at)
 		data = append(data, &UserTypeData{
 			Name:        dt.Name(),
-			VarName:     scope.GoTypeName(at),
+			VarName:     varName,
 			Description: dt.Attribute().Description,
-			Def:         scope.GoTypeDef(dt.Attribute(), false, true),
+			Def:         goTypeDef(dt, scope),
 			Ref:         scope.GoTypeRef(at),
 			Loc:         codegen.UserTypeLocation(dt),
 			Type:        dt,
+			EnumValues:  enumValues(dt.Attribute(), varName),
 		})
 		seen[dt.ID()] = struct{}{}
 		data = append(data, collect(dt.Attribute())...)
@@ -806,6 +1210,31 @@ func collectTypes(at *expr.AttributeExpr, scope *codegen.NameScope, seen map[str
 	return
 }
 
+// enumValues returns the constants defined for the values of the enum
+// validation of att if it has the "enum:type" meta, nil otherwise.
+func enumValues(att *expr.AttributeExpr, varName string) []*EnumValueData {
+	if _, ok := att.Meta["enum:type"]; !ok || att.Validation == nil {
+		return nil
+	}
+	names := att.Meta["enum:names"]
+	vals := make([]*EnumValueData, len(att.Validation.Values))
+	for i, v := range att.Validation.Values {
+		name := fmt.Sprintf("%v", v)
+		if i < len(names) {
+			name = names[i]
+		}
+		name = codegen.Goify(name, true)
+		if name == "" {
+			name = "Empty"
+		}
+		vals[i] = &EnumValueData{
+			Name:  varName + name,
+			Value: fmt.Sprintf("%#v", v),
+		}
+	}
+	return vals
+}
+
 // collectUnionMethods traverses the attribute to gather all union value methods.
 func collectUnionMethods(att *expr.AttributeExpr, scope *codegen.NameScope, loc *codegen.Location, seen map[string]struct{}) (data []*UnionValueMethodData) {
 	if att == nil || att.Type == expr.Empty {
@@ -896,7 +1325,7 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 	if m.Payload.Type != expr.Empty {
 		payloadName = scope.GoTypeName(m.Payload)
 		if dt, ok := m.Payload.Type.(expr.UserType); ok {
-			payloadDef = scope.GoTypeDef(dt.Attribute(), false, true)
+			payloadDef = goTypeDef(dt, scope)
 			payloadLoc = codegen.UserTypeLocation(dt)
 		}
 		payloadRef = scope.GoFullTypeRef(m.Payload, payloadLoc.PackageName())
@@ -906,11 +1335,14 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 				payloadName, m.Service.Name, m.Name)
 		}
 		payloadEx = m.Payload.Example(expr.Root.API.Random())
+		if len(m.Examples) > 0 {
+			payloadEx = m.Examples[len(m.Examples)-1].Value
+		}
 	}
 	if m.Result.Type != expr.Empty {
 		rname = scope.GoTypeName(m.Result)
 		if dt, ok := m.Result.Type.(expr.UserType); ok {
-			resultDef = scope.GoTypeDef(dt.Attribute(), false, true)
+			resultDef = goTypeDef(dt, scope)
 			resultLoc = codegen.UserTypeLocation(dt)
 		}
 		resultRef = scope.GoFullTypeRef(m.Result, resultLoc.PackageName())
@@ -931,9 +1363,16 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		var rs SchemesData
 		for _, s := range req.Schemes {
 			sch := BuildSchemeData(s, m)
+			if sch == nil {
+				// scheme enforced by the transport
+				continue
+			}
 			rs = rs.Append(sch)
 			schemes = schemes.Append(sch)
 		}
+		if len(rs) == 0 {
+			continue
+		}
 		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes})
 	}
 	var httpMet *expr.HTTPEndpointExpr
@@ -965,13 +1404,67 @@ func buildMethodData(m *expr.MethodExpr, scope *codegen.NameScope) *MethodData {
 		SkipResponseBodyEncodeDecode: httpMet != nil && httpMet.SkipResponseBodyEncodeDecode,
 		RequestStruct:                vname + "RequestData",
 		ResponseStruct:               vname + "ResponseData",
+		Meta:                         m.Meta,
+		Timeout:                      m.Timeout,
+		Authorize:                    m.Authorize,
 	}
 	if m.IsStreaming() {
 		initStreamData(data, m, vname, rname, resultRef, scope)
 	}
+	if m.Pagination != nil {
+		initPaginationData(data, m, vname, payloadRef, resultLoc, scope)
+	}
 	return data
 }
 
+// initPaginationData initializes the data used to render the client iterator
+// of a paginated method.
+func initPaginationData(data *MethodData, m *expr.MethodExpr, vname, payloadRef string, resultLoc *codegen.Location, scope *codegen.NameScope) {
+	p := m.Pagination
+	cursor := p.CursorAttribute()
+	zero := "0"
+	if cursor.Type.Kind() == expr.StringKind {
+		zero = `""`
+	}
+	items := expr.AsArray(p.ItemsAttribute().Type)
+	data.Pagination = &PaginationData{
+		IteratorName:      scope.Unique(codegen.Goify(m.Name, true), "Iterator"),
+		IterName:          vname + "Iter",
+		PayloadName:       strings.TrimPrefix(payloadRef, "*"),
+		CursorField:       codegen.GoifyAtt(cursor, p.Cursor, true),
+		CursorPointer:     m.Payload.IsPrimitivePointer(p.Cursor, true),
+		NextCursorField:   codegen.GoifyAtt(p.NextCursorAttribute(), p.NextCursor, true),
+		NextCursorPointer: m.Result.IsPrimitivePointer(p.NextCursor, true),
+		CursorZero:        zero,
+		ItemsField:        codegen.GoifyAtt(p.ItemsAttribute(), p.Items, true),
+		ItemRef:           scope.GoFullTypeRef(items.ElemType, resultLoc.PackageName()),
+	}
+}
+
+// initLongRunningData initializes the data used to render the client helper
+// that waits for the completion of the operation started by the long running
+// method m. status is the data of the corresponding status method.
+func initLongRunningData(data *MethodData, m *expr.MethodExpr, status *MethodData) {
+	lr := m.LongRunning
+	obj := expr.AsObject(lr.Status)
+	var fields []string
+	for _, nat := range *expr.AsObject(lr.StatusMethod.Payload.Type) {
+		if nat.Name == "id" {
+			continue
+		}
+		fields = append(fields, codegen.GoifyAtt(nat.Attribute, nat.Name, true))
+	}
+	data.LongRunning = &LongRunningData{
+		WaitName:          data.VarName + "AndWait",
+		StatusVarName:     status.VarName,
+		StatusPayloadName: strings.TrimPrefix(status.PayloadRef, "*"),
+		IDField:           codegen.GoifyAtt(obj.Attribute("id"), "id", true),
+		DoneField:         codegen.GoifyAtt(obj.Attribute("done"), "done", true),
+		DonePointer:       m.Result.IsPrimitivePointer("done", true),
+		Fields:            fields,
+	}
+}
+
 // initStreamData initializes the streaming payload data structures and methods.
 func initStreamData(data *MethodData, m *expr.MethodExpr, vname, rname, resultRef string, scope *codegen.NameScope) {
 	var (
@@ -985,7 +1478,7 @@ func initStreamData(data *MethodData, m *expr.MethodExpr, vname, rname, resultRe
 		spayloadName = scope.GoTypeName(m.StreamingPayload)
 		spayloadRef = scope.GoTypeRef(m.StreamingPayload)
 		if dt, ok := m.StreamingPayload.Type.(expr.UserType); ok {
-			spayloadDef = scope.GoTypeDef(dt.Attribute(), false, true)
+			spayloadDef = goTypeDef(dt, scope)
 		}
 		spayloadDesc = m.StreamingPayload.Description
 		if spayloadDesc == "" {
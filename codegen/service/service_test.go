@@ -24,6 +24,16 @@ func TestService(t *testing.T) {
 		{"union", testdata.UnionMethodDSL, testdata.UnionMethod},
 		{"multi-union", testdata.MultiUnionMethodDSL, testdata.MultiUnionMethod},
 		{"no-payload-no-result", testdata.EmptyMethodDSL, testdata.EmptyMethod},
+		{"method-meta", testdata.MethodMetaDSL, testdata.MethodMeta},
+		{"sensitive-fields", testdata.SensitiveFieldsDSL, testdata.SensitiveFields},
+		{"sensitive-wire-name", testdata.SensitiveWireNameDSL, testdata.SensitiveWireName},
+		{"discovery-tags", testdata.DiscoveryTagsDSL, testdata.DiscoveryTags},
+		{"authorize", testdata.AuthorizeMethodsDSL, testdata.AuthorizeMethods},
+		{"embed", testdata.EmbedMethodDSL, testdata.EmbedMethod},
+		{"embed-result-type", testdata.EmbedResultTypeDSL, testdata.EmbedResultType},
+		{"named-inline-object", testdata.NamedInlineObjectDSL, testdata.NamedInlineObject},
+		{"named-enum", testdata.NamedEnumDSL, testdata.NamedEnum},
+		{"int-enum", testdata.IntEnumDSL, testdata.IntEnum},
 		{"payload-no-result", testdata.EmptyResultMethodDSL, testdata.EmptyResultMethod},
 		{"no-payload-result", testdata.EmptyPayloadMethodDSL, testdata.EmptyPayloadMethod},
 		{"payload-result-with-default", testdata.WithDefaultDSL, testdata.WithDefault},
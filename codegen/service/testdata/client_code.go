@@ -283,3 +283,262 @@ func (c *Client) BidirectionalStreamingNoPayloadMethod(ctx context.Context) (res
 	return ires.(BidirectionalStreamingNoPayloadMethodClientStream), nil
 }
 `
+
+const PaginatedMethodClient = `// Client is the "PaginatedService" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "PaginatedService" service client given the
+// endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// List calls the "List" endpoint of the "PaginatedService" service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListIterator iterates over the items of the pages returned by the "List"
+// endpoint of the "PaginatedService" service.
+type ListIterator struct {
+	client  *Client
+	ctx     context.Context
+	payload ListPayload
+	items   []*Item
+	item    *Item
+	done    bool
+	err     error
+}
+
+// ListIter returns an iterator over the items of the pages returned by the
+// "List" endpoint of the "PaginatedService" service. The iterator retrieves
+// the pages lazily starting with the page identified by the cursor of p.
+func (c *Client) ListIter(ctx context.Context, p *ListPayload) *ListIterator {
+	it := &ListIterator{client: c, ctx: ctx}
+	if p != nil {
+		it.payload = *p
+	}
+	return it
+}
+
+// Next advances the iterator to the next item, retrieving the next page if
+// needed. Next returns false once all the pages have been retrieved or if an
+// error occurred, use Err to tell the two cases apart.
+func (it *ListIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		var res *ListResult
+		res, it.err = it.client.List(it.ctx, &it.payload)
+		if it.err != nil {
+			return false
+		}
+		it.items = res.Items
+		if res.Next == nil || *res.Next == "" {
+			it.done = true
+		} else {
+			it.payload.Cursor = res.Next
+		}
+	}
+	it.item = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *ListIterator) Item() *Item {
+	return it.item
+}
+
+// Err returns the error that caused Next to return false if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+`
+
+const PaginatedRequiredCursorMethodClient = `// Client is the "PaginatedRequiredCursorService" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "PaginatedRequiredCursorService" service client
+// given the endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// List calls the "List" endpoint of the "PaginatedRequiredCursorService"
+// service.
+func (c *Client) List(ctx context.Context, p *ListPayload) (res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListIterator iterates over the items of the pages returned by the "List"
+// endpoint of the "PaginatedRequiredCursorService" service.
+type ListIterator struct {
+	client  *Client
+	ctx     context.Context
+	payload ListPayload
+	items   []string
+	item    string
+	done    bool
+	err     error
+}
+
+// ListIter returns an iterator over the items of the pages returned by the
+// "List" endpoint of the "PaginatedRequiredCursorService" service. The
+// iterator retrieves the pages lazily starting with the page identified by the
+// cursor of p.
+func (c *Client) ListIter(ctx context.Context, p *ListPayload) *ListIterator {
+	it := &ListIterator{client: c, ctx: ctx}
+	if p != nil {
+		it.payload = *p
+	}
+	return it
+}
+
+// Next advances the iterator to the next item, retrieving the next page if
+// needed. Next returns false once all the pages have been retrieved or if an
+// error occurred, use Err to tell the two cases apart.
+func (it *ListIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		var res *ListResult
+		res, it.err = it.client.List(it.ctx, &it.payload)
+		if it.err != nil {
+			return false
+		}
+		it.items = res.Values
+		if res.NextPage == nil || *res.NextPage == 0 {
+			it.done = true
+		} else {
+			it.payload.Page = *res.NextPage
+		}
+	}
+	it.item = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *ListIterator) Item() string {
+	return it.item
+}
+
+// Err returns the error that caused Next to return false if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+`
+
+const TimeoutMethodClient = `// Client is the "TimeoutService" service client.
+type Client struct {
+	ShowEndpoint   goa.Endpoint
+	DeleteEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "TimeoutService" service client given the endpoints.
+func NewClient(show, delete_ goa.Endpoint) *Client {
+	return &Client{
+		ShowEndpoint:   show,
+		DeleteEndpoint: delete_,
+	}
+}
+
+// Show calls the "Show" endpoint of the "TimeoutService" service.
+// Show cancels the request if it does not complete within 1.5s.
+func (c *Client) Show(ctx context.Context, p string) (res string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	var ires interface{}
+	ires, err = c.ShowEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(string), nil
+}
+
+// Delete calls the "Delete" endpoint of the "TimeoutService" service.
+// Delete cancels the request if it does not complete within 1m0s.
+func (c *Client) Delete(ctx context.Context, p string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+	_, err = c.DeleteEndpoint(ctx, p)
+	return
+}
+`
+
+const LongRunningMethodClient = `// Client is the "LongRunningService" service client.
+type Client struct {
+	ExportEndpoint       goa.Endpoint
+	ExportStatusEndpoint goa.Endpoint
+}
+
+// NewClient initializes a "LongRunningService" service client given the
+// endpoints.
+func NewClient(export, exportStatus goa.Endpoint) *Client {
+	return &Client{
+		ExportEndpoint:       export,
+		ExportStatusEndpoint: exportStatus,
+	}
+}
+
+// Export calls the "Export" endpoint of the "LongRunningService" service.
+func (c *Client) Export(ctx context.Context, p *ExportPayload) (res *Operation, err error) {
+	var ires interface{}
+	ires, err = c.ExportEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Operation), nil
+}
+
+// ExportStatus calls the "Export_status" endpoint of the "LongRunningService"
+// service.
+func (c *Client) ExportStatus(ctx context.Context, p *ExportStatusPayload) (res *Operation, err error) {
+	var ires interface{}
+	ires, err = c.ExportStatusEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Operation), nil
+}
+
+// ExportAndWait calls the "Export" endpoint of the "LongRunningService"
+// service and polls the status of the operation it starts every interval until
+// the operation completes. ExportAndWait returns the status of the completed
+// operation.
+func (c *Client) ExportAndWait(ctx context.Context, p *ExportPayload, interval time.Duration) (res *Operation, err error) {
+	res, err = c.Export(ctx, p)
+	for err == nil && (res.Done == nil || !*res.Done) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		res, err = c.ExportStatus(ctx, &ExportStatusPayload{
+			ID: res.ID,
+		})
+	}
+	return
+}
+`
@@ -24,6 +24,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewAEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*AType)
+		ctx, err := goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.A(ctx, p)
 	}
 }
@@ -51,6 +55,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewUseEndpointEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(string)
+		ctx, err := goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.UseEndpoint(ctx, p)
 	}
 }
@@ -83,6 +91,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewBEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*BType)
+		ctx, err := goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.B(ctx, p)
 	}
 }
@@ -92,6 +104,10 @@ func NewBEndpoint(s Service) goa.Endpoint {
 func NewCEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		p := req.(*CType)
+		ctx, err := goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.C(ctx, p)
 	}
 }
@@ -118,6 +134,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 // "NoPayload" of service "NoPayload".
 func NewNoPayloadEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.NoPayload(ctx)
 	}
 }
@@ -144,6 +164,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 // service "WithResult".
 func NewAEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		res, err := s.A(ctx)
 		if err != nil {
 			return nil, err
@@ -180,6 +204,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 // service "WithResultMultipleViews".
 func NewAEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		res, err := s.A(ctx)
 		if err != nil {
 			return nil, err
@@ -193,6 +221,10 @@ func NewAEndpoint(s Service) goa.Endpoint {
 // service "WithResultMultipleViews".
 func NewBEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		res, err := s.B(ctx)
 		if err != nil {
 			return nil, err
@@ -237,6 +269,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewStreamingResultMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*StreamingResultMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, ep.Payload)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.StreamingResultMethod(ctx, ep.Payload, ep.Stream)
 	}
 }
@@ -275,6 +311,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewStreamingResultNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*StreamingResultNoPayloadMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.StreamingResultNoPayloadMethod(ctx, ep.Stream)
 	}
 }
@@ -315,6 +355,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewStreamingResultWithViewsMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*StreamingResultWithViewsMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, ep.Payload)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.StreamingResultWithViewsMethod(ctx, ep.Payload, ep.Stream)
 	}
 }
@@ -354,6 +398,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewStreamingPayloadMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*StreamingPayloadMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, ep.Payload)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.StreamingPayloadMethod(ctx, ep.Payload, ep.Stream)
 	}
 }
@@ -392,6 +440,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewStreamingPayloadNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*StreamingPayloadNoPayloadMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.StreamingPayloadNoPayloadMethod(ctx, ep.Stream)
 	}
 }
@@ -430,6 +482,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewStreamingPayloadNoResultMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*StreamingPayloadNoResultMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.StreamingPayloadNoResultMethod(ctx, ep.Stream)
 	}
 }
@@ -470,6 +526,10 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewBidirectionalStreamingMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*BidirectionalStreamingMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, ep.Payload)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.BidirectionalStreamingMethod(ctx, ep.Payload, ep.Stream)
 	}
 }
@@ -509,7 +569,175 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 func NewBidirectionalStreamingNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 		ep := req.(*BidirectionalStreamingNoPayloadMethodEndpointInput)
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.BidirectionalStreamingNoPayloadMethod(ctx, ep.Stream)
 	}
 }
 `
+
+const ComputedEndpoint = `// Endpoints wraps the "ComputedEndpoint" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ComputedEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Show: NewShowEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "ComputedEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "ComputedEndpoint".
+func NewShowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(string)
+		ctx, err := goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.Show(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		res.Compute()
+		return res, nil
+	}
+}
+`
+
+const ComputedViewedEndpoint = `// Endpoints wraps the "ComputedViewedEndpoint" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "ComputedViewedEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Show: NewShowEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "ComputedViewedEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "ComputedViewedEndpoint".
+func NewShowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := s.Show(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res.Compute()
+		vres := NewViewedUser(res, "default")
+		return vres, nil
+	}
+}
+`
+
+const ComputedCode = `// ComputeUserFullName computes the "full_name" attribute of User from the
+// "first" and "last" attributes. Compute sets the attribute using the function
+// if not nil.
+var ComputeUserFullName func(*User) *string
+
+// Compute sets the computed attributes of User using the corresponding hooks.
+// The generated endpoints call Compute on the method results.
+func (t *User) Compute() {
+	if t == nil {
+		return
+	}
+	if ComputeUserFullName != nil {
+		t.FullName = ComputeUserFullName(t)
+	}
+}
+`
+
+const ComputedViewedCode = `// ComputeUserFullName computes the "full_name" attribute of User from the
+// "first" and "last" attributes. Compute sets the attribute using the function
+// if not nil.
+var ComputeUserFullName func(*User) *string
+
+// Compute sets the computed attributes of User using the corresponding hooks.
+// The generated endpoints call Compute on the method results.
+func (t *User) Compute() {
+	if t == nil {
+		return
+	}
+	if ComputeUserFullName != nil {
+		t.FullName = ComputeUserFullName(t)
+	}
+}
+`
+
+const AuthorizeEndpoint = `// Endpoints wraps the "AuthorizeEndpoint" service endpoints.
+type Endpoints struct {
+	Show goa.Endpoint
+	List goa.Endpoint
+}
+
+// NewEndpoints wraps the methods of the "AuthorizeEndpoint" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Show: NewShowEndpoint(s),
+		List: NewListEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "AuthorizeEndpoint" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Show = m(e.Show)
+	e.List = m(e.List)
+}
+
+// NewShowEndpoint returns an endpoint function that calls the method "Show" of
+// service "AuthorizeEndpoint".
+func NewShowEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*ShowPayload)
+		ctx, err := goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.AuthorizeShow(ctx, p); err != nil {
+			return nil, err
+		}
+		return s.Show(ctx, p)
+	}
+}
+
+// NewListEndpoint returns an endpoint function that calls the method "List" of
+// service "AuthorizeEndpoint".
+func NewListEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctx, err := goa.RunAuthHooks(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.AuthorizeList(ctx); err != nil {
+			return nil, err
+		}
+		return nil, s.List(ctx)
+	}
+}
+`
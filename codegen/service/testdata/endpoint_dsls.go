@@ -181,3 +181,46 @@ var BidirectionalStreamingEndpointDSL = func() {
 		})
 	})
 }
+
+var ComputedEndpointDSL = func() {
+	var User = Type("User", func() {
+		Attribute("first", String)
+		Attribute("last", String)
+		Computed("full_name", String, FromAttributes("first", "last"))
+		Required("first", "last")
+	})
+	Service("ComputedEndpoint", func() {
+		Method("Show", func() {
+			Payload(String)
+			Result(User)
+		})
+	})
+}
+
+var ComputedViewedEndpointDSL = func() {
+	var User = ResultType("application/vnd.user", func() {
+		Attribute("first", String)
+		Attribute("last", String)
+		Computed("full_name", String, FromAttributes("first", "last"))
+	})
+	Service("ComputedViewedEndpoint", func() {
+		Method("Show", func() {
+			Result(User)
+		})
+	})
+}
+
+var AuthorizeEndpointDSL = func() {
+	Service("AuthorizeEndpoint", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(String)
+			Authorize()
+		})
+		Method("List", func() {
+			Authorize()
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package testdata
+
+const FakeCode = `// maxDepth is the depth of the generated values after which optional fields
+// are left empty and arrays and maps have their minimum length.
+const maxDepth = 3
+
+// The functions below return random values of the ServiceFake service types
+// that satisfy the validations defined in the design. They can be used with
+// the property-based testing libraries, for example with rapid:
+//
+//	rapid.Custom(func(t *rapid.T) *servicefake.Type {
+//		return fake.NewType(rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed"))))
+//	})
+//
+// or with gopter:
+//
+//	func(p *gopter.GenParameters) *gopter.GenResult {
+//		return gopter.NewGenResult(fake.NewType(p.Rng), gopter.NoShrinker)
+//	}
+
+// NewItem returns a random Item value.
+func NewItem(r *rand.Rand) *servicefake.Item {
+	return newItem(r, 0)
+}
+
+func newItem(r *rand.Rand, depth int) *servicefake.Item {
+	v := &servicefake.Item{}
+	v.ID = goafake.Format(r, "uuid")
+	v.Name = goafake.String(r, 3, 20)
+	if goafake.Bool(r) {
+		x := int32(goafake.Int(r, 1, 99))
+		v.Count = &x
+	}
+	if goafake.Bool(r) {
+		x := float64(goafake.Float(r, 0, 1000))
+		v.Price = &x
+	}
+	v.Status = []string{"active", "archived"}[r.Intn(2)]
+	if depth < maxDepth && goafake.Bool(r) {
+		v.Tags = func() []servicefake.Tag {
+			n := goafake.Len(r, 0, 5)
+			if depth >= maxDepth {
+				n = 0
+			}
+			s := make([]servicefake.Tag, n)
+			for i := range s {
+				s[i] = newTag(r, depth+1)
+			}
+			return s
+		}()
+	}
+	if depth < maxDepth && goafake.Bool(r) {
+		v.Labels = func() map[string]int {
+			n := goafake.Len(r, 0, 3)
+			if depth >= maxDepth {
+				n = 0
+			}
+			m := make(map[string]int, n)
+			for i := 0; i < n; i++ {
+				m[goafake.String(r, 0, 10)] = int(goafake.Int(r, -1000, 1000))
+			}
+			return m
+		}()
+	}
+	if depth < maxDepth && goafake.Bool(r) {
+		v.Parent = newItem(r, depth+1)
+	}
+	return v
+}
+
+// NewTag returns a random Tag value.
+func NewTag(r *rand.Rand) servicefake.Tag {
+	return newTag(r, 0)
+}
+
+func newTag(r *rand.Rand, depth int) servicefake.Tag {
+	return servicefake.Tag(goafake.Pattern(r, "^[a-z]+$", 0, 10))
+}
+`
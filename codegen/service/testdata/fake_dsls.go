@@ -0,0 +1,43 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var FakeDSL = func() {
+	var Tag = Type("Tag", String, func() {
+		Pattern("^[a-z]+$")
+	})
+	var Item = Type("Item", func() {
+		Attribute("id", String, func() {
+			Format(FormatUUID)
+		})
+		Attribute("name", String, func() {
+			MinLength(3)
+			MaxLength(20)
+		})
+		Attribute("count", Int32, func() {
+			Minimum(1)
+			ExclusiveMaximum(100)
+		})
+		Attribute("price", Float64, func() {
+			Minimum(0)
+		})
+		Attribute("status", String, func() {
+			Enum("active", "archived")
+			Default("active")
+		})
+		Attribute("tags", ArrayOf(Tag), func() {
+			MaxLength(5)
+		})
+		Attribute("labels", MapOf(String, Int))
+		Attribute("parent", "Item")
+		Required("id", "name")
+	})
+	Service("ServiceFake", func() {
+		Meta("fake:generate")
+		Method("A", func() {
+			Payload(Item)
+		})
+	})
+}
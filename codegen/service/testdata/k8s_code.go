@@ -0,0 +1,231 @@
+package testdata
+
+const CRDManifestCode = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    listKind: WidgetList
+    plural: widgets
+    singular: widget
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          properties:
+            apiVersion:
+              type: string
+            kind:
+              type: string
+            metadata:
+              type: object
+            spec:
+              description: Widget describes a widget to deploy.
+              properties:
+                mode:
+                  enum:
+                    - fast
+                    - safe
+                  type: string
+                name:
+                  description: Name of widget
+                  maxLength: 63
+                  pattern: ^[a-z]+$
+                  type: string
+                owner:
+                  format: email
+                  type: string
+                replicas:
+                  default: 1
+                  format: int32
+                  minimum: 1
+                  type: integer
+                tags:
+                  items:
+                    type: string
+                  maxItems: 10
+                  type: array
+              required:
+                - name
+              type: object
+            status:
+              properties:
+                error:
+                  type: string
+                observedGeneration:
+                  format: int64
+                  type: integer
+              type: object
+          type: object
+      subresources:
+        status: {}
+`
+
+const CRDTypesCode = `var (
+	// WidgetGroupVersion is the API group and version of the Widget
+	// custom resource.
+	WidgetGroupVersion = schema.GroupVersion{Group: "example.com", Version: "v1"}
+
+	// SchemeBuilder registers the custom resource types with a scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+	// AddToScheme adds the custom resource types to a scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+// addKnownTypes adds the custom resource types to the scheme.
+func addKnownTypes(s *runtime.Scheme) error {
+	s.AddKnownTypes(WidgetGroupVersion, &Widget{}, &WidgetList{})
+	metav1.AddToGroupVersion(s, WidgetGroupVersion)
+	return nil
+}
+
+// Widget is the Widget custom resource.
+type Widget struct {
+	metav1.TypeMeta   ` + "`" + `json:",inline"` + "`" + `
+	metav1.ObjectMeta ` + "`" + `json:"metadata,omitempty"` + "`" + `
+
+	Spec   WidgetSpec   ` + "`" + `json:"spec,omitempty"` + "`" + `
+	Status WidgetStatus ` + "`" + `json:"status,omitempty"` + "`" + `
+}
+
+// WidgetSpec is the desired state of a Widget. Widget describes a widget to
+// deploy.
+type WidgetSpec struct {
+	Name     string   ` + "`" + `json:"name"` + "`" + `
+	Replicas int32    ` + "`" + `json:"replicas"` + "`" + `
+	Mode     *string  ` + "`" + `json:"mode,omitempty"` + "`" + `
+	Tags     []string ` + "`" + `json:"tags,omitempty"` + "`" + `
+	Owner    *string  ` + "`" + `json:"owner,omitempty"` + "`" + `
+}
+
+// WidgetStatus is the observed state of a Widget.
+type WidgetStatus struct {
+	// ObservedGeneration is the last generation successfully reconciled.
+	ObservedGeneration int64 ` + "`" + `json:"observedGeneration,omitempty"` + "`" + `
+	// Error is the error returned by the last reconciliation if any.
+	Error string ` + "`" + `json:"error,omitempty"` + "`" + `
+}
+
+// WidgetList is a list of Widget custom resources.
+type WidgetList struct {
+	metav1.TypeMeta ` + "`" + `json:",inline"` + "`" + `
+	metav1.ListMeta ` + "`" + `json:"metadata,omitempty"` + "`" + `
+
+	Items []Widget ` + "`" + `json:"items"` + "`" + `
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *Widget) DeepCopyInto(out *Widget) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *Widget) DeepCopy() *Widget {
+	if in == nil {
+		return nil
+	}
+	out := new(Widget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the receiver as a runtime.Object.
+func (in *Widget) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *WidgetSpec) DeepCopyInto(out *WidgetSpec) {
+	*out = *in
+	if in.Mode != nil {
+		v := *in.Mode
+		out.Mode = &v
+	}
+	if in.Tags != nil {
+		out.Tags = make([]string, len(in.Tags))
+		copy(out.Tags, in.Tags)
+	}
+	if in.Owner != nil {
+		v := *in.Owner
+		out.Owner = &v
+	}
+}
+
+// DeepCopyInto copies the receiver into out.
+func (in *WidgetList) DeepCopyInto(out *WidgetList) {
+	*out = *in
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]Widget, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver.
+func (in *WidgetList) DeepCopy() *WidgetList {
+	if in == nil {
+		return nil
+	}
+	out := new(WidgetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject returns a deep copy of the receiver as a runtime.Object.
+func (in *WidgetList) DeepCopyObject() runtime.Object {
+	return in.DeepCopy()
+}
+`
+
+const CRDReconcilerCode = `// WidgetReconciler reconciles Widget custom resources by calling the Deploy
+// method of the Deployer service with the resource spec.
+type WidgetReconciler struct {
+	client.Client
+	// Service is the Deployer service client.
+	Service *deployer.Client
+}
+
+// Reconcile calls the service method with the spec of the custom resource
+// identified by req and records the outcome in the resource status.
+func (r *WidgetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var obj Widget
+	if err := r.Get(ctx, req.NamespacedName, &obj); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !obj.DeletionTimestamp.IsZero() || obj.Status.ObservedGeneration == obj.Generation {
+		return ctrl.Result{}, nil
+	}
+	p := &deployer.Widget{}
+	p.Name = obj.Spec.Name
+	p.Replicas = obj.Spec.Replicas
+	p.Mode = obj.Spec.Mode
+	p.Tags = obj.Spec.Tags
+	p.Owner = obj.Spec.Owner
+	if _, err := r.Service.Deploy(ctx, p); err != nil {
+		obj.Status.Error = err.Error()
+		if uerr := r.Status().Update(ctx, &obj); uerr != nil {
+			return ctrl.Result{}, uerr
+		}
+		return ctrl.Result{}, err
+	}
+	obj.Status.Error = ""
+	obj.Status.ObservedGeneration = obj.Generation
+	return ctrl.Result{}, r.Status().Update(ctx, &obj)
+}
+
+// SetupWithManager registers the reconciler with the manager.
+func (r *WidgetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).For(&Widget{}).Complete(r)
+}
+`
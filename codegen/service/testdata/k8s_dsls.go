@@ -0,0 +1,37 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var CRDDSL = func() {
+	var Widget = Type("Widget", func() {
+		Description("Widget describes a widget to deploy.")
+		Meta("k8s:crd", "example.com")
+		Meta("k8s:crd:version", "v1")
+		Attribute("name", String, "Name of widget", func() {
+			Pattern("^[a-z]+$")
+			MaxLength(63)
+		})
+		Attribute("replicas", Int32, func() {
+			Minimum(1)
+			Default(1)
+		})
+		Attribute("mode", String, func() {
+			Enum("fast", "safe")
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			MaxLength(10)
+		})
+		Attribute("owner", String, func() {
+			Format(FormatEmail)
+		})
+		Required("name")
+	})
+	Service("Deployer", func() {
+		Method("Deploy", func() {
+			Payload(Widget)
+			Result(String)
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package testdata
+
+const SecurityConfigBasicJWTCode = `// SecurityConfig holds the secrets used to implement the security schemes of
+// the EndpointsWithRequirements service.
+type SecurityConfig struct {
+	// BasicUsername is the username accepted by the Basic "basic" security scheme.
+	BasicUsername string
+	// BasicPassword is the password accepted by the Basic "basic" security scheme.
+	BasicPassword string
+	// JWTKey is the key used to verify the tokens of the JWT "jwt" security scheme.
+	JWTKey []byte
+}
+
+// LoadSecurityConfig loads the security configuration from the environment.
+// Each value is read from the corresponding environment variable or, if not
+// set, from the file whose path is given by the environment variable with the
+// _FILE suffix. LoadSecurityConfig returns an error listing all the missing
+// values. The variables are:
+//
+//   - BASIC_USERNAME
+//   - BASIC_PASSWORD
+//   - JWT_KEY
+func LoadSecurityConfig() (*SecurityConfig, error) {
+	var l security.SecretLoader
+	c := &SecurityConfig{
+		BasicUsername: l.Required("BASIC_USERNAME"),
+		BasicPassword: l.Required("BASIC_PASSWORD"),
+		JWTKey:        []byte(l.Required("JWT_KEY")),
+	}
+	if err := l.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+`
+
+const SecurityConfigAPIKeyCode = `// SecurityConfig holds the secrets used to implement the security schemes of
+// the SingleService service.
+type SecurityConfig struct {
+	// APIKeyKeys lists the keys accepted by the APIKey "api_key" security scheme.
+	APIKeyKeys []string
+}
+
+// LoadSecurityConfig loads the security configuration from the environment.
+// Each value is read from the corresponding environment variable or, if not
+// set, from the file whose path is given by the environment variable with the
+// _FILE suffix. LoadSecurityConfig returns an error listing all the missing
+// values. The variables are:
+//
+//   - API_KEY_KEYS
+func LoadSecurityConfig() (*SecurityConfig, error) {
+	var l security.SecretLoader
+	c := &SecurityConfig{
+		APIKeyKeys: l.List("API_KEY_KEYS"),
+	}
+	if err := l.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+`
+
+const SecurityConfigOAuth2Code = `// SecurityConfig holds the secrets used to implement the security schemes of
+// the EndpointWithOAuth2 service.
+type SecurityConfig struct {
+	// AuthCodeClientID is the client ID registered with the authorization server
+	// of the OAuth2 "authCode" security scheme.
+	AuthCodeClientID string
+	// AuthCodeClientSecret is the client secret registered with the authorization
+	// server of the OAuth2 "authCode" security scheme.
+	AuthCodeClientSecret string
+}
+
+// LoadSecurityConfig loads the security configuration from the environment.
+// Each value is read from the corresponding environment variable or, if not
+// set, from the file whose path is given by the environment variable with the
+// _FILE suffix. LoadSecurityConfig returns an error listing all the missing
+// values. The variables are:
+//
+//   - AUTH_CODE_CLIENT_ID
+//   - AUTH_CODE_CLIENT_SECRET
+func LoadSecurityConfig() (*SecurityConfig, error) {
+	var l security.SecretLoader
+	c := &SecurityConfig{
+		AuthCodeClientID:     l.Required("AUTH_CODE_CLIENT_ID"),
+		AuthCodeClientSecret: l.Required("AUTH_CODE_CLIENT_SECRET"),
+	}
+	if err := l.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+`
+
+const SecurityConfigSignatureCode = `// SecurityConfig holds the secrets used to implement the security schemes of
+// the EndpointWithSignature service.
+type SecurityConfig struct {
+	// HmacSecret is the shared secret used to verify the request signatures of the
+	// Signature "hmac" security scheme.
+	HmacSecret []byte
+	// MtlsClientCA is the PEM encoded CA certificate used to verify the client
+	// certificates of the MutualTLS "mtls" security scheme.
+	MtlsClientCA []byte
+}
+
+// LoadSecurityConfig loads the security configuration from the environment.
+// Each value is read from the corresponding environment variable or, if not
+// set, from the file whose path is given by the environment variable with the
+// _FILE suffix. LoadSecurityConfig returns an error listing all the missing
+// values. The variables are:
+//
+//   - HMAC_SECRET
+//   - MTLS_CLIENT_CA
+func LoadSecurityConfig() (*SecurityConfig, error) {
+	var l security.SecretLoader
+	c := &SecurityConfig{
+		HmacSecret:   []byte(l.Required("HMAC_SECRET")),
+		MtlsClientCA: []byte(l.Required("MTLS_CLIENT_CA")),
+	}
+	if err := l.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+`
@@ -28,6 +28,10 @@ var OAuth2AuthorizationCode = OAuth2Security("authCode", func() {
 	Scope("api:read", "Read access")
 })
 
+var SignatureAuth = SignatureSecurity("hmac")
+
+var MutualTLSAuth = MutualTLSSecurity("mtls")
+
 var EndpointWithoutRequirementDSL = func() {
 	Service("EndpointWithoutRequirement", func() {
 		Method("Unsecure", func() {
@@ -50,6 +54,17 @@ var EndpointNoSecurityDSL = func() {
 	})
 }
 
+var EndpointWithSignatureDSL = func() {
+	Service("EndpointWithSignature", func() {
+		Method("Signed", func() {
+			Security(SignatureAuth, MutualTLSAuth)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var EndpointsWithServiceRequirementsDSL = func() {
 	Service("EndpointsWithServiceRequirements", func() {
 		Security(BasicAuth)
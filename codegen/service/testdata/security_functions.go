@@ -42,6 +42,15 @@ func NewEndpoints(s Service) *Endpoints {
 }
 `
 
+var EndpointInitWithSignatureCode = `// NewEndpoints wraps the methods of the "EndpointWithSignature" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Signed: NewSignedEndpoint(s),
+	}
+}
+`
+
 var EndpointWithRequiredScopesCode = `// NewSecureWithRequiredScopesEndpoint returns an endpoint function that calls
 // the method "SecureWithRequiredScopes" of service
 // "EndpointWithRequiredScopes".
@@ -62,6 +71,10 @@ func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFu
 		if err != nil {
 			return nil, err
 		}
+		ctx, err = goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.SecureWithRequiredScopes(ctx, p)
 	}
 }
@@ -91,6 +104,10 @@ func NewSecureWithOptionalRequiredScopesEndpoint(s Service, authBasicFn security
 		if err != nil {
 			return nil, err
 		}
+		ctx, err = goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.SecureWithOptionalRequiredScopes(ctx, p)
 	}
 }
@@ -116,6 +133,10 @@ func NewSecureWithAPIKeyOverrideEndpoint(s Service, authAPIKeyFn security.AuthAP
 		if err != nil {
 			return nil, err
 		}
+		ctx, err = goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.SecureWithAPIKeyOverride(ctx, p)
 	}
 }
@@ -148,6 +169,10 @@ func NewSecureWithOAuth2Endpoint(s Service, authOAuth2Fn security.AuthOAuth2Func
 		if err != nil {
 			return nil, err
 		}
+		ctx, err = goa.RunAuthHooks(ctx, p)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.SecureWithOAuth2(ctx, p)
 	}
 }
@@ -177,6 +202,10 @@ func NewEndpointWithSkipRequestBodyEncodeDecodeEndpoint(s Service, authBasicFn s
 		if err != nil {
 			return nil, err
 		}
+		ctx, err = goa.RunAuthHooks(ctx, ep.Payload)
+		if err != nil {
+			return nil, err
+		}
 		return nil, s.EndpointWithSkipRequestBodyEncodeDecode(ctx, ep.Payload, ep.Body)
 	}
 }
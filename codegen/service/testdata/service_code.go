@@ -291,6 +291,238 @@ const ServiceName = "Empty"
 var MethodNames = [1]string{"Empty"}
 `
 
+const MethodMeta = `
+// Service is the MethodMeta service interface.
+type Service interface {
+	// Audited implements Audited.
+	Audited(context.Context) (err error)
+	// Plain implements Plain.
+	Plain(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "MethodMeta"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [2]string{"Audited", "Plain"}
+
+// MethodMeta lists the metadata defined in the design on the service methods
+// indexed by method name. Middlewares such as middleware.Audit use it to
+// configure their behavior per method.
+var MethodMeta = map[string]map[string][]string{
+	"Audited": {
+		"audit":        {"true"},
+		"audit:params": {"id", "name"},
+	},
+}
+`
+
+const EmbedMethod = `
+// Service is the Embed service interface.
+type Service interface {
+	// Update implements Update.
+	Update(context.Context, *User) (res *User, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "Embed"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"Update"}
+
+type Timestamps struct {
+	CreatedAt string
+	UpdatedAt *string
+}
+
+// User is the payload type of the Embed service Update method.
+type User struct {
+	Name      *string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// AsTimestamps returns the values of the Timestamps attributes embedded in
+// User.
+func (t *User) AsTimestamps() *Timestamps {
+	if t == nil {
+		return nil
+	}
+	v := &Timestamps{}
+	v.CreatedAt = t.CreatedAt
+	v.UpdatedAt = &t.UpdatedAt
+	return v
+}
+
+// SetTimestamps sets the values of the Timestamps attributes embedded in User.
+func (t *User) SetTimestamps(v *Timestamps) {
+	if v == nil {
+		return
+	}
+	t.CreatedAt = v.CreatedAt
+	if v.UpdatedAt != nil {
+		t.UpdatedAt = *v.UpdatedAt
+	}
+}
+`
+
+const EmbedResultType = `
+// Service is the EmbedResultType service interface.
+type Service interface {
+	// Show implements Show.
+	Show(context.Context, *Bottle) (res *Bottle, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "EmbedResultType"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"Show"}
+
+// Bottle is the payload type of the EmbedResultType service Show method.
+type Bottle struct {
+	Name string
+	Timestamps
+}
+
+type Timestamps struct {
+	CreatedAt string
+	UpdatedAt *string
+}
+
+// NewBottle initializes result type Bottle from viewed result type Bottle.
+func NewBottle(vres *embedresulttypeviews.Bottle) *Bottle {
+	return newBottle(vres.Projected)
+}
+
+// NewViewedBottle initializes viewed result type Bottle from result type
+// Bottle using the given view.
+func NewViewedBottle(res *Bottle, view string) *embedresulttypeviews.Bottle {
+	p := newBottleView(res)
+	return &embedresulttypeviews.Bottle{Projected: p, View: "default"}
+}
+
+// newBottle converts projected type Bottle to service type Bottle.
+func newBottle(vres *embedresulttypeviews.BottleView) *Bottle {
+	res := &Bottle{}
+	if vres.Name != nil {
+		res.Name = *vres.Name
+	}
+	if vres.CreatedAt != nil {
+		res.CreatedAt = *vres.CreatedAt
+	}
+	res.UpdatedAt = vres.UpdatedAt
+	return res
+}
+
+// newBottleView projects result type Bottle to projected type BottleView using
+// the "default" view.
+func newBottleView(res *Bottle) *embedresulttypeviews.BottleView {
+	vres := &embedresulttypeviews.BottleView{
+		Name:      &res.Name,
+		CreatedAt: &res.CreatedAt,
+		UpdatedAt: res.UpdatedAt,
+	}
+	return vres
+}
+`
+
+const SensitiveFields = `
+// Service is the SensitiveFields service interface.
+type Service interface {
+	// Login implements Login.
+	Login(context.Context, *Credentials) (res *LoginResult, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SensitiveFields"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"Login"}
+
+// SensitiveFields lists the names of the payload and result attributes marked
+// as sensitive in the design. Logging middlewares such as
+// middleware.LogBodies use it to redact the corresponding values.
+var SensitiveFields = []string{"password", "token"}
+
+// Credentials is the payload type of the SensitiveFields service Login method.
+type Credentials struct {
+	Username *string
+	Password *string
+}
+
+// LoginResult is the result type of the SensitiveFields service Login method.
+type LoginResult struct {
+	Token *string
+}
+`
+
+const SensitiveWireName = `
+// Service is the SensitiveWireName service interface.
+type Service interface {
+	// Login implements Login.
+	Login(context.Context) (res *LoginResult, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SensitiveWireName"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"Login"}
+
+// SensitiveFields lists the names of the payload and result attributes marked
+// as sensitive in the design. Logging middlewares such as
+// middleware.LogBodies use it to redact the corresponding values.
+var SensitiveFields = []string{"accessToken"}
+
+// LoginResult is the result type of the SensitiveWireName service Login method.
+type LoginResult struct {
+	AccessToken *string
+}
+`
+
+const DiscoveryTags = `
+// Service is the DiscoveryTags service interface.
+type Service interface {
+	// Plain implements Plain.
+	Plain(context.Context) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "DiscoveryTags"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"Plain"}
+
+// DiscoveryTags lists the tags used to register the service with service
+// discovery systems, see the goa discovery package.
+var DiscoveryTags = []string{"api", "v1", "public"}
+`
+
 const EmptyResultMethod = `
 // Service is the EmptyResult service interface.
 type Service interface {
@@ -2666,3 +2898,164 @@ type Foo struct {
 	IntField *int
 }
 `
+
+const NamedEnum = `
+// Service is the NamedEnum service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (res *AResult, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "NamedEnum"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the NamedEnum service A method.
+type APayload struct {
+	Status Status
+}
+
+// AResult is the result type of the NamedEnum service A method.
+type AResult struct {
+	Previous *Status
+}
+
+type Status string
+
+// Values of Status.
+const (
+	StatusPending    Status = "pending"
+	StatusInProgress Status = "in-progress"
+	StatusClosed     Status = "closed"
+)
+
+// IsValid returns true if v is one of the Status values.
+func (v Status) IsValid() bool {
+	switch v {
+	case StatusPending, StatusInProgress, StatusClosed:
+		return true
+	}
+	return false
+}
+`
+
+const IntEnum = `
+// Service is the IntEnum service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "IntEnum"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the IntEnum service A method.
+type APayload struct {
+	Priority *Priority
+}
+
+type Priority int
+
+// Values of Priority.
+const (
+	PriorityLow    Priority = 1
+	PriorityMedium Priority = 2
+	PriorityHigh   Priority = 4
+)
+
+// IsValid returns true if v is one of the Priority values.
+func (v Priority) IsValid() bool {
+	switch v {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+`
+
+const NamedInlineObject = `
+// Service is the NamedInlineObject service interface.
+type Service interface {
+	// A implements A.
+	A(context.Context, *APayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "NamedInlineObject"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"A"}
+
+// APayload is the payload type of the NamedInlineObject service A method.
+type APayload struct {
+	Name       *string
+	Dimensions *Dimensions
+}
+
+type Dimensions struct {
+	W int
+	H *int
+}
+`
+
+const AuthorizeMethods = `
+// Service is the AuthorizeMethods service interface.
+type Service interface {
+	// Show implements Show.
+	Show(context.Context, *ShowPayload) (err error)
+	// List implements List.
+	List(context.Context) (err error)
+	// Status implements Status.
+	Status(context.Context) (err error)
+	ShowAuthorizer
+	ListAuthorizer
+}
+
+// ShowAuthorizer defines the authorization hook of the "Show" method.
+type ShowAuthorizer interface {
+	// AuthorizeShow returns an error if the caller is not allowed to make the
+	// call. It is called once the payload has been decoded and validated and
+	// before Show.
+	AuthorizeShow(context.Context, *ShowPayload) error
+}
+
+// ListAuthorizer defines the authorization hook of the "List" method.
+type ListAuthorizer interface {
+	// AuthorizeList returns an error if the caller is not allowed to make the
+	// call. It is called once the payload has been decoded and validated and
+	// before List.
+	AuthorizeList(context.Context) error
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "AuthorizeMethods"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [3]string{"Show", "List", "Status"}
+
+// ShowPayload is the payload type of the AuthorizeMethods service Show method.
+type ShowPayload struct {
+	ID *string
+}
+`
@@ -156,6 +156,150 @@ var EmptyMethodDSL = func() {
 	})
 }
 
+var MethodMetaDSL = func() {
+	Service("MethodMeta", func() {
+		Method("Audited", func() {
+			Meta("audit", "true")
+			Meta("audit:params", "id", "name")
+		})
+		Method("Plain", func() {
+		})
+	})
+}
+
+var SensitiveFieldsDSL = func() {
+	var Credentials = Type("Credentials", func() {
+		Attribute("username", String)
+		Attribute("password", String, func() {
+			Meta("sensitive")
+		})
+	})
+	Service("SensitiveFields", func() {
+		Method("Login", func() {
+			Payload(Credentials)
+			Result(func() {
+				Attribute("token", String, func() {
+					Meta("sensitive")
+				})
+			})
+		})
+	})
+}
+
+var SensitiveWireNameDSL = func() {
+	Service("SensitiveWireName", func() {
+		Method("Login", func() {
+			Result(func() {
+				Attribute("access_token", String, func() {
+					WireName("accessToken")
+					Sensitive()
+				})
+			})
+		})
+	})
+}
+
+var DiscoveryTagsDSL = func() {
+	API("Discovery", func() {
+		Meta("discovery:tags", "api")
+	})
+	Service("DiscoveryTags", func() {
+		Meta("discovery:tags", "v1", "public")
+		Method("Plain", func() {
+		})
+	})
+}
+
+var EmbedMethodDSL = func() {
+	var Timestamps = Type("Timestamps", func() {
+		Attribute("created_at", String)
+		Attribute("updated_at", String)
+		Required("created_at")
+	})
+	var User = Type("User", func() {
+		Attribute("name", String)
+		Embed(Timestamps)
+		Required("updated_at")
+	})
+	Service("Embed", func() {
+		Method("Update", func() {
+			Payload(User)
+			Result(User)
+		})
+	})
+}
+
+var EmbedResultTypeDSL = func() {
+	var Timestamps = Type("Timestamps", func() {
+		Attribute("created_at", String)
+		Attribute("updated_at", String)
+		Required("created_at")
+	})
+	var Bottle = ResultType("application/vnd.bottle", func() {
+		Attribute("name", String)
+		Embed(Timestamps)
+		Required("name")
+	})
+	Service("EmbedResultType", func() {
+		Method("Show", func() {
+			Payload(Bottle)
+			Result(Bottle)
+		})
+	})
+}
+
+var NamedInlineObjectDSL = func() {
+	Service("NamedInlineObject", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("dimensions", func() {
+					Meta("struct:type:name", "Dimensions")
+					Attribute("w", Int)
+					Attribute("h", Int, func() {
+						Minimum(0)
+					})
+					Required("w")
+				})
+			})
+		})
+	})
+}
+
+var NamedEnumDSL = func() {
+	Service("NamedEnum", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("status", String, func() {
+					Enum("pending", "in-progress", "closed")
+					Meta("enum:type", "Status")
+				})
+				Required("status")
+			})
+			Result(func() {
+				Attribute("previous", String, func() {
+					Enum("pending", "in-progress", "closed")
+					Meta("enum:type", "Status")
+				})
+			})
+		})
+	})
+}
+
+var IntEnumDSL = func() {
+	Service("IntEnum", func() {
+		Method("A", func() {
+			Payload(func() {
+				Attribute("priority", Int, func() {
+					Enum(1, 2, 4)
+					EnumNames("Low", "Medium", "High")
+					Meta("enum:type", "Priority")
+				})
+			})
+		})
+	})
+}
+
 var EmptyPayloadMethodDSL = func() {
 	var AResult = Type("AResult", func() {
 		Attribute("IntField", Int)
@@ -711,6 +855,56 @@ var BidirectionalStreamingNoPayloadMethodDSL = func() {
 	})
 }
 
+var PaginatedMethodDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+	})
+	Service("PaginatedService", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(Item))
+				Attribute("next", String)
+				Required("items")
+			})
+			Paginated("cursor", "next", "items")
+		})
+	})
+}
+
+var TimeoutMethodDSL = func() {
+	Service("TimeoutService", func() {
+		Method("Show", func() {
+			Payload(String)
+			Result(String)
+			Timeout("1500ms")
+		})
+		Method("Delete", func() {
+			Payload(String)
+			Timeout("1m")
+		})
+	})
+}
+
+var PaginatedRequiredCursorMethodDSL = func() {
+	Service("PaginatedRequiredCursorService", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("page", Int)
+				Required("page")
+			})
+			Result(func() {
+				Attribute("values", ArrayOf(String))
+				Attribute("next_page", Int)
+			})
+			Paginated("page", "next_page", "values")
+		})
+	})
+}
+
 var BidirectionalStreamingResultWithViewsMethodDSL = func() {
 	var APayload = Type("APayload", func() {
 		Attribute("IntField", Int)
@@ -929,3 +1123,35 @@ var PkgPathPayloadAttributeDSL = func() {
 		})
 	})
 }
+
+var LongRunningMethodDSL = func() {
+	var Operation = Type("Operation", func() {
+		Attribute("id", String)
+		Attribute("done", Boolean)
+		Required("id")
+	})
+	Service("LongRunningService", func() {
+		Method("Export", func() {
+			Payload(func() {
+				Attribute("format", String)
+			})
+			LongRunning(Operation)
+		})
+	})
+}
+
+var AuthorizeMethodsDSL = func() {
+	Service("AuthorizeMethods", func() {
+		Method("Show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Authorize()
+		})
+		Method("List", func() {
+			Authorize()
+		})
+		Method("Status", func() {
+		})
+	})
+}
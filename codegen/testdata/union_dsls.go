@@ -38,12 +38,26 @@ var TestUnionDSL = func() {
 			})
 		})
 
+		OtherType = Type("OtherType", func() {
+			Attribute("someField", String)
+			Attribute("otherField", Int)
+			Required("otherField")
+		})
+		UnionDiscriminated = Type("UnionDiscriminated", func() {
+			OneOf("UnionDiscriminated", func() {
+				Discriminator("kind")
+				Attribute("some", SomeType)
+				Attribute("other", OtherType)
+			})
+		})
+
 		_ = Type("Container", func() {
 			Attribute("UnionString", UnionString)
 			Attribute("UnionString2", UnionString2)
 			Attribute("UnionStringInt", UnionStringInt)
 			Attribute("UnionStringInt2", UnionStringInt2)
 			Attribute("UnionSomeType", UnionSomeType)
+			Attribute("UnionDiscriminated", UnionDiscriminated)
 		})
 
 		_ = Type("UnionUserType", func() {
@@ -51,5 +65,12 @@ var TestUnionDSL = func() {
 			Attribute("Value", String)
 			Required("Type", "Value")
 		})
+
+		_ = Type("DiscriminatedUserType", func() {
+			Attribute("kind", String)
+			Attribute("someField", String)
+			Attribute("otherField", Int)
+			Required("kind")
+		})
 	)
 }
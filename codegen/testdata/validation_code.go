@@ -349,6 +349,7 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.array", target.Array))
 	for _, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
@@ -367,6 +368,7 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.array", target.Array))
 	for _, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
@@ -385,6 +387,7 @@ const (
 	if len(target.DefaultArray) > 3 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError("target.default_array", target.DefaultArray, len(target.DefaultArray), 3, false))
 	}
+	err = goa.MergeErrors(err, goa.ValidateUniqueItems("target.array", target.Array))
 	for _, e := range target.Array {
 		if !(e == 0 || e == 1 || e == 1 || e == 2 || e == 3 || e == 5) {
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError("target.array[*]", e, []interface{}{0, 1, 1, 2, 3, 5}))
@@ -475,5 +478,68 @@ const (
 		err = goa.MergeErrors(err, err2)
 	}
 }
+`
+
+	FieldGroupsRequiredValidationCode = `func Validate() (err error) {
+	err = goa.MergeErrors(err, goa.ValidateRequiredTogether("target", []string{"lat", "lng"}, target.Lat != nil, target.Lng != nil))
+	err = goa.MergeErrors(err, goa.ValidateMutex("target", []string{"email", "phone", "tags"}, target.Email != nil, true, target.Tags != nil))
+}
+`
+
+	FieldGroupsPointerValidationCode = `func Validate() (err error) {
+	if target.Phone == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("phone", "target"))
+	}
+	err = goa.MergeErrors(err, goa.ValidateRequiredTogether("target", []string{"lat", "lng"}, target.Lat != nil, target.Lng != nil))
+	err = goa.MergeErrors(err, goa.ValidateMutex("target", []string{"email", "phone", "tags"}, target.Email != nil, target.Phone != nil, target.Tags != nil))
+}
+`
+
+	CustomValidationRequiredValidationCode = `func Validate() (err error) {
+	if target.Vintage < 1900 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError("target.vintage", target.Vintage, 1900, true))
+	}
+	if err2 := validators.ValidateVintage(target.Vintage); err2 != nil {
+		err = goa.MergeErrors(err, goa.InvalidValueError("target.vintage", target.Vintage, err2))
+	}
+	if target.Name != nil {
+		if err2 := ValidateName(*target.Name); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidValueError("target.name", *target.Name, err2))
+		}
+	}
+}
+`
+
+	CustomValidationPointerValidationCode = `func Validate() (err error) {
+	if target.Vintage == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("vintage", "target"))
+	}
+	if target.Vintage != nil {
+		if *target.Vintage < 1900 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("target.vintage", *target.Vintage, 1900, true))
+		}
+	}
+	if target.Vintage != nil {
+		if err2 := validators.ValidateVintage(*target.Vintage); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidValueError("target.vintage", *target.Vintage, err2))
+		}
+	}
+	if target.Name != nil {
+		if err2 := ValidateName(*target.Name); err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidValueError("target.name", *target.Name, err2))
+		}
+	}
+}
+`
+
+	WireNamesPointerValidationCode = `func Validate() (err error) {
+	if target.SelfURL == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("selfURL", "target"))
+	}
+	err = goa.MergeErrors(err, goa.ValidateMutex("target", []string{"nextURL", "prev_url"}, target.NextURL != nil, target.PrevURL != nil))
+	if target.SelfURL != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("target.selfURL", *target.SelfURL, goa.FormatURI))
+	}
+}
 `
 )
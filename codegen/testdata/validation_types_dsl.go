@@ -90,6 +90,7 @@ var ValidationTypesDSL = func() {
 				Default([]string{"foo", "bar"})
 			})
 			Attribute("array", ArrayOf(UInt64), func() {
+				UniqueItems()
 				Elem(func() {
 					Enum(0, 1, 1, 2, 3, 5)
 				})
@@ -133,5 +134,39 @@ var ValidationTypesDSL = func() {
 				Attribute("collection", CollectionOf(Result))
 			})
 		})
+
+		_ = Type("FieldGroups", func() {
+			Attribute("lat", Float64)
+			Attribute("lng", Float64)
+			Attribute("email", String)
+			Attribute("phone", String)
+			Attribute("tags", ArrayOf(String))
+			Required("phone")
+			RequiredTogether("lat", "lng")
+			Mutex("email", "phone", "tags")
+		})
+
+		_ = Type("CustomValidation", func() {
+			Attribute("vintage", Int, func() {
+				Minimum(1900)
+				ValidateWith("validators.ValidateVintage", "example.com/validators")
+			})
+			Attribute("name", String, func() {
+				ValidateWith("ValidateName")
+			})
+			Required("vintage")
+		})
+		_ = Type("WireNames", func() {
+			Attribute("self_url", String, func() {
+				WireName("selfURL")
+				Format(FormatURI)
+			})
+			Attribute("next_url", String, func() {
+				Meta("wire:name", "nextURL")
+			})
+			Attribute("prev_url", String)
+			Required("self_url")
+			Mutex("next_url", "prev_url")
+		})
 	)
 }
@@ -36,6 +36,16 @@ func GoNativeTypeName(t expr.DataType) string {
 		return "[]byte"
 	case expr.AnyKind:
 		return "interface{}"
+	case expr.DateTimeKind:
+		return "time.Time"
+	case expr.DateKind:
+		return "goa.Date"
+	case expr.DurationKind:
+		return "time.Duration"
+	case expr.UUIDKind:
+		return "uuid.UUID"
+	case expr.DecimalKind:
+		return "goa.Decimal"
 	default:
 		panic(fmt.Sprintf("cannot compute native Go type for %T", t)) // bug
 	}
@@ -64,3 +74,87 @@ func AttributeTags(parent, att *expr.AttributeExpr) string {
 	}
 	return ""
 }
+
+// EmbeddedTypes returns the types embedded in the user type dt with the Embed
+// DSL that the Go struct generated for dt embeds. The struct embeds a type if
+// both types are generated in the same package and if the fields generated
+// for the embedded attributes are identical in both structs, i.e. the
+// attributes have the same requiredness and default values. The attributes of
+// the other embedded types are generated as regular struct fields.
+func EmbeddedTypes(dt expr.DataType) []expr.UserType {
+	ut, ok := dt.(expr.UserType)
+	if !ok || !expr.IsObject(ut) {
+		return nil
+	}
+	var (
+		res   []expr.UserType
+		att   = ut.Attribute()
+		obj   = expr.AsObject(ut)
+		taken = make(map[string]struct{})
+	)
+	for _, nat := range *obj {
+		taken[GoifyAtt(nat.Attribute, nat.Name, true)] = struct{}{}
+	}
+	for _, n := range att.Meta["type:embed"] {
+		et := expr.Root.UserType(n)
+		if et == nil || et == ut || !expr.IsObject(et) || !sameLocation(ut, et) {
+			continue
+		}
+		if _, ok := taken[Goify(et.Name(), true)]; ok {
+			continue
+		}
+		fields := make(map[string]struct{})
+		embeddable := true
+		for _, enat := range *expr.AsObject(et) {
+			a := obj.Attribute(enat.Name)
+			if a == nil || a.Type.Hash() != enat.Attribute.Type.Hash() ||
+				att.IsPrimitivePointer(enat.Name, true) != et.Attribute().IsPrimitivePointer(enat.Name, true) {
+				embeddable = false
+				break
+			}
+			fn := GoifyAtt(a, enat.Name, true)
+			if _, ok := taken[fn]; !ok || fn != GoifyAtt(enat.Attribute, enat.Name, true) {
+				// The field is already promoted from another embedded type.
+				embeddable = false
+				break
+			}
+			fields[fn] = struct{}{}
+		}
+		if !embeddable {
+			continue
+		}
+		for fn := range fields {
+			delete(taken, fn)
+		}
+		taken[Goify(et.Name(), true)] = struct{}{}
+		res = append(res, et)
+	}
+	return res
+}
+
+// EmbeddedFields returns the names of the fields of the Go struct generated
+// for the user type dt that are promoted from the types it embeds, see
+// EmbeddedTypes.
+func EmbeddedFields(dt expr.DataType) map[string]struct{} {
+	ets := EmbeddedTypes(dt)
+	if len(ets) == 0 {
+		return nil
+	}
+	res := make(map[string]struct{})
+	for _, et := range ets {
+		for _, nat := range *expr.AsObject(et) {
+			res[GoifyAtt(nat.Attribute, nat.Name, true)] = struct{}{}
+		}
+	}
+	return res
+}
+
+// sameLocation returns true if the Go types generated for the two user types
+// are in the same package.
+func sameLocation(a, b expr.UserType) bool {
+	la, lb := UserTypeLocation(a), UserTypeLocation(b)
+	if la == nil || lb == nil {
+		return la == lb
+	}
+	return la.RelImportPath == lb.RelImportPath
+}
@@ -17,7 +17,10 @@ var (
 	exclMinMaxValT *template.Template
 	minMaxValT     *template.Template
 	lengthValT     *template.Template
+	uniqueValT     *template.Template
 	requiredValT   *template.Template
+	groupValT      *template.Template
+	funcValT       *template.Template
 	arrayValT      *template.Template
 	mapValT        *template.Template
 	userValT       *template.Template
@@ -37,7 +40,10 @@ func init() {
 	exclMinMaxValT = template.Must(template.New("exclMinMax").Funcs(fm).Parse(exclMinMaxValTmpl))
 	minMaxValT = template.Must(template.New("minMax").Funcs(fm).Parse(minMaxValTmpl))
 	lengthValT = template.Must(template.New("length").Funcs(fm).Parse(lengthValTmpl))
+	uniqueValT = template.Must(template.New("unique").Funcs(fm).Parse(uniqueValTmpl))
 	requiredValT = template.Must(template.New("req").Funcs(fm).Parse(requiredValTmpl))
+	groupValT = template.Must(template.New("group").Funcs(fm).Parse(groupValTmpl))
+	funcValT = template.Must(template.New("func").Funcs(fm).Parse(funcValTmpl))
 	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
@@ -166,6 +172,11 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 			res = append(res, val)
 		}
 	}
+	if validation.UniqueItems && expr.IsArray(att.Type) {
+		if val := runTemplate(uniqueValT, data); val != "" {
+			res = append(res, val)
+		}
+	}
 	if req := validation.Required; len(req) > 0 {
 		obj := expr.AsObject(att.Type)
 		for _, r := range req {
@@ -180,13 +191,53 @@ func ValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alia
 				continue
 			}
 			data["req"] = r
+			data["reqName"] = att.WireName(r)
 			data["reqAtt"] = reqAtt
 			res = append(res, runTemplate(requiredValT, data))
 		}
 	}
+	for _, g := range validation.RequiredTogether {
+		data["func"] = "ValidateRequiredTogether"
+		data["names"] = att.WireNames(g)
+		data["set"] = fieldsSetCode(att, attCtx, g, target)
+		res = append(res, runTemplate(groupValT, data))
+	}
+	for _, g := range validation.Mutex {
+		data["func"] = "ValidateMutex"
+		data["names"] = att.WireNames(g)
+		data["set"] = fieldsSetCode(att, attCtx, g, target)
+		res = append(res, runTemplate(groupValT, data))
+	}
+	for _, f := range validation.Funcs {
+		data["func"] = f.Name
+		res = append(res, runTemplate(funcValT, data))
+	}
 	return strings.Join(res, "\n")
 }
 
+// fieldsSetCode returns the Go expressions that test whether the fields of
+// target corresponding to the given object attributes are set. Fields that
+// cannot be nil are always set.
+func fieldsSetCode(att *expr.AttributeExpr, attCtx *AttributeContext, names []string, target string) string {
+	obj := expr.AsObject(att.Type)
+	set := make([]string, len(names))
+	for i, n := range names {
+		fatt := obj.Attribute(n)
+		if fatt == nil {
+			set[i] = "false"
+			continue
+		}
+		if expr.IsPrimitive(fatt.Type) && fatt.Type.Kind() != expr.BytesKind &&
+			fatt.Type.Kind() != expr.AnyKind && !attCtx.IsPrimitivePointer(n, att) {
+
+			set[i] = "true"
+			continue
+		}
+		set[i] = fmt.Sprintf("%s.%s != nil", target, attCtx.Scope.Field(fatt, n, true))
+	}
+	return strings.Join(set, ", ")
+}
+
 // RecursiveValidationCode produces Go code that runs the validations defined in
 // the given attribute and its children recursively against the value held by
 // the variable named target. See ValidationCode for a description of the
@@ -200,6 +251,14 @@ func RecursiveValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext,
 	return recurseValidationCode(att, attCtx, req, alias, target, target, seen).String()
 }
 
+// RecursiveValidationCodeWithContext is similar to RecursiveValidationCode but
+// uses context instead of target to name the value in validation errors. It is
+// used to name transport elements (e.g. HTTP headers) after their wire names.
+func RecursiveValidationCodeWithContext(att *expr.AttributeExpr, attCtx *AttributeContext, req, alias bool, target, context string) string {
+	seen := make(map[string]*bytes.Buffer)
+	return recurseValidationCode(att, attCtx, req, alias, target, context, seen).String()
+}
+
 func recurseValidationCode(att *expr.AttributeExpr, attCtx *AttributeContext, req, alias bool, target, context string, seen map[string]*bytes.Buffer) *bytes.Buffer {
 	var (
 		buf   = new(bytes.Buffer)
@@ -304,7 +363,7 @@ func recurseAttribute(att *expr.AttributeExpr, attCtx *AttributeContext, nat *ex
 	ut, isut := nat.Attribute.Type.(expr.UserType)
 	if !isut {
 		target := fmt.Sprintf("%s.%s", target, attCtx.Scope.Field(nat.Attribute, nat.Name, true))
-		context := fmt.Sprintf("%s.%s", context, nat.Name)
+		context := fmt.Sprintf("%s.%s", context, att.WireName(nat.Name))
 		code := recurseValidationCode(nat.Attribute, attCtx, att.IsRequired(nat.Name), false, target, context, seen)
 		validation = code.String()
 	} else if hasValidations(attCtx, ut) {
@@ -498,7 +557,23 @@ if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target
 }
 {{- end }}`
 
+	uniqueValTmpl = `err = goa.MergeErrors(err, goa.ValidateUniqueItems({{ printf "%q" .context }}, {{ .target }}))`
+
+	groupValTmpl = `err = goa.MergeErrors(err, goa.{{ .func }}({{ printf "%q" .context }}, {{ printf "%#v" .names }}, {{ .set }}))`
+
+	funcValTmpl = `{{ if isset .zeroVal -}}
+if {{ .target }} != {{ if and (not .zeroVal) .string }}""{{ else }}{{ .zeroVal }}{{ end }} {
+{{ else if .isPointer -}}
+if {{ .target }} != nil {
+{{ end -}}
+if err2 := {{ .func }}({{ .targetVal }}); err2 != nil {
+        err = goa.MergeErrors(err, goa.InvalidValueError({{ printf "%q" .context }}, {{ .targetVal }}, err2))
+{{ if or (isset .zeroVal) .isPointer -}}
+}
+{{ end -}}
+}`
+
 	requiredValTmpl = `if {{ $.target }}.{{ .attCtx.Scope.Field $.reqAtt .req true }} == nil {
-        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
+        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .reqName }}", {{ printf "%q" $.context }}))
 }`
 )
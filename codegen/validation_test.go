@@ -23,6 +23,9 @@ func TestRecursiveValidationCode(t *testing.T) {
 		rtT      = root.UserType("Result")
 		rtcolT   = root.UserType("Collection")
 		colT     = root.UserType("TypeWithCollection")
+		groupsT  = root.UserType("FieldGroups")
+		customT  = root.UserType("CustomValidation")
+		wireT    = root.UserType("WireNames")
 	)
 	cases := []struct {
 		Name       string
@@ -56,6 +59,11 @@ func TestRecursiveValidationCode(t *testing.T) {
 		{"collection-required", rtcolT, true, false, false, testdata.ResultCollectionPointerValidationCode},
 		{"collection-pointer", rtcolT, false, true, false, testdata.ResultCollectionPointerValidationCode},
 		{"type-with-collection-pointer", colT, false, true, false, testdata.TypeWithCollectionPointerValidationCode},
+		{"field-groups-required", groupsT, true, false, false, testdata.FieldGroupsRequiredValidationCode},
+		{"field-groups-pointer", groupsT, false, true, false, testdata.FieldGroupsPointerValidationCode},
+		{"custom-validation-required", customT, true, false, false, testdata.CustomValidationRequiredValidationCode},
+		{"custom-validation-pointer", customT, false, true, false, testdata.CustomValidationPointerValidationCode},
+		{"wire-names-pointer", wireT, false, true, false, testdata.WireNamesPointerValidationCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,270 @@
+/*
+Package config provides runtime configuration that may be reloaded without
+restarting the server. A Watcher loads settings such as the log level, rate
+limits or feature flags from files and environment variables, reloads them on
+SIGHUP or periodically and notifies the registered listeners of the settings
+that changed:
+
+    w := config.NewWatcher(config.File("config.env"), config.Env("MYAPI_"))
+    if err := w.Reload(); err != nil {
+        return err
+    }
+    w.Subscribe(func(changes map[string]string) {
+        if lvl, ok := changes["log_level"]; ok {
+            setLogLevel(lvl)
+        }
+    })
+    go w.Run(ctx, time.Minute)
+*/
+package config
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type (
+	// Source loads configuration settings.
+	Source interface {
+		// Load returns the settings indexed by key.
+		Load() (map[string]string, error)
+	}
+
+	// SourceFunc is a Source implemented by a function.
+	SourceFunc func() (map[string]string, error)
+
+	// Listener is called with the settings that changed when the
+	// configuration is reloaded. Settings that were removed are reported
+	// with an empty value.
+	Listener func(changes map[string]string)
+
+	// Watcher holds the current configuration and reloads it from its
+	// sources.
+	Watcher struct {
+		// OnError is called with the errors that occur when the
+		// configuration is reloaded by Run. The previous configuration
+		// remains in effect.
+		OnError func(error)
+
+		sources   []Source
+		mu        sync.RWMutex
+		values    map[string]string
+		listeners []Listener
+	}
+
+	// fileSource loads settings from a file.
+	fileSource string
+
+	// envSource loads settings from environment variables.
+	envSource string
+)
+
+// NewWatcher returns a watcher that loads the configuration from the given
+// sources. Settings loaded by later sources override settings with the same
+// key loaded by earlier sources.
+func NewWatcher(sources ...Source) *Watcher {
+	return &Watcher{sources: sources, values: make(map[string]string)}
+}
+
+// File returns a source that loads settings from the file at the given path.
+// Files with the ".json" extension must contain a JSON object whose values are
+// strings, numbers or booleans. Other files list "key=value" pairs, one per
+// line. Empty lines and lines starting with "#" are ignored.
+func File(path string) Source {
+	return fileSource(path)
+}
+
+// Env returns a source that loads settings from the environment variables
+// whose names start with prefix. The keys are the lowercase variable names
+// without prefix, e.g. the variable "MYAPI_LOG_LEVEL" sets the key "log_level"
+// given the prefix "MYAPI_".
+func Env(prefix string) Source {
+	return envSource(prefix)
+}
+
+// Load calls f.
+func (f SourceFunc) Load() (map[string]string, error) {
+	return f()
+}
+
+// Get returns the value of the setting with the given key, "" if there is
+// none.
+func (w *Watcher) Get(key string) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.values[key]
+}
+
+// Lookup returns the value of the setting with the given key and whether it
+// is set.
+func (w *Watcher) Lookup(key string) (string, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	v, ok := w.values[key]
+	return v, ok
+}
+
+// Int returns the value of the setting with the given key as an integer, def
+// if the setting is not set or is not an integer.
+func (w *Watcher) Int(key string, def int) int {
+	if n, err := strconv.Atoi(w.Get(key)); err == nil {
+		return n
+	}
+	return def
+}
+
+// Bool returns the value of the setting with the given key as a boolean, def
+// if the setting is not set or is not a boolean.
+func (w *Watcher) Bool(key string, def bool) bool {
+	if b, err := strconv.ParseBool(w.Get(key)); err == nil {
+		return b
+	}
+	return def
+}
+
+// Duration returns the value of the setting with the given key as a duration,
+// def if the setting is not set or is not a duration.
+func (w *Watcher) Duration(key string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(w.Get(key)); err == nil {
+		return d
+	}
+	return def
+}
+
+// Subscribe registers a listener notified each time a reload changes the
+// configuration.
+func (w *Watcher) Subscribe(l Listener) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.listeners = append(w.listeners, l)
+}
+
+// Reload loads the configuration from the sources and notifies the listeners
+// if any setting changed. The current configuration is left untouched if a
+// source fails to load.
+func (w *Watcher) Reload() error {
+	values := make(map[string]string)
+	for _, s := range w.sources {
+		vals, err := s.Load()
+		if err != nil {
+			return err
+		}
+		for k, v := range vals {
+			values[k] = v
+		}
+	}
+	w.mu.Lock()
+	changes := make(map[string]string)
+	for k, v := range values {
+		if old, ok := w.values[k]; !ok || old != v {
+			changes[k] = v
+		}
+	}
+	for k := range w.values {
+		if _, ok := values[k]; !ok {
+			changes[k] = ""
+		}
+	}
+	w.values = values
+	listeners := w.listeners
+	w.mu.Unlock()
+	if len(changes) == 0 {
+		return nil
+	}
+	for _, l := range listeners {
+		l(changes)
+	}
+	return nil
+}
+
+// Run reloads the configuration each time the process receives SIGHUP and
+// every interval if interval is greater than zero. Run blocks until ctx is
+// canceled. Reload errors are reported to OnError if set.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	defer signal.Stop(sig)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+		case <-tick:
+		}
+		if err := w.Reload(); err != nil && w.OnError != nil {
+			w.OnError(err)
+		}
+	}
+}
+
+// Load reads the settings from the file.
+func (f fileSource) Load() (map[string]string, error) {
+	path := string(f)
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	defer file.Close()
+	values := make(map[string]string)
+	if filepath.Ext(path) == ".json" {
+		var raw map[string]interface{}
+		if err := json.NewDecoder(file).Decode(&raw); err != nil {
+			return nil, fmt.Errorf("failed to load configuration from %s: %w", path, err)
+		}
+		for k, v := range raw {
+			switch v.(type) {
+			case string, float64, bool:
+				values[k] = fmt.Sprint(v)
+			default:
+				return nil, fmt.Errorf("failed to load configuration from %s: value of %q must be a string, a number or a boolean", path, k)
+			}
+		}
+		return values, nil
+	}
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		elems := strings.SplitN(line, "=", 2)
+		if len(elems) != 2 {
+			return nil, fmt.Errorf("failed to load configuration from %s: line %d: missing '='", path, n)
+		}
+		values[strings.TrimSpace(elems[0])] = strings.TrimSpace(elems[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load configuration from %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// Load reads the settings from the environment.
+func (e envSource) Load() (map[string]string, error) {
+	prefix := string(e)
+	values := make(map[string]string)
+	for _, kv := range os.Environ() {
+		elems := strings.SplitN(kv, "=", 2)
+		if len(elems) != 2 || !strings.HasPrefix(elems[0], prefix) {
+			continue
+		}
+		values[strings.ToLower(strings.TrimPrefix(elems[0], prefix))] = elems[1]
+	}
+	return values, nil
+}
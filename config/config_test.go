@@ -0,0 +1,112 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWatcherReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.env")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("# settings\nlog_level = info\nrate_limit=10\n\nflag_beta=true\n")
+	t.Setenv("CONFIGTEST_RATE_LIMIT", "20")
+
+	w := NewWatcher(File(path), Env("CONFIGTEST_"))
+	var got []map[string]string
+	w.Subscribe(func(changes map[string]string) { got = append(got, changes) })
+
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if w.Get("log_level") != "info" || w.Int("rate_limit", 0) != 20 || !w.Bool("flag_beta", false) {
+		t.Errorf("invalid configuration %v", w.values)
+	}
+	if w.Duration("timeout", time.Second) != time.Second {
+		t.Error("expected default duration")
+	}
+
+	write("log_level=debug\nrate_limit=10\n")
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]string{
+		{"log_level": "info", "rate_limit": "20", "flag_beta": "true"},
+		{"log_level": "debug", "flag_beta": ""},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got changes %v, expected %v", got, expected)
+	}
+	if _, ok := w.Lookup("flag_beta"); ok {
+		t.Error("removed setting still set")
+	}
+
+	write("invalid")
+	if err := w.Reload(); err == nil {
+		t.Error("expected error for invalid file")
+	}
+	if w.Get("log_level") != "debug" {
+		t.Error("configuration changed after failed reload")
+	}
+}
+
+func TestFileJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"log_level": "warn", "rate_limit": 5, "beta": false}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	vals, err := File(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"log_level": "warn", "rate_limit": "5", "beta": "false"}
+	if !reflect.DeepEqual(vals, expected) {
+		t.Errorf("got %v, expected %v", vals, expected)
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	var (
+		n    int
+		errc = make(chan error, 1)
+	)
+	w := NewWatcher(SourceFunc(func() (map[string]string, error) {
+		n++
+		if n == 2 {
+			return nil, errors.New("boom")
+		}
+		return map[string]string{"n": "x"}, nil
+	}))
+	w.OnError = func(err error) {
+		select {
+		case errc <- err:
+		default:
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	go w.Run(ctx, time.Millisecond)
+	select {
+	case err := <-errc:
+		if err.Error() != "boom" {
+			t.Errorf("got error %q, expected %q", err, "boom")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for reload")
+	}
+}
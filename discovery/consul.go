@@ -0,0 +1,150 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Consul is a registry and resolver backed by the HTTP API of a Consul
+	// agent.
+	Consul struct {
+		// URL is the Consul agent URL, e.g. "http://localhost:8500".
+		URL string
+		// Token is the Consul ACL token if any.
+		Token string
+		// Client is the HTTP client used to make requests to the Consul
+		// agent.
+		Client *http.Client
+	}
+
+	// consulService is the body of Consul service registration requests.
+	consulService struct {
+		ID      string            `json:"ID"`
+		Name    string            `json:"Name"`
+		Tags    []string          `json:"Tags,omitempty"`
+		Address string            `json:"Address,omitempty"`
+		Port    int               `json:"Port,omitempty"`
+		Meta    map[string]string `json:"Meta,omitempty"`
+		Check   *consulCheck      `json:"Check,omitempty"`
+	}
+
+	// consulCheck is a Consul HTTP health check.
+	consulCheck struct {
+		HTTP                           string `json:"HTTP"`
+		Interval                       string `json:"Interval"`
+		DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter"`
+	}
+
+	// consulEntry is an element of the Consul health service response.
+	consulEntry struct {
+		Node struct {
+			Address string `json:"Address"`
+		} `json:"Node"`
+		Service struct {
+			Address string `json:"Address"`
+			Port    int    `json:"Port"`
+		} `json:"Service"`
+	}
+)
+
+// NewConsul returns a registry and resolver that uses the Consul agent at the
+// given URL.
+func NewConsul(url string) *Consul {
+	return &Consul{URL: strings.TrimSuffix(url, "/"), Client: http.DefaultClient}
+}
+
+// Register registers the service instance with the Consul agent. The instance
+// health endpoint if any is registered as a HTTP check and the instance is
+// deregistered if the check remains critical for one minute.
+func (c *Consul) Register(ctx context.Context, r *Registration) error {
+	svc := &consulService{
+		ID:      r.ID,
+		Name:    r.Name,
+		Tags:    r.Tags,
+		Address: r.Address,
+		Port:    r.Port,
+		Meta:    r.Meta,
+	}
+	if r.HealthURL != "" {
+		interval := r.HealthInterval
+		if interval == 0 {
+			interval = defaultHealthInterval
+		}
+		svc.Check = &consulCheck{
+			HTTP:                           r.HealthURL,
+			Interval:                       interval.String(),
+			DeregisterCriticalServiceAfter: "1m",
+		}
+	}
+	return c.do(ctx, http.MethodPut, "/v1/agent/service/register", svc, nil)
+}
+
+// Deregister removes the service instance from the Consul agent.
+func (c *Consul) Deregister(ctx context.Context, r *Registration) error {
+	return c.do(ctx, http.MethodPut, "/v1/agent/service/deregister/"+url.PathEscape(r.ID), nil, nil)
+}
+
+// Resolve returns the addresses of the instances of the service whose health
+// checks are passing.
+func (c *Consul) Resolve(ctx context.Context, name string) ([]string, error) {
+	var entries []*consulEntry
+	if err := c.do(ctx, http.MethodGet, "/v1/health/service/"+url.PathEscape(name)+"?passing=true", nil, &entries); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNotFound
+	}
+	addrs := make([]string, len(entries))
+	for i, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		addrs[i] = hostPort(host, e.Service.Port)
+	}
+	return addrs, nil
+}
+
+// do sends a request to the Consul agent and decodes the response body into
+// res if not nil.
+func (c *Consul) do(ctx context.Context, method, path string, body, res interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.URL+path, r)
+	if err != nil {
+		return err
+	}
+	if c.Token != "" {
+		req.Header.Set("X-Consul-Token", c.Token)
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("consul: %s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if res == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
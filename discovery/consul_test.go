@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConsul(t *testing.T) {
+	var (
+		registered   consulService
+		deregistered string
+		token        string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Consul-Token")
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/agent/service/register":
+			json.NewDecoder(r.Body).Decode(&registered) // nolint: errcheck
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/agent/service/deregister/calc-1":
+			deregistered = "calc-1"
+		case r.URL.Path == "/v1/health/service/calc" && r.URL.Query().Get("passing") == "true":
+			w.Write([]byte(`[{"Node":{"Address":"10.0.0.1"},"Service":{"Address":"","Port":8000}},{"Node":{"Address":"10.0.0.1"},"Service":{"Address":"10.0.0.2","Port":8001}}]`)) // nolint: errcheck
+		case r.URL.Path == "/v1/health/service/unknown":
+			w.Write([]byte(`[]`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c := NewConsul(srv.URL + "/")
+	c.Token = "secret"
+	ctx := context.Background()
+
+	r := &Registration{
+		ID:             "calc-1",
+		Name:           "calc",
+		Address:        "10.0.0.1",
+		Port:           8000,
+		Tags:           []string{"v1"},
+		HealthURL:      "http://10.0.0.1:8000/health",
+		HealthInterval: 5 * time.Second,
+	}
+	if err := c.Register(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	expected := consulService{
+		ID:      "calc-1",
+		Name:    "calc",
+		Tags:    []string{"v1"},
+		Address: "10.0.0.1",
+		Port:    8000,
+		Check:   &consulCheck{HTTP: "http://10.0.0.1:8000/health", Interval: "5s", DeregisterCriticalServiceAfter: "1m"},
+	}
+	if !reflect.DeepEqual(registered, expected) {
+		t.Errorf("got registration %+v, expected %+v", registered, expected)
+	}
+	if token != "secret" {
+		t.Errorf("got token %q, expected %q", token, "secret")
+	}
+
+	addrs, err := c.Resolve(ctx, "calc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(addrs, []string{"10.0.0.1:8000", "10.0.0.2:8001"}) {
+		t.Errorf("got addresses %v", addrs)
+	}
+	if _, err := c.Resolve(ctx, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, expected ErrNotFound", err)
+	}
+
+	if err := c.Deregister(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	if deregistered != "calc-1" {
+		t.Error("service not deregistered")
+	}
+	if err := c.Deregister(ctx, &Registration{ID: "other"}); err == nil {
+		t.Error("expected error for unknown service")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Package discovery registers services with service discovery systems such as
+Consul or etcd and resolves service names into instance addresses.
+
+Servers register on start and deregister on stop:
+
+    reg := &discovery.Registration{
+        Name:      calc.ServiceName,
+        Address:   "10.0.0.12",
+        Port:      8000,
+        Tags:      calc.DiscoveryTags,
+        HealthURL: "http://10.0.0.12:8000" + server.HealthCalcPath(),
+    }
+    deregister, err := discovery.Register(ctx, discovery.NewConsul("http://localhost:8500"), reg)
+    if err != nil {
+        return err
+    }
+    defer deregister(context.Background())
+
+Clients target the service name instead of a host by dialing through a
+resolver:
+
+    dialer := discovery.DialContext(discovery.NewConsul("http://localhost:8500"), nil)
+    doer := &http.Client{Transport: &http.Transport{DialContext: dialer}}
+    c := calcc.NewClient("http", "calc", doer, enc, dec, false)
+
+The same dialer may be used by gRPC clients:
+
+    conn, err := grpc.Dial("calc", grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+        return dialer(ctx, "tcp", addr)
+    }))
+*/
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Registration describes a service instance registered with a service
+	// discovery system.
+	Registration struct {
+		// ID uniquely identifies the service instance. Register sets it to
+		// "<name>-<address>-<port>" if empty.
+		ID string
+		// Name is the service name clients resolve.
+		Name string
+		// Address is the instance host or IP address.
+		Address string
+		// Port is the instance port.
+		Port int
+		// Tags lists the instance tags.
+		Tags []string
+		// Meta contains arbitrary instance metadata.
+		Meta map[string]string
+		// HealthURL is the URL of the instance health endpoint if any.
+		// The service discovery system only returns instances whose
+		// health endpoint responds with a 2xx status code.
+		HealthURL string
+		// HealthInterval is the interval between health checks. Defaults
+		// to 10 seconds.
+		HealthInterval time.Duration
+	}
+
+	// Registry registers and deregisters service instances.
+	Registry interface {
+		// Register registers the service instance.
+		Register(ctx context.Context, r *Registration) error
+		// Deregister removes the service instance.
+		Deregister(ctx context.Context, r *Registration) error
+	}
+
+	// Resolver resolves service names into the addresses of the healthy
+	// service instances.
+	Resolver interface {
+		// Resolve returns the "host:port" addresses of the instances of
+		// the service with the given name. Resolve returns ErrNotFound if
+		// there is no healthy instance.
+		Resolve(ctx context.Context, name string) ([]string, error)
+	}
+)
+
+// ErrNotFound is the error returned by resolvers when there is no healthy
+// instance for a service name.
+var ErrNotFound = errors.New("service not found")
+
+// defaultHealthInterval is the default interval between health checks.
+const defaultHealthInterval = 10 * time.Second
+
+// Register registers the service instance with the registry and returns a
+// function that deregisters it. Register is meant to be called on server start
+// and the returned function on server stop.
+func Register(ctx context.Context, reg Registry, r *Registration) (func(context.Context) error, error) {
+	if r.Name == "" {
+		return nil, errors.New("service registration must define a name")
+	}
+	if r.ID == "" {
+		r.ID = fmt.Sprintf("%s-%s-%d", r.Name, r.Address, r.Port)
+	}
+	if err := reg.Register(ctx, r); err != nil {
+		return nil, fmt.Errorf("failed to register service %q: %w", r.Name, err)
+	}
+	return func(ctx context.Context) error {
+		return reg.Deregister(ctx, r)
+	}, nil
+}
+
+// DialContext returns a dial function suitable for use with http.Transport
+// that resolves the host of the dialed address using the resolver and
+// connects to one of the returned instances. Instances are tried in round
+// robin order, the port of the dialed address is ignored. Addresses whose host
+// is not a known service name (the resolver returns ErrNotFound) are dialed
+// as is. dialer is used to establish the connections, a default net.Dialer is
+// used if nil.
+func DialContext(res Resolver, dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dialer == nil {
+		dialer = &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	}
+	var next uint64
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		addrs, err := res.Resolve(ctx, host)
+		if errors.Is(err, ErrNotFound) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve service %q: %w", host, err)
+		}
+		start := atomic.AddUint64(&next, 1)
+		var lastErr error
+		for i := range addrs {
+			a := addrs[(start+uint64(i))%uint64(len(addrs))]
+			conn, err := dialer.DialContext(ctx, network, a)
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
+
+// hostPort returns the "host:port" address of the instance.
+func hostPort(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
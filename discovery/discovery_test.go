@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+type (
+	fakeRegistry struct {
+		registered   *Registration
+		deregistered *Registration
+	}
+
+	fakeResolver map[string][]string
+)
+
+func (r *fakeRegistry) Register(_ context.Context, reg *Registration) error {
+	r.registered = reg
+	return nil
+}
+
+func (r *fakeRegistry) Deregister(_ context.Context, reg *Registration) error {
+	r.deregistered = reg
+	return nil
+}
+
+func (r fakeResolver) Resolve(_ context.Context, name string) ([]string, error) {
+	addrs, ok := r[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return addrs, nil
+}
+
+func TestRegister(t *testing.T) {
+	var reg fakeRegistry
+	if _, err := Register(context.Background(), &reg, &Registration{}); err == nil {
+		t.Error("expected error for registration without name")
+	}
+	r := &Registration{Name: "calc", Address: "10.0.0.1", Port: 8000}
+	deregister, err := Register(context.Background(), &reg, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reg.registered != r {
+		t.Fatal("service not registered")
+	}
+	if r.ID != "calc-10.0.0.1-8000" {
+		t.Errorf("got ID %q, expected %q", r.ID, "calc-10.0.0.1-8000")
+	}
+	if err := deregister(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if reg.deregistered != r {
+		t.Error("service not deregistered")
+	}
+}
+
+func TestDialContext(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			c.Close()
+		}
+	}()
+	dial := DialContext(fakeResolver{"calc": {l.Addr().String()}, "down": {}}, nil)
+
+	conn, err := dial(context.Background(), "tcp", "calc:80")
+	if err != nil {
+		t.Fatalf("resolved dial: %s", err)
+	}
+	if conn.RemoteAddr().String() != l.Addr().String() {
+		t.Errorf("got remote address %q, expected %q", conn.RemoteAddr(), l.Addr())
+	}
+	conn.Close()
+
+	conn, err = dial(context.Background(), "tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("direct dial: %s", err)
+	}
+	conn.Close()
+
+	if _, err := dial(context.Background(), "tcp", "down:80"); err != nil && errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected not found error: %s", err)
+	}
+}
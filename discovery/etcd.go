@@ -0,0 +1,230 @@
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Etcd is a registry and resolver backed by the gRPC gateway of an etcd
+	// v3 cluster. Instances are stored under "<Prefix><name>/<id>" with a
+	// lease that is kept alive while the instance is registered and healthy.
+	Etcd struct {
+		// URL is the etcd endpoint URL, e.g. "http://localhost:2379".
+		URL string
+		// Prefix is the prefix of the instance keys, defaults to
+		// "/services/".
+		Prefix string
+		// TTL is the time to live of the instance leases. Instances are
+		// removed if the lease is not renewed within TTL. Defaults to 30
+		// seconds.
+		TTL time.Duration
+		// Client is the HTTP client used to make requests to etcd.
+		Client *http.Client
+
+		mu      sync.Mutex
+		leases  map[string]string
+		cancels map[string]context.CancelFunc
+	}
+
+	// etcdInstance is the value stored for each instance.
+	etcdInstance struct {
+		Address string            `json:"address"`
+		Tags    []string          `json:"tags,omitempty"`
+		Meta    map[string]string `json:"meta,omitempty"`
+	}
+
+	// etcdLease is the etcd lease grant response.
+	etcdLease struct {
+		ID string `json:"ID"`
+	}
+
+	// etcdRange is the etcd range response.
+	etcdRange struct {
+		KVs []struct {
+			Value string `json:"value"`
+		} `json:"kvs"`
+	}
+)
+
+// NewEtcd returns a registry and resolver that uses the etcd endpoint at the
+// given URL.
+func NewEtcd(url string) *Etcd {
+	return &Etcd{URL: strings.TrimSuffix(url, "/"), Client: http.DefaultClient}
+}
+
+// Register stores the service instance and starts renewing its lease. If the
+// registration defines a health endpoint the lease is renewed only while the
+// endpoint responds with a 2xx status code.
+func (e *Etcd) Register(ctx context.Context, r *Registration) error {
+	var lease etcdLease
+	ttl := int64(e.ttl() / time.Second)
+	if err := e.do(ctx, "/v3/lease/grant", map[string]interface{}{"TTL": ttl}, &lease); err != nil {
+		return err
+	}
+	val, err := json.Marshal(&etcdInstance{Address: hostPort(r.Address, r.Port), Tags: r.Tags, Meta: r.Meta})
+	if err != nil {
+		return err
+	}
+	put := map[string]interface{}{
+		"key":   base64.StdEncoding.EncodeToString([]byte(e.key(r.Name) + r.ID)),
+		"value": base64.StdEncoding.EncodeToString(val),
+		"lease": lease.ID,
+	}
+	if err := e.do(ctx, "/v3/kv/put", put, nil); err != nil {
+		return err
+	}
+	kctx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	if e.leases == nil {
+		e.leases = make(map[string]string)
+		e.cancels = make(map[string]context.CancelFunc)
+	}
+	e.leases[r.ID] = lease.ID
+	e.cancels[r.ID] = cancel
+	e.mu.Unlock()
+	go e.keepAlive(kctx, r, lease.ID)
+	return nil
+}
+
+// Deregister stops renewing the instance lease and revokes it which deletes
+// the instance key.
+func (e *Etcd) Deregister(ctx context.Context, r *Registration) error {
+	e.mu.Lock()
+	id, ok := e.leases[r.ID]
+	if ok {
+		e.cancels[r.ID]()
+		delete(e.leases, r.ID)
+		delete(e.cancels, r.ID)
+	}
+	e.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("etcd: service instance %q is not registered", r.ID)
+	}
+	return e.do(ctx, "/v3/lease/revoke", map[string]interface{}{"ID": id}, nil)
+}
+
+// Resolve returns the addresses of the instances of the service.
+func (e *Etcd) Resolve(ctx context.Context, name string) ([]string, error) {
+	prefix := e.key(name)
+	end := []byte(prefix)
+	end[len(end)-1]++
+	req := map[string]interface{}{
+		"key":       base64.StdEncoding.EncodeToString([]byte(prefix)),
+		"range_end": base64.StdEncoding.EncodeToString(end),
+	}
+	var res etcdRange
+	if err := e.do(ctx, "/v3/kv/range", req, &res); err != nil {
+		return nil, err
+	}
+	var addrs []string
+	for _, kv := range res.KVs {
+		b, err := base64.StdEncoding.DecodeString(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		var inst etcdInstance
+		if err := json.Unmarshal(b, &inst); err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, inst.Address)
+	}
+	if len(addrs) == 0 {
+		return nil, ErrNotFound
+	}
+	return addrs, nil
+}
+
+// keepAlive renews the lease with the given ID every third of the TTL until
+// ctx is canceled.
+func (e *Etcd) keepAlive(ctx context.Context, r *Registration, id string) {
+	ticker := time.NewTicker(e.ttl() / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.HealthURL != "" && !e.healthy(ctx, r.HealthURL) {
+				continue
+			}
+			e.do(ctx, "/v3/lease/keepalive", map[string]interface{}{"ID": id}, nil) // nolint: errcheck
+		}
+	}
+}
+
+// healthy returns true if the health endpoint at the given URL responds with
+// a 2xx status code.
+func (e *Etcd) healthy(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := e.client().Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// key returns the prefix of the keys of the instances of the service with the
+// given name.
+func (e *Etcd) key(name string) string {
+	prefix := e.Prefix
+	if prefix == "" {
+		prefix = "/services/"
+	}
+	return prefix + name + "/"
+}
+
+// ttl returns the lease time to live.
+func (e *Etcd) ttl() time.Duration {
+	if e.TTL < time.Second {
+		return 30 * time.Second
+	}
+	return e.TTL
+}
+
+// client returns the HTTP client used to make requests.
+func (e *Etcd) client() *http.Client {
+	if e.Client == nil {
+		return http.DefaultClient
+	}
+	return e.Client
+}
+
+// do sends a request to the etcd gateway and decodes the response body into
+// res if not nil.
+func (e *Etcd) do(ctx context.Context, path string, body, res interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("etcd: %s: %s: %s", path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if res == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEtcd(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		kvs     = make(map[string]string)
+		leases  = make(map[string]string)
+		revoked []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body) // nolint: errcheck
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/v3/lease/grant":
+			w.Write([]byte(`{"ID":"42","TTL":"30"}`)) // nolint: errcheck
+		case "/v3/kv/put":
+			key, _ := base64.StdEncoding.DecodeString(body["key"].(string))
+			kvs[string(key)] = body["value"].(string)
+			leases[string(key)] = body["lease"].(string)
+			w.Write([]byte(`{}`)) // nolint: errcheck
+		case "/v3/kv/range":
+			start, _ := base64.StdEncoding.DecodeString(body["key"].(string))
+			end, _ := base64.StdEncoding.DecodeString(body["range_end"].(string))
+			var vals []string
+			for k, v := range kvs {
+				if k >= string(start) && k < string(end) {
+					vals = append(vals, `{"value":"`+v+`"}`)
+				}
+			}
+			w.Write([]byte(`{"kvs":[` + strings.Join(vals, ",") + `]}`)) // nolint: errcheck
+		case "/v3/lease/revoke":
+			id := body["ID"].(string)
+			revoked = append(revoked, id)
+			for k, l := range leases {
+				if l == id {
+					delete(kvs, k)
+				}
+			}
+			w.Write([]byte(`{}`)) // nolint: errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	e := NewEtcd(srv.URL)
+	ctx := context.Background()
+
+	r := &Registration{ID: "calc-1", Name: "calc", Address: "10.0.0.1", Port: 8000}
+	if err := e.Register(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	if leases["/services/calc/calc-1"] != "42" {
+		t.Errorf("instance key not stored with lease, got %v", leases)
+	}
+	mu.Unlock()
+
+	addrs, err := e.Resolve(ctx, "calc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(addrs, []string{"10.0.0.1:8000"}) {
+		t.Errorf("got addresses %v", addrs)
+	}
+	if _, err := e.Resolve(ctx, "cal"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, expected ErrNotFound", err)
+	}
+
+	if err := e.Deregister(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(revoked, []string{"42"}) {
+		t.Errorf("got revoked leases %v", revoked)
+	}
+	if _, err := e.Resolve(ctx, "calc"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v after deregistration, expected ErrNotFound", err)
+	}
+	if err := e.Deregister(ctx, r); err == nil {
+		t.Error("expected error for unregistered instance")
+	}
+}
@@ -1,12 +1,21 @@
 package dsl
 
 import (
+	"mime"
+	"strings"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 )
 
 // API defines a network service API. It provides the API name, description and other global
-// properties. There may only be one API declaration in a given design package.
+// properties.
+//
+// A design may define multiple APIs, for example a public API and an admin
+// API sharing the same types. Each API may list the services it exposes with
+// Services, APIs that do not use Services expose all the services. The code is
+// generated for one API at a time, the API is selected with the --api flag of
+// the goa tool and defaults to the first API defined in the design.
 //
 // API is a top level DSL. API takes two arguments: the name of the API and the
 // defining DSL.
@@ -42,6 +51,10 @@ import (
 //        })
 //    }
 //
+//    var _ = API("admin", func() {
+//        Services("users", "audit")    // Services exposed by the API
+//    })
+//
 func API(name string, fn func()) *expr.APIExpr {
 	if name == "" {
 		eval.ReportError("API first argument cannot be empty")
@@ -51,8 +64,16 @@ func API(name string, fn func()) *expr.APIExpr {
 		eval.IncompatibleDSL()
 		return nil
 	}
-	expr.Root.API = expr.NewAPIExpr(name, fn)
-	return expr.Root.API
+	if expr.Root.APIByName(name) != nil {
+		eval.ReportError("API %q defined twice", name)
+		return nil
+	}
+	api := expr.NewAPIExpr(name, fn)
+	expr.Root.APIs = append(expr.Root.APIs, api)
+	if len(expr.Root.APIs) == 1 {
+		expr.Root.API = api
+	}
+	return api
 }
 
 // Title sets the API title. It is used by the generated OpenAPI specification.
@@ -95,6 +116,46 @@ func Version(ver string) {
 	eval.IncompatibleDSL()
 }
 
+// DefaultMediaTypePrefix sets the prefix of the result type identifiers that
+// do not define a media type. The identifier of such result types is the
+// prefix followed by a dot and the identifier given to ResultType, the
+// identifier parameters are preserved so that collections and versioned
+// identifiers compose as expected. The name of the generated type is derived
+// from the identifier given to ResultType.
+//
+// DefaultMediaTypePrefix must appear in a API expression.
+//
+// DefaultMediaTypePrefix accepts a single string argument: a media type
+// without parameters.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        DefaultMediaTypePrefix("application/vnd.acme")
+//    })
+//
+//    // Identifier is "application/vnd.acme.bottle", type name is "Bottle"
+//    var Bottle = ResultType("bottle", func() {
+//        Attribute("name", String)
+//    })
+//
+//    // Identifier is "application/vnd.acme.bottle; type=collection"
+//    var Bottles = CollectionOf(Bottle)
+//
+func DefaultMediaTypePrefix(prefix string) {
+	s, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	base, params, err := mime.ParseMediaType(prefix)
+	if err != nil || !strings.Contains(base, "/") || len(params) > 0 {
+		eval.ReportError("invalid default media type prefix %q, must be a media type without parameters", prefix)
+		return
+	}
+	s.MediaTypePrefix = base
+}
+
 // Contact sets the API contact information.
 //
 // Contact must appear in a API expression.
@@ -168,6 +168,13 @@ func Attribute(name string, args ...interface{}) {
 	}
 
 	if obj, ok := parent.Type.(*expr.Object); ok {
+		if _, ok := attr.Meta["struct:type:name"]; ok {
+			tn, _ := attr.Meta.Last("struct:type:name")
+			namedInlineObject(name, attr, tn)
+		}
+		if tn, ok := attr.Meta.Last("enum:type"); ok {
+			namedEnum(name, attr, tn)
+		}
 		obj.Set(name, attr)
 		return
 	}
@@ -179,6 +186,46 @@ func Attribute(name string, args ...interface{}) {
 	union.Values = append(union.Values, &expr.NamedAttributeExpr{Name: name, Attribute: attr})
 }
 
+// namedInlineObject turns the inline object type of attr into a user type with
+// the given name so that the generated code defines a named struct for it.
+func namedInlineObject(name string, attr *expr.AttributeExpr, typeName string) {
+	if _, ok := attr.Type.(*expr.Object); !ok {
+		eval.ReportError("attribute %#v: struct:type:name meta requires an inline object type", name)
+		return
+	}
+	if typeName == "" {
+		eval.ReportError("attribute %#v: struct:type:name meta requires a type name", name)
+		return
+	}
+	att := expr.DupAtt(attr)
+	delete(att.Meta, "struct:type:name")
+	attr.Type = &expr.UserTypeExpr{AttributeExpr: att, TypeName: typeName}
+	attr.Validation = nil
+}
+
+// namedEnum turns the type of attr into a user type with the given name so that
+// the generated code defines a named Go type with one constant per enum value.
+func namedEnum(name string, attr *expr.AttributeExpr, typeName string) {
+	switch attr.Type {
+	case expr.String, expr.Int, expr.Int32, expr.Int64, expr.UInt, expr.UInt32, expr.UInt64:
+	default:
+		eval.ReportError("attribute %#v: enum:type meta requires a String or integer attribute", name)
+		return
+	}
+	if attr.Validation == nil || len(attr.Validation.Values) == 0 {
+		eval.ReportError("attribute %#v: enum:type meta requires an Enum validation", name)
+		return
+	}
+	if typeName == "" {
+		eval.ReportError("attribute %#v: enum:type meta requires a type name", name)
+		return
+	}
+	att := expr.DupAtt(attr)
+	attr.Type = &expr.UserTypeExpr{AttributeExpr: att, TypeName: typeName}
+	attr.Validation = nil
+	delete(attr.Meta, "enum:type")
+}
+
 // Field is syntactic sugar to define an attribute that defines a tag, e.g. for
 // protobuf.  The result is the same as calling Attribute with the "rpc:tag"
 // meta set with the value of the first argument.
@@ -241,9 +288,59 @@ func OneOf(name string, args ...interface{}) {
 	Attribute(name, &expr.Union{TypeName: name}, desc, fn)
 }
 
+// Discriminator makes HTTP transports serialize the values of the enclosing
+// union inline: the value fields are written directly in the JSON object
+// together with the discriminator attribute which holds the name of the value
+// type. This makes it possible for collections of unions to contain
+// heterogeneous elements that clients decode by looking at the discriminator.
+// The generated OpenAPI specification describes the union with a
+// "discriminator" mapping.
+//
+// Discriminator must appear in a OneOf DSL. All the union types must be
+// objects and attributes with identical names must have identical types.
+//
+// Discriminator takes the name of the discriminator attribute as argument.
+//
+// Example:
+//
+//    var Pets = ResultType("application/vnd.pets", func() {
+//        Attribute("pets", ArrayOf(Pet))
+//    })
+//
+//    var Pet = Type("Pet", func() {
+//        OneOf("pet", func() {
+//            Discriminator("kind")
+//            Attribute("cat", Cat)
+//            Attribute("dog", Dog)
+//        })
+//    })
+//
+// The HTTP response body then looks like:
+//
+//    {"pets": [{"pet": {"kind": "cat", "lives": 9}}, {"pet": {"kind": "dog", "barks": true}}]}
+//
+func Discriminator(name string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	u, ok := a.Type.(*expr.Union)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("Discriminator: name cannot be empty")
+		return
+	}
+	u.Discriminator = name
+}
+
 // Default sets the default value for an attribute.
 //
-// Default must appear in an Attribute DSL.
+// Default must appear in an Attribute DSL. Default values cannot be set on
+// DateTime, Date, Duration, UUID and Decimal attributes.
 //
 // Default takes one parameter: the default value.
 func Default(def interface{}) {
@@ -252,6 +349,11 @@ func Default(def interface{}) {
 		eval.IncompatibleDSL()
 		return
 	}
+	switch a.Type {
+	case expr.DateTime, expr.Date, expr.Duration, expr.UUID, expr.Decimal:
+		eval.ReportError("default values are not supported for attributes of type %s", a.Type.Name())
+		return
+	}
 	if a.Type != nil && !a.Type.IsCompatible(def) {
 		eval.ReportError("default value %#v is incompatible with attribute of type %s",
 			def, expr.QualifiedTypeName(a.Type))
@@ -272,8 +374,14 @@ func Default(def interface{}) {
 // example is generated unless the "openapi:example" meta is set to "false".
 // See Meta.
 //
-// Example must appear in a Attributes, Attribute, Params, Param, Headers or
-// Header DSL.
+// Example must appear in a Attributes, Attribute, Params, Param, Headers,
+// Header, Method or Response DSL. Examples defined in a Response DSL describe the
+// response body and override the examples of the result type in the generated
+// OpenAPI specifications. Examples defined in a Method DSL describe complete
+// requests: the example values are payload values from which the examples of
+// the request body, parameters and headers are derived. They take precedence
+// over the payload attribute examples in the generated OpenAPI specifications
+// and client tool usage.
 //
 // Example takes one or two arguments: an optional summary and the example value
 // or defining DSL.
@@ -299,6 +407,21 @@ func Default(def interface{}) {
 //        })
 //    })
 //
+//    Response(StatusOK, func() {
+//        Example("A bottle", Val{"ID": 1, "Name": "Number 8"})
+//    })
+//
+//    Method("rate", func() {
+//        Payload(func() {
+//            Attribute("id", Int)
+//            Attribute("rating", Int)
+//        })
+//        Example("Five stars", Val{"id": 42, "rating": 5})
+//        HTTP(func() {
+//            PUT("/{id}/rating")
+//        })
+//    })
+//
 func Example(args ...interface{}) {
 	if len(args) == 0 {
 		eval.ReportError("not enough arguments")
@@ -324,8 +447,19 @@ func Example(args ...interface{}) {
 		}
 		arg = args[1]
 	}
-	a, ok := eval.Current().(*expr.AttributeExpr)
-	if !ok {
+	var (
+		a *expr.AttributeExpr
+		r *expr.HTTPResponseExpr
+		m *expr.MethodExpr
+	)
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		a = e
+	case *expr.HTTPResponseExpr:
+		r = e
+	case *expr.MethodExpr:
+		m = e
+	default:
 		eval.IncompatibleDSL()
 		return
 	}
@@ -339,6 +473,17 @@ func Example(args ...interface{}) {
 		eval.ReportError("example value is missing")
 		return
 	}
+	if r != nil {
+		r.Examples = append(r.Examples, ex)
+		return
+	}
+	if m != nil {
+		if v, ok := ex.Value.(Val); ok {
+			ex.Value = map[string]interface{}(v)
+		}
+		m.Examples = append(m.Examples, ex)
+		return
+	}
 	if a.Type != nil && !a.Type.IsCompatible(ex.Value) {
 		eval.ReportError("example value %#v is incompatible with attribute of type %s",
 			ex.Value, a.Type.Name())
@@ -347,6 +492,201 @@ func Example(args ...interface{}) {
 	a.UserExamples = append(a.UserExamples, ex)
 }
 
+// Sensitive marks the attribute as holding personally identifiable or secret
+// information. The generated HTTP response encoders omit optional sensitive
+// attributes from response bodies and render required sensitive attributes
+// with the zero value of their type unless the request context was created
+// with goahttp.Unmask or the view used to render the result sets the
+// "sensitive:unmask" metadata. Sensitive attributes are also listed in the
+// SensitiveFields variable generated in the service package so that logging
+// middlewares can redact them. Use Mask to render a placeholder value instead
+// of omitting the attribute.
+//
+// Sensitive is equivalent to Meta("sensitive") and must appear in an Attribute
+// DSL.
+//
+// Example:
+//
+//    var User = ResultType("application/vnd.user", func() {
+//        Attribute("name", String)
+//        Attribute("ssn", String, func() {
+//            Sensitive()
+//        })
+//        View("default", func() {
+//            Attribute("name")
+//            Attribute("ssn")
+//        })
+//        View("admin", func() {
+//            Attribute("name")
+//            Attribute("ssn")
+//            Meta("sensitive:unmask")
+//        })
+//    })
+//
+func Sensitive() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("sensitive")
+}
+
+// Mask marks the attribute as sensitive (see Sensitive) and sets the value
+// rendered in place of the actual value when the attribute is masked. Mask only
+// applies to string attributes.
+//
+// Mask must appear in an Attribute DSL.
+//
+// Mask takes one argument: the placeholder value.
+//
+// Example:
+//
+//    Attribute("card_number", String, func() {
+//        Mask("****")
+//    })
+//
+func Mask(placeholder string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Type != nil && a.Type.Kind() != expr.StringKind {
+		eval.ReportError("Mask can only be used on string attributes, attribute is of type %s", a.Type.Name())
+		return
+	}
+	a.AddMeta("sensitive")
+	a.Meta["sensitive:mask"] = []string{placeholder}
+}
+
+// Computed defines an attribute whose value is derived from other attributes
+// of the same type. Computed attributes are generated like other attributes
+// and are documented as read-only in the OpenAPI specifications. The service
+// package defines a hook function variable for each computed attribute and a
+// Compute method on the enclosing type that sets the computed attributes using
+// the hooks. The generated endpoints call Compute on the method results so
+// that the service implementation only needs to set the hooks.
+//
+// Computed must appear in a Type or ResultType DSL.
+//
+// Computed takes the attribute name and type as first arguments followed by
+// the result of FromAttributes and optionally a DSL function to define the
+// attribute description, validations etc.
+//
+// Example:
+//
+//    var User = ResultType("application/vnd.user", func() {
+//        Attribute("first", String)
+//        Attribute("last", String)
+//        Computed("full_name", String, FromAttributes("first", "last"), func() {
+//            Description("Full name of user")
+//        })
+//    })
+//
+// The service implementation sets the generated hook:
+//
+//    user.ComputeUserFullName = func(u *user.User) *string {
+//        n := *u.First + " " + *u.Last
+//        return &n
+//    }
+//
+func Computed(name string, t expr.DataType, fns ...func()) {
+	Attribute(name, t, func() {
+		a := eval.Current().(*expr.AttributeExpr)
+		a.AddMeta("computed")
+		for _, fn := range fns {
+			fn()
+		}
+	})
+}
+
+// FromAttributes lists the attributes a computed attribute is derived from.
+// The result of FromAttributes must be given to Computed, see Computed.
+func FromAttributes(names ...string) func() {
+	return func() {
+		a, ok := eval.Current().(*expr.AttributeExpr)
+		if !ok {
+			eval.IncompatibleDSL()
+			return
+		}
+		a.AddMeta("computed:from", names...)
+	}
+}
+
+// WireName sets the name of the attribute on the wire when it differs from the
+// attribute name. The wire name is used by the generated HTTP body types to
+// encode and decode the attribute (JSON, XML and form tags), in validation error
+// messages and in the generated OpenAPI specifications. The Go struct field name
+// is still derived from the attribute name. WireName is equivalent to setting
+// the "wire:name" meta.
+//
+// WireName must appear in an Attribute DSL.
+//
+// WireName takes one argument: the name of the attribute on the wire.
+//
+// Example:
+//
+//    var Account = Type("Account", func() {
+//        Attribute("self_url", String, func() {
+//            WireName("selfURL")
+//        })
+//    })
+//
+func WireName(name string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["wire:name"] = []string{name}
+}
+
+// Nullable marks the attribute as accepting explicit null values so that the
+// service can tell attributes omitted from the request apart from attributes
+// explicitly set to null. The generated HTTP server code records the nullable
+// request body attributes present in JSON request bodies, the service method
+// calls goa.WasSet to retrieve that information. Nullable attributes are also
+// flagged as nullable in the generated OpenAPI 3 specification. Nullable is
+// equivalent to setting the "nullable" meta.
+//
+// Nullable must appear in an Attribute DSL. Nullable attributes cannot be
+// required.
+//
+// Example:
+//
+//    Method("update", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//            Attribute("nickname", String, func() {
+//                Nullable()
+//            })
+//            Required("id")
+//        })
+//        HTTP(func() {
+//            PATCH("/{id}")
+//        })
+//    })
+//
+// The service method can then clear the nickname if the client sends
+// {"nickname": null}:
+//
+//    if p.Nickname == nil && goa.WasSet(ctx, "nickname") {
+//        // clear nickname
+//    }
+//
+func Nullable() {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	a.AddMeta("nullable")
+}
+
 func parseAttributeArgs(baseAttr *expr.AttributeExpr, args ...interface{}) (expr.DataType, string, func()) {
 	var (
 		dataType    expr.DataType
@@ -0,0 +1,43 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Authorize adds an authorization hook to the method. The generated service
+// package defines a <Method>Authorizer interface embedded in the service
+// interface with a single Authorize<Method> function. The generated endpoint
+// calls the function once the payload has been decoded and validated and the
+// security schemes have been enforced but before calling the service method.
+// The function returns an error if the caller is not allowed to make the call,
+// for example because it does not own the object identified by the payload.
+// This makes it possible to implement object-level permission checks once and
+// have them enforced consistently across all transports.
+//
+// Authorize must appear in a Method expression.
+//
+// Authorize takes no argument.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Payload(func() {
+//            Attribute("id", String)
+//        })
+//        Result(Document)
+//        Authorize()
+//    })
+//
+// The service must then implement:
+//
+//    func (s *docsrvc) AuthorizeShow(ctx context.Context, p *docs.ShowPayload) error
+//
+func Authorize() {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.Authorize = true
+}
@@ -22,6 +22,8 @@ func Description(d string) {
 	switch e := eval.Current().(type) {
 	case *expr.APIExpr:
 		e.Description = d
+	case *expr.ProfileExpr:
+		e.Description = d
 	case *expr.ServerExpr:
 		e.Description = d
 	case *expr.HostExpr:
@@ -35,6 +35,19 @@ practice in general when writing standard Go code and Goa in particular makes no
 use of them outside of writing DSLs. However they DO make designs much easier to
 read and maintain).
 
+Large designs may be split across multiple Go packages, for example one
+package for the shared types and one package per group of services. The DSL
+functions register the expressions they define when the package variables are
+initialized so that packages only need to import the packages defining the
+types they use. Packages that are not imported by the main design package (for
+example packages that only define services) are given to the goa tool after the
+main design package:
+
+    goa gen goa.design/examples/cellar/design goa.design/examples/cellar/design/admin
+
+Types, result types and services must still have unique names across all
+packages.
+
 The general structure of the DSL is shown below (partial list):
 
     API                 Service          Type            ResultType
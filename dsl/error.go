@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"time"
+
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
 	pkg "goa.design/goa/v3/pkg"
@@ -231,11 +233,23 @@ func Temporary() {
 	attr.AddMeta("goa:error:temporary")
 }
 
-// Timeout qualifies an error type as describing errors due to timeouts.
+// Timeout qualifies an error type as describing errors due to timeouts when
+// used in an Error expression. Timeout sets the maximum duration of the
+// requests made to the method when used in a Method expression. The generated
+// service client cancels the request context once the timeout elapses in
+// which case the client method returns the context error. The generated HTTP
+// server sets the deadline of the context given to the service method to the
+// timeout or to the duration given by the request X-Request-Timeout header if
+// shorter and responds with a timeout error instead of encoding results
+// returned after the deadline. Timeout sets the maximum duration of the
+// forwarded requests when used in a Proxy expression.
 //
-// Timeout must appear in a Error expression.
+// Timeout must appear in a Error, a Method or a Proxy expression. Streaming
+// methods cannot define a timeout.
 //
-// Timeout takes no argument.
+// Timeout takes no argument when used in an Error expression and a single
+// argument when used in a Method or a Proxy expression: the duration in the
+// format accepted by time.ParseDuration.
 //
 // Example:
 //
@@ -243,14 +257,55 @@ func Temporary() {
 //        Error("request_timeout", func() {
 //            Timeout()
 //        })
+//
+//        Method("divide", func() {
+//            Payload(Operands)
+//            Result(Float64)
+//            Timeout("2s")
+//        })
 //    })
-func Timeout() {
-	attr, ok := eval.Current().(*expr.AttributeExpr)
-	if !ok {
+func Timeout(d ...string) {
+	switch e := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		if len(d) > 0 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		e.AddMeta("goa:error:timeout")
+	case *expr.MethodExpr:
+		if len(d) != 1 {
+			eval.ReportError("Timeout must be given the method timeout duration")
+			return
+		}
+		if timeout, ok := parseTimeout(d[0]); ok {
+			e.Timeout = timeout
+		}
+	case *expr.HTTPProxyExpr:
+		if len(d) != 1 {
+			eval.ReportError("Timeout must be given the proxy timeout duration")
+			return
+		}
+		if timeout, ok := parseTimeout(d[0]); ok {
+			e.Timeout = timeout
+		}
+	default:
 		eval.IncompatibleDSL()
-		return
 	}
-	attr.AddMeta("goa:error:timeout")
+}
+
+// parseTimeout parses the timeout duration d and reports an error if it is
+// invalid or not positive.
+func parseTimeout(d string) (time.Duration, bool) {
+	timeout, err := time.ParseDuration(d)
+	if err != nil {
+		eval.ReportError("invalid timeout %q: %s", d, err)
+		return 0, false
+	}
+	if timeout <= 0 {
+		eval.ReportError("timeout must be positive, got %q", d)
+		return 0, false
+	}
+	return timeout, true
 }
 
 // Fault qualifies an error type as describing errors due to a server-side
@@ -0,0 +1,50 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// FeatureFlag gates the method behind the feature flag with the given name.
+// Methods gated by a feature flag are generated like any other method but
+// calls made while the flag is off are rejected by the middleware.FeatureFlag
+// middleware with a "feature_disabled" error so that the method can be shipped
+// dark and enabled gradually. The HTTP transport maps the error to a 404 Not
+// Found response and the gRPC transport to a NotFound status.
+//
+// FeatureFlag must appear in a Method expression. FeatureFlag may be called
+// multiple times in which case all the flags must be on for the method to be
+// available. FeatureFlag sets the "feature:flag" metadata of the method which
+// is made available at runtime via the MethodMeta variable generated in the
+// service package.
+//
+// FeatureFlag takes a single argument which is the name of the flag.
+//
+// Example:
+//
+//    Method("checkout", func() {
+//        FeatureFlag("new-checkout")
+//        Payload(Cart)
+//        Result(Order)
+//    })
+//
+func FeatureFlag(name string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("feature flag name cannot be empty")
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = expr.MetaExpr{}
+	}
+	for _, f := range m.Meta["feature:flag"] {
+		if f == name {
+			return
+		}
+	}
+	m.Meta["feature:flag"] = append(m.Meta["feature:flag"], name)
+}
@@ -164,11 +164,23 @@ func HTTP(fns ...func()) {
 
 // Consumes adds a MIME type to the list of MIME types the APIs supports when
 // accepting requests. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the decoders for other MIME
-// types.
-//
-// Consumes must appear in the HTTP expression of API.
+// only knows to generate the code for "application/json", "application/xml",
+// "application/gob", "application/yaml" and "application/cbor" or the media
+// types with the "+yaml" or "+cbor" suffix, e.g. "application/vnd.reading+cbor".
+// The service code must provide the decoders for other MIME types.
+//
+// Consumes may appear in the HTTP expression of API, Service or Method. The
+// mime types listed in a method override the ones listed in the service which
+// override the ones listed in the API. When Consumes is used the generated
+// server code rejects requests whose body Content-Type is not listed with a
+// 415 Unsupported Media Type response before decoding the body, and the
+// OpenAPI specification lists the mime types accepted by each operation.
+// Endpoints that use MultipartRequest only accept the multipart mime types
+// listed in Consumes or "multipart/form-data" if there is none.
+//
+// Listing "application/x-ndjson" causes the generated server code to decode
+// collection request bodies sent with this content type as newline-delimited
+// JSON, one element per line, for example to implement bulk imports.
 //
 // Consumes accepts one or more strings corresponding to the MIME types.
 //
@@ -182,20 +194,182 @@ func HTTP(fns ...func()) {
 //        })
 //    })
 //
+//    Method("upload", func() {
+//        // ...
+//        HTTP(func() {
+//            POST("/")
+//            Consumes("application/json")
+//        })
+//    })
+//
 func Consumes(args ...string) {
 	switch e := eval.Current().(type) {
 	case *expr.RootExpr:
 		e.API.HTTP.Consumes = append(e.API.HTTP.Consumes, args...)
+	case *expr.HTTPServiceExpr:
+		e.Consumes = append(e.Consumes, args...)
+	case *expr.HTTPEndpointExpr:
+		e.Consumes = append(e.Consumes, args...)
 	default:
 		eval.IncompatibleDSL()
 	}
 }
 
+// StrictPayload makes the generated server code reject requests whose body
+// contains fields that are not defined in the design with a 400 Bad Request
+// response instead of silently ignoring them. This helps catching typos in
+// client requests.
+//
+// StrictPayload may appear in the HTTP expression of API, Service or Method.
+// The mode set in a method overrides the one set in the service which
+// overrides the one set in the API.
+//
+// StrictPayload accepts an optional mode, one of:
+//
+//    - "reject" (default) rejects requests with unknown fields.
+//    - "warn" accepts the requests and reports the unknown fields to the
+//      handler set with goahttp.WithUnknownFieldsHandler.
+//    - "off" disables strict decoding, e.g. for a method of a service that
+//      uses StrictPayload.
+//
+// Strict decoding is only supported by decoders that implement the
+// DisallowUnknownFields method such as the default JSON decoder.
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            StrictPayload()
+//        })
+//    })
+//
+//    Method("update", func() {
+//        HTTP(func() {
+//            PUT("/{id}")
+//            StrictPayload("warn")
+//        })
+//    })
+//
+func StrictPayload(mode ...string) {
+	m := "reject"
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+	switch m {
+	case "reject", "warn", "off":
+	default:
+		eval.ReportError("invalid strict payload mode %q, must be one of \"reject\", \"warn\" or \"off\"", m)
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *expr.RootExpr:
+		e.API.HTTP.StrictPayload = m
+	case *expr.HTTPServiceExpr:
+		e.StrictPayload = m
+	case *expr.HTTPEndpointExpr:
+		e.StrictPayload = m
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Batch adds an endpoint that accepts batches of requests to the API. The
+// body of a batch request is a JSON array of sub-requests, each sub-request
+// describes the HTTP method, path, headers and body of a request to one of the
+// API endpoints. The batch endpoint runs the sub-requests through the server
+// multiplexer and responds with the JSON array of the sub-responses, see
+// goahttp.BatchRequest and goahttp.BatchResponse. The generated example server
+// mounts the endpoint and goahttp.BatchClient makes it possible to send
+// batches of requests built with the generated client packages.
+//
+// Batch must appear in the API HTTP expression.
+//
+// Batch accepts an optional path which defaults to "/batch".
+//
+// Example:
+//
+//    API("cellar", func() {
+//        HTTP(func() {
+//            Batch("/v1/batch")
+//        })
+//    })
+//
+func Batch(path ...string) {
+	root, ok := eval.Current().(*expr.RootExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := "/batch"
+	if len(path) > 0 {
+		p = path[0]
+	}
+	if !strings.HasPrefix(p, "/") {
+		eval.ReportError("invalid batch path %q, path must start with /", p)
+		return
+	}
+	root.API.HTTP.BatchPath = p
+}
+
+// Envelope wraps all the HTTP response bodies in an object so that responses
+// are shaped consistently across the API. The envelope must define a "data"
+// attribute which holds the success response bodies. It may also define an
+// "errors" attribute which holds the error response bodies, error responses
+// are not wrapped otherwise. The values of the other envelope attributes are
+// set by the service methods with goahttp.SetEnvelopeField and are omitted
+// when not set. The types of the "data" and "errors" attributes are ignored,
+// the generated OpenAPI specifications use the response body schemas instead.
+//
+// Envelope must appear in the API HTTP expression.
+//
+// Envelope accepts a single argument: the function defining the envelope
+// attributes.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            Envelope(func() {
+//                Attribute("data")
+//                Attribute("meta", PageInfo)
+//                Attribute("errors")
+//            })
+//        })
+//    })
+//
+func Envelope(fn func()) {
+	r, ok := eval.Current().(*expr.RootExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	attr := &expr.AttributeExpr{}
+	if !eval.Execute(fn, attr) {
+		return
+	}
+	if attr.Find("data") == nil {
+		eval.ReportError("envelope must define a \"data\" attribute")
+		return
+	}
+	r.API.HTTP.Envelope = attr
+}
+
 // Produces adds a MIME type to the list of MIME types the APIs supports when
 // writing responses. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the encoders for other MIME
-// types.
+// only knows to generate the code for "application/json", "application/xml",
+// "application/gob", "application/yaml" and "application/cbor" or the media
+// types with the "+yaml" or "+cbor" suffix, e.g. "application/vnd.reading+cbor".
+// The service code must provide the encoders for other MIME types.
+//
+// Listing "text/csv" or
+// "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet" causes
+// the generated server code to render the collection response bodies as CSV or
+// Excel workbooks when requested by the client Accept header. See the
+// "csv:columns" and "csv:column" meta to select and name the columns.
+//
+// Listing "application/x-ndjson" causes the generated server code to write the
+// collection response bodies as newline-delimited JSON, one element per line,
+// when requested by the client Accept header.
 //
 // Produces must appear in the HTTP expression of API.
 //
@@ -222,8 +396,11 @@ func Produces(args ...string) {
 
 // Path defines an API or service base path, i.e. a common HTTP path prefix to
 // all the API or service methods. The path may define wildcards (see GET for a
-// description of the wildcard syntax). The corresponding parameters must be
-// described using Params. Multiple base paths may be defined for services.
+// description of the wildcard syntax). The corresponding parameters may be
+// described using Params, they default to String otherwise. Base path
+// parameters are added to the payload of all the API or service methods that
+// do not define them explicitly so that the parameters need not be repeated
+// in each method design. Multiple base paths may be defined for services.
 //
 // GET("/") does not add a trailing slash when the base path is defined by Path.
 // For example, when Path('foo') is defined, the path generated by GET("/") will be '/foo'.
@@ -233,6 +410,18 @@ func Produces(args ...string) {
 // Path must appear in a API HTTP expression or a Service HTTP expression.
 //
 // Path accepts one argument: the HTTP path prefix.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            Path("/orgs/{org}")
+//            Params(func() {
+//                Param("org", String, "Organization name")
+//            })
+//        })
+//    })
+//
 func Path(val string) {
 	switch def := eval.Current().(type) {
 	case *expr.RootExpr:
@@ -329,6 +518,33 @@ func PATCH(path string) *expr.RouteExpr {
 	return route("PATCH", path)
 }
 
+// Route creates a route using the given HTTP method. Route makes it possible to
+// define routes that use methods other than the standard ones such as the
+// WebDAV methods. The method must be a valid HTTP token. See GET for a
+// description of the path syntax.
+//
+// The OpenAPI specifications only describe the routes that use standard HTTP
+// methods, the other routes are omitted.
+//
+// Route must appear in a method HTTP function.
+//
+// Route accepts two arguments: the HTTP method and the request path.
+//
+// Example:
+//
+//     var _ = Service("Storage", func() {
+//         Method("ListProperties", func() {
+//             Payload(String)
+//             HTTP(func() {
+//                 Route("PROPFIND", "/files/{*path}")
+//             })
+//         })
+//     })
+//
+func Route(method, path string) *expr.RouteExpr {
+	return route(method, path)
+}
+
 func route(method, path string) *expr.RouteExpr {
 	r := &expr.RouteExpr{Method: method, Path: path}
 	a, ok := eval.Current().(*expr.HTTPEndpointExpr)
@@ -357,6 +573,9 @@ func route(method, path string) *expr.RouteExpr {
 // Header accepts the same arguments as the Attribute function. The header name
 // may define a mapping between the attribute name and the HTTP header name when
 // they differ. The mapping syntax is "name of attribute:name of header".
+// Validations (enums, patterns, formats etc.) and default values defined in the
+// Header DSL override the ones inherited from the attribute and are enforced by
+// the generated code. Validation errors name the HTTP header.
 //
 // Example:
 //
@@ -798,6 +1017,116 @@ func SkipRequestBodyEncodeDecode() {
 	e.SkipRequestBodyEncodeDecode = true
 }
 
+// StreamRequestBody makes the HTTP endpoint of a method that defines a
+// StreamingPayload and no StreamingResult transfer the streamed elements as
+// the elements of a JSON array in the request body instead of using a
+// websocket connection. The generated server decodes, validates and hands the
+// elements to the service method one at a time as they are read so that large
+// collections never need to be loaded in memory. The endpoint may use any HTTP
+// method that accepts a request body. The method payload attributes must be
+// mapped to headers and params.
+//
+// StreamRequestBody must appear in a HTTP endpoint expression.
+//
+// Example:
+//
+//    var _ = Service("catalog", func() {
+//        Method("import", func() {
+//            Payload(func() {
+//                Attribute("catalog", String)
+//            })
+//            StreamingPayload(Product)
+//            Result(Int)
+//            HTTP(func() {
+//                POST("/{catalog}/products")
+//                StreamRequestBody()
+//            })
+//        })
+//    })
+//
+func StreamRequestBody() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.StreamRequestBody = true
+}
+
+// AcceptRanges makes the HTTP endpoint serve the byte ranges requested by
+// clients with the Range header, e.g. to resume downloads or to seek in media
+// files. The generated server writes 206 Partial Content responses with the
+// Content-Range header set for requests that specify a single satisfiable
+// range and 416 Range Not Satisfiable responses for requests whose range does
+// not overlap the response body. The If-Range header is compared to the
+// response ETag or Last-Modified headers. All responses set the
+// "Accept-Ranges: bytes" header.
+//
+// AcceptRanges must appear in a HTTP endpoint expression that also uses
+// SkipResponseBodyEncodeDecode. The size of the response body must be known
+// for ranges to apply: the reader returned by the service method must
+// implement io.Seeker (e.g. *os.File or *bytes.Reader) or the response must
+// set the Content-Length header. The complete body is returned otherwise.
+//
+// Example:
+//
+//    var _ = Service("media", func() {
+//        Method("download", func() {
+//            Payload(String)
+//            Result(func() {
+//                Attribute("length", Int64)
+//                Required("length")
+//            })
+//            HTTP(func() {
+//                GET("/{*path}")
+//                SkipResponseBodyEncodeDecode()
+//                AcceptRanges()
+//                Response(func() {
+//                    Header("length:Content-Length")
+//                })
+//            })
+//        })
+//    })
+//
+func AcceptRanges() {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.AcceptRanges = true
+}
+
+// Push lists the paths of related resources that the server pushes to the
+// client together with the endpoint success responses, e.g. the scripts and
+// stylesheets used by a HTML page. The generated server issues a HTTP/2
+// PUSH_PROMISE for each path before writing the response when the connection
+// supports server push. Push is ignored for other connections.
+//
+// Push must appear in a HTTP endpoint expression. Push may be called multiple
+// times, each path must start with a slash.
+//
+// Example:
+//
+//    var _ = Service("web", func() {
+//        Method("home", func() {
+//            Result(Bytes)
+//            HTTP(func() {
+//                GET("/")
+//                Push("/assets/app.js", "/assets/app.css")
+//            })
+//        })
+//    })
+//
+func Push(paths ...string) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.Push = append(e.Push, paths...)
+}
+
 // SkipResponseBodyEncodeDecode prevents Goa from generating the response
 // encoding (server) and decoding (client) code. Instead the service method
 // returns a reader from which to stream the HTTP response body io. The client
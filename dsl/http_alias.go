@@ -0,0 +1,75 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Alias mounts a legacy path in addition to the endpoint routes to ease the
+// migration of clients of services that predate the design. The requests made
+// to the legacy path are handled by the endpoint with the legacy path
+// parameters mapped onto the route parameters with the same names. Alias
+// accepts an optional redirect status code, one of StatusMovedPermanently,
+// StatusFound, StatusSeeOther, StatusTemporaryRedirect or
+// StatusPermanentRedirect, in which case the server redirects the requests
+// made to the legacy path to the first route of the endpoint instead.
+//
+// The legacy path may use the route wildcard syntax or the ":name" and "*name"
+// syntax of pre-goa routers, e.g. "/old/bottles/:id" is equivalent to
+// "/old/bottles/{id}". The legacy path must define the same parameters as the
+// endpoint routes. Like routes, legacy paths are relative to the service base
+// path unless they start with "//". Legacy paths are served by the generated
+// servers only, they are not used by the generated clients and do not appear
+// in the OpenAPI specifications.
+//
+// Alias must appear in a HTTP endpoint expression. Alias may be called
+// multiple times.
+//
+// Example:
+//
+//    var _ = Service("bottles", func() {
+//        Method("show", func() {
+//            Payload(func() {
+//                Attribute("id", Int)
+//            })
+//            HTTP(func() {
+//                GET("/bottles/{id}")
+//                Alias("/bottle/{id}")
+//                Alias("//v1/bottles/:id", StatusPermanentRedirect)
+//            })
+//        })
+//    })
+//
+func Alias(path string, code ...int) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(code) > 1 {
+		eval.ReportError("too many arguments given to Alias")
+		return
+	}
+	a := &expr.HTTPRouteAliasExpr{Path: aliasPath(path), Endpoint: e}
+	if len(code) == 1 {
+		a.StatusCode = code[0]
+	}
+	e.Aliases = append(e.Aliases, a)
+}
+
+// aliasPath translates the ":name" and "*name" wildcards of path to the route
+// wildcard syntax.
+func aliasPath(path string) string {
+	segs := strings.Split(path, "/")
+	for i, seg := range segs {
+		switch {
+		case strings.HasPrefix(seg, ":") && len(seg) > 1:
+			segs[i] = "{" + seg[1:] + "}"
+		case strings.HasPrefix(seg, "*") && len(seg) > 1:
+			segs[i] = "{*" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
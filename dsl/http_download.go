@@ -0,0 +1,78 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Download makes the HTTP endpoint stream a file in the response body. The
+// service method returns the file content as an io.ReadCloser together with
+// the method result, see SkipResponseBodyEncodeDecode which Download implies.
+// The generated server sets the Content-Type header to the given MIME type,
+// the Content-Disposition header using the disposition type ("attachment" by
+// default) and the file name and the Content-Length header when the size of
+// the file can be determined.
+//
+// The file name and size are computed from the reader returned by the service
+// method: readers that implement Name() string such as *os.File provide the
+// file name and readers that implement Stat() (os.FileInfo, error), Len() int
+// or io.Seeker provide the size. Result attributes mapped to the
+// Content-Disposition or Content-Length headers override the computed values.
+//
+// Download must appear in a HTTP endpoint expression. Download accepts an
+// optional DSL which may use Disposition.
+//
+// Example:
+//
+//    var _ = Service("reports", func() {
+//        Method("export", func() {
+//            Payload(String)
+//            HTTP(func() {
+//                GET("/reports/{id}")
+//                Download("application/pdf", func() {
+//                    Disposition("inline")
+//                })
+//            })
+//        })
+//    })
+//
+func Download(contentType string, fns ...func()) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to Download")
+		return
+	}
+	d := &expr.HTTPDownloadExpr{ContentType: contentType, Disposition: "attachment", Endpoint: e}
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], d) {
+			return
+		}
+	}
+	e.Download = d
+	e.SkipResponseBodyEncodeDecode = true
+}
+
+// Disposition sets the type of the Content-Disposition header of the responses
+// of endpoints that use Download. The type is "attachment" to have browsers
+// save the file or "inline" to display it.
+//
+// Disposition must appear in a Download expression.
+//
+// Example:
+//
+//    Download("image/png", func() {
+//        Disposition("inline")
+//    })
+//
+func Disposition(kind string) {
+	d, ok := eval.Current().(*expr.HTTPDownloadExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	d.Disposition = kind
+}
@@ -0,0 +1,95 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Proxy makes the HTTP endpoint forward the requests to the backend with the
+// given URL, e.g. "http://legacy:8080", and copy the backend responses so that
+// a service can front a legacy backend while its endpoints are migrated
+// incrementally. The request path is appended to the backend URL path and the
+// query string is preserved. The generated handler does not decode the
+// requests nor call the service method, the method still needs to be
+// implemented by the service so that the endpoint can later be migrated by
+// removing Proxy.
+//
+// Proxy must appear in a HTTP endpoint expression. Proxy accepts an optional
+// DSL which may use Timeout to set the maximum duration of the forwarded
+// requests and ProxyRequestHeader and ProxyResponseHeader to rewrite the
+// headers of the forwarded requests and of the backend responses.
+//
+// Example:
+//
+//    var _ = Service("bottles", func() {
+//        Method("list", func() {
+//            HTTP(func() {
+//                GET("/bottles")
+//                Proxy("http://legacy:8080/api", func() {
+//                    Timeout("10s")
+//                    ProxyRequestHeader("X-Legacy-Client", "goa")
+//                    ProxyRequestHeader("Cookie", "")
+//                    ProxyResponseHeader("Server", "")
+//                })
+//            })
+//        })
+//    })
+//
+func Proxy(url string, fns ...func()) {
+	e, ok := eval.Current().(*expr.HTTPEndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(fns) > 1 {
+		eval.ReportError("too many arguments given to Proxy")
+		return
+	}
+	p := &expr.HTTPProxyExpr{URL: url, Endpoint: e}
+	if len(fns) == 1 {
+		if !eval.Execute(fns[0], p) {
+			return
+		}
+	}
+	e.Proxy = p
+}
+
+// ProxyRequestHeader sets the header with the given name on the requests
+// forwarded by the proxy. The header is removed if value is empty.
+//
+// ProxyRequestHeader must appear in a Proxy expression.
+//
+// Example:
+//
+//    Proxy("http://legacy:8080", func() {
+//        ProxyRequestHeader("X-Forwarded-Prefix", "/v2")
+//    })
+//
+func ProxyRequestHeader(name, value string) {
+	p, ok := eval.Current().(*expr.HTTPProxyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.RequestHeaders = append(p.RequestHeaders, &expr.HTTPProxyHeaderExpr{Name: name, Value: value})
+}
+
+// ProxyResponseHeader sets the header with the given name on the backend
+// responses copied by the proxy. The header is removed if value is empty.
+//
+// ProxyResponseHeader must appear in a Proxy expression.
+//
+// Example:
+//
+//    Proxy("http://legacy:8080", func() {
+//        ProxyResponseHeader("X-Powered-By", "")
+//    })
+//
+func ProxyResponseHeader(name, value string) {
+	p, ok := eval.Current().(*expr.HTTPProxyExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.ResponseHeaders = append(p.ResponseHeaders, &expr.HTTPProxyHeaderExpr{Name: name, Value: value})
+}
@@ -0,0 +1,91 @@
+package dsl
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// LongRunning indicates that the method starts a long running operation. The
+// method returns the initial status of the operation and LongRunning defines
+// a companion method named after the method with the "_status" suffix that
+// returns the current status of the operation given its ID.
+//
+// LongRunning must appear in a Method expression.
+//
+// LongRunning takes a single argument which is the user type describing the
+// status of an operation. The type must define a required "id" string
+// attribute that identifies the operation and a "done" boolean attribute that
+// indicates whether the operation has completed. LongRunning sets the method
+// result to the status type. The status method payload contains the
+// operation ID as well as the method payload attributes used by the security
+// schemes and the status method inherits the method security requirements.
+//
+// The HTTP endpoint of the method returns 202 Accepted by default and sets
+// the Location header to the path of the operation status resource. The
+// status resource path is built by appending "/operations/{id}" to the paths
+// of the method, the status method payload also defines the method payload
+// attributes used as path parameters. The generated service client exposes a
+// helper that calls the method and polls the status method until the
+// operation completes.
+//
+// Example:
+//
+//    var Operation = Type("Operation", func() {
+//        Attribute("id", String, "Operation ID")
+//        Attribute("done", Boolean, "True once the operation completes")
+//        Attribute("url", String, "Export URL once done")
+//        Required("id", "done")
+//    })
+//
+//    Method("export", func() {
+//        Payload(func() {
+//            Attribute("format", String)
+//        })
+//        LongRunning(Operation)
+//        HTTP(func() {
+//            POST("/exports") // Status resource at GET "/exports/operations/{id}"
+//        })
+//    })
+//
+// The generated client code can then be used as follows:
+//
+//    op, err := c.ExportAndWait(ctx, &storage.ExportPayload{Format: &f}, time.Second)
+//
+func LongRunning(status interface{}) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	ut, ok := status.(expr.UserType)
+	if !ok {
+		eval.InvalidArgError("user type", status)
+		return
+	}
+	if _, ok := status.(*expr.ResultTypeExpr); ok {
+		eval.ReportError("long running operation status %q must be a user type, not a result type", ut.Name())
+		return
+	}
+	name := m.Name + "_status"
+	if m.Service.Method(name) != nil {
+		eval.ReportError("method %q already exists, cannot define status method of long running operation", name)
+		return
+	}
+	sm := &expr.MethodExpr{
+		Name:        name,
+		Description: fmt.Sprintf("%s returns the status of the operation started by %s.", name, m.Name),
+		Service:     m.Service,
+		Payload: &expr.AttributeExpr{
+			Type: &expr.Object{
+				{Name: "id", Attribute: &expr.AttributeExpr{Type: expr.String, Description: "ID of operation"}},
+			},
+			Validation: &expr.ValidationExpr{Required: []string{"id"}},
+		},
+		Result: &expr.AttributeExpr{Type: ut},
+	}
+	m.Service.Methods = append(m.Service.Methods, sm)
+	m.Result = &expr.AttributeExpr{Type: ut}
+	m.LongRunning = &expr.LongRunningExpr{Method: m, Status: ut, StatusMethod: sm}
+}
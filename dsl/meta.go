@@ -50,6 +50,33 @@ import (
 //        Meta("struct:pkg:path", "types")
 //    })
 //
+// - "struct:type:name" generates a named Go struct for an attribute defined
+// with an inline object type instead of an anonymous struct. The generated
+// code validates the struct and the OpenAPI specification describes it with a
+// named schema. Applicable to attributes whose type is an inline object only.
+//
+//    var Box = Type("Box", func() {
+//        Attribute("dimensions", func() {
+//            Meta("struct:type:name", "Dimensions")
+//            Attribute("w", Int)
+//            Attribute("h", Int)
+//            Required("w", "h")
+//        })
+//    })
+//
+// - "enum:type" generates a named Go type for an attribute with an Enum
+// validation together with one constant per enum value and an IsValid method.
+// Constant names are built from the type name followed by the value, e.g.
+// StatusPending, or by the name given with EnumNames. Applicable to String and
+// integer attributes and to types only.
+//
+//    var Order = Type("Order", func() {
+//        Attribute("status", String, func() {
+//            Enum("pending", "active", "closed")
+//            Meta("enum:type", "Status")
+//        })
+//    })
+//
 // - "struct:field:name" overrides the Go struct field name generated by default
 // by goa. Applicable to attributes only.
 //
@@ -108,6 +135,28 @@ import (
 //        })
 //    })
 //
+// - "wire:name" sets the name of the attribute in the HTTP request and response
+// bodies, validation errors and OpenAPI specifications, see WireName.
+// Applicable to attributes only.
+//
+//    var MyType = Type("MyType", func() {
+//        Attribute("self_url", String, func() {
+//            Meta("wire:name", "selfURL")
+//        })
+//    })
+//
+// - "nullable" marks attributes that accept explicit null values. The
+// generated HTTP server code records which nullable request body attributes
+// are present in JSON request bodies so that the service methods may call
+// goa.WasSet to tell omitted attributes apart from attributes set to null, see
+// Nullable. Applicable to attributes only.
+//
+//    var UpdatePayload = Type("UpdatePayload", func() {
+//        Attribute("nickname", String, func() {
+//            Meta("nullable")
+//        })
+//    })
+//
 // - "protoc:include" provides the list of import paths used to invoke protoc.
 // Applicable to API and service definitions only. If used on an API definition
 // the include paths are used for all services.
@@ -151,6 +200,19 @@ import (
 //        Meta("openapi:example", "false")
 //    })
 //
+// - "openapi:docs" generates a self-contained documentation site in
+// gen/http/docs. The site consists of an HTML page that inlines the OpenAPI v3
+// specification, the renderer JavaScript and CSS bundles and a Go package that
+// embeds them and exposes a http.Handler. The site does not load any external
+// resource so that it can be served offline unless the bundles are not
+// embedded in the goa module in which case the page loads them from pinned
+// CDN URLs. The value selects the renderer, either "redoc" (default) or
+// "swagger-ui". Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("openapi:docs", "swagger-ui")
+//    })
+//
 // - "swagger:tag:xxx" DEPRECATED, use "openapi:tag:xxx" instead
 //
 // - "openapi:tag:xxx" sets the OpenAPI object field tag xxx. Applicable to
@@ -179,6 +241,382 @@ import (
 //        Meta("openapi:extension:x-api", `{"foo":"bar"}`)
 //    })
 //
+// - "http:query:style" sets the serialization style of a query string
+// parameter. "multi" (the default for arrays) repeats the parameter for each
+// element (ids=1&ids=2), "csv" joins the elements with commas (ids=1,2) and
+// "deepObject" (the default for maps and objects) uses one parameter per key
+// or attribute (filter[name]=a&filter[tag]=b). The generated server and client
+// code and the OpenAPI specifications use the style. "csv" and "multi" apply
+// to arrays only, "deepObject" to maps and objects. The attributes of object
+// query parameters must be primitives or arrays of primitives. Applicable to
+// query string parameters only.
+//
+//    Param("ids", ArrayOf(Int), func() {
+//        Meta("http:query:style", "csv")
+//    })
+//
+//    var Filter = Type("Filter", func() {
+//        Attribute("name", String)
+//        Attribute("tags", ArrayOf(String))
+//    })
+//
+//    Param("filter", Filter) // ?filter[name]=a&filter[tags]=b&filter[tags]=c
+//
+// - "csv:columns" lists the attributes rendered as columns when a collection
+// of the type is written as CSV or XLSX and sets their order. The collections
+// are rendered with the primitive attributes of the type in the order of their
+// definition by default. The CSV and XLSX representations are generated for
+// the collection response bodies when the API Produces "text/csv" or the XLSX
+// content type. Applicable to user and result types only.
+//
+// - "csv:column" sets the name of the column that holds the attribute values
+// in the CSV and XLSX representations. The default is the attribute name.
+// Applicable to attributes of user and result types only.
+//
+//    var Account = ResultType("application/vnd.account", func() {
+//        Attributes(func() {
+//            Attribute("id", Int, func() {
+//                Meta("csv:column", "Account ID")
+//            })
+//            Attribute("name", String)
+//        })
+//        Meta("csv:columns", "name", "id")
+//    })
+//
+// - "http:muxer" set to "radix" causes the generated example server to use the
+// muxer returned by goahttp.NewRadixMuxer instead of the default muxer.
+// Applicable to API definitions only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("http:muxer", "radix")
+//    })
+//
+// - "http:features" generates optional integrations of the example HTTP
+// servers in cmd/<server>/http_<feature>.go. Each file starts with a build
+// constraint named after the feature so that the feature and its dependencies
+// are only compiled in when the tag is set, e.g. "go build -tags metrics".
+// "metrics" records per-method request counts, response sizes and encoding
+// times with package expvar and serves them on GET /debug/vars, "tracing"
+// sends AWS X-Ray segments to the daemon set in AWS_XRAY_DAEMON_ADDRESS.
+// Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("http:features", "metrics", "tracing")
+//    })
+//
+// - "http:snippets" generates ready-to-run command lines for each HTTP endpoint
+// in gen/http/snippets.md and in the "x-codeSamples" extension of the OpenAPI
+// v3 operations which the ReDoc documentation site renders. The values select
+// the tools, "curl" and/or "httpie", all tools are used if none is given. The
+// command lines use the design examples and shell variables for credentials
+// ($USERNAME, $PASSWORD, $API_KEY, $TOKEN or $ACCESS_TOKEN). Applicable to API
+// only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("http:snippets", "curl", "httpie")
+//    })
+//
+// - "http:fuzz" generates Go fuzz targets for the request decoders of the HTTP
+// endpoints whose requests have a body in gen/http/<service>/server/fuzz_test.go.
+// The fuzz targets send the design example requests with arbitrary bodies to
+// the decoders, the initial corpus includes malformed JSON, numbers that
+// overflow and invalid UTF-8. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("http:fuzz")
+//    })
+//
+//    $ go test ./gen/http/calc/server -fuzz FuzzDecodeAddRequest
+//
+// - "loadtest" generates load test scenarios that send the design example
+// requests to the HTTP endpoints: a k6 script in gen/http/loadtest/k6.js and
+// vegeta targets in gen/http/loadtest/vegeta.json. The values select the
+// tools, "k6" and/or "vegeta", all tools are used if none is given. The
+// credentials are represented by the ${API_KEY}, ${TOKEN} and ${ACCESS_TOKEN}
+// placeholders: the k6 script reads them from the environment and the vegeta
+// targets may be processed with envsubst. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("loadtest", "k6")
+//    })
+//
+//    $ k6 run -e BASE_URL=https://staging.example.com -e TOKEN=xxx gen/http/loadtest/k6.js
+//    $ envsubst < gen/http/loadtest/vegeta.json | vegeta attack -format=json -rate=50 | vegeta report
+//
+// - "loadtest:weight" sets the relative frequency of the requests sent to the
+// method endpoints by the load test scenarios generated with "loadtest". The
+// default weight is 1, the weight 0 excludes the method. Applicable to methods
+// only.
+//
+//    Method("list", func() {
+//        Meta("loadtest:weight", "10")
+//    })
+//
+// - "gateway" generates API gateway configurations that route the requests to
+// the HTTP endpoints: a Kong declarative configuration in
+// gen/http/gateway/kong.yaml, a Traefik IngressRoute with its middlewares in
+// gen/http/gateway/traefik.yaml and an Envoy route configuration in
+// gen/http/gateway/envoy.yaml. The values select the gateways, "kong",
+// "traefik" and/or "envoy", all gateways are used if none is given. The
+// configurations include the routes and aliases of the endpoints, the
+// security schemes of their requirements and the rate limits set with
+// "gateway:ratelimit". Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("gateway", "kong", "envoy")
+//    })
+//
+// - "gateway:ratelimit" sets the maximum number of requests per period
+// accepted by the gateways generated with "gateway" for the method endpoints.
+// The value has the form "<count>/<period>" where period is one of "second",
+// "minute", "hour" or "day". A method value overrides the service value.
+// Applicable to services and methods.
+//
+//    Method("list", func() {
+//        Meta("gateway:ratelimit", "100/minute")
+//    })
+//
+// - "terraform:resource" generates a Terraform (or OpenTofu) provider skeleton
+// in gen/terraform that exposes the service as a resource. The value sets the
+// resource type name and defaults to the service name. The resource schema
+// maps the primitive attributes of the create method payload and of the read
+// method result and the resource operations call the generated HTTP client.
+// The methods named "create", "show" (or "read" or "get"), "update" and
+// "delete" implement the corresponding operations, "terraform:operation"
+// overrides the operation implemented by a method. The provider
+// configuration defines the API endpoint and, for secured services, the token
+// or basic authentication credentials sent by the resource operations. The
+// generated code uses the terraform-plugin-framework module. Applicable to
+// services only.
+//
+//    var _ = Service("widgets", func() {
+//        Meta("terraform:resource", "widget")
+//        Method("rename", func() {
+//            Meta("terraform:operation", "update")
+//        })
+//    })
+//
+// - "soap:path" generates a SOAP facade for the service in
+// gen/http/<service>/soap: a WSDL document that describes the methods as
+// document/literal operations and a server that decodes the SOAP envelopes
+// into the method payloads, calls the service endpoints and encodes the
+// results or the errors as SOAP faults. The value sets the path of the SOAP
+// endpoint which also serves the WSDL document on GET requests.
+// "soap:namespace" sets the target namespace of the WSDL document and defaults
+// to "urn:<api>:<service>". Streaming methods and methods whose payload or
+// result uses maps, unions, bytes, nested arrays or values of any type are not
+// exposed. Applicable to services only.
+//
+//    var _ = Service("accounts", func() {
+//        Meta("soap:path", "/soap/accounts")
+//        Meta("soap:namespace", "http://example.com/accounts")
+//    })
+//
+// - "k8s:crd" generates a Kubernetes CustomResourceDefinition manifest, the
+// corresponding controller-runtime types and a reconciler skeleton in
+// gen/<service>/k8s for each method payload type that defines it. The value
+// sets the custom resource API group. The resource spec maps the primitive
+// attributes of the type and the manifest schema reflects their validations.
+// The reconciler calls the first method using the type as payload via the
+// service client. "k8s:crd:version" sets the API version and defaults to
+// "v1alpha1", "k8s:crd:plural" sets the plural resource name and defaults to
+// the lowercase kind followed by "s". Applicable to user types only.
+//
+//    var Widget = Type("Widget", func() {
+//        Meta("k8s:crd", "example.com")
+//        Meta("k8s:crd:version", "v1")
+//        Attribute("name", String)
+//    })
+//
+// - "k8s:deploy" causes the "example" command to generate Kubernetes
+// Deployment and Service manifests in deploy/k8s/<server> for each server.
+// The value sets the container image and defaults to the server name. The
+// manifests run the example server main and set its ports and host variables
+// via environment variables. "k8s:deploy:replicas" sets the number of
+// replicas, "k8s:deploy:autoscale" generates a HorizontalPodAutoscaler given
+// the minimum and maximum number of replicas and optionally the target CPU
+// utilization percentage (defaults to 80). "k8s:deploy:resources" sets the
+// CPU and memory requested by the containers, the requests default to "100m"
+// and "128Mi" when autoscaling is enabled as the utilization is computed
+// relative to them. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("k8s:deploy", "registry.example.com/myapi:v1")
+//        Meta("k8s:deploy:autoscale", "2", "10")
+//        Meta("k8s:deploy:resources", "250m", "256Mi")
+//    })
+//
+// - "k8s:probe" uses the HTTP endpoint of the method as the liveness,
+// readiness and/or startup probe of the containers generated by "k8s:deploy".
+// The endpoint must use a GET route with no path parameters. Applicable to
+// methods only.
+//
+//    Method("health", func() {
+//        Meta("k8s:probe", "liveness", "readiness")
+//        HTTP(func() {
+//            GET("/healthz")
+//        })
+//    })
+//
+// - "docker:compose" causes the "example" command to generate a multi-stage
+// Dockerfile that builds the example server mains and a docker-compose.yaml
+// file that runs them. The values list the services the servers depend on
+// such as "postgres", "mysql", "redis", "mongo" or "nats" optionally followed
+// by an image tag. The URLs of the well-known dependencies are provided to the
+// servers via environment variables (e.g. DATABASE_URL or REDIS_URL), other
+// values are used as is as the dependency image. Applicable to API only.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("docker:compose", "postgres", "redis:7")
+//    })
+//
+// - "discovery:tags" lists the tags used to register the service with service
+// discovery systems such as Consul or etcd. The tags defined on the API and on
+// the service are listed in the DiscoveryTags variable generated in the
+// service package, see the goa discovery package. Applicable to API and
+// services only.
+//
+//    var _ = Service("calc", func() {
+//        Meta("discovery:tags", "v1", "public")
+//    })
+//
+// - "concurrency" sets the number of goroutines that run the calls made to the
+// method when the service endpoints use the middleware.WorkerPool middleware.
+// "concurrency:queue" sets the number of calls that may wait for a worker, it
+// must be at least 1. Applicable to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Render", func() {
+//            Meta("concurrency", "16")
+//            Meta("concurrency:queue", "64")
+//        })
+//    })
+//
+// - "audit" set to "true" causes the middleware.Audit middleware to record
+// calls made to the method. "audit:params" lists the payload attributes
+// recorded with each event. Applicable to methods only. Method metadata is
+// made available at runtime via the MethodMeta variable generated in the
+// service package.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Delete", func() {
+//            Meta("audit", "true")
+//            Meta("audit:params", "id")
+//        })
+//    })
+//
+// - "fake:generate" generates the package gen/<service>/fake which exposes
+// functions that return random values of the service types that satisfy the
+// validations defined in the design (Enum, Format, Pattern, Minimum, Maximum,
+// MinLength and MaxLength). The functions take a *rand.Rand so that the values
+// are reproducible and can be used with property-based testing libraries
+// such as rapid or gopter. Applicable to API and services.
+//
+//    var _ = Service("calc", func() {
+//        Meta("fake:generate")
+//    })
+//
+//    p := fake.NewAddPayload(rand.New(rand.NewSource(seed)))
+//
+// - "feature:flag" lists the feature flags that must be on for the method to
+// be available when the service endpoints use the middleware.FeatureFlag
+// middleware. Set by the FeatureFlag DSL. Applicable to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Checkout", func() {
+//            Meta("feature:flag", "new-checkout")
+//        })
+//    })
+//
+// - "queue:concurrency" limits the number of concurrent calls made to the
+// method when the service endpoints use the middleware.Queue middleware.
+// "queue:size" sets the number of calls that may wait for a slot,
+// "queue:timeout" the maximum duration a call may wait and
+// "queue:retry-after" the value of the Retry-After header of the 503 Service
+// Unavailable responses sent when calls are shed. Applicable to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Search", func() {
+//            Meta("queue:concurrency", "10")
+//            Meta("queue:size", "100")
+//            Meta("queue:timeout", "2s")
+//            Meta("queue:retry-after", "5s")
+//        })
+//    })
+//
+// - "breaker:threshold" sets the number of consecutive failures that open the
+// circuit breaker of the method when the client endpoints use the
+// middleware.MethodBreaker middleware. "breaker:reset" sets how long the
+// breaker stays open before letting a trial call through (30s by default)
+// and "bulkhead" the maximum number of concurrent calls made to the method.
+// Applicable to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Charge", func() {
+//            Meta("breaker:threshold", "5")
+//            Meta("breaker:reset", "1m")
+//            Meta("bulkhead", "20")
+//        })
+//    })
+//
+// - "validation:lenient" causes the generated HTTP server code to downgrade the
+// request validation errors of the method to warnings when lenient validation
+// is enabled at runtime with goahttp.WithLenientValidation. Errors caused by
+// missing required fields or values that cannot be decoded are never
+// downgraded. Applicable to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Create", func() {
+//            Meta("validation:lenient")
+//        })
+//    })
+//
+// - "policy:params" lists the payload attributes included in the input of the
+// policy decisions made by the middleware.PolicyGuard middleware. Applicable
+// to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Show", func() {
+//            Meta("policy:params", "owner", "id")
+//        })
+//    })
+//
+// - "rbac:permission" lists the permissions the caller must be granted to call
+// the method when the service endpoints use the middleware.RBAC middleware.
+// Set by the Permission DSL. Applicable to methods only.
+//
+//    var _ = Service("MyService", func() {
+//        Method("Create", func() {
+//            Meta("rbac:permission", "bottles:write")
+//        })
+//    })
+//
+// - "tenant:param" sets the name of the payload attribute that holds the ID of
+// the tenant the method is scoped to when the service endpoints use the
+// middleware.ScopeTenant middleware. Set by the TenantParam DSL on the API
+// and on the methods whose payload defines the attribute. Applicable to APIs
+// and methods.
+//
+//    var _ = API("MyAPI", func() {
+//        Meta("tenant:param", "account_id")
+//    })
+//
+// - "sensitive" marks attributes holding sensitive values such as passwords or
+// tokens. The wire names of the payload and result attributes marked as
+// sensitive are listed in the SensitiveFields variable generated in the service
+// package so that logging middlewares such as the HTTP middleware.LogBodies can
+// redact them. Sensitive result attributes are also masked by the generated
+// HTTP response encoders. "sensitive:mask" sets the placeholder value rendered
+// for masked string attributes, "sensitive:unmask" set on a view disables
+// masking for results rendered with that view. See Sensitive and Mask.
+// Applicable to attributes and views only.
+//
+//    var Credentials = Type("Credentials", func() {
+//        Attribute("password", String, func() {
+//            Meta("sensitive")
+//        })
+//    })
+//
 func Meta(name string, value ...string) {
 	appendMeta := func(meta expr.MetaExpr, name string, value ...string) expr.MetaExpr {
 		if meta == nil {
@@ -91,6 +91,41 @@ func TestMethod(t *testing.T) {
 				}
 			},
 		},
+		"feature-flag": {
+			func() {
+				Method("d", func() {
+					FeatureFlag("new-checkout")
+					FeatureFlag("beta")
+					FeatureFlag("new-checkout")
+				})
+			},
+			func(t *testing.T, methods []*expr.MethodExpr) {
+				if len(methods) != 1 {
+					t.Fatalf("feature-flag: expected 1 method, got %d", len(methods))
+				}
+				flags := methods[0].Meta["feature:flag"]
+				if len(flags) != 2 || flags[0] != "new-checkout" || flags[1] != "beta" {
+					t.Errorf("feature-flag: expected flags [new-checkout beta], got %v", flags)
+				}
+			},
+		},
+		"permission": {
+			func() {
+				Method("d", func() {
+					Permission("bottles:write", "bottles:read")
+					Permission("bottles:write")
+				})
+			},
+			func(t *testing.T, methods []*expr.MethodExpr) {
+				if len(methods) != 1 {
+					t.Fatalf("permission: expected 1 method, got %d", len(methods))
+				}
+				perms := methods[0].Meta["rbac:permission"]
+				if len(perms) != 2 || perms[0] != "bottles:write" || perms[1] != "bottles:read" {
+					t.Errorf("permission: expected permissions [bottles:write bottles:read], got %v", perms)
+				}
+			},
+		},
 	}
 	//Run our tests
 	for k, tc := range cases {
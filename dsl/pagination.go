@@ -0,0 +1,74 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Paginated indicates that the method results are paginated using a cursor.
+// The generated service client exposes an iterator that retrieves the pages
+// transparently: the iterator sets the payload cursor attribute to the value
+// of the result next cursor attribute when requesting the next page and stops
+// once the next cursor is empty.
+//
+// Paginated must appear in a Method expression.
+//
+// Paginated takes three arguments: the name of the payload attribute that
+// contains the cursor of the page to retrieve, the name of the result
+// attribute that contains the cursor of the next page and the name of the
+// result attribute that contains the page items. The cursor attributes must
+// both be strings or both be integers of the same kind and the items
+// attribute must be an array. The transport mapping of the cursor attributes
+// is defined as usual, for example using query string parameters or headers.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("cursor", String, "Cursor of page to retrieve")
+//            Attribute("limit", Int, "Maximum number of items per page")
+//        })
+//        Result(func() {
+//            Attribute("bottles", ArrayOf(Bottle), "Page of bottles")
+//            Attribute("next", String, "Cursor of next page if any")
+//            Required("bottles")
+//        })
+//        Paginated("cursor", "next", "bottles")
+//        HTTP(func() {
+//            GET("/bottles")
+//            Param("cursor")
+//            Param("limit")
+//            Response(StatusOK, func() {
+//                Header("next:X-Next-Cursor")
+//            })
+//        })
+//    })
+//
+// The generated client code can then be used as follows:
+//
+//    it := c.ListIter(ctx, &storage.ListPayload{Limit: &limit})
+//    for it.Next() {
+//        bottle := it.Item()
+//        // ...
+//    }
+//    if err := it.Err(); err != nil {
+//        // ...
+//    }
+//
+func Paginated(cursor, next, items string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if cursor == "" || next == "" || items == "" {
+		eval.ReportError("pagination attribute names cannot be empty")
+		return
+	}
+	m.Pagination = &expr.PaginationExpr{
+		Method:     m,
+		Cursor:     cursor,
+		NextCursor: next,
+		Items:      items,
+	}
+}
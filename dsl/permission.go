@@ -0,0 +1,54 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Permission lists the permissions the caller must be granted to call the
+// method. The middleware.RBAC middleware looks up the roles of the caller and
+// rejects the calls made by callers whose roles do not grant all the
+// permissions with a "permission_denied" error that the HTTP transport maps
+// to a 403 Forbidden response and the gRPC transport to a PermissionDenied
+// status.
+//
+// Permission must appear in a Method expression. Permission may be called
+// multiple times. Permission sets the "rbac:permission" metadata of the
+// method which is made available at runtime via the MethodMeta variable
+// generated in the service package.
+//
+// Permission takes the names of the permissions as arguments.
+//
+// Example:
+//
+//    Method("create", func() {
+//        Permission("bottles:write")
+//        Payload(Bottle)
+//    })
+//
+func Permission(perms ...string) {
+	m, ok := eval.Current().(*expr.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if m.Meta == nil {
+		m.Meta = expr.MetaExpr{}
+	}
+	for _, p := range perms {
+		if p == "" {
+			eval.ReportError("permission name cannot be empty")
+			return
+		}
+		dupe := false
+		for _, e := range m.Meta["rbac:permission"] {
+			if e == p {
+				dupe = true
+				break
+			}
+		}
+		if !dupe {
+			m.Meta["rbac:permission"] = append(m.Meta["rbac:permission"], p)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// Profile defines design overrides that apply to a given environment such as
+// staging or production. A profile may define servers and security
+// requirements, the servers and requirements of the profile replace the ones
+// defined by the API when the profile is selected with the goa tool "-profile"
+// flag:
+//
+//    goa gen goa.design/examples/calc/design -profile staging
+//
+// The servers and requirements defined by the API apply when no profile is
+// selected. The selected profile is reflected in the generated OpenAPI
+// specifications, example server and client tool.
+//
+// Profile must appear in an API expression.
+//
+// Profile takes two arguments: the name of the profile and the defining DSL.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Server("calc", func() {
+//            Host("production", func() {
+//                URI("https://api.example.com")
+//            })
+//        })
+//
+//        Profile("staging", func() {
+//            Description("Staging environment")
+//            Server("calc", func() {
+//                Host("staging", func() {
+//                    URI("http://staging.api.example.com")
+//                })
+//            })
+//            Security(BasicAuth)
+//        })
+//    })
+//
+func Profile(name string, fn func()) {
+	api, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("profile name cannot be empty")
+		return
+	}
+	if api.Profile(name) != nil {
+		eval.ReportError("profile %q is defined twice", name)
+		return
+	}
+	p := &expr.ProfileExpr{Name: name}
+	if !eval.Execute(fn, p) {
+		return
+	}
+	api.Profiles = append(api.Profiles, p)
+}
@@ -468,6 +468,65 @@ func Extend(t expr.DataType) {
 	}
 }
 
+// Embed adds the attributes and validations of the parameter type to the type
+// like Extend and records the parameter type as embedded. The parameter type
+// must be a user type defined with Type or ResultType.
+//
+// The generated service struct embeds the struct generated for the parameter
+// type when possible, that is when both types are generated in the same
+// package and the embedding type does not change the requiredness or default
+// values of the embedded attributes. Otherwise the embedded attributes are
+// generated as regular fields and the code generator produces two methods on
+// the embedding type: As<Type> returns the value of the embedded attributes
+// and Set<Type> sets them. Either way the code handling the embedded
+// attributes can be shared across all the types that embed them.
+//
+// Embed may be used in Type or ResultType. Embed accepts a single argument:
+// the type or result type to embed.
+//
+// Example:
+//
+//    var Timestamps = Type("Timestamps", func() {
+//        Attribute("created_at", String, func() {
+//            Format(FormatDateTime)
+//        })
+//        Attribute("updated_at", String, func() {
+//            Format(FormatDateTime)
+//        })
+//        Required("created_at")
+//    })
+//
+//    var Bottle = ResultType("application/vnd.bottle", func() {
+//        Attribute("name", String)
+//        Embed(Timestamps) // Adds attributes "created_at" and "updated_at"
+//    })
+//
+// The generated Bottle struct embeds the Timestamps struct so that the service
+// code may use:
+//
+//    bottle.UpdatedAt = &now // field promoted from Timestamps
+//    record(&bottle.Timestamps)
+//
+func Embed(t expr.DataType) {
+	ut, ok := t.(expr.UserType)
+	if !ok || !expr.IsObject(t) {
+		eval.ReportError("argument of Embed must be a user type object, got %s", t.Name())
+		return
+	}
+	var att *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.ResultTypeExpr:
+		att = def.AttributeExpr
+	case *expr.AttributeExpr:
+		att = def
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	att.Bases = append(att.Bases, t)
+	att.AddMeta("type:embed", ut.Name())
+}
+
 // Attributes implements the result type Attributes DSL. See ResultType.
 func Attributes(fn func()) {
 	mt, ok := eval.Current().(*expr.ResultTypeExpr)
@@ -0,0 +1,41 @@
+package dsl_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestEmbedResultType(t *testing.T) {
+	root := expr.RunDSL(t, func() {
+		var Timestamps = Type("Timestamps", func() {
+			Attribute("created_at", String)
+			Attribute("updated_at", String)
+			Required("created_at")
+		})
+		ResultType("application/vnd.bottle", func() {
+			Attribute("name", String)
+			Embed(Timestamps)
+		})
+	})
+	rt, ok := root.UserType("Bottle").(*expr.ResultTypeExpr)
+	if !ok {
+		t.Fatal("result type Bottle not found")
+	}
+	view := rt.View("default")
+	if view == nil {
+		t.Fatal("default view not found")
+	}
+	for _, n := range []string{"name", "created_at", "updated_at"} {
+		if expr.AsObject(view.Type).Attribute(n) == nil {
+			t.Errorf("default view: attribute %q not found", n)
+		}
+	}
+	if !view.IsRequired("created_at") {
+		t.Error("default view: expected created_at to be required")
+	}
+	if view.IsRequired("updated_at") {
+		t.Error("default view: expected updated_at not to be required")
+	}
+}
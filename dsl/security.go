@@ -179,18 +179,114 @@ func JWTSecurity(name string, fn ...func()) *expr.SchemeExpr {
 	return e
 }
 
+// SignatureSecurity defines a security scheme where clients sign each request
+// with a shared secret key. The signature is a HMAC-SHA256 of the request
+// method, path, date and body and is carried in the Authorization header.
+// Unlike the other schemes a signature scheme does not map to a payload
+// attribute: signatures are verified by the HTTP transport using the
+// middleware.VerifySignature middleware and produced by the generated HTTP
+// clients: the client of a service whose endpoints are secured by a scheme
+// named "hmac" exposes a SignHmacRequests method that signs the requests made
+// to these endpoints with the given key.
+//
+// SignatureSecurity is a top level DSL.
+//
+// SignatureSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//    var Signed = SignatureSecurity("hmac", func() {
+//        Description("Machine to machine requests signed with a shared key")
+//    })
+//
+func SignatureSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName: name,
+		Kind:       expr.SignatureKind,
+		In:         "header",
+		Name:       "Authorization",
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
+// MutualTLSSecurity defines a security scheme where clients authenticate by
+// presenting a X.509 certificate during the TLS handshake. Like signature
+// schemes mutual TLS schemes do not map to payload attributes: the server must
+// be configured to verify client certificates (see goahttp.MutualTLSConfig)
+// and the middleware.MutualTLS middleware stores the identity of the verified
+// certificate in the request context. The generated HTTP client of a service
+// whose endpoints are secured by a scheme named "mtls" exposes a
+// UseMtlsCertificate method that configures the client to present the given
+// certificate when calling these endpoints.
+//
+// MutualTLSSecurity is a top level DSL.
+//
+// MutualTLSSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//    var MTLS = MutualTLSSecurity("mtls", func() {
+//        Description("Internal services authenticate with mesh certificates")
+//    })
+//
+func MutualTLSSecurity(name string, fn ...func()) *expr.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+
+	if securitySchemeRedefined(name) {
+		return nil
+	}
+
+	e := &expr.SchemeExpr{
+		SchemeName: name,
+		Kind:       expr.MutualTLSKind,
+	}
+
+	if len(fn) != 0 {
+		if !eval.Execute(fn[0], e) {
+			return nil
+		}
+	}
+
+	expr.Root.Schemes = append(expr.Root.Schemes, e)
+
+	return e
+}
+
 // Security defines authentication requirements to access an entire API, service
 // or individual service method.
 //
 // The requirement refers to one or more OAuth2Security, BasicAuthSecurity,
-// APIKeySecurity or JWTSecurity security scheme. If the schemes include a
-// OAuth2Security or JWTSecurity scheme then required scopes may be listed by
-// name in the Security DSL. All the listed schemes must be validated by the
-// client for the request to be authorized. Security may appear multiple times
-// in the same scope in which case the client may validate any one of the
-// requirements for the request to be authorized.
+// APIKeySecurity, JWTSecurity, SignatureSecurity or MutualTLSSecurity security
+// scheme. If the schemes include a OAuth2Security or JWTSecurity scheme then
+// required scopes may be listed by name in the Security DSL. All the listed
+// schemes must be validated by the client for the request to be authorized.
+// Security may appear multiple times in the same scope in which case the client
+// may validate any one of the requirements for the request to be authorized.
 //
-// Security must appear in a API, Service or Method expression.
+// Security must appear in a API, Profile, Service or Method expression.
 //
 // Security accepts an arbitrary number of security schemes as argument
 // specified by name or by reference and an optional DSL function as last
@@ -280,6 +376,8 @@ func Security(args ...interface{}) {
 		actual.Requirements = append(actual.Requirements, security)
 	case *expr.APIExpr:
 		actual.Requirements = append(actual.Requirements, security)
+	case *expr.ProfileExpr:
+		actual.Requirements = append(actual.Requirements, security)
 	default:
 		eval.IncompatibleDSL()
 		return
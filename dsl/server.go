@@ -18,7 +18,7 @@ import (
 // the first host is used to set the OpenAPI v2 specification 'host' and
 // 'basePath' values.
 //
-// Server must appear in a API expression.
+// Server must appear in a API or Profile expression.
 //
 // Server takes two arguments: the name of the server and the defining DSL.
 //
@@ -61,21 +61,27 @@ func Server(name string, fn ...func()) *expr.ServerExpr {
 	if len(fn) > 1 {
 		eval.ReportError("too many arguments given to Server")
 	}
-	api, ok := eval.Current().(*expr.APIExpr)
-	if !ok {
+	server := &expr.ServerExpr{Name: name}
+	switch actual := eval.Current().(type) {
+	case *expr.APIExpr:
+		actual.Servers = append(actual.Servers, server)
+	case *expr.ProfileExpr:
+		actual.Servers = append(actual.Servers, server)
+	default:
 		eval.IncompatibleDSL()
+		return server
 	}
-	server := &expr.ServerExpr{Name: name}
 	if len(fn) > 0 {
 		eval.Execute(fn[0], server)
 	}
-	api.Servers = append(api.Servers, server)
 	return server
 }
 
-// Services sets the list of services implemented by a server.
+// Services sets the list of services implemented by a server or exposed by an
+// API. APIs that do not use Services expose all the services of the design, see
+// API.
 //
-// Services must appear in a Server expression.
+// Services must appear in a Server or API expression.
 //
 // Services takes one or more strings as argument corresponding to service
 // names.
@@ -88,12 +94,14 @@ func Server(name string, fn ...func()) *expr.ServerExpr {
 //    })
 //
 func Services(svcs ...string) {
-	s, ok := eval.Current().(*expr.ServerExpr)
-	if !ok {
+	switch actual := eval.Current().(type) {
+	case *expr.ServerExpr:
+		actual.Services = append(actual.Services, svcs...)
+	case *expr.APIExpr:
+		actual.Services = append(actual.Services, svcs...)
+	default:
 		eval.IncompatibleDSL()
-		return
 	}
-	s.Services = append(s.Services, svcs...)
 }
 
 // Host defines a server host. A single server may define multiple hosts. Each
@@ -0,0 +1,55 @@
+package dsl
+
+import (
+	"goa.design/goa/v3/eval"
+	"goa.design/goa/v3/expr"
+)
+
+// TenantParam scopes the API methods to a tenant identified by the payload
+// attribute with the given name. The methods whose payload defines the
+// attribute are tenant-scoped: the middleware.ScopeTenant middleware resolves
+// the tenant of each call made to these methods from the attribute value -
+// which may be loaded from the request path, a header or any other transport
+// element - or from the caller token claims, stores it in the request
+// context and rejects the calls that do not identify a tenant.
+//
+// TenantParam must appear in an API expression. TenantParam sets the
+// "tenant:param" metadata of the API and of the tenant-scoped methods, the
+// latter is made available at runtime via the MethodMeta variable generated
+// in the service package.
+//
+// TenantParam takes a single argument which is the name of the payload
+// attribute that holds the tenant ID.
+//
+// Example:
+//
+//    var _ = API("billing", func() {
+//        TenantParam("account_id")
+//    })
+//
+//    var _ = Service("invoices", func() {
+//        Method("list", func() {
+//            Payload(func() {
+//                Attribute("account_id", String)
+//            })
+//            HTTP(func() {
+//                GET("/accounts/{account_id}/invoices")
+//            })
+//        })
+//    })
+//
+func TenantParam(name string) {
+	a, ok := eval.Current().(*expr.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("tenant parameter name cannot be empty")
+		return
+	}
+	if a.Meta == nil {
+		a.Meta = expr.MetaExpr{}
+	}
+	a.Meta["tenant:param"] = []string{name}
+}
@@ -38,6 +38,26 @@ const (
 
 	// Any is the type for an arbitrary JSON value (interface{} in Go).
 	Any = expr.Any
+
+	// DateTime is the type for a RFC3339 date and time (time.Time in Go).
+	DateTime = expr.DateTime
+
+	// Date is the type for a RFC3339 full-date (goa.Date in Go). Dates are
+	// encoded using the "2006-01-02" layout.
+	Date = expr.Date
+
+	// Duration is the type for a duration (time.Duration in Go). Durations
+	// are encoded as a number of nanoseconds, path, query and header
+	// parameters also accept strings such as "1h30m".
+	Duration = expr.Duration
+
+	// UUID is the type for a RFC4122 UUID (uuid.UUID in Go).
+	UUID = expr.UUID
+
+	// Decimal is the type for an arbitrary precision decimal number
+	// (goa.Decimal in Go). Decimals are encoded as strings such as "12.30"
+	// so that monetary amounts do not suffer from floating point rounding.
+	Decimal = expr.Decimal
 )
 
 // Empty represents empty values.
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"goa.design/goa/v3/eval"
 	"goa.design/goa/v3/expr"
@@ -68,8 +69,16 @@ const (
 //        })
 //    })
 //
+// Enum cannot be used with DateTime, Date, Duration, UUID and Decimal
+// attributes.
+//
 func Enum(vals ...interface{}) {
 	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		switch a.Type {
+		case expr.DateTime, expr.Date, expr.Duration, expr.UUID, expr.Decimal:
+			incompatibleAttributeType("enum", a.Type.Name(), "a type other than DateTime, Date, Duration, UUID or Decimal")
+			return
+		}
 		for i, v := range vals {
 			// When can a.Type be nil? glad you asked
 			// There are two ways to write an Attribute declaration with the DSL that
@@ -114,6 +123,31 @@ func Enum(vals ...interface{}) {
 	}
 }
 
+// EnumNames sets the names of the values listed in the Enum validation of the
+// attribute. The names are used to name the constants generated for enum types
+// (see the "enum:type" meta) and are listed in the "x-enum-varnames" extension
+// of the generated OpenAPI specifications.
+//
+// EnumNames must appear in an Attribute DSL that also uses Enum.
+//
+// EnumNames takes one name per enum value listed in the same order.
+//
+// Example:
+//
+//    Attribute("priority", Int, func() {
+//        Enum(1, 2, 4)
+//        EnumNames("Low", "Medium", "High")
+//        Meta("enum:type", "Priority")
+//    })
+//
+func EnumNames(names ...string) {
+	if _, ok := eval.Current().(*expr.AttributeExpr); !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	Meta("enum:names", names...)
+}
+
 // Format adds a "format" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor104.
 // The formats supported by goa are:
@@ -425,6 +459,70 @@ func MaxLength(val int) {
 	}
 }
 
+// MinItems adds a "minItems" validation to an array attribute. MinItems is
+// equivalent to MinLength but may only be used on arrays.
+//
+// Example:
+//
+//    Attribute("tags", ArrayOf(String), func() {
+//        MinItems(1)
+//    })
+//
+func MinItems(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.ArrayKind {
+			incompatibleAttributeType("minimum items", a.Type.Name(), "an array")
+			return
+		}
+		MinLength(val)
+	}
+}
+
+// MaxItems adds a "maxItems" validation to an array attribute. MaxItems is
+// equivalent to MaxLength but may only be used on arrays.
+//
+// Example:
+//
+//    Attribute("tags", ArrayOf(String), func() {
+//        MaxItems(10)
+//    })
+//
+func MaxItems(val int) {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.ArrayKind {
+			incompatibleAttributeType("maximum items", a.Type.Name(), "an array")
+			return
+		}
+		MaxLength(val)
+	}
+}
+
+// UniqueItems adds a "uniqueItems" validation to an array attribute. The
+// generated code rejects arrays that contain the same element more than once.
+// See http://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.4.3.
+//
+// Example:
+//
+//    Attribute("tags", ArrayOf(String), func() {
+//        UniqueItems()
+//        Elem(func() {
+//            Pattern("^[a-z]+$") // validation applied to each element
+//        })
+//    })
+//
+func UniqueItems() {
+	if a, ok := eval.Current().(*expr.AttributeExpr); ok {
+		if a.Type != nil && a.Type.Kind() != expr.ArrayKind {
+			incompatibleAttributeType("unique items", a.Type.Name(), "an array")
+			return
+		}
+		if a.Validation == nil {
+			a.Validation = &expr.ValidationExpr{}
+		}
+		a.Validation.UniqueItems = true
+	}
+}
+
 // Required adds a "required" validation to the attribute.
 // See http://json-schema.org/latest/json-schema-validation.html#anchor61.
 //
@@ -467,6 +565,128 @@ func Required(names ...string) {
 	}
 }
 
+// RequiredTogether adds a validation to the object attribute that requires the
+// attributes with the given names to be either all set or all omitted. The
+// rule is documented in the description of the generated OpenAPI schema.
+//
+// RequiredTogether may appear anywhere Required can.
+//
+// Example:
+//
+//    var Location = Type("Location", func() {
+//        Attribute("lat", Float64)
+//        Attribute("lng", Float64)
+//        RequiredTogether("lat", "lng")
+//    })
+//
+func RequiredTogether(names ...string) {
+	addFieldGroup("required together", names, func(v *expr.ValidationExpr) {
+		v.RequiredTogether = append(v.RequiredTogether, names)
+	})
+}
+
+// Mutex adds a validation to the object attribute that requires at most one of
+// the attributes with the given names to be set. The rule is documented in the
+// description of the generated OpenAPI schema.
+//
+// Mutex may appear anywhere Required can.
+//
+// Example:
+//
+//    var Contact = Type("Contact", func() {
+//        Attribute("email", String)
+//        Attribute("phone", String)
+//        Mutex("email", "phone")
+//    })
+//
+func Mutex(names ...string) {
+	addFieldGroup("mutex", names, func(v *expr.ValidationExpr) {
+		v.Mutex = append(v.Mutex, names)
+	})
+}
+
+// ValidateWith adds a validation to the attribute that calls the user provided
+// function with the given name. The generated code calls the function after
+// running the other validations defined on the attribute. This makes it possible to
+// keep domain specific rules next to the design instead of editing generated
+// files.
+//
+// ValidateWith must appear in an Attribute expression of primitive type. The
+// function accepts a value of the Go type of the attribute (e.g. int for Int)
+// and returns a non-nil error if the value is invalid.
+//
+// The first argument is the name of the function. The optional second
+// argument is the import path of the package that defines the function, in
+// which case the name must be qualified with the package name.
+//
+// Example:
+//
+//    Attribute("vintage", Int, func() {
+//        Minimum(1900)
+//        ValidateWith("validators.ValidateVintage", "example.com/wine/validators")
+//    })
+//
+// where ValidateVintage is defined as:
+//
+//    func ValidateVintage(v int) error
+//
+func ValidateWith(fn string, pkgPath ...string) {
+	a, ok := eval.Current().(*expr.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if a.Type != nil && !expr.IsPrimitive(a.Type) {
+		incompatibleAttributeType("custom", a.Type.Name(), "a primitive")
+		return
+	}
+	if fn == "" {
+		eval.ReportError("invalid custom validation definition: function name cannot be empty")
+		return
+	}
+	f := &expr.ValidationFuncExpr{Name: fn}
+	if len(pkgPath) > 0 {
+		f.Path = pkgPath[0]
+		if !strings.Contains(fn, ".") {
+			eval.ReportError("invalid custom validation definition: function name %q must be qualified with the package name when an import path is given", fn)
+			return
+		}
+	}
+	if a.Validation == nil {
+		a.Validation = &expr.ValidationExpr{}
+	}
+	a.Validation.Funcs = append(a.Validation.Funcs, f)
+}
+
+// addFieldGroup adds a validation that spans the object attributes with the
+// given names to the current attribute.
+func addFieldGroup(validation string, names []string, add func(*expr.ValidationExpr)) {
+	var at *expr.AttributeExpr
+	switch def := eval.Current().(type) {
+	case *expr.AttributeExpr:
+		at = def
+	case *expr.ResultTypeExpr:
+		at = def.AttributeExpr
+	case *expr.MappedAttributeExpr:
+		at = def.AttributeExpr
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(names) < 2 {
+		eval.ReportError("invalid %s validation definition: at least two attribute names are required", validation)
+		return
+	}
+	if at.Type != nil && !expr.IsObject(at.Type) {
+		incompatibleAttributeType(validation, at.Type.Name(), "an object")
+		return
+	}
+	if at.Validation == nil {
+		at.Validation = &expr.ValidationExpr{}
+	}
+	add(at.Validation)
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
@@ -81,3 +81,71 @@ func TestRequired(t *testing.T) {
 		t.Errorf("Required invalid on %+v, expected foo, got %+v", uattr, uattr.Validation.Required)
 	}
 }
+
+func TestArrayItems(t *testing.T) {
+	cases := map[string]struct {
+		Type      expr.DataType
+		DSL       func()
+		Min, Max  int
+		Unique    bool
+		ErrExpect bool
+	}{
+		"items":      {&expr.Array{ElemType: &expr.AttributeExpr{Type: String}}, func() { MinItems(1); MaxItems(3); UniqueItems() }, 1, 3, true, false},
+		"not-array":  {String, func() { UniqueItems() }, 0, 0, false, true},
+		"min-string": {String, func() { MinItems(1) }, 0, 0, false, true},
+	}
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(tc.DSL, att)
+		if tc.ErrExpect {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: expected error", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Fatalf("%s: unexpected error %s", k, eval.Context.Errors)
+		}
+		val := att.Validation
+		if val == nil || val.MinLength == nil || *val.MinLength != tc.Min || val.MaxLength == nil || *val.MaxLength != tc.Max || val.UniqueItems != tc.Unique {
+			t.Errorf("%s: invalid validation %+v", k, val)
+		}
+	}
+}
+
+func TestValidateWith(t *testing.T) {
+	cases := map[string]struct {
+		Type      expr.DataType
+		DSL       func()
+		Name      string
+		Path      string
+		ErrExpect bool
+	}{
+		"local":       {Int, func() { ValidateWith("ValidateVintage") }, "ValidateVintage", "", false},
+		"qualified":   {String, func() { ValidateWith("v.ValidateName", "example.com/v") }, "v.ValidateName", "example.com/v", false},
+		"unqualified": {String, func() { ValidateWith("ValidateName", "example.com/v") }, "", "", true},
+		"empty":       {String, func() { ValidateWith("") }, "", "", true},
+		"object":      {&expr.Object{}, func() { ValidateWith("ValidateObject") }, "", "", true},
+	}
+	for k, tc := range cases {
+		eval.Context = &eval.DSLContext{}
+		att := &expr.AttributeExpr{Type: tc.Type}
+		eval.Execute(tc.DSL, att)
+		if tc.ErrExpect {
+			if eval.Context.Errors == nil {
+				t.Errorf("%s: expected error", k)
+			}
+			continue
+		}
+		if eval.Context.Errors != nil {
+			t.Fatalf("%s: unexpected error %s", k, eval.Context.Errors)
+		}
+		if att.Validation == nil || len(att.Validation.Funcs) != 1 {
+			t.Fatalf("%s: invalid validation %+v", k, att.Validation)
+		}
+		if f := att.Validation.Funcs[0]; f.Name != tc.Name || f.Path != tc.Path {
+			t.Errorf("%s: got function %+v, expected %s (%s)", k, f, tc.Name, tc.Path)
+		}
+	}
+}
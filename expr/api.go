@@ -19,8 +19,17 @@ type (
 		Description string
 		// Version is the version of the API described by this DSL.
 		Version string
+		// MediaTypePrefix is the prefix of the identifiers of the result
+		// types that do not define a media type.
+		MediaTypePrefix string
 		// Servers lists the API hosts.
 		Servers []*ServerExpr
+		// Profiles lists the environment specific overrides of the API
+		// servers and security requirements.
+		Profiles []*ProfileExpr
+		// Services lists the names of the services exposed by the API,
+		// all the services of the design if empty.
+		Services []string
 		// TermsOfService describes or links to the service terms of API.
 		TermsOfService string
 		// Contact provides the API users with contact information.
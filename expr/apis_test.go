@@ -0,0 +1,144 @@
+package expr_test
+
+import (
+	"testing"
+
+	. "goa.design/goa/v3/dsl"
+	"goa.design/goa/v3/expr"
+)
+
+func TestRootExprSelectAPI(t *testing.T) {
+	cases := []struct {
+		Name             string
+		APIName          string
+		ExpectedAPI      string
+		ExpectedServices []string
+	}{
+		{"default", "", "public", []string{"users", "audit"}},
+		{"public", "public", "public", []string{"users", "audit"}},
+		{"admin", "admin", "admin", []string{"audit"}},
+	}
+	for _, c := range cases {
+		apiName := c.APIName
+		t.Run(c.Name, func(t *testing.T) {
+			root := expr.RunDSL(t, func() {
+				multipleAPIsDSL()
+				expr.Root.APIName = apiName
+			})
+			if root.API.Name != c.ExpectedAPI {
+				t.Errorf("got API %q, expected %q", root.API.Name, c.ExpectedAPI)
+			}
+			if len(root.Services) != len(c.ExpectedServices) {
+				t.Fatalf("got %d services, expected %d", len(root.Services), len(c.ExpectedServices))
+			}
+			for i, s := range root.Services {
+				if s.Name != c.ExpectedServices[i] {
+					t.Errorf("got service %q at index %d, expected %q", s.Name, i, c.ExpectedServices[i])
+				}
+			}
+			if len(root.API.HTTP.Services) != len(c.ExpectedServices) {
+				t.Errorf("got %d HTTP services, expected %d", len(root.API.HTTP.Services), len(c.ExpectedServices))
+			}
+		})
+	}
+}
+
+func TestRootExprSelectAPIInvalid(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		APIName string
+		Error   string
+	}{
+		{"unknown API", multipleAPIsDSL, "internal", `design: API "internal" is not defined`},
+		{"unknown service", func() {
+			API("admin", func() {
+				Services("unknown")
+			})
+		}, "", `design: API "admin" exposes service "unknown" which is not defined`},
+	}
+	for _, c := range cases {
+		dsl, apiName, expected := c.DSL, c.APIName, c.Error
+		t.Run(c.Name, func(t *testing.T) {
+			err := expr.RunInvalidDSL(t, func() {
+				dsl()
+				expr.Root.APIName = apiName
+			})
+			if err == nil || err.Error() != expected {
+				t.Errorf("got error %v, expected %q", err, expected)
+			}
+		})
+	}
+}
+
+func TestDefaultMediaTypePrefix(t *testing.T) {
+	root := expr.RunDSL(t, func() {
+		API("cellar", func() {
+			DefaultMediaTypePrefix("application/vnd.acme")
+		})
+		var Bottle = ResultType("bottle", func() {
+			Attribute("name", String)
+		})
+		var Bottles = CollectionOf(Bottle)
+		var Versioned = ResultType("bottle.v2+json; version=2", func() {
+			Attribute("name", String)
+		})
+		var Other = ResultType("application/vnd.other.bottle", func() {
+			Attribute("name", String)
+		})
+		Service("cellar", func() {
+			Method("list", func() {
+				Result(Bottles)
+			})
+			Method("show", func() {
+				Result(Versioned)
+			})
+			Method("other", func() {
+				Result(Other)
+			})
+		})
+	})
+	expected := map[string]string{
+		"list":  "application/vnd.acme.bottle; type=collection",
+		"show":  "application/vnd.acme.bottle.v2+json; version=2",
+		"other": "application/vnd.other.bottle",
+	}
+	for _, m := range root.Service("cellar").Methods {
+		rt, ok := m.Result.Type.(*expr.ResultTypeExpr)
+		if !ok {
+			t.Fatalf("%s: result is not a result type", m.Name)
+		}
+		if rt.Identifier != expected[m.Name] {
+			t.Errorf("%s: got identifier %q, expected %q", m.Name, rt.Identifier, expected[m.Name])
+		}
+	}
+	if n := root.Service("cellar").Method("show").Result.Type.Name(); n != "BottleV2" {
+		t.Errorf("got type name %q, expected %q", n, "BottleV2")
+	}
+}
+
+var multipleAPIsDSL = func() {
+	API("public", func() {})
+	API("admin", func() {
+		Services("audit")
+	})
+	var User = Type("User", func() {
+		Attribute("name", String)
+	})
+	Service("users", func() {
+		Method("show", func() {
+			Result(User)
+			HTTP(func() {
+				GET("/users")
+			})
+		})
+	})
+	Service("audit", func() {
+		Method("list", func() {
+			Result(ArrayOf(User))
+			HTTP(func() {
+				GET("/audit")
+			})
+		})
+	})
+}
@@ -95,10 +95,33 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor26.
 		MaxLength *int
+		// UniqueItems represents a uniqueItems validation as described
+		// at
+		// http://json-schema.org/draft/2019-09/json-schema-validation.html#rfc.section.6.4.3.
+		UniqueItems bool
 		// Required list the required fields of object attributes as
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
+		// RequiredTogether lists groups of object attributes that must
+		// either all be set or all be omitted.
+		RequiredTogether [][]string
+		// Mutex lists groups of object attributes of which at most one
+		// may be set.
+		Mutex [][]string
+		// Funcs lists the user provided functions called by the
+		// generated code after the built-in validations.
+		Funcs []*ValidationFuncExpr
+	}
+
+	// ValidationFuncExpr describes a user provided validation function.
+	ValidationFuncExpr struct {
+		// Name is the name of the function, qualified with the package
+		// name if Path is not empty.
+		Name string
+		// Path is the import path of the package that defines the
+		// function if not the package of the generated code.
+		Path string
 	}
 
 	// ValidationFormat is the type used to enumerate the possible string
@@ -200,15 +223,58 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		ctx += " - "
 	}
 	verr.Merge(a.validateEnumDefault(ctx, parent))
+	if names, ok := a.Meta["enum:names"]; ok {
+		val := a.Validation
+		if ut, ok := a.Type.(UserType); ok && val == nil {
+			val = ut.Attribute().Validation
+		}
+		if val == nil || len(val.Values) != len(names) {
+			verr.Add(parent, "%senum names must list one name per enum value", ctx)
+		}
+	}
 	if o := AsObject(a.Type); o != nil {
 		for _, n := range a.AllRequired() {
 			if a.Find(n) == nil {
 				verr.Add(parent, `%srequired field %q does not exist in type %s`, ctx, n, a.Type.Name())
 			}
 		}
+		if a.Validation != nil {
+			groups := append(append([][]string{}, a.Validation.RequiredTogether...), a.Validation.Mutex...)
+			for _, g := range groups {
+				for _, n := range g {
+					if a.Find(n) == nil {
+						verr.Add(parent, `%sfield %q used in validation does not exist in type %s`, ctx, n, a.Type.Name())
+					}
+				}
+			}
+		}
 		for _, nat := range *o {
 			ctx = fmt.Sprintf("field %s", nat.Name)
 			verr.Merge(nat.Attribute.Validate(ctx, parent))
+			if wn := a.WireName(nat.Name); wn != nat.Name {
+				for _, other := range *o {
+					if other != nat && (other.Name == wn || a.WireName(other.Name) == wn) {
+						verr.Add(parent, "%s: wire name %q conflicts with attribute %q", ctx, wn, other.Name)
+					}
+				}
+			}
+			if _, ok := nat.Attribute.Meta["computed"]; ok {
+				from := nat.Attribute.Meta["computed:from"]
+				if len(from) == 0 {
+					verr.Add(parent, "%s: computed attribute must list the attributes it is derived from with FromAttributes", ctx)
+				}
+				for _, n := range from {
+					src := o.Attribute(n)
+					if src == nil {
+						verr.Add(parent, "%s: computed attribute is derived from %q which does not exist in type %s", ctx, n, a.Type.Name())
+					} else if _, ok := src.Meta["computed"]; ok || n == nat.Name {
+						verr.Add(parent, "%s: computed attribute cannot be derived from computed attribute %q", ctx, n)
+					}
+				}
+			}
+			if _, ok := nat.Attribute.Meta["nullable"]; ok && a.IsRequired(nat.Name) {
+				verr.Add(parent, "%s: nullable attribute cannot be required", ctx)
+			}
 		}
 	} else if ar := AsArray(a.Type); ar != nil {
 		elemType := ar.ElemType
@@ -222,6 +288,27 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 				verr.Add(parent, "union type %s has map elements, not supported by gRPC", u.Name())
 			}
 		}
+		if u.Discriminator != "" {
+			hashes := make(map[string]string)
+			for _, ut := range u.Values {
+				o := AsObject(ut.Attribute.Type)
+				if o == nil {
+					verr.Add(parent, "union type %s has discriminator %q but type %s of %q is not an object", u.Name(), u.Discriminator, ut.Attribute.Type.Name(), ut.Name)
+					continue
+				}
+				for _, nat := range *o {
+					if nat.Name == u.Discriminator {
+						verr.Add(parent, "union type %s: discriminator %q conflicts with attribute of %q", u.Name(), u.Discriminator, ut.Name)
+						continue
+					}
+					h := nat.Attribute.Type.Hash()
+					if prev, ok := hashes[nat.Name]; ok && prev != h {
+						verr.Add(parent, "union type %s: attribute %q of %q has a different type than in the other union types", u.Name(), nat.Name, ut.Name)
+					}
+					hashes[nat.Name] = h
+				}
+			}
+		}
 	}
 
 	if views, ok := a.Meta["view"]; ok {
@@ -553,6 +640,44 @@ func (a *AttributeExpr) Delete(name string) {
 	}
 }
 
+// ComputedFrom returns the names of the attributes the attribute is derived
+// from if the attribute is computed, see dsl.Computed.
+func (a *AttributeExpr) ComputedFrom() ([]string, bool) {
+	if _, ok := a.Meta["computed"]; !ok {
+		return nil, false
+	}
+	return a.Meta["computed:from"], true
+}
+
+// WireName returns the name used on the wire for the child attribute of a with
+// the given name, see dsl.WireName. WireName returns name if a is not an object,
+// has no such child or if the child does not define a wire name.
+func (a *AttributeExpr) WireName(name string) string {
+	obj := AsObject(a.Type)
+	if obj == nil {
+		return name
+	}
+	if att := obj.Attribute(name); att != nil {
+		if wn, ok := att.Meta.Last("wire:name"); ok {
+			return wn
+		}
+	}
+	return name
+}
+
+// WireNames returns the wire names of the child attributes of a with the given
+// names, see WireName.
+func (a *AttributeExpr) WireNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	res := make([]string, len(names))
+	for i, n := range names {
+		res[i] = a.WireName(n)
+	}
+	return res
+}
+
 // AddMeta adds values to the meta field of the attribute.
 func (a *AttributeExpr) AddMeta(name string, vals ...string) {
 	if a.Meta == nil {
@@ -772,7 +897,40 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.MaxLength == nil || (other.MaxLength != nil && *v.MaxLength < *other.MaxLength) {
 		v.MaxLength = other.MaxLength
 	}
+	v.UniqueItems = v.UniqueItems || other.UniqueItems
 	v.AddRequired(other.Required...)
+	v.RequiredTogether = mergeGroups(v.RequiredTogether, other.RequiredTogether)
+	v.Mutex = mergeGroups(v.Mutex, other.Mutex)
+	for _, f := range other.Funcs {
+		found := false
+		for _, vf := range v.Funcs {
+			if f.Name == vf.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.Funcs = append(v.Funcs, f)
+		}
+	}
+}
+
+// mergeGroups appends the attribute name groups in other that are not already
+// in groups.
+func mergeGroups(groups, other [][]string) [][]string {
+	for _, o := range other {
+		found := false
+		for _, g := range groups {
+			if strings.Join(g, ",") == strings.Join(o, ",") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, o)
+		}
+	}
+	return groups
 }
 
 // AddRequired merges the required fields into v.
@@ -815,7 +973,11 @@ func (v *ValidationExpr) HasRequiredOnly() bool {
 		(v.ExclusiveMaximum != nil) ||
 		(v.Maximum != nil) ||
 		(v.MinLength != nil) ||
-		(v.MaxLength != nil) {
+		(v.MaxLength != nil) ||
+		v.UniqueItems {
+		return false
+	}
+	if len(v.RequiredTogether) > 0 || len(v.Mutex) > 0 || len(v.Funcs) > 0 {
 		return false
 	}
 	return true
@@ -838,7 +1000,11 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		Maximum:          v.Maximum,
 		MinLength:        v.MinLength,
 		MaxLength:        v.MaxLength,
+		UniqueItems:      v.UniqueItems,
 		Required:         req,
+		RequiredTogether: v.RequiredTogether,
+		Mutex:            v.Mutex,
+		Funcs:            v.Funcs,
 	}
 }
 
@@ -875,9 +1041,21 @@ func (v *ValidationExpr) Debug(title, prefix, indent string) {
 	if v.MaxLength != nil {
 		fmt.Printf("%s%s- maxLength: %v\n", prefix, indent, *v.MaxLength)
 	}
+	if v.UniqueItems {
+		fmt.Printf("%s%s- uniqueItems\n", prefix, indent)
+	}
 	if len(v.Required) > 0 {
 		fmt.Printf("%s%s- required: %v\n", prefix, indent, v.Required)
 	}
+	for _, g := range v.RequiredTogether {
+		fmt.Printf("%s%s- required together: %v\n", prefix, indent, g)
+	}
+	for _, g := range v.Mutex {
+		fmt.Printf("%s%s- mutex: %v\n", prefix, indent, g)
+	}
+	for _, f := range v.Funcs {
+		fmt.Printf("%s%s- func: %s\n", prefix, indent, f.Name)
+	}
 }
 
 // IsSupportedValidationFormat checks if the validation format is supported by goa.
@@ -124,6 +124,25 @@ func TestAttributeExprValidate(t *testing.T) {
 		errRequiredFieldNotExist = fmt.Errorf(`%srequired field %q does not exist in type %s`, normalizedCtx, "foo", fieldNotExistType.Name())
 		errViewButNotAResultType = fmt.Errorf("%s uses view %q but %q is not a result type", normalizedCtx, metadata["view"][0], notAResultType.Name())
 		errTypeNotDefineView     = fmt.Errorf("%s: type %q does not define view %q", normalizedCtx, viewNotDefinedTypeName, "foo")
+		errWireNameConflict      = fmt.Errorf("field %s: wire name %q conflicts with attribute %q", "foo", "bar", "bar")
+		errComputedNoSource      = fmt.Errorf("field %s: computed attribute must list the attributes it is derived from with FromAttributes", "foo")
+		errComputedSourceMissing = fmt.Errorf("field %s: computed attribute is derived from %q which does not exist in type %s", "foo", "baz", "object")
+		errComputedFromComputed  = fmt.Errorf("field %s: computed attribute cannot be derived from computed attribute %q", "foo", "foo")
+		errNullableRequired      = fmt.Errorf("field %s: nullable attribute cannot be required", "foo")
+		errDiscriminatorObject   = fmt.Errorf("union type %s has discriminator %q but type %s of %q is not an object", "pet", "kind", "string", "name")
+		errDiscriminatorConflict = fmt.Errorf("union type %s: discriminator %q conflicts with attribute of %q", "pet", "kind", "cat")
+		errDiscriminatorType     = fmt.Errorf("union type %s: attribute %q of %q has a different type than in the other union types", "pet", "name", "dog")
+
+		pet = func(discriminator string, values ...*NamedAttributeExpr) *Union {
+			return &Union{TypeName: "pet", Discriminator: discriminator, Values: values}
+		}
+		value = func(name string, fields ...*NamedAttributeExpr) *NamedAttributeExpr {
+			obj := Object(fields)
+			return &NamedAttributeExpr{Name: name, Attribute: &AttributeExpr{Type: &obj}}
+		}
+		field = func(name string, dt DataType) *NamedAttributeExpr {
+			return &NamedAttributeExpr{Name: name, Attribute: &AttributeExpr{Type: dt}}
+		}
 	)
 	cases := map[string]struct {
 		typ        DataType
@@ -284,6 +303,74 @@ func TestAttributeExprValidate(t *testing.T) {
 			metadata: metadata,
 			expected: &eval.ValidationErrors{Errors: []error{errTypeNotDefineView}},
 		},
+		"wire name": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"wire:name": {"fooBar"}}}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+			},
+			expected: &eval.ValidationErrors{},
+		},
+		"wire name conflict": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"wire:name": {"bar"}}}},
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errWireNameConflict}},
+		},
+		"computed attribute": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: String}},
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"computed": nil, "computed:from": {"bar"}}}},
+			},
+			expected: &eval.ValidationErrors{},
+		},
+		"computed attribute without source": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"computed": nil}}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errComputedNoSource}},
+		},
+		"computed attribute source does not exist": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"computed": nil, "computed:from": {"baz"}}}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errComputedSourceMissing}},
+		},
+		"computed attribute derived from itself": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"computed": nil, "computed:from": {"foo"}}}},
+			},
+			expected: &eval.ValidationErrors{Errors: []error{errComputedFromComputed}},
+		},
+		"nullable attribute": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"nullable": nil}}},
+			},
+			expected: &eval.ValidationErrors{},
+		},
+		"required nullable attribute": {
+			typ: &Object{
+				&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: String, Meta: MetaExpr{"nullable": nil}}},
+			},
+			validation: &ValidationExpr{Required: []string{"foo"}},
+			expected:   &eval.ValidationErrors{Errors: []error{errNullableRequired}},
+		},
+		"union discriminator": {
+			typ:      pet("kind", value("cat", field("name", String), field("lives", Int)), value("dog", field("name", String))),
+			expected: &eval.ValidationErrors{},
+		},
+		"union discriminator with non object": {
+			typ:      pet("kind", value("cat", field("name", String)), &NamedAttributeExpr{Name: "name", Attribute: &AttributeExpr{Type: String}}),
+			expected: &eval.ValidationErrors{Errors: []error{errDiscriminatorObject}},
+		},
+		"union discriminator conflict": {
+			typ:      pet("kind", value("cat", field("kind", String)), value("dog", field("name", String))),
+			expected: &eval.ValidationErrors{Errors: []error{errDiscriminatorConflict}},
+		},
+		"union discriminator type mismatch": {
+			typ:      pet("kind", value("cat", field("name", String)), value("dog", field("name", Int))),
+			expected: &eval.ValidationErrors{Errors: []error{errDiscriminatorType}},
+		},
 	}
 
 	for k, tc := range cases {
@@ -3,6 +3,7 @@ package expr
 import (
 	"fmt"
 	"math"
+	"reflect"
 	"regexp"
 	"time"
 
@@ -33,6 +34,14 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 		return nil
 	}
 
+	// the example of a union serialized inline is the example of one of its
+	// values with the discriminator set, see DiscriminatedUnionObject
+	if len(a.Bases) == 1 && IsObject(a.Type) {
+		if u := AsUnion(a.Bases[0]); u != nil && u.Discriminator != "" && len(u.Values) > 0 {
+			return DiscriminatedExample(u, u.Values[r.Int()%len(u.Values)], r)
+		}
+	}
+
 	// randomize array length first, since that's from higher level
 	if hasLengthValidation(a) {
 		return byLength(a, r)
@@ -78,6 +87,73 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 	return a.Type.Example(r)
 }
 
+// DiscriminatedExample returns an example of the value nat of the union u
+// serialized inline, that is the example of the value with the discriminator
+// attribute set to the name of the value.
+func DiscriminatedExample(u *Union, nat *NamedAttributeExpr, r *Random) interface{} {
+	ex := nat.Attribute.Example(r)
+	m, ok := ex.(map[string]interface{})
+	if !ok {
+		return ex
+	}
+	res := make(map[string]interface{}, len(m)+1)
+	for k, v := range m {
+		res[k] = v
+	}
+	res[u.Discriminator] = nat.Name
+	return res
+}
+
+// WireExample returns a copy of the example value ex of the attribute where the
+// keys of the object values use the wire names of the corresponding attributes,
+// see WireName. WireExample returns ex if it does not match the attribute type.
+func (a *AttributeExpr) WireExample(ex interface{}) interface{} {
+	switch t := a.Type.(type) {
+	case UserType:
+		return (&AttributeExpr{Type: t.Attribute().Type, Meta: a.Meta}).WireExample(ex)
+	case *Object:
+		m, ok := ex.(map[string]interface{})
+		if !ok {
+			return ex
+		}
+		res := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if att := t.Attribute(k); att != nil {
+				res[a.WireName(k)] = att.WireExample(v)
+				continue
+			}
+			res[k] = v
+		}
+		return res
+	case *Array:
+		v := reflect.ValueOf(ex)
+		if v.Kind() != reflect.Slice {
+			return ex
+		}
+		res := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res[i] = t.ElemType.WireExample(v.Index(i).Interface())
+		}
+		return res
+	case *Map:
+		v := reflect.ValueOf(ex)
+		if v.Kind() != reflect.Map {
+			return ex
+		}
+		res := reflect.MakeMap(v.Type())
+		iter := v.MapRange()
+		for iter.Next() {
+			w := reflect.ValueOf(t.ElemType.WireExample(iter.Value().Interface()))
+			if !w.IsValid() || !w.Type().AssignableTo(v.Type().Elem()) {
+				w = iter.Value()
+			}
+			res.SetMapIndex(iter.Key(), w)
+		}
+		return res.Interface()
+	}
+	return ex
+}
+
 // NewLength returns an int that validates the generator attribute length
 // validations if any.
 func NewLength(a *AttributeExpr, r *Random) int {
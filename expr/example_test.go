@@ -88,3 +88,60 @@ func TestExample(t *testing.T) {
 		})
 	}
 }
+
+func TestWireExample(t *testing.T) {
+	child := &expr.Object{
+		{Name: "self_url", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"wire:name": {"selfURL"}}}},
+	}
+	att := &expr.AttributeExpr{Type: &expr.Object{
+		{Name: "display_name", Attribute: &expr.AttributeExpr{Type: expr.String, Meta: expr.MetaExpr{"wire:name": {"displayName"}}}},
+		{Name: "links", Attribute: &expr.AttributeExpr{Type: &expr.Array{ElemType: &expr.AttributeExpr{Type: child}}}},
+		{Name: "age", Attribute: &expr.AttributeExpr{Type: expr.Int}},
+	}}
+	ex := map[string]interface{}{
+		"display_name": "name",
+		"links":        []interface{}{map[string]interface{}{"self_url": "url"}},
+		"age":          1,
+	}
+	expected := map[string]interface{}{
+		"displayName": "name",
+		"links":       []interface{}{map[string]interface{}{"selfURL": "url"}},
+		"age":         1,
+	}
+	if got := att.WireExample(ex); !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+	if _, ok := ex["display_name"]; !ok {
+		t.Error("WireExample modified the given example")
+	}
+}
+
+func TestDiscriminatedExample(t *testing.T) {
+	cat := &expr.AttributeExpr{Type: &expr.Object{
+		{Name: "lives", Attribute: &expr.AttributeExpr{Type: expr.Int, UserExamples: []*expr.ExampleExpr{{Value: 9}}}},
+	}}
+	dog := &expr.AttributeExpr{Type: &expr.Object{
+		{Name: "barks", Attribute: &expr.AttributeExpr{Type: expr.Boolean, UserExamples: []*expr.ExampleExpr{{Value: true}}}},
+	}}
+	u := &expr.Union{TypeName: "pet", Discriminator: "kind", Values: []*expr.NamedAttributeExpr{
+		{Name: "cat", Attribute: cat},
+		{Name: "dog", Attribute: dog},
+	}}
+	att := expr.DiscriminatedUnionObject(u)
+	att.Bases = []expr.DataType{u}
+	expected := map[string]map[string]interface{}{
+		"cat": {"kind": "cat", "lives": 9},
+		"dog": {"kind": "dog", "barks": true},
+	}
+	r := expr.NewRandom("test")
+	for i := 0; i < 10; i++ {
+		ex, ok := att.Example(r).(map[string]interface{})
+		if !ok {
+			t.Fatalf("got example %v, expected an object", ex)
+		}
+		kind, _ := ex["kind"].(string)
+		if !reflect.DeepEqual(ex, expected[kind]) {
+			t.Errorf("got example %v, expected one of %v", ex, expected)
+		}
+	}
+}
@@ -255,9 +255,10 @@ func (e *GRPCEndpointExpr) Finalize() {
 				dupReq := DupRequirement(req)
 				for _, sch := range dupReq.Schemes {
 					var field string
-					switch sch.Kind {
-					case NoKind:
+					if sch.Kind == NoKind || sch.IsTransport() {
 						continue
+					}
+					switch sch.Kind {
 					case BasicAuthKind:
 						field = TaggedAttribute(e.MethodExpr.Payload, "security:username")
 						sch.Name, sch.In = findKey(e, field)
@@ -528,11 +529,12 @@ func getSecurityAttributes(m *MethodExpr) []string {
 }
 
 // hasAnyType recurses through the given attribute and returns validation error
-// if any attribute is of Any type.
+// if any attribute is of Any type or of a type that has no protocol buffer
+// equivalent.
 func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[string]struct{}) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
-	if a.Type == Any {
-		verr.Add(e, "%s type is Any type which is not supported in gRPC", typ)
+	if n := unsupportedGRPCType(a.Type); n != "" {
+		verr.Add(e, "%s type is %s type which is not supported in gRPC", typ, n)
 	}
 	switch actual := a.Type.(type) {
 	case UserType:
@@ -550,23 +552,23 @@ func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[
 		verr.Merge(e.hasAnyType(actual.Attribute(), typ, seen...))
 	case *Array:
 		if IsPrimitive(actual.ElemType.Type) {
-			if actual.ElemType.Type == Any {
-				verr.Add(e, "Array element type is Any type which is not supported in gRPC")
+			if n := unsupportedGRPCType(actual.ElemType.Type); n != "" {
+				verr.Add(e, "Array element type is %s type which is not supported in gRPC", n)
 			}
 			return verr
 		}
 		verr.Merge(e.hasAnyType(actual.ElemType, typ, seen...))
 	case *Map:
 		if IsPrimitive(actual.KeyType.Type) {
-			if actual.KeyType.Type == Any {
-				verr.Add(e, "Map key type is Any type which is not supported in gRPC")
+			if n := unsupportedGRPCType(actual.KeyType.Type); n != "" {
+				verr.Add(e, "Map key type is %s type which is not supported in gRPC", n)
 			}
 		} else {
 			verr.Merge(e.hasAnyType(actual.KeyType, typ, seen...))
 		}
 		if IsPrimitive(actual.ElemType.Type) {
-			if actual.ElemType.Type == Any {
-				verr.Add(e, "Map element type is Any type which is not supported in gRPC")
+			if n := unsupportedGRPCType(actual.ElemType.Type); n != "" {
+				verr.Add(e, "Map element type is %s type which is not supported in gRPC", n)
 			}
 			return verr
 		}
@@ -574,8 +576,8 @@ func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[
 	case *Object:
 		for _, nat := range *actual {
 			if IsPrimitive(nat.Attribute.Type) {
-				if nat.Attribute.Type == Any {
-					verr.Add(e, "Attribute %q is Any type which is not supported in gRPC", nat.Name)
+				if n := unsupportedGRPCType(nat.Attribute.Type); n != "" {
+					verr.Add(e, "Attribute %q is %s type which is not supported in gRPC", nat.Name, n)
 				}
 				continue
 			}
@@ -585,6 +587,26 @@ func (e *GRPCEndpointExpr) hasAnyType(a *AttributeExpr, typ string, seen ...map[
 	return verr
 }
 
+// unsupportedGRPCType returns the DSL name of the given type if it is a
+// primitive type that cannot be used with gRPC, the empty string otherwise.
+func unsupportedGRPCType(dt DataType) string {
+	switch dt {
+	case Any:
+		return "Any"
+	case DateTime:
+		return "DateTime"
+	case Date:
+		return "Date"
+	case Duration:
+		return "Duration"
+	case UUID:
+		return "UUID"
+	case Decimal:
+		return "Decimal"
+	}
+	return ""
+}
+
 func setZero(att *AttributeExpr, seen ...map[string]struct{}) {
 	if att.Type == Empty {
 		return
@@ -23,6 +23,14 @@ service "Service" gRPC endpoint "Method": Error "invalid_error_type" type is Any
 service "Service" gRPC endpoint "Method": Map element type is Any type which is not supported in gRPC`,
 			},
 		},
+		"endpoint-with-time-types": {
+			DSL: testdata.GRPCEndpointWithTimeTypes,
+			Errors: []string{`service "Service" gRPC endpoint "Method": Attribute "id" is UUID type which is not supported in gRPC
+service "Service" gRPC endpoint "Method": Attribute "since" is DateTime type which is not supported in gRPC
+service "Service" gRPC endpoint "Method": Array element type is Date type which is not supported in gRPC
+service "Service" gRPC endpoint "Method": Result type is Duration type which is not supported in gRPC`,
+			},
+		},
 		"endpoint-with-untagged-fields": {
 			DSL: testdata.GRPCEndpointWithUntaggedFields,
 			Errors: []string{`service "Service" gRPC endpoint "Method": attribute "req_not_field" does not have "rpc:tag" defined in the meta, use "Field" to define the attribute of a type used in a gRPC method
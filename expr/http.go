@@ -25,10 +25,25 @@ type (
 		// Produces lists the mime types generated by the API
 		// controllers.
 		Produces []string
+		// StrictPayload is the mode used to decode request bodies that
+		// contain unknown fields, one of "reject", "warn" or "off".
+		// Empty means "off".
+		StrictPayload string
+		// Envelope describes the object that wraps all the HTTP
+		// response bodies if any. The "data" attribute holds the
+		// success response bodies and the optional "errors" attribute
+		// holds the error response bodies.
+		Envelope *AttributeExpr
+		// BatchPath is the path of the batch endpoint if any, see
+		// dsl.Batch.
+		BatchPath string
 		// Services contains the services created by the DSL.
 		Services []*HTTPServiceExpr
 		// Errors lists the error HTTP responses.
 		Errors []*HTTPErrorExpr
+		// consumesSet is true if Consumes was set explicitly in the
+		// design in which case the request content types are enforced.
+		consumesSet bool
 	}
 )
 
@@ -36,6 +51,9 @@ type (
 // parameters.
 var HTTPWildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)}`)
 
+// httpMethodRegex is the regular expression used to validate HTTP methods.
+var httpMethodRegex = regexp.MustCompile("^[!#$%&'*+\\-.^_`|~0-9A-Za-z]+$")
+
 // ExtractHTTPWildcards returns the names of the wildcards that appear in
 // a HTTP path.
 func ExtractHTTPWildcards(path string) []string {
@@ -77,6 +95,7 @@ func (h *HTTPExpr) EvalName() string {
 
 // Finalize initializes Consumes and Produces with defaults if not set.
 func (h *HTTPExpr) Finalize() {
+	h.consumesSet = len(h.Consumes) > 0
 	if len(h.Consumes) == 0 {
 		h.Consumes = []string{"application/json", "application/xml", "application/gob"}
 	}
@@ -0,0 +1,41 @@
+package expr
+
+import (
+	"fmt"
+)
+
+type (
+	// HTTPRouteAliasExpr defines a legacy path mounted by the server in
+	// addition to the endpoint routes.
+	HTTPRouteAliasExpr struct {
+		// Path is the legacy path using the route wildcard syntax, e.g.
+		// "/old/bottles/{id}".
+		Path string
+		// StatusCode is the status code of the redirect to the endpoint
+		// route, 0 if the requests made to the legacy path are handled
+		// by the endpoint.
+		StatusCode int
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (a *HTTPRouteAliasExpr) EvalName() string {
+	suffix := fmt.Sprintf("alias %q", a.Path)
+	if a.Endpoint != nil {
+		return a.Endpoint.EvalName() + " " + suffix
+	}
+	return suffix
+}
+
+// FullPaths returns the legacy full paths computed the same way as the
+// endpoint route full paths.
+func (a *HTTPRouteAliasExpr) FullPaths() []string {
+	return (&RouteExpr{Path: a.Path, Endpoint: a.Endpoint}).FullPaths()
+}
+
+// Params returns the names of the legacy path parameters.
+func (a *HTTPRouteAliasExpr) Params() []string {
+	return (&RouteExpr{Path: a.Path, Endpoint: a.Endpoint}).Params()
+}
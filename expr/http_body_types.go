@@ -24,9 +24,10 @@ func defaultRequestHeaderAttributes(e *HTTPEndpointExpr) map[string]bool {
 	for _, req := range e.MethodExpr.Requirements {
 		for _, sch := range req.Schemes {
 			var field string
-			switch sch.Kind {
-			case NoKind:
+			if sch.Kind == NoKind || sch.IsTransport() {
 				continue
+			}
+			switch sch.Kind {
 			case BasicAuthKind:
 				user := TaggedAttribute(e.MethodExpr.Payload, "security:username")
 				if name, _ := findKey(e, user); name == "" {
@@ -292,30 +293,70 @@ func buildHTTPResponseBody(name string, attr *AttributeExpr, resp *HTTPResponseE
 	}
 }
 
-// unionToObject returns an object adequate to serialize the given union type.
-func unionToObject(att *AttributeExpr, name, suffix, svcName string) *AttributeExpr {
-	values := AsUnion(att.Type).Values
-	names := make([]interface{}, len(values))
-	vals := make([]string, len(values))
-	for i, nat := range values {
-		names[i] = nat.Attribute.Type.Name()
-		vals[i] = fmt.Sprintf("- %q", nat.Attribute.Type.Name())
+// DiscriminatedUnionObject returns the object used to serialize the values of
+// the given union inline. The object starts with the discriminator attribute
+// which holds the name of the value type and lists the attributes of all the
+// union types next. u must have a discriminator.
+func DiscriminatedUnionObject(u *Union) *AttributeExpr {
+	names := make([]interface{}, len(u.Values))
+	vals := make([]string, len(u.Values))
+	for i, nat := range u.Values {
+		names[i] = nat.Name
+		vals[i] = fmt.Sprintf("- %q", nat.Name)
 	}
 	obj := Object([]*NamedAttributeExpr{{
-		"Type", &AttributeExpr{
+		u.Discriminator, &AttributeExpr{
 			Type:        String,
 			Description: "Union type name, one of:\n" + strings.Join(vals, "\n"),
 			Validation:  &ValidationExpr{Values: names},
-		}}, {
-		"Value", &AttributeExpr{
-			Type:         String,
-			Description:  "JSON formatted union value",
-			UserExamples: []*ExampleExpr{{Value: `"JSON"`}},
 		}},
 	})
-	uatt := &AttributeExpr{
+	for _, nat := range u.Values {
+		o := AsObject(nat.Attribute.Type)
+		if o == nil {
+			continue
+		}
+		for _, f := range *o {
+			if obj.Attribute(f.Name) == nil {
+				obj = append(obj, &NamedAttributeExpr{Name: f.Name, Attribute: DupAtt(f.Attribute)})
+			}
+		}
+	}
+	return &AttributeExpr{
 		Type:       &obj,
-		Validation: &ValidationExpr{Required: []string{"Type", "Value"}},
+		Validation: &ValidationExpr{Required: []string{u.Discriminator}},
+	}
+}
+
+// unionToObject returns an object adequate to serialize the given union type.
+func unionToObject(att *AttributeExpr, name, suffix, svcName string) *AttributeExpr {
+	var uatt *AttributeExpr
+	if u := AsUnion(att.Type); u.Discriminator != "" {
+		uatt = DiscriminatedUnionObject(u)
+	} else {
+		values := u.Values
+		names := make([]interface{}, len(values))
+		vals := make([]string, len(values))
+		for i, nat := range values {
+			names[i] = nat.Attribute.Type.Name()
+			vals[i] = fmt.Sprintf("- %q", nat.Attribute.Type.Name())
+		}
+		obj := Object([]*NamedAttributeExpr{{
+			"Type", &AttributeExpr{
+				Type:        String,
+				Description: "Union type name, one of:\n" + strings.Join(vals, "\n"),
+				Validation:  &ValidationExpr{Values: names},
+			}}, {
+			"Value", &AttributeExpr{
+				Type:         String,
+				Description:  "JSON formatted union value",
+				UserExamples: []*ExampleExpr{{Value: `"JSON"`}},
+			}},
+		})
+		uatt = &AttributeExpr{
+			Type:       &obj,
+			Validation: &ValidationExpr{Required: []string{"Type", "Value"}},
+		}
 	}
 	ut := &UserTypeExpr{
 		AttributeExpr: uatt,
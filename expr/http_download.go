@@ -0,0 +1,28 @@
+package expr
+
+import (
+	"fmt"
+)
+
+type (
+	// HTTPDownloadExpr defines an endpoint whose response body is a file
+	// streamed by the service method.
+	HTTPDownloadExpr struct {
+		// ContentType is the MIME type of the file.
+		ContentType string
+		// Disposition is the Content-Disposition type, "attachment" or
+		// "inline".
+		Disposition string
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (d *HTTPDownloadExpr) EvalName() string {
+	suffix := fmt.Sprintf("download of %s", d.ContentType)
+	if d.Endpoint != nil {
+		return d.Endpoint.EvalName() + " " + suffix
+	}
+	return suffix
+}
@@ -2,6 +2,8 @@ package expr
 
 import (
 	"fmt"
+	"mime"
+	"net/url"
 	"path"
 	"strings"
 
@@ -42,6 +44,15 @@ type (
 		Cookies *MappedAttributeExpr
 		// Body describes the HTTP request body.
 		Body *AttributeExpr
+		// Consumes lists the mime types accepted in the request body.
+		// Finalize initializes it with the mime types listed in the
+		// service or API HTTP expressions if not set explicitly.
+		Consumes []string
+		// StrictPayload is the mode used to decode request bodies that
+		// contain unknown fields, see HTTPExpr. Finalize initializes it
+		// with the mode set in the service or API HTTP expressions if
+		// not set explicitly.
+		StrictPayload string
 		// StreamingBody describes the body transferred through the websocket
 		// stream.
 		StreamingBody *AttributeExpr
@@ -53,6 +64,11 @@ type (
 		// returns a reader and that the client accepts a reader to stream the
 		// response body.
 		SkipResponseBodyEncodeDecode bool
+		// StreamRequestBody indicates that the elements of the method
+		// streaming payload are sent as the elements of a JSON array in
+		// the HTTP request body instead of through a websocket
+		// connection.
+		StreamRequestBody bool
 		// Responses is the list of all the possible success HTTP
 		// responses.
 		Responses []*HTTPResponseExpr
@@ -66,6 +82,21 @@ type (
 		MultipartRequest bool
 		// Redirect defines a redirect for the endpoint.
 		Redirect *HTTPRedirectExpr
+		// Download describes the file streamed in the response body if
+		// the endpoint uses the Download DSL.
+		Download *HTTPDownloadExpr
+		// AcceptRanges indicates that the endpoint serves the byte ranges
+		// requested with the Range header.
+		AcceptRanges bool
+		// Push lists the paths of the resources pushed to HTTP/2 clients
+		// together with the endpoint success responses.
+		Push []string
+		// Aliases lists the legacy paths mounted by the server in
+		// addition to the endpoint routes.
+		Aliases []*HTTPRouteAliasExpr
+		// Proxy defines the backend the endpoint requests are forwarded
+		// to if any.
+		Proxy *HTTPProxyExpr
 		// Meta is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Meta.
 		Meta MetaExpr
@@ -122,6 +153,46 @@ func (e *HTTPEndpointExpr) HasAbsoluteRoutes() bool {
 	return true
 }
 
+// inheritBasePathParams adds the parameters defined in the API and service
+// base paths to the method payload if not already defined. The attributes
+// are initialized from the API and service params definitions, see Params.
+// Base path params are added to the payload only if the payload is empty or
+// an object.
+func (e *HTTPEndpointExpr) inheritBasePathParams() {
+	wcs := make(map[string]struct{})
+	for _, wc := range ExtractHTTPWildcards(Root.API.HTTP.Path) {
+		wcs[wc] = struct{}{}
+	}
+	for _, p := range e.Service.Paths {
+		for _, wc := range ExtractHTTPWildcards(p) {
+			wcs[wc] = struct{}{}
+		}
+	}
+	if len(wcs) == 0 {
+		return
+	}
+	payload := e.MethodExpr.Payload
+	if payload.Type != Empty && AsObject(payload.Type) == nil {
+		return
+	}
+	WalkMappedAttr(e.Params, func(name, elem string, att *AttributeExpr) error {
+		if _, ok := wcs[elem]; !ok {
+			return nil
+		}
+		if payload.Type == Empty {
+			payload.Type = &Object{}
+		}
+		if o := AsObject(payload.Type); o.Attribute(name) == nil {
+			o.Set(name, DupAtt(att))
+			if payload.Validation == nil {
+				payload.Validation = &ValidationExpr{}
+			}
+			payload.Validation.AddRequired(name)
+		}
+		return nil
+	})
+}
+
 // PathParams computes a mapped attribute containing the subset of e.Params that
 // describe path parameters.
 func (e *HTTPEndpointExpr) PathParams() *MappedAttributeExpr {
@@ -260,11 +331,18 @@ func (e *HTTPEndpointExpr) Prepare() {
 		}
 	}
 
+	// Inherit attributes for the API and service base path params
+	if !e.HasAbsoluteRoutes() {
+		e.inheritBasePathParams()
+	}
+
 	// Make sure there's a default success response if none define explicitly.
 	if len(e.Responses) == 0 {
 		status := StatusOK
 		if e.Redirect != nil {
 			status = e.Redirect.StatusCode
+		} else if e.MethodExpr.LongRunning != nil {
+			status = StatusAccepted
 		} else if e.MethodExpr.Result.Type == Empty && !e.SkipResponseBodyEncodeDecode {
 			status = StatusNoContent
 		}
@@ -352,6 +430,98 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if e.MethodExpr.IsResultStreaming() {
 			verr.Add(e, "Endpoint cannot use SkipRequestBodyEncodeDecode when method defines a StreamingResult. Use SkipResponseBodyEncodeDecode instead.")
 		}
+		if e.MethodExpr.Pagination != nil {
+			verr.Add(e, "Endpoint cannot use SkipRequestBodyEncodeDecode when method is paginated.")
+		}
+	}
+
+	// StreamRequestBody requires a streaming payload and a non-streaming
+	// result.
+	if e.StreamRequestBody {
+		if e.MethodExpr.Stream != ClientStreamKind {
+			verr.Add(e, "Endpoint cannot use StreamRequestBody unless method defines a StreamingPayload and no StreamingResult.")
+		}
+		if e.MultipartRequest {
+			verr.Add(e, "Endpoint cannot use StreamRequestBody and MultipartRequest.")
+		}
+	}
+
+	// Download requires a valid content type and disposition.
+	if d := e.Download; d != nil {
+		if _, _, err := mime.ParseMediaType(d.ContentType); err != nil {
+			verr.Add(d, "invalid content type %q: %s", d.ContentType, err)
+		}
+		if d.Disposition != "attachment" && d.Disposition != "inline" {
+			verr.Add(d, "invalid disposition %q, must be \"attachment\" or \"inline\"", d.Disposition)
+		}
+	}
+
+	// AcceptRanges requires a response body streamed by the service.
+	if e.AcceptRanges && !e.SkipResponseBodyEncodeDecode {
+		verr.Add(e, "Endpoint cannot use AcceptRanges unless it uses SkipResponseBodyEncodeDecode.")
+	}
+
+	// Push requires absolute paths and a response written by the server.
+	for _, p := range e.Push {
+		if !strings.HasPrefix(p, "/") {
+			verr.Add(e, "Push path %q must start with a slash.", p)
+		}
+	}
+	if len(e.Push) > 0 {
+		if e.Redirect != nil {
+			verr.Add(e, "Endpoint cannot use Push and Redirect.")
+		}
+		if e.MethodExpr.IsStreaming() && !e.StreamRequestBody {
+			verr.Add(e, "Endpoint cannot use Push when method uses a websocket stream.")
+		}
+	}
+
+	// Proxy requires an absolute HTTP backend URL and a unary endpoint
+	// whose responses are written by the backend.
+	if p := e.Proxy; p != nil {
+		u, err := url.Parse(p.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			verr.Add(p, "invalid backend URL %q, must be an absolute http or https URL", p.URL)
+		}
+		if e.Redirect != nil {
+			verr.Add(e, "Endpoint cannot use Proxy and Redirect.")
+		}
+		if len(e.Push) > 0 {
+			verr.Add(e, "Endpoint cannot use Proxy and Push.")
+		}
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "Endpoint cannot use Proxy when method uses streaming.")
+		}
+		if e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "Endpoint cannot use Proxy with SkipRequestBodyEncodeDecode or SkipResponseBodyEncodeDecode.")
+		}
+	}
+
+	// Aliases must define the same parameters as the endpoint routes.
+	for _, a := range e.Aliases {
+		switch a.StatusCode {
+		case 0, StatusMovedPermanently, StatusFound, StatusSeeOther, StatusTemporaryRedirect, StatusPermanentRedirect:
+		default:
+			verr.Add(a, "invalid redirect status code %d, must be one of 301, 302, 303, 307 or 308", a.StatusCode)
+		}
+		if len(e.Routes) == 0 {
+			continue
+		}
+		params := make(map[string]bool)
+		for _, p := range e.Routes[0].Params() {
+			params[p] = true
+		}
+		for _, p := range a.Params() {
+			if !params[p] {
+				verr.Add(a, "alias parameter %q is not a parameter of route %q", p, e.Routes[0].Path)
+			}
+			delete(params, p)
+		}
+		for _, p := range e.Routes[0].Params() {
+			if params[p] {
+				verr.Add(a, "alias is missing parameter %q of route %q", p, e.Routes[0].Path)
+			}
+		}
 	}
 
 	// SkipResponseBodyEncodeDecode is not compatible with gRPC or WebSocket.
@@ -367,6 +537,15 @@ func (e *HTTPEndpointExpr) Validate() error {
 		if e.MethodExpr.IsResultStreaming() {
 			verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method defines a StreamingResult.")
 		}
+		if e.MethodExpr.Pagination != nil {
+			verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method is paginated.")
+		}
+		if e.MethodExpr.Timeout > 0 {
+			verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method defines a timeout.")
+		}
+		if e.MethodExpr.LongRunning != nil {
+			verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method is long running.")
+		}
 		if rt, ok := e.MethodExpr.Result.Type.(*ResultTypeExpr); ok {
 			if len(rt.Views) > 1 {
 				verr.Add(e, "Endpoint cannot use SkipResponseBodyEncodeDecode when method result type defines multiple views.")
@@ -427,6 +606,18 @@ func (e *HTTPEndpointExpr) Validate() error {
 		}
 	}
 
+	// Kubernetes probes must be plain GET requests
+	if probes, ok := e.MethodExpr.Meta["k8s:probe"]; ok {
+		for _, p := range probes {
+			if p != "liveness" && p != "readiness" && p != "startup" {
+				verr.Add(e, "invalid k8s:probe value %q, must be one of \"liveness\", \"readiness\" or \"startup\"", p)
+			}
+		}
+		if len(e.Routes) > 0 && (e.Routes[0].Method != "GET" || len(e.Routes[0].Params()) > 0) {
+			verr.Add(e, "Endpoint used as Kubernetes probe must use a GET route with no path parameters.")
+		}
+	}
+
 	// Validate responses
 
 	// All responses but one must have tags for the same status code
@@ -655,6 +846,22 @@ func (e *HTTPEndpointExpr) Validate() error {
 // types so that the response encoding code can properly use the type to infer
 // the response that it needs to build.
 func (e *HTTPEndpointExpr) Finalize() {
+	// Inherit the request mime types from the service or API
+	if len(e.Consumes) == 0 {
+		e.Consumes = e.Service.Consumes
+	}
+	if len(e.Consumes) == 0 && Root.API.HTTP.consumesSet {
+		e.Consumes = Root.API.HTTP.Consumes
+	}
+
+	// Inherit the strict payload decoding mode from the service or API
+	if e.StrictPayload == "" {
+		e.StrictPayload = e.Service.StrictPayload
+	}
+	if e.StrictPayload == "" {
+		e.StrictPayload = Root.API.HTTP.StrictPayload
+	}
+
 	// Compute security scheme attribute name and corresponding HTTP location
 	if reqLen := len(e.MethodExpr.Requirements); reqLen > 0 {
 		e.Requirements = make([]*SecurityExpr, 0, reqLen)
@@ -665,10 +872,12 @@ func (e *HTTPEndpointExpr) Finalize() {
 				switch sch.Kind {
 				case NoKind:
 					continue
-				case BasicAuthKind:
+				case BasicAuthKind, SignatureKind:
 					sch.In = "header"
 					sch.Name = "Authorization"
 					continue
+				case MutualTLSKind:
+					continue
 				case APIKeyKind:
 					field = TaggedAttribute(e.MethodExpr.Payload, "security:apikey:"+sch.SchemeName)
 				case JWTKind:
@@ -697,12 +906,19 @@ func (e *HTTPEndpointExpr) Finalize() {
 	}
 
 	// Initialize the HTTP specific attributes with the corresponding
-	// payload attributes.
+	// payload attributes, the method request examples take precedence over
+	// the payload attribute examples.
+	initAttrExamples(e.Params, e.MethodExpr.Examples)
+	initAttrExamples(e.Headers, e.MethodExpr.Examples)
+	initAttrExamples(e.Cookies, e.MethodExpr.Examples)
 	initAttr(e.Params, e.MethodExpr.Payload)
 	initAttr(e.Headers, e.MethodExpr.Payload)
 	initAttr(e.Cookies, e.MethodExpr.Payload)
 
 	e.Body = httpRequestBody(e)
+	if exs := requestBodyExamples(e); len(exs) > 0 {
+		e.Body.UserExamples = exs
+	}
 	e.Body.Finalize()
 
 	e.StreamingBody = httpStreamingBody(e)
@@ -761,9 +977,35 @@ func (e *HTTPEndpointExpr) validateParams() *eval.ValidationErrors {
 		return nil
 	})
 	WalkMappedAttr(qparams, func(name, _ string, a *AttributeExpr) error {
+		if style, ok := a.Meta.Last("http:query:style"); ok {
+			switch style {
+			case "csv", "multi":
+				if !IsArray(a.Type) {
+					verr.Add(e, "query parameter %q must be an array to use the %q style", name, style)
+				}
+			case "deepObject":
+				if !IsMap(a.Type) && !IsObject(a.Type) {
+					verr.Add(e, "query parameter %q must be a map or an object to use the %q style", name, style)
+				}
+			default:
+				verr.Add(e, "invalid style %q for query parameter %q, style must be one of \"csv\", \"multi\" or \"deepObject\"", style, name)
+			}
+		}
 		switch {
-		case IsObject(a.Type), IsUnion(a.Type):
+		case IsUnion(a.Type):
 			invalidTypeErr(verr, e, name)
+		case IsObject(a.Type):
+			for _, nat := range *AsObject(a.Type) {
+				t := nat.Attribute.Type
+				if arr := AsArray(t); arr != nil {
+					t = arr.ElemType.Type
+				}
+				if !IsPrimitive(t) || IsAlias(t) {
+					verr.Add(e, "attribute %q of object query parameter %q must be a primitive or an array of primitives", nat.Name, name)
+				}
+			}
+			ctx := fmt.Sprintf("query parameter %s", name)
+			verr.Merge(a.Validate(ctx, e))
 		case IsArray(a.Type):
 			arr := AsArray(a.Type)
 			if !IsPrimitive(arr.ElemType.Type) {
@@ -884,6 +1126,10 @@ func (r *RouteExpr) EvalName() string {
 func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 
+	if !httpMethodRegex.MatchString(r.Method) {
+		verr.Add(r, "invalid HTTP method %q, HTTP methods must be tokens as defined in RFC 7230", r.Method)
+	}
+
 	// Make sure route params are defined in the method payload
 	if rparams := r.Params(); len(rparams) > 0 {
 		if r.Endpoint.MethodExpr.Payload == nil {
@@ -919,7 +1165,7 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 	}
 
 	// For streaming endpoints, websockets does not support verbs other than GET
-	if r.Endpoint.MethodExpr.IsStreaming() && len(r.Endpoint.Responses) > 0 {
+	if r.Endpoint.MethodExpr.IsStreaming() && len(r.Endpoint.Responses) > 0 && !r.Endpoint.StreamRequestBody {
 		if r.Method != "GET" {
 			verr.Add(r, "WebSocket endpoint supports only \"GET\" method. Got %q.", r.Method)
 		}
@@ -1019,6 +1265,62 @@ func initAttr(ma *MappedAttributeExpr, svcAtt *AttributeExpr) {
 	}
 }
 
+// initAttrExamples sets the examples of the attributes of ma to the values of
+// the corresponding payload attributes in the given method request examples.
+func initAttrExamples(ma *MappedAttributeExpr, examples []*ExampleExpr) {
+	for _, nat := range *AsObject(ma.Type) {
+		for _, ex := range examples {
+			m, ok := ex.Value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if v, ok := m[nat.Name]; ok {
+				nat.Attribute.UserExamples = append(nat.Attribute.UserExamples,
+					&ExampleExpr{Summary: ex.Summary, Description: ex.Description, Value: v})
+			}
+		}
+	}
+}
+
+// requestBodyExamples returns the examples of the request body of e derived
+// from the method request examples.
+func requestBodyExamples(e *HTTPEndpointExpr) []*ExampleExpr {
+	if len(e.MethodExpr.Examples) == 0 || e.Body.Type == Empty {
+		return nil
+	}
+	var exs []*ExampleExpr
+	for _, ex := range e.MethodExpr.Examples {
+		m, ok := ex.Value.(map[string]interface{})
+		if !ok {
+			// Payload is not an object, the body is the payload.
+			exs = append(exs, ex)
+			continue
+		}
+		var val interface{}
+		if o, ok := e.Body.Meta["origin:attribute"]; ok {
+			v, ok := m[o[0]]
+			if !ok {
+				continue
+			}
+			val = v
+		} else {
+			obj := AsObject(e.Body.Type)
+			if obj == nil {
+				continue
+			}
+			bm := make(map[string]interface{})
+			for _, nat := range *obj {
+				if v, ok := m[nat.Name]; ok {
+					bm[nat.Name] = v
+				}
+			}
+			val = bm
+		}
+		exs = append(exs, &ExampleExpr{Summary: ex.Summary, Description: ex.Description, Value: val})
+	}
+	return exs
+}
+
 // initAttrFromDesign overrides the type of att with the one of patt and
 // initializes other non-initialized fields of att with the one of patt except
 // Meta.
@@ -15,6 +15,8 @@ func TestHTTPRouteValidation(t *testing.T) {
 		Error string
 	}{
 		{"valid", testdata.ValidRouteDSL, ""},
+		{"custom-method", testdata.CustomMethodRouteDSL, ""},
+		{"invalid-method", testdata.InvalidMethodRouteDSL, `route PROP FIND "/" of service "InvalidMethod" HTTP endpoint "Method": invalid HTTP method "PROP FIND", HTTP methods must be tokens as defined in RFC 7230`},
 		{"invalid", testdata.DuplicateWCRouteDSL, `route POST "/{id}" of service "InvalidRoute" HTTP endpoint "Method": Wildcard "id" appears multiple times in full path "/{id}/{id}"`},
 		{"disallow-response-body", testdata.DisallowResponseBodyHeadDSL, `route HEAD "/" of service "DisallowResponseBody" HTTP endpoint "Method": HTTP status 200: Response body defined for HEAD method which does not allow response body.
 route HEAD "/" of service "DisallowResponseBody" HTTP endpoint "Method": HTTP status 404: Response body defined for HEAD method which does not allow response body.`,
@@ -167,10 +169,58 @@ service "Service" HTTP endpoint "Method": HTTP endpoint response body must be em
 			DSL:   testdata.EndpointHasSkipEncodeAndGRPC,
 			Error: `service "Service" HTTP endpoint "Method": Endpoint cannot use SkipRequestBodyEncodeDecode and define a gRPC transport.`,
 		},
+		"endpoint-stream-request-body": {
+			DSL: testdata.EndpointStreamRequestBody,
+		},
+		"endpoint-stream-request-body-and-result-streaming": {
+			DSL:   testdata.EndpointStreamRequestBodyResultStreaming,
+			Error: `service "Service" HTTP endpoint "Method": Endpoint cannot use StreamRequestBody unless method defines a StreamingPayload and no StreamingResult.`,
+		},
 		"endpoint-payload-missing-required": {
 			DSL:   testdata.EndpointPayloadMissingRequired,
 			Error: `service "Service" HTTP endpoint "Method": The following HTTP request body attribute is required but the corresponding method payload attribute is not: nonreq. Use 'Required' to make the attribute required in the method payload as well.`,
 		},
+		"endpoint-query-style-invalid": {
+			DSL:   testdata.EndpointQueryStyleInvalid,
+			Error: `service "Service" HTTP endpoint "Method": query parameter "ids" must be an array to use the "csv" style`,
+		},
+		"endpoint-query-object": {
+			DSL: testdata.EndpointQueryObject,
+		},
+		"endpoint-query-object-invalid": {
+			DSL:   testdata.EndpointQueryObjectInvalid,
+			Error: `service "Service" HTTP endpoint "Method": attribute "owner" of object query parameter "filter" must be a primitive or an array of primitives`,
+		},
+		"endpoint-invalid-probe": {
+			DSL: testdata.EndpointInvalidProbe,
+			Error: `service "Service" HTTP endpoint "Method": invalid k8s:probe value "alive", must be one of "liveness", "readiness" or "startup"
+service "Service" HTTP endpoint "Method": Endpoint used as Kubernetes probe must use a GET route with no path parameters.`,
+		},
+		"endpoint-invalid-accept-ranges": {
+			DSL:   testdata.EndpointInvalidAcceptRanges,
+			Error: `service "Service" HTTP endpoint "Method": Endpoint cannot use AcceptRanges unless it uses SkipResponseBodyEncodeDecode.`,
+		},
+		"endpoint-invalid-download": {
+			DSL: testdata.EndpointInvalidDownload,
+			Error: `service "Service" HTTP endpoint "Method" download of pdf;;: invalid content type "pdf;;": mime: invalid media parameter
+service "Service" HTTP endpoint "Method" download of pdf;;: invalid disposition "download", must be "attachment" or "inline"`,
+		},
+		"endpoint-invalid-push": {
+			DSL: testdata.EndpointInvalidPush,
+			Error: `service "Service" HTTP endpoint "Method": Push path "assets/app.js" must start with a slash.
+service "Service" HTTP endpoint "Method": Endpoint cannot use Push and Redirect.`,
+		},
+		"endpoint-invalid-alias": {
+			DSL: testdata.EndpointInvalidAlias,
+			Error: `service "Service" HTTP endpoint "Method" alias "/old/{name}": invalid redirect status code 200, must be one of 301, 302, 303, 307 or 308
+service "Service" HTTP endpoint "Method" alias "/old/{name}": alias parameter "name" is not a parameter of route "/{id}"
+service "Service" HTTP endpoint "Method" alias "/old/{name}": alias is missing parameter "id" of route "/{id}"`,
+		},
+		"endpoint-invalid-proxy": {
+			DSL: testdata.EndpointInvalidProxy,
+			Error: `service "Service" HTTP endpoint "Method" proxy to legacy:8080: invalid backend URL "legacy:8080", must be an absolute http or https URL
+service "Service" HTTP endpoint "Method": Endpoint cannot use Proxy and Redirect.`,
+		},
 		"streaming-endpoint-has-request-body": {
 			DSL: testdata.StreamingEndpointRequestBody,
 			Error: `service "Service" HTTP endpoint "MethodA": HTTP endpoint request body must be empty when the endpoint uses streaming. Payload attributes must be mapped to headers and/or params.
@@ -224,6 +274,46 @@ func TestHTTPEndpointParentRequired(t *testing.T) {
 	}
 }
 
+func TestHTTPEndpointBasePathParams(t *testing.T) {
+	root := expr.RunDSL(t, testdata.EndpointBasePathParamsDSL)
+	svc := root.Service("BasePathParams")
+	cases := map[string]struct {
+		Attributes []string
+		Required   []string
+	}{
+		"NoPayload": {[]string{"org", "team"}, []string{"org", "team"}},
+		"Payload":   {[]string{"id", "team", "org"}, []string{"org"}},
+	}
+	for n, c := range cases {
+		t.Run(n, func(t *testing.T) {
+			m := svc.Method(n)
+			obj := expr.AsObject(m.Payload.Type)
+			if obj == nil {
+				t.Fatalf("got payload type %s, expected object", m.Payload.Type.Name())
+			}
+			if len(*obj) != len(c.Attributes) {
+				t.Errorf("got %d attributes, expected %d", len(*obj), len(c.Attributes))
+			}
+			for _, a := range c.Attributes {
+				if obj.Attribute(a) == nil {
+					t.Errorf("attribute %q is missing", a)
+				}
+			}
+			for _, r := range c.Required {
+				if !m.Payload.IsRequired(r) {
+					t.Errorf("attribute %q is not required", r)
+				}
+			}
+			if obj.Attribute("org").Type != expr.Int {
+				t.Errorf("got org type %s, expected Int", obj.Attribute("org").Type.Name())
+			}
+		})
+	}
+	if got := expr.AsObject(svc.Method("Payload").Payload.Type).Attribute("team").Validation; got == nil || got.MinLength == nil {
+		t.Errorf("expected explicit team attribute to be preserved")
+	}
+}
+
 func TestHTTPEndpointFinalization(t *testing.T) {
 	cases := map[string]struct {
 		DSL          func()
@@ -0,0 +1,43 @@
+package expr
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// HTTPProxyExpr defines an endpoint whose requests are forwarded to a
+	// backend by a reverse proxy.
+	HTTPProxyExpr struct {
+		// URL is the URL of the backend, e.g. "http://legacy:8080".
+		URL string
+		// Timeout is the maximum duration of the forwarded requests, 0
+		// if there is no timeout.
+		Timeout time.Duration
+		// RequestHeaders lists the headers set on the forwarded
+		// requests, headers with an empty value are removed.
+		RequestHeaders []*HTTPProxyHeaderExpr
+		// ResponseHeaders lists the headers set on the backend
+		// responses, headers with an empty value are removed.
+		ResponseHeaders []*HTTPProxyHeaderExpr
+		// Endpoint is the parent endpoint.
+		Endpoint *HTTPEndpointExpr
+	}
+
+	// HTTPProxyHeaderExpr defines a header rewritten by a reverse proxy.
+	HTTPProxyHeaderExpr struct {
+		// Name is the header name.
+		Name string
+		// Value is the header value, empty to remove the header.
+		Value string
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (p *HTTPProxyExpr) EvalName() string {
+	suffix := fmt.Sprintf("proxy to %s", p.URL)
+	if p.Endpoint != nil {
+		return p.Endpoint.EvalName() + " " + suffix
+	}
+	return suffix
+}
@@ -98,6 +98,10 @@ type (
 		Parent eval.Expression
 		// Meta is a list of key/value pairs
 		Meta MetaExpr
+		// Examples lists the response body examples defined in the
+		// Response DSL. They take precedence over the body attribute
+		// examples in the generated OpenAPI specifications.
+		Examples []*ExampleExpr
 	}
 )
 
@@ -135,6 +139,17 @@ func (r *HTTPResponseExpr) Validate(e *HTTPEndpointExpr) *eval.ValidationErrors
 		}
 	}
 
+	if len(r.Examples) > 0 {
+		if ep, ok := r.Parent.(*HTTPEndpointExpr); ok {
+			body := httpResponseBody(ep, r)
+			for _, ex := range r.Examples {
+				if !body.Type.IsCompatible(ex.Value) {
+					verr.Add(r, "example %q is incompatible with response body of type %s", ex.Summary, body.Type.Name())
+				}
+			}
+		}
+	}
+
 	// text/html and text/plain can only encode strings so make sure there isn't
 	// an explicit conflict with the content-type and response.
 	if (r.ContentType == "text/html" || r.ContentType == "text/plain") && !e.SkipRequestBodyEncodeDecode {
@@ -316,6 +331,7 @@ func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 		ContentType: r.ContentType,
 		Parent:      r.Parent,
 		Meta:        r.Meta,
+		Examples:    r.Examples,
 	}
 	if r.Body != nil {
 		res.Body = DupAtt(r.Body)
@@ -30,6 +30,12 @@ type (
 		// Cookies defines the HTTP request cookies common to all the
 		// service endpoints.
 		Cookies *MappedAttributeExpr
+		// Consumes lists the mime types accepted by the service
+		// endpoints in request bodies.
+		Consumes []string
+		// StrictPayload is the mode used to decode request bodies that
+		// contain unknown fields, see HTTPExpr.
+		StrictPayload string
 		// Name of parent service if any
 		ParentName string
 		// Endpoint with canonical service path
@@ -191,6 +197,56 @@ func (svc *HTTPServiceExpr) Prepare() {
 	for _, er := range svc.HTTPErrors {
 		er.Response.Prepare()
 	}
+
+	// Add the status endpoints of the long running methods.
+	for _, e := range svc.HTTPEndpoints {
+		lr := e.MethodExpr.LongRunning
+		if lr == nil || svc.Endpoint(lr.StatusMethod.Name) != nil {
+			continue
+		}
+		se := &HTTPEndpointExpr{
+			MethodExpr: lr.StatusMethod,
+			Service:    svc,
+			Headers:    NewEmptyMappedAttributeExpr(),
+		}
+		// The status method payload must define the method path parameters.
+		sp := lr.StatusMethod.Payload
+		if obj := AsObject(e.MethodExpr.Payload.Type); obj != nil {
+			for _, r := range e.Routes {
+				for _, p := range r.Params() {
+					att := obj.Attribute(p)
+					if att == nil || AsObject(sp.Type).Attribute(p) != nil {
+						continue
+					}
+					AsObject(sp.Type).Set(p, DupAtt(att))
+					if e.MethodExpr.Payload.IsRequired(p) {
+						sp.Validation.AddRequired(p)
+					}
+				}
+			}
+		}
+		// Use the same headers as the method for the security attributes.
+		if e.Headers != nil {
+			for _, nat := range *AsObject(lr.StatusMethod.Payload.Type) {
+				if elem, ok := e.Headers.FindKey(nat.Name); ok {
+					se.Headers.Merge(NewMappedAttributeExpr(&AttributeExpr{
+						Type: &Object{{Name: nat.Name + ":" + elem, Attribute: DupAtt(nat.Attribute)}},
+					}))
+				}
+			}
+		}
+		for _, r := range e.Routes {
+			se.Routes = append(se.Routes, &RouteExpr{
+				Method:   "GET",
+				Path:     strings.TrimSuffix(r.Path, "/") + "/operations/{id}",
+				Endpoint: se,
+			})
+		}
+		svc.HTTPEndpoints = append(svc.HTTPEndpoints, se)
+		// The endpoint is created after the endpoints expression set is
+		// built so it must be prepared explicitly.
+		se.Prepare()
+	}
 }
 
 // Validate makes sure the service is valid.
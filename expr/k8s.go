@@ -0,0 +1,21 @@
+package expr
+
+import (
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+// validateCRD makes sure the method payload type that defines the "k8s:crd"
+// meta can be mapped to a Kubernetes custom resource.
+func validateCRD(m *MethodExpr, ut UserType, verr *eval.ValidationErrors) {
+	if group, _ := ut.Attribute().Meta.Last("k8s:crd"); !strings.Contains(group, ".") {
+		verr.Add(m, "k8s:crd meta of type %q must be the custom resource API group such as \"example.com\", got %q", ut.Name(), group)
+	}
+	if !IsObject(ut) {
+		verr.Add(m, "type %q defines the k8s:crd meta and must be an object", ut.Name())
+	}
+	if m.IsStreaming() {
+		verr.Add(m, "method uses the custom resource type %q as payload and cannot use streaming", ut.Name())
+	}
+}
@@ -0,0 +1,86 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// LongRunningExpr describes a method that starts a long running
+	// operation. The method returns the initial status of the operation and
+	// the status method returns the current status given the operation ID.
+	LongRunningExpr struct {
+		// Method is the method that starts the operation.
+		Method *MethodExpr
+		// Status is the type that describes the status of an operation.
+		Status UserType
+		// StatusMethod is the method that returns the status of an
+		// operation given its ID.
+		StatusMethod *MethodExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (l *LongRunningExpr) EvalName() string {
+	return fmt.Sprintf("long running operation of %s", l.Method.EvalName())
+}
+
+// Prepare copies the method payload attributes used by the security schemes
+// and the method security requirements to the status method so that the
+// status method is secured like the method.
+func (l *LongRunningExpr) Prepare() {
+	sm := l.StatusMethod
+	if len(sm.Requirements) == 0 {
+		sm.Requirements = l.Method.Requirements
+	}
+	obj := AsObject(l.Method.Payload.Type)
+	if obj == nil {
+		return
+	}
+	sobj := AsObject(sm.Payload.Type)
+	for _, nat := range *obj {
+		if sobj.Attribute(nat.Name) != nil {
+			continue
+		}
+		for k := range nat.Attribute.Meta {
+			if strings.HasPrefix(k, "security:") {
+				sobj.Set(nat.Name, DupAtt(nat.Attribute))
+				if l.Method.Payload.IsRequired(nat.Name) {
+					sm.Payload.Validation.AddRequired(nat.Name)
+				}
+				break
+			}
+		}
+	}
+}
+
+// Validate makes sure the status type defines the operation ID and completion
+// attributes and that the method result is the status type.
+func (l *LongRunningExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if l.Method.IsStreaming() {
+		verr.Add(l, "streaming methods cannot be long running")
+	}
+	if l.Method.Result.Type != l.Status {
+		verr.Add(l, "result must be the status type %q", l.Status.Name())
+	}
+	obj := AsObject(l.Status)
+	if obj == nil {
+		verr.Add(l, "status type %q must be an object", l.Status.Name())
+		return verr
+	}
+	if id := obj.Attribute("id"); id == nil || id.Type != String {
+		verr.Add(l, "status type %q must define a string \"id\" attribute", l.Status.Name())
+	} else if !l.Status.Attribute().IsRequired("id") {
+		verr.Add(l, "status type %q must make the \"id\" attribute required", l.Status.Name())
+	}
+	if done := obj.Attribute("done"); done == nil || done.Type != Boolean {
+		verr.Add(l, "status type %q must define a boolean \"done\" attribute", l.Status.Name())
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
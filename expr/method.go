@@ -2,6 +2,8 @@ package expr
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"goa.design/goa/v3/eval"
 )
@@ -22,6 +24,10 @@ type (
 		Docs *DocsExpr
 		// Payload attribute
 		Payload *AttributeExpr
+		// Examples lists the request examples defined in the method DSL.
+		// The example values are payload values, the transports derive
+		// the examples of the request body and parameters from them.
+		Examples []*ExampleExpr
 		// Result attribute
 		Result *AttributeExpr
 		// Errors lists the error responses.
@@ -40,6 +46,18 @@ type (
 		Stream StreamKind
 		// StreamingPayload is the payload sent across the stream.
 		StreamingPayload *AttributeExpr
+		// Pagination describes how the method results are paginated if
+		// at all.
+		Pagination *PaginationExpr
+		// Timeout is the maximum duration of the requests made by the
+		// generated clients to the method if any.
+		Timeout time.Duration
+		// LongRunning describes the long running operation started by
+		// the method if any.
+		LongRunning *LongRunningExpr
+		// Authorize is true if the service must implement the method
+		// authorization hook.
+		Authorize bool
 	}
 )
 
@@ -94,12 +112,46 @@ func (m *MethodExpr) Prepare() {
 	if m.Result == nil {
 		m.Result = &AttributeExpr{Type: Empty}
 	}
+	if m.LongRunning != nil {
+		m.LongRunning.Prepare()
+	}
 }
 
 // Validate validates the method payloads, results, and errors (if any).
 func (m *MethodExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
 	verr.Merge(m.Payload.Validate("payload", m))
+	for _, ex := range m.Examples {
+		if !m.Payload.Type.IsCompatible(ex.Value) {
+			verr.Add(m, "example %q is incompatible with payload of type %s", ex.Summary, m.Payload.Type.Name())
+			continue
+		}
+		vals, ok := ex.Value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		obj := AsObject(m.Payload.Type)
+		if obj == nil {
+			continue
+		}
+		names := make([]string, 0, len(vals))
+		for n := range vals {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			if att := obj.Attribute(n); att == nil {
+				verr.Add(m, "example %q: payload does not define attribute %q", ex.Summary, n)
+			} else if !att.Type.IsCompatible(vals[n]) {
+				verr.Add(m, "example %q: value of attribute %q is incompatible with type %s", ex.Summary, n, att.Type.Name())
+			}
+		}
+	}
+	if ut, ok := m.Payload.Type.(UserType); ok {
+		if _, ok := ut.Attribute().Meta["k8s:crd"]; ok {
+			validateCRD(m, ut, verr)
+		}
+	}
 	// validate security scheme requirements
 	var requirements []*SecurityExpr
 	if len(m.Requirements) > 0 {
@@ -190,6 +242,23 @@ func (m *MethodExpr) Validate() error {
 	if m.Result.Type != Empty {
 		verr.Merge(m.Result.Validate("result", m))
 	}
+	if m.Timeout > 0 && m.IsStreaming() {
+		verr.Add(m, "streaming methods cannot define a timeout")
+	}
+	if m.LongRunning != nil {
+		if err := m.LongRunning.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
+	if m.Pagination != nil {
+		if err := m.Pagination.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
 	for i, e := range m.Errors {
 		if err := e.Validate(); err != nil {
 			if verrs, ok := err.(*eval.ValidationErrors); ok {
@@ -288,6 +357,19 @@ func (m *MethodExpr) Finalize() {
 		e.Finalize()
 	}
 
+	// Scope the method to the tenant if its payload defines the tenant
+	// attribute.
+	if Root.API != nil {
+		if p, ok := Root.API.Meta.Last("tenant:param"); ok && m.Payload.Find(p) != nil {
+			if _, ok := m.Meta["tenant:param"]; !ok {
+				if m.Meta == nil {
+					m.Meta = MetaExpr{}
+				}
+				m.Meta["tenant:param"] = []string{p}
+			}
+		}
+	}
+
 	// Inherit security requirements
 	noreq := false
 loop:
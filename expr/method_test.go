@@ -32,6 +32,36 @@ service "AnotherInvalidSecuritySchemesService" method "Method": payload of metho
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a JWT token attribute, but no JWT auth security scheme exist
 service "AnotherInvalidSecuritySchemesService" method "Method": payload of method "Method" of service "AnotherInvalidSecuritySchemesService" defines a OAuth2 access token attribute, but no OAuth2 security scheme exist`,
 		},
+		{"valid-pagination", testdata.ValidPaginationDSL, ""},
+		{"invalid-pagination", testdata.InvalidPaginationDSL,
+			`pagination of service "PaginatedService" method "NotObject": payload must be an object defining the cursor attribute "cursor"
+pagination of service "PaginatedService" method "NotObject": result must be an object defining the next cursor attribute "next" and the items attribute "items"
+pagination of service "PaginatedService" method "MissingAttributes": payload does not define the cursor attribute "cursor"
+pagination of service "PaginatedService" method "MissingAttributes": result does not define the next cursor attribute "next"
+pagination of service "PaginatedService" method "MissingAttributes": result does not define the items attribute "items"
+pagination of service "PaginatedService" method "InvalidTypes": type of cursor attribute "cursor" (string) is not the same as the type of next cursor attribute "next" (int)
+pagination of service "PaginatedService" method "InvalidTypes": items attribute "items" must be an array, got string
+pagination of service "PaginatedService" method "Streaming": streaming methods cannot be paginated`,
+		},
+		{"invalid-timeout", testdata.InvalidTimeoutDSL,
+			`service "TimeoutService" method "Streaming": streaming methods cannot define a timeout`,
+		},
+		{"invalid-example", testdata.InvalidExampleDSL,
+			`service "ExampleService" method "Method": example "invalid": value of attribute "id" is incompatible with type int
+service "ExampleService" method "Method": example "unknown": payload does not define attribute "name"`,
+		},
+		{"invalid-crd", testdata.InvalidCRDDSL,
+			`service "CRDService" method "Apply": k8s:crd meta of type "Widget" must be the custom resource API group such as "example.com", got "widgets"
+service "CRDService" method "Tag": type "Tags" defines the k8s:crd meta and must be an object
+service "CRDService" method "Watch": method uses the custom resource type "Gadget" as payload and cannot use streaming`,
+		},
+		{"valid-long-running", testdata.ValidLongRunningDSL, ""},
+		{"invalid-long-running", testdata.InvalidLongRunningDSL,
+			`long running operation of service "LongRunningService" method "Streaming": streaming methods cannot be long running
+long running operation of service "LongRunningService" method "BadStatus": status type "BadOperation" must define a string "id" attribute
+long running operation of service "LongRunningService" method "BadStatus": status type "BadOperation" must define a boolean "done" attribute
+long running operation of service "LongRunningService" method "ResultOverride": result must be the status type "Operation"`,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -154,3 +184,14 @@ func TestMethodExprIsPayloadStreaming(t *testing.T) {
 		}
 	}
 }
+
+func TestMethodExprFinalizeTenant(t *testing.T) {
+	root := expr.RunDSL(t, testdata.TenantDSL)
+	svc := root.Service("TenantService")
+	if p := svc.Method("Scoped").Meta["tenant:param"]; len(p) != 1 || p[0] != "account_id" {
+		t.Errorf("Scoped: got tenant param %v, expected [account_id]", p)
+	}
+	if p, ok := svc.Method("Unscoped").Meta["tenant:param"]; ok {
+		t.Errorf("Unscoped: got tenant param %v, expected none", p)
+	}
+}
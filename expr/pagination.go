@@ -0,0 +1,96 @@
+package expr
+
+import (
+	"fmt"
+
+	"goa.design/goa/v3/eval"
+)
+
+type (
+	// PaginationExpr describes how the results of a method are paginated.
+	// Clients iterate over the pages by setting the payload cursor attribute
+	// to the value of the result next cursor attribute until the latter is
+	// empty.
+	PaginationExpr struct {
+		// Method is the paginated method.
+		Method *MethodExpr
+		// Cursor is the name of the payload attribute that contains the
+		// cursor of the page to retrieve.
+		Cursor string
+		// NextCursor is the name of the result attribute that contains the
+		// cursor of the next page.
+		NextCursor string
+		// Items is the name of the result attribute that contains the
+		// items of the page.
+		Items string
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	return fmt.Sprintf("pagination of %s", p.Method.EvalName())
+}
+
+// CursorAttribute returns the payload attribute that contains the cursor of
+// the page to retrieve.
+func (p *PaginationExpr) CursorAttribute() *AttributeExpr {
+	return AsObject(p.Method.Payload.Type).Attribute(p.Cursor)
+}
+
+// NextCursorAttribute returns the result attribute that contains the cursor of
+// the next page.
+func (p *PaginationExpr) NextCursorAttribute() *AttributeExpr {
+	return AsObject(p.Method.Result.Type).Attribute(p.NextCursor)
+}
+
+// ItemsAttribute returns the result attribute that contains the page items.
+func (p *PaginationExpr) ItemsAttribute() *AttributeExpr {
+	return AsObject(p.Method.Result.Type).Attribute(p.Items)
+}
+
+// Validate makes sure the pagination attributes are defined by the method
+// payload and result and have compatible types.
+func (p *PaginationExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	m := p.Method
+	if m.IsStreaming() {
+		verr.Add(p, "streaming methods cannot be paginated")
+		return verr
+	}
+	if !IsObject(m.Payload.Type) {
+		verr.Add(p, "payload must be an object defining the cursor attribute %q", p.Cursor)
+	}
+	if !IsObject(m.Result.Type) {
+		verr.Add(p, "result must be an object defining the next cursor attribute %q and the items attribute %q", p.NextCursor, p.Items)
+	}
+	if len(verr.Errors) > 0 {
+		return verr
+	}
+	cursor := p.CursorAttribute()
+	if cursor == nil {
+		verr.Add(p, "payload does not define the cursor attribute %q", p.Cursor)
+	}
+	next := p.NextCursorAttribute()
+	if next == nil {
+		verr.Add(p, "result does not define the next cursor attribute %q", p.NextCursor)
+	}
+	if cursor != nil && next != nil {
+		switch cursor.Type.Kind() {
+		case StringKind, IntKind, Int32Kind, Int64Kind, UIntKind, UInt32Kind, UInt64Kind:
+			if cursor.Type.Kind() != next.Type.Kind() {
+				verr.Add(p, "type of cursor attribute %q (%s) is not the same as the type of next cursor attribute %q (%s)", p.Cursor, cursor.Type.Name(), p.NextCursor, next.Type.Name())
+			}
+		default:
+			verr.Add(p, "cursor attribute %q must be a string or an integer, got %s", p.Cursor, cursor.Type.Name())
+		}
+	}
+	if items := p.ItemsAttribute(); items == nil {
+		verr.Add(p, "result does not define the items attribute %q", p.Items)
+	} else if !IsArray(items.Type) {
+		verr.Add(p, "items attribute %q must be an array, got %s", p.Items, items.Type.Name())
+	}
+	if len(verr.Errors) == 0 {
+		return nil
+	}
+	return verr
+}
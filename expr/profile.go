@@ -0,0 +1,49 @@
+package expr
+
+import (
+	"fmt"
+)
+
+type (
+	// ProfileExpr describes the design overrides that apply when generating
+	// code for a given environment.
+	ProfileExpr struct {
+		// Name of profile
+		Name string
+		// Description of profile
+		Description string
+		// Servers lists the API hosts of the environment. The servers
+		// replace the API servers when the profile is selected.
+		Servers []*ServerExpr
+		// Requirements contains the security requirements of the
+		// environment. The requirements replace the API security
+		// requirements when the profile is selected.
+		Requirements []*SecurityExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (p *ProfileExpr) EvalName() string {
+	return fmt.Sprintf("profile %q", p.Name)
+}
+
+// Profile returns the API profile with the given name if any.
+func (a *APIExpr) Profile(name string) *ProfileExpr {
+	for _, p := range a.Profiles {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Apply overrides the API servers and security requirements with the ones
+// defined by the profile.
+func (p *ProfileExpr) Apply(api *APIExpr) {
+	if len(p.Servers) > 0 {
+		api.Servers = p.Servers
+	}
+	if len(p.Requirements) > 0 {
+		api.Requirements = p.Requirements
+	}
+}
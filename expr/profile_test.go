@@ -0,0 +1,66 @@
+package expr
+
+import (
+	"testing"
+)
+
+func TestRootExprPrepareProfile(t *testing.T) {
+	var (
+		apiServer     = &ServerExpr{Name: "api"}
+		stagingServer = &ServerExpr{Name: "staging"}
+		apiSecurity   = &SecurityExpr{Scopes: []string{"api"}}
+		stagingSec    = &SecurityExpr{Scopes: []string{"staging"}}
+	)
+	cases := map[string]struct {
+		profile              string
+		profiles             []*ProfileExpr
+		expectedServer       *ServerExpr
+		expectedRequirements *SecurityExpr
+	}{
+		"no profile": {
+			profiles:             []*ProfileExpr{{Name: "staging", Servers: []*ServerExpr{stagingServer}}},
+			expectedServer:       apiServer,
+			expectedRequirements: apiSecurity,
+		},
+		"servers": {
+			profile:              "staging",
+			profiles:             []*ProfileExpr{{Name: "staging", Servers: []*ServerExpr{stagingServer}}},
+			expectedServer:       stagingServer,
+			expectedRequirements: apiSecurity,
+		},
+		"security": {
+			profile:              "staging",
+			profiles:             []*ProfileExpr{{Name: "staging", Requirements: []*SecurityExpr{stagingSec}}},
+			expectedServer:       apiServer,
+			expectedRequirements: stagingSec,
+		},
+		"other profile": {
+			profile: "staging",
+			profiles: []*ProfileExpr{
+				{Name: "production", Servers: []*ServerExpr{apiServer}},
+				{Name: "staging", Servers: []*ServerExpr{stagingServer}, Requirements: []*SecurityExpr{stagingSec}},
+			},
+			expectedServer:       stagingServer,
+			expectedRequirements: stagingSec,
+		},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := &RootExpr{
+				API: &APIExpr{
+					Servers:      []*ServerExpr{apiServer},
+					Requirements: []*SecurityExpr{apiSecurity},
+					Profiles:     tc.profiles,
+				},
+				Profile: tc.profile,
+			}
+			r.Prepare()
+			if len(r.API.Servers) != 1 || r.API.Servers[0] != tc.expectedServer {
+				t.Errorf("got servers %v, expected %v", r.API.Servers, tc.expectedServer)
+			}
+			if len(r.API.Requirements) != 1 || r.API.Requirements[0] != tc.expectedRequirements {
+				t.Errorf("got requirements %v, expected %v", r.API.Requirements, tc.expectedRequirements)
+			}
+		})
+	}
+}
@@ -133,6 +133,21 @@ func CanonicalIdentifier(identifier string) string {
 	return mime.FormatMediaType(id, params)
 }
 
+// ExpandIdentifier returns the identifier made of prefix, a dot and the given
+// result type identifier if the identifier does not define a media type (i.e.
+// contains no "/"), the identifier unchanged otherwise. The identifier
+// parameters are preserved.
+func ExpandIdentifier(prefix, identifier string) string {
+	if prefix == "" {
+		return identifier
+	}
+	base, params, err := mime.ParseMediaType(identifier)
+	if err != nil || strings.Contains(base, "/") {
+		return identifier
+	}
+	return mime.FormatMediaType(prefix+"."+base, params)
+}
+
 // Kind implements DataKind.
 func (m *ResultTypeExpr) Kind() Kind { return ResultTypeKind }
 
@@ -179,8 +194,15 @@ func (m *ResultTypeExpr) ViewHasAttribute(view, attr string) bool {
 }
 
 // Finalize builds the default view if not explicitly defined and finalizes
-// the underlying UserTypeExpr.
+// the underlying UserTypeExpr. The attributes of the types embedded or
+// extended with Embed or Extend are merged first so that the default view
+// includes them.
 func (m *ResultTypeExpr) Finalize() {
+	for _, b := range m.Bases {
+		if ut, ok := b.(UserType); ok && IsObject(ut) {
+			m.Merge(ut.Attribute())
+		}
+	}
 	if m.View("default") == nil {
 		m.ensureDefaultView()
 	}
@@ -114,6 +114,40 @@ func TestCanonicalIdentifier(t *testing.T) {
 	}
 }
 
+func TestExpandIdentifier(t *testing.T) {
+	cases := map[string]struct {
+		prefix     string
+		identifier string
+		expected   string
+	}{
+		"no prefix": {
+			identifier: "bottle",
+			expected:   "bottle",
+		},
+		"relative": {
+			prefix:     "application/vnd.acme",
+			identifier: "bottle",
+			expected:   "application/vnd.acme.bottle",
+		},
+		"relative with parameter": {
+			prefix:     "application/vnd.acme",
+			identifier: "bottle; type=collection",
+			expected:   "application/vnd.acme.bottle; type=collection",
+		},
+		"media type": {
+			prefix:     "application/vnd.acme",
+			identifier: "application/vnd.other.bottle",
+			expected:   "application/vnd.other.bottle",
+		},
+	}
+
+	for k, tc := range cases {
+		if actual := ExpandIdentifier(tc.prefix, tc.identifier); tc.expected != actual {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+}
+
 func TestViewExprEvalName(t *testing.T) {
 	var (
 		result = &ResultTypeExpr{
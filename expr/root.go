@@ -14,8 +14,11 @@ var Root = &RootExpr{GeneratedTypes: &GeneratedRoot{}}
 type (
 	// RootExpr is the struct built by the DSL on process start.
 	RootExpr struct {
-		// API contains the API expression built by the DSL.
+		// API contains the API expression built by the DSL. API is the
+		// API selected with APIName if the design defines multiple APIs.
 		API *APIExpr
+		// APIs lists all the API expressions built by the DSL.
+		APIs []*APIExpr
 		// Services contains the list of services exposed by the API.
 		Services []*ServiceExpr
 		// Errors contains the list of errors returned by all the API
@@ -34,6 +37,12 @@ type (
 		Creations []*TypeMap
 		// Schemes list the registered security schemes.
 		Schemes []*SchemeExpr
+		// Profile is the name of the API profile selected when generating
+		// code if any.
+		Profile string
+		// APIName is the name of the API selected when generating code if
+		// any, the first API defined in the design is used otherwise.
+		APIName string
 	}
 
 	// MetaExpr is a set of key/value pairs
@@ -51,6 +60,9 @@ type (
 
 // WalkSets returns the expressions in order of evaluation.
 func (r *RootExpr) WalkSets(walk eval.SetWalker) {
+	if a := r.APIByName(r.APIName); a != nil {
+		r.API = a
+	}
 	if r.API == nil {
 		name := "API"
 		if len(r.Services) > 0 {
@@ -65,6 +77,35 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 	// Servers
 	walk(eval.ToExpressionSet(r.API.Servers))
 
+	// Expand the result type identifiers that do not define a media type
+	// (must be done before the DSL looks up result types by identifier).
+	if p := r.API.MediaTypePrefix; p != "" {
+		rts := r.ResultTypes
+		if r.GeneratedTypes != nil {
+			rts = append(rts[:len(rts):len(rts)], *r.GeneratedTypes...)
+		}
+		for _, rt := range rts {
+			if mt, ok := rt.(*ResultTypeExpr); ok {
+				mt.Identifier = ExpandIdentifier(p, mt.Identifier)
+			}
+		}
+	}
+
+	// Only keep the services exposed by the selected API (must be done
+	// after the API DSL has run and before the services DSL run).
+	if len(r.API.Services) > 0 {
+		var svcs []*ServiceExpr
+		for _, s := range r.Services {
+			for _, n := range r.API.Services {
+				if s.Name == n {
+					svcs = append(svcs, s)
+					break
+				}
+			}
+		}
+		r.Services = svcs
+	}
+
 	// User types
 	types := make(eval.ExpressionSet, len(r.Types))
 	for i, t := range r.Types {
@@ -179,6 +220,16 @@ func (r *RootExpr) Service(name string) *ServiceExpr {
 	return nil
 }
 
+// APIByName returns the API with the given name, nil if there is none.
+func (r *RootExpr) APIByName(name string) *APIExpr {
+	for _, a := range r.APIs {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
 // Error returns the error with the given name.
 func (r *RootExpr) Error(name string) *ErrorExpr {
 	for _, e := range r.Errors {
@@ -217,11 +268,45 @@ func (r *RootExpr) EvalName() string {
 	return "design"
 }
 
+// Prepare applies the selected API profile if any.
+func (r *RootExpr) Prepare() {
+	if r.API == nil || r.Profile == "" {
+		return
+	}
+	if p := r.API.Profile(r.Profile); p != nil {
+		p.Apply(r.API)
+	}
+}
+
 // Validate makes sure the root expression is valid for code generation.
 func (r *RootExpr) Validate() error {
 	var verr eval.ValidationErrors
 	if r.API == nil {
 		verr.Add(r, "Missing API declaration")
+	} else if r.Profile != "" && r.API.Profile(r.Profile) == nil {
+		verr.Add(r, "profile %q is not defined by the API", r.Profile)
+	}
+	if r.APIName != "" && r.APIByName(r.APIName) == nil {
+		verr.Add(r, "API %q is not defined", r.APIName)
+	}
+	if r.API != nil {
+		for _, n := range r.API.Services {
+			if r.Service(n) == nil {
+				verr.Add(r, "API %q exposes service %q which is not defined", r.API.Name, n)
+			}
+		}
+	}
+	if r.API != nil {
+		for _, p := range r.API.Profiles {
+			if p.Name == r.Profile {
+				// servers of the selected profile are validated with the
+				// API servers.
+				continue
+			}
+			for _, svr := range p.Servers {
+				verr.Merge(svr.Validate().(*eval.ValidationErrors))
+			}
+		}
 	}
 	return &verr
 }
@@ -10,6 +10,7 @@ import (
 func TestRootExprValidate(t *testing.T) {
 	cases := map[string]struct {
 		api      *APIExpr
+		profile  string
 		expected *eval.ValidationErrors
 	}{
 		"no error": {
@@ -20,6 +21,26 @@ func TestRootExprValidate(t *testing.T) {
 				Errors: []error{},
 			},
 		},
+		"profile": {
+			api: &APIExpr{
+				Name:     "foo",
+				Profiles: []*ProfileExpr{{Name: "staging"}},
+			},
+			profile: "staging",
+			expected: &eval.ValidationErrors{
+				Errors: []error{},
+			},
+		},
+		"unknown profile": {
+			api: &APIExpr{
+				Name:     "foo",
+				Profiles: []*ProfileExpr{{Name: "staging"}},
+			},
+			profile: "production",
+			expected: &eval.ValidationErrors{
+				Errors: []error{fmt.Errorf("profile \"production\" is not defined by the API")},
+			},
+		},
 		"missing api declaration": {
 			api: nil,
 			expected: &eval.ValidationErrors{
@@ -30,7 +51,8 @@ func TestRootExprValidate(t *testing.T) {
 
 	for k, tc := range cases {
 		e := RootExpr{
-			API: tc.api,
+			API:     tc.api,
+			Profile: tc.profile,
 		}
 		if actual := e.Validate().(*eval.ValidationErrors); len(tc.expected.Errors) != len(actual.Errors) {
 			t.Errorf("%s: expected the number of error values to match %d got %d ", k, len(tc.expected.Errors), len(actual.Errors))
@@ -22,6 +22,12 @@ const (
 	JWTKind
 	// NoKind means to have no security for this endpoint.
 	NoKind
+	// SignatureKind means a request signature security scheme where
+	// clients sign requests with a shared secret key.
+	SignatureKind
+	// MutualTLSKind means a mutual TLS security scheme where clients
+	// authenticate with a X.509 certificate.
+	MutualTLSKind
 )
 
 // FlowKind is a type of OAuth2 flow.
@@ -142,6 +148,10 @@ func (s *SchemeExpr) Type() string {
 		return "APIKey"
 	case JWTKind:
 		return "JWT"
+	case SignatureKind:
+		return "Signature"
+	case MutualTLSKind:
+		return "MutualTLS"
 	default:
 		panic(fmt.Sprintf("unknown scheme kind: %#v", s.Kind)) // bug
 	}
@@ -157,6 +167,13 @@ func (s *SchemeExpr) Hash() string {
 	return fmt.Sprintf("%s_%s_%s", s.SchemeName, s.In, s.Name)
 }
 
+// IsTransport returns true if the scheme is enforced by the transport layer
+// (e.g. HTTP middleware) rather than by the generated endpoint auth functions.
+// Such schemes do not map to payload attributes.
+func (s *SchemeExpr) IsTransport() bool {
+	return s.Kind == SignatureKind || s.Kind == MutualTLSKind
+}
+
 // Validate ensures that the method payload contains attributes required
 // by the scheme.
 func (s *SchemeExpr) Validate() *eval.ValidationErrors {
@@ -215,6 +232,10 @@ func (k SchemeKind) String() string {
 		return "JWT"
 	case OAuth2Kind:
 		return "OAuth2"
+	case SignatureKind:
+		return "Signature"
+	case MutualTLSKind:
+		return "MutualTLS"
 	case NoKind:
 		return "None"
 	default:
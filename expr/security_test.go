@@ -176,6 +176,8 @@ func TestSchemeExprEvalName(t *testing.T) {
 		"BasicAuthKind": {kind: BasicAuthKind, expected: "BasicAuthSecurity"},
 		"APIKeyKind":    {kind: APIKeyKind, expected: "APIKeySecurity"},
 		"JWTKind":       {kind: JWTKind, expected: "JWTSecurity"},
+		"SignatureKind": {kind: SignatureKind, expected: "SignatureSecurity"},
+		"MutualTLSKind": {kind: MutualTLSKind, expected: "MutualTLSSecurity"},
 		"NoKind":        {kind: NoKind, expected: "This case is panic"},
 	}
 
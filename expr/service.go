@@ -85,6 +85,9 @@ func (s *ServiceExpr) Validate() error {
 			}
 		}
 	}
+	if IsTerraformResource(s) {
+		validateTerraformResource(s, verr)
+	}
 	return verr
 }
 
@@ -94,6 +94,10 @@ func TestServiceExprValidate(t *testing.T) {
 		Error string
 	}{
 		{"service errors", testdata.ServiceErrorDSL, `attribute: error name "a" must be required in type "ServiceError"`},
+		{"terraform resource", testdata.InvalidTerraformResourceDSL, `service "Widgets" method "purge": invalid terraform:operation value "remove", must be one of "create", "read", "update" or "delete"
+service "Widgets" method "create": method implementing the terraform create operation must define an object payload
+service "Widgets": terraform resource must define a method that implements the delete operation
+service "Widgets" method "show": method implementing the terraform read operation must return an object`},
 	}
 
 	for _, tc := range cases {
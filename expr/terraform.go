@@ -0,0 +1,97 @@
+package expr
+
+import (
+	"goa.design/goa/v3/eval"
+)
+
+const (
+	// TerraformCreate is the Terraform resource operation that creates
+	// resources.
+	TerraformCreate = "create"
+	// TerraformRead is the Terraform resource operation that reads
+	// resources.
+	TerraformRead = "read"
+	// TerraformUpdate is the Terraform resource operation that updates
+	// resources.
+	TerraformUpdate = "update"
+	// TerraformDelete is the Terraform resource operation that deletes
+	// resources.
+	TerraformDelete = "delete"
+)
+
+// IsTerraformResource returns true if the service defines the
+// "terraform:resource" meta.
+func IsTerraformResource(s *ServiceExpr) bool {
+	_, ok := s.Meta["terraform:resource"]
+	return ok
+}
+
+// TerraformOperation returns the Terraform resource operation implemented by
+// the method. The operation is given by the "terraform:operation" meta of the
+// method if any. Otherwise methods named "create", "show", "read", "get",
+// "update" and "delete" implement the corresponding operation. It returns ""
+// if the method does not implement any operation.
+func TerraformOperation(m *MethodExpr) string {
+	if op, ok := m.Meta.Last("terraform:operation"); ok {
+		return op
+	}
+	switch m.Name {
+	case "create":
+		return TerraformCreate
+	case "show", "read", "get":
+		return TerraformRead
+	case "update":
+		return TerraformUpdate
+	case "delete":
+		return TerraformDelete
+	}
+	return ""
+}
+
+// TerraformMethods returns the methods of the service that implement the
+// Terraform resource operations indexed by operation.
+func TerraformMethods(s *ServiceExpr) map[string]*MethodExpr {
+	methods := make(map[string]*MethodExpr)
+	for _, m := range s.Methods {
+		if op := TerraformOperation(m); op != "" {
+			if _, ok := methods[op]; !ok {
+				methods[op] = m
+			}
+		}
+	}
+	return methods
+}
+
+// validateTerraformResource makes sure the service defines the methods
+// required to implement a Terraform resource and that they can be mapped to
+// the resource attributes.
+func validateTerraformResource(s *ServiceExpr, verr *eval.ValidationErrors) {
+	for _, m := range s.Methods {
+		if op, ok := m.Meta.Last("terraform:operation"); ok {
+			switch op {
+			case TerraformCreate, TerraformRead, TerraformUpdate, TerraformDelete:
+			default:
+				verr.Add(m, "invalid terraform:operation value %q, must be one of %q, %q, %q or %q", op, TerraformCreate, TerraformRead, TerraformUpdate, TerraformDelete)
+			}
+		}
+	}
+	methods := TerraformMethods(s)
+	for _, op := range []string{TerraformCreate, TerraformRead, TerraformUpdate, TerraformDelete} {
+		m, ok := methods[op]
+		if !ok {
+			if op != TerraformUpdate {
+				verr.Add(s, "terraform resource must define a method that implements the %s operation", op)
+			}
+			continue
+		}
+		if m.IsStreaming() {
+			verr.Add(m, "method implementing the terraform %s operation cannot use streaming", op)
+		}
+		if m.Payload.Type != Empty && !IsObject(m.Payload.Type) {
+			verr.Add(m, "method implementing the terraform %s operation must define an object payload", op)
+		}
+	}
+	if m, ok := methods[TerraformRead]; ok && (m.Result.Type == Empty || !IsObject(m.Result.Type)) {
+		verr.Add(m, "method implementing the terraform read operation must return an object")
+	}
+}
@@ -394,6 +394,35 @@ var EndpointHasSkipRequestEncodeAndResultStreaming = func() {
 	})
 }
 
+var EndpointStreamRequestBody = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			StreamingPayload(String)
+			Result(Int)
+			HTTP(func() {
+				POST("/{id}")
+				StreamRequestBody()
+			})
+		})
+	})
+}
+
+var EndpointStreamRequestBodyResultStreaming = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			StreamingPayload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				StreamRequestBody()
+			})
+		})
+	})
+}
+
 var EndpointHasSkipResponseEncodeAndPayloadStreaming = func() {
 	Service("Service", func() {
 		Method("Method", func() {
@@ -452,6 +481,60 @@ var EndpointPayloadMissingRequired = func() {
 	})
 }
 
+var EndpointQueryStyleInvalid = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("ids", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Meta("http:query:style", "csv")
+				})
+			})
+		})
+	})
+}
+
+var EndpointQueryObject = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("name", String)
+		Attribute("tags", ArrayOf(String))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter")
+			})
+		})
+	})
+}
+
+var EndpointQueryObjectInvalid = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("name", String)
+		Attribute("owner", func() {
+			Attribute("id", String)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter")
+			})
+		})
+	})
+}
+
 var StreamingEndpointRequestBody = func() {
 	var PT = Type("Payload", func() {
 		Attribute("foo", String)
@@ -617,6 +700,21 @@ var GRPCEndpointWithAnyType = func() {
 	})
 }
 
+var GRPCEndpointWithTimeTypes = func() {
+	var Req = Type("Req", func() {
+		Field(1, "id", UUID)
+		Field(2, "since", DateTime)
+		Field(3, "days", ArrayOf(Date))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(Req)
+			Result(Duration)
+			GRPC(func() {})
+		})
+	})
+}
+
 var GRPCEndpointWithUntaggedFields = func() {
 	var Req = Type("Req", func() {
 		Attribute("req_not_field", String)
@@ -705,3 +803,129 @@ var GRPCEndpointWithExtendedTypes = func() {
 		})
 	})
 }
+
+var EndpointBasePathParamsDSL = func() {
+	API("BasePathParams", func() {
+		HTTP(func() {
+			Path("/orgs/{org}")
+			Params(func() {
+				Param("org", Int, "Organization ID")
+			})
+		})
+	})
+	Service("BasePathParams", func() {
+		HTTP(func() {
+			Path("/teams/{team}")
+		})
+		Method("NoPayload", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Payload", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("team", String, func() {
+					MinLength(2)
+				})
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
+
+var CustomMethodRouteDSL = func() {
+	Service("CustomMethod", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				Route("PROPFIND", "/")
+			})
+		})
+	})
+}
+
+var InvalidMethodRouteDSL = func() {
+	Service("InvalidMethod", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				Route("PROP FIND", "/")
+			})
+		})
+	})
+}
+
+var EndpointInvalidProbe = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Meta("k8s:probe", "alive")
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var EndpointInvalidPush = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Redirect("/home", StatusMovedPermanently)
+				Push("assets/app.js")
+			})
+		})
+	})
+}
+
+var EndpointInvalidAlias = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Alias("/old/:name", StatusOK)
+			})
+		})
+	})
+}
+
+var EndpointInvalidProxy = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Redirect("/home", StatusMovedPermanently)
+				Proxy("legacy:8080")
+			})
+		})
+	})
+}
+
+var EndpointInvalidAcceptRanges = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			Result(Bytes)
+			HTTP(func() {
+				GET("/")
+				AcceptRanges()
+			})
+		})
+	})
+}
+
+var EndpointInvalidDownload = func() {
+	Service("Service", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+				Download("pdf;;", func() {
+					Disposition("download")
+				})
+			})
+		})
+	})
+}
@@ -71,3 +71,159 @@ var InvalidSecuritySchemesDSL = func() {
 		})
 	})
 }
+
+var ValidPaginationDSL = func() {
+	Service("PaginatedService", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("cursor", Int64)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next", Int64)
+			})
+			Paginated("cursor", "next", "items")
+		})
+	})
+}
+
+var InvalidPaginationDSL = func() {
+	Service("PaginatedService", func() {
+		Method("NotObject", func() {
+			Payload(String)
+			Result(ArrayOf(String))
+			Paginated("cursor", "next", "items")
+		})
+		Method("MissingAttributes", func() {
+			Payload(func() {
+				Attribute("page", Int)
+			})
+			Result(func() {
+				Attribute("values", ArrayOf(String))
+			})
+			Paginated("cursor", "next", "items")
+		})
+		Method("InvalidTypes", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			Result(func() {
+				Attribute("items", String)
+				Attribute("next", Int)
+			})
+			Paginated("cursor", "next", "items")
+		})
+		Method("Streaming", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+			})
+			StreamingResult(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next", String)
+			})
+			Paginated("cursor", "next", "items")
+		})
+	})
+}
+
+var InvalidTimeoutDSL = func() {
+	Service("TimeoutService", func() {
+		Method("Streaming", func() {
+			StreamingResult(String)
+			Timeout("1s")
+		})
+	})
+}
+
+var ValidLongRunningDSL = func() {
+	var Operation = Type("Operation", func() {
+		Attribute("id", String)
+		Attribute("done", Boolean)
+		Required("id", "done")
+	})
+	Service("LongRunningService", func() {
+		Method("Export", func() {
+			Payload(String)
+			LongRunning(Operation)
+		})
+	})
+}
+
+var InvalidLongRunningDSL = func() {
+	var Operation = Type("Operation", func() {
+		Attribute("id", String)
+		Attribute("done", Boolean)
+		Required("id")
+	})
+	var BadOperation = Type("BadOperation", func() {
+		Attribute("id", Int)
+	})
+	Service("LongRunningService", func() {
+		Method("Streaming", func() {
+			StreamingResult(Operation)
+			LongRunning(Operation)
+		})
+		Method("BadStatus", func() {
+			LongRunning(BadOperation)
+		})
+		Method("ResultOverride", func() {
+			LongRunning(Operation)
+			Result(String)
+		})
+	})
+}
+
+var InvalidExampleDSL = func() {
+	Service("ExampleService", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			Example("valid", Val{"id": 1})
+			Example("invalid", Val{"id": "one"})
+			Example("unknown", Val{"name": "one"})
+		})
+	})
+}
+
+var InvalidCRDDSL = func() {
+	var Widget = Type("Widget", func() {
+		Meta("k8s:crd", "widgets")
+		Attribute("name", String)
+	})
+	var Tags = Type("Tags", ArrayOf(String), func() {
+		Meta("k8s:crd", "example.com")
+	})
+	var Gadget = Type("Gadget", func() {
+		Meta("k8s:crd", "example.com")
+		Attribute("name", String)
+	})
+	Service("CRDService", func() {
+		Method("Apply", func() {
+			Payload(Widget)
+		})
+		Method("Tag", func() {
+			Payload(Tags)
+		})
+		Method("Watch", func() {
+			Payload(Gadget)
+			StreamingResult(String)
+		})
+	})
+}
+
+var TenantDSL = func() {
+	var _ = API("Tenant", func() {
+		TenantParam("account_id")
+	})
+	Service("TenantService", func() {
+		Method("Scoped", func() {
+			Payload(func() {
+				Attribute("account_id", String)
+			})
+		})
+		Method("Unscoped", func() {
+			Payload(String)
+		})
+	})
+}
@@ -65,3 +65,24 @@ var ServiceErrorDSL = func() {
 		Method("Method", func() {})
 	})
 }
+
+var InvalidTerraformResourceDSL = func() {
+	Service("Widgets", func() {
+		Meta("terraform:resource", "widget")
+		Method("create", func() {
+			Payload(String)
+			// invalid: payload must be an object
+		})
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			// invalid: result must be an object
+		})
+		Method("purge", func() {
+			Meta("terraform:operation", "remove")
+			// invalid: unknown operation
+		})
+		// invalid: missing delete method
+	})
+}
@@ -3,8 +3,11 @@ package expr
 import (
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/google/uuid"
 	"goa.design/goa/v3/eval"
+	goa "goa.design/goa/v3/pkg"
 )
 
 type (
@@ -57,6 +60,10 @@ type (
 	Union struct {
 		TypeName string
 		Values   []*NamedAttributeExpr
+		// Discriminator is the name of the attribute that holds the
+		// name of the value type when serializing the union inline, if
+		// any. See DSL function Discriminator.
+		Discriminator string
 	}
 
 	// UserType is the interface implemented by all user type
@@ -126,6 +133,16 @@ const (
 	ResultTypeKind
 	// AnyKind represents an unknown type.
 	AnyKind
+	// DateTimeKind represents a RFC3339 date and time.
+	DateTimeKind
+	// DateKind represents a RFC3339 full-date.
+	DateKind
+	// DurationKind represents a duration.
+	DurationKind
+	// UUIDKind represents a RFC4122 UUID.
+	UUIDKind
+	// DecimalKind represents an arbitrary precision decimal number.
+	DecimalKind
 )
 
 const (
@@ -164,6 +181,22 @@ const (
 
 	// Any is the type for an arbitrary JSON value (interface{} in Go).
 	Any = Primitive(AnyKind)
+
+	// DateTime is the type for a RFC3339 date and time (time.Time in Go).
+	DateTime = Primitive(DateTimeKind)
+
+	// Date is the type for a RFC3339 full-date (goa.Date in Go).
+	Date = Primitive(DateKind)
+
+	// Duration is the type for a duration (time.Duration in Go).
+	Duration = Primitive(DurationKind)
+
+	// UUID is the type for a RFC4122 UUID (uuid.UUID in Go).
+	UUID = Primitive(UUIDKind)
+
+	// Decimal is the type for an arbitrary precision decimal number
+	// (goa.Decimal in Go).
+	Decimal = Primitive(DecimalKind)
 )
 
 // Built-in composite types
@@ -315,6 +348,16 @@ func (p Primitive) Name() string {
 		return "bytes"
 	case Any:
 		return "any"
+	case DateTime:
+		return "datetime"
+	case Date:
+		return "date"
+	case Duration:
+		return "duration"
+	case UUID:
+		return "uuid"
+	case Decimal:
+		return "decimal"
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -331,15 +374,33 @@ func (p Primitive) IsCompatible(val interface{}) bool {
 	case int, int8, int16, int32, uint, uint8, uint16, uint32:
 		return p == Int || p == Int32 || p == Int64 ||
 			p == UInt || p == UInt32 || p == UInt64 ||
-			p == Float32 || p == Float64
+			p == Float32 || p == Float64 || p == Decimal
 	case int64, uint64:
-		return p == Int64 || p == UInt64 || p == Float32 || p == Float64
+		return p == Int64 || p == UInt64 || p == Float32 || p == Float64 || p == Duration || p == Decimal
 	case float32, float64:
-		return p == Float32 || p == Float64
+		return p == Float32 || p == Float64 || p == Decimal
 	case string:
+		switch p {
+		case DateTime:
+			_, err := time.Parse(time.RFC3339, val.(string))
+			return err == nil
+		case Date:
+			_, err := time.Parse("2006-01-02", val.(string))
+			return err == nil
+		case UUID:
+			_, err := uuid.Parse(val.(string))
+			return err == nil
+		case Decimal:
+			_, err := goa.ParseDecimal(val.(string))
+			return err == nil
+		}
 		return p == String || p == Bytes
 	case []byte:
 		return p == Bytes
+	case time.Time:
+		return p == DateTime || p == Date
+	case time.Duration:
+		return p == Duration
 	}
 	return false
 }
@@ -370,6 +431,21 @@ func (p Primitive) Example(r *Random) interface{} {
 		return r.String()
 	case Bytes:
 		return []byte(r.String())
+	case DateTime:
+		return time.Unix(r.Int64()%2000000000, 0).UTC().Format(time.RFC3339)
+	case Date:
+		return time.Unix(r.Int64()%2000000000, 0).UTC().Format("2006-01-02")
+	case Duration:
+		return int64(time.Duration(r.Int64()%86400) * time.Second)
+	case UUID:
+		b := make([]byte, 16)
+		for i := range b {
+			b[i] = byte(r.Int())
+		}
+		u, _ := uuid.FromBytes(b)
+		return u.String()
+	case Decimal:
+		return goa.NewDecimal(int64(r.Int()%100000), 2).String()
 	default:
 		panic("unknown primitive type") // bug
 	}
@@ -695,6 +771,10 @@ func toReflectType(dtype DataType) reflect.Type {
 		return reflect.TypeOf("")
 	case BytesKind:
 		return reflect.TypeOf([]byte{})
+	case DateTimeKind, DateKind, UUIDKind, DecimalKind:
+		return reflect.TypeOf("")
+	case DurationKind:
+		return reflect.TypeOf(int64(0))
 	case ObjectKind:
 		return reflect.TypeOf(map[string]interface{}{})
 	case UserTypeKind:
@@ -99,7 +99,13 @@ func (u *UserTypeExpr) recExample(r *Random) *interface{} {
 	var ex interface{}
 	pex := &ex
 	r.Seen[u.ID()] = pex
-	actual := u.Type.Example(r)
+	var actual interface{}
+	if IsPrimitive(u.Type) {
+		// Take the validations of the type into account, e.g. enums.
+		actual = u.AttributeExpr.Example(r)
+	} else {
+		actual = u.Type.Example(r)
+	}
 	*pex = actual
 	return pex
 }
@@ -0,0 +1,148 @@
+/*
+Package fake provides the functions used by the code generated with the
+"fake:generate" meta to produce random values that satisfy the validations
+defined in the design. The functions take the random number generator as
+first argument so that the generated values are reproducible given a seed.
+*/
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	regen "github.com/zach-klippenstein/goregen"
+)
+
+// maxPatternAttempts is the maximum number of strings generated to find one
+// that matches a pattern and whose length is in range.
+const maxPatternAttempts = 20
+
+// letters is the alphabet of the generated strings.
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Int returns a random integer in the range [min, max].
+func Int(r *rand.Rand, min, max int64) int64 {
+	if max <= min {
+		return min
+	}
+	span := uint64(max - min)
+	if span == math.MaxUint64 {
+		return int64(r.Uint64())
+	}
+	return min + int64(r.Uint64()%(span+1))
+}
+
+// Float returns a random floating point number in the range [min, max).
+func Float(r *rand.Rand, min, max float64) float64 {
+	if max <= min {
+		return min
+	}
+	return min + r.Float64()*(max-min)
+}
+
+// Bool returns a random boolean.
+func Bool(r *rand.Rand) bool {
+	return r.Intn(2) == 0
+}
+
+// Len returns a random length in the range [min, max].
+func Len(r *rand.Rand, min, max int) int {
+	return int(Int(r, int64(min), int64(max)))
+}
+
+// String returns a random alphanumeric string whose length is in the range
+// [min, max].
+func String(r *rand.Rand, min, max int) string {
+	b := make([]byte, Len(r, min, max))
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+// Bytes returns random bytes whose length is in the range [min, max].
+func Bytes(r *rand.Rand, min, max int) []byte {
+	b := make([]byte, Len(r, min, max))
+	r.Read(b) // nolint: errcheck
+	return b
+}
+
+// Pattern returns a random string that matches the given regular expression
+// and whose length in runes is in the range [min, max] if possible. Pattern
+// returns a string whose length is out of range if it fails to generate one
+// that is in range after a few attempts. Pattern panics if pattern is not a
+// valid regular expression.
+func Pattern(r *rand.Rand, pattern string, min, max int) string {
+	repeat := max
+	if repeat > 10 || repeat < 1 {
+		repeat = 10
+	}
+	gen, err := regen.NewGenerator(pattern, &regen.GeneratorArgs{RngSource: r, MaxUnboundedRepeatCount: uint(repeat)})
+	if err != nil {
+		panic(fmt.Sprintf("invalid pattern %q: %s", pattern, err))
+	}
+	var s string
+	for i := 0; i < maxPatternAttempts; i++ {
+		s = gen.Generate()
+		if n := utf8.RuneCountInString(s); n >= min && n <= max {
+			break
+		}
+	}
+	return s
+}
+
+// Format returns a random string that satisfies the given format validation,
+// see the expr.ValidationFormat constants. Format returns a random string of
+// up to 10 characters if format is unknown.
+func Format(r *rand.Rand, format string) string {
+	switch format {
+	case "date":
+		return randomTime(r).Format("2006-01-02")
+	case "date-time":
+		return randomTime(r).Format(time.RFC3339)
+	case "rfc1123":
+		return randomTime(r).Format(time.RFC1123)
+	case "uuid":
+		b := Bytes(r, 16, 16)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "email":
+		return strings.ToLower(String(r, 1, 10)) + "@example.com"
+	case "hostname":
+		return strings.ToLower(String(r, 1, 10)) + ".example.com"
+	case "ipv4":
+		return net.IP(Bytes(r, 4, 4)).String()
+	case "ipv6":
+		return net.IP(Bytes(r, 16, 16)).String()
+	case "ip":
+		if Bool(r) {
+			return Format(r, "ipv4")
+		}
+		return Format(r, "ipv6")
+	case "uri":
+		return "https://example.com/" + String(r, 1, 10)
+	case "mac":
+		return net.HardwareAddr(Bytes(r, 6, 6)).String()
+	case "cidr":
+		return fmt.Sprintf("%s/%d", Format(r, "ipv4"), r.Intn(33))
+	case "regexp":
+		return "^" + String(r, 1, 10) + "$"
+	case "json":
+		b, _ := json.Marshal(map[string]interface{}{String(r, 1, 10): r.Intn(1000)})
+		return string(b)
+	default:
+		return String(r, 0, 10)
+	}
+}
+
+// randomTime returns a random time between 1970 and 2100.
+func randomTime(r *rand.Rand) time.Time {
+	return time.Unix(Int(r, 0, 4102444800), 0).UTC()
+}
@@ -0,0 +1,67 @@
+package fake
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestInt(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if v := Int(r, -3, 3); v < -3 || v > 3 {
+			t.Fatalf("got %d, expected value in [-3, 3]", v)
+		}
+	}
+	if v := Int(r, 5, 5); v != 5 {
+		t.Errorf("got %d, expected 5", v)
+	}
+}
+
+func TestPattern(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	re := regexp.MustCompile("^[a-z]+-[0-9]{2}$")
+	for i := 0; i < 20; i++ {
+		s := Pattern(r, re.String(), 4, 8)
+		if !re.MatchString(s) {
+			t.Fatalf("got %q, expected it to match %s", s, re)
+		}
+		if len(s) < 4 || len(s) > 8 {
+			t.Errorf("got %q, expected length in [4, 8]", s)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	cases := map[string]func(string) error{
+		"date":      func(s string) error { _, err := time.Parse("2006-01-02", s); return err },
+		"date-time": func(s string) error { _, err := time.Parse(time.RFC3339, s); return err },
+		"rfc1123":   func(s string) error { _, err := time.Parse(time.RFC1123, s); return err },
+		"uuid":      func(s string) error { _, err := uuid.Parse(s); return err },
+		"mac":       func(s string) error { _, err := net.ParseMAC(s); return err },
+		"cidr":      func(s string) error { _, _, err := net.ParseCIDR(s); return err },
+		"regexp":    func(s string) error { _, err := regexp.Compile(s); return err },
+		"json":      func(s string) error { var v interface{}; return json.Unmarshal([]byte(s), &v) },
+		"ip": func(s string) error {
+			if net.ParseIP(s) == nil {
+				return &net.ParseError{Type: "IP address", Text: s}
+			}
+			return nil
+		},
+	}
+	for format, check := range cases {
+		t.Run(format, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				if s := Format(r, format); check(s) != nil {
+					t.Fatalf("got invalid %s %q: %s", format, s, check(s))
+				}
+			}
+		})
+	}
+}
@@ -196,6 +196,10 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 		postInitCode string
 	)
 	{
+		// fields promoted from embedded structs cannot be set in the
+		// composite literal
+		promoted := codegen.EmbeddedFields(target.Type)
+
 		// iterate through primitive attributes to initialize the struct
 		walkMatches(source, target, func(srcMatt, tgtMatt *expr.MappedAttributeExpr, srcc, tgtc *expr.AttributeExpr, n string) {
 			if !expr.IsPrimitive(srcc.Type) {
@@ -240,6 +244,10 @@ func transformObject(source, target *expr.AttributeExpr, sourceVar, targetVar st
 				}
 				srcFieldConv = "&" + srcFieldConv
 			}
+			if _, ok := promoted[tgtField]; ok {
+				postInitCode += fmt.Sprintf("%s.%s = %s\n", targetVar, tgtField, srcFieldConv)
+				return
+			}
 			initCode += fmt.Sprintf("\n%s: %s,", tgtField, srcFieldConv)
 		})
 		if initCode != "" {
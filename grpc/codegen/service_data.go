@@ -606,7 +606,12 @@ func (d ServicesData) analyze(gs *expr.GRPCServiceExpr) *ServiceData {
 		{
 			for _, req := range e.Requirements {
 				for _, sch := range req.Schemes {
-					s := md.Requirements.Scheme(sch.SchemeName).Dup()
+					sd := md.Requirements.Scheme(sch.SchemeName)
+					if sd == nil {
+						// scheme enforced by the transport
+						continue
+					}
+					s := sd.Dup()
 					s.In = sch.In
 					switch s.In {
 					case "message":
@@ -101,6 +101,12 @@ func EncodeError(err error) error {
 			if gerr.Temporary {
 				code = codes.Unavailable
 			}
+			switch gerr.Name {
+			case goa.FeatureDisabled:
+				code = codes.NotFound
+			case goa.FeatureForbidden, goa.PermissionDenied:
+				code = codes.PermissionDenied
+			}
 		}
 		return NewStatusError(code, err, NewErrorResponse(err))
 	}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// batchKey is the context key used to mark the requests of a batch.
+var batchKey = batchKeyType{}
+
+type (
+	// BatchRequest describes a single request of a batch.
+	BatchRequest struct {
+		// Method is the HTTP method of the request.
+		Method string `json:"method"`
+		// Path is the request path including the query string if any.
+		Path string `json:"path"`
+		// Header lists the request headers. The headers of the batch
+		// request apply to all the requests of the batch unless
+		// overridden.
+		Header http.Header `json:"header,omitempty"`
+		// Body is the request body if it is valid JSON.
+		Body json.RawMessage `json:"body,omitempty"`
+		// RawBody is the request body if it is not valid JSON.
+		RawBody []byte `json:"raw_body,omitempty"`
+	}
+
+	// BatchResponse describes the response to a single request of a batch.
+	BatchResponse struct {
+		// Status is the response status code.
+		Status int `json:"status"`
+		// Header lists the response headers.
+		Header http.Header `json:"header,omitempty"`
+		// Body is the response body if it is valid JSON.
+		Body json.RawMessage `json:"body,omitempty"`
+		// RawBody is the response body if it is not valid JSON.
+		RawBody []byte `json:"raw_body,omitempty"`
+	}
+
+	// BatchClient sends batches of requests to a batch endpoint.
+	BatchClient struct {
+		doer Doer
+		url  *url.URL
+	}
+
+	// batchKeyType is the type of the context key used to mark the requests
+	// of a batch.
+	batchKeyType struct{}
+
+	// batchResponseWriter records the response to a request of a batch.
+	batchResponseWriter struct {
+		header http.Header
+		status int
+		body   bytes.Buffer
+	}
+)
+
+// BatchHandler returns a HTTP handler that serves batch requests. The body of
+// a batch request is a JSON array of BatchRequest objects. The handler runs the
+// requests sequentially through h and writes the JSON array of the
+// corresponding BatchResponse objects. max is the maximum number of requests
+// in a batch, zero means no limit. The generated example server mounts the
+// handler on the path given to the Batch DSL:
+//
+//    mux.Handle("POST", "/batch", goahttp.BatchHandler(mux, 20))
+//
+func BatchHandler(h http.Handler, max int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(batchKey) != nil {
+			http.Error(w, "batch requests cannot be nested", http.StatusBadRequest)
+			return
+		}
+		var reqs []*BatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			http.Error(w, fmt.Sprintf("invalid batch request: %s", err), http.StatusBadRequest)
+			return
+		}
+		if max > 0 && len(reqs) > max {
+			http.Error(w, fmt.Sprintf("too many requests in batch: %d, maximum is %d", len(reqs), max), http.StatusRequestEntityTooLarge)
+			return
+		}
+		resps := make([]*BatchResponse, len(reqs))
+		for i, br := range reqs {
+			resps[i] = serveBatchRequest(h, r, br)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		newPooledJSONEncoder(w).Encode(resps) // nolint: errcheck
+	}
+}
+
+// NewBatchClient returns a client that sends batches of requests to the batch
+// endpoint at the given URL using doer.
+func NewBatchClient(doer Doer, u *url.URL) *BatchClient {
+	return &BatchClient{doer: doer, url: u}
+}
+
+// Do sends the given requests in a single batch and returns the corresponding
+// responses. The requests are typically built and encoded with the functions
+// of the generated client packages and the responses decoded with the
+// corresponding decoders:
+//
+//    req, _ := c.BuildShowRequest(ctx, p)
+//    _ = storagec.EncodeShowRequest(enc)(req, p)
+//    resps, err := bc.Do(ctx, req)
+//    ...
+//    res, err := storagec.DecodeShowResponse(dec, false)(resps[0])
+//
+func (c *BatchClient) Do(ctx context.Context, reqs ...*http.Request) ([]*http.Response, error) {
+	breqs := make([]*BatchRequest, len(reqs))
+	for i, req := range reqs {
+		br := &BatchRequest{Method: req.Method, Path: req.URL.RequestURI(), Header: req.Header}
+		if req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			br.Body, br.RawBody = splitBatchBody(body)
+		}
+		breqs[i] = br
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(breqs); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url.String(), &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("batch request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var bresps []*BatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bresps); err != nil {
+		return nil, err
+	}
+	if len(bresps) != len(reqs) {
+		return nil, fmt.Errorf("batch response contains %d responses, expected %d", len(bresps), len(reqs))
+	}
+	resps := make([]*http.Response, len(bresps))
+	for i, br := range bresps {
+		body := []byte(br.Body)
+		if br.RawBody != nil {
+			body = br.RawBody
+		}
+		resps[i] = &http.Response{
+			Status:        fmt.Sprintf("%d %s", br.Status, http.StatusText(br.Status)),
+			StatusCode:    br.Status,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        br.Header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       reqs[i],
+		}
+		if resps[i].Header == nil {
+			resps[i].Header = make(http.Header)
+		}
+	}
+	return resps, nil
+}
+
+// serveBatchRequest runs a single request of the batch through h.
+func serveBatchRequest(h http.Handler, parent *http.Request, br *BatchRequest) *BatchResponse {
+	if !strings.HasPrefix(br.Path, "/") {
+		return batchError(http.StatusBadRequest, fmt.Sprintf("invalid path %q, path must start with /", br.Path))
+	}
+	body := []byte(br.Body)
+	if br.RawBody != nil {
+		body = br.RawBody
+	}
+	ctx := context.WithValue(parent.Context(), batchKey, true)
+	req, err := http.NewRequestWithContext(ctx, br.Method, br.Path, bytes.NewReader(body))
+	if err != nil {
+		return batchError(http.StatusBadRequest, err.Error())
+	}
+	req.Host = parent.Host
+	req.RemoteAddr = parent.RemoteAddr
+	req.TLS = parent.TLS
+	for k, v := range parent.Header {
+		if k == "Content-Length" || k == "Content-Type" {
+			continue
+		}
+		req.Header[k] = v
+	}
+	for k, v := range br.Header {
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
+	w := &batchResponseWriter{header: make(http.Header)}
+	h.ServeHTTP(w, req)
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	resp := &BatchResponse{Status: w.status, Header: w.header}
+	resp.Body, resp.RawBody = splitBatchBody(w.body.Bytes())
+	return resp
+}
+
+// batchError returns a batch response describing an invalid request.
+func batchError(status int, msg string) *BatchResponse {
+	return &BatchResponse{Status: status, RawBody: []byte(msg)}
+}
+
+// splitBatchBody returns the given body as JSON if valid or as raw bytes
+// otherwise.
+func splitBatchBody(body []byte) (json.RawMessage, []byte) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body), nil
+	}
+	return nil, body
+}
+
+// Header returns the response headers.
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader records the response status code.
+func (w *batchResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write records the response body.
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
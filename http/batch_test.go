@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	mux := NewMuxer()
+	mux.Handle("GET", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"` + mux.Vars(r)["id"] + `","auth":"` + r.Header.Get("Authorization") + `"}`))
+	})
+	mux.Handle("POST", "/echo", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	})
+	mux.Handle("POST", "/batch", BatchHandler(mux, 3))
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL + "/batch")
+	bc := NewBatchClient(http.DefaultClient, u)
+
+	t.Run("round-trip", func(t *testing.T) {
+		r1, _ := http.NewRequest("GET", ts.URL+"/items/1", nil)
+		r1.Header.Set("Authorization", "Bearer token")
+		r2, _ := http.NewRequest("POST", ts.URL+"/echo", strings.NewReader("not json"))
+		r3, _ := http.NewRequest("GET", ts.URL+"/unknown", nil)
+		resps, err := bc.Do(context.Background(), r1, r2, r3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []struct {
+			Status int
+			Body   string
+		}{
+			{http.StatusOK, `{"id":"1","auth":"Bearer token"}`},
+			{http.StatusCreated, "not json"},
+			{http.StatusNotFound, ""},
+		}
+		for i, e := range expected {
+			resp := resps[i]
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != e.Status {
+				t.Errorf("response %d: got status %d, expected %d", i, resp.StatusCode, e.Status)
+			}
+			if e.Body != "" && string(body) != e.Body {
+				t.Errorf("response %d: got body %q, expected %q", i, string(body), e.Body)
+			}
+		}
+		if ct := resps[0].Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("got content type %q, expected application/json", ct)
+		}
+	})
+
+	t.Run("too-many", func(t *testing.T) {
+		var reqs []*http.Request
+		for i := 0; i < 4; i++ {
+			r, _ := http.NewRequest("GET", ts.URL+"/items/1", nil)
+			reqs = append(reqs, r)
+		}
+		_, err := bc.Do(context.Background(), reqs...)
+		if err == nil || !strings.Contains(err.Error(), "status 413") {
+			t.Errorf("got error %v, expected status 413", err)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		r, _ := http.NewRequest("POST", ts.URL+"/batch", strings.NewReader("[]"))
+		resps, err := bc.Do(context.Background(), r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resps[0].StatusCode != http.StatusBadRequest {
+			t.Errorf("got status %d, expected %d", resps[0].StatusCode, http.StatusBadRequest)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// streamChunkSize is the number of bytes buffered before the elements of a
+// streamed JSON array are written to the response.
+const streamChunkSize = 32 << 10
+
+type (
+	// ArrayDecoder decodes the elements of a JSON array one at a time so
+	// that large collections can be processed without loading the entire
+	// array in memory. The generated server code uses it to implement the
+	// stream interface of the endpoints that use StreamRequestBody.
+	ArrayDecoder struct {
+		dec     *json.Decoder
+		started bool
+		done    bool
+	}
+
+	// ArrayEncoder encodes values as the elements of a JSON array written
+	// to an underlying writer as they are encoded.
+	ArrayEncoder struct {
+		w   io.Writer
+		enc *json.Encoder
+		n   int
+	}
+
+	// RequestBodyStream sends a HTTP request whose body is a JSON array
+	// written element by element. The generated client code uses it to
+	// implement the stream interface of the endpoints that use
+	// StreamRequestBody.
+	RequestBodyStream struct {
+		enc    *ArrayEncoder
+		pw     *io.PipeWriter
+		result chan requestResult
+		closed bool
+	}
+
+	// requestResult is the outcome of the request sent by a
+	// RequestBodyStream.
+	requestResult struct {
+		resp *http.Response
+		err  error
+	}
+)
+
+// NewArrayDecoder returns a decoder that reads the elements of the JSON array
+// read from r.
+func NewArrayDecoder(r io.Reader) *ArrayDecoder {
+	return &ArrayDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode decodes the next element of the array into v. It returns io.EOF once
+// all the elements have been decoded. An empty body is decoded as an empty
+// array.
+func (d *ArrayDecoder) Decode(v interface{}) error {
+	if d.done {
+		return io.EOF
+	}
+	if !d.started {
+		d.started = true
+		tok, err := d.dec.Token()
+		if err == io.EOF {
+			d.done = true
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("expected JSON array, got %v", tok)
+		}
+	}
+	if !d.dec.More() {
+		d.done = true
+		if _, err := d.dec.Token(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	return d.dec.Decode(v)
+}
+
+// NewArrayEncoder returns an encoder that writes the elements of a JSON array
+// to w.
+func NewArrayEncoder(w io.Writer) *ArrayEncoder {
+	return &ArrayEncoder{w: w, enc: json.NewEncoder(w)}
+}
+
+// Encode writes v as the next element of the array.
+func (e *ArrayEncoder) Encode(v interface{}) error {
+	sep := ","
+	if e.n == 0 {
+		sep = "["
+	}
+	if _, err := io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	e.n++
+	return e.enc.Encode(v)
+}
+
+// Close terminates the array. It does not close the underlying writer.
+func (e *ArrayEncoder) Close() error {
+	end := "]"
+	if e.n == 0 {
+		end = "[]"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// streamingJSONEncoder is the JSON encoder used by StreamingResponseEncoder.
+type streamingJSONEncoder struct {
+	w io.Writer
+}
+
+// newStreamingJSONEncoder returns a JSON encoder that streams the elements of
+// slices and arrays to w, see encodeArray.
+func newStreamingJSONEncoder(w io.Writer) Encoder {
+	return &streamingJSONEncoder{w: w}
+}
+
+// Encode writes the JSON encoding of v to the underlying writer. Slices and
+// arrays are written element by element, see encodeArray. Other values are
+// written with a single call to the underlying writer.
+func (e *streamingJSONEncoder) Encode(v interface{}) error {
+	if rv, ok := streamable(v); ok {
+		return e.encodeArray(rv)
+	}
+	return newPooledJSONEncoder(e.w).Encode(v)
+}
+
+// encodeArray writes the JSON representation of the slice or array v one
+// element at a time. The encoded elements are buffered and written to the
+// underlying writer each time the buffer grows past streamChunkSize bytes,
+// the writer is flushed after each write if it implements http.Flusher. This
+// avoids holding the complete JSON document in memory in addition to v, it
+// does not avoid building v: the generated response encoders still create the
+// entire slice before calling Encode. The output is identical to the output
+// of json.Encoder. However if an element cannot be marshaled after a chunk has
+// been written the response status and that chunk have already been sent so
+// that the client receives a truncated body.
+func (e *streamingJSONEncoder) encodeArray(v reflect.Value) error {
+	be := jsonEncoders.Get().(*bufferedJSONEncoder)
+	defer func() {
+		if be.buf.Cap() <= maxPooledBufferSize {
+			be.buf.Reset()
+			jsonEncoders.Put(be)
+		}
+	}()
+	flusher, _ := e.w.(http.Flusher)
+	be.buf.WriteByte('[')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			be.buf.WriteByte(',')
+		}
+		if err := be.enc.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+		be.buf.Truncate(be.buf.Len() - 1) // json.Encoder appends a newline
+		if be.buf.Len() >= streamChunkSize {
+			if _, err := e.w.Write(be.buf.Bytes()); err != nil {
+				return err
+			}
+			be.buf.Reset()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+	be.buf.WriteString("]\n")
+	_, err := e.w.Write(be.buf.Bytes())
+	return err
+}
+
+// streamable returns the reflect value of v if v is a non-nil slice or an
+// array that json.Marshal encodes as a JSON array.
+func streamable(v interface{}) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv, false
+		}
+	case reflect.Array:
+	default:
+		return rv, false
+	}
+	t := rv.Type()
+	if t.Elem().Kind() == reflect.Uint8 {
+		return rv, false // encoded as base64 string
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return rv, false
+	}
+	return rv, true
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// NewRequestBodyStream sends req using doer in the background and returns a
+// stream used to write the elements of the JSON array sent in the request
+// body. Any existing request body is discarded.
+func NewRequestBodyStream(doer Doer, req *http.Request) *RequestBodyStream {
+	pr, pw := io.Pipe()
+	req.Body = pr
+	req.GetBody = nil
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	s := &RequestBodyStream{
+		enc:    NewArrayEncoder(pw),
+		pw:     pw,
+		result: make(chan requestResult, 1),
+	}
+	go func() {
+		resp, err := doer.Do(req)
+		pr.CloseWithError(errRequestDone)
+		s.result <- requestResult{resp, err}
+	}()
+	return s
+}
+
+// Send writes v as the next element of the request body array. Send returns
+// an error if the request failed or the server already responded.
+func (s *RequestBodyStream) Send(v interface{}) error {
+	if s.closed {
+		return errors.New("request body stream is closed")
+	}
+	return s.enc.Encode(v)
+}
+
+// Close terminates the request body and returns the HTTP response.
+func (s *RequestBodyStream) Close() (*http.Response, error) {
+	if !s.closed {
+		s.closed = true
+		if err := s.enc.Close(); err == nil {
+			s.pw.Close()
+		}
+	}
+	res := <-s.result
+	s.result <- res
+	return res.resp, res.err
+}
+
+// errRequestDone is the error returned when writing to the body of a request
+// that completed.
+var errRequestDone = errors.New("request completed")
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestArrayDecoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Body     string
+		Expected []int
+		Error    bool
+	}{
+		{"empty body", ``, nil, false},
+		{"empty array", `[]`, nil, false},
+		{"elements", `[1, 2,3]`, []int{1, 2, 3}, false},
+		{"not an array", `{"a":1}`, nil, true},
+		{"invalid element", `[1,"a"]`, []int{1}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			dec := NewArrayDecoder(strings.NewReader(c.Body))
+			var got []int
+			var err error
+			for {
+				var v int
+				if err = dec.Decode(&v); err != nil {
+					break
+				}
+				got = append(got, v)
+			}
+			if c.Error == (err == io.EOF) {
+				t.Errorf("got error %v", err)
+			}
+			if len(got) != len(c.Expected) {
+				t.Fatalf("got %v, expected %v", got, c.Expected)
+			}
+			for i, v := range got {
+				if v != c.Expected[i] {
+					t.Errorf("got %v, expected %v", got, c.Expected)
+				}
+			}
+		})
+	}
+}
+
+func TestArrayEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewArrayEncoder(&buf)
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]" {
+		t.Errorf("got %q, expected %q", buf.String(), "[]")
+	}
+	buf.Reset()
+	enc = NewArrayEncoder(&buf)
+	for _, v := range []string{"a", "b"} {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[\"a\"\n,\"b\"\n]"; buf.String() != expected {
+		t.Errorf("got %q, expected %q", buf.String(), expected)
+	}
+}
+
+func TestRequestBodyStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dec := NewArrayDecoder(r.Body)
+		var sum int
+		for {
+			var v int
+			if err := dec.Decode(&v); err != nil {
+				if err != io.EOF {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				break
+			}
+			sum += v
+		}
+		io.WriteString(w, strings.Repeat("x", sum))
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest("POST", srv.URL, nil)
+	stream := NewRequestBodyStream(http.DefaultClient, req)
+	for _, v := range []int{1, 2, 3} {
+		if err := stream.Send(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := stream.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, _ := ioutil.ReadAll(resp.Body)
+	if string(b) != "xxxxxx" {
+		t.Errorf("got %q, expected %q", b, "xxxxxx")
+	}
+	if err := stream.Send(4); err == nil {
+		t.Error("got no error sending after close")
+	}
+}
+
+type textID int
+
+func (id textID) MarshalText() ([]byte, error) { return []byte("id"), nil }
+
+type textIDs []int
+
+func (ids textIDs) MarshalText() ([]byte, error) { return []byte("ids"), nil }
+
+func TestStreamingJSONEncoderArray(t *testing.T) {
+	type elem struct {
+		Name string `json:"name"`
+		HTML string `json:"html,omitempty"`
+	}
+	cases := []struct {
+		Name  string
+		Value interface{}
+	}{
+		{"nil slice", []*elem(nil)},
+		{"empty slice", []*elem{}},
+		{"slice", []*elem{{Name: "a"}, nil, {Name: "b", HTML: "<b>"}}},
+		{"array", [2]int{1, 2}},
+		{"bytes", []byte("foo")},
+		{"text marshaler elements", []textID{1, 2}},
+		{"text marshaler", textIDs{1, 2}},
+		{"nested", [][]string{{"a"}, {}, nil}},
+	}
+	for _, c := range cases {
+		v := c.Value
+		t.Run(c.Name, func(t *testing.T) {
+			var expected, actual bytes.Buffer
+			if err := json.NewEncoder(&expected).Encode(v); err != nil {
+				t.Fatal(err)
+			}
+			if err := newStreamingJSONEncoder(&actual).Encode(v); err != nil {
+				t.Fatal(err)
+			}
+			if actual.String() != expected.String() {
+				t.Errorf("got %q, expected %q", actual.String(), expected.String())
+			}
+		})
+	}
+}
+
+func TestStreamingJSONEncoderArrayFlush(t *testing.T) {
+	elems := make([]string, 3*streamChunkSize/100)
+	for i := range elems {
+		elems[i] = strings.Repeat("a", 98)
+	}
+	w := httptest.NewRecorder()
+	if err := newStreamingJSONEncoder(w).Encode(elems); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	var decoded []string
+	if err := json.Unmarshal(w.Body.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(elems) {
+		t.Errorf("got %d elements, expected %d", len(decoded), len(elems))
+	}
+}
+
+func BenchmarkStreamingJSONEncoderArray(b *testing.B) {
+	type elem struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	elems := make([]*elem, 10000)
+	for i := range elems {
+		elems[i] = &elem{ID: i, Name: strings.Repeat("a", 100)}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := newStreamingJSONEncoder(io.Discard).Encode(elems); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
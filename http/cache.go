@@ -0,0 +1,299 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// ResponseCache is the interface implemented by the stores used by the
+	// caching doer to keep responses.
+	ResponseCache interface {
+		// Get returns the response stored under the given key if any.
+		Get(key string) (*CachedResponse, bool)
+		// Set stores the response under the given key.
+		Set(key string, resp *CachedResponse)
+		// Delete removes the response stored under the given key if any.
+		Delete(key string)
+	}
+
+	// CachedResponse is a response stored in a ResponseCache.
+	CachedResponse struct {
+		// Response contains the wire representation of the response
+		// including the body as written by http.Response.Write.
+		Response []byte
+		// Expires is the time after which the response must be
+		// revalidated before being reused.
+		Expires time.Time
+		// Vary holds the values of the request headers listed in the
+		// Vary header of the response. The response is only reused for
+		// requests that define the same values.
+		Vary http.Header
+	}
+
+	// cachingDoer is a Doer that caches responses.
+	cachingDoer struct {
+		Doer
+		cache ResponseCache
+	}
+
+	// memoryCache is a ResponseCache that keeps responses in memory. It
+	// evicts the least recently used responses once it holds size
+	// responses.
+	memoryCache struct {
+		sync.Mutex
+		size    int
+		lru     *list.List
+		entries map[string]*list.Element
+	}
+
+	// memoryEntry is an element of the memory cache LRU list.
+	memoryEntry struct {
+		key  string
+		resp *CachedResponse
+	}
+)
+
+// NewCachingDoer wraps the given doer so that the responses to GET requests
+// are cached according to their Cache-Control, Expires, ETag, Last-Modified
+// and Vary headers. Fresh responses are served from the cache without making a
+// request, stale responses that define an ETag or Last-Modified header are
+// revalidated with a conditional request. Responses are only reused for
+// requests made to the same URL with the same values for the headers listed in
+// their Vary header. Responses to requests that carry an Authorization header
+// and responses marked private are not cached so that the doer may be shared
+// by clients acting on behalf of different users. Successful requests made
+// with other methods invalidate the response cached for the same URL. Pass the
+// returned doer to the generated client constructors to cache the responses of
+// all the client requests:
+//
+//    doer := goahttp.NewCachingDoer(http.DefaultClient, goahttp.NewMemoryCache(1000))
+//    c := calcc.NewClient("http", "localhost:8080", doer, enc, dec, false)
+//
+func NewCachingDoer(d Doer, cache ResponseCache) Doer {
+	return &cachingDoer{Doer: d, cache: cache}
+}
+
+// NewMemoryCache returns a ResponseCache that keeps up to size responses in
+// memory. The least recently used responses are evicted first. NewMemoryCache
+// panics if size is lower than 1.
+func NewMemoryCache(size int) ResponseCache {
+	if size < 1 {
+		panic(fmt.Sprintf("invalid memory cache size: %d", size))
+	}
+	return &memoryCache{size: size, lru: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Do serves the response from the cache if possible, otherwise it sends the
+// request using the wrapped doer and caches the response if allowed.
+func (cd *cachingDoer) Do(req *http.Request) (*http.Response, error) {
+	key := http.MethodGet + " " + req.URL.String()
+	if req.Method != http.MethodGet {
+		resp, err := cd.Doer.Do(req)
+		if err == nil && req.Method != http.MethodHead && resp.StatusCode < 400 {
+			cd.cache.Delete(key)
+		}
+		return resp, err
+	}
+	if hasDirective(req.Header.Get("Cache-Control"), "no-store") || req.Header.Get("Authorization") != "" {
+		return cd.Doer.Do(req)
+	}
+	cached, ok := cd.cache.Get(key)
+	if ok && !varyMatches(cached.Vary, req.Header) {
+		ok = false
+	}
+	var stored *http.Response
+	if ok {
+		var err error
+		stored, err = http.ReadResponse(bufio.NewReader(bytes.NewReader(cached.Response)), req)
+		if err != nil {
+			cd.cache.Delete(key)
+			ok = false
+		}
+	}
+	if ok && time.Now().Before(cached.Expires) && !hasDirective(req.Header.Get("Cache-Control"), "no-cache") {
+		return stored, nil
+	}
+	if ok {
+		etag, lm := stored.Header.Get("ETag"), stored.Header.Get("Last-Modified")
+		if etag != "" || lm != "" {
+			req = req.Clone(req.Context())
+			if etag != "" && req.Header.Get("If-None-Match") == "" {
+				req.Header.Set("If-None-Match", etag)
+			}
+			if lm != "" && req.Header.Get("If-Modified-Since") == "" {
+				req.Header.Set("If-Modified-Since", lm)
+			}
+		}
+	}
+	resp, err := cd.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		for k, v := range resp.Header {
+			stored.Header[k] = v
+		}
+		return stored, cd.store(key, req, stored)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	return resp, cd.store(key, req, resp)
+}
+
+// store caches the response to req if its headers allow it. store reads the
+// response body and replaces it with a reader on the content read.
+func (cd *cachingDoer) store(key string, req *http.Request, resp *http.Response) error {
+	cc := resp.Header.Get("Cache-Control")
+	vary := varyValues(resp.Header, req.Header)
+	if hasDirective(cc, "no-store") || hasDirective(cc, "private") || vary == nil {
+		cd.cache.Delete(key)
+		return nil
+	}
+	expires, ok := expiration(resp.Header)
+	if !ok && resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var buf bytes.Buffer
+	stored := *resp
+	stored.Body = io.NopCloser(bytes.NewReader(body))
+	stored.ContentLength = int64(len(body))
+	stored.TransferEncoding = nil
+	if err := stored.Write(&buf); err != nil {
+		return err
+	}
+	cd.cache.Set(key, &CachedResponse{Response: buf.Bytes(), Expires: expires, Vary: vary})
+	return nil
+}
+
+// varyValues returns the values of the request headers listed in the Vary
+// header of the response. It returns nil if the response varies on "*" and
+// thus cannot be reused.
+func varyValues(resp, req http.Header) http.Header {
+	vary := make(http.Header)
+	for _, v := range resp.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				return nil
+			}
+			if name != "" {
+				vary[http.CanonicalHeaderKey(name)] = req.Values(name)
+			}
+		}
+	}
+	return vary
+}
+
+// varyMatches returns true if the request headers have the values recorded
+// when the response was cached.
+func varyMatches(vary, req http.Header) bool {
+	for name, vals := range vary {
+		got := req.Values(name)
+		if len(got) != len(vals) {
+			return false
+		}
+		for i, v := range vals {
+			if got[i] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// expiration computes the time after which the response with the given
+// headers must be revalidated. It returns false if the headers do not define
+// an expiration.
+func expiration(h http.Header) (time.Time, bool) {
+	cc := h.Get("Cache-Control")
+	if hasDirective(cc, "no-cache") {
+		return time.Time{}, true
+	}
+	for _, d := range strings.Split(cc, ",") {
+		d = strings.TrimSpace(d)
+		if !strings.HasPrefix(d, "max-age=") {
+			continue
+		}
+		secs, err := strconv.Atoi(strings.TrimPrefix(d, "max-age="))
+		if err != nil {
+			return time.Time{}, true
+		}
+		return time.Now().Add(time.Duration(secs) * time.Second), true
+	}
+	if exp := h.Get("Expires"); exp != "" {
+		t, err := http.ParseTime(exp)
+		if err != nil {
+			return time.Time{}, true
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// hasDirective returns true if the given Cache-Control header value contains
+// the given directive.
+func hasDirective(cc, directive string) bool {
+	for _, d := range strings.Split(cc, ",") {
+		if strings.EqualFold(strings.TrimSpace(d), directive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the response stored under the given key if any.
+func (c *memoryCache) Get(key string) (*CachedResponse, bool) {
+	c.Lock()
+	defer c.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*memoryEntry).resp, true
+}
+
+// Set stores the response under the given key and evicts the least recently
+// used response if the cache is full.
+func (c *memoryCache) Set(key string, resp *CachedResponse) {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryEntry).resp = resp
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoryEntry{key: key, resp: resp})
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Delete removes the response stored under the given key.
+func (c *memoryCache) Delete(key string) {
+	c.Lock()
+	defer c.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.Remove(e)
+		delete(c.entries, key)
+	}
+}
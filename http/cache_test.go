@@ -0,0 +1,165 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCachingDoer(t *testing.T) {
+	cases := []struct {
+		Name          string
+		CacheControl  string
+		ETag          string
+		Requests      int
+		ExpectedCalls int
+		ExpectedSent  int
+	}{
+		{"max-age", "max-age=60", "", 3, 1, 1},
+		{"no-store", "no-store, max-age=60", "", 3, 3, 3},
+		{"no-cache-etag", "no-cache", `"v1"`, 3, 3, 1},
+		{"etag-only", "", `"v1"`, 3, 3, 1},
+		{"no-header", "", "", 3, 3, 3},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var calls, sent int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if c.CacheControl != "" {
+					w.Header().Set("Cache-Control", c.CacheControl)
+				}
+				if c.ETag != "" {
+					w.Header().Set("ETag", c.ETag)
+					if r.Header.Get("If-None-Match") == c.ETag {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+				}
+				sent++
+				w.Write([]byte("hello"))
+			}))
+			defer ts.Close()
+			doer := NewCachingDoer(http.DefaultClient, NewMemoryCache(10))
+			for i := 0; i < c.Requests; i++ {
+				req, _ := http.NewRequest("GET", ts.URL+"/items", nil)
+				resp, err := doer.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusOK || string(body) != "hello" {
+					t.Errorf("request %d: got status %d and body %q, expected 200 and %q", i, resp.StatusCode, string(body), "hello")
+				}
+			}
+			if calls != c.ExpectedCalls {
+				t.Errorf("got %d calls, expected %d", calls, c.ExpectedCalls)
+			}
+			if sent != c.ExpectedSent {
+				t.Errorf("got %d full responses, expected %d", sent, c.ExpectedSent)
+			}
+		})
+	}
+
+	t.Run("invalidate", func(t *testing.T) {
+		var calls int
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				calls++
+			}
+			w.Header().Set("Cache-Control", "max-age=60")
+		}))
+		defer ts.Close()
+		doer := NewCachingDoer(http.DefaultClient, NewMemoryCache(10))
+		for _, m := range []string{"GET", "GET", "PUT", "GET"} {
+			req, _ := http.NewRequest(m, ts.URL, nil)
+			resp, err := doer.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+		}
+		if calls != 2 {
+			t.Errorf("got %d calls, expected 2", calls)
+		}
+	})
+
+	t.Run("request headers", func(t *testing.T) {
+		cases := []struct {
+			Name          string
+			CacheControl  string
+			Vary          string
+			Headers       []http.Header
+			ExpectedCalls int
+		}{
+			{"vary match", "max-age=60", "Accept-Language", []http.Header{{"Accept-Language": {"fr"}}, {"Accept-Language": {"fr"}}}, 1},
+			{"vary mismatch", "max-age=60", "Accept-Language", []http.Header{{"Accept-Language": {"fr"}}, {"Accept-Language": {"en"}}, {"Accept-Language": {"fr"}}}, 3},
+			{"vary star", "max-age=60", "*", []http.Header{{}, {}}, 2},
+			{"private", "private, max-age=60", "", []http.Header{{}, {}}, 2},
+			{"authorization", "max-age=60", "", []http.Header{{"Authorization": {"Bearer a"}}, {"Authorization": {"Bearer b"}}}, 2},
+		}
+		for _, c := range cases {
+			t.Run(c.Name, func(t *testing.T) {
+				var calls int
+				ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls++
+					w.Header().Set("Cache-Control", c.CacheControl)
+					if c.Vary != "" {
+						w.Header().Set("Vary", c.Vary)
+					}
+				}))
+				defer ts.Close()
+				doer := NewCachingDoer(http.DefaultClient, NewMemoryCache(10))
+				for _, h := range c.Headers {
+					req, _ := http.NewRequest("GET", ts.URL, nil)
+					req.Header = h
+					resp, err := doer.Do(req)
+					if err != nil {
+						t.Fatal(err)
+					}
+					resp.Body.Close()
+				}
+				if calls != c.ExpectedCalls {
+					t.Errorf("got %d calls, expected %d", calls, c.ExpectedCalls)
+				}
+			})
+		}
+	})
+
+	t.Run("revalidate", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+			}
+		}))
+		defer ts.Close()
+		doer := NewCachingDoer(http.DefaultClient, NewMemoryCache(10))
+		for i := 0; i < 2; i++ {
+			req, _ := http.NewRequest("GET", ts.URL, nil)
+			resp, err := doer.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if v := req.Header.Get("If-None-Match"); v != "" {
+				t.Errorf("request %d: got If-None-Match %q added to the caller request", i, v)
+			}
+		}
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", &CachedResponse{})
+	c.Set("b", &CachedResponse{})
+	c.Get("a")
+	c.Set("c", &CachedResponse{})
+	for key, expected := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := c.Get(key); ok != expected {
+			t.Errorf("%s: got cached %v, expected %v", key, ok, expected)
+		}
+	}
+}
@@ -0,0 +1,438 @@
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CBORContentType is the content type of CBOR (RFC 8949) encoded bodies.
+const CBORContentType = "application/cbor"
+
+// cborMaxDepth is the maximum nesting depth of the decoded CBOR data items.
+const cborMaxDepth = 1000
+
+// CBOR major types.
+const (
+	cborUint byte = iota << 5
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+type (
+	// cborEncoder writes values as CBOR data items.
+	cborEncoder struct {
+		w io.Writer
+	}
+
+	// cborDecoder reads values from CBOR data items.
+	cborDecoder struct {
+		r io.Reader
+	}
+
+	// cborReader reads the data items of a CBOR encoded buffer.
+	cborReader struct {
+		b     []byte
+		depth int
+	}
+)
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// NewCBOREncoder returns an encoder that writes values to w as CBOR data
+// items. The values are first marshaled to JSON so that the CBOR maps use the
+// same keys as the JSON representation, i.e. the "json" struct tags of the
+// generated types, and list the keys in the same order. Integers are encoded
+// as CBOR integers, the other numbers as single or double precision floats
+// and binary values as base64 encoded text strings.
+func NewCBOREncoder(w io.Writer) Encoder {
+	return &cborEncoder{w: w}
+}
+
+// NewCBORDecoder returns a decoder that reads values from the CBOR data item
+// read from r. The data item is converted to JSON before being unmarshaled so
+// that the map keys match the "json" struct tags of the generated types. Byte
+// strings are converted to base64 encoded strings so that they can be
+// unmarshaled into byte slices. Decode returns io.EOF if r is empty.
+func NewCBORDecoder(r io.Reader) Decoder {
+	return &cborDecoder{r: r}
+}
+
+// Encode writes v as a CBOR data item.
+func (e *cborEncoder) Encode(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if err := writeCBOR(&buf, dec, tok); err != nil {
+		return err
+	}
+	_, err = e.w.Write(buf.Bytes())
+	return err
+}
+
+// Decode reads the CBOR data item into v.
+func (d *cborDecoder) Decode(v interface{}) error {
+	b, err := io.ReadAll(d.r)
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 {
+		return io.EOF
+	}
+	cr := &cborReader{b: b}
+	var buf bytes.Buffer
+	if err := cr.readJSON(&buf); err != nil {
+		return err
+	}
+	if len(cr.b) > 0 {
+		return errors.New("cbor: unexpected data after data item")
+	}
+	return json.Unmarshal(buf.Bytes(), v)
+}
+
+// isCBOR returns true if the given media type is a CBOR media type.
+func isCBOR(mt string) bool {
+	return mt == CBORContentType || strings.HasSuffix(mt, "+cbor")
+}
+
+// writeCBOR writes the JSON value starting with tok as a CBOR data item.
+// Arrays and objects are read in full first so that their lengths can be
+// written before their elements.
+func writeCBOR(buf *bytes.Buffer, dec *json.Decoder, tok json.Token) error {
+	switch t := tok.(type) {
+	case nil:
+		buf.WriteByte(cborSimple | 22)
+	case bool:
+		if t {
+			buf.WriteByte(cborSimple | 21)
+		} else {
+			buf.WriteByte(cborSimple | 20)
+		}
+	case string:
+		writeCBORHead(buf, cborText, uint64(len(t)))
+		buf.WriteString(t)
+	case json.Number:
+		return writeCBORNumber(buf, t)
+	case json.Delim:
+		var (
+			items bytes.Buffer
+			n     uint64
+		)
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := writeCBOR(&items, dec, tok); err != nil {
+				return err
+			}
+			if t == '{' {
+				// tok is the key, write the value.
+				if tok, err = dec.Token(); err != nil {
+					return err
+				}
+				if err := writeCBOR(&items, dec, tok); err != nil {
+					return err
+				}
+			}
+			n++
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return err
+		}
+		major := cborArray
+		if t == '{' {
+			major = cborMap
+		}
+		writeCBORHead(buf, major, n)
+		buf.Write(items.Bytes())
+	default:
+		return fmt.Errorf("cbor: unexpected JSON token %v", tok)
+	}
+	return nil
+}
+
+// writeCBORNumber writes the given JSON number as a CBOR integer if it is one
+// and as a float otherwise.
+func writeCBORNumber(buf *bytes.Buffer, n json.Number) error {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		if i >= 0 {
+			writeCBORHead(buf, cborUint, uint64(i))
+		} else {
+			writeCBORHead(buf, cborNegInt, uint64(-1-i))
+		}
+		return nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		writeCBORHead(buf, cborUint, u)
+		return nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return err
+	}
+	if f32 := float32(f); float64(f32) == f {
+		buf.WriteByte(cborSimple | 26)
+		binary.Write(buf, binary.BigEndian, math.Float32bits(f32)) // nolint: errcheck
+		return nil
+	}
+	buf.WriteByte(cborSimple | 27)
+	binary.Write(buf, binary.BigEndian, math.Float64bits(f)) // nolint: errcheck
+	return nil
+}
+
+// writeCBORHead writes the head of a data item of the given major type with
+// the given argument using the shortest encoding.
+func writeCBORHead(buf *bytes.Buffer, major byte, n uint64) {
+	switch {
+	case n < 24:
+		buf.WriteByte(major | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(major | 24)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(major | 25)
+		binary.Write(buf, binary.BigEndian, uint16(n)) // nolint: errcheck
+	case n <= math.MaxUint32:
+		buf.WriteByte(major | 26)
+		binary.Write(buf, binary.BigEndian, uint32(n)) // nolint: errcheck
+	default:
+		buf.WriteByte(major | 27)
+		binary.Write(buf, binary.BigEndian, n) // nolint: errcheck
+	}
+}
+
+// readJSON reads the next data item and writes it to buf as JSON.
+func (r *cborReader) readJSON(buf *bytes.Buffer) error {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > cborMaxDepth {
+		return errors.New("cbor: maximum nesting depth exceeded")
+	}
+	major, info, n, err := r.readHead()
+	if err != nil {
+		return err
+	}
+	indefinite := info == 31
+	switch major {
+	case cborUint:
+		buf.WriteString(strconv.FormatUint(n, 10))
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			buf.WriteByte('-')
+			buf.WriteString(strconv.FormatUint(n, 10)) // -1-n rounded, out of range for Go integers
+			return nil
+		}
+		buf.WriteString(strconv.FormatInt(-1-int64(n), 10))
+	case cborBytes, cborText:
+		s, err := r.readString(major, n, indefinite)
+		if err != nil {
+			return err
+		}
+		if major == cborBytes {
+			s = []byte(base64.StdEncoding.EncodeToString(s))
+		} else if !utf8.Valid(s) {
+			return errors.New("cbor: invalid UTF-8 text string")
+		}
+		b, _ := json.Marshal(string(s))
+		buf.Write(b)
+	case cborArray, cborMap:
+		open, end := byte('['), byte(']')
+		if major == cborMap {
+			open, end = '{', '}'
+		}
+		buf.WriteByte(open)
+		for i := uint64(0); indefinite || i < n; i++ {
+			if indefinite && r.isBreak() {
+				break
+			}
+			if !indefinite && uint64(len(r.b)) < n-i {
+				return errCBORTruncated
+			}
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if major == cborMap {
+				if err := r.readKey(buf); err != nil {
+					return err
+				}
+				buf.WriteByte(':')
+			}
+			if err := r.readJSON(buf); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(end)
+	case cborTag:
+		return r.readJSON(buf) // tags are ignored
+	case cborSimple:
+		return r.readSimple(buf, info, n)
+	}
+	return nil
+}
+
+// readKey reads a map key and writes it to buf as a JSON string. Integer keys
+// are converted to their decimal representation.
+func (r *cborReader) readKey(buf *bytes.Buffer) error {
+	if len(r.b) == 0 {
+		return errCBORTruncated
+	}
+	if major := r.b[0] & 0xe0; major == cborText || major == cborBytes {
+		return r.readJSON(buf)
+	}
+	var key bytes.Buffer
+	if err := r.readJSON(&key); err != nil {
+		return err
+	}
+	b, _ := json.Marshal(key.String())
+	buf.Write(b)
+	return nil
+}
+
+// readSimple writes the simple value or float with the given additional
+// information to buf as JSON.
+func (r *cborReader) readSimple(buf *bytes.Buffer, info byte, n uint64) error {
+	var f float64
+	switch info {
+	case 20:
+		buf.WriteString("false")
+		return nil
+	case 21:
+		buf.WriteString("true")
+		return nil
+	case 22, 23:
+		buf.WriteString("null")
+		return nil
+	case 25:
+		f = float16(uint16(n))
+	case 26:
+		f = float64(math.Float32frombits(uint32(n)))
+	case 27:
+		f = math.Float64frombits(n)
+	default:
+		return fmt.Errorf("cbor: unsupported simple value %d", n)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return errors.New("cbor: unsupported NaN or infinite number")
+	}
+	buf.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	return nil
+}
+
+// readString reads the content of a byte or text string with the given
+// length or of the chunks of an indefinite length string.
+func (r *cborReader) readString(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		if uint64(len(r.b)) < n {
+			return nil, errCBORTruncated
+		}
+		s := r.b[:n]
+		r.b = r.b[n:]
+		return s, nil
+	}
+	var s []byte
+	for !r.isBreak() {
+		m, info, n, err := r.readHead()
+		if err != nil {
+			return nil, err
+		}
+		if m != major || info == 31 {
+			return nil, errors.New("cbor: invalid indefinite length string chunk")
+		}
+		chunk, err := r.readString(major, n, false)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, chunk...)
+	}
+	return s, nil
+}
+
+// readHead reads the head of the next data item and returns its major type,
+// additional information and argument.
+func (r *cborReader) readHead() (major, info byte, n uint64, err error) {
+	if len(r.b) == 0 {
+		return 0, 0, 0, errCBORTruncated
+	}
+	major, info = r.b[0]&0xe0, r.b[0]&0x1f
+	r.b = r.b[1:]
+	var size int
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	case info == 31 && major >= cborBytes && major <= cborMap:
+		return major, info, 0, nil
+	default:
+		return 0, 0, 0, fmt.Errorf("cbor: invalid additional information %d", info)
+	}
+	if len(r.b) < size {
+		return 0, 0, 0, errCBORTruncated
+	}
+	for _, c := range r.b[:size] {
+		n = n<<8 | uint64(c)
+	}
+	r.b = r.b[size:]
+	return major, info, n, nil
+}
+
+// isBreak returns true and consumes the "break" stop code if it is the next
+// byte.
+func (r *cborReader) isBreak() bool {
+	if len(r.b) > 0 && r.b[0] == 0xff {
+		r.b = r.b[1:]
+		return true
+	}
+	return false
+}
+
+// float16 returns the value of the given IEEE 754 half precision float.
+func float16(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type cborReading struct {
+	Sensor string   `json:"sensor"`
+	Value  float64  `json:"value"`
+	Tags   []string `json:"tags,omitempty"`
+	Raw    []byte   `json:"raw,omitempty"`
+	Count  *int     `json:"count,omitempty"`
+}
+
+func TestCBOREncoder(t *testing.T) {
+	count := -500
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected string
+	}{
+		{"zero", 0, "00"},
+		{"uint8", 24, "1818"},
+		{"uint16", 1000, "1903e8"},
+		{"uint32", 1000000, "1a000f4240"},
+		{"uint64", uint64(18446744073709551615), "1bffffffffffffffff"},
+		{"negative", -1000, "3903e7"},
+		{"float32", 1.5, "fa3fc00000"},
+		{"float64", 1.1, "fb3ff199999999999a"},
+		{"true", true, "f5"},
+		{"null", nil, "f6"},
+		{"string", "IETF", "6449455446"},
+		{"array", []int{1, 2, 3}, "83010203"},
+		{"struct", &cborReading{Sensor: "a", Value: 2, Count: &count}, "a3" + "6673656e736f72" + "6161" + "6576616c7565" + "02" + "65636f756e74" + "3901f3"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewCBOREncoder(&buf).Encode(c.Value); err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(buf.Bytes()); got != c.Expected {
+				t.Errorf("got %s, expected %s", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCBORDecoder(t *testing.T) {
+	count := -500
+	cases := []struct {
+		Name     string
+		Data     string
+		Expected *cborReading
+		Err      bool
+	}{
+		{"struct", "a3" + "6673656e736f72" + "6161" + "6576616c7565" + "02" + "65636f756e74" + "3901f3", &cborReading{Sensor: "a", Value: 2, Count: &count}, false},
+		{"half-float", "a1" + "6576616c7565" + "f93e00", &cborReading{Value: 1.5}, false},
+		{"byte-string", "a1" + "63726177" + "4401020304", &cborReading{Raw: []byte{1, 2, 3, 4}}, false},
+		{"indefinite", "bf" + "6474616773" + "9f" + "7f6241426143ff" + "ff" + "ff", &cborReading{Tags: []string{"ABC"}}, false},
+		{"tagged", "a1" + "6673656e736f72" + "d82061" + "61", &cborReading{Sensor: "a"}, false},
+		{"truncated", "a2" + "6673656e736f72" + "6161", nil, true},
+		{"trailing-data", "a0" + "00", nil, true},
+		{"invalid-utf8", "a1" + "6673656e736f72" + "61ff", nil, true},
+		{"nan", "a1" + "6576616c7565" + "f97e00", nil, true},
+		{"huge-length", "9bffffffffffffffff", nil, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			data, err := hex.DecodeString(c.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var r cborReading
+			err = NewCBORDecoder(bytes.NewReader(data)).Decode(&r)
+			if c.Err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(&r, c.Expected) {
+				t.Errorf("got %+v, expected %+v", r, c.Expected)
+			}
+		})
+	}
+
+	var r cborReading
+	if err := NewCBORDecoder(bytes.NewReader(nil)).Decode(&r); err != io.EOF {
+		t.Errorf("got error %v, expected io.EOF", err)
+	}
+}
+
+func TestCBORRoundTrip(t *testing.T) {
+	count := 42
+	v := &cborReading{Sensor: "temperature", Value: -12.75, Tags: []string{"roof", "north"}, Raw: []byte("raw"), Count: &count}
+	var buf bytes.Buffer
+	if err := NewCBOREncoder(&buf).Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	var got cborReading
+	if err := NewCBORDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, v) {
+		t.Errorf("got %+v, expected %+v", got, v)
+	}
+}
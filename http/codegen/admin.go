@@ -0,0 +1,207 @@
+package codegen
+
+import (
+	"path/filepath"
+	"sort"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/expr"
+)
+
+// AdminFiles returns the files generated by the "admin" command: for each
+// server a cmd/<server>/admin.go file that implements an admin HTTP server
+// listening on its own address. The admin server serves the operational
+// endpoints (health, metrics, configuration dump, feature flag and
+// maintenance toggles) so that they can be bound to an internal port distinct
+// from the public API.
+func AdminFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svr := range root.API.Servers {
+		svrdata := example.Servers.Get(svr)
+		specs := []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/json"},
+			{Path: "expvar"},
+			{Path: "flag"},
+			{Path: "log"},
+			{Path: "net/http"},
+			{Path: "sort"},
+			{Path: "sync"},
+			{Path: "time"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		}
+		fw = append(fw, &codegen.File{
+			Path: filepath.Join("cmd", svrdata.Dir, "admin.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header("", "main", specs),
+				{
+					Name:   "admin-server",
+					Source: adminServerT,
+					Data: map[string]interface{}{
+						"Flags": adminFeatureFlags(root, svr),
+					},
+				},
+				{Name: "admin-flags", Source: adminFlagsT},
+			},
+			SkipExist: true,
+		})
+	}
+	return fw
+}
+
+// adminFeatureFlags returns the sorted names of the feature flags that gate
+// the methods of the services hosted by the given server.
+func adminFeatureFlags(root *expr.RootExpr, svr *expr.ServerExpr) []string {
+	seen := make(map[string]bool)
+	var flags []string
+	for _, name := range svr.Services {
+		svc := root.Service(name)
+		if svc == nil {
+			continue
+		}
+		for _, m := range svc.Methods {
+			for _, f := range m.Meta["feature:flag"] {
+				if !seen[f] {
+					seen[f] = true
+					flags = append(flags, f)
+				}
+			}
+		}
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+// input: map[string]interface{}{"Flags":[]string}
+const adminServerT = `
+// adminAddrF is the address of the admin server. The admin server exposes
+// operational endpoints that must only be reachable from the internal network.
+var adminAddrF = flag.String("admin-addr", "localhost:8081", "Admin server address (health, metrics, config and toggles)")
+
+// adminFlags holds the feature flags toggled with the admin server, all flags
+// are off initially. adminFlags implements middleware.FlagProvider so that it
+// can be given to the FeatureFlag endpoint middleware:
+//
+//    endpoints.Use(middleware.FeatureFlag(adminFlags, svc.MethodMeta))
+var adminFlags = &adminFlagSet{flags: map[string]bool{
+{{- range .Flags }}
+	{{ printf "%q" . }}: false,
+{{- end }}
+}}
+
+// adminMaintenance is the maintenance switch toggled with the admin server.
+// Add the Maintenance middleware to the HTTP service to honor it:
+//
+//    httpSvc.Use(httpmdlwr.Maintenance(adminMaintenance))
+var adminMaintenance = httpmdlwr.NewMaintenanceSwitch("")
+
+// handleAdminServer starts the admin HTTP server on the given address and
+// shuts it down when ctx is done. Call it from main after the other servers
+// are started:
+//
+//    handleAdminServer(ctx, *adminAddrF, &wg, errc, logger)
+//
+// The admin server serves the following endpoints:
+//
+//    GET /healthz                   liveness check
+//    GET /metrics                   variables published with package expvar
+//    GET /config                    values of the command line flags
+//    GET /flags                     state of the feature flags
+//    PUT, DELETE /flags/{name}      turn a feature flag on or off
+//    GET, PUT, DELETE /maintenance  maintenance mode state and toggle
+func handleAdminServer(ctx context.Context, addr string, wg *sync.WaitGroup, errc chan error, logger *log.Logger) {
+	mux := goahttp.NewMuxer()
+	mux.Handle("GET", "/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeAdminJSON(w, map[string]string{"status": "ok"})
+	})
+	mux.Handle("GET", "/metrics", expvar.Handler().ServeHTTP)
+	mux.Handle("GET", "/config", func(w http.ResponseWriter, r *http.Request) {
+		conf := make(map[string]string)
+		flag.VisitAll(func(f *flag.Flag) { conf[f.Name] = f.Value.String() })
+		writeAdminJSON(w, conf)
+	})
+	mux.Handle("GET", "/flags", func(w http.ResponseWriter, r *http.Request) {
+		writeAdminJSON(w, adminFlags.state())
+	})
+	mux.Handle("PUT", "/flags/{name}", func(w http.ResponseWriter, r *http.Request) {
+		adminFlags.set(mux.Vars(r)["name"], true)
+		writeAdminJSON(w, adminFlags.state())
+	})
+	mux.Handle("DELETE", "/flags/{name}", func(w http.ResponseWriter, r *http.Request) {
+		adminFlags.set(mux.Vars(r)["name"], false)
+		writeAdminJSON(w, adminFlags.state())
+	})
+	for _, verb := range []string{"GET", "PUT", "POST", "DELETE"} {
+		mux.Handle(verb, "/maintenance", adminMaintenance.Handler().ServeHTTP)
+	}
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start admin server in a separate goroutine.
+		go func() {
+			logger.Printf("admin server listening on %q", addr)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down admin server at %q", addr)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown admin server: %v", err)
+		}
+	}()
+}
+
+// writeAdminJSON writes v as the JSON response body.
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) // nolint: errcheck
+}
+`
+
+const adminFlagsT = `
+// adminFlagSet is the set of feature flags toggled with the admin server.
+type adminFlagSet struct {
+	mu    sync.RWMutex
+	flags map[string]bool
+}
+
+// Enabled returns true if the flag with the given name is on.
+func (s *adminFlagSet) Enabled(_ context.Context, flag string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.flags[flag]
+}
+
+// set turns the flag with the given name on or off.
+func (s *adminFlagSet) set(flag string, on bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flags[flag] = on
+}
+
+// state returns the flags sorted by name with their state.
+func (s *adminFlagSet) state() []map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.flags))
+	for name := range s.flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	res := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		res[i] = map[string]interface{}{"name": name, "on": s.flags[name]}
+	}
+	return res
+}
+`
@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestAdminFiles(t *testing.T) {
+	example.Servers = make(example.ServersData)
+	codegen.RunDSL(t, testdata.AdminDSL)
+	fs := AdminFiles("", expr.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	f := fs[0]
+	if f.Path != "cmd/test_api/admin.go" {
+		t.Errorf("got path %q, expected cmd/test_api/admin.go", f.Path)
+	}
+	if !f.SkipExist {
+		t.Error("expected the admin file to be skipped if it exists")
+	}
+	sections := f.Section("admin-server")
+	if len(sections) != 1 {
+		t.Fatalf("got %d admin-server sections, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.AdminServerCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.AdminServerCode))
+	}
+}
@@ -0,0 +1,304 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+// initBodyStreamData initializes the data used to render the stream structs of
+// endpoints that stream the payload elements in the HTTP request body.
+func initBodyStreamData(ed *EndpointData, e *expr.HTTPEndpointExpr, sd *ServiceData) {
+	initWebSocketData(ed, e, sd)
+	ed.ServerBodyStream, ed.ServerWebSocket = ed.ServerWebSocket, nil
+	ed.ClientBodyStream, ed.ClientWebSocket = ed.ClientWebSocket, nil
+
+	var (
+		md   = ed.Method
+		svr  = ed.ServerBodyStream
+		cli  = ed.ClientBodyStream
+		recv = svr.RecvTypeName
+	)
+	svr.RecvDesc = fmt.Sprintf("%s reads instances of %q from the %q endpoint request body.", md.ServerStream.RecvName, recv, md.Name)
+	cli.SendDesc = fmt.Sprintf("%s streams instances of %q to the %q endpoint request body.", md.ClientStream.SendName, recv, md.Name)
+	if svr.SendTypeRef != "" {
+		svr.SendDesc = fmt.Sprintf("%s writes the %q endpoint response with the given %q.", md.ServerStream.SendName, md.Name, svr.SendTypeName)
+		cli.RecvDesc = fmt.Sprintf("%s terminates the %q endpoint request body and reads the %q from the response.", md.ClientStream.RecvName, md.Name, cli.RecvTypeName)
+	}
+}
+
+// serverStream returns the data used to render the server stream struct of
+// the endpoint if any.
+func serverStream(ed *EndpointData) *WebSocketData {
+	if ed.ServerBodyStream != nil {
+		return ed.ServerBodyStream
+	}
+	return ed.ServerWebSocket
+}
+
+// clientStream returns the data used to render the client stream struct of
+// the endpoint if any.
+func clientStream(ed *EndpointData) *WebSocketData {
+	if ed.ClientBodyStream != nil {
+		return ed.ClientBodyStream
+	}
+	return ed.ClientWebSocket
+}
+
+// hasBodyStream returns true if at least one of the endpoints in the service
+// streams its payload in the request body.
+func hasBodyStream(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.ServerBodyStream != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyStreamServerFile returns the file implementing the server stream
+// interfaces of the endpoints that stream their payload in the request body if
+// any.
+func bodyStreamServerFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasBodyStream(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s request body server streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "io"},
+			{Path: "net/http"},
+			codegen.GoaImport(""),
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		if e.ServerBodyStream == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-body-stream-struct-type",
+			Source: bodyStreamStructTypeT,
+			Data:   e.ServerBodyStream,
+		})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-body-stream-recv",
+			Source: bodyStreamRecvT,
+			Data:   e.ServerBodyStream,
+		})
+		if e.ServerBodyStream.SendTypeRef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-body-stream-send",
+				Source: bodyStreamSendT,
+				Data:   e.ServerBodyStream,
+			})
+		}
+		if e.ServerBodyStream.MustClose {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-body-stream-close",
+				Source: bodyStreamCloseT,
+				Data:   e.ServerBodyStream,
+			})
+		}
+		if e.Method.ViewedResult != nil && e.Method.ViewedResult.ViewName == "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "server-body-stream-set-view",
+				Source: bodyStreamSetViewT,
+				Data:   e.ServerBodyStream,
+			})
+		}
+	}
+
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "server", "body_stream.go"),
+		SectionTemplates: sections,
+	}
+}
+
+// bodyStreamClientFile returns the file implementing the client stream
+// interfaces of the endpoints that stream their payload in the request body if
+// any.
+func bodyStreamClientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	if !hasBodyStream(data) {
+		return nil
+	}
+	svcName := data.Service.PathName
+	title := fmt.Sprintf("%s request body client streaming", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "net/http"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: genpkg + "/" + svcName, Name: data.Service.PkgName},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		if e.ClientBodyStream == nil {
+			continue
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-body-stream-struct-type",
+			Source: bodyStreamStructTypeT,
+			Data:   e.ClientBodyStream,
+		})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-body-stream-send",
+			Source: bodyStreamSendT,
+			Data:   e.ClientBodyStream,
+		})
+		if e.ClientBodyStream.RecvTypeRef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-body-stream-recv",
+				Source: bodyStreamRecvT,
+				Data:   e.ClientBodyStream,
+			})
+		}
+		if e.ClientBodyStream.MustClose {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-body-stream-close",
+				Source: bodyStreamCloseT,
+				Data:   e.ClientBodyStream,
+			})
+		}
+	}
+
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "client", "body_stream.go"),
+		SectionTemplates: sections,
+	}
+}
+
+const (
+	// bodyStreamStructTypeT renders the server and client struct types that
+	// implement the stream interfaces using the request body.
+	// input: WebSocketData
+	bodyStreamStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- if eq .Type "server" }}
+	{{ comment "ctx is the request context." }}
+	ctx context.Context
+	{{ comment "w is the HTTP response writer." }}
+	w http.ResponseWriter
+	{{ comment "dec decodes the elements of the request body." }}
+	dec *goahttp.ArrayDecoder
+	{{ comment "encodeResponse encodes the endpoint response." }}
+	encodeResponse func(context.Context, http.ResponseWriter, interface{}) error
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if not .Endpoint.Method.ViewedResult.ViewName }}
+	{{ printf "view is the view to render %s result type before writing the response." .SendTypeName | comment }}
+	view string
+		{{- end }}
+	{{- end }}
+{{- else }}
+	{{ comment "stream writes the elements of the request body." }}
+	stream *goahttp.RequestBodyStream
+	{{ comment "decodeResponse decodes the endpoint response." }}
+	decodeResponse func(*http.Response) (interface{}, error)
+{{- end }}
+}
+`
+
+	// bodyStreamRecvT renders the function implementing the Recv method of
+	// the server stream interface or the CloseAndRecv method of the client
+	// stream interface.
+	// input: WebSocketData
+	bodyStreamRecvT = `{{ comment .RecvDesc }}
+func (s *{{ .VarName }}) {{ .RecvName }}() ({{ .RecvTypeRef }}, error) {
+{{- if eq .Type "server" }}
+	var (
+		rv   {{ .RecvTypeRef }}
+		body {{ .Payload.VarName }}
+		err  error
+	)
+	if err = s.dec.Decode(&body); err != nil {
+		if err == io.EOF {
+			return rv, err
+		}
+		return rv, goa.DecodePayloadError(err.Error())
+	}
+	{{- if .Payload.ValidateRef }}
+	{{ .Payload.ValidateRef }}
+	if err != nil {
+		return rv, err
+	}
+	{{- end }}
+	{{- if .Payload.Init }}
+	return {{ .Payload.Init.Name }}({{ range .Payload.Init.ServerArgs }}{{ .Ref }}{{ end }}), nil
+	{{- else }}
+	return body, nil
+	{{- end }}
+{{- else }}
+	var rv {{ .RecvTypeRef }}
+	resp, err := s.stream.Close()
+	if err != nil {
+		return rv, goahttp.ErrRequestError({{ printf "%q" .Endpoint.ServiceName }}, {{ printf "%q" .Endpoint.Method.Name }}, err)
+	}
+	res, err := s.decodeResponse(resp)
+	if err != nil {
+		return rv, err
+	}
+	return res.({{ .RecvTypeRef }}), nil
+{{- end }}
+}
+`
+
+	// bodyStreamSendT renders the function implementing the SendAndClose
+	// method of the server stream interface or the Send method of the client
+	// stream interface.
+	// input: WebSocketData
+	bodyStreamSendT = `{{ comment .SendDesc }}
+func (s *{{ .VarName }}) {{ .SendName }}(v {{ .SendTypeRef }}) error {
+{{- if eq .Type "server" }}
+	{{- if .Endpoint.Method.ViewedResult }}
+		{{- if .Endpoint.Method.ViewedResult.ViewName }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, {{ printf "%q" .Endpoint.Method.ViewedResult.ViewName }})
+		{{- else }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, s.view)
+		{{- end }}
+	return s.encodeResponse(s.ctx, s.w, res)
+	{{- else }}
+	return s.encodeResponse(s.ctx, s.w, v)
+	{{- end }}
+{{- else }}
+	{{- if .Payload.Init }}
+	return s.stream.Send({{ .Payload.Init.Name }}(v))
+	{{- else }}
+	return s.stream.Send(v)
+	{{- end }}
+{{- end }}
+}
+`
+
+	// bodyStreamCloseT renders the function implementing the Close method of
+	// the stream interfaces.
+	// input: WebSocketData
+	bodyStreamCloseT = `{{ printf "Close terminates the %q endpoint request body stream." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+{{- if eq .Type "server" }}
+	return nil
+{{- else }}
+	resp, err := s.stream.Close()
+	if err != nil {
+		return goahttp.ErrRequestError({{ printf "%q" .Endpoint.ServiceName }}, {{ printf "%q" .Endpoint.Method.Name }}, err)
+	}
+	_, err = s.decodeResponse(resp)
+	return err
+{{- end }}
+}
+`
+
+	// bodyStreamSetViewT renders the function implementing the SetView
+	// method of the server stream interface.
+	// input: WebSocketData
+	bodyStreamSetViewT = `{{ printf "SetView sets the view to render the %s type before writing the %q endpoint response." .SendTypeName .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) SetView(view string) {
+	s.view = view
+}
+`
+)
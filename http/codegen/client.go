@@ -17,6 +17,9 @@ func ClientFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketClientFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := bodyStreamClientFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := clientEncodeDecodeFile(genpkg, svc); f != nil {
@@ -35,6 +38,8 @@ func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "client", []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "crypto/tls"},
+			{Path: "crypto/x509"},
 			{Path: "fmt"},
 			{Path: "io"},
 			{Path: "mime/multipart"},
@@ -85,6 +90,26 @@ func clientFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		})
 	}
 
+	if len(data.Hosts) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-hosts",
+			Source: clientHostsT,
+			Data:   data,
+		})
+	}
+
+	for _, ts := range data.TransportSchemes {
+		name, source := "client-signature", clientSignatureT
+		if ts.Kind == "MutualTLS" {
+			name, source = "client-mutual-tls", clientMutualTLST
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   name,
+			Source: source,
+			Data:   map[string]interface{}{"ClientStruct": data.ClientStruct, "Scheme": ts},
+		})
+	}
+
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -257,6 +282,56 @@ func responseStructPkg(m *service.MethodData, def string) string {
 	return def
 }
 
+// input: ServiceData
+const clientHostsT = `{{ printf "Hosts lists the HTTP hosts of the servers that expose the %s service. Use goahttp.HostURL to compute the scheme and host given to NewClient." .Service.Name | comment }}
+var Hosts = []*goahttp.ServerHost{
+{{- range .Hosts }}
+	{
+		Server: {{ printf "%q" .Server }},
+		Name:   {{ printf "%q" .Name }},
+		URIs:   []string{ {{- range $i, $u := .URIs }}{{ if $i }}, {{ end }}{{ printf "%q" $u }}{{ end }}},
+	{{- if .Variables }}
+		Variables: map[string]string{
+		{{- range .Variables }}
+			{{ printf "%q" .Name }}: {{ printf "%q" .DefaultValue }},
+		{{- end }}
+		},
+		{{- $hasValues := false }}
+		{{- range .Variables }}{{ if .Values }}{{ $hasValues = true }}{{ end }}{{ end }}
+		{{- if $hasValues }}
+		Values: map[string][]string{
+			{{- range .Variables }}
+				{{- if .Values }}
+			{{ printf "%q" .Name }}: { {{- range $i, $v := .Values }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end }}},
+				{{- end }}
+			{{- end }}
+		},
+		{{- end }}
+	{{- end }}
+	},
+{{- end }}
+}
+`
+
+// input: map[string]interface{}{"ClientStruct": string, "Scheme": *TransportSchemeData}
+const clientSignatureT = `{{ printf "Sign%sRequests signs the requests made to the endpoints secured by the %q signature scheme with the given key. The signatures are verified by the middleware.VerifySignature HTTP middleware." .Scheme.VarName .Scheme.SchemeName | comment }}
+func (c *{{ .ClientStruct }}) Sign{{ .Scheme.VarName }}Requests(keyID string, key []byte) {
+{{- range .Scheme.Methods }}
+	c.{{ . }}Doer = goahttp.NewSigningDoer(c.{{ . }}Doer, keyID, key)
+{{- end }}
+}
+`
+
+// input: map[string]interface{}{"ClientStruct": string, "Scheme": *TransportSchemeData}
+const clientMutualTLST = `{{ printf "Use%sCertificate configures the client so that the requests made to the endpoints secured by the %q mutual TLS scheme present the given certificate. Server certificates are verified against rootCAs or the system pool if nil. Call it before the methods that wrap the endpoint doers such as the request signing methods." .Scheme.VarName .Scheme.SchemeName | comment }}
+func (c *{{ .ClientStruct }}) Use{{ .Scheme.VarName }}Certificate(cert tls.Certificate, rootCAs *x509.CertPool) {
+	doer := goahttp.NewMutualTLSClient(cert, rootCAs)
+{{- range .Scheme.Methods }}
+	c.{{ . }}Doer = doer
+{{- end }}
+}
+`
+
 // input: ServiceData
 const clientStructT = `{{ printf "%s lists the %s service endpoint HTTP clients." .ClientStruct .Service.Name | comment }}
 type {{ .ClientStruct }} struct {
@@ -305,7 +380,11 @@ func New{{ .ClientStruct }}(
 		RestoreResponseBody: restoreBody,
 		scheme:            scheme,
 		host:              host,
+		{{- if .Envelope }}
+		decoder:           goahttp.EnvelopeDecoder(dec, {{ printf "%q" .Envelope.DataKey }}, {{ printf "%q" .Envelope.ErrorKey }}),
+		{{- else }}
 		decoder:           dec,
+		{{- end }}
 		encoder:           enc,
 		{{- if hasWebSocket . }}
 		dialer: dialer,
@@ -372,6 +451,9 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			{{- end }}
 		{{- end }}
 		return stream, nil
+	{{- else if .ClientBodyStream }}
+		stream := goahttp.NewRequestBodyStream(c.{{ .Method.VarName }}Doer, req)
+		return &{{ .ClientBodyStream.VarName }}{stream: stream, decodeResponse: decodeResponse}, nil
 	{{- else }}
 		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
 		if err != nil {
@@ -503,10 +585,50 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			values.Add(keyStr, valueStr)
 			{{- end }}
     }
+		{{- else if .Fields }}
+		{{- $param := . }}
+		if p.{{ .FieldName }} != nil {
+		{{- range .Fields }}
+			{{- $target := printf "p.%s.%s" $param.FieldName .FieldName }}
+			{{- if .StringSlice }}
+			for _, value := range {{ $target }} {
+				values.Add("{{ .Name }}", value)
+			}
+			{{- else if .Slice }}
+			for _, value := range {{ $target }} {
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type (aliasedType .FieldType).ElemType.Type "valueStr" "value") }}
+				values.Add("{{ .Name }}", valueStr)
+			}
+			{{- else }}
+			{{- if .Pointer }}
+			if {{ $target }} != nil {
+				{{- $target = printf "(*%s)" $target }}
+			{{- end }}
+			{{ template "type_conversion" (typeConversionData .Type .FieldType (printf "%sStr" .VarName) $target) }}
+			values.Add("{{ .Name }}", {{ .VarName }}Str)
+			{{- if .Pointer }}
+			}
+			{{- end }}
+			{{- end }}
+		{{- end }}
+		}
+		{{- else if and .StringSlice (eq .Style "csv") }}
+			if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+				values.Add("{{ .Name }}", strings.Join(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}, ","))
+			}
 		{{- else if .StringSlice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				values.Add("{{ .Name }}", value)
 			}
+		{{- else if and .Slice (eq .Style "csv") }}
+			if len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}) > 0 {
+				{{ .VarName }}Strs := make([]string, len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}))
+				for i, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+					{{ template "type_conversion" (typeConversionData .Type.ElemType.Type (aliasedType .FieldType).ElemType.Type "valueStr" "value") }}
+					{{ .VarName }}Strs[i] = valueStr
+				}
+				values.Add("{{ .Name }}", strings.Join({{ .VarName }}Strs, ","))
+			}
 		{{- else if .Slice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type (aliasedType .FieldType).ElemType.Type "valueStr" "value") }}
@@ -518,6 +640,11 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- if .FieldPointer }}
 		if p.{{ .FieldName }} != nil {
 			{{- end }}
+			{{- if eq .Type.Name "datetime" }}
+		values.Add("{{ .Name }}", {{ if .FieldPointer }}(*p.{{ .FieldName }}){{ else }}p.{{ .FieldName }}{{ end }}.Format(time.RFC3339))
+			{{- else if eq .Type.Name "duration" }}
+		values.Add("{{ .Name }}", strconv.FormatInt(int64({{ if .FieldPointer }}*{{ end }}p.{{ .FieldName }}), 10))
+			{{- else }}
 		values.Add("{{ .Name }}",
 			{{- if or (eq .Type.Name "bytes") (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }} string(
 			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v",
@@ -525,6 +652,7 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- if .FieldPointer }}*{{ end }}p.{{ .FieldName }}
 			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) (and (isAlias .FieldType) (eq (underlyingType .FieldType).Name "string")) }})
 			{{- end }})
+			{{- end }}
 			{{- if .FieldPointer }}
 		}
 			{{- end }}
@@ -626,6 +754,12 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
     {{ .VarName }} := string({{ .Target }})
   {{- else if eq .Type.Name "any" -}}
     {{ .VarName }} := fmt.Sprintf("%v", {{ .Target }})
+  {{- else if eq .Type.Name "datetime" -}}
+    {{ .VarName }} := {{ .Target }}.Format(time.RFC3339)
+  {{- else if eq .Type.Name "duration" -}}
+    {{ .VarName }} := strconv.FormatInt(int64({{ .Target }}), 10)
+  {{- else if eq .Type.Name "date" "uuid" "decimal" -}}
+    {{ .VarName }} := {{ .Target }}.String()
   {{- else }}
     // unsupported type {{ .Type.Name }} for field {{ .FieldName }}
   {{- end }}
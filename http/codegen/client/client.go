@@ -0,0 +1,571 @@
+/*
+Package client builds a language independent description of the HTTP API
+defined in a design and generates clients for languages other than Go from
+it. The description lists the services, their methods with the HTTP request
+and response mappings and the types of the payloads, results and errors so
+that each language generator only has to render it.
+
+Language generators are registered with Register and looked up with Lookup,
+the package registers the "ts" (TypeScript), "python" and "java" generators,
+the "react" (React Query hooks) and "angular" (Angular services) generators
+that wrap the TypeScript client as well as the "swift" and "kotlin"
+generators that only produce the models.
+*/
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// Kinds of TypeRef.
+const (
+	// BooleanKind is the kind of boolean values.
+	BooleanKind = "boolean"
+	// IntKind is the kind of integer values.
+	IntKind = "int"
+	// FloatKind is the kind of floating point values.
+	FloatKind = "float"
+	// StringKind is the kind of string values.
+	StringKind = "string"
+	// BytesKind is the kind of binary values, encoded in base64 in JSON.
+	BytesKind = "bytes"
+	// AnyKind is the kind of values of any type.
+	AnyKind = "any"
+	// ArrayKind is the kind of arrays.
+	ArrayKind = "array"
+	// MapKind is the kind of maps.
+	MapKind = "map"
+	// TypeKind is the kind of the values whose type is one of the API
+	// types.
+	TypeKind = "type"
+)
+
+type (
+	// Generator returns the files that implement the client of the given API
+	// in a language. genpkg is the Go import path of the gen package.
+	Generator func(genpkg string, api *API) ([]*codegen.File, error)
+
+	// API describes the HTTP API exposed by the design.
+	API struct {
+		// Name is the API name.
+		Name string
+		// Title is the API title.
+		Title string
+		// Description is the API description.
+		Description string
+		// Version is the API version.
+		Version string
+		// Services lists the API services sorted by name.
+		Services []*Service
+		// Types lists the types of the payloads, results and errors
+		// sorted by name.
+		Types []*Type
+	}
+
+	// Service describes a service.
+	Service struct {
+		// Name is the service name.
+		Name string
+		// Description is the service description.
+		Description string
+		// BaseURL is the default URL of the service.
+		BaseURL string
+		// Methods lists the service methods in design order.
+		Methods []*Method
+	}
+
+	// Method describes a service method and its HTTP request and response.
+	Method struct {
+		// Name is the method name.
+		Name string
+		// Description is the method description.
+		Description string
+		// Verb is the HTTP method of the request.
+		Verb string
+		// Path is the request path, path parameters are written
+		// {name} where name is the parameter wire name.
+		Path string
+		// Payload is the method payload type, nil if the method has no
+		// payload.
+		Payload *TypeRef
+		// PathParams lists the path parameters.
+		PathParams []*Param
+		// QueryParams lists the query string parameters.
+		QueryParams []*Param
+		// Headers lists the request headers.
+		Headers []*Param
+		// BasicAuth describes the basic auth credentials of the
+		// request if any.
+		BasicAuth *BasicAuth
+		// Body describes how the request body is built from the
+		// payload, nil if the request has no body.
+		Body *Body
+		// Result is the method result type, nil if the method has no
+		// result.
+		Result *TypeRef
+		// Status is the status code of the successful responses.
+		Status int
+		// ResultHeaders lists the response headers mapped to result
+		// fields.
+		ResultHeaders []*Param
+		// ResultBody describes how the result is built from the
+		// response body, nil if the response has no body.
+		ResultBody *Body
+		// Errors lists the errors returned by the method.
+		Errors []*Error
+	}
+
+	// Param describes a path parameter, a query string parameter or a
+	// header mapped to a payload or result field.
+	Param struct {
+		// Name is the name of the parameter on the wire.
+		Name string
+		// Field is the name of the corresponding payload or result
+		// field, empty if the parameter is the whole payload.
+		Field string
+		// Type is the parameter type.
+		Type *TypeRef
+		// Required is true if the parameter is required.
+		Required bool
+		// Bearer is true if the parameter is a token sent in the
+		// Authorization header with the "Bearer" scheme.
+		Bearer bool
+		// Style is the query string serialization style of the
+		// parameter set with the "http:query:style" meta, e.g. "csv"
+		// for arrays whose elements are joined with commas. Empty if
+		// not set.
+		Style string
+	}
+
+	// BasicAuth describes the payload fields that hold the basic auth
+	// credentials.
+	BasicAuth struct {
+		// Username is the name of the username field.
+		Username string
+		// Password is the name of the password field.
+		Password string
+	}
+
+	// Body describes how a request or response body maps to the payload or
+	// result.
+	Body struct {
+		// Field is the name of the payload or result field that is
+		// the body, empty if the body is the whole value or made of
+		// Fields.
+		Field string
+		// Fields lists the names of the payload or result fields that
+		// make the body object, nil if the body is the whole value or
+		// Field.
+		Fields []string
+	}
+
+	// Error describes an error returned by a method.
+	Error struct {
+		// Name is the error name.
+		Name string
+		// Description is the error description.
+		Description string
+		// Status is the status code of the error responses.
+		Status int
+		// Type is the error type.
+		Type *TypeRef
+	}
+
+	// Type describes an object type.
+	Type struct {
+		// Name is the type name in PascalCase.
+		Name string
+		// Description is the type description.
+		Description string
+		// Fields lists the type fields in design order.
+		Fields []*Field
+	}
+
+	// Field describes a field of an object type.
+	Field struct {
+		// Name is the field name in the design, the language generators
+		// derive the field identifiers from it.
+		Name string
+		// JSON is the field name in JSON, see dsl.WireName.
+		JSON string
+		// Description is the field description.
+		Description string
+		// Type is the field type.
+		Type *TypeRef
+		// Required is true if the field is required.
+		Required bool
+	}
+
+	// TypeRef describes the type of a value.
+	TypeRef struct {
+		// Kind is the type kind, one of the Kind constants.
+		Kind string
+		// Name is the name of the referenced type if Kind is TypeKind.
+		Name string
+		// Elem is the type of the elements of arrays and maps.
+		Elem *TypeRef
+		// Key is the type of the keys of maps.
+		Key *TypeRef
+		// Enum lists the values allowed by the design if any.
+		Enum []interface{}
+	}
+
+	// builder builds the API description collecting the types.
+	builder struct {
+		// svc is the name of the service being described.
+		svc string
+		// types maps the type names to the types.
+		types map[string]*Type
+		// sources maps the type names to the design types they
+		// describe: the user type IDs or the inline types.
+		sources map[string]interface{}
+	}
+)
+
+// generators maps the language names to the language generators.
+var generators = map[string]Generator{
+	"angular": AngularFiles,
+	"java":    JavaFiles,
+	"kotlin":  KotlinFiles,
+	"python":  PythonFiles,
+	"react":   ReactQueryFiles,
+	"swift":   SwiftFiles,
+	"ts":      TypeScriptFiles,
+}
+
+// Register registers the generator of the clients for the given language,
+// overriding any existing generator. Register is meant to be called by
+// plugins from init functions.
+func Register(lang string, gen Generator) {
+	generators[lang] = gen
+}
+
+// Lookup returns the generator registered for the given language.
+func Lookup(lang string) (Generator, bool) {
+	gen, ok := generators[lang]
+	return gen, ok
+}
+
+// Languages returns the sorted names of the languages with a registered
+// generator.
+func Languages() []string {
+	langs := make([]string, 0, len(generators))
+	for lang := range generators {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// NewAPI returns the description of the HTTP API defined by the given design
+// root. Streaming methods, methods that skip the body encoding and methods
+// with multipart requests are not included.
+func NewAPI(root *expr.RootExpr) *API {
+	b := &builder{types: make(map[string]*Type), sources: make(map[string]interface{})}
+	api := &API{
+		Name:        root.API.Name,
+		Title:       root.API.Title,
+		Description: root.API.Description,
+		Version:     root.API.Version,
+	}
+	for _, svc := range root.API.HTTP.Services {
+		b.svc = svc.Name()
+		s := &Service{
+			Name:        svc.Name(),
+			Description: svc.ServiceExpr.Description,
+			BaseURL:     openapi.ServiceBaseURL(svc.ServiceExpr),
+		}
+		for _, e := range svc.HTTPEndpoints {
+			if e.MethodExpr.IsStreaming() || e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode || e.MultipartRequest {
+				continue
+			}
+			s.Methods = append(s.Methods, b.method(e))
+		}
+		api.Services = append(api.Services, s)
+	}
+	sort.Slice(api.Services, func(i, j int) bool { return api.Services[i].Name < api.Services[j].Name })
+	for _, t := range b.types {
+		api.Types = append(api.Types, t)
+	}
+	sort.Slice(api.Types, func(i, j int) bool { return api.Types[i].Name < api.Types[j].Name })
+	return api
+}
+
+// Type returns the API type with the given name, nil if there is none.
+func (a *API) Type(name string) *Type {
+	for _, t := range a.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// JSONName returns the name in JSON of the field of the object type t with the
+// given name, name if t is not an object type or has no such field. Param
+// fields, body fields and basic auth fields are field names, use JSONName to
+// compute the corresponding JSON object keys.
+func (a *API) JSONName(t *TypeRef, name string) string {
+	if t == nil || t.Kind != TypeKind {
+		return name
+	}
+	if typ := a.Type(t.Name); typ != nil {
+		for _, f := range typ.Fields {
+			if f.Name == name {
+				return f.JSON
+			}
+		}
+	}
+	return name
+}
+
+// HasPayloadField returns true if the payload of the method is an object type
+// as opposed to a primitive, array or map in which case the parameters and body
+// are the whole payload.
+func (m *Method) HasPayloadField() bool {
+	return m.Payload != nil && m.Payload.Kind == TypeKind
+}
+
+// method returns the description of the given endpoint.
+func (b *builder) method(e *expr.HTTPEndpointExpr) *Method {
+	m := e.MethodExpr
+	route := e.Routes[0]
+	path := route.FullPaths()[0]
+	for _, w := range expr.ExtractHTTPWildcards(path) {
+		path = strings.Replace(path, "{*"+w+"}", "{"+w+"}", 1)
+	}
+	meth := &Method{
+		Name:        m.Name,
+		Description: m.Description,
+		Verb:        route.Method,
+		Path:        path,
+		Payload:     b.typeRef(m.Payload, codegen.Goify(m.Name, true)+"Payload"),
+		Result:      b.typeRef(m.Result, codegen.Goify(m.Name, true)+"Result"),
+	}
+
+	// Request
+	wildcards := make(map[string]bool)
+	for _, w := range expr.ExtractHTTPWildcards(path) {
+		wildcards[w] = true
+	}
+	for _, p := range b.params(e.Params, m.Payload) {
+		if wildcards[p.Name] {
+			p.Required = true
+			meth.PathParams = append(meth.PathParams, p)
+		} else {
+			meth.QueryParams = append(meth.QueryParams, p)
+		}
+	}
+	meth.Headers = b.params(e.Headers, m.Payload)
+	for _, req := range e.Requirements {
+		for _, sch := range req.Schemes {
+			switch sch.Kind {
+			case expr.BasicAuthKind:
+				meth.BasicAuth = &BasicAuth{
+					Username: expr.TaggedAttribute(m.Payload, "security:username"),
+					Password: expr.TaggedAttribute(m.Payload, "security:password"),
+				}
+			case expr.JWTKind, expr.OAuth2Kind:
+				for _, h := range meth.Headers {
+					if h.Name == "Authorization" {
+						h.Bearer = true
+					}
+				}
+			}
+		}
+	}
+	meth.Body = body(e.Body, m.Payload)
+
+	// Response
+	if len(e.Responses) > 0 {
+		resp := e.Responses[0]
+		meth.Status = resp.StatusCode
+		meth.ResultHeaders = b.params(resp.Headers, m.Result)
+		meth.ResultBody = body(resp.Body, m.Result)
+	}
+
+	// Errors
+	for _, v := range e.HTTPErrors {
+		meth.Errors = append(meth.Errors, &Error{
+			Name:        v.Name,
+			Description: v.ErrorExpr.Description,
+			Status:      v.Response.StatusCode,
+			Type:        b.typeRef(v.ErrorExpr.AttributeExpr, codegen.Goify(v.Name, true)+"Error"),
+		})
+	}
+	return meth
+}
+
+// params returns the parameters described by the given mapped attribute whose
+// attributes are the attributes of parent of the attribute itself.
+func (b *builder) params(ma *expr.MappedAttributeExpr, parent *expr.AttributeExpr) []*Param {
+	if ma == nil {
+		return nil
+	}
+	var params []*Param
+	whole := !expr.IsObject(parent.Type)
+	for _, nat := range *expr.AsObject(ma.Type) {
+		p := &Param{
+			Name:     ma.ElemName(nat.Name),
+			Field:    nat.Name,
+			Type:     b.typeRef(nat.Attribute, codegen.Goify(nat.Name, true)),
+			Required: ma.IsRequired(nat.Name),
+		}
+		p.Style, _ = nat.Attribute.Meta.Last("http:query:style")
+		if whole {
+			p.Field = ""
+			p.Required = true
+		}
+		params = append(params, p)
+	}
+	return params
+}
+
+// body returns the description of the given request or response body whose
+// value is built from the given payload or result, nil if there is no body.
+func body(att, parent *expr.AttributeExpr) *Body {
+	if att == nil || att.Type == expr.Empty {
+		return nil
+	}
+	if o, ok := att.Meta["origin:attribute"]; ok {
+		return &Body{Field: o[0]}
+	}
+	if !expr.IsObject(parent.Type) {
+		return &Body{}
+	}
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return &Body{}
+	}
+	bd := &Body{Fields: []string{}}
+	for _, nat := range *obj {
+		bd.Fields = append(bd.Fields, nat.Name)
+	}
+	return bd
+}
+
+// typeRef returns the reference to the type of the given attribute, nil if
+// the attribute is nil or empty. name is the name given to the inline objects.
+func (b *builder) typeRef(att *expr.AttributeExpr, name string) *TypeRef {
+	if att == nil || att.Type == nil || att.Type == expr.Empty {
+		return nil
+	}
+	var enum []interface{}
+	if att.Validation != nil {
+		enum = att.Validation.Values
+	}
+	switch t := att.Type.(type) {
+	case expr.UserType:
+		if !expr.IsObject(t) {
+			return b.typeRef(t.Attribute(), name)
+		}
+		if t == expr.ErrorResult {
+			// Avoid clashes with the Error types of the target languages.
+			return b.object("ErrorResult", t.Attribute(), t.ID())
+		}
+		return b.object(codegen.Goify(t.Name(), true), t.Attribute(), t.ID())
+	case *expr.Object:
+		return b.object(name, att, t)
+	case *expr.Array:
+		return &TypeRef{Kind: ArrayKind, Elem: b.typeRef(t.ElemType, name+"Item")}
+	case *expr.Map:
+		return &TypeRef{Kind: MapKind, Key: b.typeRef(t.KeyType, name+"Key"), Elem: b.typeRef(t.ElemType, name+"Value")}
+	case *expr.Union:
+		uatt := unionObject(t)
+		uatt.Description = att.Description
+		return b.object(name, uatt, t)
+	}
+	switch att.Type.Kind() {
+	case expr.BooleanKind:
+		return &TypeRef{Kind: BooleanKind, Enum: enum}
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return &TypeRef{Kind: IntKind, Enum: enum}
+	case expr.Float32Kind, expr.Float64Kind:
+		return &TypeRef{Kind: FloatKind, Enum: enum}
+	case expr.StringKind:
+		return &TypeRef{Kind: StringKind, Enum: enum}
+	case expr.BytesKind:
+		return &TypeRef{Kind: BytesKind}
+	default:
+		return &TypeRef{Kind: AnyKind}
+	}
+}
+
+// object records the object type described by att under the given name and
+// returns a reference to it. src identifies the design type described by att:
+// the ID of user types so that copies of the same user type are recorded once
+// or the inline type itself. The name is prefixed with the service name if a
+// different type was recorded with the same name, e.g. the payloads of two
+// methods with the same name in different services.
+func (b *builder) object(name string, att *expr.AttributeExpr, src interface{}) *TypeRef {
+	if s, ok := b.sources[name]; ok && s != src {
+		name = codegen.Goify(b.svc, true) + name
+	}
+	ref := &TypeRef{Kind: TypeKind, Name: name}
+	if _, ok := b.types[name]; ok {
+		return ref
+	}
+	t := &Type{Name: name, Description: att.Description}
+	b.types[name] = t
+	b.sources[name] = src
+	for _, nat := range *expr.AsObject(att.Type) {
+		t.Fields = append(t.Fields, &Field{
+			Name:        nat.Name,
+			JSON:        att.WireName(nat.Name),
+			Description: nat.Attribute.Description,
+			Type:        b.typeRef(nat.Attribute, name+codegen.Goify(nat.Name, true)),
+			Required:    att.IsRequired(nat.Name),
+		})
+	}
+	return ref
+}
+
+// unionObject returns the object that describes the JSON representation of the
+// values of the given union: the name and the JSON encoded value of the union
+// type or the discriminator and the fields of the union type if the union has a
+// discriminator, see expr.DiscriminatedUnionObject.
+func unionObject(u *expr.Union) *expr.AttributeExpr {
+	if u.Discriminator != "" {
+		return expr.DiscriminatedUnionObject(u)
+	}
+	names := make([]interface{}, len(u.Values))
+	for i, nat := range u.Values {
+		names[i] = nat.Name
+	}
+	obj := expr.Object{
+		{Name: "Type", Attribute: &expr.AttributeExpr{
+			Type:        expr.String,
+			Description: "Union type name",
+			Validation:  &expr.ValidationExpr{Values: names},
+		}},
+		{Name: "Value", Attribute: &expr.AttributeExpr{
+			Type:        expr.String,
+			Description: "JSON formatted union value",
+		}},
+	}
+	return &expr.AttributeExpr{
+		Type:       &obj,
+		Validation: &expr.ValidationExpr{Required: []string{"Type", "Value"}},
+	}
+}
+
+// String returns a description of the type reference used in error messages
+// and tests, e.g. "array<type Account>".
+func (t *TypeRef) String() string {
+	switch t.Kind {
+	case TypeKind:
+		return "type " + t.Name
+	case ArrayKind:
+		return fmt.Sprintf("array<%s>", t.Elem)
+	case MapKind:
+		return fmt.Sprintf("map<%s, %s>", t.Key, t.Elem)
+	default:
+		return t.Kind
+	}
+}
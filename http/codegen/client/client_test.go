@@ -0,0 +1,141 @@
+package client_test
+
+import (
+	"strings"
+	"testing"
+
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/client"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestNewAPI(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.ClientDSL)
+	api := client.NewAPI(root)
+
+	if len(api.Services) != 2 || len(api.Services[0].Methods) != 4 {
+		t.Fatalf("got %d services, expected 2 with 4 methods in the first", len(api.Services))
+	}
+	svc := api.Services[0]
+	if svc.BaseURL != "https://api.example.com" {
+		t.Errorf("got base URL %q, expected https://api.example.com", svc.BaseURL)
+	}
+	var types []string
+	for _, t := range api.Types {
+		types = append(types, t.Name)
+	}
+	if got, expected := strings.Join(types, ","), "Account,AccountOwner,CreatePayload,NotFound,ShowPayload,ShowResult,UsersShowPayload"; got != expected {
+		t.Errorf("got types %s, expected %s", got, expected)
+	}
+
+	create := svc.Methods[0]
+	if create.Verb != "POST" || create.Path != "/orgs/{org}/accounts" || create.Status != 201 {
+		t.Errorf("got %s %s %d, expected POST /orgs/{org}/accounts 201", create.Verb, create.Path, create.Status)
+	}
+	if len(create.PathParams) != 1 || create.PathParams[0].Field != "org" || !create.PathParams[0].Required {
+		t.Errorf("got invalid path params %+v", create.PathParams)
+	}
+	if len(create.QueryParams) != 1 || create.QueryParams[0].Name != "dry" || create.QueryParams[0].Field != "dry-run" {
+		t.Errorf("got invalid query params %+v", create.QueryParams)
+	}
+	if len(create.Headers) != 1 || create.Headers[0].Name != "Authorization" || !create.Headers[0].Bearer {
+		t.Errorf("got invalid headers %+v", create.Headers)
+	}
+	if create.Body == nil || strings.Join(create.Body.Fields, ",") != "name" {
+		t.Errorf("got invalid body %+v, expected name field", create.Body)
+	}
+
+	show := svc.Methods[1]
+	if show.BasicAuth == nil || show.BasicAuth.Username != "user" || show.BasicAuth.Password != "pass" {
+		t.Errorf("got invalid basic auth %+v", show.BasicAuth)
+	}
+	if show.Body != nil {
+		t.Errorf("got body %+v, expected none", show.Body)
+	}
+	if show.ResultBody == nil || show.ResultBody.Field != "account" {
+		t.Errorf("got invalid result body %+v, expected account field", show.ResultBody)
+	}
+	if len(show.ResultHeaders) != 1 || show.ResultHeaders[0].Name != "ETag" || show.ResultHeaders[0].Field != "etag" {
+		t.Errorf("got invalid result headers %+v", show.ResultHeaders)
+	}
+	if len(show.Errors) != 1 || show.Errors[0].Name != "not_found" || show.Errors[0].Status != 404 || show.Errors[0].Type.String() != "type NotFound" {
+		t.Errorf("got invalid errors %+v", show.Errors)
+	}
+
+	count := svc.Methods[2]
+	if count.Payload.String() != "array<string>" || count.Result.String() != "int" {
+		t.Errorf("got payload %s and result %s, expected array<string> and int", count.Payload, count.Result)
+	}
+	if len(count.QueryParams) != 1 || count.QueryParams[0].Field != "" || count.QueryParams[0].Style != "csv" {
+		t.Errorf("got invalid query params %+v, expected whole payload", count.QueryParams)
+	}
+
+	purge := svc.Methods[3]
+	if purge.Payload != nil || purge.Result != nil || purge.Body != nil || purge.ResultBody != nil {
+		t.Errorf("got payload, result or body for method without any")
+	}
+
+	account := api.Type("Account")
+	if account == nil || len(account.Fields) != 5 {
+		t.Fatalf("got invalid Account type %+v", account)
+	}
+	if status := account.Fields[2]; status.Required || len(status.Type.Enum) != 2 {
+		t.Errorf("got invalid status field %+v", status)
+	}
+	if tags := account.Fields[3]; tags.Name != "tags" || tags.JSON != "labels" {
+		t.Errorf("got tags field with name %q and JSON name %q, expected tags and labels", tags.Name, tags.JSON)
+	}
+	if got := api.JSONName(create.Payload, "name"); got != "display_name" {
+		t.Errorf("got JSON name %q for create payload name, expected display_name", got)
+	}
+	if got := api.JSONName(count.Payload, "tags"); got != "tags" {
+		t.Errorf("got JSON name %q for non object payload, expected tags", got)
+	}
+}
+
+func TestNewAPISharedTypes(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.ClientSharedTypesDSL)
+	api := client.NewAPI(root)
+
+	var types []string
+	for _, t := range api.Types {
+		types = append(types, t.Name)
+	}
+	if got, expected := strings.Join(types, ","), "Bottle,StorePayload,StorePayloadLabel,StorePayloadPet"; got != expected {
+		t.Errorf("got types %s, expected %s", got, expected)
+	}
+	store := api.Type("StorePayload")
+	if store == nil || len(store.Fields) != 3 {
+		t.Fatalf("got invalid StorePayload type %+v", store)
+	}
+	if got := store.Fields[1].Type.String(); got != "type StorePayloadLabel" {
+		t.Errorf("got label type %s, expected type StorePayloadLabel", got)
+	}
+	label := api.Type("StorePayloadLabel")
+	if len(label.Fields) != 2 || label.Fields[0].Name != "Type" || len(label.Fields[0].Type.Enum) != 2 || label.Fields[1].Name != "Value" {
+		t.Errorf("got invalid union type fields %+v", label.Fields)
+	}
+	pet := api.Type("StorePayloadPet")
+	var fields []string
+	for _, f := range pet.Fields {
+		fields = append(fields, f.JSON)
+	}
+	if got, expected := strings.Join(fields, ","), "kind,lives,barks"; got != expected {
+		t.Errorf("got discriminated union fields %s, expected %s", got, expected)
+	}
+	if !pet.Fields[0].Required || pet.Fields[1].Required {
+		t.Errorf("got required %v and %v, expected only the discriminator to be required", pet.Fields[0].Required, pet.Fields[1].Required)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if _, ok := client.Lookup("ts"); !ok {
+		t.Error("ts generator not registered")
+	}
+	if _, ok := client.Lookup("cobol"); ok {
+		t.Error("unexpected cobol generator")
+	}
+	if langs := strings.Join(client.Languages(), ","); langs != "angular,java,kotlin,python,react,swift,ts" {
+		t.Errorf("got languages %s, expected angular,java,kotlin,python,react,swift,ts", langs)
+	}
+}
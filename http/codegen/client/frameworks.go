@@ -0,0 +1,231 @@
+package client
+
+import (
+	"path/filepath"
+	"sort"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// frameworkData is the data used to render the React Query hooks and
+	// the Angular services that wrap the TypeScript client.
+	frameworkData struct {
+		// Title is the API title.
+		Title string
+		// Types lists the names of the API types used by the methods.
+		Types []string
+		// Services lists the services.
+		Services []*frameworkService
+	}
+
+	// frameworkService describes the hooks or Angular service of a
+	// service.
+	frameworkService struct {
+		// Name is the service name.
+		Name string
+		// Prop is the name of the property of the root client that
+		// holds the service client.
+		Prop string
+		// Client is the name of the client class.
+		Client string
+		// Class is the name of the Angular service class.
+		Class string
+		// Description is the service description.
+		Description string
+		// Methods lists the service methods.
+		Methods []*frameworkMethod
+	}
+
+	// frameworkMethod describes the hook or Angular service method of a
+	// method.
+	frameworkMethod struct {
+		// Name is the name of the client method.
+		Name string
+		// Hook is the name of the React hook.
+		Hook string
+		// Description is the method description.
+		Description string
+		// Payload is the TypeScript type of the payload, empty if
+		// there is no payload.
+		Payload string
+		// Result is the TypeScript type of the result.
+		Result string
+		// Query is true if the method is a query, i.e. uses the GET
+		// HTTP method and has a result, as opposed to a mutation.
+		Query bool
+	}
+)
+
+// ReactQueryFiles returns the TypeScript client file and the file
+// gen/client/ts/hooks.ts that defines a React Query (TanStack Query v5) hook
+// per method. Methods that use the GET HTTP method and have a result are
+// wrapped with useQuery, the others with useMutation as React Query does not
+// allow queries without data.
+func ReactQueryFiles(genpkg string, api *API) ([]*codegen.File, error) {
+	return frameworkFiles(genpkg, api, "hooks.ts", "client-ts-react-query", tsReactQueryT)
+}
+
+// AngularFiles returns the TypeScript client file and the file
+// gen/client/ts/angular.ts that defines an injectable Angular service per
+// service whose methods return cold observables.
+func AngularFiles(genpkg string, api *API) ([]*codegen.File, error) {
+	return frameworkFiles(genpkg, api, "angular.ts", "client-ts-angular", tsAngularT)
+}
+
+// frameworkFiles returns the TypeScript client file and the file with the
+// given name rendered with the given template.
+func frameworkFiles(genpkg string, api *API, name, section, source string) ([]*codegen.File, error) {
+	files, err := TypeScriptFiles(genpkg, api)
+	if err != nil {
+		return nil, err
+	}
+	title := api.Title
+	if title == "" {
+		title = api.Name
+	}
+	data := &frameworkData{Title: title}
+	names := make(map[string]bool)
+	for _, svc := range api.Services {
+		s := &frameworkService{
+			Name:        svc.Name,
+			Prop:        codegen.Goify(svc.Name, false),
+			Client:      codegen.Goify(svc.Name, true) + "Client",
+			Class:       codegen.Goify(svc.Name, true) + "Service",
+			Description: svc.Description,
+		}
+		for _, m := range svc.Methods {
+			fm := &frameworkMethod{
+				Name:        codegen.Goify(m.Name, false),
+				Hook:        "use" + codegen.Goify(svc.Name, true) + codegen.Goify(m.Name, true),
+				Description: m.Description,
+				Result:      "void",
+				Query:       m.Verb == "GET" && m.Result != nil,
+			}
+			if m.Payload != nil {
+				fm.Payload = tsType(m.Payload)
+				typeNames(m.Payload, names)
+			}
+			if m.Result != nil {
+				fm.Result = tsType(m.Result)
+				typeNames(m.Result, names)
+			}
+			s.Methods = append(s.Methods, fm)
+		}
+		data.Services = append(data.Services, s)
+	}
+	for n := range names {
+		data.Types = append(data.Types, n)
+	}
+	sort.Strings(data.Types)
+	return append(files, &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "client", "ts", name),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:    section,
+			Source:  source,
+			Data:    data,
+			FuncMap: map[string]interface{}{"js": jsString, "tsDoc": tsDoc},
+		}},
+	}), nil
+}
+
+// typeNames adds the names of the API types referred to by t to names.
+func typeNames(t *TypeRef, names map[string]bool) {
+	if t == nil {
+		return
+	}
+	if t.Kind == TypeKind {
+		names[t.Name] = true
+	}
+	typeNames(t.Elem, names)
+	typeNames(t.Key, names)
+}
+
+// input: *frameworkData
+const tsReactQueryT = `// {{ .Title }} React Query hooks.
+//
+// Code generated by goa, DO NOT EDIT.
+
+import { createContext, useContext } from "react";
+import { useMutation, useQuery, type UseMutationOptions, type UseQueryOptions } from "@tanstack/react-query";
+import { Client{{ range .Types }}, type {{ . }}{{ end }} } from "./client";
+
+/** ClientContext provides the client used by the hooks, a client with the default options if not provided. */
+export const ClientContext = createContext<Client>(new Client());
+
+/** useClient returns the client provided by ClientContext. */
+export function useClient(): Client {
+	return useContext(ClientContext);
+}
+
+/** queryKeys builds the keys of the queries made by the hooks, e.g. to invalidate them. */
+export const queryKeys = {
+{{- range $svc := .Services }}
+	{{ .Prop }}: {
+		all: [{{ js .Name }}] as const,
+{{- range .Methods }}
+{{- if .Query }}
+		{{ .Name }}: ({{ if .Payload }}payload: {{ .Payload }}{{ end }}) => [{{ js $svc.Name }}, {{ js .Name }}{{ if .Payload }}, payload{{ end }}] as const,
+{{- end }}
+{{- end }}
+	},
+{{- end }}
+};
+{{- range $svc := .Services }}
+{{- range .Methods }}
+
+{{ tsDoc "" .Description }}
+{{- if .Query -}}
+export function {{ .Hook }}({{ if .Payload }}payload: {{ .Payload }}, {{ end }}options?: Omit<UseQueryOptions<{{ .Result }}, Error>, "queryKey" | "queryFn">) {
+	const client = useClient();
+	return useQuery({
+		queryKey: queryKeys.{{ $svc.Prop }}.{{ .Name }}({{ if .Payload }}payload{{ end }}),
+		queryFn: () => client.{{ $svc.Prop }}.{{ .Name }}({{ if .Payload }}payload{{ end }}),
+		...options,
+	});
+}
+{{- else -}}
+export function {{ .Hook }}(options?: Omit<UseMutationOptions<{{ .Result }}, Error, {{ if .Payload }}{{ .Payload }}{{ else }}void{{ end }}>, "mutationFn">) {
+	const client = useClient();
+	return useMutation({
+		mutationFn: ({{ if .Payload }}payload: {{ .Payload }}{{ end }}) => client.{{ $svc.Prop }}.{{ .Name }}({{ if .Payload }}payload{{ end }}),
+		...options,
+	});
+}
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+// input: *frameworkData
+const tsAngularT = `// {{ .Title }} Angular services.
+//
+// Code generated by goa, DO NOT EDIT.
+
+import { Injectable, InjectionToken, inject, type Provider } from "@angular/core";
+import { defer, type Observable } from "rxjs";
+import {
+{{- range .Services }} {{ .Client }},{{ end }} type ClientOptions{{ range .Types }}, type {{ . }}{{ end }} } from "./client";
+
+/** CLIENT_OPTIONS is the injection token of the options of the service clients. */
+export const CLIENT_OPTIONS = new InjectionToken<ClientOptions>("CLIENT_OPTIONS", { factory: () => ({}) });
+
+/** provideClientOptions returns the provider of the options of the service clients. */
+export function provideClientOptions(options: ClientOptions): Provider {
+	return { provide: CLIENT_OPTIONS, useValue: options };
+}
+{{- range .Services }}
+
+{{ tsDoc "" .Description -}}
+@Injectable({ providedIn: "root" })
+export class {{ .Class }} {
+	private readonly client = new {{ .Client }}(inject(CLIENT_OPTIONS));
+{{- range .Methods }}
+
+{{ tsDoc "\t" .Description }}	{{ .Name }}({{ if .Payload }}payload: {{ .Payload }}{{ end }}): Observable<{{ .Result }}> {
+		return defer(() => this.client.{{ .Name }}({{ if .Payload }}payload{{ end }}));
+	}
+{{- end }}
+}
+{{- end }}
+`
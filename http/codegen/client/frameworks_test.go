@@ -0,0 +1,50 @@
+package client_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/client"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestFrameworkFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		Gen  client.Generator
+		Path string
+		Code string
+	}{
+		{"react", client.ReactQueryFiles, filepath.Join("gen", "client", "ts", "hooks.ts"), testdata.ClientReactQueryCode},
+		{"angular", client.AngularFiles, filepath.Join("gen", "client", "ts", "angular.ts"), testdata.ClientAngularCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpgen.RunHTTPDSL(t, testdata.ClientDSL)
+			fs, err := c.Gen("", client.NewAPI(root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected 2", len(fs))
+			}
+			if expected := filepath.Join("gen", "client", "ts", "client.ts"); fs[0].Path != expected {
+				t.Errorf("got path %q, expected %q", fs[0].Path, expected)
+			}
+			if fs[1].Path != c.Path {
+				t.Errorf("got path %q, expected %q", fs[1].Path, c.Path)
+			}
+			var buf bytes.Buffer
+			if err := fs[1].SectionTemplates[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			code := buf.String()
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
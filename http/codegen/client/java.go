@@ -0,0 +1,961 @@
+package client
+
+import (
+	"encoding/json"
+	"html"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// javaData is the data used to render the Java sources.
+	javaData struct {
+		// Package is the name of the Java package of the clients.
+		Package string
+		// ModelPackage is the name of the Java package of the records.
+		ModelPackage string
+		// Title is the API title.
+		Title string
+		// Version is the version of the Maven artifact.
+		Version string
+		// Services lists the service clients.
+		Services []*javaService
+	}
+
+	// javaRecord describes the record of an API type.
+	javaRecord struct {
+		// Name is the record name.
+		Name string
+		// Doc is the Javadoc of the record.
+		Doc string
+		// Imports lists the imported Java types.
+		Imports []string
+		// Components lists the record components.
+		Components []*javaComponent
+	}
+
+	// javaComponent describes a record component.
+	javaComponent struct {
+		// Name is the Java component name.
+		Name string
+		// JSON is the name of the field in JSON.
+		JSON string
+		// Type is the Java type of the component.
+		Type string
+		// Required is true if the field is required.
+		Required bool
+	}
+
+	// javaError describes an exception class.
+	javaError struct {
+		// Class is the class name.
+		Class string
+		// Name is the error name.
+		Name string
+		// Description is the error description.
+		Description string
+		// Type is the Java type of the error value.
+		Type string
+		// Unchecked is true if the cast of the error value to Type is
+		// unchecked, i.e. Type is generic.
+		Unchecked bool
+		// Imports lists the imported Java types.
+		Imports []string
+	}
+
+	// javaService describes the client class of a service.
+	javaService struct {
+		// Class is the class name.
+		Class string
+		// Field is the name of the field of the root client that holds
+		// the service client.
+		Field string
+		// Name is the service name.
+		Name string
+		// Description is the service description.
+		Description string
+		// BaseURL is the default URL of the service.
+		BaseURL string
+		// Imports lists the imported Java types.
+		Imports []string
+		// Methods lists the client methods.
+		Methods []*javaMethod
+	}
+
+	// javaMethod describes a client method.
+	javaMethod struct {
+		// Name is the method name.
+		Name string
+		// Description is the method description.
+		Description string
+		// Verb is the HTTP method of the request.
+		Verb string
+		// Payload is the payload type, empty if there is no payload.
+		Payload string
+		// Result is the result type, "void" if there is no result.
+		Result string
+		// Path is the expression that computes the request path.
+		Path string
+		// Calls lists the calls to the request builder methods that
+		// set the query string, headers, credentials and body.
+		Calls []string
+		// Status is the status code of the successful responses.
+		Status int
+		// Errors lists the Transport.ErrorType expressions given to
+		// Transport.error.
+		Errors []string
+		// Return is the kind of result: "none", "decode" (the decoded
+		// body or response header) or "object" (a result object built
+		// from the body and headers).
+		Return string
+		// Decode is the expression that computes the JSON value of the
+		// result if Return is "decode".
+		Decode string
+		// ResultInit is the expression that initializes the JSON
+		// object of the result if Return is "object".
+		ResultInit string
+		// ResultHeaders lists the response headers mapped to result
+		// fields.
+		ResultHeaders []*javaHeader
+	}
+
+	// javaHeader describes a response header.
+	javaHeader struct {
+		// Name is the header name.
+		Name string
+		// Field is the name of the result field in JSON.
+		Field string
+		// List is true if the header value is a comma separated list.
+		List bool
+	}
+)
+
+// javaKeywords lists the Java reserved words and literals.
+var javaKeywords = map[string]bool{
+	"abstract": true, "assert": true, "boolean": true, "break": true, "byte": true,
+	"case": true, "catch": true, "char": true, "class": true, "const": true,
+	"continue": true, "default": true, "do": true, "double": true, "else": true,
+	"enum": true, "extends": true, "false": true, "final": true, "finally": true,
+	"float": true, "for": true, "goto": true, "if": true, "implements": true,
+	"import": true, "instanceof": true, "int": true, "interface": true, "long": true,
+	"native": true, "new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "short": true, "static": true,
+	"strictfp": true, "super": true, "switch": true, "synchronized": true, "this": true,
+	"throw": true, "throws": true, "transient": true, "true": true, "try": true,
+	"void": true, "volatile": true, "while": true, "_": true,
+}
+
+// JavaFiles returns the files of a Maven project that implements the API
+// client in gen/client/java: a record for each API type in the model package,
+// an exception class per error and a client class per service that sends the
+// requests with OkHttp and encodes and decodes the bodies with Jackson. The
+// records require Java 17.
+func JavaFiles(_ string, api *API) ([]*codegen.File, error) {
+	title := api.Title
+	if title == "" {
+		title = api.Name
+	}
+	version := api.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+	pkg := strings.ToLower(javaIdent(api.Name))
+	data := &javaData{
+		Package:      pkg,
+		ModelPackage: pkg + ".model",
+		Title:        title,
+		Version:      version,
+	}
+	var errs []*javaError
+	seen := make(map[string]bool)
+	for _, svc := range api.Services {
+		s := &javaService{
+			Class:       codegen.Goify(svc.Name, true) + "Client",
+			Field:       javaIdent(svc.Name),
+			Name:        svc.Name,
+			Description: svc.Description,
+			BaseURL:     svc.BaseURL,
+		}
+		types := []string{"java.io.IOException", "java.util.Map", "okhttp3.OkHttpClient", "okhttp3.Response"}
+		if len(api.Types) > 0 {
+			types = append(types, data.ModelPackage+".*")
+		}
+		for _, m := range svc.Methods {
+			for _, e := range m.Errors {
+				if seen[e.Name] {
+					continue
+				}
+				seen[e.Name] = true
+				je := &javaError{Class: javaExceptionClass(e.Name), Name: e.Name, Description: e.Description, Type: "Object"}
+				if e.Type != nil {
+					je.Type = javaTypeName(e.Type)
+				}
+				je.Unchecked = strings.Contains(je.Type, "<")
+				imports := []string{"com.fasterxml.jackson.databind.JsonNode"}
+				if e.Type != nil && usesTypes(e.Type) {
+					imports = append(imports, data.ModelPackage+".*")
+				}
+				je.Imports = javaImports(je.Type, imports...)
+				errs = append(errs, je)
+			}
+			jm := javaMethodData(api, m)
+			for _, t := range []string{jm.Payload, jm.Result} {
+				types = append(types, javaImports(t)...)
+			}
+			if jm.Result != "void" || len(jm.Errors) > 0 {
+				types = append(types, "com.fasterxml.jackson.core.type.TypeReference")
+			}
+			if jm.Return == "object" {
+				types = append(types, "com.fasterxml.jackson.databind.node.ObjectNode")
+			}
+			s.Methods = append(s.Methods, jm)
+		}
+		s.Imports = javaImports("", types...)
+		data.Services = append(data.Services, s)
+	}
+
+	funcs := map[string]interface{}{"java": javaString, "javaDoc": javaDoc, "xml": html.EscapeString}
+	root := filepath.Join(codegen.Gendir, "client", "java")
+	dir := filepath.Join(append([]string{root, "src", "main", "java"}, strings.Split(pkg, ".")...)...)
+	file := func(path, name, source string, d interface{}) *codegen.File {
+		return &codegen.File{
+			Path:             path,
+			SectionTemplates: []*codegen.SectionTemplate{{Name: name, Source: source, Data: d, FuncMap: funcs}},
+		}
+	}
+	files := []*codegen.File{
+		file(filepath.Join(root, "pom.xml"), "client-java-pom", javaPomT, data),
+		file(filepath.Join(dir, "Client.java"), "client-java-client", javaClientT, data),
+		file(filepath.Join(dir, "Transport.java"), "client-java-transport", javaTransportT, data),
+		file(filepath.Join(dir, "ServiceException.java"), "client-java-service-exception", javaServiceExceptionT, data),
+	}
+	for _, s := range data.Services {
+		d := map[string]interface{}{"Package": pkg, "Service": s}
+		files = append(files, file(filepath.Join(dir, s.Class+".java"), "client-java-service", javaServiceT, d))
+	}
+	for _, e := range errs {
+		d := map[string]interface{}{"Package": pkg, "Error": e}
+		files = append(files, file(filepath.Join(dir, e.Class+".java"), "client-java-exception", javaExceptionT, d))
+	}
+	for _, t := range api.Types {
+		d := map[string]interface{}{"Package": data.ModelPackage, "Record": javaRecordData(t)}
+		files = append(files, file(filepath.Join(dir, "model", t.Name+".java"), "client-java-record", javaRecordT, d))
+	}
+	return files, nil
+}
+
+// javaRecordData returns the data used to render the record of t.
+func javaRecordData(t *Type) *javaRecord {
+	r := &javaRecord{Name: t.Name}
+	doc := t.Description
+	if doc == "" {
+		doc = t.Name + " is the " + t.Name + " type."
+	}
+	var params, types []string
+	for _, f := range t.Fields {
+		c := &javaComponent{
+			Name:     javaIdent(f.Name),
+			JSON:     f.JSON,
+			Type:     javaTypeName(f.Type),
+			Required: f.Required,
+		}
+		if f.Description != "" {
+			params = append(params, "@param "+c.Name+" "+f.Description)
+		}
+		types = append(types, c.Type)
+		r.Components = append(r.Components, c)
+	}
+	if len(params) > 0 {
+		doc += "\n\n" + strings.Join(params, "\n")
+	}
+	r.Doc = javaDoc("", doc)
+	r.Imports = javaImports(strings.Join(types, " "),
+		"com.fasterxml.jackson.annotation.JsonIgnoreProperties",
+		"com.fasterxml.jackson.annotation.JsonInclude",
+		"com.fasterxml.jackson.annotation.JsonProperty",
+	)
+	return r
+}
+
+// javaMethodData returns the data used to render the client method of m.
+func javaMethodData(api *API, m *Method) *javaMethod {
+	jm := &javaMethod{
+		Name:        javaIdent(m.Name),
+		Description: m.Description,
+		Verb:        m.Verb,
+		Result:      "void",
+		Status:      m.Status,
+		Return:      "none",
+	}
+	if m.Payload != nil {
+		jm.Payload = javaTypeName(m.Payload)
+	}
+	if m.Result != nil {
+		jm.Result = javaTypeName(m.Result)
+	}
+	path := javaString(m.Path)
+	for _, p := range m.PathParams {
+		path = strings.Replace(path, "{"+p.Name+"}", `" + Transport.path(`+javaAccess(p.Field)+`) + "`, 1)
+	}
+	jm.Path = strings.TrimSuffix(path, ` + ""`)
+	for _, p := range m.QueryParams {
+		e := javaAccess(p.Field)
+		if p.Style == "csv" {
+			e = "Transport.csv(" + e + ")"
+		}
+		jm.Calls = append(jm.Calls, ".query("+javaString(p.Name)+", "+e+")")
+	}
+	for _, h := range m.Headers {
+		e := javaAccess(h.Field)
+		if h.Bearer {
+			e = "Transport.bearer(" + e + ")"
+		}
+		jm.Calls = append(jm.Calls, ".header("+javaString(h.Name)+", "+e+")")
+	}
+	if m.BasicAuth != nil {
+		jm.Calls = append(jm.Calls, ".basicAuth("+javaAccess(m.BasicAuth.Username)+", "+javaAccess(m.BasicAuth.Password)+")")
+	}
+	if b := m.Body; b != nil {
+		switch {
+		case b.Field != "":
+			jm.Calls = append(jm.Calls, ".body("+javaAccess(b.Field)+")")
+		case b.Fields != nil:
+			fields := make([]string, len(b.Fields))
+			for i, f := range b.Fields {
+				fields[i] = javaString(api.JSONName(m.Payload, f)) + ", " + javaAccess(f)
+			}
+			jm.Calls = append(jm.Calls, ".body(Transport.fields("+strings.Join(fields, ", ")+"))")
+		default:
+			jm.Calls = append(jm.Calls, ".body(payload)")
+		}
+	}
+	for _, e := range m.Errors {
+		typ := "Object"
+		if e.Type != nil {
+			typ = javaTypeName(e.Type)
+		}
+		jm.Errors = append(jm.Errors, "new Transport.ErrorType("+strconv.Itoa(e.Status)+", "+javaString(e.Name)+", "+
+			javaExceptionClass(e.Name)+"::new, new TypeReference<"+typ+">() {})")
+	}
+	if m.Result == nil {
+		return jm
+	}
+	for _, h := range m.ResultHeaders {
+		jh := &javaHeader{Name: h.Name, Field: api.JSONName(m.Result, h.Field), List: h.Type.Kind == ArrayKind}
+		if h.Field == "" {
+			jm.Return = "decode"
+			jm.Decode = "Transport.headerValue(res.header(" + javaString(h.Name) + "), " + strconv.FormatBool(jh.List) + ")"
+			return jm
+		}
+		jm.ResultHeaders = append(jm.ResultHeaders, jh)
+	}
+	switch {
+	case m.ResultBody != nil && m.ResultBody.Field == "" && len(jm.ResultHeaders) == 0:
+		jm.Return = "decode"
+		jm.Decode = "Transport.json(res)"
+	case m.Result.Kind == TypeKind:
+		jm.Return = "object"
+		jm.ResultInit = "Transport.object(null, null)"
+		if b := m.ResultBody; b != nil {
+			if b.Field != "" {
+				jm.ResultInit = "Transport.object(" + javaString(api.JSONName(m.Result, b.Field)) + ", Transport.json(res))"
+			} else {
+				jm.ResultInit = "Transport.object(null, Transport.json(res))"
+			}
+		}
+	}
+	return jm
+}
+
+// javaTypeName returns the Java type of the given type reference. Primitive
+// values are boxed so that absent values are null.
+func javaTypeName(t *TypeRef) string {
+	switch t.Kind {
+	case BooleanKind:
+		return "Boolean"
+	case IntKind:
+		return "Long"
+	case FloatKind:
+		return "Double"
+	case StringKind:
+		return "String"
+	case BytesKind:
+		return "byte[]"
+	case ArrayKind:
+		return "List<" + javaTypeName(t.Elem) + ">"
+	case MapKind:
+		return "Map<" + javaTypeName(t.Key) + ", " + javaTypeName(t.Elem) + ">"
+	case TypeKind:
+		return t.Name
+	default:
+		return "Object"
+	}
+}
+
+// usesTypes returns true if t refers to one of the API types.
+func usesTypes(t *TypeRef) bool {
+	if t == nil {
+		return false
+	}
+	return t.Kind == TypeKind || usesTypes(t.Elem) || usesTypes(t.Key)
+}
+
+// javaImports returns the sorted list of the given imports and of the
+// java.util collection types used in the Java type expression typ.
+func javaImports(typ string, imports ...string) []string {
+	set := make(map[string]bool)
+	for _, i := range imports {
+		set[i] = true
+	}
+	if strings.Contains(typ, "List<") {
+		set["java.util.List"] = true
+	}
+	if strings.Contains(typ, "Map<") {
+		set["java.util.Map"] = true
+	}
+	res := make([]string, 0, len(set))
+	for i := range set {
+		res = append(res, i)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// javaIdent returns the lowerCamelCase Java identifier for the given name.
+func javaIdent(name string) string {
+	n := camelIdent(name)
+	if javaKeywords[n] {
+		n += "_"
+	}
+	return n
+}
+
+// javaExceptionClass returns the name of the exception class of the given
+// error.
+func javaExceptionClass(name string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(codegen.Goify(name, true), "Error"), "Exception") + "Exception"
+}
+
+// javaAccess returns the expression that reads the given payload field, the
+// payload itself if field is empty.
+func javaAccess(field string) string {
+	if field == "" {
+		return "payload"
+	}
+	return "payload." + javaIdent(field) + "()"
+}
+
+// javaString returns the Java string literal for s.
+func javaString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// javaDoc returns the Javadoc comment for the given description indented with
+// the given prefix, an empty string if the description is empty.
+func javaDoc(indent, desc string) string {
+	desc = strings.TrimSpace(strings.ReplaceAll(desc, "*/", "*&#47;"))
+	if desc == "" {
+		return ""
+	}
+	lines := strings.Split(desc, "\n")
+	if len(lines) == 1 {
+		return indent + "/** " + desc + " */"
+	}
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, l := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+l, " ") + "\n")
+	}
+	b.WriteString(indent + " */")
+	return b.String()
+}
+
+// input: *javaData
+const javaPomT = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Code generated by goa, DO NOT EDIT. -->
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+
+  <groupId>{{ .Package }}</groupId>
+  <artifactId>{{ .Package }}-client</artifactId>
+  <version>{{ xml .Version }}</version>
+  <name>{{ xml .Title }} client</name>
+
+  <properties>
+    <maven.compiler.release>17</maven.compiler.release>
+    <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+  </properties>
+
+  <dependencies>
+    <dependency>
+      <groupId>com.squareup.okhttp3</groupId>
+      <artifactId>okhttp</artifactId>
+      <version>4.12.0</version>
+    </dependency>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>2.17.2</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+// input: *javaData
+const javaClientT = `// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }};
+
+import java.util.Map;
+import okhttp3.OkHttpClient;
+
+/** Client groups the clients of all the {{ .Title }} services. */
+public final class Client {
+{{- range .Services }}
+    /** {{ .Field }} is the client of the {{ .Name }} service. */
+    public final {{ .Class }} {{ .Field }};
+{{- end }}
+
+    /** Creates a client that sends the requests to the default URLs with a new OkHttp client. */
+    public Client() {
+        this(null, null, null);
+    }
+
+    /**
+     * Creates a client that sends the requests to baseUrl, or the default
+     * URLs if null, with the given OkHttp client, or a new client if null.
+     * The given headers, if not null, are added to all the requests.
+     */
+    public Client(String baseUrl, OkHttpClient http, Map<String, String> headers) {
+        if (http == null) {
+            http = new OkHttpClient();
+        }
+{{- range .Services }}
+        this.{{ .Field }} = new {{ .Class }}(baseUrl, http, headers);
+{{- end }}
+    }
+}
+`
+
+// input: *javaData
+const javaTransportT = `// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }};
+
+import com.fasterxml.jackson.core.JsonProcessingException;
+import com.fasterxml.jackson.core.type.TypeReference;
+import com.fasterxml.jackson.databind.DeserializationFeature;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.node.ArrayNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+import com.fasterxml.jackson.databind.node.TextNode;
+import java.io.IOException;
+import java.net.URLEncoder;
+import java.nio.charset.StandardCharsets;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.stream.Collectors;
+import okhttp3.Credentials;
+import okhttp3.HttpUrl;
+import okhttp3.MediaType;
+import okhttp3.OkHttpClient;
+import okhttp3.Request;
+import okhttp3.RequestBody;
+import okhttp3.Response;
+
+/** Transport sends the requests of the service clients with OkHttp. */
+final class Transport {
+    /** MAPPER encodes and decodes the JSON bodies. */
+    static final ObjectMapper MAPPER = new ObjectMapper()
+            .configure(DeserializationFeature.FAIL_ON_UNKNOWN_PROPERTIES, false);
+
+    private static final MediaType JSON = MediaType.get("application/json; charset=utf-8");
+
+    private final String baseUrl;
+    private final OkHttpClient http;
+    private final Map<String, String> headers;
+
+    Transport(String baseUrl, OkHttpClient http, Map<String, String> headers) {
+        this.baseUrl = baseUrl;
+        this.http = http == null ? new OkHttpClient() : http;
+        this.headers = headers == null ? Map.of() : Map.copyOf(headers);
+    }
+
+    /** Returns a builder of a request with the given method and path. */
+    RequestBuilder request(String method, String path) {
+        return new RequestBuilder(method, path);
+    }
+
+    /** RequestBuilder builds and sends a request. */
+    final class RequestBuilder {
+        private final String method;
+        private final HttpUrl.Builder url;
+        private final Request.Builder req = new Request.Builder();
+        private RequestBody body;
+
+        RequestBuilder(String method, String path) {
+            this.method = method;
+            this.url = HttpUrl.get(baseUrl + path).newBuilder();
+            headers.forEach(req::header);
+        }
+
+        /** Adds the query string parameter unless value is null, one parameter per element for lists. */
+        RequestBuilder query(String name, Object value) {
+            if (value instanceof List<?> list) {
+                for (Object v : list) {
+                    url.addQueryParameter(name, string(v));
+                }
+            } else if (value != null) {
+                url.addQueryParameter(name, string(value));
+            }
+            return this;
+        }
+
+        /** Sets the header unless value is null, lists are joined with commas. */
+        RequestBuilder header(String name, Object value) {
+            if (value instanceof List<?> list) {
+                req.header(name, list.stream().map(Transport::string).collect(Collectors.joining(",")));
+            } else if (value != null) {
+                req.header(name, string(value));
+            }
+            return this;
+        }
+
+        /** Sets the basic auth credentials unless username is null. */
+        RequestBuilder basicAuth(String username, String password) {
+            if (username != null) {
+                req.header("Authorization", Credentials.basic(username, password == null ? "" : password));
+            }
+            return this;
+        }
+
+        /** Sets the JSON body. */
+        RequestBuilder body(Object value) throws IOException {
+            body = RequestBody.create(MAPPER.writeValueAsBytes(value), JSON);
+            return this;
+        }
+
+        /** Sends the request and returns the response that must be closed. */
+        Response send() throws IOException {
+            RequestBody b = body;
+            if (b == null && (method.equals("POST") || method.equals("PUT") || method.equals("PATCH"))) {
+                b = RequestBody.create(new byte[0], null);
+            }
+            return http.newCall(req.url(url.build()).method(method, b).build()).execute();
+        }
+    }
+
+    /** ErrorFactory creates the exception of an error. */
+    interface ErrorFactory {
+        ServiceException create(int status, JsonNode body, Object data);
+    }
+
+    /** ErrorType describes an error returned by a method. */
+    record ErrorType(int status, String name, ErrorFactory factory, TypeReference<?> type) {
+    }
+
+    /**
+     * Returns the exception of the error response: the error whose name is
+     * the value of the body "name" field or else whose status is the
+     * response status, a ServiceException if none matches.
+     */
+    static ServiceException error(Response res, ErrorType... errors) throws IOException {
+        JsonNode body = json(res);
+        String name = body != null && body.hasNonNull("name") ? body.get("name").asText() : null;
+        ErrorType match = null;
+        for (ErrorType e : errors) {
+            if (e.name().equals(name)) {
+                match = e;
+                break;
+            }
+        }
+        if (match == null) {
+            for (ErrorType e : errors) {
+                if (e.status() == res.code()) {
+                    match = e;
+                    break;
+                }
+            }
+        }
+        if (match == null) {
+            return new ServiceException(res.code(), body, null);
+        }
+        Object data;
+        try {
+            data = decode(body, match.type());
+        } catch (IOException e) {
+            data = null;
+        }
+        return match.factory().create(res.code(), body, data);
+    }
+
+    /** Returns the JSON value of the response body, null if the body is empty. */
+    static JsonNode json(Response res) throws IOException {
+        byte[] b = res.body() == null ? new byte[0] : res.body().bytes();
+        if (b.length == 0) {
+            return null;
+        }
+        try {
+            return MAPPER.readTree(b);
+        } catch (JsonProcessingException e) {
+            return TextNode.valueOf(new String(b, StandardCharsets.UTF_8));
+        }
+    }
+
+    /** Returns the value of the given type described by node, null if node is null. */
+    static <T> T decode(JsonNode node, TypeReference<T> type) throws IOException {
+        if (node == null || node.isNull()) {
+            return null;
+        }
+        return MAPPER.readerFor(type).readValue(node);
+    }
+
+    /**
+     * Returns the JSON object of a result: node if field is null and node is
+     * an object, an object whose field is node otherwise.
+     */
+    static ObjectNode object(String field, JsonNode node) {
+        if (field == null && node instanceof ObjectNode o) {
+            return o;
+        }
+        ObjectNode o = MAPPER.createObjectNode();
+        if (field != null && node != null) {
+            o.set(field, node);
+        }
+        return o;
+    }
+
+    /** Sets the field of the result object to the value of the response header if any. */
+    static void header(ObjectNode d, String field, Response res, String name, boolean list) {
+        JsonNode v = headerValue(res.header(name), list);
+        if (v != null) {
+            d.set(field, v);
+        }
+    }
+
+    /** Returns the JSON value of a header, split on commas if list is true. */
+    static JsonNode headerValue(String value, boolean list) {
+        if (value == null) {
+            return null;
+        }
+        if (!list) {
+            return TextNode.valueOf(value);
+        }
+        ArrayNode a = MAPPER.createArrayNode();
+        for (String v : value.split(",")) {
+            a.add(v.trim());
+        }
+        return a;
+    }
+
+    /** Returns the object made of the given pairs of names and values, null values are skipped. */
+    static Map<String, Object> fields(Object... pairs) {
+        Map<String, Object> m = new LinkedHashMap<>();
+        for (int i = 0; i + 1 < pairs.length; i += 2) {
+            if (pairs[i + 1] != null) {
+                m.put((String) pairs[i], pairs[i + 1]);
+            }
+        }
+        return m;
+    }
+
+    /** Returns the path segment that encodes value. */
+    static String path(Object value) {
+        return URLEncoder.encode(string(value), StandardCharsets.UTF_8).replace("+", "%20");
+    }
+
+    /** Returns the elements of the list joined with commas, null if value is null. */
+    static String csv(List<?> value) {
+        if (value == null) {
+            return null;
+        }
+        return value.stream().map(Transport::string).collect(Collectors.joining(","));
+    }
+
+    /** Returns the value of the Authorization header for the given token. */
+    static String bearer(String token) {
+        if (token == null || token.contains(" ")) {
+            return token;
+        }
+        return "Bearer " + token;
+    }
+
+    private static String string(Object value) {
+        return String.valueOf(value);
+    }
+}
+`
+
+// input: *javaData
+const javaServiceExceptionT = `// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }};
+
+import com.fasterxml.jackson.databind.JsonNode;
+import java.io.IOException;
+
+/**
+ * ServiceException is thrown when the service returns an error response.
+ * status is the response status code, body the JSON value of the response
+ * body and data the error value decoded with the error type described in the
+ * design.
+ */
+public class ServiceException extends IOException {
+    private final String name;
+    private final int status;
+    private final transient JsonNode body;
+    private final transient Object data;
+
+    public ServiceException(int status, JsonNode body, Object data) {
+        this(null, status, body, data);
+    }
+
+    protected ServiceException(String name, int status, JsonNode body, Object data) {
+        super((name == null ? "unexpected response" : name) + " (" + status + ")");
+        this.name = name;
+        this.status = status;
+        this.body = body;
+        this.data = data;
+    }
+
+    /** Returns the error name, null if the response matches no error of the design. */
+    public String name() {
+        return name;
+    }
+
+    /** Returns the response status code. */
+    public int status() {
+        return status;
+    }
+
+    /** Returns the JSON value of the response body, null if the body is empty. */
+    public JsonNode body() {
+        return body;
+    }
+
+    /** Returns the decoded error value, null if the body could not be decoded. */
+    public Object data() {
+        return data;
+    }
+}
+`
+
+// input: map[string]interface{}{"Package": string, "Service": *javaService}
+const javaServiceT = `// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }};
+{{ with .Service }}
+{{ range .Imports }}
+import {{ . }};
+{{- end }}
+
+{{ if .Description }}{{ javaDoc "" .Description }}{{ else }}/** {{ .Class }} is the client of the {{ .Name }} service. */{{ end }}
+public final class {{ .Class }} {
+    /** DEFAULT_URL is the default URL of the service. */
+    public static final String DEFAULT_URL = {{ java .BaseURL }};
+
+    private final Transport transport;
+
+    /** Creates a client that sends the requests to DEFAULT_URL with a new OkHttp client. */
+    public {{ .Class }}() {
+        this(null, null, null);
+    }
+
+    /**
+     * Creates a client that sends the requests to baseUrl, or DEFAULT_URL if
+     * null, with the given OkHttp client, or a new client if null. The given
+     * headers, if not null, are added to all the requests.
+     */
+    public {{ .Class }}(String baseUrl, OkHttpClient http, Map<String, String> headers) {
+        this.transport = new Transport(baseUrl == null ? DEFAULT_URL : baseUrl, http, headers);
+    }
+{{- range .Methods }}
+
+{{- if .Description }}
+{{ javaDoc "    " .Description }}
+{{- else }}
+{{ end }}
+    public {{ .Result }} {{ .Name }}({{ if .Payload }}{{ .Payload }} payload{{ end }}) throws IOException {
+        try (Response res = transport.request({{ java .Verb }}, {{ .Path }})
+{{- range .Calls }}
+                {{ . }}
+{{- end }}
+                .send()) {
+            if (res.code() != {{ .Status }}) {
+                throw Transport.error(res{{ range .Errors }},
+                        {{ . }}{{ end }});
+            }
+{{- if eq .Return "decode" }}
+            return Transport.decode({{ .Decode }}, new TypeReference<{{ .Result }}>() {});
+{{- else if eq .Return "object" }}
+            ObjectNode d = {{ .ResultInit }};
+{{- range .ResultHeaders }}
+            Transport.header(d, {{ java .Field }}, res, {{ java .Name }}, {{ .List }});
+{{- end }}
+            return Transport.decode(d, new TypeReference<{{ .Result }}>() {});
+{{- end }}
+        }
+    }
+{{- end }}
+}
+{{- end }}
+`
+
+// input: map[string]interface{}{"Package": string, "Error": *javaError}
+const javaExceptionT = `// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }};
+{{ with .Error }}
+{{ range .Imports }}
+import {{ . }};
+{{- end }}
+
+{{ if .Description }}{{ javaDoc "" .Description }}{{ else }}/** {{ .Class }} is thrown when the service returns a {{ .Name }} error. */{{ end }}
+public class {{ .Class }} extends ServiceException {
+    public {{ .Class }}(int status, JsonNode body, Object data) {
+        super({{ java .Name }}, status, body, data);
+    }
+
+{{- if ne .Type "Object" }}
+
+    /** Returns the decoded error value, null if the body could not be decoded. */
+    @Override
+{{- if .Unchecked }}
+    @SuppressWarnings("unchecked")
+{{- end }}
+    public {{ .Type }} data() {
+        return ({{ .Type }}) super.data();
+    }
+{{- end }}
+}
+{{- end }}
+`
+
+// input: map[string]interface{}{"Package": string, "Record": *javaRecord}
+const javaRecordT = `// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }};
+{{ with .Record }}
+{{ range .Imports }}
+import {{ . }};
+{{- end }}
+
+{{ .Doc }}
+@JsonInclude(JsonInclude.Include.NON_NULL)
+@JsonIgnoreProperties(ignoreUnknown = true)
+public record {{ .Name }}(
+{{- range $i, $c := .Components }}{{ if $i }},{{ end }}
+        @JsonProperty({{ if .Required }}value = {{ java .JSON }}, required = true{{ else }}{{ java .JSON }}{{ end }}) {{ .Type }} {{ .Name }}
+{{- end }}) {
+}
+{{- end }}
+`
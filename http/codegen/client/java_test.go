@@ -0,0 +1,59 @@
+package client_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/client"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestJavaFiles(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.ClientDSL)
+	fs, err := client.JavaFiles("", client.NewAPI(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join("src", "main", "java", "test")
+	expected := []struct {
+		Path string
+		Code string
+	}{
+		{"pom.xml", testdata.ClientJavaPomCode},
+		{filepath.Join(src, "Client.java"), testdata.ClientJavaClientCode},
+		{filepath.Join(src, "Transport.java"), testdata.ClientJavaTransportCode},
+		{filepath.Join(src, "ServiceException.java"), testdata.ClientJavaServiceExceptionCode},
+		{filepath.Join(src, "AccountsClient.java"), testdata.ClientJavaAccountsClientCode},
+		{filepath.Join(src, "UsersClient.java"), ""},
+		{filepath.Join(src, "NotFoundException.java"), testdata.ClientJavaNotFoundExceptionCode},
+		{filepath.Join(src, "model", "Account.java"), testdata.ClientJavaAccountCode},
+		{filepath.Join(src, "model", "AccountOwner.java"), ""},
+		{filepath.Join(src, "model", "CreatePayload.java"), ""},
+		{filepath.Join(src, "model", "NotFound.java"), ""},
+		{filepath.Join(src, "model", "ShowPayload.java"), ""},
+		{filepath.Join(src, "model", "ShowResult.java"), testdata.ClientJavaShowResultCode},
+		{filepath.Join(src, "model", "UsersShowPayload.java"), ""},
+	}
+	if len(fs) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected))
+	}
+	for i, e := range expected {
+		if p := filepath.Join("gen", "client", "java", e.Path); fs[i].Path != p {
+			t.Errorf("got path %q, expected %q", fs[i].Path, p)
+		}
+		var buf bytes.Buffer
+		if err := fs[i].SectionTemplates[0].Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if e.Code == "" {
+			continue
+		}
+		code := buf.String()
+		if code != e.Code {
+			t.Errorf("%s: invalid code, got:\n%s\ngot vs. expected:\n%s", e.Path, code, codegen.Diff(t, code, e.Code))
+		}
+	}
+}
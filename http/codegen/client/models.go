@@ -0,0 +1,357 @@
+package client
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// modelData is the data used to render the Swift and Kotlin models.
+	modelData struct {
+		// Package is the name of the Kotlin package.
+		Package string
+		// Title is the API title.
+		Title string
+		// Types lists the structs or data classes.
+		Types []*modelType
+		// HasAny is true if a field holds values of any type.
+		HasAny bool
+	}
+
+	// modelType describes a Swift struct or a Kotlin data class.
+	modelType struct {
+		// Name is the type name.
+		Name string
+		// Doc is the documentation comment of the type.
+		Doc string
+		// Fields lists the type fields.
+		Fields []*modelField
+		// Renamed is true if the name of a field differs from its JSON
+		// name.
+		Renamed bool
+	}
+
+	// modelField describes a field of a Swift struct or Kotlin data
+	// class.
+	modelField struct {
+		// Name is the field name.
+		Name string
+		// JSON is the name of the field in JSON.
+		JSON string
+		// Doc is the documentation comment of the field.
+		Doc string
+		// Type is the field type, optional if the field is not
+		// required.
+		Type string
+		// Required is true if the field is required.
+		Required bool
+		// Renamed is true if the field name differs from its JSON name.
+		Renamed bool
+	}
+)
+
+// swiftKeywords lists the Swift keywords that must be escaped with backticks
+// when used as identifiers.
+var swiftKeywords = map[string]bool{
+	"Any": true, "Self": true, "as": true, "associatedtype": true, "break": true,
+	"case": true, "catch": true, "class": true, "continue": true, "default": true,
+	"defer": true, "deinit": true, "do": true, "else": true, "enum": true,
+	"extension": true, "fallthrough": true, "false": true, "fileprivate": true,
+	"for": true, "func": true, "guard": true, "if": true, "import": true, "in": true,
+	"init": true, "inout": true, "internal": true, "is": true, "let": true, "nil": true,
+	"open": true, "operator": true, "private": true, "protocol": true, "public": true,
+	"repeat": true, "rethrows": true, "return": true, "self": true, "static": true,
+	"struct": true, "subscript": true, "super": true, "switch": true, "throw": true,
+	"throws": true, "true": true, "try": true, "typealias": true, "var": true,
+	"where": true, "while": true,
+}
+
+// kotlinKeywords lists the Kotlin hard keywords that must be escaped with
+// backticks when used as identifiers.
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true,
+	"else": true, "false": true, "for": true, "fun": true, "if": true, "in": true,
+	"interface": true, "is": true, "null": true, "object": true, "package": true,
+	"return": true, "super": true, "this": true, "throw": true, "true": true,
+	"try": true, "typealias": true, "typeof": true, "val": true, "var": true,
+	"when": true, "while": true,
+}
+
+// identInvalid matches the characters that are not valid in identifiers.
+var identInvalid = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// SwiftFiles returns the file gen/client/swift/Models.swift that defines a
+// Codable struct for each API type. The file only contains the models, the
+// requests are left to the networking layer of the application.
+func SwiftFiles(_ string, api *API) ([]*codegen.File, error) {
+	data := modelsData(api, swiftTypeName, func(n string) string {
+		if swiftKeywords[n] {
+			return "`" + n + "`"
+		}
+		return n
+	})
+	funcs := map[string]interface{}{"swift": javaString, "swiftDoc": swiftDoc}
+	return []*codegen.File{{
+		Path: filepath.Join(codegen.Gendir, "client", "swift", "Models.swift"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			{Name: "client-swift-models", Source: swiftModelsT, Data: data, FuncMap: funcs},
+		},
+	}}, nil
+}
+
+// KotlinFiles returns the file gen/client/kotlin/Models.kt that defines a
+// data class serializable with kotlinx.serialization for each API type. The
+// file only contains the models, the requests are left to the networking layer
+// of the application.
+func KotlinFiles(_ string, api *API) ([]*codegen.File, error) {
+	data := modelsData(api, kotlinTypeName, func(n string) string {
+		if kotlinKeywords[n] {
+			return "`" + n + "`"
+		}
+		return n
+	})
+	funcs := map[string]interface{}{"kotlin": javaString, "kotlinDoc": javaDoc}
+	return []*codegen.File{{
+		Path: filepath.Join(codegen.Gendir, "client", "kotlin", "Models.kt"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			{Name: "client-kotlin-models", Source: kotlinModelsT, Data: data, FuncMap: funcs},
+		},
+	}}, nil
+}
+
+// modelsData returns the data used to render the models of the API types.
+// typeName returns the type of a field and ident escapes the field names that
+// are keywords.
+func modelsData(api *API, typeName func(*TypeRef) string, ident func(string) string) *modelData {
+	title := api.Title
+	if title == "" {
+		title = api.Name
+	}
+	data := &modelData{
+		Package: strings.ToLower(javaIdent(api.Name)) + ".model",
+		Title:   title,
+	}
+	for _, t := range api.Types {
+		mt := &modelType{Name: t.Name, Doc: t.Description}
+		if mt.Doc == "" {
+			mt.Doc = t.Name + " is the " + t.Name + " type."
+		}
+		for _, f := range t.Fields {
+			mf := &modelField{
+				Name:     ident(camelIdent(f.Name)),
+				JSON:     f.JSON,
+				Doc:      f.Description,
+				Type:     typeName(f.Type),
+				Required: f.Required,
+			}
+			if !f.Required {
+				mf.Type += "?"
+			}
+			if strings.Trim(mf.Name, "`") != f.JSON {
+				mf.Renamed = true
+				mt.Renamed = true
+			}
+			if usesAny(f.Type) {
+				data.HasAny = true
+			}
+			mt.Fields = append(mt.Fields, mf)
+		}
+		data.Types = append(data.Types, mt)
+	}
+	return data
+}
+
+// swiftTypeName returns the Swift type of the given type reference. Map keys
+// are always strings as JSON object keys are.
+func swiftTypeName(t *TypeRef) string {
+	switch t.Kind {
+	case BooleanKind:
+		return "Bool"
+	case IntKind:
+		return "Int64"
+	case FloatKind:
+		return "Double"
+	case StringKind:
+		return "String"
+	case BytesKind:
+		return "Data"
+	case ArrayKind:
+		return "[" + swiftTypeName(t.Elem) + "]"
+	case MapKind:
+		return "[String: " + swiftTypeName(t.Elem) + "]"
+	case TypeKind:
+		return t.Name
+	default:
+		return "JSONValue"
+	}
+}
+
+// kotlinTypeName returns the Kotlin type of the given type reference. Binary
+// values are base64 encoded strings as kotlinx.serialization encodes byte
+// arrays as arrays of numbers.
+func kotlinTypeName(t *TypeRef) string {
+	switch t.Kind {
+	case BooleanKind:
+		return "Boolean"
+	case IntKind:
+		return "Long"
+	case FloatKind:
+		return "Double"
+	case StringKind, BytesKind:
+		return "String"
+	case ArrayKind:
+		return "List<" + kotlinTypeName(t.Elem) + ">"
+	case MapKind:
+		return "Map<" + kotlinTypeName(t.Key) + ", " + kotlinTypeName(t.Elem) + ">"
+	case TypeKind:
+		return t.Name
+	default:
+		return "JsonElement"
+	}
+}
+
+// usesAny returns true if t holds values of any type.
+func usesAny(t *TypeRef) bool {
+	if t == nil {
+		return false
+	}
+	return t.Kind == AnyKind || usesAny(t.Elem) || usesAny(t.Key)
+}
+
+// camelIdent returns the lowerCamelCase identifier for the given name.
+func camelIdent(name string) string {
+	n := identInvalid.ReplaceAllString(codegen.CamelCase(name, false, false), "_")
+	if n == "" || n[0] >= '0' && n[0] <= '9' {
+		n = "_" + n
+	}
+	return n
+}
+
+// swiftDoc returns the documentation comment for the given description
+// indented with the given prefix, an empty string if the description is empty.
+func swiftDoc(indent, desc string) string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return ""
+	}
+	lines := strings.Split(desc, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(indent+"/// "+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// input: *modelData
+const swiftModelsT = `// {{ .Title }} types.
+//
+// Code generated by goa, DO NOT EDIT.
+
+import Foundation
+{{- if .HasAny }}
+
+/// JSONValue is a JSON value of any type.
+public enum JSONValue: Codable, Equatable {
+    case null
+    case bool(Bool)
+    case number(Double)
+    case string(String)
+    case array([JSONValue])
+    case object([String: JSONValue])
+
+    public init(from decoder: Decoder) throws {
+        let c = try decoder.singleValueContainer()
+        if c.decodeNil() {
+            self = .null
+        } else if let v = try? c.decode(Bool.self) {
+            self = .bool(v)
+        } else if let v = try? c.decode(Double.self) {
+            self = .number(v)
+        } else if let v = try? c.decode(String.self) {
+            self = .string(v)
+        } else if let v = try? c.decode([JSONValue].self) {
+            self = .array(v)
+        } else {
+            self = .object(try c.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var c = encoder.singleValueContainer()
+        switch self {
+        case .null:
+            try c.encodeNil()
+        case .bool(let v):
+            try c.encode(v)
+        case .number(let v):
+            try c.encode(v)
+        case .string(let v):
+            try c.encode(v)
+        case .array(let v):
+            try c.encode(v)
+        case .object(let v):
+            try c.encode(v)
+        }
+    }
+}
+{{- end }}
+{{- range .Types }}
+
+{{ swiftDoc "" .Doc }}
+public struct {{ .Name }}: Codable, Equatable {
+{{- range .Fields }}
+{{- if .Doc }}
+{{ swiftDoc "    " .Doc }}
+{{- end }}
+    public var {{ .Name }}: {{ .Type }}
+{{- end }}
+{{- if .Renamed }}
+
+    enum CodingKeys: String, CodingKey {
+{{- range .Fields }}
+        case {{ .Name }}{{ if .Renamed }} = {{ swift .JSON }}{{ end }}
+{{- end }}
+    }
+{{- end }}
+
+    public init({{ range $i, $f := .Fields }}{{ if $i }}, {{ end }}{{ .Name }}: {{ .Type }}{{ if not .Required }} = nil{{ end }}{{ end }}) {
+{{- range .Fields }}
+        self.{{ .Name }} = {{ .Name }}
+{{- end }}
+    }
+}
+{{- end }}
+`
+
+// input: *modelData
+const kotlinModelsT = `// {{ .Title }} types.
+//
+// Code generated by goa, DO NOT EDIT.
+
+package {{ .Package }}
+
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+{{- if .HasAny }}
+import kotlinx.serialization.json.JsonElement
+{{- end }}
+{{- range .Types }}
+
+{{ kotlinDoc "" .Doc }}
+@Serializable
+{{- if .Fields }}
+data class {{ .Name }}(
+{{- range .Fields }}
+{{- if .Doc }}
+{{ kotlinDoc "    " .Doc }}
+{{- end }}
+    {{ if .Renamed }}@SerialName({{ kotlin .JSON }}) {{ end }}val {{ .Name }}: {{ .Type }}{{ if not .Required }} = null{{ end }},
+{{- end }}
+)
+{{- else }}
+class {{ .Name }}
+{{- end }}
+{{- end }}
+`
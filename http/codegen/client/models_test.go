@@ -0,0 +1,47 @@
+package client_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/client"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestModelFiles(t *testing.T) {
+	cases := []struct {
+		Name string
+		Gen  client.Generator
+		Path string
+		Code string
+	}{
+		{"swift", client.SwiftFiles, filepath.Join("gen", "client", "swift", "Models.swift"), testdata.ClientSwiftModelsCode},
+		{"kotlin", client.KotlinFiles, filepath.Join("gen", "client", "kotlin", "Models.kt"), testdata.ClientKotlinModelsCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := httpgen.RunHTTPDSL(t, testdata.ClientDSL)
+			fs, err := c.Gen("", client.NewAPI(root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			if fs[0].Path != c.Path {
+				t.Errorf("got path %q, expected %q", fs[0].Path, c.Path)
+			}
+			var buf bytes.Buffer
+			if err := fs[0].SectionTemplates[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			code := buf.String()
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
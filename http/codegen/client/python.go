@@ -0,0 +1,771 @@
+package client
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// pyData is the data used to render the Python package.
+	pyData struct {
+		// Package is the name of the Python package.
+		Package string
+		// Title is the API title.
+		Title string
+		// Description is the API description.
+		Description string
+		// Version is the version of the Python package.
+		Version string
+		// Types lists the dataclasses.
+		Types []*pyType
+		// Errors lists the exception classes.
+		Errors []*pyError
+		// Services lists the service clients.
+		Services []*pyService
+	}
+
+	// pyType describes a dataclass.
+	pyType struct {
+		// Name is the class name.
+		Name string
+		// Description is the type description.
+		Description string
+		// Fields lists the dataclass fields, required fields first.
+		Fields []*pyField
+	}
+
+	// pyField describes a dataclass field.
+	pyField struct {
+		// Name is the Python attribute name.
+		Name string
+		// JSON is the name of the field in JSON.
+		JSON string
+		// Description is the field description.
+		Description string
+		// Hint is the field type hint.
+		Hint string
+		// Spec is the decoding spec of the field value.
+		Spec string
+		// Required is true if the field is required.
+		Required bool
+	}
+
+	// pyError describes an exception class.
+	pyError struct {
+		// Class is the class name.
+		Class string
+		// Name is the error name.
+		Name string
+		// Description is the error description.
+		Description string
+	}
+
+	// pyService describes the client class of a service.
+	pyService struct {
+		// Class is the class name.
+		Class string
+		// Attr is the name of the attribute of the root client that
+		// holds the service client.
+		Attr string
+		// Description is the service description.
+		Description string
+		// BaseURL is the default URL of the service.
+		BaseURL string
+		// Methods lists the client methods.
+		Methods []*pyMethod
+	}
+
+	// pyMethod describes a client method.
+	pyMethod struct {
+		// Name is the method name.
+		Name string
+		// Description is the method description.
+		Description string
+		// Verb is the HTTP method of the request.
+		Verb string
+		// Payload is the payload type hint, empty if there is no
+		// payload.
+		Payload string
+		// Result is the result type hint.
+		Result string
+		// Path is the expression that computes the request path.
+		Path string
+		// Params lists the query string parameter names and value
+		// expressions.
+		Params [][2]string
+		// Headers lists the request header names and value
+		// expressions.
+		Headers [][2]string
+		// Auth is the basic auth credentials expression if any.
+		Auth string
+		// Body is the expression that computes the request body if
+		// any.
+		Body string
+		// Status is the status code of the successful responses.
+		Status int
+		// Errors lists the error tuples given to _fail.
+		Errors []string
+		// Return is the kind of result: "none", "body" (the decoded
+		// body), "header" (a response header) or "object" (a result
+		// object built from the body and headers).
+		Return string
+		// ResultSpec is the decoding spec of the result.
+		ResultSpec string
+		// ResultType is the result class if Return is "object".
+		ResultType string
+		// ResultInit is the expression that initializes the JSON
+		// object of the result if Return is "object".
+		ResultInit string
+		// ResultHeaders lists the response headers mapped to result
+		// fields.
+		ResultHeaders []*pyHeader
+	}
+
+	// pyHeader describes a response header.
+	pyHeader struct {
+		// Name is the header name.
+		Name string
+		// Field is the name of the result field in JSON.
+		Field string
+		// Convert is the function that converts the header value.
+		Convert string
+	}
+)
+
+// pyKeywords lists the Python reserved words.
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true,
+	"not": true, "or": true, "pass": true, "raise": true, "return": true,
+	"try": true, "while": true, "with": true, "yield": true,
+}
+
+// pyInvalid matches the characters that are not valid in Python identifiers.
+var pyInvalid = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// PythonFiles returns the files of a Python package that implements the API
+// client in gen/client/python: dataclasses for the API types in models.py,
+// an exception class per error in errors.py and a client class per service
+// in client.py. The clients send the requests with requests by default, any
+// session object compatible with requests.Session (e.g. httpx.Client) may be
+// given instead.
+func PythonFiles(_ string, api *API) ([]*codegen.File, error) {
+	title := api.Title
+	if title == "" {
+		title = api.Name
+	}
+	version := api.Version
+	if version == "" {
+		version = "0.0.0"
+	}
+	data := &pyData{
+		Package:     pyName(api.Name),
+		Title:       title,
+		Description: api.Description,
+		Version:     version,
+	}
+	for _, t := range api.Types {
+		data.Types = append(data.Types, pyTypeData(t))
+	}
+	seen := make(map[string]bool)
+	for _, svc := range api.Services {
+		s := &pyService{
+			Class:       codegen.Goify(svc.Name, true) + "Client",
+			Attr:        pyName(svc.Name),
+			Description: svc.Description,
+			BaseURL:     svc.BaseURL,
+		}
+		for _, m := range svc.Methods {
+			for _, e := range m.Errors {
+				if !seen[e.Name] {
+					seen[e.Name] = true
+					data.Errors = append(data.Errors, &pyError{Class: pyErrorClass(e.Name), Name: e.Name, Description: e.Description})
+				}
+			}
+			s.Methods = append(s.Methods, pyMethodData(api, m))
+		}
+		data.Services = append(data.Services, s)
+	}
+	funcs := map[string]interface{}{"py": pyString, "pyDoc": pyDoc}
+	dir := filepath.Join(codegen.Gendir, "client", "python")
+	pkg := filepath.Join(dir, data.Package)
+	file := func(path, name, source string) *codegen.File {
+		return &codegen.File{
+			Path:             path,
+			SectionTemplates: []*codegen.SectionTemplate{{Name: name, Source: source, Data: data, FuncMap: funcs}},
+		}
+	}
+	return []*codegen.File{
+		file(filepath.Join(dir, "pyproject.toml"), "client-python-project", pyProjectT),
+		file(filepath.Join(pkg, "__init__.py"), "client-python-init", pyInitT),
+		file(filepath.Join(pkg, "models.py"), "client-python-models", pyModelsT),
+		file(filepath.Join(pkg, "errors.py"), "client-python-errors", pyErrorsT),
+		file(filepath.Join(pkg, "client.py"), "client-python-client", pyClientT),
+	}, nil
+}
+
+// pyTypeData returns the data used to render the dataclass of t.
+func pyTypeData(t *Type) *pyType {
+	pt := &pyType{Name: t.Name, Description: t.Description}
+	var optional []*pyField
+	for _, f := range t.Fields {
+		hint := pyHint(f.Type, "")
+		if !f.Required {
+			hint = "Optional[" + hint + "]"
+		}
+		pf := &pyField{
+			Name:        pyName(f.Name),
+			JSON:        f.JSON,
+			Description: f.Description,
+			Hint:        hint,
+			Spec:        pySpec(f.Type, ""),
+			Required:    f.Required,
+		}
+		if f.Required {
+			pt.Fields = append(pt.Fields, pf)
+		} else {
+			optional = append(optional, pf)
+		}
+	}
+	pt.Fields = append(pt.Fields, optional...)
+	return pt
+}
+
+// pyMethodData returns the data used to render the client method of m.
+func pyMethodData(api *API, m *Method) *pyMethod {
+	pm := &pyMethod{
+		Name:        pyName(m.Name),
+		Description: m.Description,
+		Verb:        m.Verb,
+		Result:      "None",
+		Status:      m.Status,
+		Return:      "none",
+	}
+	if m.Payload != nil {
+		pm.Payload = pyHint(m.Payload, "models.")
+	}
+	if m.Result != nil {
+		pm.Result = pyHint(m.Result, "models.")
+		pm.ResultSpec = pySpec(m.Result, "models.")
+	}
+	path := pyString(m.Path)
+	for _, p := range m.PathParams {
+		path = strings.Replace(path, "{"+p.Name+"}", `" + _path(`+pyAccess(p.Field)+`) + "`, 1)
+	}
+	pm.Path = strings.TrimSuffix(path, ` + ""`)
+	for _, p := range m.QueryParams {
+		e := pyAccess(p.Field)
+		if p.Style == "csv" {
+			e = "_csv(" + e + ")"
+		}
+		pm.Params = append(pm.Params, [2]string{pyString(p.Name), e})
+	}
+	for _, h := range m.Headers {
+		e := pyAccess(h.Field)
+		if h.Bearer {
+			e = "_bearer(" + e + ")"
+		}
+		pm.Headers = append(pm.Headers, [2]string{pyString(h.Name), e})
+	}
+	if m.BasicAuth != nil {
+		pm.Auth = "(" + pyAccess(m.BasicAuth.Username) + ", " + pyAccess(m.BasicAuth.Password) + ")"
+	}
+	if b := m.Body; b != nil {
+		switch {
+		case b.Field != "":
+			pm.Body = "_to_json(" + pyAccess(b.Field) + ")"
+		case b.Fields != nil:
+			fields := make([]string, len(b.Fields))
+			for i, f := range b.Fields {
+				fields[i] = pyString(api.JSONName(m.Payload, f)) + ": _to_json(" + pyAccess(f) + ")"
+			}
+			pm.Body = "_compact({" + strings.Join(fields, ", ") + "})"
+		default:
+			pm.Body = "_to_json(payload)"
+		}
+	}
+	for _, e := range m.Errors {
+		pm.Errors = append(pm.Errors, "("+strconv.Itoa(e.Status)+", "+pyString(e.Name)+", errors."+pyErrorClass(e.Name)+", "+pySpec(e.Type, "models.")+")")
+	}
+	if m.Result == nil {
+		return pm
+	}
+	for _, h := range m.ResultHeaders {
+		conv := "str"
+		switch h.Type.Kind {
+		case IntKind:
+			conv = "int"
+		case FloatKind:
+			conv = "float"
+		case BooleanKind:
+			conv = "_bool"
+		case ArrayKind:
+			conv = "_list"
+		}
+		ph := &pyHeader{Name: h.Name, Field: api.JSONName(m.Result, h.Field), Convert: conv}
+		if h.Field == "" {
+			pm.Return = "header"
+			pm.ResultHeaders = []*pyHeader{ph}
+			return pm
+		}
+		pm.ResultHeaders = append(pm.ResultHeaders, ph)
+	}
+	switch {
+	case m.ResultBody != nil && m.ResultBody.Field == "" && len(pm.ResultHeaders) == 0:
+		pm.Return = "body"
+	case m.Result.Kind == TypeKind:
+		pm.Return = "object"
+		pm.ResultType = "models." + m.Result.Name
+		pm.ResultInit = "{}"
+		if b := m.ResultBody; b != nil {
+			if b.Field != "" {
+				pm.ResultInit = "{" + pyString(api.JSONName(m.Result, b.Field)) + ": _json(res)}"
+			} else {
+				pm.ResultInit = "dict(_json(res) or {})"
+			}
+		}
+	}
+	return pm
+}
+
+// pyHint returns the Python type hint of the given type reference, prefix is
+// prepended to the names of the API types.
+func pyHint(t *TypeRef, prefix string) string {
+	switch t.Kind {
+	case BooleanKind:
+		return "bool"
+	case IntKind:
+		return "int"
+	case FloatKind:
+		return "float"
+	case StringKind:
+		if len(t.Enum) > 0 {
+			vals := make([]string, len(t.Enum))
+			for i, v := range t.Enum {
+				b, _ := json.Marshal(v)
+				vals[i] = string(b)
+			}
+			return "Literal[" + strings.Join(vals, ", ") + "]"
+		}
+		return "str"
+	case BytesKind:
+		return "str"
+	case ArrayKind:
+		return "List[" + pyHint(t.Elem, prefix) + "]"
+	case MapKind:
+		return "Dict[" + pyHint(t.Key, prefix) + ", " + pyHint(t.Elem, prefix) + "]"
+	case TypeKind:
+		return prefix + t.Name
+	default:
+		return "Any"
+	}
+}
+
+// pySpec returns the spec used by _decode to decode JSON values of the given
+// type: None for values that need no decoding, the dataclass for objects and
+// ("list", spec) or ("map", spec) for arrays and maps.
+func pySpec(t *TypeRef, prefix string) string {
+	if t == nil {
+		return "None"
+	}
+	switch t.Kind {
+	case TypeKind:
+		return prefix + t.Name
+	case ArrayKind, MapKind:
+		if elem := pySpec(t.Elem, prefix); elem != "None" {
+			kind := "list"
+			if t.Kind == MapKind {
+				kind = "map"
+			}
+			return "(" + pyString(kind) + ", " + elem + ")"
+		}
+	}
+	return "None"
+}
+
+// pyName returns the snake_case Python identifier for the given name.
+func pyName(name string) string {
+	n := pyInvalid.ReplaceAllString(codegen.SnakeCase(name), "_")
+	if n == "" || n[0] >= '0' && n[0] <= '9' {
+		n = "_" + n
+	}
+	if pyKeywords[n] {
+		n += "_"
+	}
+	return n
+}
+
+// pyErrorClass returns the name of the exception class of the given error.
+func pyErrorClass(name string) string {
+	return strings.TrimSuffix(codegen.Goify(name, true), "Error") + "Error"
+}
+
+// pyAccess returns the expression that reads the given payload field, the
+// payload itself if field is empty.
+func pyAccess(field string) string {
+	if field == "" {
+		return "payload"
+	}
+	return "payload." + pyName(field)
+}
+
+// pyString returns the Python string literal for s.
+func pyString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// pyDoc returns the docstring for the given description indented with the
+// given prefix, an empty string if the description is empty.
+func pyDoc(indent, desc string) string {
+	desc = strings.TrimSpace(strings.ReplaceAll(desc, `"""`, `\"\"\"`))
+	if desc == "" {
+		return ""
+	}
+	lines := strings.Split(desc, "\n")
+	if len(lines) == 1 {
+		return indent + `"""` + desc + `"""`
+	}
+	var b strings.Builder
+	b.WriteString(indent + `"""` + lines[0] + "\n")
+	for _, l := range lines[1:] {
+		b.WriteString(strings.TrimRight(indent+l, " ") + "\n")
+	}
+	b.WriteString(indent + `"""`)
+	return b.String()
+}
+
+// input: *pyData
+const pyProjectT = `[build-system]
+requires = ["setuptools>=61"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = {{ py (printf "%s-client" .Package) }}
+version = {{ py .Version }}
+description = {{ py (printf "%s client" .Title) }}
+requires-python = ">=3.8"
+dependencies = ["requests>=2"]
+
+[tool.setuptools]
+packages = [{{ py .Package }}]
+`
+
+// input: *pyData
+const pyInitT = `"""{{ .Title }} client.
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from .client import Client
+{{- range .Services }}, {{ .Class }}{{ end }}
+from .errors import ServiceError
+{{- range .Errors }}, {{ .Class }}{{ end }}
+{{- if .Types }}
+from .models import (
+{{- range .Types }}
+    {{ .Name }},
+{{- end }}
+)
+{{- end }}
+
+__all__ = [
+    "Client",
+{{- range .Services }}
+    {{ py .Class }},
+{{- end }}
+    "ServiceError",
+{{- range .Errors }}
+    {{ py .Class }},
+{{- end }}
+{{- range .Types }}
+    {{ py .Name }},
+{{- end }}
+]
+`
+
+// input: *pyData
+const pyModelsT = `"""{{ .Title }} types.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+import dataclasses
+from dataclasses import dataclass
+from typing import Any, Dict, List, Literal, Optional
+
+
+def _to_json(value: Any) -> Any:
+    """Return the JSON representation of value."""
+    if dataclasses.is_dataclass(value):
+        return value.to_dict()
+    if isinstance(value, list):
+        return [_to_json(v) for v in value]
+    if isinstance(value, dict):
+        return {k: _to_json(v) for k, v in value.items()}
+    return value
+
+
+def _decode(value: Any, spec: Any) -> Any:
+    """Return the value described by the JSON value and the decoding spec."""
+    if value is None or spec is None:
+        return value
+    if isinstance(spec, tuple):
+        kind, elem = spec
+        if kind == "list":
+            return [_decode(v, elem) for v in value]
+        return {k: _decode(v, elem) for k, v in value.items()}
+    return spec.from_dict(value)
+{{- range .Types }}
+
+
+@dataclass
+class {{ .Name }}:
+{{- if .Description }}
+{{ pyDoc "    " .Description }}
+{{- end }}
+{{- range .Fields }}
+    {{ .Name }}: {{ .Hint }}{{ if not .Required }} = None{{ end }}
+{{- if .Description }}
+{{ pyDoc "    " .Description }}
+{{- end }}
+{{- end }}
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+{{- range .Fields }}
+        if self.{{ .Name }} is not None:
+            d[{{ py .JSON }}] = _to_json(self.{{ .Name }})
+{{- end }}
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> {{ .Name }}:
+        """Return the value described by the JSON object d."""
+        return cls(
+{{- range .Fields }}
+            {{ .Name }}={{ if eq .Spec "None" }}d.get({{ py .JSON }}){{ else }}_decode(d.get({{ py .JSON }}), {{ .Spec }}){{ end }},
+{{- end }}
+        )
+{{- end }}
+`
+
+// input: *pyData
+const pyErrorsT = `"""{{ .Title }} errors.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+
+class ServiceError(Exception):
+    """Raised when the service returns an error response.
+
+    status is the response status code, body the decoded response body and
+    data the error value decoded with the error type described in the design.
+    """
+
+    name = ""
+
+    def __init__(self, status: int, body: Any = None, data: Any = None) -> None:
+        super().__init__(f"{self.name or 'unexpected response'} ({status})")
+        self.status = status
+        self.body = body
+        self.data = data
+{{- range .Errors }}
+
+
+class {{ .Class }}(ServiceError):
+{{- if .Description }}
+{{ pyDoc "    " .Description }}
+{{- end }}
+    name = {{ py .Name }}
+{{- end }}
+`
+
+// input: *pyData
+const pyClientT = `"""{{ .Title }} client.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any, Dict, List, NoReturn, Optional, Tuple
+from urllib.parse import quote
+
+from . import errors, models
+from .models import _decode, _to_json
+
+
+class _Transport:
+    """Sends the requests with a requests (or compatible) session."""
+
+    def __init__(self, base_url: str, session: Any, headers: Optional[Dict[str, str]], timeout: Optional[float]) -> None:
+        if session is None:
+            import requests
+
+            session = requests.Session()
+        self.base_url = base_url
+        self.session = session
+        self.headers = dict(headers or {})
+        self.timeout = timeout
+
+    def request(self, method: str, path: str, params: List[Tuple[str, str]], headers: Dict[str, str], body: Any = None, auth: Any = None) -> Any:
+        kwargs: Dict[str, Any] = {"params": params, "headers": {**self.headers, **headers}}
+        if body is not None:
+            kwargs["json"] = body
+        if auth is not None:
+            kwargs["auth"] = auth
+        if self.timeout is not None:
+            kwargs["timeout"] = self.timeout
+        return self.session.request(method, self.base_url + path, **kwargs)
+
+
+def _str(value: Any) -> str:
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    return str(value)
+
+
+def _path(value: Any) -> str:
+    return quote(_str(value), safe="")
+
+
+def _params(pairs: List[Tuple[str, Any]]) -> List[Tuple[str, str]]:
+    params = []
+    for name, value in pairs:
+        if value is None:
+            continue
+        for v in value if isinstance(value, list) else [value]:
+            params.append((name, _str(v)))
+    return params
+
+
+def _csv(value: Any) -> Any:
+    return None if value is None else ",".join(_str(v) for v in value)
+
+
+def _headers(pairs: List[Tuple[str, Any]]) -> Dict[str, str]:
+    headers = {}
+    for name, value in pairs:
+        if value is None:
+            continue
+        headers[name] = ",".join(_str(v) for v in value) if isinstance(value, list) else _str(value)
+    return headers
+
+
+def _bearer(token: Optional[str]) -> Optional[str]:
+    if token is None or " " in token:
+        return token
+    return "Bearer " + token
+
+
+def _compact(d: Dict[str, Any]) -> Dict[str, Any]:
+    return {k: v for k, v in d.items() if v is not None}
+
+
+def _bool(value: str) -> bool:
+    return value == "true"
+
+
+def _list(value: str) -> List[str]:
+    return [v.strip() for v in value.split(",")]
+
+
+def _json(res: Any) -> Any:
+    if not res.content:
+        return None
+    try:
+        return res.json()
+    except ValueError:
+        return res.text
+
+
+def _fail(res: Any, errs: List[Tuple[int, str, Any, Any]]) -> NoReturn:
+    body = _json(res)
+    name = body.get("name") if isinstance(body, dict) else None
+    match = next((e for e in errs if e[1] == name), None) or next((e for e in errs if e[0] == res.status_code), None)
+    if match is None:
+        raise errors.ServiceError(res.status_code, body)
+    _, _, cls, spec = match
+    data = _decode(body, spec) if isinstance(body, dict) or spec is None else body
+    raise cls(res.status_code, body, data)
+{{- range .Services }}
+
+
+class {{ .Class }}:
+{{- if .Description }}
+{{ pyDoc "    " .Description }}
+{{- end }}
+
+    def __init__(self, base_url: Optional[str] = None, session: Any = None, headers: Optional[Dict[str, str]] = None, timeout: Optional[float] = None) -> None:
+        self._transport = _Transport(base_url or {{ py .BaseURL }}, session, headers, timeout)
+{{- range .Methods }}
+
+    def {{ .Name }}(self{{ if .Payload }}, payload: {{ .Payload }}{{ end }}) -> {{ .Result }}:
+{{- if .Description }}
+{{ pyDoc "        " .Description }}
+{{- end }}
+        res = self._transport.request(
+            {{ py .Verb }},
+            {{ .Path }},
+            _params([{{ range $i, $p := .Params }}{{ if $i }}, {{ end }}({{ index $p 0 }}, {{ index $p 1 }}){{ end }}]),
+            _headers([{{ range $i, $h := .Headers }}{{ if $i }}, {{ end }}({{ index $h 0 }}, {{ index $h 1 }}){{ end }}]),
+{{- if .Body }}
+            body={{ .Body }},
+{{- end }}
+{{- if .Auth }}
+            auth={{ .Auth }},
+{{- end }}
+        )
+        if res.status_code != {{ .Status }}:
+            _fail(res, [{{ range $i, $e := .Errors }}{{ if $i }}, {{ end }}{{ $e }}{{ end }}])
+{{- if eq .Return "body" }}
+        return _decode(_json(res), {{ .ResultSpec }})
+{{- else if eq .Return "header" }}
+{{- range .ResultHeaders }}
+        return {{ .Convert }}(res.headers.get({{ py .Name }}, ""))
+{{- end }}
+{{- else if eq .Return "object" }}
+        d = {{ .ResultInit }}
+{{- range .ResultHeaders }}
+        if {{ py .Name }} in res.headers:
+            d[{{ py .Field }}] = {{ .Convert }}(res.headers[{{ py .Name }}])
+{{- end }}
+        return {{ .ResultType }}.from_dict(d)
+{{- end }}
+{{- end }}
+{{- end }}
+
+
+class Client:
+    """Client groups the clients of all the services."""
+
+    def __init__(self, base_url: Optional[str] = None, session: Any = None, headers: Optional[Dict[str, str]] = None, timeout: Optional[float] = None) -> None:
+        if session is None:
+            import requests
+
+            session = requests.Session()
+{{- range .Services }}
+        self.{{ .Attr }} = {{ .Class }}(base_url, session, headers, timeout)
+{{- end }}
+`
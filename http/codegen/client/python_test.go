@@ -0,0 +1,46 @@
+package client_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/client"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestPythonFiles(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.ClientDSL)
+	fs, err := client.PythonFiles("", client.NewAPI(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []struct {
+		Path string
+		Code string
+	}{
+		{"pyproject.toml", testdata.ClientPythonProjectCode},
+		{filepath.Join("test", "__init__.py"), testdata.ClientPythonInitCode},
+		{filepath.Join("test", "models.py"), testdata.ClientPythonModelsCode},
+		{filepath.Join("test", "errors.py"), testdata.ClientPythonErrorsCode},
+		{filepath.Join("test", "client.py"), testdata.ClientPythonClientCode},
+	}
+	if len(fs) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected))
+	}
+	for i, e := range expected {
+		if p := filepath.Join("gen", "client", "python", e.Path); fs[i].Path != p {
+			t.Errorf("got path %q, expected %q", fs[i].Path, p)
+		}
+		var buf bytes.Buffer
+		if err := fs[i].SectionTemplates[0].Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		code := buf.String()
+		if code != e.Code {
+			t.Errorf("%s: invalid code, got:\n%s\ngot vs. expected:\n%s", e.Path, code, codegen.Diff(t, code, e.Code))
+		}
+	}
+}
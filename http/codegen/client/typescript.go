@@ -0,0 +1,465 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+)
+
+type (
+	// tsData is the data used to render the TypeScript client.
+	tsData struct {
+		// Title is the API title.
+		Title string
+		// Description is the API description.
+		Description string
+		// Types lists the API types.
+		Types []*Type
+		// Services lists the service clients.
+		Services []*tsService
+	}
+
+	// tsService describes the client of a service.
+	tsService struct {
+		// Name is the name of the client class.
+		Name string
+		// Prop is the name of the property of the root client that
+		// holds the service client.
+		Prop string
+		// Description is the service description.
+		Description string
+		// BaseURL is the default URL of the service.
+		BaseURL string
+		// Methods lists the client methods.
+		Methods []*tsMethod
+	}
+
+	// tsMethod describes a client method.
+	tsMethod struct {
+		// Name is the name of the client method.
+		Name string
+		// Description is the method description.
+		Description string
+		// Verb is the HTTP method of the request.
+		Verb string
+		// Payload is the TypeScript type of the payload, empty if
+		// there is no payload.
+		Payload string
+		// Result is the TypeScript type of the result.
+		Result string
+		// Path is the expression that computes the request path.
+		Path string
+		// Query lists the query string parameter names and value
+		// expressions.
+		Query [][2]string
+		// Headers lists the request headers.
+		Headers []*tsHeader
+		// Body is the expression that computes the request body, empty
+		// if there is no body.
+		Body string
+		// Status is the status code of the successful responses.
+		Status int
+		// Errors lists the status codes and names of the errors.
+		Errors []*Error
+		// ResultBody is the description of the result body if any, its
+		// Field is the JSON name of the result field.
+		ResultBody *Body
+		// ResultHeaders lists the response headers mapped to result
+		// fields.
+		ResultHeaders []*tsHeader
+		// WholeResultHeader is the response header that holds the
+		// result if the result is not an object.
+		WholeResultHeader *tsHeader
+	}
+
+	// tsHeader describes a request or response header.
+	tsHeader struct {
+		// Name is the header name.
+		Name string
+		// Expr is the value expression of request headers.
+		Expr string
+		// Field is the JSON name of the result field of response
+		// headers.
+		Field string
+		// Convert is the function that converts the response header
+		// string value.
+		Convert string
+	}
+)
+
+// tsIdentifier matches the valid TypeScript identifiers.
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScriptFiles returns the file gen/client/ts/client.ts which defines the
+// TypeScript interfaces of the API types and a client class per service whose
+// methods send the requests with fetch. The client classes may be created
+// individually or via the Client class that groups them.
+func TypeScriptFiles(_ string, api *API) ([]*codegen.File, error) {
+	title := api.Title
+	if title == "" {
+		title = api.Name
+	}
+	data := &tsData{Title: title, Description: api.Description, Types: api.Types}
+	for _, svc := range api.Services {
+		s := &tsService{
+			Name:        codegen.Goify(svc.Name, true) + "Client",
+			Prop:        codegen.Goify(svc.Name, false),
+			Description: svc.Description,
+			BaseURL:     svc.BaseURL,
+		}
+		for _, m := range svc.Methods {
+			s.Methods = append(s.Methods, tsMethodData(api, m))
+		}
+		data.Services = append(data.Services, s)
+	}
+	return []*codegen.File{{
+		Path: filepath.Join(codegen.Gendir, "client", "ts", "client.ts"),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:   "client-ts",
+			Source: tsClientT,
+			Data:   data,
+			FuncMap: map[string]interface{}{
+				"js":     jsString,
+				"tsType": tsType,
+				"tsProp": tsProp,
+				"tsDoc":  tsDoc,
+			},
+		}},
+	}}, nil
+}
+
+// tsMethodData returns the data used to render the client method of m. The
+// TypeScript interfaces describe the JSON values so that the payload and result
+// fields are accessed with their JSON names.
+func tsMethodData(api *API, m *Method) *tsMethod {
+	tm := &tsMethod{
+		Name:        codegen.Goify(m.Name, false),
+		Description: m.Description,
+		Verb:        m.Verb,
+		Result:      "void",
+		Status:      m.Status,
+		Errors:      m.Errors,
+		ResultBody:  m.ResultBody,
+	}
+	if m.Payload != nil {
+		tm.Payload = tsType(m.Payload)
+	}
+	if m.Result != nil {
+		tm.Result = tsType(m.Result)
+	}
+	if b := m.ResultBody; b != nil && b.Field != "" {
+		tm.ResultBody = &Body{Field: api.JSONName(m.Result, b.Field)}
+	}
+	payload := func(field string) string {
+		return tsAccess("payload", api.JSONName(m.Payload, field))
+	}
+	path := jsString(m.Path)
+	for _, p := range m.PathParams {
+		path = strings.Replace(path, "{"+p.Name+"}", `" + encodeURIComponent(String(`+payload(p.Field)+`)) + "`, 1)
+	}
+	tm.Path = strings.TrimSuffix(strings.TrimPrefix(path, `"" + `), ` + ""`)
+	for _, p := range m.QueryParams {
+		e := payload(p.Field)
+		if p.Style == "csv" {
+			e += `?.join(",")`
+		}
+		tm.Query = append(tm.Query, [2]string{jsString(p.Name), e})
+	}
+	for _, h := range m.Headers {
+		e := payload(h.Field)
+		if h.Bearer {
+			e = "bearer(" + e + ")"
+		}
+		tm.Headers = append(tm.Headers, &tsHeader{Name: h.Name, Expr: e})
+	}
+	if m.BasicAuth != nil {
+		tm.Headers = append(tm.Headers, &tsHeader{
+			Name: "Authorization",
+			Expr: fmt.Sprintf(`"Basic " + btoa(%s + ":" + %s)`, payload(m.BasicAuth.Username), payload(m.BasicAuth.Password)),
+		})
+	}
+	if b := m.Body; b != nil {
+		switch {
+		case b.Field != "":
+			tm.Body = payload(b.Field)
+		case b.Fields != nil:
+			fields := make([]string, len(b.Fields))
+			for i, f := range b.Fields {
+				fields[i] = tsProp(api.JSONName(m.Payload, f)) + ": " + payload(f)
+			}
+			tm.Body = "{ " + strings.Join(fields, ", ") + " }"
+		default:
+			tm.Body = "payload"
+		}
+	}
+	for _, h := range m.ResultHeaders {
+		conv := "String"
+		switch h.Type.Kind {
+		case IntKind, FloatKind:
+			conv = "Number"
+		case BooleanKind:
+			conv = "parseBoolean"
+		case ArrayKind:
+			conv = "parseList"
+		}
+		th := &tsHeader{Name: h.Name, Field: api.JSONName(m.Result, h.Field), Convert: conv}
+		if h.Field == "" {
+			tm.WholeResultHeader = th
+			continue
+		}
+		tm.ResultHeaders = append(tm.ResultHeaders, th)
+	}
+	return tm
+}
+
+// tsType returns the TypeScript type of the given type reference.
+func tsType(t *TypeRef) string {
+	if len(t.Enum) > 0 {
+		vals := make([]string, len(t.Enum))
+		for i, v := range t.Enum {
+			b, _ := json.Marshal(v)
+			vals[i] = string(b)
+		}
+		return strings.Join(vals, " | ")
+	}
+	switch t.Kind {
+	case BooleanKind:
+		return "boolean"
+	case IntKind, FloatKind:
+		return "number"
+	case StringKind, BytesKind:
+		return "string"
+	case ArrayKind:
+		elem := tsType(t.Elem)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case MapKind:
+		key := "string"
+		if t.Key.Kind == IntKind || t.Key.Kind == FloatKind {
+			key = "number"
+		}
+		return "Record<" + key + ", " + tsType(t.Elem) + ">"
+	case TypeKind:
+		return t.Name
+	default:
+		return "unknown"
+	}
+}
+
+// tsProp returns the TypeScript property name for the given field name.
+func tsProp(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return jsString(name)
+}
+
+// tsAccess returns the expression that reads the given field of v, v itself if
+// field is empty.
+func tsAccess(v, field string) string {
+	if field == "" {
+		return v
+	}
+	if tsIdentifier.MatchString(field) {
+		return v + "." + field
+	}
+	return v + "[" + jsString(field) + "]"
+}
+
+// tsDoc returns the JSDoc comment for the given description indented with the
+// given prefix, an empty string if the description is empty.
+func tsDoc(indent, desc string) string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return ""
+	}
+	lines := strings.Split(desc, "\n")
+	if len(lines) == 1 {
+		return indent + "/** " + strings.ReplaceAll(desc, "*/", "*\\/") + " */\n"
+	}
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, l := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+strings.ReplaceAll(l, "*/", "*\\/"), " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+	return b.String()
+}
+
+// jsString returns the JavaScript string literal for s.
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// input: *tsData
+const tsClientT = `// {{ .Title }} client.
+//
+// Code generated by goa, DO NOT EDIT.
+{{- if .Description }}
+//
+{{ comment .Description }}
+{{- end }}
+
+{{- range .Types }}
+
+{{ tsDoc "" .Description }}export interface {{ .Name }} {
+	{{- range .Fields }}
+{{ tsDoc "\t" .Description }}	{{ tsProp .JSON }}{{ if not .Required }}?{{ end }}: {{ tsType .Type }};
+	{{- end }}
+}
+{{- end }}
+
+/** ClientOptions configures the service clients. */
+export interface ClientOptions {
+	/** baseURL overrides the default URL of the services. */
+	baseURL?: string;
+	/** fetch overrides the function used to send the requests. */
+	fetch?: typeof fetch;
+	/** headers are added to all the requests. */
+	headers?: Record<string, string>;
+}
+
+/** ServiceError is the error thrown when the service returns an error response. */
+export class ServiceError extends Error {
+	constructor(
+		/** errorName is the name of the error in the design, empty if the error is not described in the design. */
+		readonly errorName: string,
+		/** status is the response status code. */
+		readonly status: number,
+		/** body is the decoded response body. */
+		readonly body: unknown,
+	) {
+		super(errorName ? errorName + " (" + status + ")" : "unexpected response status " + status);
+	}
+}
+
+type Query = [string, unknown][];
+
+async function send(options: ClientOptions, baseURL: string, method: string, path: string, query: Query, headers: Record<string, string>, body?: unknown): Promise<Response> {
+	const params = new URLSearchParams();
+	for (const [name, value] of query) {
+		if (value === undefined || value === null) {
+			continue;
+		}
+		for (const v of Array.isArray(value) ? value : [value]) {
+			params.append(name, String(v));
+		}
+	}
+	const qs = params.toString();
+	const init: RequestInit = { method, headers: { ...options.headers, ...headers } };
+	if (body !== undefined) {
+		init.body = JSON.stringify(body);
+		(init.headers as Record<string, string>)["Content-Type"] = "application/json";
+	}
+	return (options.fetch || fetch)((options.baseURL || baseURL) + path + (qs ? "?" + qs : ""), init);
+}
+
+function setHeader(headers: Record<string, string>, name: string, value: unknown) {
+	if (value === undefined || value === null) {
+		return;
+	}
+	headers[name] = Array.isArray(value) ? value.join(",") : String(value);
+}
+
+function bearer(token: unknown): string | undefined {
+	if (token === undefined || token === null) {
+		return undefined;
+	}
+	const s = String(token);
+	return s.includes(" ") ? s : "Bearer " + s;
+}
+
+function parseBoolean(v: string): boolean {
+	return v === "true";
+}
+
+function parseList(v: string): string[] {
+	return v.split(",").map((s) => s.trim());
+}
+
+async function decode(res: Response): Promise<unknown> {
+	const text = await res.text();
+	if (!text) {
+		return undefined;
+	}
+	try {
+		return JSON.parse(text);
+	} catch {
+		return text;
+	}
+}
+
+async function fail(res: Response, errors: [number, string][]): Promise<never> {
+	const body = await decode(res);
+	let name = "";
+	if (body && typeof body === "object" && typeof (body as Record<string, unknown>).name === "string") {
+		name = (body as Record<string, unknown>).name as string;
+	}
+	if (!errors.some(([, n]) => n === name)) {
+		const found = errors.find(([status]) => status === res.status);
+		name = found ? found[1] : "";
+	}
+	throw new ServiceError(name, res.status, body);
+}
+{{- range .Services }}
+
+{{ tsDoc "" .Description }}export class {{ .Name }} {
+	constructor(private readonly options: ClientOptions = {}) {}
+	{{- $svc := . }}
+	{{- range .Methods }}
+
+{{ tsDoc "\t" .Description }}	async {{ .Name }}({{ if .Payload }}payload: {{ .Payload }}{{ end }}): Promise<{{ .Result }}> {
+		const query: Query = [{{ range $i, $q := .Query }}{{ if $i }}, {{ end }}[{{ index $q 0 }}, {{ index $q 1 }}]{{ end }}];
+		const headers: Record<string, string> = {};
+		{{- range .Headers }}
+		setHeader(headers, {{ js .Name }}, {{ .Expr }});
+		{{- end }}
+		const res = await send(this.options, {{ js $svc.BaseURL }}, {{ js .Verb }}, {{ .Path }}, query, headers{{ if .Body }}, {{ .Body }}{{ end }});
+		if (res.status !== {{ .Status }}) {
+			return fail(res, [{{ range $i, $e := .Errors }}{{ if $i }}, {{ end }}[{{ .Status }}, {{ js .Name }}]{{ end }}]);
+		}
+	{{- if eq .Result "void" }}
+	}
+	{{- else if and .ResultBody (not .ResultBody.Field) (not .ResultHeaders) }}
+		return (await decode(res)) as {{ .Result }};
+	}
+	{{- else if .WholeResultHeader }}
+		return {{ .WholeResultHeader.Convert }}(res.headers.get({{ js .WholeResultHeader.Name }}) || "") as {{ .Result }};
+	}
+	{{- else }}
+		const result = {{ if and .ResultBody .ResultBody.Fields }}((await decode(res)) || {}){{ else }}{}{{ end }} as {{ .Result }};
+		{{- if and .ResultBody .ResultBody.Field }}
+		result[{{ js .ResultBody.Field }}] = (await decode(res)) as {{ .Result }}[{{ js .ResultBody.Field }}];
+		{{- end }}
+		{{- range .ResultHeaders }}
+		if (res.headers.has({{ js .Name }})) {
+			Object.assign(result, { {{ tsProp .Field }}: {{ .Convert }}(res.headers.get({{ js .Name }}) as string) });
+		}
+		{{- end }}
+		return result;
+	}
+	{{- end }}
+	{{- end }}
+}
+{{- end }}
+
+/** Client groups the clients of all the services. */
+export class Client {
+	{{- range .Services }}
+	readonly {{ .Prop }}: {{ .Name }};
+	{{- end }}
+
+	constructor(options: ClientOptions = {}) {
+		{{- range .Services }}
+		this.{{ .Prop }} = new {{ .Name }}(options);
+		{{- end }}
+	}
+}
+`
@@ -0,0 +1,34 @@
+package client_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	"goa.design/goa/v3/http/codegen/client"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestTypeScriptFiles(t *testing.T) {
+	root := httpgen.RunHTTPDSL(t, testdata.ClientDSL)
+	fs, err := client.TypeScriptFiles("", client.NewAPI(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if expected := filepath.Join("gen", "client", "ts", "client.ts"); fs[0].Path != expected {
+		t.Errorf("got path %q, expected %q", fs[0].Path, expected)
+	}
+	var buf bytes.Buffer
+	if err := fs[0].SectionTemplates[0].Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	code := buf.String()
+	if code != testdata.ClientTypeScriptCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ClientTypeScriptCode))
+	}
+}
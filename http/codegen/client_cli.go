@@ -235,6 +235,18 @@ func makeFlags(e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*
 		if arg.FieldName == "" && arg.VarName != "body" {
 			continue
 		}
+		if expr.IsObject(arg.Type) && arg.FieldName != "" {
+			// Object query parameter: decode the JSON into a struct
+			// using the wire names and convert it to the service type.
+			fdata = append(fdata, &cli.FieldData{
+				Name:    arg.VarName,
+				VarName: arg.VarName,
+				TypeRef: arg.TypeRef,
+				Init:    queryObjectLoadCode(f, arg),
+			})
+			check = true
+			continue
+		}
 		code, chek := cli.FieldLoadCode(f, arg.VarName, arg.TypeName, arg.Validate, arg.DefaultValue, payload, e.Payload.Ref)
 		check = check || chek
 		tn := arg.TypeRef
@@ -275,6 +287,22 @@ func makeFlags(e *EndpointData, args []*InitArgData, payload expr.DataType) ([]*
 	}
 }
 
+// queryObjectLoadCode returns the code that initializes the object query
+// parameter described by arg from the JSON value of the flag f.
+func queryObjectLoadCode(f *cli.FlagData, arg *InitArgData) string {
+	code := fmt.Sprintf("var val %s\nerr = json.Unmarshal([]byte(%s), &val)\n", arg.TypeName, f.FullName)
+	code += fmt.Sprintf("if err != nil {\n\treturn nil, fmt.Errorf(\"invalid JSON for %s, \\nerror: %%s, \\nexample of valid JSON:\\n%%s\", err, %q)\n}\n",
+		arg.VarName, f.Example)
+	code += fmt.Sprintf("%s = (%s)(&val)", arg.VarName, arg.TypeRef)
+	if arg.Validate != "" {
+		code += "\n" + arg.Validate + "\nif err != nil {\n\treturn nil, err\n}"
+	}
+	if !f.Required {
+		code = fmt.Sprintf("if %s != \"\" {\n%s\n}", f.FullName, code)
+	}
+	return code
+}
+
 // streamFlag returns the flag used to specify the upload file for endpoints
 // that use SkipRequestBodyEncodeDecode.
 func streamFlag(svcn, en string) *cli.FlagData {
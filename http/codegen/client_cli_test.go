@@ -27,6 +27,7 @@ func TestClientCLIFiles(t *testing.T) {
 		{"bool-build", testdata.PayloadQueryBoolDSL, testdata.QueryBoolBuildCode, 1, 1},
 		{"uint32-build", testdata.PayloadQueryUInt32DSL, testdata.QueryUInt32BuildCode, 1, 1},
 		{"uint64-build", testdata.PayloadQueryUIntDSL, testdata.QueryUIntBuildCode, 1, 1},
+		{"query-object-build", testdata.PayloadQueryObjectDSL, testdata.QueryObjectBuildCode, 1, 1},
 		{"string-build", testdata.PayloadQueryStringDSL, testdata.QueryStringBuildCode, 1, 1},
 		{"string-required-build", testdata.PayloadQueryStringValidateDSL, testdata.QueryStringRequiredBuildCode, 1, 1},
 		{"string-default-build", testdata.PayloadQueryStringDefaultDSL, testdata.QueryStringDefaultBuildCode, 1, 1},
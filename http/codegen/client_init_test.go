@@ -18,6 +18,7 @@ func TestClientInit(t *testing.T) {
 	}{
 		{"multiple endpoints", testdata.ServerMultiEndpointsDSL, testdata.MultipleEndpointsClientInitCode, 2, 2},
 		{"streaming", testdata.StreamingResultDSL, testdata.StreamingClientInitCode, 3, 2},
+		{"envelope", testdata.ResultBodyEnvelopeDSL, testdata.EnvelopeClientInitCode, 2, 2},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -37,3 +38,35 @@ func TestClientInit(t *testing.T) {
 		})
 	}
 }
+
+func TestClientHosts(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerHostsDSL)
+	fs := ClientFiles("", expr.Root)
+	sections := fs[0].SectionTemplates
+	code := codegen.SectionCode(t, sections[len(sections)-1])
+	if code != testdata.ServerHostsClientCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerHostsClientCode))
+	}
+}
+
+func TestClientTransportSecurity(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"signature", testdata.ClientSignatureDSL, testdata.ClientSignatureCode},
+		{"mutual-tls", testdata.ClientMutualTLSDSL, testdata.ClientMutualTLSCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles("", expr.Root)
+			sections := fs[0].SectionTemplates
+			code := codegen.SectionCode(t, sections[len(sections)-1])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -87,8 +87,8 @@ func clientType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				validatedTypes = append(validatedTypes, data)
 			}
 		}
-		if adata.ClientWebSocket != nil {
-			if data := adata.ClientWebSocket.Payload; data != nil {
+		if ws := clientStream(adata); ws != nil {
+			if data := ws.Payload; data != nil {
 				if _, ok := seen[data.Name]; ok {
 					continue
 				}
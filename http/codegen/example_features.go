@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/codegen/example"
+	"goa.design/goa/v3/expr"
+)
+
+// exampleFeature describes an optional feature of the example HTTP servers.
+type exampleFeature struct {
+	// Imports lists the packages used by the feature code.
+	Imports []*codegen.ImportSpec
+	// Source is the template rendering the feature code, the template data
+	// is the API expression.
+	Source string
+}
+
+// exampleFeatureSources lists the optional features of the example HTTP
+// servers indexed by name. The names are used as the build tags that guard
+// the files implementing the features.
+var exampleFeatureSources = map[string]*exampleFeature{
+	"metrics": {
+		Imports: []*codegen.ImportSpec{
+			{Path: "expvar"},
+			{Path: "log"},
+			{Path: "net/http"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+		},
+		Source: httpMetricsFeatureT,
+	},
+	"tracing": {
+		Imports: []*codegen.ImportSpec{
+			{Path: "log"},
+			{Path: "os"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			codegen.GoaNamedImport("http/middleware", "httpmdlwr"),
+			codegen.GoaImport("http/middleware/xray"),
+		},
+		Source: httpTracingFeatureT,
+	},
+}
+
+// exampleFeatures returns the names of the optional features listed in the
+// API "http:features" meta in order, unknown names are ignored.
+func exampleFeatures(api *expr.APIExpr) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range api.Meta["http:features"] {
+		if _, ok := exampleFeatureSources[name]; ok && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// exampleFeatureFiles returns the files implementing the optional features of
+// the example HTTP server. Each file is only compiled when the build tag named
+// after the feature is set.
+func exampleFeatureFiles(root *expr.RootExpr, svr *expr.ServerExpr) []*codegen.File {
+	var fw []*codegen.File
+	svrdata := example.Servers.Get(svr)
+	for _, name := range exampleFeatures(root.API) {
+		f := exampleFeatureSources[name]
+		fw = append(fw, &codegen.File{
+			Path: filepath.Join("cmd", svrdata.Dir, "http_"+name+".go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header("", "main", f.Imports),
+				{Name: "server-http-feature-" + name, Source: f.Source, Data: root.API},
+			},
+			SkipExist: true,
+			BuildTags: name,
+		})
+	}
+	return fw
+}
+
+const (
+	// input: *expr.APIExpr
+	httpMetricsFeatureT = `
+func init() {
+	httpFeatures = append(httpFeatures, httpMetrics)
+}
+
+// Counters published by the metrics feature indexed by "service.method".
+var (
+	httpRequests      = expvar.NewMap("http_requests")
+	httpResponseBytes = expvar.NewMap("http_response_bytes")
+	httpEncodeNanos   = expvar.NewMap("http_encode_nanoseconds")
+)
+
+// httpMetrics records the number of requests, the number of bytes written and
+// the time spent encoding the responses of each method and serves the counters
+// as JSON on GET /debug/vars.
+func httpMetrics(svc *goahttp.Service, logger *log.Logger) {
+	svc.Use(httpmdlwr.Metrics(func(r *http.Request, s *goahttp.ResponseStats) {
+		key := "unhandled"
+		if s.Method != "" {
+			key = s.Service + "." + s.Method
+		}
+		httpRequests.Add(key, 1)
+		httpResponseBytes.Add(key, int64(s.Bytes))
+		httpEncodeNanos.Add(key, int64(s.EncodeDuration))
+	}))
+	svc.Mux.Handle("GET", "/debug/vars", expvar.Handler().ServeHTTP)
+	logger.Printf("HTTP metrics mounted on GET /debug/vars")
+}
+`
+
+	// input: *expr.APIExpr
+	httpTracingFeatureT = `
+func init() {
+	httpFeatures = append(httpFeatures, httpTracing)
+}
+
+// httpTracing sends the AWS X-Ray segments of the sampled requests to the
+// daemon listening on the address set in the AWS_XRAY_DAEMON_ADDRESS
+// environment variable, 127.0.0.1:2000 by default.
+func httpTracing(svc *goahttp.Service, logger *log.Logger) {
+	daemon := os.Getenv("AWS_XRAY_DAEMON_ADDRESS")
+	if daemon == "" {
+		daemon = "127.0.0.1:2000"
+	}
+	xrayMiddleware, err := xray.New({{ printf "%q" .Name }}, daemon)
+	if err != nil {
+		logger.Printf("HTTP tracing disabled: %v", err)
+		return
+	}
+	// The trace middleware must run first to initialize the trace IDs.
+	svc.Use(xrayMiddleware, httpmdlwr.Trace())
+	logger.Printf("HTTP tracing sends X-Ray segments to %s", daemon)
+}
+`
+)
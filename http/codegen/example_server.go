@@ -17,6 +17,7 @@ func ExampleServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 	for _, svr := range root.API.Servers {
 		if m := exampleServer(genpkg, root, svr); m != nil {
 			fw = append(fw, m)
+			fw = append(fw, exampleFeatureFiles(root, svr)...)
 		}
 	}
 	for _, svc := range root.API.HTTP.Services {
@@ -91,18 +92,30 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 			},
 		},
 		{Name: "server-http-logger", Source: httpSvrLoggerT},
-		{Name: "server-http-encoding", Source: httpSvrEncodingT},
-		{Name: "server-http-mux", Source: httpSvrMuxT},
+		{
+			Name:   "server-http-service",
+			Source: httpSvrServiceT,
+			Data: map[string]interface{}{
+				"Radix": isRadixMuxer(root.API),
+			},
+		},
 		{
 			Name:   "server-http-init",
 			Source: httpSvrInitT,
 			Data: map[string]interface{}{
-				"Services": svcdata,
-				"APIPkg":   apiPkg,
+				"Services":  svcdata,
+				"APIPkg":    apiPkg,
+				"BatchPath": root.API.HTTP.BatchPath,
 			},
 			FuncMap: map[string]interface{}{"needStream": needStream, "hasWebSocket": hasWebSocket},
 		},
-		{Name: "server-http-middleware", Source: httpSvrMiddlewareT},
+		{
+			Name:   "server-http-middleware",
+			Source: httpSvrMiddlewareT,
+			Data: map[string]interface{}{
+				"Features": len(exampleFeatures(root.API)) > 0,
+			},
+		},
 		{
 			Name:   "server-http-end",
 			Source: httpSvrEndT,
@@ -112,10 +125,20 @@ func exampleServer(genpkg string, root *expr.RootExpr, svr *expr.ServerExpr) *co
 		},
 		{Name: "server-http-errorhandler", Source: httpSvrErrorHandlerT},
 	}
+	if len(exampleFeatures(root.API)) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-http-features", Source: httpSvrFeaturesT})
+	}
 
 	return &codegen.File{Path: fpath, SectionTemplates: sections, SkipExist: true}
 }
 
+// isRadixMuxer returns true if the API "http:muxer" meta selects the radix
+// tree muxer.
+func isRadixMuxer(api *expr.APIExpr) bool {
+	v, ok := api.Meta.Last("http:muxer")
+	return ok && v == "radix"
+}
+
 // dummyMultipartFile returns a dummy implementation of the multipart decoders
 // and encoders.
 func dummyMultipartFile(genpkg string, root *expr.RootExpr, svc *expr.HTTPServiceExpr) *codegen.File {
@@ -213,27 +236,24 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	}
 	`
 
-	httpSvrEncodingT = `
-	// Provide the transport specific request decoder and response encoder.
-	// The goa http package has built-in support for JSON, XML and gob.
-	// Other encodings can be used by providing the corresponding functions,
-	// see goa.design/implement/encoding.
-	var (
-		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+	// input: map[string]interface{}{"Radix":bool}
+	httpSvrServiceT = `
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+	{{- if .Radix }}
+		goahttp.WithMuxer(goahttp.NewRadixMuxer()),
+	{{- end }}
+		goahttp.WithErrorHandler(errorHandler(logger)),
 	)
 `
 
-	httpSvrMuxT = `
-	// Build the service HTTP request multiplexer and configure it to serve
-	// HTTP requests to the service endpoints.
-	var mux goahttp.Muxer
-	{
-		mux = goahttp.NewMuxer()
-	}
-`
-
-	// input: map[string]interface{}{"APIPkg":string, "Services":[]*ServiceData}
+	// input: map[string]interface{}{"APIPkg":string, "Services":[]*ServiceData, "BatchPath":string}
 	httpSvrInitT = `
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
@@ -245,15 +265,14 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 	{{- end }}
 	)
 	{
-		eh := errorHandler(logger)
 	{{- if needStream .Services }}
 		upgrader := &websocket.Upgrader{}
 	{{- end }}
 	{{- range $svc := .Services }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh, nil{{ if hasWebSocket $svc }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ range .FileServers }}, nil{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter{{ if hasWebSocket $svc }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ range .FileServers }}, nil{{ end }})
 		{{-  else }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh, nil{{ range .FileServers }}, nil{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter{{ range .FileServers }}, nil{{ end }})
 		{{-  end }}
 	{{- end }}
 	{{- if .Services }}
@@ -263,31 +282,47 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 				{{ .Service.VarName }}Server,
 				{{- end }}
 			}
-			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
 		}
 	{{- end }}
 	}
-	// Configure the mux.
-	{{- range .Services }}
-		{{ .Service.PkgName }}svr.Mount(mux, {{ .Service.VarName }}Server)
+	{{- if .Services }}
+	// Mount the servers on the service mux.
+	httpSvc.Mount(
+	{{- range $i, $svc := .Services }}{{ if $i }}, {{ end }}{{ .Service.VarName }}Server{{ end -}}
+	)
+	{{- end }}
+	{{- if .BatchPath }}
+
+	// Mount the batch endpoint, sub-requests are served by the mux.
+	httpSvc.Mux.Handle("POST", {{ printf "%q" .BatchPath }}, goahttp.BatchHandler(httpSvc.Mux, 20))
+	logger.Printf("HTTP batch endpoint mounted on POST %s", {{ printf "%q" .BatchPath }})
 	{{- end }}
 `
 
+	// input: map[string]interface{}{"Features":bool}
 	httpSvrMiddlewareT = `
-	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
-	var handler http.Handler = mux
-	{
-		handler = httpmdlwr.Log(adapter)(handler)
-		handler = httpmdlwr.RequestID()(handler)
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
+{{- if .Features }}
+
+	// Configure the optional features compiled in with build tags, see the
+	// http_*.go files.
+	for _, configure := range httpFeatures {
+		configure(httpSvc, logger)
 	}
+{{- end }}
 `
 
 	// input: map[string]interface{}{"Services":[]*ServiceData}
 	httpSvrEndT = `
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
 
 	{{- range .Services }}
 		for _, m := range {{ .Service.VarName }}Server.Mounts {
@@ -318,6 +353,14 @@ func handleHTTPServer(ctx context.Context, u *url.URL{{ range $.Services }}{{ if
 		}
 	}()
 }
+`
+
+	httpSvrFeaturesT = `
+// httpFeatures lists the functions that configure the optional features of the
+// HTTP server. The files implementing the features register the functions in
+// their init function and are only compiled when the corresponding build tag
+// is set, e.g. "go build -tags metrics".
+var httpFeatures []func(*goahttp.Service, *log.Logger)
 `
 
 	httpSvrErrorHandlerT = `
@@ -73,6 +73,9 @@ func TestExampleServerFiles(t *testing.T) {
 			{"server-hosting-service-subset", ctestdata.ServerHostingServiceSubsetDSL, testdata.ServerHostingServiceSubsetServerHandleCode},
 			{"server-hosting-multiple-services", ctestdata.ServerHostingMultipleServicesDSL, testdata.ServerHostingMultipleServicesServerHandleCode},
 			{"streaming", testdata.StreamingMultipleServicesDSL, testdata.StreamingServerHandleCode},
+			{"batch", testdata.BatchDSL, testdata.BatchServerHandleCode},
+			{"radix-muxer", testdata.RadixMuxerDSL, testdata.RadixMuxerServerHandleCode},
+			{"features", testdata.FeaturesDSL, testdata.FeaturesServerHandleCode},
 		}
 		for _, c := range cases {
 			t.Run(c.Name, func(t *testing.T) {
@@ -102,3 +105,35 @@ func TestExampleServerFiles(t *testing.T) {
 		}
 	})
 }
+
+func TestExampleFeatureFiles(t *testing.T) {
+	HTTPServices = make(ServicesData)
+	service.Services = make(service.ServicesData)
+	example.Servers = make(example.ServersData)
+	codegen.RunDSL(t, testdata.FeaturesDSL)
+	fs := ExampleServerFiles("", expr.Root)
+	if len(fs) != 3 {
+		t.Fatalf("got %d files, expected 3", len(fs))
+	}
+	cases := []struct {
+		Path string
+		Tags string
+		Code string
+	}{
+		{"cmd/features_api/http_tracing.go", "tracing", testdata.FeaturesTracingCode},
+		{"cmd/features_api/http_metrics.go", "metrics", testdata.FeaturesMetricsCode},
+	}
+	for i, c := range cases {
+		f := fs[i+1]
+		if f.Path != c.Path {
+			t.Errorf("got path %q, expected %q", f.Path, c.Path)
+		}
+		if f.BuildTags != c.Tags {
+			t.Errorf("got build tags %q, expected %q", f.BuildTags, c.Tags)
+		}
+		code := codegen.SectionCode(t, f.SectionTemplates[1])
+		if code != c.Code {
+			t.Errorf("invalid code for %s: got\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+// fuzzData describes the fuzz target of a request decoder.
+type fuzzData struct {
+	// Name is the name of the fuzz function.
+	Name string
+	// Description is the fuzz function description.
+	Description string
+	// RequestDecoder is the name of the request decoder function.
+	RequestDecoder string
+	// Verb is the HTTP method of the route.
+	Verb string
+	// Path is the route path including wildcards.
+	Path string
+	// URL is the path and query string of the example request.
+	URL string
+	// Headers lists the example request headers.
+	Headers [][2]string
+	// Seeds lists the initial request bodies of the fuzz corpus.
+	Seeds []string
+}
+
+// ServerFuzzFiles returns the files that define Go fuzz targets for the
+// request decoders of the HTTP endpoints whose requests have a body. The fuzz
+// targets send the design example requests with arbitrary bodies to the
+// decoders so that "go test -fuzz" exercises the decoding and validation of
+// malformed bodies (invalid JSON, numbers that overflow, invalid UTF-8 etc.).
+// ServerFuzzFiles returns nil if the API does not define the "http:fuzz" meta.
+func ServerFuzzFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	if _, ok := root.API.Meta["http:fuzz"]; !ok {
+		return nil
+	}
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		if f := serverFuzzFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// serverFuzzFile returns the file that defines the fuzz targets of the given
+// service request decoders, nil if there are none.
+func serverFuzzFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
+	data := HTTPServices.Get(svc.Name())
+	svcName := data.Service.PathName
+	var sections []*codegen.SectionTemplate
+	for _, e := range data.Endpoints {
+		if e.RequestDecoder == "" || e.Payload.Request.ServerBody == nil || e.MultipartRequestDecoder != nil || isWebSocketEndpoint(e) {
+			continue
+		}
+		ex := openapi.NewExampleRequest(svc.Endpoint(e.Method.Name).Routes[0])
+		var headers [][2]string
+		for _, h := range ex.Headers {
+			if h[0] != "Content-Type" {
+				headers = append(headers, h)
+			}
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-fuzz",
+			Source: serverFuzzT,
+			Data: &fuzzData{
+				Name:           "Fuzz" + e.RequestDecoder,
+				Description:    fmt.Sprintf("Fuzz%s exercises the decoder of the requests sent to the %s %s endpoint with arbitrary bodies.", e.RequestDecoder, e.ServiceName, e.Method.Name),
+				RequestDecoder: e.RequestDecoder,
+				Verb:           e.Routes[0].Verb,
+				Path:           e.Routes[0].Path,
+				URL:            ex.Path,
+				Headers:        headers,
+				Seeds:          fuzzSeeds(ex.Body),
+			},
+		})
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	title := fmt.Sprintf("%s HTTP server fuzz tests", svc.Name())
+	header := codegen.Header(title, "server", []*codegen.ImportSpec{
+		{Path: "bytes"},
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "testing"},
+		codegen.GoaNamedImport("http", "goahttp"),
+	})
+	return &codegen.File{
+		Path:             filepath.Join(codegen.Gendir, "http", svcName, "server", "fuzz_test.go"),
+		SectionTemplates: append([]*codegen.SectionTemplate{header}, sections...),
+	}
+}
+
+// fuzzSeeds returns the initial corpus of a fuzz target given the example
+// body: the example itself, malformed JSON documents and for each top-level
+// field of the example documents where the field value is a number that
+// overflows, a string that is not valid UTF-8 and a value of the wrong type.
+func fuzzSeeds(example string) []string {
+	seeds := []string{example, "", "{", "null", "[]", `{"":`}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(example), &fields); err != nil {
+		return seeds
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key, _ := json.Marshal(k)
+		seeds = append(seeds,
+			"{"+string(key)+":1e400}",
+			"{"+string(key)+":-99999999999999999999}",
+			"{"+string(key)+":\"\xff\xfe\"}",
+			"{"+string(key)+":[{}]}",
+		)
+	}
+	return seeds
+}
+
+// input: fuzzData
+const serverFuzzT = `{{ comment .Description }}
+func {{ .Name }}(f *testing.F) {
+{{- range .Seeds }}
+	f.Add([]byte({{ printf "%q" . }}))
+{{- end }}
+	mux := goahttp.NewMuxer()
+	decode := {{ .RequestDecoder }}(mux, goahttp.RequestDecoder)
+	mux.Handle({{ printf "%q" .Verb }}, {{ printf "%q" .Path }}, func(w http.ResponseWriter, r *http.Request) {
+		decode(r) // nolint: errcheck
+	})
+	f.Fuzz(func(t *testing.T, body []byte) {
+		req := httptest.NewRequest({{ printf "%q" .Verb }}, {{ printf "%q" .URL }}, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+	{{- range .Headers }}
+		req.Header.Add({{ printf "%q" (index . 0) }}, {{ printf "%q" (index . 1) }})
+	{{- end }}
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+`
@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestServerFuzzFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected string
+	}{
+		{"no-fuzz", testdata.PayloadBodyUserDSL, ""},
+		{"fuzz", testdata.PayloadFuzzDSL, testdata.PayloadFuzzCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			fs := ServerFuzzFiles("", root)
+			if c.Expected == "" {
+				if len(fs) != 0 {
+					t.Fatalf("got %d files, expected none", len(fs))
+				}
+				return
+			}
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			var buf bytes.Buffer
+			for _, s := range fs[0].SectionTemplates[1:] {
+				if err := s.Write(&buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := codegen.FormatTestCode(t, "package foo\n"+buf.String())
+			if code != c.Expected {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,552 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+const (
+	// GatewayKong is the "gateway" value that selects the Kong declarative
+	// configuration.
+	GatewayKong = "kong"
+	// GatewayTraefik is the "gateway" value that selects the Traefik
+	// IngressRoute.
+	GatewayTraefik = "traefik"
+	// GatewayEnvoy is the "gateway" value that selects the Envoy route
+	// configuration.
+	GatewayEnvoy = "envoy"
+)
+
+type (
+	// gatewayData is the data used to render the gateway configurations.
+	gatewayData struct {
+		// Title is the API title.
+		Title string
+		// Name is the API name usable as a gateway resource name.
+		Name string
+		// Services lists the HTTP services.
+		Services []*gatewayServiceData
+		// Schemes lists the security schemes used by the routes.
+		Schemes []*gatewaySchemeData
+		// RateLimits lists the routes that define a rate limit.
+		RateLimits []*gatewayRouteData
+	}
+
+	// gatewayServiceData describes the upstream of the routes of a service.
+	gatewayServiceData struct {
+		// Name is the service name usable as a gateway resource name.
+		Name string
+		// URL is the base URL of the service.
+		URL string
+		// Host is the host of the service base URL.
+		Host string
+		// Port is the port of the service base URL.
+		Port int
+		// Routes lists the service routes.
+		Routes []*gatewayRouteData
+	}
+
+	// gatewayRouteData describes a route served by a service.
+	gatewayRouteData struct {
+		// Name is the route name made of the service and method names.
+		Name string
+		// Service is the name of the service that serves the route.
+		Service string
+		// Verb is the HTTP method.
+		Verb string
+		// Path is the route full path, empty if the path has wildcards.
+		Path string
+		// Regex is the regular expression that matches the route full
+		// path.
+		Regex string
+		// RateLimit is the route rate limit if any.
+		RateLimit *gatewayRateLimitData
+		// Schemes lists the security schemes of the route.
+		Schemes []*gatewaySchemeData
+		// JWT is the first JWT or OAuth2 scheme of the route if any.
+		JWT *gatewaySchemeData
+		// Others lists the names of the Basic and API key schemes of the
+		// route.
+		Others []string
+	}
+
+	// gatewayRateLimitData describes the rate limit set with the
+	// "gateway:ratelimit" meta.
+	gatewayRateLimitData struct {
+		// Count is the maximum number of requests per period.
+		Count int
+		// Period is "second", "minute", "hour" or "day".
+		Period string
+		// Seconds is the duration of the period in seconds.
+		Seconds int
+	}
+
+	// gatewaySchemeData describes a security scheme enforced by the
+	// gateway.
+	gatewaySchemeData struct {
+		// Name is the scheme name usable as a gateway resource name.
+		Name string
+		// Kind is "basic", "apikey", "jwt" or "oauth2".
+		Kind string
+		// In is the location of the API key, "header" or "query".
+		In string
+		// KeyName is the name of the API key header or query string
+		// parameter.
+		KeyName string
+	}
+)
+
+// gatewayPeriods maps the "gateway:ratelimit" periods to their duration in
+// seconds.
+var gatewayPeriods = map[string]int{"second": 1, "minute": 60, "hour": 3600, "day": 86400}
+
+// gatewayNameRegex matches the characters that are not allowed in gateway
+// resource names.
+var gatewayNameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GatewayFiles returns the API gateway configurations that route the requests
+// to the HTTP endpoints: the Kong declarative configuration in
+// gen/http/gateway/kong.yaml, the Traefik IngressRoute and middlewares in
+// gen/http/gateway/traefik.yaml and/or the Envoy route configuration in
+// gen/http/gateway/envoy.yaml. The configurations are built from the routes
+// and aliases of the endpoints, their security requirements and the rate
+// limits set with the "gateway:ratelimit" meta. GatewayFiles returns nil if
+// the API does not define the "gateway" meta.
+func GatewayFiles(root *expr.RootExpr) []*codegen.File {
+	vals, ok := root.API.Meta["gateway"]
+	if !ok {
+		return nil
+	}
+	gateways := make(map[string]bool)
+	for _, v := range vals {
+		gateways[v] = v == GatewayKong || v == GatewayTraefik || v == GatewayEnvoy
+	}
+	if !gateways[GatewayKong] && !gateways[GatewayTraefik] && !gateways[GatewayEnvoy] {
+		gateways = map[string]bool{GatewayKong: true, GatewayTraefik: true, GatewayEnvoy: true}
+	}
+	data := buildGatewayData(root)
+	if data == nil {
+		return nil
+	}
+	var files []*codegen.File
+	for _, g := range []struct{ name, source string }{
+		{GatewayKong, gatewayKongT},
+		{GatewayTraefik, gatewayTraefikT},
+		{GatewayEnvoy, gatewayEnvoyT},
+	} {
+		if !gateways[g.name] {
+			continue
+		}
+		files = append(files, &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "http", "gateway", g.name+".yaml"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:   "gateway-" + g.name,
+				Source: g.source,
+				Data:   data,
+				FuncMap: map[string]interface{}{
+					"yaml":        yamlString,
+					"join":        strings.Join,
+					"kongPath":    kongPath,
+					"traefikRule": traefikRule,
+				},
+			}},
+		})
+	}
+	return files
+}
+
+// buildGatewayData returns the data used to render the gateway
+// configurations, nil if the API does not define HTTP routes.
+func buildGatewayData(root *expr.RootExpr) *gatewayData {
+	title := root.API.Title
+	if title == "" {
+		title = root.API.Name
+	}
+	data := &gatewayData{Title: title, Name: gatewayName(root.API.Name)}
+	seen := make(map[string]bool)
+	for _, svc := range root.API.HTTP.Services {
+		base := openapi.ServiceBaseURL(svc.ServiceExpr)
+		sd := &gatewayServiceData{Name: gatewayName(svc.Name()), URL: base}
+		if u, err := url.Parse(base); err == nil {
+			sd.Host = u.Hostname()
+			sd.Port, _ = strconv.Atoi(u.Port())
+			if sd.Port == 0 {
+				sd.Port = 80
+				if u.Scheme == "https" {
+					sd.Port = 443
+				}
+			}
+		}
+		for _, e := range svc.HTTPEndpoints {
+			rl := gatewayRateLimit(e)
+			schemes := gatewaySchemes(e)
+			for _, s := range schemes {
+				if !seen[s.Name] {
+					seen[s.Name] = true
+					data.Schemes = append(data.Schemes, s)
+				}
+			}
+			var paths [][2]string
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					paths = append(paths, [2]string{strings.ToUpper(r.Method), p})
+				}
+			}
+			for _, a := range e.Aliases {
+				for _, p := range a.FullPaths() {
+					for _, r := range e.Routes {
+						paths = append(paths, [2]string{strings.ToUpper(r.Method), p})
+					}
+				}
+			}
+			name := gatewayName(svc.Name() + "-" + e.Name())
+			for i, p := range paths {
+				rd := &gatewayRouteData{
+					Name:      name,
+					Service:   sd.Name,
+					Verb:      p[0],
+					Regex:     gatewayRegex(p[1]),
+					RateLimit: rl,
+					Schemes:   schemes,
+				}
+				if len(paths) > 1 {
+					rd.Name += "-" + strconv.Itoa(i+1)
+				}
+				if len(expr.ExtractHTTPWildcards(p[1])) == 0 {
+					rd.Path = p[1]
+				}
+				for _, s := range schemes {
+					switch s.Kind {
+					case "jwt", "oauth2":
+						if rd.JWT == nil {
+							rd.JWT = s
+						}
+					default:
+						rd.Others = append(rd.Others, s.Name)
+					}
+				}
+				sd.Routes = append(sd.Routes, rd)
+				if rl != nil {
+					data.RateLimits = append(data.RateLimits, rd)
+				}
+			}
+		}
+		if len(sd.Routes) > 0 {
+			data.Services = append(data.Services, sd)
+		}
+	}
+	if len(data.Services) == 0 {
+		return nil
+	}
+	return data
+}
+
+// gatewayRateLimit returns the rate limit set with the "gateway:ratelimit"
+// meta of the endpoint method or service, nil if there is none or if the
+// value is invalid. The value has the form "<count>/<period>" where period is
+// "second", "minute", "hour" or "day", e.g. "100/minute".
+func gatewayRateLimit(e *expr.HTTPEndpointExpr) *gatewayRateLimitData {
+	v, ok := e.MethodExpr.Meta.Last("gateway:ratelimit")
+	if !ok {
+		if v, ok = e.Service.ServiceExpr.Meta.Last("gateway:ratelimit"); !ok {
+			return nil
+		}
+	}
+	parts := strings.SplitN(v, "/", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || count <= 0 {
+		return nil
+	}
+	period := strings.TrimSpace(parts[1])
+	seconds, ok := gatewayPeriods[period]
+	if !ok {
+		return nil
+	}
+	return &gatewayRateLimitData{Count: count, Period: period, Seconds: seconds}
+}
+
+// gatewaySchemes returns the security schemes of the endpoint requirements.
+func gatewaySchemes(e *expr.HTTPEndpointExpr) []*gatewaySchemeData {
+	var (
+		schemes []*gatewaySchemeData
+		seen    = make(map[string]bool)
+	)
+	for _, req := range e.Requirements {
+		for _, s := range req.Schemes {
+			if seen[s.SchemeName] {
+				continue
+			}
+			var kind string
+			switch s.Kind {
+			case expr.BasicAuthKind:
+				kind = "basic"
+			case expr.APIKeyKind:
+				kind = "apikey"
+			case expr.JWTKind:
+				kind = "jwt"
+			case expr.OAuth2Kind:
+				kind = "oauth2"
+			default:
+				continue
+			}
+			seen[s.SchemeName] = true
+			schemes = append(schemes, &gatewaySchemeData{
+				Name:    gatewayName(s.SchemeName),
+				Kind:    kind,
+				In:      s.In,
+				KeyName: s.Name,
+			})
+		}
+	}
+	return schemes
+}
+
+// gatewayRegex returns the anchored regular expression that matches the
+// given route full path.
+func gatewayRegex(path string) string {
+	var (
+		re   strings.Builder
+		last int
+	)
+	re.WriteString("^")
+	for _, m := range expr.HTTPWildcardRegex.FindAllStringIndex(path, -1) {
+		re.WriteString(regexp.QuoteMeta(path[last:m[0]]))
+		if strings.HasPrefix(path[m[0]:], "/{*") {
+			re.WriteString("/.*")
+		} else {
+			re.WriteString("/[^/]+")
+		}
+		last = m[1]
+	}
+	re.WriteString(regexp.QuoteMeta(path[last:]))
+	re.WriteString("$")
+	return re.String()
+}
+
+// kongPath returns the Kong regular expression path of the route. Kong
+// matches path prefixes so that all the paths are anchored regular
+// expressions.
+func kongPath(r *gatewayRouteData) string {
+	return "~" + strings.TrimPrefix(r.Regex, "^")
+}
+
+// traefikRule returns the Traefik rule that matches the route.
+func traefikRule(r *gatewayRouteData) string {
+	if r.Path != "" {
+		return fmt.Sprintf("Method(`%s`) && Path(`%s`)", r.Verb, r.Path)
+	}
+	return fmt.Sprintf("Method(`%s`) && PathRegexp(`%s`)", r.Verb, r.Regex)
+}
+
+// yamlString returns the YAML double-quoted string for s.
+func yamlString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s) // nolint: errcheck
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// gatewayName returns name in lower case with the characters not allowed in
+// gateway resource names replaced with dashes.
+func gatewayName(name string) string {
+	return strings.Trim(gatewayNameRegex.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// input: *gatewayData
+const gatewayKongT = `# {{ .Title }} Kong declarative configuration.
+#
+# Apply with:
+#
+#     deck gateway sync kong.yaml
+#
+# JWT and OAuth2 schemes are enforced with the jwt plugin. The plugins of the
+# routes that accept alternative security requirements must be configured
+# with an anonymous consumer.
+_format_version: "3.0"
+services:
+{{- range .Services }}
+- name: {{ yaml .Name }}
+  url: {{ yaml .URL }}
+  routes:
+	{{- range .Routes }}
+  - name: {{ yaml .Name }}
+    methods:
+    - {{ .Verb }}
+    paths:
+    - {{ yaml (kongPath .) }}
+    strip_path: false
+		{{- if or .Schemes .RateLimit }}
+    plugins:
+			{{- range .Schemes }}
+				{{- if eq .Kind "basic" }}
+    - name: basic-auth
+				{{- else if eq .Kind "apikey" }}
+    - name: key-auth
+      config:
+        key_names:
+        - {{ yaml .KeyName }}
+        key_in_header: {{ eq .In "header" }}
+        key_in_query: {{ eq .In "query" }}
+				{{- else }}
+    - name: jwt
+				{{- end }}
+			{{- end }}
+			{{- with .RateLimit }}
+    - name: rate-limiting
+      config:
+        {{ .Period }}: {{ .Count }}
+        policy: local
+			{{- end }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+`
+
+// input: *gatewayData
+const gatewayTraefikT = `# {{ .Title }} Traefik IngressRoute and middlewares.
+#
+# Apply with:
+#
+#     kubectl apply -f traefik.yaml
+#
+# The routes forward the requests to the Kubernetes services named after the
+# design services. The basicAuth secrets and forwardAuth addresses of the
+# security middlewares must be adapted to the deployment.
+apiVersion: traefik.io/v1alpha1
+kind: IngressRoute
+metadata:
+  name: {{ yaml .Name }}
+spec:
+  routes:
+{{- range .Services }}
+	{{- $svc := . }}
+	{{- range .Routes }}
+  - kind: Rule
+    match: {{ yaml (traefikRule .) }}
+		{{- if or .Schemes .RateLimit }}
+    middlewares:
+			{{- range .Schemes }}
+    - name: {{ yaml (printf "%s-auth" .Name) }}
+			{{- end }}
+			{{- if .RateLimit }}
+    - name: {{ yaml (printf "%s-ratelimit" .Name) }}
+			{{- end }}
+		{{- end }}
+    services:
+    - name: {{ yaml $svc.Name }}
+      port: {{ $svc.Port }}
+	{{- end }}
+{{- end }}
+{{- range .Schemes }}
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: {{ yaml (printf "%s-auth" .Name) }}
+spec:
+	{{- if eq .Kind "basic" }}
+  basicAuth:
+    secret: {{ yaml (printf "%s-users" .Name) }}
+	{{- else }}
+  forwardAuth:
+    address: {{ yaml (printf "http://%s-auth/verify" .Name) }}
+	{{- end }}
+{{- end }}
+{{- range .RateLimits }}
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: {{ yaml (printf "%s-ratelimit" .Name) }}
+spec:
+  rateLimit:
+    average: {{ .RateLimit.Count }}
+    period: {{ .RateLimit.Seconds }}s
+    burst: {{ .RateLimit.Count }}
+{{- end }}
+`
+
+// input: *gatewayData
+const gatewayEnvoyT = `# {{ .Title }} Envoy route configuration.
+#
+# The routes forward the requests to the clusters named after the design
+# services:
+#
+{{- range .Services }}
+#     {{ .Name }}: {{ .Host }}:{{ .Port }}
+{{- end }}
+#
+# JWT and OAuth2 schemes are enforced by the jwt_authn filter using the
+# requirement named after the scheme. The other schemes are listed in the
+# context extensions given to the ext_authz filter.
+name: {{ yaml .Name }}
+virtual_hosts:
+- name: {{ yaml .Name }}
+  domains:
+  - "*"
+  routes:
+{{- range .Services }}
+	{{- range .Routes }}
+  - name: {{ yaml .Name }}
+    match:
+		{{- if .Path }}
+      path: {{ yaml .Path }}
+		{{- else }}
+      safe_regex:
+        regex: {{ yaml .Regex }}
+		{{- end }}
+      headers:
+      - name: ":method"
+        string_match:
+          exact: {{ .Verb }}
+    route:
+      cluster: {{ yaml .Service }}
+		{{- if or .JWT .Others .RateLimit }}
+    typed_per_filter_config:
+			{{- with .JWT }}
+      envoy.filters.http.jwt_authn:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.jwt_authn.v3.PerRouteConfig
+        requirement_name: {{ yaml .Name }}
+			{{- end }}
+			{{- if .Others }}
+      envoy.filters.http.ext_authz:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute
+        check_settings:
+          context_extensions:
+            security_schemes: {{ yaml (join .Others ",") }}
+			{{- end }}
+			{{- if .RateLimit }}
+      envoy.filters.http.local_ratelimit:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit
+        stat_prefix: {{ yaml .Name }}
+        token_bucket:
+          max_tokens: {{ .RateLimit.Count }}
+          tokens_per_fill: {{ .RateLimit.Count }}
+          fill_interval: {{ .RateLimit.Seconds }}s
+        filter_enabled:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+        filter_enforced:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+			{{- end }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+`
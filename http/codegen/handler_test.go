@@ -23,6 +23,14 @@ func TestHandlerInit(t *testing.T) {
 		{"no payload result", testdata.ServerNoPayloadResultDSL, testdata.ServerNoPayloadResultHandlerConstructorCode, 2},
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode, 2},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode, 2},
+		{"long running", testdata.ServerLongRunningDSL, testdata.ServerLongRunningHandlerConstructorCode, 2},
+		{"accept ranges", testdata.ServerAcceptRangesDSL, testdata.ServerAcceptRangesHandlerConstructorCode, 2},
+		{"download", testdata.ServerDownloadDSL, testdata.ServerDownloadHandlerConstructorCode, 2},
+		{"push", testdata.ServerPushDSL, testdata.ServerPushHandlerConstructorCode, 2},
+		{"proxy", testdata.ServerProxyDSL, testdata.ServerProxyHandlerConstructorCode, 1},
+		{"proxy no option", testdata.ServerProxyNoOptionDSL, testdata.ServerProxyNoOptionHandlerConstructorCode, 1},
+		{"timeout", testdata.ServerTimeoutDSL, testdata.ServerTimeoutHandlerConstructorCode, 2},
+		{"nullable", testdata.ServerNullableDSL, testdata.ServerNullableHandlerConstructorCode, 2},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
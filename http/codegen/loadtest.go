@@ -0,0 +1,228 @@
+package codegen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+const (
+	// LoadTestK6 is the "loadtest" value that selects the k6 script.
+	LoadTestK6 = "k6"
+	// LoadTestVegeta is the "loadtest" value that selects the vegeta
+	// targets.
+	LoadTestVegeta = "vegeta"
+)
+
+type (
+	// loadTestData is the data used to render the load test scenarios.
+	loadTestData struct {
+		// Title is the API title.
+		Title string
+		// BaseURL is the default base URL of the requests.
+		BaseURL string
+		// Requests lists the requests of the scenario.
+		Requests []*loadTestRequestData
+	}
+
+	// loadTestRequestData describes a request of the load test scenario.
+	loadTestRequestData struct {
+		// Name is the request name made of the service and method names.
+		Name string
+		// Weight is the relative frequency of the request.
+		Weight int
+		// Request is the example request.
+		Request *openapi.ExampleRequest
+	}
+
+	// vegetaTarget is a target in the vegeta JSON format.
+	vegetaTarget struct {
+		Method string              `json:"method"`
+		URL    string              `json:"url"`
+		Header map[string][]string `json:"header,omitempty"`
+		Body   string              `json:"body,omitempty"`
+	}
+)
+
+// LoadTestFiles returns the load test scenarios that send the design example
+// requests to the HTTP endpoints: a k6 script in gen/http/loadtest/k6.js
+// and/or vegeta targets in gen/http/loadtest/vegeta.json. The requests are
+// weighted with the "loadtest:weight" meta of the methods, each vegeta target
+// is repeated as many times as the weight of its method. LoadTestFiles returns
+// nil if the API does not define the "loadtest" meta.
+func LoadTestFiles(root *expr.RootExpr) []*codegen.File {
+	vals, ok := root.API.Meta["loadtest"]
+	if !ok {
+		return nil
+	}
+	var k6, vegeta bool
+	for _, v := range vals {
+		k6 = k6 || v == LoadTestK6
+		vegeta = vegeta || v == LoadTestVegeta
+	}
+	if !k6 && !vegeta {
+		k6, vegeta = true, true
+	}
+	title := root.API.Title
+	if title == "" {
+		title = root.API.Name
+	}
+	data := &loadTestData{Title: title}
+	for _, svc := range root.API.HTTP.Services {
+		for _, e := range svc.HTTPEndpoints {
+			if e.MethodExpr.IsStreaming() || e.MultipartRequest || e.SkipRequestBodyEncodeDecode {
+				continue
+			}
+			weight := 1
+			if w, ok := e.MethodExpr.Meta.Last("loadtest:weight"); ok {
+				if n, err := strconv.Atoi(w); err == nil {
+					weight = n
+				}
+			}
+			if weight <= 0 {
+				continue
+			}
+			for _, r := range e.Routes {
+				req := openapi.NewExampleRequest(r)
+				if data.BaseURL == "" {
+					data.BaseURL = req.BaseURL
+				}
+				data.Requests = append(data.Requests, &loadTestRequestData{
+					Name:    svc.Name() + "." + e.Name(),
+					Weight:  weight,
+					Request: req,
+				})
+			}
+		}
+	}
+	if len(data.Requests) == 0 {
+		return nil
+	}
+	var files []*codegen.File
+	if k6 {
+		files = append(files, &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "http", "loadtest", "k6.js"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "loadtest-k6",
+				Source:  loadTestK6T,
+				Data:    data,
+				FuncMap: map[string]interface{}{"js": jsString},
+			}},
+		})
+	}
+	if vegeta {
+		var targets []string
+		for _, r := range data.Requests {
+			t := vegetaTargetJSON(r.Request)
+			for i := 0; i < r.Weight; i++ {
+				targets = append(targets, t)
+			}
+		}
+		files = append(files, &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "http", "loadtest", "vegeta.json"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:   "loadtest-vegeta",
+				Source: loadTestVegetaT,
+				Data:   targets,
+			}},
+		})
+	}
+	return files
+}
+
+// jsString returns the JavaScript string literal for s.
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// vegetaTargetJSON returns the vegeta JSON target for the given request.
+func vegetaTargetJSON(req *openapi.ExampleRequest) string {
+	t := vegetaTarget{Method: req.Method, URL: req.BaseURL + req.Path}
+	if len(req.Headers) > 0 {
+		t.Header = make(map[string][]string)
+		for _, h := range req.Headers {
+			t.Header[h[0]] = append(t.Header[h[0]], h[1])
+		}
+	}
+	if req.Body != "" {
+		t.Body = base64.StdEncoding.EncodeToString([]byte(req.Body))
+	}
+	b, _ := json.Marshal(t)
+	return string(b)
+}
+
+// input: *loadTestData
+const loadTestK6T = `// {{ .Title }} load test scenario.
+//
+// Run with:
+//
+//     k6 run -e BASE_URL={{ .BaseURL }} -e TOKEN=... k6.js
+//
+// Each iteration sends one of the requests below picked randomly according to
+// its weight. The ${NAME} placeholders are replaced with the values of the
+// corresponding environment variables.
+import http from 'k6/http';
+import { check } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || {{ js .BaseURL }};
+
+const requests = [
+{{- range .Requests }}
+	{
+		name: {{ js .Name }},
+		weight: {{ .Weight }},
+		method: {{ js .Request.Method }},
+		path: {{ js .Request.Path }},
+	{{- if .Request.Headers }}
+		headers: {
+		{{- range .Request.Headers }}
+			{{ js (index . 0) }}: {{ js (index . 1) }},
+		{{- end }}
+		},
+	{{- else }}
+		headers: {},
+	{{- end }}
+	{{- if .Request.Body }}
+		body: {{ js .Request.Body }},
+	{{- end }}
+	},
+{{- end }}
+];
+
+const totalWeight = requests.reduce((sum, req) => sum + req.weight, 0);
+
+export const options = {
+	vus: 10,
+	duration: '30s',
+};
+
+function env(s) {
+	return s.replace(/\$\{(\w+)\}/g, (_, name) => __ENV[name] || '');
+}
+
+export default function () {
+	let n = Math.random() * totalWeight;
+	const req = requests.find((r) => (n -= r.weight) < 0) || requests[requests.length - 1];
+	const headers = {};
+	for (const name in req.headers) {
+		headers[name] = env(req.headers[name]);
+	}
+	const res = http.request(req.method, BASE_URL + env(req.path), req.body ? env(req.body) : null, {
+		headers: headers,
+		tags: { name: req.name },
+	});
+	check(res, {
+		'no server error': (r) => r.status < 500,
+	});
+}
+`
+
+// input: []string
+const loadTestVegetaT = `{{ range . }}{{ . }}
+{{ end }}`
@@ -0,0 +1,45 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestLoadTestFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected map[string]string
+	}{
+		{"no-loadtest", testdata.SimpleDSL, nil},
+		{"loadtest", testdata.LoadTestDSL, map[string]string{"k6.js": testdata.LoadTestK6Code, "vegeta.json": testdata.LoadTestVegetaCode}},
+		{"k6", testdata.LoadTestK6DSL, map[string]string{"k6.js": testdata.LoadTestK6OnlyCode}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			fs := LoadTestFiles(root)
+			if len(fs) != len(c.Expected) {
+				t.Fatalf("got %d files, expected %d", len(fs), len(c.Expected))
+			}
+			for _, f := range fs {
+				expected, ok := c.Expected[filepath.Base(f.Path)]
+				if !ok {
+					t.Fatalf("unexpected file %q", f.Path)
+				}
+				var buf bytes.Buffer
+				if err := f.SectionTemplates[0].Write(&buf); err != nil {
+					t.Fatal(err)
+				}
+				code := buf.String()
+				if code != expected {
+					t.Errorf("%s: invalid code, got:\n%s\ngot vs. expected:\n%s", filepath.Base(f.Path), code, codegen.Diff(t, code, expected))
+				}
+			}
+		})
+	}
+}
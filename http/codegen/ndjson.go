@@ -0,0 +1,23 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/expr"
+)
+
+// ndjsonContentType is the content type of newline-delimited JSON.
+const ndjsonContentType = "application/x-ndjson"
+
+// ndjsonBody returns true if the given request or response body is a
+// collection and the given content types include newline-delimited JSON. The
+// bodies wrapped in an envelope are always encoded as a whole.
+func ndjsonBody(body *expr.AttributeExpr, contentTypes []string, envKey string) bool {
+	if envKey != "" || body == nil || !expr.IsArray(body.Type) {
+		return false
+	}
+	for _, ct := range contentTypes {
+		if ct == ndjsonContentType {
+			return true
+		}
+	}
+	return false
+}
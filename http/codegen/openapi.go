@@ -8,6 +8,8 @@ import (
 )
 
 // OpenAPIFiles returns the files for the OpenAPIFile spec of the given HTTP API.
+// It also returns the request snippets file if the API defines the
+// "http:snippets" meta.
 func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 	// Only create a OpenAPI specification if there are HTTP services.
 	if len(root.API.HTTP.Services) == 0 {
@@ -30,5 +32,8 @@ func OpenAPIFiles(root *expr.RootExpr) ([]*codegen.File, error) {
 		}
 		files = append(files, fs...)
 	}
+	if f := SnippetsFile(root); f != nil {
+		files = append(files, f)
+	}
 	return files, nil
 }
@@ -24,6 +24,21 @@ func ExtensionsFromExpr(mdata expr.MetaExpr) map[string]interface{} {
 	return swag
 }
 
+// EnumNamesExtension adds the "x-enum-varnames" extension listing the enum
+// value names set with EnumNames in the given meta expression to exts. It
+// returns the resulting extensions.
+func EnumNamesExtension(exts map[string]interface{}, mdata expr.MetaExpr) map[string]interface{} {
+	names, ok := mdata["enum:names"]
+	if !ok {
+		return exts
+	}
+	if exts == nil {
+		exts = make(map[string]interface{})
+	}
+	exts["x-enum-varnames"] = names
+	return exts
+}
+
 // extensionsFromExprWithPrefix generates openapi extensions from
 // the given meta expression with keys starting the given prefix.
 func extensionsFromExprWithPrefix(mdata expr.MetaExpr, prefix string) map[string]interface{} {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -28,6 +29,7 @@ type (
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
 		ReadOnly  bool    `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`
+		Nullable  bool    `json:"nullable,omitempty" yaml:"nullable,omitempty"`
 		PathStart string  `json:"pathStart,omitempty" yaml:"pathStart,omitempty"`
 		Links     []*Link `json:"links,omitempty" yaml:"links,omitempty"`
 		Ref       string  `json:"$ref,omitempty" yaml:"$ref,omitempty"`
@@ -44,11 +46,15 @@ type (
 		MaxLength            *int          `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
 		MinItems             *int          `json:"minItems,omitempty" yaml:"minItems,omitempty"`
 		MaxItems             *int          `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+		UniqueItems          bool          `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
 		Required             []string      `json:"required,omitempty" yaml:"required,omitempty"`
 		AdditionalProperties interface{}   `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 
 		// Union
-		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+		AllOf         []*Schema      `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+		AnyOf         []*Schema      `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+		OneOf         []*Schema      `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+		Discriminator *Discriminator `json:"discriminator,omitempty" yaml:"discriminator,omitempty"`
 
 		// Extensions defines the OpenAPI extensions.
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
@@ -63,6 +69,17 @@ type (
 		Type           string `json:"type,omitempty" yaml:"type,omitempty"`
 	}
 
+	// Discriminator describes the property used to tell apart the schemas
+	// of a "oneOf" union.
+	Discriminator struct {
+		// PropertyName is the name of the property that holds the
+		// discriminator value.
+		PropertyName string `json:"propertyName" yaml:"propertyName"`
+		// Mapping maps the discriminator values to the corresponding
+		// schema references.
+		Mapping map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	}
+
 	// Link represents a "link" field in a JSON hyper schema.
 	Link struct {
 		Title        string  `json:"title,omitempty" yaml:"title,omitempty"`
@@ -323,6 +340,21 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 		case expr.BytesKind:
 			s.Type = Type("string")
 			s.Format = "byte"
+		case expr.DateTimeKind:
+			s.Type = Type("string")
+			s.Format = "date-time"
+		case expr.DateKind:
+			s.Type = Type("string")
+			s.Format = "date"
+		case expr.DurationKind:
+			s.Type = Type("integer")
+			s.Format = "int64"
+		case expr.UUIDKind:
+			s.Type = Type("string")
+			s.Format = "uuid"
+		case expr.DecimalKind:
+			s.Type = Type("string")
+			s.Format = "decimal"
 		}
 	case *expr.Array:
 		s.Type = Array
@@ -330,10 +362,11 @@ func TypeSchemaWithPrefix(api *expr.APIExpr, t expr.DataType, prefix string) *Sc
 		buildAttributeSchema(api, s.Items, actual.ElemType)
 	case *expr.Object:
 		s.Type = Object
+		obj := &expr.AttributeExpr{Type: actual}
 		for _, nat := range *actual {
 			prop := NewSchema()
 			buildAttributeSchema(api, prop, nat.Attribute)
-			s.Properties[nat.Name] = prop
+			s.Properties[obj.WireName(nat.Name)] = prop
 		}
 	case *expr.Map:
 		s.Type = Object
@@ -428,6 +461,7 @@ func (s *Schema) Dup() *Schema {
 		Title:                s.Title,
 		Media:                s.Media,
 		ReadOnly:             s.ReadOnly,
+		Nullable:             s.Nullable,
 		PathStart:            s.PathStart,
 		Links:                s.Links,
 		Ref:                  s.Ref,
@@ -440,6 +474,7 @@ func (s *Schema) Dup() *Schema {
 		MaxLength:            s.MaxLength,
 		MinItems:             s.MinItems,
 		MaxItems:             s.MaxItems,
+		UniqueItems:          s.UniqueItems,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
 	}
@@ -465,13 +500,84 @@ func buildAttributeSchema(api *expr.APIExpr, s *Schema, at *expr.AttributeExpr)
 	}
 	s.DefaultValue = ToStringMap(at.DefaultValue)
 	s.Description = at.Description
-	s.Example = at.Example(api.Random())
-	s.Extensions = ExtensionsFromExpr(at.Meta)
+	if note := FieldGroupsNote(at.Validation); note != "" {
+		if s.Description != "" {
+			s.Description += "\n"
+		}
+		s.Description += note
+	}
+	if note := ComputedNote(at); note != "" {
+		if s.Description != "" {
+			s.Description += "\n"
+		}
+		s.Description += note
+		s.ReadOnly = true
+	}
+	s.Example = at.WireExample(at.Example(api.Random()))
+	s.Extensions = EnumNamesExtension(ExtensionsFromExpr(at.Meta), at.Meta)
 	initAttributeValidation(s, at)
 
 	return s
 }
 
+// FieldGroupsNote returns a description of the RequiredTogether and Mutex
+// validations, the empty string if there are none.
+func FieldGroupsNote(val *expr.ValidationExpr) string {
+	if val == nil {
+		return ""
+	}
+	quote := func(names []string) string {
+		q := make([]string, len(names))
+		for i, n := range names {
+			q[i] = fmt.Sprintf("%q", n)
+		}
+		return strings.Join(q[:len(q)-1], ", ") + " and " + q[len(q)-1]
+	}
+	var notes []string
+	for _, g := range val.RequiredTogether {
+		notes = append(notes, fmt.Sprintf("%s must be set together.", quote(g)))
+	}
+	for _, g := range val.Mutex {
+		notes = append(notes, fmt.Sprintf("At most one of %s may be set.", quote(g)))
+	}
+	return strings.Join(notes, "\n")
+}
+
+// ComputedNote returns a description of the attributes a computed attribute is
+// derived from, the empty string if the attribute is not computed.
+func ComputedNote(at *expr.AttributeExpr) string {
+	from, ok := at.ComputedFrom()
+	if !ok || len(from) == 0 {
+		return ""
+	}
+	q := make([]string, len(from))
+	for i, n := range from {
+		q[i] = fmt.Sprintf("%q", n)
+	}
+	if len(q) == 1 {
+		return fmt.Sprintf("Computed from %s.", q[0])
+	}
+	return fmt.Sprintf("Computed from %s and %s.", strings.Join(q[:len(q)-1], ", "), q[len(q)-1])
+}
+
+// EnvelopeSchema returns the schema of the response envelope env wrapping a
+// response body with the given schema under key. attSchema returns the schemas
+// of the other envelope attributes.
+func EnvelopeSchema(env *expr.AttributeExpr, key string, body *Schema, attSchema func(*expr.AttributeExpr) *Schema) *Schema {
+	s := NewSchema()
+	s.Type = Object
+	s.Description = env.Description
+	s.Properties[key] = body
+	s.Required = []string{key}
+	for _, nat := range *expr.AsObject(env.Type) {
+		if nat.Name == "data" || nat.Name == "errors" {
+			continue
+		}
+		s.Properties[nat.Name] = attSchema(nat.Attribute)
+	}
+	return s
+}
+
 // initAttributeValidation initializes validation rules for an attribute.
 func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 	val := at.Validation
@@ -507,7 +613,8 @@ func initAttributeValidation(s *Schema, at *expr.AttributeExpr) {
 			s.MaxLength = val.MaxLength
 		}
 	}
-	s.Required = val.Required
+	s.UniqueItems = val.UniqueItems
+	s.Required = at.WireNames(val.Required)
 }
 
 // toSchemaHrefs produces hrefs that replace the path wildcards with JSON
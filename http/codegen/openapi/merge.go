@@ -51,6 +51,7 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.Title, other.Title, s.Title == ""},
 		{&s.Media, other.Media, s.Media == nil},
 		{&s.ReadOnly, other.ReadOnly, !s.ReadOnly},
+		{&s.Nullable, other.Nullable, !s.Nullable},
 		{&s.PathStart, other.PathStart, s.PathStart == ""},
 		{&s.Enum, other.Enum, s.Enum == nil},
 		{&s.Format, other.Format, s.Format == ""},
@@ -62,5 +63,6 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.MaxLength, other.MaxLength, maxInt(s.MaxLength, other.MaxLength)},
 		{&s.MinItems, other.MinItems, minInt(s.MinItems, other.MinItems)},
 		{&s.MaxItems, other.MaxItems, maxInt(s.MaxItems, other.MaxItems)},
+		{&s.UniqueItems, other.UniqueItems, !s.UniqueItems},
 	}
 }
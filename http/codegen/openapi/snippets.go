@@ -0,0 +1,402 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// SnippetCurl is the "http:snippets" value that selects curl snippets.
+	SnippetCurl = "curl"
+	// SnippetHTTPie is the "http:snippets" value that selects HTTPie
+	// snippets.
+	SnippetHTTPie = "httpie"
+)
+
+type (
+	// Snippet is a command line that sends an example request to a HTTP
+	// endpoint.
+	Snippet struct {
+		// Tool is the command line tool used by the snippet, either
+		// SnippetCurl or SnippetHTTPie.
+		Tool string
+		// Source is the command line.
+		Source string
+	}
+
+	// ExampleRequest is an example request sent to a HTTP route built from
+	// the design examples.
+	ExampleRequest struct {
+		// Method is the request HTTP method.
+		Method string
+		// BaseURL is the first HTTP URI of the servers that expose the
+		// service.
+		BaseURL string
+		// Path is the request path and query string.
+		Path string
+		// Headers lists the request header names and values including
+		// the cookies and the content type.
+		Headers [][2]string
+		// Body is the JSON encoded request body, empty if the request
+		// does not have a body.
+		Body string
+	}
+
+	// snippetRequest describes the example request rendered by a snippet.
+	// The names and values are escaped for use in double quoted shell
+	// strings, see shellEscape.
+	snippetRequest struct {
+		method  string
+		base    string
+		path    string
+		query   [][2]string
+		headers [][2]string
+		cookies []string
+		user    string
+		body    string
+	}
+)
+
+// SnippetTools returns the command line tools listed by the "http:snippets"
+// meta of the API. SnippetTools returns both SnippetCurl and SnippetHTTPie if
+// the meta does not list any known tool and nil if the API does not define the
+// meta.
+func SnippetTools(api *expr.APIExpr) []string {
+	vals, ok := api.Meta["http:snippets"]
+	if !ok {
+		return nil
+	}
+	var tools []string
+	for _, t := range []string{SnippetCurl, SnippetHTTPie} {
+		for _, v := range vals {
+			if v == t {
+				tools = append(tools, t)
+				break
+			}
+		}
+	}
+	if len(tools) == 0 {
+		tools = []string{SnippetCurl, SnippetHTTPie}
+	}
+	return tools
+}
+
+// Snippets returns the command lines that send an example request to the
+// given route using the given tools. The command lines use the first HTTP
+// URI of the API servers, the design examples of the request parameters,
+// headers, cookies and body and shell variables for the credentials:
+// $USERNAME and $PASSWORD for basic auth, $API_KEY for API keys, $TOKEN for
+// JWTs and $ACCESS_TOKEN for OAuth2 access tokens. The random examples are
+// seeded with the route so that the snippets of a route do not depend on the
+// order in which they are generated.
+func Snippets(tools []string, r *expr.RouteExpr) []*Snippet {
+	req := newSnippetRequest(r, snippetRandom(r), func(v string) string { return "$" + v }, shellEscape)
+	snippets := make([]*Snippet, len(tools))
+	for i, t := range tools {
+		src := req.curl()
+		if t == SnippetHTTPie {
+			src = req.httpie()
+		}
+		snippets[i] = &Snippet{Tool: t, Source: src}
+	}
+	return snippets
+}
+
+// NewExampleRequest returns the example request sent to the given route. The
+// request uses the same design examples as the snippets. The credentials are
+// represented by the placeholders ${API_KEY}, ${TOKEN} and ${ACCESS_TOKEN}
+// meant to be substituted by the tools that send the request. Basic auth
+// credentials are not included.
+func NewExampleRequest(r *expr.RouteExpr) *ExampleRequest {
+	req := newSnippetRequest(r, snippetRandom(r), func(v string) string { return "${" + v + "}" }, noEscape)
+	path := req.path
+	for i, q := range req.query {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		path += sep + q[0] + "=" + q[1]
+	}
+	headers := req.headers
+	if len(req.cookies) > 0 {
+		headers = append(headers, [2]string{"Cookie", strings.Join(req.cookies, "; ")})
+	}
+	if req.body != "" {
+		headers = append(headers, [2]string{"Content-Type", "application/json"})
+	}
+	return &ExampleRequest{
+		Method:  req.method,
+		BaseURL: req.base,
+		Path:    path,
+		Headers: headers,
+		Body:    req.body,
+	}
+}
+
+// CodeSamplesExtension adds the "x-codeSamples" extension listing the given
+// snippets to exts. It returns the resulting extensions. ReDoc renders the
+// extension in the operation documentation.
+func CodeSamplesExtension(exts map[string]interface{}, snippets []*Snippet) map[string]interface{} {
+	if len(snippets) == 0 {
+		return exts
+	}
+	samples := make([]map[string]interface{}, len(snippets))
+	for i, s := range snippets {
+		label := "curl"
+		if s.Tool == SnippetHTTPie {
+			label = "HTTPie"
+		}
+		samples[i] = map[string]interface{}{"lang": "Shell", "label": label, "source": s.Source}
+	}
+	if exts == nil {
+		exts = make(map[string]interface{})
+	}
+	exts["x-codeSamples"] = samples
+	return exts
+}
+
+// snippetRandom returns the random generator used to compute the examples of
+// the given route. It is seeded with the route so that the examples do not
+// depend on the order in which the routes are processed.
+func snippetRandom(r *expr.RouteExpr) *expr.Random {
+	return expr.NewRandom(fmt.Sprintf("%s#%s %s %s", r.Endpoint.Service.Name(), r.Endpoint.Name(), r.Method, r.Path))
+}
+
+// newSnippetRequest computes the example request sent to the given route.
+// variable returns the reference to the variable with the given name that
+// holds credentials and esc escapes the names and values.
+func newSnippetRequest(r *expr.RouteExpr, rand *expr.Random, variable func(string) string, esc func(string) string) *snippetRequest {
+	e := r.Endpoint
+	creds := snippetCredentials(e, variable)
+	// value returns the escaped example values of the attribute or the
+	// corresponding credentials variable.
+	value := func(name string, att *expr.AttributeExpr, escape func(string) string) []string {
+		if c, ok := creds[name]; ok {
+			return []string{c}
+		}
+		vals := snippetValues(att.Example(rand))
+		for i, v := range vals {
+			vals[i] = esc(escape(v))
+		}
+		return vals
+	}
+
+	var (
+		req       = &snippetRequest{method: r.Method}
+		path      = r.FullPaths()[0]
+		wildcards = expr.ExtractHTTPWildcards(path)
+	)
+	codegen.WalkMappedAttr(e.Params, func(name, elem string, _ bool, att *expr.AttributeExpr) error {
+		for _, w := range wildcards {
+			if w == name {
+				var v string
+				if vals := value(name, att, url.PathEscape); len(vals) > 0 {
+					v = vals[0]
+				}
+				path = expr.HTTPWildcardRegex.ReplaceAllStringFunc(path, func(s string) string {
+					if strings.TrimLeft(s[2:len(s)-1], "*") == w {
+						return "/" + v
+					}
+					return s
+				})
+				return nil
+			}
+		}
+		for _, v := range value(name, att, url.QueryEscape) {
+			req.query = append(req.query, [2]string{esc(url.QueryEscape(elem)), v})
+		}
+		return nil
+	})
+	req.base = esc(ServiceBaseURL(e.Service.ServiceExpr))
+	req.path = path
+
+	expr.WalkMappedAttr(e.Headers, func(name, elem string, att *expr.AttributeExpr) error {
+		for _, v := range value(name, att, noEscape) {
+			req.headers = append(req.headers, [2]string{esc(elem), v})
+		}
+		return nil
+	})
+	expr.WalkMappedAttr(e.Cookies, func(name, elem string, att *expr.AttributeExpr) error {
+		if vals := value(name, att, noEscape); len(vals) > 0 {
+			req.cookies = append(req.cookies, esc(elem)+"="+vals[0])
+		}
+		return nil
+	})
+	for _, sec := range e.Requirements {
+		for _, sch := range sec.Schemes {
+			if sch.Kind == expr.BasicAuthKind {
+				req.user = variable("USERNAME") + ":" + variable("PASSWORD")
+			}
+		}
+	}
+
+	if e.Body.Type != expr.Empty && !e.MultipartRequest && !e.SkipRequestBodyEncodeDecode {
+		if b, err := json.Marshal(ToStringMap(e.Body.WireExample(e.Body.Example(rand)))); err == nil {
+			req.body = string(b)
+		}
+	}
+	return req
+}
+
+// curl renders the request as a curl command line.
+func (r *snippetRequest) curl() string {
+	args := []string{"curl"}
+	if r.method != "GET" || r.body != "" {
+		args[0] += " -X " + r.method
+	}
+	u := r.base + r.path
+	for i, q := range r.query {
+		sep := "&"
+		if i == 0 {
+			sep = "?"
+		}
+		u += sep + q[0] + "=" + q[1]
+	}
+	args[0] += " " + shellQuote(u)
+	if r.user != "" {
+		args = append(args, "-u "+shellQuote(r.user))
+	}
+	for _, h := range r.headers {
+		args = append(args, "-H "+shellQuote(h[0]+": "+h[1]))
+	}
+	if len(r.cookies) > 0 {
+		args = append(args, "-b "+shellQuote(strings.Join(r.cookies, "; ")))
+	}
+	if r.body != "" {
+		args = append(args, "-H "+shellQuote("Content-Type: application/json"))
+		args = append(args, "-d "+shellQuoteRaw(r.body))
+	}
+	return strings.Join(args, " \\\n  ")
+}
+
+// httpie renders the request as a HTTPie command line.
+func (r *snippetRequest) httpie() string {
+	args := []string{"http " + r.method + " " + shellQuote(r.base+r.path)}
+	if r.user != "" {
+		args = append(args, "-a "+shellQuote(r.user))
+	}
+	for _, q := range r.query {
+		args = append(args, shellQuote(q[0]+"=="+q[1]))
+	}
+	for _, h := range r.headers {
+		args = append(args, shellQuote(h[0]+":"+h[1]))
+	}
+	if len(r.cookies) > 0 {
+		args = append(args, shellQuote("Cookie:"+strings.Join(r.cookies, "; ")))
+	}
+	if r.body != "" {
+		args = append(args, "--raw "+shellQuoteRaw(r.body))
+	}
+	return strings.Join(args, " \\\n  ")
+}
+
+// snippetCredentials returns the references to the variables that hold the
+// credentials of the payload attributes indexed by attribute name. variable
+// returns the reference to the variable with the given name.
+func snippetCredentials(e *expr.HTTPEndpointExpr, variable func(string) string) map[string]string {
+	creds := make(map[string]string)
+	p := e.MethodExpr.Payload
+	if p == nil {
+		return creds
+	}
+	if n := expr.TaggedAttribute(p, "security:username"); n != "" {
+		creds[n] = variable("USERNAME")
+	}
+	if n := expr.TaggedAttribute(p, "security:password"); n != "" {
+		creds[n] = variable("PASSWORD")
+	}
+	for _, sec := range e.Requirements {
+		for _, sch := range sec.Schemes {
+			var n, v string
+			switch sch.Kind {
+			case expr.APIKeyKind:
+				n, v = expr.TaggedAttribute(p, "security:apikey:"+sch.SchemeName), variable("API_KEY")
+			case expr.JWTKind:
+				n, v = expr.TaggedAttribute(p, "security:token"), variable("TOKEN")
+			case expr.OAuth2Kind:
+				n, v = expr.TaggedAttribute(p, "security:accesstoken"), variable("ACCESS_TOKEN")
+			default:
+				continue
+			}
+			if n == "" {
+				continue
+			}
+			if sch.In == "header" && sch.Name == "Authorization" && sch.Kind != expr.APIKeyKind {
+				v = "Bearer " + v
+			}
+			creds[n] = v
+		}
+	}
+	return creds
+}
+
+// ServiceBaseURL returns the first HTTP URI of the servers that expose the
+// given service, "http://localhost" if there is none.
+func ServiceBaseURL(svc *expr.ServiceExpr) string {
+	for _, s := range expr.Root.API.Servers {
+		var found bool
+		for _, n := range s.Services {
+			if n == svc.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		for _, h := range s.Hosts {
+			for _, u := range h.URIs {
+				if sch := u.Scheme(); sch != "http" && sch != "https" {
+					continue
+				}
+				if uri, err := h.URIString(u); err == nil {
+					return strings.TrimSuffix(uri, "/")
+				}
+			}
+		}
+	}
+	return "http://localhost"
+}
+
+// snippetValues returns the string representations of the given example
+// value, one per element if the value is an array.
+func snippetValues(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return []string{fmt.Sprintf("%v", v)}
+	}
+	vals := make([]string, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		vals[i] = fmt.Sprintf("%v", rv.Index(i).Interface())
+	}
+	return vals
+}
+
+// shellEscape escapes the characters of s that are special in double quoted
+// shell strings.
+func shellEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(s)
+}
+
+// noEscape returns s.
+func noEscape(s string) string { return s }
+
+// shellQuote returns s in double quotes so that the credentials shell
+// variables it contains are expanded. s must be escaped with shellEscape.
+func shellQuote(s string) string {
+	return `"` + s + `"`
+}
+
+// shellQuoteRaw returns s in single quotes so that it is passed verbatim.
+func shellQuoteRaw(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
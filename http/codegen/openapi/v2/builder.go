@@ -152,6 +152,10 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 		for _, e := range svc.HTTPEndpoints {
 			for _, req := range e.Requirements {
 				for _, s := range req.Schemes {
+					if s.Kind == expr.MutualTLSKind {
+						// OpenAPI 2.0 cannot describe mutual TLS schemes
+						continue
+					}
 					sd := SecurityDefinition{
 						Description: s.Description,
 						Extensions:  openapi.ExtensionsFromExpr(s.Meta),
@@ -173,6 +177,12 @@ func securitySpecFromExpr(root *expr.RootExpr) map[string]*SecurityDefinition {
 						addScopeDescription(s.Scopes, &sd)
 						sd.In = s.In
 						sd.Name = s.Name
+					case expr.SignatureKind:
+						// OpenAPI V2 spec does not support signature schemes,
+						// the signature is carried in the Authorization header.
+						sd.Type = "apiKey"
+						sd.In = s.In
+						sd.Name = s.Name
 					case expr.OAuth2Kind:
 						sd.Type = "oauth2"
 						if scopesLen := len(s.Scopes); scopesLen > 0 {
@@ -283,6 +293,15 @@ func paramsFromExpr(params *expr.MappedAttributeExpr, path string) []*Parameter
 				break
 			}
 		}
+		if in == "query" && expr.IsObject(at.Type) {
+			// OpenAPI v2 does not support object parameters, describe each
+			// field with its deepObject key instead, e.g. "filter[name]".
+			for _, nat := range *expr.AsObject(at.Type) {
+				name := fmt.Sprintf("%s[%s]", pn, at.WireName(nat.Name))
+				res = append(res, paramFor(nat.Attribute, name, in, required && at.IsRequired(nat.Name)))
+			}
+			return nil
+		}
 		param := paramFor(at, pn, in, required)
 		res = append(res, param)
 		return nil
@@ -348,6 +367,9 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	if expr.IsArray(at.Type) {
 		p.Items = itemsFromExpr(expr.AsArray(at.Type).ElemType)
 		p.CollectionFormat = "multi"
+		if style, ok := alias.Meta.Last("http:query:style"); ok && in == "query" && style == "csv" {
+			p.CollectionFormat = "csv"
+		}
 	}
 	switch at.Type {
 	case expr.Int, expr.UInt, expr.UInt32, expr.UInt64:
@@ -364,8 +386,23 @@ func paramFor(at *expr.AttributeExpr, name, in string, required bool) *Parameter
 	case expr.Bytes:
 		p.Type = "string"
 		p.Format = "byte"
+	case expr.DateTime:
+		p.Type = "string"
+		p.Format = "date-time"
+	case expr.Date:
+		p.Type = "string"
+		p.Format = "date"
+	case expr.Duration:
+		p.Type = "integer"
+		p.Format = "int64"
+	case expr.UUID:
+		p.Type = "string"
+		p.Format = "uuid"
+	case expr.Decimal:
+		p.Type = "string"
+		p.Format = "decimal"
 	}
-	p.Extensions = openapi.ExtensionsFromExpr(at.Meta)
+	p.Extensions = openapi.EnumNamesExtension(openapi.ExtensionsFromExpr(at.Meta), at.Meta)
 	initValidations(alias, p)
 	return p
 }
@@ -381,6 +418,21 @@ func itemsFromExpr(at *expr.AttributeExpr) *Items {
 			items.Type = "number"
 		case expr.BytesKind:
 			items.Type = "string"
+		case expr.DateTimeKind:
+			items.Type = "string"
+			items.Format = "date-time"
+		case expr.DateKind:
+			items.Type = "string"
+			items.Format = "date"
+		case expr.DurationKind:
+			items.Type = "integer"
+			items.Format = "int64"
+		case expr.UUIDKind:
+			items.Type = "string"
+			items.Format = "uuid"
+		case expr.DecimalKind:
+			items.Type = "string"
+			items.Format = "decimal"
 		}
 	}
 	initValidations(at, items)
@@ -410,14 +462,41 @@ func responseSpecFromExpr(s *V2, root *expr.RootExpr, r *expr.HTTPResponseExpr,
 	if desc == "" {
 		desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
 	}
+	var examples map[string]interface{}
+	if len(r.Examples) > 0 {
+		ct := r.ContentType
+		if mt, ok := r.Body.Type.(*expr.ResultTypeExpr); ok && ct == "" {
+			ct = mt.ContentType
+		}
+		if ct == "" {
+			ct = "application/json"
+		}
+		examples = map[string]interface{}{ct: r.Examples[0].Value}
+	}
 	return &Response{
 		Description: desc,
 		Schema:      schema,
 		Headers:     headers,
+		Examples:    examples,
 		Extensions:  openapi.ExtensionsFromExpr(r.Meta),
 	}
 }
 
+// envelopeResponse wraps the response schema and examples in the API response
+// envelope under the given key if the envelope defines it.
+func envelopeResponse(root *expr.RootExpr, resp *Response, key, typeNamePrefix string) {
+	env := root.API.HTTP.Envelope
+	if env == nil || resp.Schema == nil || env.Find(key) == nil {
+		return
+	}
+	resp.Schema = openapi.EnvelopeSchema(env, key, resp.Schema, func(att *expr.AttributeExpr) *openapi.Schema {
+		return openapi.AttributeTypeSchemaWithPrefix(root.API, att, typeNamePrefix)
+	})
+	for ct, ex := range resp.Examples {
+		resp.Examples[ct] = map[string]interface{}{key: ex}
+	}
+}
+
 func headersFromExpr(headers *expr.MappedAttributeExpr) map[string]*Header {
 	if headers == nil {
 		return nil
@@ -508,6 +587,12 @@ func buildPathFromFileServer(s *V2, root *expr.RootExpr, fs *expr.HTTPFileServer
 }
 
 func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr.RouteExpr, basePath string) {
+	switch route.Method {
+	case "GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH":
+	default:
+		// OpenAPI v2 cannot describe other HTTP methods
+		return
+	}
 	endpoint := route.Endpoint
 
 	tagNames := openapi.TagNamesFromExpr(endpoint.Service.Meta, endpoint.Meta)
@@ -523,8 +608,9 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		params = append(params, paramsFromHeaders(endpoint)...)
 		produces := []string{}
 		responses := make(map[string]*Response, len(endpoint.Responses))
+		websocket := endpoint.MethodExpr.IsStreaming() && !endpoint.StreamRequestBody
 		for _, r := range endpoint.Responses {
-			if endpoint.MethodExpr.IsStreaming() {
+			if websocket {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -534,6 +620,9 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 				}
 			}
 			resp := responseSpecFromExpr(s, root, r, endpoint.Service.Name())
+			if !websocket {
+				envelopeResponse(root, resp, "data", endpoint.Service.Name())
+			}
 			responses[strconv.Itoa(r.StatusCode)] = resp
 			if r.ContentType != "" {
 				foundCT := false
@@ -550,12 +639,15 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 		}
 		for _, er := range endpoint.HTTPErrors {
 			resp := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
+			envelopeResponse(root, resp, "errors", endpoint.Service.Name())
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
 
 		var consumes []string
 		if endpoint.MultipartRequest {
 			consumes = []string{"multipart/form-data"}
+		} else if len(endpoint.Consumes) > 0 && !sameMimeTypes(endpoint.Consumes, root.API.HTTP.Consumes) {
+			consumes = endpoint.Consumes
 		}
 
 		if endpoint.Body.Type != expr.Empty {
@@ -610,10 +702,13 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 
 		description := endpoint.Description()
 
-		requirements := make([]map[string][]string, len(endpoint.Requirements))
-		for i, req := range endpoint.Requirements {
+		requirements := make([]map[string][]string, 0, len(endpoint.Requirements))
+		for _, req := range endpoint.Requirements {
 			requirement := make(map[string][]string)
 			for _, s := range req.Schemes {
+				if s.Kind == expr.MutualTLSKind {
+					continue
+				}
 				requirement[s.Hash()] = []string{}
 				switch s.Kind {
 				case expr.OAuth2Kind:
@@ -632,7 +727,11 @@ func buildPathFromExpr(s *V2, root *expr.RootExpr, h *expr.HostExpr, route *expr
 					}
 				}
 			}
-			requirements[i] = requirement
+			if len(requirement) == 0 {
+				// requirement only lists schemes that cannot be described
+				continue
+			}
+			requirements = append(requirements, requirement)
 		}
 
 		operation := &Operation{
@@ -809,13 +908,26 @@ func initMaxLengthValidation(def interface{}, isArray bool, max *int) {
 	}
 }
 
+func initUniqueItemsValidation(def interface{}) {
+	switch actual := def.(type) {
+	case *Parameter:
+		actual.UniqueItems = true
+	case *Header:
+		actual.UniqueItems = true
+	case *Items:
+		actual.UniqueItems = true
+	}
+}
+
 func initValidations(attr *expr.AttributeExpr, def interface{}) {
 	val := attr.Validation
 	if val == nil {
 		return
 	}
 	initEnumValidation(def, val.Values)
-	initFormatValidation(def, string(val.Format))
+	if val.Format != "" {
+		initFormatValidation(def, string(val.Format))
+	}
 	initPatternValidation(def, val.Pattern)
 	if val.ExclusiveMinimum != nil {
 		initExclusiveMinimumValidation(def, val.ExclusiveMinimum)
@@ -835,4 +947,20 @@ func initValidations(attr *expr.AttributeExpr, def interface{}) {
 	if val.MaxLength != nil {
 		initMaxLengthValidation(def, expr.IsArray(attr.Type), val.MaxLength)
 	}
+	if val.UniqueItems {
+		initUniqueItemsValidation(def)
+	}
+}
+
+// sameMimeTypes returns true if a and b list the same mime types.
+func sameMimeTypes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, mt := range a {
+		if mt != b[i] {
+			return false
+		}
+	}
+	return true
 }
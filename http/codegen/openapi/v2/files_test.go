@@ -34,6 +34,19 @@ func TestSections(t *testing.T) {
 		{"valid", testdata.SimpleDSL},
 		{"multiple-services", testdata.MultipleServicesDSL},
 		{"multiple-views", testdata.MultipleViewsDSL},
+		{"response-example", testdata.ResponseExampleDSL},
+		{"request-example", testdata.RequestExampleDSL},
+		{"query-style", testdata.QueryStyleDSL},
+		{"query-object", testdata.QueryObjectDSL},
+		{"consumes", testdata.ConsumesDSL},
+		{"named-inline-object", testdata.NamedInlineObjectDSL},
+		{"unique-array-validation", testdata.UniqueArrayValidationDSL},
+		{"time-types", testdata.TimeTypesDSL},
+		{"decimal", testdata.DecimalDSL},
+		{"int-enum", testdata.IntEnumDSL},
+		{"field-groups", testdata.FieldGroupsDSL},
+		{"envelope", testdata.EnvelopeDSL},
+		{"server-multiple-hosts", testdata.ServerMultipleHostsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
 		{"security", testdata.SecurityDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
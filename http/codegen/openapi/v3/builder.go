@@ -89,6 +89,10 @@ func buildComponents(root *expr.RootExpr, types map[string]*openapi.Schema) *Com
 		}
 		schemesRef = make(map[string]*SecuritySchemeRef, len(schemes))
 		for _, se := range schemes {
+			if se.Kind == expr.MutualTLSKind {
+				// OpenAPI 3.0 cannot describe mutual TLS schemes
+				continue
+			}
 			schemesRef[se.Hash()] = &SecuritySchemeRef{
 				Value: buildSecurityScheme(se),
 			}
@@ -119,6 +123,12 @@ func buildPaths(h *expr.HTTPExpr, bodies map[string]map[string]*EndpointBodies,
 			}
 
 			for _, r := range e.Routes {
+				switch r.Method {
+				case "GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE":
+				default:
+					// OpenAPI v3 cannot describe other HTTP methods
+					continue
+				}
 				for _, key := range r.FullPaths() {
 					// Remove any wildcards that is defined in path as a workaround to
 					// https://github.com/OAI/OpenAPI-Specification/issues/291
@@ -144,6 +154,8 @@ func buildPaths(h *expr.HTTPExpr, bodies map[string]map[string]*EndpointBodies,
 						path.Head = operation
 					case "PATCH":
 						path.Patch = operation
+					case "TRACE":
+						path.Trace = operation
 					}
 					path.Extensions = openapi.ExtensionsFromExpr(r.Endpoint.Meta)
 					if len(exts) > 0 {
@@ -223,16 +235,22 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	// request body
 	var requestBody *RequestBodyRef
 	if e.Body.Type != expr.Empty {
-		ct := "application/json" // TBD: need a way to specify method media type in design...
+		cts := []string{"application/json"}
 		if e.MultipartRequest {
-			ct = "multipart/form-data"
+			cts = []string{"multipart/form-data"}
+		} else if len(e.Consumes) > 0 {
+			cts = e.Consumes
 		}
 		mt := &MediaType{Schema: bodies.RequestBody}
 		initExamples(mt, e.Body, rand)
+		content := make(map[string]*MediaType, len(cts))
+		for _, ct := range cts {
+			content[ct] = mt
+		}
 		requestBody = &RequestBodyRef{Value: &RequestBody{
 			Description: e.Body.Description,
 			Required:    e.Body.Type != expr.Empty,
-			Content:     map[string]*MediaType{ct: mt},
+			Content:     content,
 			Extensions:  openapi.ExtensionsFromExpr(e.Body.Meta),
 		}}
 	}
@@ -253,7 +271,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 	{
 		responses = make(map[string]*ResponseRef, len(e.Responses))
 		for _, r := range e.Responses {
-			if e.MethodExpr.IsStreaming() {
+			if e.MethodExpr.IsStreaming() && !e.StreamRequestBody {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
 				// response to a HTTP 101 response for openapi docs.
@@ -266,6 +284,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 				}
 			}
 			resp := responseFromExpr(r, bodies.ResponseBodies, rand)
+			envelopeExamples(resp, envelopeKey(expr.Root.API.HTTP.Envelope, e, false))
 			responses[strconv.Itoa(r.StatusCode)] = &ResponseRef{Value: resp}
 		}
 		for _, er := range e.HTTPErrors {
@@ -273,6 +292,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 				er.Response.Description = er.Description
 			}
 			resp := responseFromExpr(er.Response, bodies.ResponseBodies, rand)
+			envelopeExamples(resp, envelopeKey(expr.Root.API.HTTP.Envelope, e, true))
 			desc := er.Name
 			if resp.Description != nil {
 				desc += ": " + *resp.Description
@@ -295,6 +315,12 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 		}
 	}
 
+	// extensions
+	exts := openapi.ExtensionsFromExpr(m.Meta)
+	if tools := openapi.SnippetTools(expr.Root.API); len(tools) > 0 {
+		exts = openapi.CodeSamplesExtension(exts, openapi.Snippets(tools, r))
+	}
+
 	return &Operation{
 		Tags:         tagNames,
 		Summary:      summary,
@@ -306,7 +332,7 @@ func buildOperation(key string, r *expr.RouteExpr, bodies *EndpointBodies, rand
 		Security:     buildSecurityRequirements(e.Requirements),
 		Deprecated:   false,
 		ExternalDocs: openapi.DocsFromExpr(m.Docs, m.Meta),
-		Extensions:   openapi.ExtensionsFromExpr(m.Meta),
+		Extensions:   exts,
 	}
 }
 
@@ -393,36 +419,19 @@ func buildFileServerOperation(key string, fs *expr.HTTPFileServerExpr, api *expr
 func buildServers(servers []*expr.ServerExpr) []*Server {
 	var svrs []*Server
 	for _, svr := range servers {
-		var server *Server
 		for _, host := range svr.Hosts {
-			var (
-				serverVariable   = make(map[string]*ServerVariable)
-				defaultValue     interface{}
-				validationValues []interface{}
-			)
-
-			// Get the first URL expression in the host by default.
-			// Host expression must have at least one URI (validations would have failed
-			// otherwise).
-			uExpr := host.URIs[0]
-			// attempt to find the first HTTP/HTTPS URL
-			for _, ue := range host.URIs {
-				s := ue.Scheme()
-				if s == "http" || s == "https" {
-					uExpr = ue
-					break
-				}
-			}
-
 			// retrieve host variables
+			serverVariable := make(map[string]*ServerVariable)
 			vars := expr.AsObject(host.Variables.Type)
 			for _, v := range *vars {
-				defaultValue = v.Attribute.DefaultValue
-
+				var (
+					defaultValue     = v.Attribute.DefaultValue
+					validationValues []interface{}
+				)
 				if v.Attribute.Validation != nil && len(v.Attribute.Validation.Values) > 0 {
-					validationValues = append(validationValues, v.Attribute.Validation.Values...)
+					validationValues = v.Attribute.Validation.Values
 					if defaultValue == nil {
-						defaultValue = v.Attribute.Validation.Values[0]
+						defaultValue = validationValues[0]
 					}
 				}
 
@@ -430,17 +439,36 @@ func buildServers(servers []*expr.ServerExpr) []*Server {
 					serverVariable[v.Name] = &ServerVariable{
 						Enum:        validationValues,
 						Default:     defaultValue,
-						Description: host.Variables.Description,
+						Description: v.Attribute.Description,
 					}
 				}
 			}
 
-			server = &Server{
-				URL:         string(uExpr),
-				Description: svr.Description,
-				Variables:   serverVariable,
+			description := host.Description
+			if description == "" {
+				description = svr.Description
+			}
+
+			// Add a server for each HTTP/HTTPS URL. Host expression must
+			// have at least one URI (validations would have failed
+			// otherwise), default to the first URI if none is HTTP.
+			var uris []expr.URIExpr
+			for _, ue := range host.URIs {
+				s := ue.Scheme()
+				if s == "http" || s == "https" {
+					uris = append(uris, ue)
+				}
+			}
+			if len(uris) == 0 {
+				uris = host.URIs[:1]
+			}
+			for _, u := range uris {
+				svrs = append(svrs, &Server{
+					URL:         string(u),
+					Description: description,
+					Variables:   serverVariable,
+				})
 			}
-			svrs = append(svrs, server)
 		}
 	}
 	return svrs
@@ -449,12 +477,12 @@ func buildServers(servers []*expr.ServerExpr) []*Server {
 // buildSecurityRequirements builds the OpenAPI security requirements for the
 // given security expressions.
 func buildSecurityRequirements(reqs []*expr.SecurityExpr) []map[string][]string {
-	srs := make([]map[string][]string, len(reqs))
-	for i, req := range reqs {
+	srs := make([]map[string][]string, 0, len(reqs))
+	for _, req := range reqs {
 		sr := make(map[string][]string, len(req.Schemes))
 		for _, sch := range req.Schemes {
 			switch sch.Kind {
-			case expr.BasicAuthKind, expr.APIKeyKind:
+			case expr.BasicAuthKind, expr.APIKeyKind, expr.SignatureKind:
 				sr[sch.Hash()] = []string{}
 			case expr.OAuth2Kind, expr.JWTKind:
 				scopes := make([]string, len(sch.Scopes))
@@ -464,7 +492,11 @@ func buildSecurityRequirements(reqs []*expr.SecurityExpr) []map[string][]string
 				sr[sch.Hash()] = scopes
 			}
 		}
-		srs[i] = sr
+		if len(sr) == 0 {
+			// requirement only lists schemes that cannot be described
+			continue
+		}
+		srs = append(srs, sr)
 	}
 	return srs
 }
@@ -489,6 +521,16 @@ func buildSecurityScheme(se *expr.SchemeExpr) *SecurityScheme {
 			Name:        se.Name,
 			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
 		}
+	case expr.SignatureKind:
+		// OpenAPI does not support signature schemes, the signature is
+		// carried in the Authorization header.
+		scheme = &SecurityScheme{
+			Type:        "apiKey",
+			Description: se.Description,
+			In:          "header",
+			Name:        "Authorization",
+			Extensions:  openapi.ExtensionsFromExpr(se.Meta),
+		}
 	case expr.JWTKind:
 		scheme = &SecurityScheme{
 			Type:        "http",
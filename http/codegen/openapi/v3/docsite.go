@@ -0,0 +1,210 @@
+package openapiv3
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// DocsRedoc is the "openapi:docs" value that selects the ReDoc renderer.
+	DocsRedoc = "redoc"
+	// DocsSwaggerUI is the "openapi:docs" value that selects the Swagger UI
+	// renderer.
+	DocsSwaggerUI = "swagger-ui"
+
+	// redocVersion is the version of the ReDoc bundle, it must match the
+	// version in docsite_fetch.go.
+	redocVersion = "2.1.5"
+	// swaggerUIVersion is the version of the swagger-ui-dist bundles, it
+	// must match the version in docsite_fetch.go.
+	swaggerUIVersion = "5.17.14"
+)
+
+// docsiteFS contains the ReDoc and Swagger UI bundles copied into the
+// generated docs site, see docsite/README.md.
+//
+//go:generate go run docsite_fetch.go
+//go:embed docsite
+var docsiteFS embed.FS
+
+// docsiteAssets is the file system the renderer bundles are read from.
+var docsiteAssets fs.FS = docsiteFS
+
+// docsiteBundles lists the bundle files of each renderer.
+var docsiteBundles = map[string][]string{
+	DocsRedoc:     {"redoc.standalone.js"},
+	DocsSwaggerUI: {"swagger-ui.css", "swagger-ui-bundle.js"},
+}
+
+// docsiteCDN lists the URLs the docs site loads the bundles from when they are
+// not embedded.
+var docsiteCDN = map[string]string{
+	"redoc.standalone.js":  "https://cdn.redoc.ly/redoc/v" + redocVersion + "/bundles/redoc.standalone.js",
+	"swagger-ui.css":       "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui.css",
+	"swagger-ui-bundle.js": "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui-bundle.js",
+}
+
+// docsiteData is the data used to render the docs site templates.
+type docsiteData struct {
+	// Title is the HTML page title.
+	Title string
+	// Renderer is either DocsRedoc or DocsSwaggerUI.
+	Renderer string
+	// Spec is the OpenAPI v3 specification.
+	Spec *OpenAPI
+	// Assets lists the names of the files embedded by the docs package.
+	Assets []string
+	// Sources maps the bundle names to the location the HTML page loads
+	// them from: the bundle name if the bundle is part of the site, the
+	// CDN URL otherwise.
+	Sources map[string]string
+}
+
+// DocsiteFiles returns the files that make up a self-contained documentation
+// site for the given OpenAPI specification: an HTML page with the spec
+// inlined, a copy of the spec, the renderer bundles and a Go package that
+// embeds them all and exposes a http.Handler. The site does not load any
+// external resource unless the renderer bundles are not embedded in the
+// generator, see docsite/README.md, in which case the HTML page loads them
+// from the pinned CDN URLs. DocsiteFiles returns nil if the API does not
+// define the "openapi:docs" meta.
+func DocsiteFiles(api *expr.APIExpr, spec *OpenAPI) ([]*codegen.File, error) {
+	if _, ok := api.Meta["openapi:docs"]; !ok || spec == nil {
+		return nil, nil
+	}
+	renderer, _ := api.Meta.Last("openapi:docs")
+	if renderer != DocsSwaggerUI {
+		renderer = DocsRedoc
+	}
+	var bundles []*codegen.File
+	data := &docsiteData{
+		Title:    spec.Info.Title,
+		Renderer: renderer,
+		Spec:     spec,
+		Assets:   []string{"index.html", "openapi3.json"},
+		Sources:  make(map[string]string),
+	}
+	dir := filepath.Join(codegen.Gendir, "http", "docs")
+	for _, name := range docsiteBundles[renderer] {
+		b, err := fs.ReadFile(docsiteAssets, "docsite/"+name)
+		if errors.Is(err, fs.ErrNotExist) {
+			data.Sources[name] = docsiteCDN[name]
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read docs site bundle %q: %w", name, err)
+		}
+		data.Sources[name] = name
+		data.Assets = append(data.Assets, name)
+		bundles = append(bundles, &codegen.File{
+			Path: filepath.Join(dir, name),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:   "docsite-bundle",
+				Source: "{{ . }}",
+				Data:   string(b),
+			}},
+		})
+	}
+	fm := template.FuncMap{"toJSON": toJSON, "toScriptJSON": toScriptJSON}
+
+	files := []*codegen.File{
+		{
+			Path: filepath.Join(dir, "index.html"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "docsite-html",
+				FuncMap: fm,
+				Source:  docsiteHTMLT,
+				Data:    data,
+			}},
+		},
+		{
+			Path: filepath.Join(dir, "openapi3.json"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "docsite-spec",
+				FuncMap: fm,
+				Source:  "{{ toJSON .Spec }}",
+				Data:    data,
+			}},
+		},
+		{
+			Path: filepath.Join(dir, "docs.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header("HTTP documentation site", "docs", []*codegen.ImportSpec{
+					codegen.SimpleImport("embed"),
+					codegen.SimpleImport("net/http"),
+				}),
+				{
+					Name:    "docsite-handler",
+					FuncMap: template.FuncMap{"join": strings.Join},
+					Source:  docsiteHandlerT,
+					Data:    data,
+				},
+			},
+		},
+	}
+	return append(files, bundles...), nil
+}
+
+// toScriptJSON returns the JSON representation of d that can be inlined in a
+// HTML script element: the characters <, > and & are escaped so that the
+// spec descriptions cannot close the element.
+func toScriptJSON(d interface{}) string {
+	var buf bytes.Buffer
+	json.HTMLEscape(&buf, []byte(toJSON(d)))
+	return buf.String()
+}
+
+// input: *docsiteData
+const docsiteHTMLT = `<!DOCTYPE html>
+<html>
+  <head>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1"/>
+    <title>{{ html .Title }}</title>
+{{- if eq .Renderer "swagger-ui" }}
+    <link rel="stylesheet" href="{{ index .Sources "swagger-ui.css" }}"/>
+{{- end }}
+  </head>
+  <body>
+    <div id="docs"></div>
+{{- if eq .Renderer "swagger-ui" }}
+    <script src="{{ index .Sources "swagger-ui-bundle.js" }}"></script>
+    <script>
+      window.ui = SwaggerUIBundle({ spec: {{ toScriptJSON .Spec }}, dom_id: "#docs" });
+    </script>
+{{- else }}
+    <script src="{{ index .Sources "redoc.standalone.js" }}"></script>
+    <script>
+      Redoc.init({{ toScriptJSON .Spec }}, {}, document.getElementById("docs"));
+    </script>
+{{- end }}
+  </body>
+</html>
+`
+
+// input: *docsiteData
+const docsiteHandlerT = `var (
+	//go:embed {{ join .Assets " " }}
+	assets embed.FS
+
+	// Spec is the OpenAPI v3 specification served by Handler.
+	Spec, _ = assets.ReadFile("openapi3.json")
+)
+
+// Handler returns a HTTP handler that serves the documentation site at "/"
+// and the OpenAPI v3 specification at "/openapi3.json". Use http.StripPrefix
+// to mount the handler under a sub-path.
+func Handler() http.Handler {
+	return http.FileServer(http.FS(assets))
+}
+`
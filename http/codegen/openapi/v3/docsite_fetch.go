@@ -0,0 +1,57 @@
+//go:build ignore
+
+// docsite_fetch downloads the pinned ReDoc and Swagger UI bundles embedded by
+// the docs site generator into the docsite directory.
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// redocVersion is the version of the ReDoc bundle, it must match the
+	// version in docsite.go.
+	redocVersion = "2.1.5"
+	// swaggerUIVersion is the version of the swagger-ui-dist bundles, it
+	// must match the version in docsite.go.
+	swaggerUIVersion = "5.17.14"
+)
+
+var bundles = map[string]string{
+	"redoc.standalone.js":  "https://cdn.redoc.ly/redoc/v" + redocVersion + "/bundles/redoc.standalone.js",
+	"swagger-ui.css":       "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui.css",
+	"swagger-ui-bundle.js": "https://unpkg.com/swagger-ui-dist@" + swaggerUIVersion + "/swagger-ui-bundle.js",
+}
+
+func main() {
+	for name, url := range bundles {
+		if err := fetch(url, filepath.Join("docsite", name)); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", name, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func fetch(url, path string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
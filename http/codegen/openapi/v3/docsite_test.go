@@ -0,0 +1,152 @@
+package openapiv3_test
+
+import (
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"goa.design/goa/v3/codegen"
+	httpgen "goa.design/goa/v3/http/codegen"
+	openapi "goa.design/goa/v3/http/codegen/openapi"
+	openapiv3 "goa.design/goa/v3/http/codegen/openapi/v3"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestDocsiteFiles(t *testing.T) {
+	defer openapiv3.SetDocsiteAssets(fstest.MapFS{
+		"docsite/redoc.standalone.js":  {Data: []byte("/* redoc */")},
+		"docsite/swagger-ui.css":       {Data: []byte("/* swagger-ui css */")},
+		"docsite/swagger-ui-bundle.js": {Data: []byte("/* swagger-ui */")},
+	})()
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected string
+		Bundles  []string
+	}{
+		{"no-docs", testdata.SimpleDSL, "", nil},
+		{"redoc", testdata.DocsiteDSL, "Redoc.init(", []string{"redoc.standalone.js"}},
+		{"swagger-ui", testdata.DocsiteSwaggerUIDSL, "SwaggerUIBundle(", []string{"swagger-ui.css", "swagger-ui-bundle.js"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := httpgen.RunHTTPDSL(t, c.DSL)
+			files, err := openapiv3.DocsiteFiles(root.API, openapiv3.New(root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Expected == "" {
+				if len(files) != 0 {
+					t.Fatalf("got %d files, expected none", len(files))
+				}
+				return
+			}
+			assets := append([]string{"index.html", "openapi3.json", "docs.go"}, c.Bundles...)
+			if len(files) != len(assets) {
+				t.Fatalf("got %d files, expected %d", len(files), len(assets))
+			}
+			dir := filepath.Join("gen", "http", "docs")
+			for i, p := range assets {
+				if files[i].Path != filepath.Join(dir, p) {
+					t.Errorf("file %d: got path %q, expected %q", i, files[i].Path, filepath.Join(dir, p))
+				}
+			}
+			var buf bytes.Buffer
+			if err := files[0].SectionTemplates[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			html := buf.String()
+			if !strings.Contains(html, c.Expected) {
+				t.Errorf("HTML does not contain %q:\n%s", c.Expected, html)
+			}
+			if strings.Contains(html, `src="http`) || strings.Contains(html, `href="http`) {
+				t.Errorf("HTML loads external resources:\n%s", html)
+			}
+			if strings.Contains(html, "</script> &") {
+				t.Errorf("HTML does not escape the spec:\n%s", html)
+			}
+			for _, b := range c.Bundles {
+				if !strings.Contains(html, `"`+b+`"`) {
+					t.Errorf("HTML does not load %q:\n%s", b, html)
+				}
+			}
+			handler := codegen.SectionCode(t, files[2].SectionTemplates[1])
+			embed := "//go:embed index.html openapi3.json " + strings.Join(c.Bundles, " ")
+			if !strings.Contains(handler, embed) {
+				t.Errorf("handler does not embed the site assets, expected %q:\n%s", embed, handler)
+			}
+		})
+	}
+}
+
+func TestDocsiteFilesMissingBundle(t *testing.T) {
+	defer openapiv3.SetDocsiteAssets(fstest.MapFS{})()
+	openapi.Definitions = make(map[string]*openapi.Schema)
+	root := httpgen.RunHTTPDSL(t, testdata.DocsiteDSL)
+	files, err := openapiv3.DocsiteFiles(root.API, openapiv3.New(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("got %d files, expected 3", len(files))
+	}
+	var buf bytes.Buffer
+	if err := files[0].SectionTemplates[0].Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	cdn := `src="https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js"`
+	if html := buf.String(); !strings.Contains(html, cdn) {
+		t.Errorf("HTML does not load the bundle from the CDN, expected %q:\n%s", cdn, html)
+	}
+	handler := codegen.SectionCode(t, files[2].SectionTemplates[1])
+	if !strings.Contains(handler, "//go:embed index.html openapi3.json\n") {
+		t.Errorf("handler embeds missing bundles:\n%s", handler)
+	}
+}
+
+func TestDocsiteFilesEmbeddedBundles(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Bundles []string
+	}{
+		{"redoc", testdata.DocsiteDSL, []string{"redoc.standalone.js"}},
+		{"swagger-ui", testdata.DocsiteSwaggerUIDSL, []string{"swagger-ui.css", "swagger-ui-bundle.js"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			openapi.Definitions = make(map[string]*openapi.Schema)
+			root := httpgen.RunHTTPDSL(t, c.DSL)
+			files, err := openapiv3.DocsiteFiles(root.API, openapiv3.New(root))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := files[0].SectionTemplates[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			html := buf.String()
+			paths := make(map[string]bool)
+			for _, f := range files {
+				paths[filepath.Base(f.Path)] = true
+			}
+			for _, b := range c.Bundles {
+				_, err := fs.Stat(openapiv3.DocsiteFS, "docsite/"+b)
+				embedded := err == nil
+				if paths[b] != embedded {
+					t.Errorf("%s: got file generated %v, expected %v", b, paths[b], embedded)
+				}
+				if embedded && !strings.Contains(html, `"`+b+`"`) {
+					t.Errorf("HTML does not load the embedded %q:\n%s", b, html)
+				}
+				if !embedded && !strings.Contains(html, `"`+openapiv3.DocsiteCDN[b]+`"`) {
+					t.Errorf("HTML does not load %q from the CDN:\n%s", b, html)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package openapiv3
 
-import "goa.design/goa/v3/expr"
+import (
+	"fmt"
+
+	"goa.design/goa/v3/expr"
+)
 
 type (
 	// exampler is the interface used to initialize the example of an
@@ -21,15 +25,33 @@ func initExamples(obj exampler, attr *expr.AttributeExpr, r *expr.Random) {
 			example := &Example{
 				Summary:     ex.Summary,
 				Description: ex.Description,
-				Value:       ex.Value,
+				Value:       attr.WireExample(ex.Value),
 			}
 			refs[ex.Summary] = &ExampleRef{Value: example}
 		}
 		obj.setExamples(refs)
 		return
 	case len(examples) > 0:
-		obj.setExample(examples[0].Value)
+		obj.setExample(attr.WireExample(examples[0].Value))
 	default:
-		obj.setExample(attr.Example(r))
+		obj.setExample(attr.WireExample(attr.Example(r)))
+	}
+}
+
+// initViewExamples sets the examples of the given object to one example per
+// view of the given result type. The examples are named after the views.
+func initViewExamples(obj exampler, rt *expr.ResultTypeExpr, r *expr.Random) {
+	refs := make(map[string]*ExampleRef, len(rt.Views))
+	for _, v := range rt.Views {
+		prt, err := expr.Project(rt, v.Name)
+		if err != nil {
+			continue
+		}
+		att := &expr.AttributeExpr{Type: prt}
+		refs[v.Name] = &ExampleRef{Value: &Example{
+			Summary: fmt.Sprintf("%s view", v.Name),
+			Value:   att.WireExample(att.Example(r)),
+		}}
 	}
+	obj.setExamples(refs)
 }
@@ -0,0 +1,18 @@
+package openapiv3
+
+import "io/fs"
+
+// SetDocsiteAssets sets the file system the docs site bundles are read from
+// and returns a function that restores the default.
+func SetDocsiteAssets(assets fs.FS) func() {
+	old := docsiteAssets
+	docsiteAssets = assets
+	return func() { docsiteAssets = old }
+}
+
+var (
+	// DocsiteFS is the file system embedding the docs site bundles.
+	DocsiteFS = docsiteFS
+	// DocsiteCDN lists the URLs of the bundles that are not embedded.
+	DocsiteCDN = docsiteCDN
+)
@@ -12,6 +12,8 @@ import (
 )
 
 // Files returns the OpenAPI v3 specification files in JSON and YAML formats.
+// It also returns the documentation site files if the API defines the
+// "openapi:docs" meta.
 func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 	spec := New(root)
 	jsonSection := &codegen.SectionTemplate{
@@ -27,7 +29,7 @@ func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 		Data:    spec,
 	}
 
-	return []*codegen.File{
+	files := []*codegen.File{
 		{
 			Path:             filepath.Join(codegen.Gendir, "http", "openapi3.json"),
 			SectionTemplates: []*codegen.SectionTemplate{jsonSection},
@@ -36,7 +38,12 @@ func Files(root *expr.RootExpr) ([]*codegen.File, error) {
 			Path:             filepath.Join(codegen.Gendir, "http", "openapi3.yaml"),
 			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
 		},
-	}, nil
+	}
+	docs, err := DocsiteFiles(root.API, spec)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, docs...), nil
 }
 
 func toJSON(d interface{}) string {
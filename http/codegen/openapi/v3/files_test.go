@@ -36,6 +36,20 @@ func TestFiles(t *testing.T) {
 		{"valid", testdata.SimpleDSL},
 		{"multiple-services", testdata.MultipleServicesDSL},
 		{"multiple-views", testdata.MultipleViewsDSL},
+		{"response-example", testdata.ResponseExampleDSL},
+		{"request-example", testdata.RequestExampleDSL},
+		{"snippets", testdata.SnippetsDSL},
+		{"query-style", testdata.QueryStyleDSL},
+		{"query-object", testdata.QueryObjectDSL},
+		{"consumes", testdata.ConsumesDSL},
+		{"named-inline-object", testdata.NamedInlineObjectDSL},
+		{"unique-array-validation", testdata.UniqueArrayValidationDSL},
+		{"time-types", testdata.TimeTypesDSL},
+		{"decimal", testdata.DecimalDSL},
+		{"int-enum", testdata.IntEnumDSL},
+		{"field-groups", testdata.FieldGroupsDSL},
+		{"envelope", testdata.EnvelopeDSL},
+		{"server-multiple-hosts", testdata.ServerMultipleHostsDSL},
 		{"explicit-view", testdata.ExplicitViewDSL},
 		{"security", testdata.SecurityDSL},
 		{"server-host-with-variables", testdata.ServerHostWithVariablesDSL},
@@ -124,6 +138,8 @@ func prettifyJSON(t *testing.T, b []byte) string {
 }
 
 func validateSwagger(t *testing.T, b []byte) {
+	// "decimal" is the custom format used for Decimal attributes.
+	openapi3.DefineStringFormat("decimal", `^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
 	swagger, err := openapi3.NewLoader().LoadFromData(b)
 	if err == nil {
 		err = swagger.Validate(context.Background())
@@ -56,15 +56,35 @@ func paramsFromHeadersAndCookies(endpoint *expr.HTTPEndpointExpr, rand *expr.Ran
 
 // paramFor converts the given attribute into a OpenAPI spec parameter.
 func paramFor(att *expr.AttributeExpr, name, in string, required bool, rand *expr.Random) *Parameter {
+	schema := newSchemafier(rand).schemafy(att)
+	if ut, ok := att.Type.(expr.UserType); ok && expr.IsObject(ut) {
+		// Parameter types are not part of the components, inline the
+		// schema of object query parameters.
+		schema = newSchemafier(rand).schemafy(ut.Attribute())
+	}
 	param := &Parameter{
 		Name:            name,
 		In:              in,
 		Description:     att.Description,
 		AllowEmptyValue: in != "path",
 		Required:        required,
-		Schema:          newSchemafier(rand).schemafy(att),
+		Schema:          schema,
 		Extensions:      openapi.ExtensionsFromExpr(att.Meta),
 	}
+	if in == "query" {
+		style, ok := att.Meta.Last("http:query:style")
+		if !ok && (expr.IsMap(att.Type) || expr.IsObject(att.Type)) {
+			style, ok = "deepObject", true
+		}
+		if ok {
+			explode := style != "csv"
+			param.Explode = &explode
+			param.Style = "form"
+			if style == "deepObject" {
+				param.Style = "deepObject"
+			}
+		}
+	}
 	initExamples(param, att, rand)
 	return param
 }
@@ -44,7 +44,14 @@ func responseFromExpr(r *expr.HTTPResponseExpr, bodies map[int][]*openapi.Schema
 				Schema:     bodies[r.StatusCode][0],
 				Extensions: openapi.ExtensionsFromExpr(r.Body.Meta),
 			}
-			initExamples(content[ct], r.Body, rand)
+			switch {
+			case len(r.Examples) > 0:
+				initExamples(content[ct], &expr.AttributeExpr{UserExamples: r.Examples}, rand)
+			case ok && rt.HasMultipleViews() && r.Body.Meta["view"] == nil && len(r.Body.ExtractUserExamples()) == 0:
+				initViewExamples(content[ct], rt, rand)
+			default:
+				initExamples(content[ct], r.Body, rand)
+			}
 		}
 	}
 	desc := r.Description
@@ -58,3 +65,37 @@ func responseFromExpr(r *expr.HTTPResponseExpr, bodies map[int][]*openapi.Schema
 		Extensions:  openapi.ExtensionsFromExpr(r.Meta),
 	}
 }
+
+// envelopeKey returns the name of the attribute of the API response envelope
+// env that wraps the success or error response bodies of endpoint e, the empty
+// string if the response bodies are not wrapped.
+func envelopeKey(env *expr.AttributeExpr, e *expr.HTTPEndpointExpr, isError bool) string {
+	if env == nil || e.MethodExpr.IsStreaming() && !e.StreamRequestBody {
+		return ""
+	}
+	key := "data"
+	if isError {
+		key = "errors"
+	}
+	if env.Find(key) == nil {
+		return ""
+	}
+	return key
+}
+
+// envelopeExamples wraps the response examples under the given envelope key.
+func envelopeExamples(resp *Response, key string) {
+	if key == "" {
+		return
+	}
+	for _, mt := range resp.Content {
+		if mt.Example != nil {
+			mt.Example = map[string]interface{}{key: mt.Example}
+		}
+		for _, ex := range mt.Examples {
+			if ex.Value != nil && ex.Value.Value != nil {
+				ex.Value.Value = map[string]interface{}{key: ex.Value.Value}
+			}
+		}
+	}
+}
@@ -47,6 +47,67 @@ func ObjectBodyDSL(svcName, metName string) func() {
 	}
 }
 
+func WireNameBodyDSL(svcName, metName string) func() {
+	return func() {
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				Payload(func() {
+					Attribute("self_url", String, func() {
+						WireName("selfURL")
+					})
+					Attribute("age", Int)
+				})
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
+func DiscriminatedUnionBodyDSL(svcName, metName string) func() {
+	return func() {
+		var Cat = Type("Cat", func() {
+			Attribute("lives", Int)
+		})
+		var Dog = Type("Dog", func() {
+			Attribute("barks", Boolean)
+		})
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				Payload(func() {
+					OneOf("pet", func() {
+						Discriminator("kind")
+						Attribute("cat", Cat)
+						Attribute("dog", Dog)
+					})
+				})
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
+
+func NullableBodyDSL(svcName, metName string) func() {
+	return func() {
+		var _ = Service(svcName, func() {
+			Method(metName, func() {
+				Payload(func() {
+					Attribute("name", String)
+					Attribute("nickname", String, func() {
+						Nullable()
+					})
+				})
+				HTTP(func() {
+					PATCH("/")
+				})
+			})
+		})
+	}
+}
+
 func MapBodyDSL(svcName, metName string) func() {
 	return func() {
 		var _ = Service(svcName, func() {
@@ -100,7 +100,7 @@ func buildBodyTypes(api *expr.APIExpr) (map[string]map[string]*EndpointBodies, m
 			for _, er := range e.HTTPErrors {
 				resps = append(resps, er.Response)
 			}
-			for _, resp := range resps {
+			for i, resp := range resps {
 				var view string
 				if vs, ok := resp.Body.Meta["view"]; ok {
 					view = vs[0]
@@ -124,6 +124,11 @@ func buildBodyTypes(api *expr.APIExpr) (map[string]map[string]*EndpointBodies, m
 						js.Description += sf.viewsNote(rt)
 					}
 				}
+				if key := envelopeKey(api.HTTP.Envelope, e, i >= len(e.Responses)); key != "" && js != nil {
+					js = openapi.EnvelopeSchema(api.HTTP.Envelope, key, js, func(att *expr.AttributeExpr) *openapi.Schema {
+						return sf.schemafy(att)
+					})
+				}
 				res[resp.StatusCode] = append(res[resp.StatusCode], js)
 			}
 			sbodies[e.Name()] = &EndpointBodies{req, res}
@@ -159,6 +164,21 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 		case expr.Float64Kind:
 			s.Type = openapi.Type("number")
 			s.Format = "double"
+		case expr.DateTimeKind:
+			s.Type = openapi.Type("string")
+			s.Format = "date-time"
+		case expr.DateKind:
+			s.Type = openapi.Type("string")
+			s.Format = "date"
+		case expr.DurationKind:
+			s.Type = openapi.Type("integer")
+			s.Format = "int64"
+		case expr.UUIDKind:
+			s.Type = openapi.Type("string")
+			s.Format = "uuid"
+		case expr.DecimalKind:
+			s.Type = openapi.Type("string")
+			s.Format = "decimal"
 		case expr.BytesKind, expr.AnyKind:
 			if bases := attr.Bases; len(bases) > 0 {
 				for _, b := range bases {
@@ -177,10 +197,20 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 		s.Type = openapi.Array
 		s.Items = sf.schemafy(t.ElemType)
 	case *expr.Object:
+		if len(attr.Bases) == 1 {
+			if u := expr.AsUnion(attr.Bases[0]); u != nil && u.Discriminator != "" {
+				// Union serialized inline, see makeHTTPType
+				sf.discriminate(s, u)
+				break
+			}
+		}
 		s.Type = openapi.Object
 		var itemNotes []string
 		for _, nat := range *t {
-			s.Properties[nat.Name] = sf.schemafy(nat.Attribute)
+			s.Properties[attr.WireName(nat.Name)] = sf.schemafy(nat.Attribute)
+		}
+		if n := openapi.FieldGroupsNote(attr.Validation); n != "" {
+			itemNotes = append(itemNotes, n)
 		}
 		if len(itemNotes) > 0 {
 			note = strings.Join(itemNotes, "\n")
@@ -196,6 +226,10 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			s.AdditionalProperties = true
 		}
 	case *expr.Union:
+		if t.Discriminator != "" {
+			sf.discriminate(s, t)
+			break
+		}
 		for _, val := range t.Values {
 			s.AnyOf = append(s.AnyOf, sf.schemafy(val.Attribute))
 		}
@@ -224,13 +258,32 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 	}
 	s.Description = attr.Description
 	if note != "" {
-		s.Description += "\n" + note
+		if s.Description != "" {
+			s.Description += "\n"
+		}
+		s.Description += note
+	}
+	if note := openapi.ComputedNote(attr); note != "" {
+		if s.Description != "" {
+			s.Description += "\n"
+		}
+		s.Description += note
+		s.ReadOnly = true
+	}
+	if _, ok := attr.Meta["nullable"]; ok {
+		s.Nullable = true
 	}
 
 	// Default value, example, extensions
 	s.DefaultValue = toStringMap(attr.DefaultValue)
-	s.Example = attr.Example(sf.rand)
-	s.Extensions = openapi.ExtensionsFromExpr(attr.Meta)
+	if u, ok := attr.Type.(*expr.Union); ok && u.Discriminator != "" && len(u.Values) > 0 && len(attr.ExtractUserExamples()) == 0 {
+		// Union serialized inline, see expr.DiscriminatedUnionObject
+		nat := u.Values[sf.rand.Int()%len(u.Values)]
+		s.Example = nat.Attribute.WireExample(expr.DiscriminatedExample(u, nat, sf.rand))
+	} else {
+		s.Example = attr.WireExample(attr.Example(sf.rand))
+	}
+	s.Extensions = openapi.EnumNamesExtension(openapi.ExtensionsFromExpr(attr.Meta), attr.Meta)
 
 	// Validations
 	val := attr.Validation
@@ -266,11 +319,53 @@ func (sf *schemafier) schemafy(attr *expr.AttributeExpr, noref ...bool) *openapi
 			s.MaxLength = val.MaxLength
 		}
 	}
-	s.Required = val.Required
+	s.UniqueItems = val.UniqueItems
+	if s.Discriminator == nil {
+		// The "oneOf" schemas require the discriminator
+		s.Required = attr.WireNames(val.Required)
+	}
 
 	return s
 }
 
+// discriminate initializes s with the "oneOf" schemas of the given union types
+// and the discriminator mapping of the union type names to the schemas. The
+// schema of each union type adds the discriminator property set to the name of
+// the type to the type schema.
+func (sf *schemafier) discriminate(s *openapi.Schema, u *expr.Union) {
+	s.Discriminator = &openapi.Discriminator{
+		PropertyName: u.Discriminator,
+		Mapping:      make(map[string]string, len(u.Values)),
+	}
+	for _, nat := range u.Values {
+		ref := sf.discriminated(u, nat)
+		s.OneOf = append(s.OneOf, &openapi.Schema{Ref: ref})
+		s.Discriminator.Mapping[nat.Name] = ref
+	}
+}
+
+// discriminated returns the reference to the schema of the union type nat
+// that includes the discriminator property. The schema is created the first
+// time discriminated is called for the type.
+func (sf *schemafier) discriminated(u *expr.Union, nat *expr.NamedAttributeExpr) string {
+	h := sf.hashAttribute(nat.Attribute, fnv.New64()) ^ hashString(u.Discriminator+"="+nat.Name, fnv.New64())
+	if ref, ok := sf.hashes[h]; ok {
+		return ref
+	}
+	typeName := sf.uniquify(codegen.Goify(u.TypeName, true) + codegen.Goify(nat.Name, true))
+	ref := toRef(typeName)
+	sf.hashes[h] = ref
+	disc := openapi.NewSchema()
+	disc.Type = openapi.Object
+	disc.Properties[u.Discriminator] = &openapi.Schema{Type: openapi.Type("string"), Enum: []interface{}{nat.Name}}
+	disc.Required = []string{u.Discriminator}
+	s := openapi.NewSchema()
+	s.AllOf = []*openapi.Schema{sf.schemafy(nat.Attribute), disc}
+	s.Example = nat.Attribute.WireExample(expr.DiscriminatedExample(u, nat, sf.rand))
+	sf.schemas[typeName] = s
+	return ref
+}
+
 // uniquify returns n if n is not a known type name. Otherwise uniquify appends
 // the smallest integer greater than 1 to n so the result is not a known type
 // name.
@@ -382,7 +477,7 @@ func hashAttribute(att *expr.AttributeExpr, h hash.Hash64, seen map[string]*uint
 	case expr.ObjectKind:
 		o := expr.AsObject(t)
 		for _, m := range *o {
-			kh := hashString(m.Name, h)
+			kh := hashString(att.WireName(m.Name), h)
 			vh := hashAttribute(m.Attribute, h, seen)
 			*res = *res ^ orderedHash(kh, *vh, h)
 		}
@@ -88,6 +88,12 @@ func TestBuildBodyTypes(t *testing.T) {
 
 		ExpectedType:          tobj("name", tstring, "age", tint),
 		ExpectedResponseTypes: rt{204: tempty},
+	}, {
+		Name: "wire_name_body",
+		DSL:  dsls.WireNameBodyDSL(svcName, "wire_name_body"),
+
+		ExpectedType:          tobj("selfURL", tstring, "age", tint),
+		ExpectedResponseTypes: rt{204: tempty},
 	}, {
 		Name: "map_body",
 		DSL:  dsls.MapBodyDSL(svcName, "map_body"),
@@ -184,6 +190,94 @@ func TestBuildBodyTypes(t *testing.T) {
 	}
 }
 
+func TestBuildBodyTypesDiscriminator(t *testing.T) {
+	const svcName = "test service"
+	api := codegen.RunDSL(t, dsls.DiscriminatedUnionBodyDSL(svcName, "discriminated_union")).API
+	bodies, types := buildBodyTypes(api)
+	body := bodies[svcName]["discriminated_union"].RequestBody
+	if body.Ref != "" {
+		body = types[nameFromRef(body.Ref)]
+	}
+	pet, ok := body.Properties["pet"]
+	if !ok {
+		t.Fatal("request body does not define pet")
+	}
+	if len(pet.OneOf) != 2 {
+		t.Errorf("got %d oneOf schemas, expected 2", len(pet.OneOf))
+	}
+	if pet.Discriminator == nil {
+		t.Fatal("pet has no discriminator")
+	}
+	if pet.Discriminator.PropertyName != "kind" {
+		t.Errorf("got discriminator property %q, expected %q", pet.Discriminator.PropertyName, "kind")
+	}
+	if len(pet.Required) > 0 {
+		t.Errorf("got required %v beside oneOf, expected none", pet.Required)
+	}
+	expected := map[string]string{"cat": "#/components/schemas/PetCat", "dog": "#/components/schemas/PetDog"}
+	if len(pet.Discriminator.Mapping) != len(expected) {
+		t.Errorf("got mapping %v, expected %v", pet.Discriminator.Mapping, expected)
+	}
+	for k, v := range expected {
+		if pet.Discriminator.Mapping[k] != v {
+			t.Errorf("got mapping %q for %q, expected %q", pet.Discriminator.Mapping[k], k, v)
+		}
+		member, ok := types[nameFromRef(v)]
+		if !ok {
+			t.Errorf("%q schema not found", v)
+			continue
+		}
+		if len(member.AllOf) != 2 {
+			t.Fatalf("got %d allOf schemas for %q, expected 2", len(member.AllOf), k)
+		}
+		kind, ok := member.AllOf[1].Properties["kind"]
+		if !ok {
+			t.Errorf("%q schema does not define the discriminator", v)
+		} else if len(kind.Enum) != 1 || kind.Enum[0] != k {
+			t.Errorf("got discriminator values %v for %q, expected [%s]", kind.Enum, k, k)
+		}
+		if ex, ok := member.Example.(map[string]interface{}); !ok || ex["kind"] != k {
+			t.Errorf("got example %v for %q, expected kind %q", member.Example, k, k)
+		}
+	}
+	ex, ok := pet.Example.(map[string]interface{})
+	if !ok {
+		t.Fatalf("got example %v, expected an object", pet.Example)
+	}
+	switch ex["kind"] {
+	case "cat":
+		if _, ok := ex["barks"]; ok {
+			t.Errorf("got cat example %v with dog attribute", ex)
+		}
+	case "dog":
+		if _, ok := ex["lives"]; ok {
+			t.Errorf("got dog example %v with cat attribute", ex)
+		}
+	default:
+		t.Errorf("got example %v, expected kind cat or dog", ex)
+	}
+}
+
+func TestBuildBodyTypesNullable(t *testing.T) {
+	const svcName = "test service"
+	api := codegen.RunDSL(t, dsls.NullableBodyDSL(svcName, "nullable")).API
+	bodies, types := buildBodyTypes(api)
+	body := bodies[svcName]["nullable"].RequestBody
+	if body.Ref != "" {
+		body = types[nameFromRef(body.Ref)]
+	}
+	cases := map[string]bool{"name": false, "nickname": true}
+	for n, nullable := range cases {
+		p, ok := body.Properties[n]
+		if !ok {
+			t.Fatalf("request body does not define %s", n)
+		}
+		if p.Nullable != nullable {
+			t.Errorf("%s: got nullable %v, expected %v", n, p.Nullable, nullable)
+		}
+	}
+}
+
 func matchesSchema(t *testing.T, ctx string, s *openapi.Schema, types map[string]*openapi.Schema, tt typ) {
 	matchesSchemaWithPrefix(t, ctx, s, types, tt, "")
 }
@@ -3,6 +3,7 @@ package codegen
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/expr"
@@ -38,14 +39,16 @@ func clientPath(svc *expr.HTTPServiceExpr) *codegen.File {
 // contains the request path constructors for the given service.
 func pathSections(svc *expr.HTTPServiceExpr, pkg string) []*codegen.SectionTemplate {
 	title := fmt.Sprintf("HTTP request path constructors for the %s service.", svc.Name())
-	sections := []*codegen.SectionTemplate{
-		codegen.Header(title, pkg, []*codegen.ImportSpec{
-			{Path: "fmt"},
-			{Path: "net/url"},
-			{Path: "strconv"},
-			{Path: "strings"},
-		}),
+	specs := []*codegen.ImportSpec{
+		{Path: "fmt"},
+		{Path: "net/url"},
+		{Path: "strconv"},
+		{Path: "strings"},
 	}
+	if pkg == "server" {
+		specs = append(specs, &codegen.ImportSpec{Path: "goa.design/goa/v3/http", Name: "goahttp"})
+	}
+	sections := []*codegen.SectionTemplate{codegen.Header(title, pkg, specs)}
 	sdata := HTTPServices.Get(svc.Name())
 	for _, e := range svc.HTTPEndpoints {
 		sections = append(sections, &codegen.SectionTemplate{
@@ -54,13 +57,38 @@ func pathSections(svc *expr.HTTPServiceExpr, pkg string) []*codegen.SectionTempl
 			Data:   sdata.Endpoint(e.Name()),
 		})
 	}
+	if pkg == "server" {
+		for _, e := range svc.HTTPEndpoints {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "path-url",
+				Source:  pathURLT,
+				Data:    sdata.Endpoint(e.Name()),
+				FuncMap: map[string]interface{}{"urlName": pathURLName},
+			})
+		}
+	}
 
 	return sections
 }
 
+// pathURLName returns the name of the function that builds the URL to the
+// endpoint given the name of the corresponding path constructor.
+func pathURLName(pathName string) string {
+	i := strings.LastIndex(pathName, "Path")
+	return pathName[:i] + "URL" + pathName[i+len("Path"):]
+}
+
 // input: EndpointData
 const pathT = `{{ range .Routes }}// {{ .PathInit.Description }}
 func {{ .PathInit.Name }}({{ range .PathInit.ServerArgs }}{{ .VarName }} {{ .TypeRef }}, {{ end }}) {{ .PathInit.ReturnTypeRef }} {
 {{- .PathInit.ServerCode }}
 }
 {{ end }}`
+
+// input: EndpointData
+const pathURLT = `{{ range .Routes }}// {{ urlName .PathInit.Name }} returns the URL to the {{ $.ServiceName }} service {{ $.Method.Name }} HTTP endpoint
+// built by appending the endpoint path to the path of base.
+func {{ urlName .PathInit.Name }}(base *url.URL, {{ range .PathInit.ServerArgs }}{{ .VarName }} {{ .TypeRef }}, {{ end }}) *url.URL {
+	return goahttp.JoinURLPath(base, {{ .PathInit.Name }}({{ range .PathInit.ServerArgs }}{{ .VarName }}, {{ end }}))
+}
+{{ end }}`
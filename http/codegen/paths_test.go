@@ -76,3 +76,30 @@ func TestPathTrailingShash(t *testing.T) {
 		})
 	}
 }
+
+func TestPathURLs(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"no-param", testdata.PathNoParamDSL, testdata.PathURLNoParamCode},
+		{"multiple-params", testdata.PathMultipleParamsDSL, testdata.PathURLMultipleParamsCode},
+		{"alternative-paths", testdata.PathAlternativesDSL, testdata.PathURLAlternativesCode},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := serverPath(expr.Root.API.HTTP.Services[0])
+			sections := fs.SectionTemplates
+			if len(sections) != 3 {
+				t.Fatalf("got %d sections, expected 3", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[2])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
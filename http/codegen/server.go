@@ -19,6 +19,9 @@ func ServerFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
 		if f := websocketServerFile(genpkg, svc); f != nil {
 			files = append(files, f)
 		}
+		if f := bodyStreamServerFile(genpkg, svc); f != nil {
+			files = append(files, f)
+		}
 	}
 	for _, svc := range root.API.HTTP.Services {
 		if f := serverEncodeDecodeFile(genpkg, svc); f != nil {
@@ -42,6 +45,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 		"mustDecodeRequest":       mustDecodeRequest,
 		"addLeadingSlash":         addLeadingSlash,
 		"removeTrailingIndexHTML": removeTrailingIndexHTML,
+		"durationCode":            codegen.DurationCode,
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
@@ -52,6 +56,7 @@ func serverFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.File {
 			{Path: "net/http"},
 			{Path: "path"},
 			{Path: "strings"},
+			{Path: "time"},
 			{Path: "github.com/gorilla/websocket"},
 			codegen.GoaImport(""),
 			codegen.GoaNamedImport("http", "goahttp"),
@@ -116,7 +121,7 @@ func serverEncodeDecodeFile(genpkg string, svc *expr.HTTPServiceExpr) *codegen.F
 	sections := []*codegen.SectionTemplate{codegen.Header(title, "server", imports)}
 
 	for _, e := range data.Endpoints {
-		if e.Redirect == nil && !isWebSocketEndpoint(e) {
+		if e.Redirect == nil && e.Proxy == nil && !isWebSocketEndpoint(e) {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:    "response-encoder",
 				FuncMap: transTmplFuncs(svc),
@@ -332,6 +337,9 @@ func {{ .ServerInit }}(
 		Mounts: []*{{ .MountPointStruct }}{
 			{{- range $e := .Endpoints }}
 				{{- range $e.Routes }}
+			{"{{ $e.Method.VarName }}", "{{ .Verb }}", "{{ .Path }}"},
+				{{- end }}
+				{{- range $e.Aliases }}
 			{"{{ $e.Method.VarName }}", "{{ .Verb }}", "{{ .Path }}"},
 				{{- end }}
 			{{- end }}
@@ -405,6 +413,13 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 	{{- range .Routes }}
 	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
 	{{- end }}
+	{{- range .Aliases }}
+		{{- if .StatusCode }}
+	mux.Handle("{{ .Verb }}", "{{ .Path }}", goahttp.AliasRedirect(mux, "{{ .Target }}", {{ .StatusCode }}))
+		{{- else }}
+	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+		{{- end }}
+	{{- end }}
 }
 `
 
@@ -425,7 +440,7 @@ func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 `
 
 // input: EndpointData
-const serverHandlerInitT = `{{ printf "%s creates a HTTP handler which loads the HTTP request and calls the %q service %q endpoint." .HandlerInit .ServiceName .Method.Name | comment }}
+const serverHandlerInitT = `{{ if .Proxy }}{{ printf "%s creates a HTTP handler which forwards the requests made to the %q service %q endpoint to %s." .HandlerInit .ServiceName .Method.Name .Proxy.URL | comment }}{{ else }}{{ printf "%s creates a HTTP handler which loads the HTTP request and calls the %q service %q endpoint." .HandlerInit .ServiceName .Method.Name | comment }}{{ end }}
 func {{ .HandlerInit }}(
 	endpoint goa.Endpoint,
 	mux goahttp.Muxer,
@@ -438,6 +453,21 @@ func {{ .HandlerInit }}(
 	configurer goahttp.ConnConfigureFunc,
 	{{- end }}
 ) http.Handler {
+{{- if .Proxy }}
+	return goahttp.ReverseProxy({{ printf "%q" .Proxy.URL }}
+	{{- if .Proxy.Timeout }},
+		goahttp.ProxyTimeout({{ durationCode .Proxy.Timeout }})
+	{{- end }}
+	{{- range .Proxy.RequestHeaders }},
+		goahttp.ProxyRequestHeader({{ printf "%q" .Name }}, {{ printf "%q" .Value }})
+	{{- end }}
+	{{- range .Proxy.ResponseHeaders }},
+		goahttp.ProxyResponseHeader({{ printf "%q" .Name }}, {{ printf "%q" .Value }})
+	{{- end }}
+	{{- if or .Proxy.Timeout .Proxy.RequestHeaders .Proxy.ResponseHeaders }},
+	{{ end }})
+}
+{{- else }}
 	{{- if (or (mustDecodeRequest .) (not (or .Redirect (isWebSocketEndpoint .))) (not .Redirect) .Method.SkipResponseBodyEncodeDecode) }}
 	var (
 	{{- end }}
@@ -457,6 +487,17 @@ func {{ .HandlerInit }}(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+	{{- if .Envelope }}
+		ctx = goahttp.WithEnvelope(ctx, {{ printf "%q" .Envelope.ErrorKey }})
+	{{- end }}
+	{{- if and .Method.Timeout (not .Redirect) }}
+		ctx, cancel := goahttp.WithRequestTimeout(ctx, r, {{ durationCode .Method.Timeout }})
+		defer cancel()
+	{{- end }}
+	{{- if .NullableFields }}
+		ctx = goa.WithSetFields(ctx)
+		r = r.WithContext(ctx)
+	{{- end }}
 
 	{{- if mustDecodeRequest . }}
 		{{ if .Redirect }}_{{ else }}payload{{ end }}, err := decodeRequest(r)
@@ -485,6 +526,19 @@ func {{ .HandlerInit }}(
 		{{- end }}
 		}
 		_, err = endpoint(ctx, v)
+	{{- else if .ServerBodyStream }}
+		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
+			Stream: &{{ .ServerBodyStream.VarName }}{
+				ctx: ctx,
+				w: w,
+				dec: goahttp.NewArrayDecoder(r.Body),
+				encodeResponse: encodeResponse,
+			},
+		{{- if .Payload.Ref }}
+			Payload: payload.({{ .Payload.Ref }}),
+		{{- end }}
+		}
+		_, err = endpoint(ctx, v)
 	{{- else if .Method.SkipRequestBodyEncodeDecode }}
 		data := &{{ .ServicePkgName }}.{{ .Method.RequestStruct }}{ {{ if .Payload.Ref }}Payload: payload.({{ .Payload.Ref }}), {{ end }}Body: r.Body }
 		res, err := endpoint(ctx, data)
@@ -493,6 +547,11 @@ func {{ .HandlerInit }}(
 	{{- else }}
 		res, err := endpoint(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
 	{{- end }}
+	{{- if and .Method.Timeout (not .Redirect) }}
+		if err == nil {
+			err = goahttp.DeadlineError(ctx)
+		}
+	{{- end }}
 	{{- if not .Redirect }}
 		if err != nil {
 			{{- if isWebSocketEndpoint . }}
@@ -511,8 +570,27 @@ func {{ .HandlerInit }}(
 	{{- if .Method.SkipResponseBodyEncodeDecode }}
 		o := res.(*{{ .ServicePkgName }}.{{ .Method.ResponseStruct }})
 		defer o.Body.Close()
+		{{- if .Download }}
+		goahttp.SetDownloadHeaders(w, o.Body, {{ printf "%q" .Download.ContentType }}, {{ printf "%q" .Download.Disposition }})
+		{{- end }}
+		{{- if .AcceptRanges }}
+		rw := goahttp.NewRangeWriter(w, r, o.Body)
+		w = rw
+		{{- end }}
+	{{- end }}
+	{{- if .Method.LongRunning }}
+		w.Header().Set("Location", goahttp.OperationPath(r, res.({{ .Result.Ref }}).{{ .Method.LongRunning.IDField }}))
+	{{- end }}
+	{{- if .Push }}
+		goahttp.Push(w, r{{ range .Push }}, {{ printf "%q" . }}{{ end }})
 	{{- end }}
-	{{- if not (or .Redirect (isWebSocketEndpoint .)) }}
+	{{- if .ServerBodyStream }}
+		{{- if not .ServerBodyStream.SendTypeRef }}
+		if err := encodeResponse(ctx, w, nil); err != nil {
+			errhandler(ctx, w, err)
+		}
+		{{- end }}
+	{{- else if not (or .Redirect (isWebSocketEndpoint .)) }}
 		if err := encodeResponse(ctx, w, {{ if and .Method.SkipResponseBodyEncodeDecode .Result.Ref }}o.Result{{ else }}res{{ end }}); err != nil {
 			errhandler(ctx, w, err)
 			{{- if .Method.SkipResponseBodyEncodeDecode }}
@@ -521,7 +599,7 @@ func {{ .HandlerInit }}(
 		}
 	{{- end }}
 	{{- if .Method.SkipResponseBodyEncodeDecode }}
-		if _, err := io.Copy(w, o.Body); err != nil {
+		if _, err := io.Copy(w, {{ if .AcceptRanges }}rw.Body(){{ else }}o.Body{{ end }}); err != nil {
 			if err := encodeError(ctx, w, err); err != nil {
 				errhandler(ctx, w, err)
 			}
@@ -529,6 +607,7 @@ func {{ .HandlerInit }}(
 	{{- end }}
 	})
 }
+{{- end }}
 `
 
 // input: TransformFunctionData
@@ -543,6 +622,11 @@ func {{ .Name }}(v {{ .ParamTypeRef }}) {{ .ResultTypeRef }} {
 const requestDecoderT = `{{ printf "%s returns a decoder for requests sent to the %s %s endpoint." .RequestDecoder .ServiceName .Method.Name | comment }}
 func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
 	return func(r *http.Request) (interface{}, error) {
+{{- if .Consumes }}
+		if err := goahttp.CheckContentType(r{{ range .Consumes }}, {{ printf "%q" . }}{{ end }}); err != nil {
+			return nil, err
+		}
+{{- end }}
 {{- if .MultipartRequestDecoder }}
 		var payload {{ .Payload.Ref }}
 		if err := decoder(r).Decode(&payload); err != nil {
@@ -553,7 +637,20 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			body {{ .Payload.Request.ServerBody.VarName }}
 			err  error
 		)
-		err = decoder(r).Decode(&body)
+	{{- if .NullableFields }}
+		if err = goahttp.RecordSetFields(r, map[string]string{ {{- range $wire, $name := .NullableFields }}{{ printf "%q" $wire }}: {{ printf "%q" $name }}, {{ end }}}); err != nil {
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+	{{- end }}
+	{{- $decoder := "decoder" }}
+	{{- if .Payload.Request.NDJSON }}
+		{{- $decoder = "goahttp.NDJSONRequestDecoder(decoder)" }}
+	{{- end }}
+	{{- if .StrictPayload }}
+		err = goahttp.DecodeStrict(r, {{ $decoder }}, &body, {{ eq .StrictPayload "warn" }})
+	{{- else }}
+		err = {{ $decoder }}(r).Decode(&body)
+	{{- end }}
 		if err != nil {
 	{{- if .Payload.Request.MustHaveBody }}
 			if err == io.EOF {
@@ -571,17 +668,13 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 		}
 	{{- if .Payload.Request.ServerBody.ValidateRef }}
 		{{ .Payload.Request.ServerBody.ValidateRef }}
-		if err != nil {
-			return nil, err
-		}
+		{{- template "validation_error" $ }}
 	{{- end }}
 {{- end }}
 {{- if not .MultipartRequestDecoder }}
 	{{- template "request_elements" .Payload.Request }}
 	{{- if .Payload.Request.MustValidate }}
-		if err != nil {
-			return nil, err
-		}
+		{{- template "validation_error" $ }}
 	{{- end }}
 	{{- if .Payload.Request.PayloadInit }}
 	payload := {{ .Payload.Request.PayloadInit.Name }}({{ range .Payload.Request.PayloadInit.ServerArgs }}{{ .Ref }}, {{ end }})
@@ -618,7 +711,21 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 	return payload, nil
 	}
 }
-` + requestElementsT
+` + requestElementsT + validationErrorT
+
+// input: EndpointData
+const validationErrorT = `{{- define "validation_error" }}
+		if err != nil {
+	{{- if .LenientValidation }}
+			if err = goahttp.LenientValidation(r, err); err != nil {
+				return nil, err
+			}
+	{{- else }}
+			return nil, err
+	{{- end }}
+		}
+{{- end }}
+`
 
 // input: RequestData
 const requestElementsT = `{{- define "request_elements" }}
@@ -644,10 +751,14 @@ const requestElementsT = `{{- define "request_elements" }}
 		{{- if .Cookies }}
 			c *http.Cookie
 		{{- end }}
+		{{- if or .PathParams .QueryParams }}
+{{ end }}
 		{{- if .PathParams }}
-
 			params = mux.Vars(r)
 		{{- end }}
+		{{- if .QueryParams }}
+			qp = r.URL.Query()
+		{{- end }}
 		)
 
 {{- range .PathParams }}
@@ -668,13 +779,13 @@ const requestElementsT = `{{- define "request_elements" }}
 
 {{- range .QueryParams }}
 	{{- if and (or (eq .Type.Name "string") (eq .Type.Name "any")) .Required }}
-		{{ .VarName }} = r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }} = qp.Get("{{ .Name }}")
 		if {{ .VarName }} == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
 		}
 
 	{{- else if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
-		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }}Raw := qp.Get("{{ .Name }}")
 		if {{ .VarName }}Raw != "" {
 			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
 		}
@@ -683,8 +794,59 @@ const requestElementsT = `{{- define "request_elements" }}
 		}
 		{{- end }}
 
+	{{- else if .Fields }}
+	{
+		{{- $param := . }}
+		{{ .VarName }}Raw := &{{ slice .TypeRef 1 }}{}
+		var {{ .VarName }}Found bool
+		{{- range .Fields }}
+		{{- if .StringSlice }}
+		if {{ .VarName }}, ok := qp["{{ .Name }}"]; ok {
+			{{ $param.VarName }}Raw.{{ .FieldName }} = {{ .VarName }}
+			{{ $param.VarName }}Found = true
+		}
+		{{- else if .Slice }}
+		if {{ .VarName }}Raw, ok := qp["{{ .Name }}"]; ok {
+			var {{ .VarName }} {{ .TypeRef }}
+			{{- template "slice_conversion" . }}
+			{{ $param.VarName }}Raw.{{ .FieldName }} = {{ .VarName }}
+			{{ $param.VarName }}Found = true
+		}
+		{{- else }}
+		if {{ .VarName }}Raw := qp.Get("{{ .Name }}"); {{ .VarName }}Raw != "" {
+			{{- if or (eq .Type.Name "string") (eq .Type.Name "any") }}
+			{{ $param.VarName }}Raw.{{ .FieldName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+			{{- else }}
+			var {{ .VarName }} {{ .TypeRef }}
+			{{- template "type_conversion" . }}
+			{{ $param.VarName }}Raw.{{ .FieldName }} = {{ .VarName }}
+			{{- end }}
+			{{ $param.VarName }}Found = true
+		}
+		{{- if .DefaultValue }} else {
+			{{ $param.VarName }}Raw.{{ .FieldName }} = {{ printf "%#v" .DefaultValue }}
+		}
+		{{- end }}
+		{{- end }}
+		{{- end }}
+		if {{ .VarName }}Found {
+			{{ .VarName }} = {{ .VarName }}Raw
+			{{- range .Fields }}
+			{{- if .Required }}
+			if qp["{{ .Name }}"] == nil {
+				err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+			}
+			{{- end }}
+			{{- end }}
+		}
+		{{- if .Required }} else {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+		}
+		{{- end }}
+	}
+
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }} = {{ if eq .Style "csv" }}goahttp.SplitCSV(qp["{{ .Name }}"]){{ else }}qp["{{ .Name }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -701,7 +863,7 @@ const requestElementsT = `{{- define "request_elements" }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }}Raw := {{ if eq .Style "csv" }}goahttp.SplitCSV(qp["{{ .Name }}"]){{ else }}qp["{{ .Name }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }}Raw == nil {
 			return nil, goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -724,7 +886,7 @@ const requestElementsT = `{{- define "request_elements" }}
 
 	{{- else if .Map }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()
+		{{ .VarName }}Raw := qp
 		{{- if .Required }}
 		if len({{ .VarName }}Raw) == 0 {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -751,7 +913,7 @@ const requestElementsT = `{{- define "request_elements" }}
 
 	{{- else if .MapQueryParams }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()
+		{{ .VarName }}Raw := qp
 		{{- if .Required }}
 		if len({{ .VarName }}Raw) == 0 {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -778,7 +940,7 @@ const requestElementsT = `{{- define "request_elements" }}
 
 	{{- else }}{{/* not string, not any, not slice and not map */}}
 	{
-		{{ .VarName }}Raw := r.URL.Query().Get("{{ .Name }}")
+		{{ .VarName }}Raw := qp.Get("{{ .Name }}")
 		{{- if .Required }}
 		if {{ .VarName }}Raw == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -1083,6 +1245,36 @@ const typeConversionT = `{{- define "slice_conversion" }}
 			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "boolean"))
 		}
 		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "datetime" }}
+		v, err2 := time.Parse(time.RFC3339, {{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "datetime"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "date" }}
+		v, err2 := goa.ParseDate({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "date"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "duration" }}
+		v, err2 := goahttp.ParseDuration({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "duration"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "uuid" }}
+		v, err2 := uuid.Parse({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "uuid"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
+	{{- else if eq .Type.Name "decimal" }}
+		v, err2 := goa.ParseDecimal({{ .VarName }}Raw)
+		if err2 != nil {
+			err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "decimal"))
+		}
+		{{ .VarName }} = {{ if .Pointer }}&{{ end }}v
 	{{- else }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
@@ -1148,6 +1340,36 @@ const typeConversionT = `{{- define "slice_conversion" }}
 			{{ .VarName }}[i] = v
 		{{- else if eq .Type.ElemType.Type.Name "any" }}
 			{{ .VarName }}[i] = rv
+		{{- else if eq .Type.ElemType.Type.Name "datetime" }}
+			v, err2 := time.Parse(time.RFC3339, rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of datetimes"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "date" }}
+			v, err2 := goa.ParseDate(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of dates"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "duration" }}
+			v, err2 := goahttp.ParseDuration(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of durations"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "uuid" }}
+			v, err2 := uuid.Parse(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of uuids"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "decimal" }}
+			v, err2 := goa.ParseDecimal(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of decimals"))
+			}
+			{{ .VarName }}[i] = v
 		{{- else }}
 			// unsupported slice type {{ .Type.ElemType.Type.Name }} for var {{ .VarName }}
 		{{- end }}
@@ -1180,6 +1402,11 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 			{{- end -}}
 			{{ template "response" . }}
 			{{- if .ServerBody }}
+				{{- if .Masks }}
+				if !goahttp.IsUnmasked(ctx){{ range $.Result.UnmaskedViews }} && res.View != {{ printf "%q" . }}{{ end }} {
+					goahttp.MaskSensitive(body, {{ printf "%#v" .Masks }})
+				}
+				{{- end }}
 				return enc.Encode(body)
 			{{- else }}
 				return nil
@@ -1237,7 +1464,17 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 const responseT = `{{ define "response" -}}
 	{{- $servBodyLen := len .ServerBody }}
 	{{- if gt $servBodyLen 0 }}
-	enc := encoder(ctx, w)
+		{{- $encoder := "encoder" }}
+		{{- if .NDJSON }}
+			{{- $encoder = "goahttp.NDJSONResponseEncoder(encoder)" }}
+		{{- end }}
+		{{- if .EnvelopeKey }}
+	enc := goahttp.EnvelopeEncoder(ctx, encoder(ctx, w), {{ printf "%q" .EnvelopeKey }})
+		{{- else if .TableContentTypes }}
+	enc := goahttp.TableEncoder(ctx, w, {{ $encoder }}{{ range .TableContentTypes }}, {{ printf "%q" . }}{{ end }})
+		{{- else }}
+	enc := {{ $encoder }}(ctx, w)
+		{{- end }}
 	{{- end }}
 	{{- if gt $servBodyLen 0 }}
 		{{- if and (gt $servBodyLen 1) $.ViewedResult }}
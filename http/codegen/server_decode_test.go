@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 
@@ -43,6 +44,8 @@ func TestDecode(t *testing.T) {
 		{"query-array-bool", testdata.PayloadQueryArrayBoolDSL, testdata.PayloadQueryArrayBoolDecodeCode},
 		{"query-array-bool-validate", testdata.PayloadQueryArrayBoolValidateDSL, testdata.PayloadQueryArrayBoolValidateDecodeCode},
 		{"query-array-int", testdata.PayloadQueryArrayIntDSL, testdata.PayloadQueryArrayIntDecodeCode},
+		{"query-array-csv", testdata.PayloadQueryArrayCSVDSL, testdata.PayloadQueryArrayCSVDecodeCode},
+		{"query-object", testdata.PayloadQueryObjectDSL, testdata.PayloadQueryObjectDecodeCode},
 		{"query-array-int-validate", testdata.PayloadQueryArrayIntValidateDSL, testdata.PayloadQueryArrayIntValidateDecodeCode},
 		{"query-array-int32", testdata.PayloadQueryArrayInt32DSL, testdata.PayloadQueryArrayInt32DecodeCode},
 		{"query-array-int32-validate", testdata.PayloadQueryArrayInt32ValidateDSL, testdata.PayloadQueryArrayInt32ValidateDecodeCode},
@@ -111,6 +114,7 @@ func TestDecode(t *testing.T) {
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringDecodeCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateDecodeCode},
+		{"header-string-validate-in-header", testdata.PayloadHeaderStringValidateInHeaderDSL, testdata.PayloadHeaderStringValidateInHeaderDecodeCode},
 		{"header-array-string", testdata.PayloadHeaderArrayStringDSL, testdata.PayloadHeaderArrayStringDecodeCode},
 		{"header-array-string-validate", testdata.PayloadHeaderArrayStringValidateDSL, testdata.PayloadHeaderArrayStringValidateDecodeCode},
 
@@ -134,6 +138,13 @@ func TestDecode(t *testing.T) {
 		{"cookie-primitive-string-default", testdata.PayloadCookiePrimitiveStringDefaultDSL, testdata.PayloadCookiePrimitiveStringDefaultDecodeCode},
 
 		{"body-string", testdata.PayloadBodyStringDSL, testdata.PayloadBodyStringDecodeCode},
+		{"body-string-consumes", testdata.PayloadBodyStringConsumesDSL, testdata.PayloadBodyStringConsumesDecodeCode},
+		{"body-strict", testdata.PayloadBodyStrictDSL, testdata.PayloadBodyStrictDecodeCode},
+		{"body-strict-warn", testdata.PayloadBodyStrictWarnDSL, testdata.PayloadBodyStrictWarnDecodeCode},
+		{"body-lenient", testdata.PayloadBodyLenientDSL, testdata.PayloadBodyLenientDecodeCode},
+		{"body-nullable", testdata.PayloadBodyNullableDSL, testdata.PayloadBodyNullableDecodeCode},
+		{"time-types", testdata.PayloadTimeTypesDSL, testdata.PayloadTimeTypesDecodeCode},
+		{"decimal", testdata.PayloadDecimalDSL, testdata.PayloadDecimalDecodeCode},
 		{"body-string-validate", testdata.PayloadBodyStringValidateDSL, testdata.PayloadBodyStringValidateDecodeCode},
 		{"body-user", testdata.PayloadBodyUserDSL, testdata.PayloadBodyUserDecodeCode},
 		{"body-user-required", testdata.PayloadBodyUserRequiredDSL, testdata.PayloadBodyUserRequiredDecodeCode},
@@ -148,6 +159,7 @@ func TestDecode(t *testing.T) {
 		{"body-array-string", testdata.PayloadBodyArrayStringDSL, testdata.PayloadBodyArrayStringDecodeCode},
 		{"body-array-string-validate", testdata.PayloadBodyArrayStringValidateDSL, testdata.PayloadBodyArrayStringValidateDecodeCode},
 		{"body-array-user", testdata.PayloadBodyArrayUserDSL, testdata.PayloadBodyArrayUserDecodeCode},
+		{"body-ndjson", testdata.PayloadBodyNDJSONDSL, testdata.PayloadBodyNDJSONDecodeCode},
 		{"body-array-user-validate", testdata.PayloadBodyArrayUserValidateDSL, testdata.PayloadBodyArrayUserValidateDecodeCode},
 		{"body-map-string", testdata.PayloadBodyMapStringDSL, testdata.PayloadBodyMapStringDecodeCode},
 		{"body-map-string-validate", testdata.PayloadBodyMapStringValidateDSL, testdata.PayloadBodyMapStringValidateDecodeCode},
@@ -184,6 +196,7 @@ func TestDecode(t *testing.T) {
 		{"map-query-primitive-array", testdata.PayloadMapQueryPrimitiveArrayDSL, testdata.PayloadMapQueryPrimitiveArrayDecodeCode},
 		{"map-query-object", testdata.PayloadMapQueryObjectDSL, testdata.PayloadMapQueryObjectDecodeCode},
 		{"multipart-body-primitive", testdata.PayloadMultipartPrimitiveDSL, testdata.PayloadMultipartPrimitiveDecodeCode},
+		{"multipart-body-consumes", testdata.PayloadMultipartConsumesDSL, testdata.PayloadMultipartConsumesDecodeCode},
 		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.PayloadMultipartUserTypeDecodeCode},
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.PayloadMultipartArrayTypeDecodeCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.PayloadMultipartMapTypeDecodeCode},
@@ -227,3 +240,33 @@ func TestDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeFile(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"lenient", testdata.ServerLenientFileDSL, testdata.ServerLenientFileCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("", expr.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			var buf bytes.Buffer
+			buf.WriteString("package foo\n")
+			for _, s := range fs[1].SectionTemplates[1:] {
+				if err := s.Write(&buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := codegen.FormatTestCode(t, buf.String())
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
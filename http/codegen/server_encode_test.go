@@ -51,10 +51,13 @@ func TestEncode(t *testing.T) {
 		{"body-string", testdata.ResultBodyStringDSL, testdata.ResultBodyStringEncodeCode},
 		{"body-object", testdata.ResultBodyObjectDSL, testdata.ResultBodyObjectEncodeCode},
 		{"body-user", testdata.ResultBodyUserDSL, testdata.ResultBodyUserEncodeCode},
+		{"body-envelope", testdata.ResultBodyEnvelopeDSL, testdata.ResultBodyEnvelopeEncodeCode},
 		{"body-union", testdata.ResultBodyUnionDSL, testdata.ResultBodyUnionEncodeCode},
 		{"body-result-multiple-views", testdata.ResultBodyMultipleViewsDSL, testdata.ResultBodyMultipleViewsEncodeCode},
 		{"body-result-collection-multiple-views", testdata.ResultBodyCollectionDSL, testdata.ResultBodyCollectionMultipleViewsEncodeCode},
 		{"body-result-collection-explicit-view", testdata.ResultBodyCollectionExplicitViewDSL, testdata.ResultBodyCollectionExplicitViewEncodeCode},
+		{"body-result-collection-table", testdata.ResultCollectionTableDSL, testdata.ResultCollectionTableEncodeCode},
+		{"body-result-collection-ndjson", testdata.PayloadBodyNDJSONDSL, testdata.ResultCollectionNDJSONEncodeCode},
 		{"empty-body-result-multiple-views", testdata.EmptyBodyResultMultipleViewsDSL, testdata.EmptyBodyResultMultipleViewsEncodeCode},
 		{"body-array-string", testdata.ResultBodyArrayStringDSL, testdata.ResultBodyArrayStringEncodeCode},
 		{"body-array-user", testdata.ResultBodyArrayUserDSL, testdata.ResultBodyArrayUserEncodeCode},
@@ -65,6 +68,10 @@ func TestEncode(t *testing.T) {
 		{"body-primitive-array-bool", testdata.ResultBodyPrimitiveArrayBoolDSL, testdata.ResultBodyPrimitiveArrayBoolEncodeCode},
 		{"body-primitive-array-user", testdata.ResultBodyPrimitiveArrayUserDSL, testdata.ResultBodyPrimitiveArrayUserEncodeCode},
 		{"body-inline-object", testdata.ResultBodyInlineObjectDSL, testdata.ResultBodyInlineObjectEncodeCode},
+		{"body-sensitive", testdata.ResultBodySensitiveDSL, testdata.ResultBodySensitiveEncodeCode},
+		{"body-sensitive-views", testdata.ResultBodySensitiveViewsDSL, testdata.ResultBodySensitiveViewsEncodeCode},
+		{"body-nested-sensitive", testdata.ResultBodyNestedSensitiveDSL, testdata.ResultBodyNestedSensitiveEncodeCode},
+		{"body-sensitive-wire-name", testdata.ResultBodySensitiveWireNameDSL, testdata.ResultBodySensitiveWireNameEncodeCode},
 
 		{"body-header-object", testdata.ResultBodyHeaderObjectDSL, testdata.ResultBodyHeaderObjectEncodeCode},
 		{"body-header-user", testdata.ResultBodyHeaderUserDSL, testdata.ResultBodyHeaderUserEncodeCode},
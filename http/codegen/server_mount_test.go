@@ -25,6 +25,8 @@ func TestServerMount(t *testing.T) {
 		{"multiple files mounter /w prefix path", testdata.ServerMultipleFilesWithPrefixPathDSL, testdata.ServerMultipleFilesWithPrefixPathMounterCode, 1, 10},
 		{"multiple files with a redirect constructor", testdata.ServerMultipleFilesWithRedirectDSL, testdata.ServerMultipleFilesWithRedirectConstructorCode, 1, 6},
 		{"multiple files with a redirect mounter", testdata.ServerMultipleFilesWithRedirectDSL, testdata.ServerMultipleFilesMounterCode, 1, 10},
+		{"alias constructor", testdata.ServerAliasDSL, testdata.ServerAliasConstructorCode, 2, 3},
+		{"alias handler mounter", testdata.ServerAliasDSL, testdata.ServerAliasHandlerMounterCode, 2, 7},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -88,6 +88,7 @@ func TestPayloadConstructor(t *testing.T) {
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringConstructorCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateConstructorCode},
+		{"header-string-validate-in-header", testdata.PayloadHeaderStringValidateInHeaderDSL, testdata.PayloadHeaderStringValidateInHeaderConstructorCode},
 		{"header-array-string", testdata.PayloadHeaderArrayStringDSL, testdata.PayloadHeaderArrayStringConstructorCode},
 		{"header-array-string-validate", testdata.PayloadHeaderArrayStringValidateDSL, testdata.PayloadHeaderArrayStringValidateConstructorCode},
 
@@ -81,8 +81,8 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				validatedTypes = append(validatedTypes, data)
 			}
 		}
-		if adata.ServerWebSocket != nil {
-			if data := adata.ServerWebSocket.Payload; data != nil {
+		if ws := serverStream(adata); ws != nil {
+			if data := ws.Payload; data != nil {
 				if data.Def != "" {
 					sections = append(sections, &codegen.SectionTemplate{
 						Name:   "request-stream-payload-type-decl",
@@ -110,6 +110,13 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 							Data:   tdata,
 						})
 					}
+					if len(tdata.Columns) > 0 {
+						sections = append(sections, &codegen.SectionTemplate{
+							Name:   "response-server-body-table",
+							Source: tableT,
+							Data:   tdata,
+						})
+					}
 					if tdata.Init != nil {
 						initData = append(initData, tdata.Init)
 					}
@@ -180,8 +187,8 @@ func serverType(genpkg string, svc *expr.HTTPServiceExpr, seen map[string]struct
 				FuncMap: map[string]interface{}{"fieldCode": fieldCode},
 			})
 		}
-		if isWebSocketEndpoint(adata) && adata.ServerWebSocket.Payload != nil {
-			if init := adata.ServerWebSocket.Payload.Init; init != nil {
+		if ws := serverStream(adata); ws != nil && ws.Payload != nil {
+			if init := ws.Payload.Init; init != nil {
 				sections = append(sections, &codegen.SectionTemplate{
 					Name:    "server-payload-init",
 					Source:  serverTypeInitT,
@@ -22,7 +22,10 @@ func TestServerTypes(t *testing.T) {
 		{"result-type-validate", testdata.ResultTypeValidateDSL, ResultTypeValidateServerTypesFile},
 		{"with-result-collection", testdata.ResultWithResultCollectionDSL, ResultWithResultCollectionServerTypesFile},
 		{"with-result-view", testdata.ResultWithResultViewDSL, ResultWithResultViewServerTypesFile},
+		{"with-result-collection-table", testdata.ResultCollectionTableDSL, ResultCollectionTableServerTypesFile},
 		{"empty-error-response-body", testdata.EmptyErrorResponseBodyDSL, ""},
+		{"wire-name", testdata.PayloadWireNameDSL, WireNameServerTypesFile},
+		{"union-discriminator", testdata.PayloadUnionDiscriminatorDSL, UnionDiscriminatorServerTypesFile},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -302,3 +305,240 @@ func NewMethodResultWithResultViewResponseBodyFull(res *serviceresultwithresultv
 	return body
 }
 `
+
+const WireNameServerTypesFile = `// MethodWireNameRequestBody is the type of the "ServiceWireName" service
+// "MethodWireName" endpoint HTTP request body.
+type MethodWireNameRequestBody struct {
+	SelfURL     *string ` + "`" + `form:"selfURL,omitempty" json:"selfURL,omitempty" xml:"selfURL,omitempty"` + "`" + `
+	DisplayName *string ` + "`" + `form:"display_name,omitempty" json:"display_name,omitempty" xml:"display_name,omitempty"` + "`" + `
+}
+
+// MethodWireNameResponseBody is the type of the "ServiceWireName" service
+// "MethodWireName" endpoint HTTP response body.
+type MethodWireNameResponseBody struct {
+	SelfURL     string  ` + "`" + `form:"selfURL" json:"selfURL" xml:"selfURL"` + "`" + `
+	DisplayName *string ` + "`" + `form:"display_name,omitempty" json:"display_name,omitempty" xml:"display_name,omitempty"` + "`" + `
+}
+
+// NewMethodWireNameResponseBody builds the HTTP response body from the result
+// of the "MethodWireName" endpoint of the "ServiceWireName" service.
+func NewMethodWireNameResponseBody(res *servicewirename.Account) *MethodWireNameResponseBody {
+	body := &MethodWireNameResponseBody{
+		SelfURL:     res.SelfURL,
+		DisplayName: res.DisplayName,
+	}
+	return body
+}
+
+// NewMethodWireNameAccount builds a ServiceWireName service MethodWireName
+// endpoint payload.
+func NewMethodWireNameAccount(body *MethodWireNameRequestBody) *servicewirename.Account {
+	v := &servicewirename.Account{
+		SelfURL:     *body.SelfURL,
+		DisplayName: body.DisplayName,
+	}
+
+	return v
+}
+
+// ValidateMethodWireNameRequestBody runs the validations defined on
+// MethodWireNameRequestBody
+func ValidateMethodWireNameRequestBody(body *MethodWireNameRequestBody) (err error) {
+	if body.SelfURL == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("selfURL", "body"))
+	}
+	if body.SelfURL != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.selfURL", *body.SelfURL, goa.FormatURI))
+	}
+	return
+}
+`
+
+const UnionDiscriminatorServerTypesFile = `// MethodUnionDiscriminatorRequestBody is the type of the
+// "ServiceUnionDiscriminator" service "MethodUnionDiscriminator" endpoint HTTP
+// request body.
+type MethodUnionDiscriminatorRequestBody struct {
+	Pets []*PetRequestBody ` + "`" + `form:"pets,omitempty" json:"pets,omitempty" xml:"pets,omitempty"` + "`" + `
+}
+
+// MethodUnionDiscriminatorResponseBody is the type of the
+// "ServiceUnionDiscriminator" service "MethodUnionDiscriminator" endpoint HTTP
+// response body.
+type MethodUnionDiscriminatorResponseBody struct {
+	Pets []*PetResponseBody ` + "`" + `form:"pets,omitempty" json:"pets,omitempty" xml:"pets,omitempty"` + "`" + `
+}
+
+// PetResponseBody is used to define fields on response body types.
+type PetResponseBody struct {
+	Pet *struct {
+		// Union type name, one of:
+		// - "cat"
+		// - "dog"
+		Kind  string  ` + "`" + `form:"kind" json:"kind" xml:"kind"` + "`" + `
+		Name  *string ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+		Lives *int    ` + "`" + `form:"lives,omitempty" json:"lives,omitempty" xml:"lives,omitempty"` + "`" + `
+		Barks *bool   ` + "`" + `form:"barks,omitempty" json:"barks,omitempty" xml:"barks,omitempty"` + "`" + `
+	} ` + "`" + `form:"pet,omitempty" json:"pet,omitempty" xml:"pet,omitempty"` + "`" + `
+}
+
+// PetRequestBody is used to define fields on request body types.
+type PetRequestBody struct {
+	Pet *struct {
+		// Union type name, one of:
+		// - "cat"
+		// - "dog"
+		Kind  *string ` + "`" + `form:"kind" json:"kind" xml:"kind"` + "`" + `
+		Name  *string ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+		Lives *int    ` + "`" + `form:"lives,omitempty" json:"lives,omitempty" xml:"lives,omitempty"` + "`" + `
+		Barks *bool   ` + "`" + `form:"barks,omitempty" json:"barks,omitempty" xml:"barks,omitempty"` + "`" + `
+	} ` + "`" + `form:"pet,omitempty" json:"pet,omitempty" xml:"pet,omitempty"` + "`" + `
+}
+
+// NewMethodUnionDiscriminatorResponseBody builds the HTTP response body from
+// the result of the "MethodUnionDiscriminator" endpoint of the
+// "ServiceUnionDiscriminator" service.
+func NewMethodUnionDiscriminatorResponseBody(res *serviceuniondiscriminator.Pets) *MethodUnionDiscriminatorResponseBody {
+	body := &MethodUnionDiscriminatorResponseBody{}
+	if res.Pets != nil {
+		body.Pets = make([]*PetResponseBody, len(res.Pets))
+		for i, val := range res.Pets {
+			body.Pets[i] = marshalServiceuniondiscriminatorPetToPetResponseBody(val)
+		}
+	}
+	return body
+}
+
+// NewMethodUnionDiscriminatorPets builds a ServiceUnionDiscriminator service
+// MethodUnionDiscriminator endpoint payload.
+func NewMethodUnionDiscriminatorPets(body *MethodUnionDiscriminatorRequestBody) *serviceuniondiscriminator.Pets {
+	v := &serviceuniondiscriminator.Pets{}
+	if body.Pets != nil {
+		v.Pets = make([]*serviceuniondiscriminator.Pet, len(body.Pets))
+		for i, val := range body.Pets {
+			v.Pets[i] = unmarshalPetRequestBodyToServiceuniondiscriminatorPet(val)
+		}
+	}
+
+	return v
+}
+
+// ValidateMethodUnionDiscriminatorRequestBody runs the validations defined on
+// MethodUnionDiscriminatorRequestBody
+func ValidateMethodUnionDiscriminatorRequestBody(body *MethodUnionDiscriminatorRequestBody) (err error) {
+	for _, e := range body.Pets {
+		if e != nil {
+			if err2 := ValidatePetRequestBody(e); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// ValidatePetRequestBody runs the validations defined on PetRequestBody
+func ValidatePetRequestBody(body *PetRequestBody) (err error) {
+	if body.Pet != nil {
+		if body.Pet.Kind == nil {
+			err = goa.MergeErrors(err, goa.MissingFieldError("kind", "body.pet"))
+		}
+		if body.Pet.Kind != nil {
+			if !(*body.Pet.Kind == "cat" || *body.Pet.Kind == "dog") {
+				err = goa.MergeErrors(err, goa.InvalidEnumValueError("body.pet.kind", *body.Pet.Kind, []interface{}{"cat", "dog"}))
+			}
+		}
+	}
+	return
+}
+`
+
+const ResultCollectionTableServerTypesFile = `// ResulttypetableResponseCollection is the type of the
+// "ServiceCollectionTable" service "MethodCollectionTable" endpoint HTTP
+// response body.
+type ResulttypetableResponseCollection []*ResulttypetableResponse
+
+// TableHeader returns the names of the columns of the CSV and XLSX
+// representations of ResulttypetableResponseCollection.
+func (body ResulttypetableResponseCollection) TableHeader() []string {
+	return []string{"ID", "name", "score"}
+}
+
+// TableRows returns the rows of the CSV and XLSX representations of
+// ResulttypetableResponseCollection.
+func (body ResulttypetableResponseCollection) TableRows() [][]interface{} {
+	rows := make([][]interface{}, len(body))
+	for i, v := range body {
+		row := make([]interface{}, 3)
+		rows[i] = row
+		if v == nil {
+			continue
+		}
+		row[0] = v.ID
+		if v.Name != nil {
+			row[1] = *v.Name
+		}
+		row[2] = v.Score
+	}
+	return rows
+}
+
+// ResulttypetableResponseTinyCollection is the type of the
+// "ServiceCollectionTable" service "MethodCollectionTable" endpoint HTTP
+// response body.
+type ResulttypetableResponseTinyCollection []*ResulttypetableResponseTiny
+
+// TableHeader returns the names of the columns of the CSV and XLSX
+// representations of ResulttypetableResponseTinyCollection.
+func (body ResulttypetableResponseTinyCollection) TableHeader() []string {
+	return []string{"ID"}
+}
+
+// TableRows returns the rows of the CSV and XLSX representations of
+// ResulttypetableResponseTinyCollection.
+func (body ResulttypetableResponseTinyCollection) TableRows() [][]interface{} {
+	rows := make([][]interface{}, len(body))
+	for i, v := range body {
+		row := make([]interface{}, 1)
+		rows[i] = row
+		if v == nil {
+			continue
+		}
+		row[0] = v.ID
+	}
+	return rows
+}
+
+// ResulttypetableResponse is used to define fields on response body types.
+type ResulttypetableResponse struct {
+	ID    int      ` + "`" + `form:"id" json:"id" xml:"id"` + "`" + `
+	Name  *string  ` + "`" + `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"` + "`" + `
+	Score float64  ` + "`" + `form:"score" json:"score" xml:"score"` + "`" + `
+	Tags  []string ` + "`" + `form:"tags,omitempty" json:"tags,omitempty" xml:"tags,omitempty"` + "`" + `
+}
+
+// ResulttypetableResponseTiny is used to define fields on response body types.
+type ResulttypetableResponseTiny struct {
+	ID int ` + "`" + `form:"id" json:"id" xml:"id"` + "`" + `
+}
+
+// NewResulttypetableResponseCollection builds the HTTP response body from the
+// result of the "MethodCollectionTable" endpoint of the
+// "ServiceCollectionTable" service.
+func NewResulttypetableResponseCollection(res servicecollectiontableviews.ResulttypetableCollectionView) ResulttypetableResponseCollection {
+	body := make([]*ResulttypetableResponse, len(res))
+	for i, val := range res {
+		body[i] = marshalServicecollectiontableviewsResulttypetableViewToResulttypetableResponse(val)
+	}
+	return body
+}
+
+// NewResulttypetableResponseTinyCollection builds the HTTP response body from
+// the result of the "MethodCollectionTable" endpoint of the
+// "ServiceCollectionTable" service.
+func NewResulttypetableResponseTinyCollection(res servicecollectiontableviews.ResulttypetableCollectionView) ResulttypetableResponseTinyCollection {
+	body := make([]*ResulttypetableResponseTiny, len(res))
+	for i, val := range res {
+		body[i] = marshalServicecollectiontableviewsResulttypetableViewToResulttypetableResponseTiny(val)
+	}
+	return body
+}
+`
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"goa.design/goa/v3/codegen"
 	"goa.design/goa/v3/codegen/service"
@@ -88,6 +89,29 @@ type (
 		ClientTransformHelpers []*codegen.TransformFunctionData
 		// Scope initialized with all the server and client types.
 		Scope *codegen.NameScope
+		// Envelope describes the envelope that wraps the response
+		// bodies, nil if the design does not define one.
+		Envelope *EnvelopeData
+		// Hosts lists the HTTP hosts of the servers that expose the
+		// service.
+		Hosts []*HostData
+		// TransportSchemes lists the security schemes enforced by the
+		// HTTP transport that secure the service endpoints.
+		TransportSchemes []*TransportSchemeData
+	}
+
+	// TransportSchemeData describes a security scheme enforced by the HTTP
+	// transport rather than by the endpoint auth functions.
+	TransportSchemeData struct {
+		// SchemeName is the name of the scheme.
+		SchemeName string
+		// VarName is the Go name of the scheme.
+		VarName string
+		// Kind is the kind of scheme, one of "Signature" or "MutualTLS".
+		Kind string
+		// Methods lists the Go names of the methods whose endpoints are
+		// secured by the scheme.
+		Methods []string
 	}
 
 	// EndpointData contains the data used to render the code related to a
@@ -139,14 +163,51 @@ type (
 		ResponseEncoder string
 		// ErrorEncoder is the name of the error encoder function.
 		ErrorEncoder string
+		// Consumes lists the mime types accepted in the request body, the
+		// request decoder rejects requests with other content types.
+		Consumes []string
+		// StrictPayload is "reject" or "warn" if the request decoder
+		// checks the request body for unknown fields, empty otherwise.
+		StrictPayload string
+		// LenientValidation is true if the request decoder downgrades
+		// validation errors to warnings when lenient validation is
+		// enabled at runtime, see goahttp.WithLenientValidation.
+		LenientValidation bool
+		// NullableFields maps the wire names of the nullable request body
+		// attributes to the corresponding payload attribute names. The
+		// request decoder records which of these attributes are present
+		// in the request body, see goa.WasSet.
+		NullableFields map[string]string
 		// MultipartRequestDecoder indicates the request decoder for
 		// multipart content type.
 		MultipartRequestDecoder *MultipartData
 		// ServerWebSocket holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerWebSocket *WebSocketData
+		// ServerBodyStream holds the data to render the server struct
+		// which implements the server stream interface by reading the
+		// streamed elements from the request body.
+		ServerBodyStream *WebSocketData
+		// Envelope describes the envelope that wraps the response
+		// bodies, nil if the design does not define one.
+		Envelope *EnvelopeData
 		// Redirect defines a redirect for the endpoint.
 		Redirect *RedirectData
+		// Download describes the file streamed in the response body,
+		// nil if the endpoint does not use the Download DSL.
+		Download *DownloadData
+		// AcceptRanges indicates that the handler serves the byte ranges
+		// requested with the Range header.
+		AcceptRanges bool
+		// Push lists the paths of the resources pushed to HTTP/2 clients
+		// before writing the success responses.
+		Push []string
+		// Aliases lists the legacy paths mounted by the server in
+		// addition to the routes.
+		Aliases []*AliasData
+		// Proxy describes the backend the requests are forwarded to,
+		// nil if the endpoint does not use the Proxy DSL.
+		Proxy *ProxyData
 
 		// client
 
@@ -167,6 +228,10 @@ type (
 		// ClientWebSocket holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientWebSocket *WebSocketData
+		// ClientBodyStream holds the data to render the client struct
+		// which implements the client stream interface by writing the
+		// streamed elements to the request body.
+		ClientBodyStream *WebSocketData
 		// BuildStreamPayload is the name of the function used to create the
 		// payload for endpoints that use SkipRequestBodyEncodeDecode.
 		BuildStreamPayload string
@@ -203,6 +268,52 @@ type (
 		StatusCode string
 	}
 
+	// AliasData describes a legacy path mounted by the server.
+	AliasData struct {
+		// Verb is the HTTP method.
+		Verb string
+		// Path is the legacy full path including wildcards.
+		Path string
+		// Target is the full path of the route the requests are
+		// redirected to if StatusCode is not empty.
+		Target string
+		// StatusCode is the redirect status code constant, empty if the
+		// requests are handled by the endpoint.
+		StatusCode string
+	}
+
+	// ProxyData lists the data needed to generate the reverse proxy of an
+	// endpoint.
+	ProxyData struct {
+		// URL is the backend URL.
+		URL string
+		// Timeout is the maximum duration of the forwarded requests.
+		Timeout time.Duration
+		// RequestHeaders lists the headers set on the forwarded
+		// requests.
+		RequestHeaders []*ProxyHeaderData
+		// ResponseHeaders lists the headers set on the backend
+		// responses.
+		ResponseHeaders []*ProxyHeaderData
+	}
+
+	// ProxyHeaderData describes a header rewritten by a reverse proxy.
+	ProxyHeaderData struct {
+		// Name is the header name.
+		Name string
+		// Value is the header value, empty to remove the header.
+		Value string
+	}
+
+	// DownloadData lists the data needed to set the headers of the
+	// responses of endpoints that stream a file.
+	DownloadData struct {
+		// ContentType is the MIME type of the file.
+		ContentType string
+		// Disposition is the Content-Disposition type.
+		Disposition string
+	}
+
 	// PayloadData contains the payload information required to generate the
 	// transport decode (server) and encode (client) code.
 	PayloadData struct {
@@ -240,6 +351,9 @@ type (
 		// the result variable only if there are multiple responses, or the
 		// response has a body, a header or a cookie.
 		MustInit bool
+		// UnmaskedViews lists the result views that render sensitive
+		// attributes unmasked.
+		UnmaskedViews []string
 	}
 
 	// ErrorGroupData contains the error information required to generate
@@ -302,6 +416,9 @@ type (
 		// Multipart if true indicates the request is a multipart
 		// request.
 		Multipart bool
+		// NDJSON is true if the request body is a collection that may be
+		// sent as newline-delimited JSON.
+		NDJSON bool
 	}
 
 	// ResponseData describes a response.
@@ -320,6 +437,19 @@ type (
 		// ErrorHeader contains the value of the response "goa-error"
 		// header if any.
 		ErrorHeader string
+		// EnvelopeKey is the name of the envelope attribute that wraps
+		// the response body if any.
+		EnvelopeKey string
+		// TableContentTypes lists the content types of the tabular
+		// representations of the response body, see TypeData.Columns.
+		TableContentTypes []string
+		// Masks maps the paths of the response body attributes marked as
+		// sensitive in the design to the corresponding mask values, see
+		// goahttp.MaskSensitive.
+		Masks map[string]string
+		// NDJSON is true if the response body is a collection that may
+		// be written as newline-delimited JSON.
+		NDJSON bool
 		// ServerBody is the type of the response body used by server
 		// code, nil if body should be empty. The type does NOT use
 		// pointers for all fields. If the method result is a result
@@ -360,6 +490,39 @@ type (
 		ViewedResult *service.ViewedResultTypeData
 	}
 
+	// EnvelopeData describes the envelope that wraps the response bodies.
+	EnvelopeData struct {
+		// DataKey is the name of the envelope attribute that holds the
+		// success response bodies.
+		DataKey string
+		// ErrorKey is the name of the envelope attribute that holds the
+		// error response bodies, empty if error responses are not
+		// wrapped.
+		ErrorKey string
+	}
+
+	// HostData describes a HTTP host of a server that exposes the service.
+	HostData struct {
+		// Server is the name of the server.
+		Server string
+		// Name is the name of the host.
+		Name string
+		// URIs lists the HTTP URIs of the host.
+		URIs []string
+		// Variables lists the host URI variables.
+		Variables []*HostVariableData
+	}
+
+	// HostVariableData describes a host URI variable.
+	HostVariableData struct {
+		// Name is the name of the variable.
+		Name string
+		// DefaultValue is the variable default value.
+		DefaultValue string
+		// Values lists the allowed values if any.
+		Values []string
+	}
+
 	// InitData contains the data required to render a constructor.
 	InitData struct {
 		// Name is the constructor function name.
@@ -483,6 +646,13 @@ type (
 		// to the entire payload (empty string) or a payload attribute
 		// (attribute name).
 		MapQueryParams *string
+		// Style is the query string serialization style set with the
+		// "http:query:style" metadata, one of "csv", "multi" or
+		// "deepObject". Empty if not set.
+		Style string
+		// Fields lists the attributes of an object query parameter. Each
+		// field is encoded with its own key, e.g. "filter[name]".
+		Fields []*Element
 	}
 
 	// HeaderData describes a HTTP request or response header.
@@ -530,6 +700,21 @@ type (
 		Example interface{}
 		// View is the view used to render the (result) type if any.
 		View string
+		// Columns lists the columns of the CSV and XLSX representations
+		// of the collection response body type if any.
+		Columns []*ColumnData
+	}
+
+	// ColumnData describes a column of the CSV and XLSX representations of
+	// a collection response body.
+	ColumnData struct {
+		// Name is the column name.
+		Name string
+		// FieldName is the name of the element struct field that holds
+		// the column values.
+		FieldName string
+		// Pointer is true if the field holds a pointer.
+		Pointer bool
 	}
 
 	// MultipartData contains the data needed to render multipart
@@ -595,6 +780,9 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 		ServerTypeNames:  make(map[string]bool),
 		ClientTypeNames:  make(map[string]bool),
 		Scope:            scope,
+		Envelope:         buildEnvelopeData(expr.Root.API.HTTP.Envelope),
+		Hosts:            buildHostsData(svc.Name),
+		TransportSchemes: buildTransportSchemesData(hs, svc),
 	}
 
 	for _, s := range hs.FileServers {
@@ -726,6 +914,10 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				var rs service.SchemesData
 				for _, sch := range req.Schemes {
 					s := service.BuildSchemeData(sch, a.MethodExpr)
+					if s == nil {
+						// scheme enforced by the transport
+						continue
+					}
 					rs = rs.Append(s)
 					switch s.Type {
 					case "Basic":
@@ -741,6 +933,9 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 						}
 					}
 				}
+				if len(rs) == 0 {
+					continue
+				}
 				reqs = append(reqs, &service.RequirementData{Schemes: rs, Scopes: req.Scopes})
 			}
 		}
@@ -784,7 +979,7 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 				"Args":         args,
 				"PathInit":     routes[0].PathInit,
 				"Verb":         routes[0].Verb,
-				"IsStreaming":  a.MethodExpr.IsStreaming(),
+				"IsStreaming":  a.MethodExpr.IsStreaming() && !a.StreamRequestBody,
 			}
 			if a.SkipRequestBodyEncodeDecode {
 				data["RequestStruct"] = pkg + "." + ep.RequestStruct
@@ -826,10 +1021,31 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
 			Requirements:    reqs,
-		}
-		if a.MethodExpr.IsStreaming() {
+			Envelope:        rd.Envelope,
+			AcceptRanges:    a.AcceptRanges,
+			Push:            a.Push,
+			Aliases:         buildAliasesData(a, routes),
+			Proxy:           buildProxyData(a),
+		}
+		if a.StreamRequestBody {
+			initBodyStreamData(ad, a, rd)
+		} else if a.MethodExpr.IsStreaming() {
 			initWebSocketData(ad, a, rd)
 		}
+		if a.MultipartRequest {
+			ad.Consumes = multipartConsumes(a.Consumes)
+		} else if a.Body.Type != expr.Empty {
+			ad.Consumes = a.Consumes
+		}
+		if a.Body.Type != expr.Empty && !a.MultipartRequest && a.StrictPayload != "off" {
+			ad.StrictPayload = a.StrictPayload
+		}
+		if l, ok := a.MethodExpr.Meta["validation:lenient"]; ok && (len(l) == 0 || l[0] != "false") {
+			ad.LenientValidation = true
+		}
+		if a.Body.Type != expr.Empty && !a.MultipartRequest && !a.StreamRequestBody {
+			ad.NullableFields = nullableFields(a.Body)
+		}
 
 		if a.MultipartRequest {
 			ad.MultipartRequestDecoder = &MultipartData{
@@ -854,6 +1070,13 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 			ad.BuildStreamPayload = scope.Unique("Build" + codegen.Goify(ep.Name, true) + "StreamPayload")
 		}
 
+		if a.Download != nil {
+			ad.Download = &DownloadData{
+				ContentType: a.Download.ContentType,
+				Disposition: a.Download.Disposition,
+			}
+		}
+
 		if a.Redirect != nil {
 			ad.Redirect = &RedirectData{
 				URL:        a.Redirect.URL,
@@ -916,7 +1139,9 @@ func (d ServicesData) analyze(hs *expr.HTTPServiceExpr) *ServiceData {
 // makeHTTPType traverses the attribute recursively and performs these actions:
 //
 // * removes aliased user type by replacing them with the underlying type.
-// * changes unions into structs with Type and Value fields.
+// * changes unions into structs with Type and Value fields or, for unions
+//   with a discriminator, into structs with the discriminator and the fields
+//   of all the union types.
 //
 func makeHTTPType(att *expr.AttributeExpr) *expr.AttributeExpr {
 	if att == nil {
@@ -959,6 +1184,26 @@ func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *e
 		}
 		att.Type = &obj
 	case *expr.Union:
+		if dt.Discriminator != "" {
+			uatt := expr.DiscriminatedUnionObject(dt)
+			for _, nat := range *expr.AsObject(uatt.Type) {
+				nat.Attribute = makeHTTPTypeRecursive(nat.Attribute, seen)
+				tag := uatt.WireName(nat.Name)
+				if nat.Name != dt.Discriminator {
+					tag += ",omitempty"
+				}
+				if nat.Attribute.Meta == nil {
+					nat.Attribute.Meta = expr.MetaExpr{}
+				}
+				nat.Attribute.Meta["struct:tag:form"] = []string{tag}
+				nat.Attribute.Meta["struct:tag:json"] = []string{tag}
+				nat.Attribute.Meta["struct:tag:xml"] = []string{tag}
+			}
+			att.Type = uatt.Type
+			att.Validation = uatt.Validation
+			att.Bases = []expr.DataType{dt} // For OpenAPI generation
+			break
+		}
 		values := expr.AsUnion(dt).Values
 		names := make([]interface{}, len(values))
 		vals := make([]string, len(values))
@@ -997,6 +1242,61 @@ func makeHTTPTypeRecursive(att *expr.AttributeExpr, seen map[string]struct{}) *e
 	return att
 }
 
+// buildProxyData returns the data structure used to generate the reverse
+// proxy of the endpoint, nil if the endpoint does not use Proxy.
+func buildProxyData(e *expr.HTTPEndpointExpr) *ProxyData {
+	if e.Proxy == nil {
+		return nil
+	}
+	headers := func(hs []*expr.HTTPProxyHeaderExpr) []*ProxyHeaderData {
+		var res []*ProxyHeaderData
+		for _, h := range hs {
+			res = append(res, &ProxyHeaderData{Name: h.Name, Value: h.Value})
+		}
+		return res
+	}
+	return &ProxyData{
+		URL:             e.Proxy.URL,
+		Timeout:         e.Proxy.Timeout,
+		RequestHeaders:  headers(e.Proxy.RequestHeaders),
+		ResponseHeaders: headers(e.Proxy.ResponseHeaders),
+	}
+}
+
+// buildAliasesData returns the data structures used to mount the legacy
+// paths of the endpoint, one per legacy full path and route HTTP method.
+func buildAliasesData(e *expr.HTTPEndpointExpr, routes []*RouteData) []*AliasData {
+	if len(e.Aliases) == 0 || len(routes) == 0 {
+		return nil
+	}
+	var verbs []string
+	seen := make(map[string]bool)
+	for _, r := range routes {
+		if !seen[r.Verb] {
+			seen[r.Verb] = true
+			verbs = append(verbs, r.Verb)
+		}
+	}
+	var aliases []*AliasData
+	for _, a := range e.Aliases {
+		var code string
+		if a.StatusCode != 0 {
+			code = statusCodeToHTTPConst(a.StatusCode)
+		}
+		for _, p := range a.FullPaths() {
+			for _, v := range verbs {
+				aliases = append(aliases, &AliasData{
+					Verb:       v,
+					Path:       p,
+					Target:     routes[0].Path,
+					StatusCode: code,
+				})
+			}
+		}
+	}
+	return aliases
+}
+
 // buildPayloadData returns the data structure used to describe the endpoint
 // payload including the HTTP request details. It also returns the user types
 // used by the request body type recursively if any.
@@ -1020,7 +1320,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			serverBodyData = buildRequestBodyType(e.Body, payload, e, true, sd)
 			clientBodyData = buildRequestBodyType(e.Body, payload, e, false, sd)
 			paramsData     = extractPathParams(e.PathParams(), payload, sd.Scope)
-			queryData      = extractQueryParams(e.QueryParams(), payload, sd.Scope)
+			queryData      = extractQueryParams(e.QueryParams(), payload, pkg, svc.Scope, sd.Scope)
 			headersData    = extractHeaders(e.Headers, payload, svcctx, sd.Scope)
 			cookiesData    = extractCookies(e.Cookies, payload, svcctx, sd.Scope)
 			origin         string
@@ -1122,6 +1422,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 			MustHaveBody: mustHaveBody,
 			MustValidate: mustValidate,
 			Multipart:    e.MultipartRequest,
+			NDJSON:       ndjsonBody(e.Body, e.Consumes, ""),
 		}
 	}
 
@@ -1168,7 +1469,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					TypeRef:  sd.Scope.GoTypeRef(e.Body),
 					Type:     body,
 					Required: true,
-					Example:  e.Body.Example(expr.Root.API.Random()),
+					Example:  e.Body.WireExample(e.Body.Example(expr.Root.API.Random())),
 					Validate: svcode,
 				},
 			}}
@@ -1180,7 +1481,7 @@ func buildPayloadData(e *expr.HTTPEndpointExpr, sd *ServiceData) *PayloadData {
 					TypeRef:  sd.Scope.GoTypeRefWithDefaults(e.Body),
 					Type:     body,
 					Required: true,
-					Example:  e.Body.Example(expr.Root.API.Random()),
+					Example:  e.Body.WireExample(e.Body.Example(expr.Root.API.Random())),
 					Validate: cvcode,
 				},
 			}}
@@ -1465,13 +1766,86 @@ func buildResultData(e *expr.HTTPEndpointExpr, sd *ServiceData) *ResultData {
 			}
 		}
 	}
+	var unmasked []string
+	{
+		var masked bool
+		for _, r := range responses {
+			if r.Masks != nil {
+				masked = true
+				break
+			}
+		}
+		if rt, ok := e.MethodExpr.Result.Type.(*expr.ResultTypeExpr); ok && masked && ep.ViewedResult != nil {
+			for _, v := range rt.Views {
+				if _, ok := v.Meta["sensitive:unmask"]; ok {
+					unmasked = append(unmasked, v.Name)
+				}
+			}
+		}
+	}
+
 	return &ResultData{
-		IsStruct:  expr.IsObject(result.Type),
-		Name:      name,
-		Ref:       ref,
-		Responses: responses,
-		View:      view,
-		MustInit:  mustInit,
+		IsStruct:      expr.IsObject(result.Type),
+		Name:          name,
+		Ref:           ref,
+		Responses:     responses,
+		View:          view,
+		MustInit:      mustInit,
+		UnmaskedViews: unmasked,
+	}
+}
+
+// collectMasks returns the mask values of the attributes marked as sensitive
+// in the given attribute type indexed by path, see goahttp.MaskSensitive. The
+// paths list the wire names of the attributes, body is the response body used
+// to map the names of the top level attributes to their body element names.
+// It returns nil if there is no such attribute.
+func collectMasks(att, body *expr.AttributeExpr) map[string]string {
+	var top *expr.MappedAttributeExpr
+	if body != nil && expr.IsObject(body.Type) {
+		top = expr.NewMappedAttributeExpr(body)
+	}
+	masks := make(map[string]string)
+	collectMasksAt(att, top, "", masks, make(map[string]bool))
+	if len(masks) == 0 {
+		return nil
+	}
+	return masks
+}
+
+// collectMasksAt records the masks of the sensitive attributes of att in
+// masks using prefix to build their paths. top is the mapped body attribute
+// used to compute the element names of the top level attributes, nil for
+// nested attributes. seen records the user types being visited so that the
+// masks of recursive types are collected once.
+func collectMasksAt(att *expr.AttributeExpr, top *expr.MappedAttributeExpr, prefix string, masks map[string]string, seen map[string]bool) {
+	switch dt := att.Type.(type) {
+	case expr.UserType:
+		if seen[dt.ID()] {
+			return
+		}
+		seen[dt.ID()] = true
+		defer delete(seen, dt.ID())
+		collectMasksAt(dt.Attribute(), top, prefix, masks, seen)
+	case *expr.Array:
+		collectMasksAt(dt.ElemType, nil, prefix, masks, seen)
+	case *expr.Map:
+		collectMasksAt(dt.ElemType, nil, prefix, masks, seen)
+	case *expr.Object:
+		for _, nat := range *dt {
+			name := att.WireName(nat.Name)
+			if top != nil && expr.AsObject(top.Type).Attribute(nat.Name) != nil {
+				if elem := top.ElemName(nat.Name); elem != nat.Name {
+					name = elem
+				}
+			}
+			path := prefix + name
+			if _, ok := nat.Attribute.Meta["sensitive"]; ok {
+				masks[path], _ = nat.Attribute.Meta.Last("sensitive:mask")
+				continue
+			}
+			collectMasksAt(nat.Attribute, nil, path+".", masks, seen)
+		}
 	}
 }
 
@@ -1490,8 +1864,12 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 		pkg        = pkgWithDefault(md.ResultLoc, svc.PkgName)
 		httpclictx = httpContext("", sd.Scope, false, false)
 		svcctx     = serviceContext(pkg, sd.Service.Scope)
+		envKey     string
 	)
 	{
+		if sd.Envelope != nil {
+			envKey = sd.Envelope.DataKey
+		}
 		scope = svc.Scope
 		if viewed {
 			scope = svc.ViewScope
@@ -1515,6 +1893,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 				init           *InitData
 				origin         string
 				mustValidate   bool
+				masks          map[string]string
 
 				resAttr = result
 			)
@@ -1529,6 +1908,7 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 						origin = o[0]
 						resAttr = expr.AsObject(resAttr.Type).Attribute(origin)
 					}
+					masks = collectMasks(resAttr, resp.Body)
 				}
 				if viewed {
 					vname := ""
@@ -1739,6 +2119,11 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 					MustValidate: mustValidate,
 					ResultAttr:   codegen.Goify(origin, true),
 					ViewedResult: md.ViewedResult,
+					EnvelopeKey:  envKey,
+					NDJSON:       ndjsonBody(resp.Body, expr.Root.API.HTTP.Produces, envKey),
+					Masks:        masks,
+
+					TableContentTypes: tableContentTypes(serverBodyData, envKey),
 				})
 			}
 		}
@@ -1751,6 +2136,99 @@ func buildResponses(e *expr.HTTPEndpointExpr, result *expr.AttributeExpr, viewed
 	return responses
 }
 
+// buildEnvelopeData returns the data describing the given response envelope,
+// nil if env is nil.
+func buildEnvelopeData(env *expr.AttributeExpr) *EnvelopeData {
+	if env == nil {
+		return nil
+	}
+	data := &EnvelopeData{DataKey: "data"}
+	if env.Find("errors") != nil {
+		data.ErrorKey = "errors"
+	}
+	return data
+}
+
+// buildHostsData returns the data describing the HTTP hosts of the servers
+// that expose the service with the given name.
+func buildHostsData(svc string) []*HostData {
+	var hosts []*HostData
+	for _, svr := range expr.Root.API.Servers {
+		found := false
+		for _, s := range svr.Services {
+			if s == svc {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		for _, h := range svr.Hosts {
+			var uris []string
+			for _, u := range h.URIs {
+				if sch := u.Scheme(); sch == "http" || sch == "https" {
+					uris = append(uris, string(u))
+				}
+			}
+			if len(uris) == 0 {
+				continue
+			}
+			var vars []*HostVariableData
+			for _, v := range *expr.AsObject(h.Variables.Type) {
+				vd := &HostVariableData{Name: v.Name}
+				if v.Attribute.Validation != nil {
+					for _, val := range v.Attribute.Validation.Values {
+						vd.Values = append(vd.Values, fmt.Sprintf("%v", val))
+					}
+				}
+				if v.Attribute.DefaultValue != nil {
+					vd.DefaultValue = fmt.Sprintf("%v", v.Attribute.DefaultValue)
+				} else if len(vd.Values) > 0 {
+					vd.DefaultValue = vd.Values[0]
+				}
+				vars = append(vars, vd)
+			}
+			hosts = append(hosts, &HostData{Server: svr.Name, Name: h.Name, URIs: uris, Variables: vars})
+		}
+	}
+	return hosts
+}
+
+// buildTransportSchemesData returns the data describing the security schemes
+// enforced by the HTTP transport that secure the endpoints of the given
+// service.
+func buildTransportSchemesData(hs *expr.HTTPServiceExpr, svc *service.Data) []*TransportSchemeData {
+	var (
+		schemes []*TransportSchemeData
+		seen    = make(map[string]*TransportSchemeData)
+	)
+	for _, e := range hs.HTTPEndpoints {
+		for _, req := range e.Requirements {
+			for _, sch := range req.Schemes {
+				if !sch.IsTransport() {
+					continue
+				}
+				ts, ok := seen[sch.SchemeName]
+				if !ok {
+					ts = &TransportSchemeData{
+						SchemeName: sch.SchemeName,
+						VarName:    codegen.Goify(sch.SchemeName, true),
+						Kind:       sch.Type(),
+					}
+					seen[sch.SchemeName] = ts
+					schemes = append(schemes, ts)
+				}
+				name := svc.Method(e.MethodExpr.Name).VarName
+				if n := len(ts.Methods); n == 0 || ts.Methods[n-1] != name {
+					ts.Methods = append(ts.Methods, name)
+				}
+			}
+		}
+	}
+	return schemes
+}
+
 // buildErrorsData builds the error data for all the error responses in the
 // endpoint expression. The response headers, cookies and body for each response
 // are inferred from the method's error expression if not specified explicitly.
@@ -1758,7 +2236,11 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 	var (
 		svc        = sd.Service
 		httpclictx = httpContext("", sd.Scope, false, false)
+		envKey     string
 	)
+	if sd.Envelope != nil {
+		envKey = sd.Envelope.ErrorKey
+	}
 
 	data := make(map[string][]*ErrorData)
 	for _, v := range e.HTTPErrors {
@@ -1927,6 +2409,7 @@ func buildErrorsData(e *expr.HTTPEndpointExpr, sd *ServiceData) []*ErrorGroupDat
 				ContentType:  contentType,
 				Cookies:      cookies,
 				ErrorHeader:  v.Name,
+				EnvelopeKey:  envKey,
 				ServerBody:   serverBodyData,
 				ClientBody:   clientBodyData,
 				ResultInit:   init,
@@ -2211,6 +2694,11 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 
 	}
 
+	var columns []*ColumnData
+	if svr && def != "" {
+		columns = tableColumns(body)
+	}
+
 	var init *InitData
 	{
 		if svr && mustInit {
@@ -2296,6 +2784,7 @@ func buildResponseBodyType(body, att *expr.AttributeExpr, e *expr.HTTPEndpointEx
 		ValidateRef: validateRef,
 		Example:     body.Example(expr.Root.API.Random()),
 		View:        viewName,
+		Columns:     columns,
 	}
 }
 
@@ -2355,7 +2844,7 @@ func extractPathParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr,
 	return params
 }
 
-func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr, pkg string, svcScope, scope *codegen.NameScope) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
 		// The StringSlice field of ParamData must be false for aliased primitive types
@@ -2386,8 +2875,31 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 			fptr = service.IsPrimitivePointer(name, true)
 			ft = service.Find(name).Type
 		}
+		var (
+			fields   []*Element
+			validate = codegen.RecursiveValidationCode(c, ctx, required, expr.IsAlias(c.Type), varn)
+			dt       = c.Type
+			typeName = scope.GoTypeName(c)
+		)
+		if expr.IsObject(c.Type) {
+			// Object query parameters are decoded directly into the service
+			// type, one field per "name[field]" key.
+			satt := service.Find(name)
+			typeRef = "*" + svcScope.GoFullTypeName(satt, pkg)
+			typeName = queryParamJSONDef(satt, svcScope)
+			fields = extractQueryParamFields(c, satt, elem, varn, svcScope)
+			// Use the underlying object as field type so that the payload
+			// constructor assigns the decoded value as is.
+			dt, ft = ft, expr.AsObject(ft)
+			if validate != "" {
+				validate = fmt.Sprintf("if %s != nil {\n%s\n}", varn, validate)
+			}
+		}
+		style, _ := c.Meta.Last("http:query:style")
 		params = append(params, &ParamData{
-			Map: mp != nil,
+			Style:  style,
+			Fields: fields,
+			Map:    mp != nil,
 			MapStringSlice: mp != nil &&
 				mp.KeyType.Type.Kind() == expr.StringKind &&
 				mp.ElemType.Type.Kind() == expr.ArrayKind &&
@@ -2404,11 +2916,11 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 					FieldType:    ft,
 					VarName:      varn,
 					Required:     required,
-					Type:         c.Type,
-					TypeName:     scope.GoTypeName(c),
+					Type:         dt,
+					TypeName:     typeName,
 					TypeRef:      typeRef,
 					Pointer:      pointer,
-					Validate:     codegen.RecursiveValidationCode(c, ctx, required, expr.IsAlias(c.Type), varn),
+					Validate:     validate,
 					DefaultValue: c.DefaultValue,
 					Example:      c.Example(expr.Root.API.Random()),
 				},
@@ -2420,9 +2932,73 @@ func extractQueryParams(a *expr.MappedAttributeExpr, service *expr.AttributeExpr
 	return params
 }
 
+// queryParamJSONDef returns the definition of a struct type with the same
+// fields as the service type of the object query parameter att and JSON tags
+// set to the attribute wire names. The CLI decodes the flag value into this
+// type before converting it to the service type.
+func queryParamJSONDef(att *expr.AttributeExpr, svcScope *codegen.NameScope) string {
+	if ut, ok := att.Type.(expr.UserType); ok {
+		att = ut.Attribute()
+	}
+	var (
+		obj    = expr.AsObject(att.Type)
+		tagged = make(expr.Object, len(*obj))
+	)
+	for i, nat := range *obj {
+		fatt := *nat.Attribute
+		fatt.Meta = expr.MetaExpr{}
+		for k, v := range nat.Attribute.Meta {
+			fatt.Meta[k] = v
+		}
+		fatt.Meta["struct:tag:json"] = []string{att.WireName(nat.Name)}
+		tagged[i] = &expr.NamedAttributeExpr{Name: nat.Name, Attribute: &fatt}
+	}
+	return svcScope.GoTypeDef(&expr.AttributeExpr{Type: &tagged, Validation: att.Validation}, false, true)
+}
+
+// extractQueryParamFields returns the elements describing the attributes of
+// the object query parameter att. svc is the corresponding service attribute
+// which defines the Go type of the fields.
+func extractQueryParamFields(att, svc *expr.AttributeExpr, elem, varn string, svcScope *codegen.NameScope) []*Element {
+	var (
+		obj    = expr.AsObject(att.Type)
+		fields = make([]*Element, 0, len(*obj))
+	)
+	for _, nat := range *obj {
+		var (
+			fatt    = nat.Attribute
+			sfatt   = svc.Find(nat.Name)
+			arr     = expr.AsArray(fatt.Type)
+			pointer = svc.IsPrimitivePointer(nat.Name, true)
+			typeRef = svcScope.GoTypeRef(sfatt)
+		)
+		if pointer {
+			typeRef = "*" + typeRef
+		}
+		fields = append(fields, &Element{
+			Name:          fmt.Sprintf("%s[%s]", elem, att.WireName(nat.Name)),
+			AttributeName: nat.Name,
+			Slice:         arr != nil,
+			StringSlice:   arr != nil && arr.ElemType.Type.Kind() == expr.StringKind && !expr.IsAlias(expr.AsArray(sfatt.Type).ElemType.Type),
+			AttributeData: &AttributeData{
+				VarName:      varn + codegen.Goify(nat.Name, true),
+				FieldName:    codegen.GoifyAtt(fatt, nat.Name, true),
+				FieldPointer: pointer,
+				FieldType:    sfatt.Type,
+				Required:     att.IsRequired(nat.Name),
+				Type:         fatt.Type,
+				TypeRef:      typeRef,
+				Pointer:      pointer,
+				DefaultValue: fatt.DefaultValue,
+			},
+		})
+	}
+	return fields
+}
+
 func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svcCtx *codegen.AttributeContext, scope *codegen.NameScope) []*HeaderData {
 	var headers []*HeaderData
-	codegen.WalkMappedAttr(a, func(name, elem string, required bool, _ *expr.AttributeExpr) error {
+	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *expr.AttributeExpr) error {
 		var attr *expr.AttributeExpr
 		if attr = svcAtt.Find(name); attr == nil {
 			attr = svcAtt
@@ -2435,7 +3011,9 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 				stringSlice = arr.ElemType.Type.Kind() == expr.StringKind
 			}
 
-			hattr = makeHTTPType(attr)
+			// Use the header attribute so that validations and default
+			// values defined in the Header DSL apply.
+			hattr = makeHTTPType(c)
 		}
 		var (
 			varn    = scope.Name(codegen.Goify(name, false))
@@ -2475,7 +3053,7 @@ func extractHeaders(a *expr.MappedAttributeExpr, svcAtt *expr.AttributeExpr, svc
 					Required:     required,
 					Pointer:      pointer,
 					Type:         hattr.Type,
-					Validate:     codegen.RecursiveValidationCode(hattr, svcCtx, required, expr.IsAlias(hattr.Type), varn),
+					Validate:     codegen.RecursiveValidationCodeWithContext(hattr, svcCtx, required, expr.IsAlias(hattr.Type), varn, elem),
 					DefaultValue: hattr.DefaultValue,
 					Example:      hattr.Example(expr.Root.API.Random()),
 				},
@@ -2729,6 +3307,27 @@ func needConversion(dt expr.DataType) bool {
 	}
 }
 
+// multipartConsumes returns the mime types accepted by a multipart endpoint
+// given the Consumes mime types inherited from the design. The multipart
+// request decoder only decodes multipart bodies so only the multipart types
+// listed in consumes apply, "multipart/form-data" if there is none. It returns
+// nil if consumes is empty, i.e. if the design does not use Consumes.
+func multipartConsumes(consumes []string) []string {
+	if len(consumes) == 0 {
+		return nil
+	}
+	var mts []string
+	for _, mt := range consumes {
+		if strings.HasPrefix(strings.ToLower(mt), "multipart/") {
+			mts = append(mts, mt)
+		}
+	}
+	if len(mts) == 0 {
+		mts = []string{"multipart/form-data"}
+	}
+	return mts
+}
+
 // AddMarshalTags adds JSON, XML and Form tags to all inline object attributes recursively.
 func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
 	if !expr.IsObject(att.Type) {
@@ -2749,7 +3348,10 @@ func AddMarshalTags(att *expr.AttributeExpr, seen map[string]struct{}) {
 		if natt.Attribute.Meta == nil {
 			natt.Attribute.Meta = expr.MetaExpr{}
 		}
-		ns := []string{natt.Name}
+		if _, ok := natt.Attribute.Meta["struct:tag:json"]; ok {
+			continue // tags set explicitly, e.g. by makeHTTPType
+		}
+		ns := []string{att.WireName(natt.Name)}
 		natt.Attribute.Meta["struct:tag:form"] = ns
 		natt.Attribute.Meta["struct:tag:json"] = ns
 		natt.Attribute.Meta["struct:tag:xml"] = ns
@@ -2819,6 +3421,8 @@ const (
 	{{- end }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
 	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .VarName }}Slice, ",")
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "datetime" }}{{ .VarName }}.Format(time.RFC3339)
+	{{- else if eq (index $.PathParams $i).Attribute.Type.Name "duration" }}int64({{ .VarName }})
 	{{- else }}{{ .VarName }}
 	{{- end }}, {{ end }})
 {{- else }}
@@ -2835,6 +3439,9 @@ const (
 	{{- else if eq . "float64" }} strconv.FormatFloat(v, 'f', -1, 64)
 	{{- else if eq . "boolean" }} strconv.FormatBool(v)
 	{{- else if eq . "bytes" }} url.QueryEscape(string(v))
+	{{- else if eq . "datetime" }} url.QueryEscape(v.Format(time.RFC3339))
+	{{- else if eq . "duration" }} strconv.FormatInt(int64(v), 10)
+	{{- else if eq . "date" "uuid" "decimal" }} v.String()
 	{{- else }} url.QueryEscape(fmt.Sprintf("%v", v))
 	{{- end }}
 {{- end }}`
@@ -2908,3 +3515,27 @@ const (
 
 	return req, nil`
 )
+
+// nullableFields returns the names of the nullable attributes of the given
+// request body indexed by their wire names, nil if there is none or if the body
+// is not made of payload attributes.
+func nullableFields(body *expr.AttributeExpr) map[string]string {
+	if _, ok := body.Meta["origin:attribute"]; ok {
+		return nil
+	}
+	obj := expr.AsObject(body.Type)
+	if obj == nil {
+		return nil
+	}
+	var fields map[string]string
+	for _, nat := range *obj {
+		if _, ok := nat.Attribute.Meta["nullable"]; !ok {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		fields[body.WireName(nat.Name)] = nat.Name
+	}
+	return fields
+}
@@ -0,0 +1,107 @@
+package codegen
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+type (
+	// snippetsData is the data used to render the snippets file.
+	snippetsData struct {
+		// Title is the API title.
+		Title string
+		// Services lists the HTTP services.
+		Services []*snippetsServiceData
+	}
+
+	// snippetsServiceData describes the snippets of a service.
+	snippetsServiceData struct {
+		// Name is the service name.
+		Name string
+		// Description is the service description.
+		Description string
+		// Routes lists the snippets of the service endpoint routes.
+		Routes []*snippetsRouteData
+	}
+
+	// snippetsRouteData describes the snippets of an endpoint route.
+	snippetsRouteData struct {
+		// Name is the endpoint name.
+		Name string
+		// Description is the endpoint description.
+		Description string
+		// Method is the route HTTP method.
+		Method string
+		// Path is the route full path.
+		Path string
+		// Snippets lists the route snippets.
+		Snippets []*openapi.Snippet
+	}
+)
+
+// SnippetsFile returns the markdown file that lists ready-to-run curl and
+// HTTPie command lines for each HTTP endpoint. SnippetsFile returns nil if the
+// API does not define the "http:snippets" meta.
+func SnippetsFile(root *expr.RootExpr) *codegen.File {
+	tools := openapi.SnippetTools(root.API)
+	if len(tools) == 0 {
+		return nil
+	}
+	title := root.API.Title
+	if title == "" {
+		title = root.API.Name
+	}
+	data := &snippetsData{Title: title}
+	for _, svc := range root.API.HTTP.Services {
+		sd := &snippetsServiceData{Name: svc.Name(), Description: svc.Description()}
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				sd.Routes = append(sd.Routes, &snippetsRouteData{
+					Name:        e.Name(),
+					Description: e.Description(),
+					Method:      r.Method,
+					Path:        r.FullPaths()[0],
+					Snippets:    openapi.Snippets(tools, r),
+				})
+			}
+		}
+		data.Services = append(data.Services, sd)
+	}
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "http", "snippets.md"),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:   "snippets",
+			Source: snippetsT,
+			Data:   data,
+		}},
+	}
+}
+
+// input: *snippetsData
+const snippetsT = `# {{ .Title }} HTTP request snippets
+{{- range .Services }}
+
+## {{ .Name }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- range .Routes }}
+
+### {{ .Name }}: ` + "`" + `{{ .Method }} {{ .Path }}` + "`" + `
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- range .Snippets }}
+
+` + "```sh" + `
+{{ .Source }}
+` + "```" + `
+{{- end }}
+{{- end }}
+{{- end }}
+`
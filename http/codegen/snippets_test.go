@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestSnippetsFile(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected string
+	}{
+		{"no-snippets", testdata.SimpleDSL, ""},
+		{"snippets", testdata.SnippetsDSL, testdata.SnippetsFileCode},
+		{"httpie", testdata.SnippetsHTTPieDSL, testdata.SnippetsHTTPieFileCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			f := SnippetsFile(root)
+			if c.Expected == "" {
+				if f != nil {
+					t.Fatalf("got file %q, expected none", f.Path)
+				}
+				return
+			}
+			if f == nil {
+				t.Fatal("got no file")
+			}
+			var buf bytes.Buffer
+			if err := f.SectionTemplates[0].Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			code := buf.String()
+			if code != c.Expected {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Expected))
+			}
+		})
+	}
+}
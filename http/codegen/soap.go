@@ -0,0 +1,485 @@
+package codegen
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+	"goa.design/goa/v3/http/codegen/openapi"
+)
+
+type (
+	// soapData is the data used to render the WSDL document and the SOAP
+	// server of a service.
+	soapData struct {
+		// Service is the service name.
+		Service string
+		// Description is the service description.
+		Description string
+		// Namespace is the target namespace of the WSDL document.
+		Namespace string
+		// Path is the path of the SOAP endpoint.
+		Path string
+		// Location is the URL of the SOAP endpoint.
+		Location string
+		// WSDL is the name of the WSDL file.
+		WSDL string
+		// PkgName is the name of the service package.
+		PkgName string
+		// Types lists the complex types of the WSDL document.
+		Types []*soapType
+		// Operations lists the SOAP operations.
+		Operations []*soapOperation
+	}
+
+	// soapType describes a complex type of the WSDL document.
+	soapType struct {
+		// Name is the type name.
+		Name string
+		// Elements lists the type elements.
+		Elements []*soapElement
+	}
+
+	// soapElement describes an element of a complex type.
+	soapElement struct {
+		// Name is the element name, the name of the Go struct field it
+		// is decoded into.
+		Name string
+		// Type is the qualified name of the element type.
+		Type string
+		// Optional is true if the element may be omitted.
+		Optional bool
+		// Many is true if the element may be repeated, i.e. it holds
+		// the elements of an array.
+		Many bool
+	}
+
+	// soapOperation describes a SOAP operation.
+	soapOperation struct {
+		// Name is the method name.
+		Name string
+		// VarName is the name of the method endpoint field.
+		VarName string
+		// Description is the method description.
+		Description string
+		// Request lists the elements of the request element.
+		Request []*soapElement
+		// Response lists the elements of the response element.
+		Response []*soapElement
+		// PayloadRef is the reference to the payload type, empty if
+		// there is no payload.
+		PayloadRef string
+		// PayloadObject is true if the payload is an object whose
+		// fields are the request elements as opposed to a value held
+		// by the "Payload" element.
+		PayloadObject bool
+		// ResultRef is the reference to the result type, empty if there
+		// is no result.
+		ResultRef string
+		// ResultObject is true if the result is an object whose fields
+		// are the response elements as opposed to a value held by the
+		// "Result" element.
+		ResultObject bool
+		// ViewedRef is the reference to the viewed result type returned
+		// by the endpoint if any.
+		ViewedRef string
+		// ViewedPointer is true if the viewed result type is a pointer,
+		// collections of result types are viewed using a struct value.
+		ViewedPointer bool
+		// ResultInit is the name of the function that initializes the
+		// result from the viewed result.
+		ResultInit string
+	}
+
+	// soapBuilder collects the complex types of a WSDL document.
+	soapBuilder struct {
+		types []*soapType
+		seen  map[string]bool
+	}
+)
+
+// SOAPFiles returns the files that implement a SOAP facade for the services
+// that define the "soap:path" meta: a WSDL document that describes the service
+// methods as document/literal operations and a SOAP server that decodes the
+// request envelopes into the method payloads, calls the service endpoints and
+// encodes the results in the response envelopes. The elements are named after
+// the fields of the service types so that the payloads and results are encoded
+// with encoding/xml. Streaming methods and methods whose payload or result
+// uses maps, unions, bytes, nested arrays or values of any type are not
+// exposed.
+func SOAPFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var files []*codegen.File
+	for _, svc := range root.API.HTTP.Services {
+		path, ok := svc.ServiceExpr.Meta.Last("soap:path")
+		if !ok {
+			continue
+		}
+		files = append(files, soapFiles(genpkg, root, svc, path)...)
+	}
+	return files
+}
+
+// soapFiles returns the WSDL document and the SOAP server of the given
+// service.
+func soapFiles(genpkg string, root *expr.RootExpr, svc *expr.HTTPServiceExpr, path string) []*codegen.File {
+	sd := HTTPServices.Get(svc.Name())
+	svcName := sd.Service.PathName
+	ns, ok := svc.ServiceExpr.Meta.Last("soap:namespace")
+	if !ok {
+		ns = fmt.Sprintf("urn:%s:%s", codegen.SnakeCase(root.API.Name), codegen.SnakeCase(svc.Name()))
+	}
+	data := &soapData{
+		Service:     svc.Name(),
+		Description: svc.Description(),
+		Namespace:   ns,
+		Path:        path,
+		Location:    openapi.ServiceBaseURL(svc.ServiceExpr) + path,
+		WSDL:        svcName + ".wsdl",
+		PkgName:     sd.Service.PkgName,
+	}
+	b := &soapBuilder{seen: make(map[string]bool)}
+	for _, e := range sd.Endpoints {
+		m := svc.ServiceExpr.Method(e.Method.Name)
+		if m.IsStreaming() || !soapSupported(m.Payload, nil) || !soapSupported(m.Result, nil) {
+			continue
+		}
+		op := &soapOperation{
+			Name:        e.Method.Name,
+			VarName:     e.Method.VarName,
+			Description: e.Method.Description,
+		}
+		if m.Payload.Type != expr.Empty {
+			op.PayloadRef = e.Payload.Ref
+			op.PayloadObject = expr.IsObject(m.Payload.Type)
+			op.Request = b.elements(m.Payload, "Payload", codegen.Goify(m.Name, true)+"Payload")
+		}
+		if m.Result.Type != expr.Empty {
+			op.ResultRef = e.Result.Ref
+			op.ResultObject = expr.IsObject(m.Result.Type)
+			op.Response = b.elements(m.Result, "Result", codegen.Goify(m.Name, true)+"Result")
+			if vr := e.Method.ViewedResult; vr != nil {
+				op.ViewedRef = vr.FullRef
+				op.ViewedPointer = strings.HasPrefix(vr.FullRef, "*")
+				op.ResultInit = sd.Service.PkgName + "." + vr.ResultInit.Name
+			}
+		}
+		data.Operations = append(data.Operations, op)
+	}
+	data.Types = b.types
+
+	dir := filepath.Join(codegen.Gendir, "http", svcName, "soap")
+	title := fmt.Sprintf("%s SOAP server", svc.Name())
+	funcs := map[string]interface{}{"xml": html.EscapeString}
+	return []*codegen.File{
+		{
+			Path: filepath.Join(dir, data.WSDL),
+			SectionTemplates: []*codegen.SectionTemplate{
+				{Name: "soap-wsdl", Source: soapWSDLT, Data: data, FuncMap: funcs},
+			},
+		},
+		{
+			Path: filepath.Join(dir, "server.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header(title, "soap", []*codegen.ImportSpec{
+					{Path: "context"},
+					{Path: "embed", Name: "_"},
+					{Path: "encoding/xml"},
+					{Path: "net/http"},
+					codegen.GoaNamedImport("http", "goahttp"),
+					{Path: genpkg + "/" + svcName, Name: sd.Service.PkgName},
+					{Path: genpkg + "/" + svcName + "/views", Name: sd.Service.ViewsPkg},
+				}),
+				{Name: "soap-server", Source: soapServerT, Data: data, FuncMap: map[string]interface{}{"trimPrefix": strings.TrimPrefix}},
+			},
+		},
+	}
+}
+
+// elements returns the elements of the request or response element of the
+// given payload or result: the object fields or a single element with the
+// given name that holds the value. typeName is the name of the complex type
+// of inline objects.
+func (b *soapBuilder) elements(att *expr.AttributeExpr, name, typeName string) []*soapElement {
+	obj := expr.AsObject(att.Type)
+	if obj == nil {
+		return []*soapElement{b.element(name, att, true, typeName)}
+	}
+	elems := make([]*soapElement, len(*obj))
+	for i, nat := range *obj {
+		elems[i] = b.element(soapElementName(nat.Attribute, nat.Name), nat.Attribute, att.IsRequired(nat.Name), typeName+codegen.Goify(nat.Name, true))
+	}
+	return elems
+}
+
+// element returns the element with the given name that holds values of the
+// given attribute type.
+func (b *soapBuilder) element(name string, att *expr.AttributeExpr, required bool, typeName string) *soapElement {
+	el := &soapElement{Name: name, Optional: !required}
+	if arr := expr.AsArray(att.Type); arr != nil {
+		el.Many = true
+		el.Optional = true
+		att = arr.ElemType
+	}
+	el.Type = b.typeName(att, typeName)
+	return el
+}
+
+// typeName returns the qualified name of the XML schema type of the given
+// attribute, recording the complex types of objects.
+func (b *soapBuilder) typeName(att *expr.AttributeExpr, name string) string {
+	if expr.IsObject(att.Type) {
+		if ut, ok := att.Type.(expr.UserType); ok {
+			name = codegen.Goify(ut.Name(), true)
+		}
+		if b.seen[name] {
+			return "tns:" + name
+		}
+		b.seen[name] = true
+		t := &soapType{Name: name}
+		b.types = append(b.types, t)
+		t.Elements = b.elements(att, "", name)
+		return "tns:" + name
+	}
+	switch att.Type.Kind() {
+	case expr.BooleanKind:
+		return "xsd:boolean"
+	case expr.IntKind, expr.Int64Kind:
+		return "xsd:long"
+	case expr.Int32Kind:
+		return "xsd:int"
+	case expr.UIntKind, expr.UInt64Kind:
+		return "xsd:unsignedLong"
+	case expr.UInt32Kind:
+		return "xsd:unsignedInt"
+	case expr.Float32Kind:
+		return "xsd:float"
+	case expr.Float64Kind:
+		return "xsd:double"
+	default:
+		return "xsd:string"
+	}
+}
+
+// soapSupported returns true if the values of the given attribute type can be
+// encoded with encoding/xml.
+func soapSupported(att *expr.AttributeExpr, seen map[expr.DataType]bool) bool {
+	if att == nil || att.Type == expr.Empty {
+		return true
+	}
+	if seen == nil {
+		seen = make(map[expr.DataType]bool)
+	}
+	if seen[att.Type] {
+		return true
+	}
+	seen[att.Type] = true
+	switch t := expr.DupAtt(att).Type.(type) {
+	case expr.UserType:
+		return soapSupported(t.Attribute(), seen)
+	case *expr.Object:
+		for _, nat := range *t {
+			if !soapSupported(nat.Attribute, seen) {
+				return false
+			}
+		}
+		return true
+	case *expr.Array:
+		return expr.AsArray(t.ElemType.Type) == nil && soapSupported(t.ElemType, seen)
+	case *expr.Map, *expr.Union:
+		return false
+	}
+	switch att.Type.Kind() {
+	case expr.BytesKind, expr.AnyKind:
+		return false
+	}
+	return true
+}
+
+// soapElementName returns the name of the element that holds the value of the
+// given object field: the name given with the "struct:tag:xml" meta if any,
+// the name of the Go struct field otherwise.
+func soapElementName(att *expr.AttributeExpr, name string) string {
+	if tag, ok := att.Meta.Last("struct:tag:xml"); ok {
+		if n := strings.Split(tag, ",")[0]; n != "" {
+			return n
+		}
+	}
+	return codegen.GoifyAtt(att, name, true)
+}
+
+// input: soapData
+const soapWSDLT = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Code generated by goa, DO NOT EDIT. -->
+<definitions name="{{ xml .Service }}" targetNamespace="{{ xml .Namespace }}"
+    xmlns="http://schemas.xmlsoap.org/wsdl/"
+    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/"
+    xmlns:tns="{{ xml .Namespace }}"
+    xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+{{- if .Description }}
+  <documentation>{{ xml .Description }}</documentation>
+{{- end }}
+  <types>
+    <xsd:schema targetNamespace="{{ xml .Namespace }}" elementFormDefault="qualified">
+{{- range .Types }}
+      <xsd:complexType name="{{ .Name }}">
+        <xsd:sequence>
+{{- range .Elements }}
+          {{ template "element" . }}
+{{- end }}
+        </xsd:sequence>
+      </xsd:complexType>
+{{- end }}
+{{- range .Operations }}
+      <xsd:element name="{{ .Name }}">
+        <xsd:complexType>
+          <xsd:sequence>
+{{- range .Request }}
+            {{ template "element" . }}
+{{- end }}
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="{{ .Name }}Response">
+        <xsd:complexType>
+          <xsd:sequence>
+{{- range .Response }}
+            {{ template "element" . }}
+{{- end }}
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+{{- end }}
+    </xsd:schema>
+  </types>
+{{- range .Operations }}
+  <message name="{{ .Name }}Request">
+    <part name="parameters" element="tns:{{ .Name }}"/>
+  </message>
+  <message name="{{ .Name }}Response">
+    <part name="parameters" element="tns:{{ .Name }}Response"/>
+  </message>
+{{- end }}
+  <portType name="{{ xml .Service }}PortType">
+{{- range .Operations }}
+    <operation name="{{ .Name }}">
+{{- if .Description }}
+      <documentation>{{ xml .Description }}</documentation>
+{{- end }}
+      <input message="tns:{{ .Name }}Request"/>
+      <output message="tns:{{ .Name }}Response"/>
+    </operation>
+{{- end }}
+  </portType>
+  <binding name="{{ xml .Service }}Binding" type="tns:{{ xml .Service }}PortType">
+    <soap:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+{{- range .Operations }}
+    <operation name="{{ .Name }}">
+      <soap:operation soapAction="{{ xml $.Namespace }}#{{ .Name }}"/>
+      <input><soap:body use="literal"/></input>
+      <output><soap:body use="literal"/></output>
+    </operation>
+{{- end }}
+  </binding>
+  <service name="{{ xml .Service }}">
+    <port name="{{ xml .Service }}Port" binding="tns:{{ xml .Service }}Binding">
+      <soap:address location="{{ xml .Location }}"/>
+    </port>
+  </service>
+</definitions>
+{{- define "element" }}<xsd:element name="{{ .Name }}" type="{{ .Type }}"{{ if .Optional }} minOccurs="0"{{ end }}{{ if .Many }} maxOccurs="unbounded"{{ end }}/>{{ end }}
+`
+
+// input: soapData
+const soapServerT = `// WSDL is the WSDL document that describes the {{ .Service }} SOAP operations.
+//
+//go:embed {{ .WSDL }}
+var WSDL []byte
+
+// Namespace is the target namespace of the WSDL document.
+const Namespace = {{ printf "%q" .Namespace }}
+
+// Path is the path of the SOAP endpoint.
+const Path = {{ printf "%q" .Path }}
+
+// New returns the SOAP server that exposes the {{ .Service }} service
+// endpoints. errhandler is called with the errors that prevent writing the
+// responses.
+func New(e *{{ .PkgName }}.Endpoints, errhandler func(context.Context, http.ResponseWriter, error)) *goahttp.SOAPServer {
+	return goahttp.NewSOAPServer({{ printf "%q" .Service }}, Namespace, WSDL, []*goahttp.SOAPOperation{
+{{- range .Operations }}
+		{
+			Name:     {{ printf "%q" .Name }},
+			Endpoint: e.{{ .VarName }},
+{{- if .PayloadRef }}
+			Decode:   Decode{{ .VarName }}Request,
+{{- end }}
+{{- if .ResultRef }}
+			Encode:   Encode{{ .VarName }}Response,
+{{- end }}
+		},
+{{- end }}
+	}, errhandler)
+}
+
+// Mount configures the mux to serve the SOAP requests and the WSDL document
+// at Path.
+func Mount(mux goahttp.Muxer, srv *goahttp.SOAPServer) {
+	mux.Handle("GET", Path, srv.ServeHTTP)
+	mux.Handle("POST", Path, srv.ServeHTTP)
+}
+{{- range .Operations }}
+{{- if .PayloadRef }}
+
+// Decode{{ .VarName }}Request decodes the {{ .Name }} request element into the
+// method payload.
+func Decode{{ .VarName }}Request(d *xml.Decoder, start *xml.StartElement) (interface{}, error) {
+{{- if .PayloadObject }}
+	p := &{{ trimPrefix .PayloadRef "*" }}{}
+	if err := d.DecodeElement(p, start); err != nil {
+		return nil, err
+	}
+	return p, nil
+{{- else }}
+	var body struct {
+		Payload {{ .PayloadRef }} ` + "`" + `xml:"Payload"` + "`" + `
+	}
+	if err := d.DecodeElement(&body, start); err != nil {
+		return nil, err
+	}
+	return body.Payload, nil
+{{- end }}
+}
+{{- end }}
+{{- if .ResultRef }}
+
+// Encode{{ .VarName }}Response returns the content of the {{ .Name }} response
+// element given the method result.
+func Encode{{ .VarName }}Response(v interface{}) interface{} {
+{{- if .ViewedRef }}
+	vres, ok := v.({{ .ViewedRef }})
+	if !ok{{ if .ViewedPointer }} || vres == nil{{ end }} {
+		return struct{}{}
+	}
+	res := {{ .ResultInit }}(vres)
+{{- else }}
+	res, _ := v.({{ .ResultRef }})
+{{- if .ResultObject }}
+	if res == nil {
+		return struct{}{}
+	}
+{{- end }}
+{{- end }}
+{{- if .ResultObject }}
+	return res
+{{- else }}
+	return struct {
+		Result {{ .ResultRef }} ` + "`" + `xml:"Result"` + "`" + `
+	}{res}
+{{- end }}
+}
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestSOAPFiles(t *testing.T) {
+	root := RunHTTPDSL(t, testdata.SimpleDSL)
+	if fs := SOAPFiles("gen", root); len(fs) != 0 {
+		t.Fatalf("got %d files, expected none", len(fs))
+	}
+
+	root = RunHTTPDSL(t, testdata.SOAPDSL)
+	fs := SOAPFiles("gen", root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	if p := filepath.ToSlash(fs[0].Path); p != "gen/http/accounts/soap/accounts.wsdl" {
+		t.Errorf("got WSDL path %q, expected %q", p, "gen/http/accounts/soap/accounts.wsdl")
+	}
+	var buf bytes.Buffer
+	if err := fs[0].SectionTemplates[0].Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	wsdl := buf.String()
+	if wsdl != testdata.SOAPWSDLCode {
+		t.Errorf("invalid WSDL, got:\n%s\ngot vs. expected:\n%s", wsdl, codegen.Diff(t, wsdl, testdata.SOAPWSDLCode))
+	}
+	server := codegen.SectionCode(t, fs[1].SectionTemplates[1])
+	if server != testdata.SOAPServerCode {
+		t.Errorf("invalid server code, got:\n%s\ngot vs. expected:\n%s", server, codegen.Diff(t, server, testdata.SOAPServerCode))
+	}
+}
@@ -196,6 +196,21 @@ func TestServerStreaming(t *testing.T) {
 			{"server-websocket-send", &testdata.BidirectionalStreamingUserTypeMapServerStreamSendCode},
 			{"server-websocket-recv", &testdata.BidirectionalStreamingUserTypeMapServerStreamRecvCode},
 		}},
+		// streaming payload in request body
+
+		{"stream-request-body", testdata.StreamRequestBodyDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.StreamRequestBodyServerHandlerInitCode},
+			{"server-body-stream-recv", &testdata.StreamRequestBodyServerStreamRecvCode},
+			{"server-body-stream-send", &testdata.StreamRequestBodyServerStreamSendCode},
+			{"server-body-stream-set-view", &testdata.StreamRequestBodyServerStreamSetViewCode},
+			{"server-body-stream-close", nil},
+		}},
+		{"stream-request-body-no-result", testdata.StreamRequestBodyNoResultDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.StreamRequestBodyNoResultServerHandlerInitCode},
+			{"server-body-stream-recv", &testdata.StreamRequestBodyNoResultServerStreamRecvCode},
+			{"server-body-stream-send", nil},
+			{"server-body-stream-close", &testdata.StreamRequestBodyNoResultServerStreamCloseCode},
+		}},
 	}
 
 	filesFn := func() []*codegen.File { return ServerFiles("", expr.Root) }
@@ -377,6 +392,19 @@ func TestClientStreaming(t *testing.T) {
 			{"client-websocket-send", &testdata.BidirectionalStreamingUserTypeMapClientStreamSendCode},
 			{"client-websocket-recv", &testdata.BidirectionalStreamingUserTypeMapClientStreamRecvCode},
 		}},
+		// streaming payload in request body
+
+		{"stream-request-body", testdata.StreamRequestBodyDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.StreamRequestBodyClientEndpointCode},
+			{"client-body-stream-send", &testdata.StreamRequestBodyClientStreamSendCode},
+			{"client-body-stream-recv", &testdata.StreamRequestBodyClientStreamRecvCode},
+			{"client-body-stream-close", nil},
+		}},
+		{"stream-request-body-no-result", testdata.StreamRequestBodyNoResultDSL, []*sectionExpectation{
+			{"client-body-stream-send", &testdata.StreamRequestBodyNoResultClientStreamSendCode},
+			{"client-body-stream-recv", nil},
+			{"client-body-stream-close", &testdata.StreamRequestBodyNoResultClientStreamCloseCode},
+		}},
 	}
 	filesFn := func() []*codegen.File { return ClientFiles("", expr.Root) }
 	runTests(t, cases, filesFn)
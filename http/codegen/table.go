@@ -0,0 +1,124 @@
+package codegen
+
+import (
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+const (
+	// csvContentType is the content type of the CSV representation of
+	// collections.
+	csvContentType = "text/csv"
+	// xlsxContentType is the content type of the XLSX representation of
+	// collections.
+	xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// tableContentTypes returns the content types listed in the API Produces that
+// render the response bodies as tables, nil if the response is wrapped in an
+// envelope or if a body is not a collection with columns.
+func tableContentTypes(bodies []*TypeData, envKey string) []string {
+	if envKey != "" || len(bodies) == 0 {
+		return nil
+	}
+	for _, b := range bodies {
+		if b == nil || len(b.Columns) == 0 {
+			return nil
+		}
+	}
+	return producedTableTypes()
+}
+
+// producedTableTypes returns the table content types listed in the API
+// Produces.
+func producedTableTypes() []string {
+	var cts []string
+	for _, p := range expr.Root.API.HTTP.Produces {
+		if p == csvContentType || p == xlsxContentType {
+			cts = append(cts, p)
+		}
+	}
+	return cts
+}
+
+// tableColumns returns the columns of the CSV and XLSX representations of the
+// given response body: the primitive attributes of the collection elements in
+// the order given by the "csv:columns" meta of the element type if any, in the
+// element order otherwise. The "csv:column" meta of the attributes sets the
+// column names. tableColumns returns nil if the body is not a collection of
+// objects or if the API does not produce tables.
+func tableColumns(body *expr.AttributeExpr) []*ColumnData {
+	if len(producedTableTypes()) == 0 {
+		return nil
+	}
+	arr := expr.AsArray(body.Type)
+	if arr == nil {
+		return nil
+	}
+	elem := arr.ElemType
+	if ut, ok := elem.Type.(expr.UserType); ok {
+		elem = ut.Attribute()
+	}
+	obj := expr.AsObject(elem.Type)
+	if obj == nil {
+		return nil
+	}
+	names, ok := elem.Meta["csv:columns"]
+	if !ok {
+		names, ok = arr.ElemType.Meta["csv:columns"]
+	}
+	if !ok {
+		for _, nat := range *obj {
+			names = append(names, nat.Name)
+		}
+	}
+	var columns []*ColumnData
+	for _, n := range names {
+		att := obj.Attribute(n)
+		if att == nil || !expr.IsPrimitive(att.Type) {
+			continue
+		}
+		if k := att.Type.Kind(); k == expr.BytesKind || k == expr.AnyKind {
+			continue
+		}
+		name := n
+		if c, ok := att.Meta.Last("csv:column"); ok {
+			name = c
+		}
+		columns = append(columns, &ColumnData{
+			Name:      name,
+			FieldName: codegen.GoifyAtt(att, n, true),
+			Pointer:   elem.IsPrimitivePointer(n, true),
+		})
+	}
+	return columns
+}
+
+// input: TypeData
+const tableT = `{{ printf "TableHeader returns the names of the columns of the CSV and XLSX representations of %s." .VarName | comment }}
+func (body {{ .VarName }}) TableHeader() []string {
+	return []string{ {{- range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ printf "%q" .Name }}{{ end }}}
+}
+
+{{ printf "TableRows returns the rows of the CSV and XLSX representations of %s." .VarName | comment }}
+func (body {{ .VarName }}) TableRows() [][]interface{} {
+	rows := make([][]interface{}, len(body))
+	for i, v := range body {
+		row := make([]interface{}, {{ len .Columns }})
+		rows[i] = row
+		if v == nil {
+			continue
+		}
+	{{- range $i, $c := .Columns }}
+		{{- if .Pointer }}
+		if v.{{ .FieldName }} != nil {
+			row[{{ $i }}] = *v.{{ .FieldName }}
+		}
+		{{- else }}
+		row[{{ $i }}] = v.{{ .FieldName }}
+		{{- end }}
+	{{- end }}
+	}
+	return rows
+}
+`
@@ -0,0 +1,715 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/expr"
+)
+
+type (
+	// terraformData is the data used to render the Terraform provider.
+	terraformData struct {
+		// TypeName is the provider type name used as prefix of the
+		// resource type names.
+		TypeName string
+		// Resources lists the provider resources.
+		Resources []*terraformResourceData
+		// Credentials lists the credentials defined in the provider
+		// configuration and used by the resources to call secured
+		// endpoints.
+		Credentials []*terraformCredentialData
+	}
+
+	// terraformResourceData describes a Terraform resource implemented with
+	// the generated client of a service.
+	terraformResourceData struct {
+		// TypeName is the resource type name without the provider prefix.
+		TypeName string
+		// Description is the service description.
+		Description string
+		// ClientField is the name of the Clients struct field that holds
+		// the service client.
+		ClientField string
+		// StructName is the name of the resource struct.
+		StructName string
+		// ModelName is the name of the resource model struct.
+		ModelName string
+		// ConstructorName is the name of the function that creates the
+		// resource.
+		ConstructorName string
+		// PkgName is the name of the service package.
+		PkgName string
+		// ClientPkgName is the name of the service HTTP client package.
+		ClientPkgName string
+		// Endpoints lists the expressions that initialize the endpoints
+		// given to the service client constructor.
+		Endpoints []string
+		// NeedStream is true if the HTTP client constructor requires the
+		// websocket dialer and configurer.
+		NeedStream bool
+		// Attributes lists the resource attributes.
+		Attributes []*terraformAttributeData
+		// Credentials lists the provider credentials sent by the
+		// resource operations.
+		Credentials []*terraformCredentialData
+		// Create, Read, Update and Delete describe the calls made by the
+		// resource operations. Update is nil if the service does not
+		// define a method that implements the update operation.
+		Create, Read, Update, Delete *terraformOperationData
+	}
+
+	// terraformAttributeData describes a resource attribute.
+	terraformAttributeData struct {
+		// Name is the attribute name in the Terraform schema.
+		Name string
+		// FieldName is the name of the model struct field.
+		FieldName string
+		// Kind is the Terraform type of the attribute: "String", "Bool",
+		// "Int64" or "Float64".
+		Kind string
+		// Description is the attribute description.
+		Description string
+		// Required, Optional and Computed are the schema flags.
+		Required, Optional, Computed bool
+		// PlanModifier is the name of the package that implements the
+		// plan modifier that keeps the state value of computed
+		// attributes across updates, e.g. "stringplanmodifier". It is
+		// empty if the attribute is not computed only.
+		PlanModifier string
+	}
+
+	// terraformCredentialData describes a credential set in the provider
+	// configuration.
+	terraformCredentialData struct {
+		// Name is the attribute name in the provider schema.
+		Name string
+		// FieldName is the name of the Clients struct field.
+		FieldName string
+		// VarName is the name of the resource struct field.
+		VarName string
+		// Env is the name of the environment variable that holds the
+		// default value.
+		Env string
+		// Description is the attribute description.
+		Description string
+	}
+
+	// terraformOperationData describes the method call made by a resource
+	// operation.
+	terraformOperationData struct {
+		// MethodName is the name of the service client method.
+		MethodName string
+		// Error is the summary of the error reported when the call fails.
+		Error string
+		// PayloadRef is the reference to the method payload type, empty if
+		// the method does not define a payload.
+		PayloadRef string
+		// Payload lists the statements that initialize the payload fields
+		// from the model "m".
+		Payload []string
+		// ResultVar is the variable assigned the method result, "_" if
+		// the operation does not use the result and empty if the method
+		// does not return a result.
+		ResultVar string
+		// Result lists the statements that initialize the model "m"
+		// fields from the method result "res".
+		Result []string
+	}
+)
+
+// TerraformFiles returns the files that make up a Terraform (or OpenTofu)
+// provider skeleton for the services that define the "terraform:resource"
+// meta: a provider file and a file per resource that implements the resource
+// schema and CRUD operations using the generated HTTP clients. The files are
+// generated with the terraform-plugin-framework API. TerraformFiles returns
+// nil if no service defines the meta.
+func TerraformFiles(genpkg string, root *expr.RootExpr) []*codegen.File {
+	var (
+		data  = &terraformData{TypeName: codegen.SnakeCase(root.API.Name)}
+		files []*codegen.File
+		specs = []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "net/http"},
+			{Path: "net/url"},
+			codegen.GoaNamedImport("http", "goahttp"),
+			{Path: "github.com/hashicorp/terraform-plugin-framework/datasource"},
+			{Path: "github.com/hashicorp/terraform-plugin-framework/provider"},
+			{Path: "github.com/hashicorp/terraform-plugin-framework/provider/schema"},
+			{Path: "github.com/hashicorp/terraform-plugin-framework/resource"},
+			{Path: "github.com/hashicorp/terraform-plugin-framework/types"},
+		}
+	)
+	for _, svc := range root.API.HTTP.Services {
+		if !expr.IsTerraformResource(svc.ServiceExpr) {
+			continue
+		}
+		sd := HTTPServices.Get(svc.Name())
+		rd := buildTerraformResourceData(svc, sd, data.TypeName)
+		data.Resources = append(data.Resources, rd)
+		for _, c := range rd.Credentials {
+			data.Credentials = appendTerraformCredential(data.Credentials, c)
+		}
+		imports := []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "fmt"},
+			{Path: genpkg + "/" + sd.Service.PathName, Name: sd.Service.PkgName},
+			{Path: "github.com/hashicorp/terraform-plugin-framework/resource"},
+			{Path: "github.com/hashicorp/terraform-plugin-framework/resource/schema"},
+		}
+		modifiers := make(map[string]struct{})
+		for _, a := range rd.Attributes {
+			if a.PlanModifier == "" {
+				continue
+			}
+			if len(modifiers) == 0 {
+				imports = append(imports, &codegen.ImportSpec{Path: "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"})
+			}
+			if _, ok := modifiers[a.PlanModifier]; !ok {
+				modifiers[a.PlanModifier] = struct{}{}
+				imports = append(imports, &codegen.ImportSpec{Path: "github.com/hashicorp/terraform-plugin-framework/resource/schema/" + a.PlanModifier})
+			}
+		}
+		imports = append(imports, &codegen.ImportSpec{Path: "github.com/hashicorp/terraform-plugin-framework/types"})
+		specs = append(specs,
+			&codegen.ImportSpec{Path: genpkg + "/" + sd.Service.PathName, Name: sd.Service.PkgName},
+			&codegen.ImportSpec{Path: genpkg + "/http/" + sd.Service.PathName + "/client", Name: sd.Service.PkgName + "c"},
+		)
+		title := fmt.Sprintf("Terraform %s resource", rd.TypeName)
+		files = append(files, &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "terraform", sd.Service.PathName+"_resource.go"),
+			SectionTemplates: []*codegen.SectionTemplate{
+				codegen.Header(title, "terraform", imports),
+				{Name: "terraform-resource", Source: terraformResourceT, Data: rd},
+			},
+		})
+	}
+	if len(data.Resources) == 0 {
+		return nil
+	}
+	if len(data.Credentials) > 0 {
+		specs = append(specs, &codegen.ImportSpec{Path: "os"})
+	}
+	provider := &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "terraform", "provider.go"),
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header(fmt.Sprintf("Terraform %s provider", data.TypeName), "terraform", specs),
+			{Name: "terraform-provider", Source: terraformProviderT, Data: data},
+		},
+	}
+	return append([]*codegen.File{provider}, files...)
+}
+
+// buildTerraformResourceData computes the data used to render the resource
+// implemented by the given service.
+//
+// provider is the provider type name used to compute the names of the
+// environment variables that hold the default credentials.
+func buildTerraformResourceData(svc *expr.HTTPServiceExpr, sd *ServiceData, provider string) *terraformResourceData {
+	typeName, _ := svc.ServiceExpr.Meta.Last("terraform:resource")
+	if typeName == "" {
+		typeName = svc.Name()
+	}
+	typeName = codegen.SnakeCase(typeName)
+	rd := &terraformResourceData{
+		TypeName:        typeName,
+		Description:     svc.Description(),
+		ClientField:     sd.Service.StructName,
+		StructName:      codegen.Goify(typeName, false) + "Resource",
+		ModelName:       codegen.Goify(typeName, false) + "ResourceModel",
+		ConstructorName: "New" + codegen.Goify(typeName, true) + "Resource",
+		PkgName:         sd.Service.PkgName,
+		ClientPkgName:   sd.Service.PkgName + "c",
+		NeedStream:      hasWebSocket(sd),
+	}
+	for _, m := range sd.Service.Methods {
+		ep := "nil"
+		if sd.Endpoint(m.Name) != nil {
+			ep = "hc." + m.VarName + "()"
+		}
+		rd.Endpoints = append(rd.Endpoints, ep)
+	}
+
+	// Compute the resource attributes from the read method result and the
+	// create method payload.
+	methods := expr.TerraformMethods(svc.ServiceExpr)
+	var (
+		create = methods[expr.TerraformCreate]
+		read   = methods[expr.TerraformRead]
+		attrs  = make(map[string]*terraformAttributeData)
+	)
+	addAttrs := func(parent *expr.AttributeExpr, fromCreate bool) {
+		obj := expr.AsObject(parent.Type)
+		if obj == nil {
+			return
+		}
+		for _, nat := range *obj {
+			kind := terraformKind(nat.Attribute)
+			if kind == "" {
+				continue
+			}
+			if _, ok := attrs[nat.Name]; ok {
+				continue
+			}
+			a := &terraformAttributeData{
+				Name:        codegen.SnakeCase(nat.Name),
+				FieldName:   codegen.Goify(nat.Name, true),
+				Kind:        kind,
+				Description: nat.Attribute.Description,
+			}
+			if fromCreate {
+				a.Required = parent.IsRequired(nat.Name)
+				a.Optional = !a.Required
+			} else {
+				a.Computed = true
+			}
+			attrs[nat.Name] = a
+			rd.Attributes = append(rd.Attributes, a)
+		}
+	}
+	addAttrs(create.Payload, true)
+	addAttrs(read.Result, false)
+	robj := expr.AsObject(read.Result.Type)
+	pobj := expr.AsObject(read.Payload.Type)
+	for n, a := range attrs {
+		if a.Optional && robj.Attribute(n) != nil {
+			// Optional attributes returned by the read method are
+			// computed when not set.
+			a.Computed = true
+		}
+		if a.Computed && !a.Optional && pobj != nil && pobj.Attribute(n) != nil {
+			// The computed attributes that identify the resource in
+			// the read method payload, e.g. the resource ID, do not
+			// change on update: keep the state value so that the
+			// plan given to the update method includes it.
+			a.PlanModifier = strings.ToLower(a.Kind) + "planmodifier"
+		}
+	}
+
+	op := func(name, verb string) *terraformOperationData {
+		m, ok := methods[name]
+		if !ok {
+			return nil
+		}
+		md := sd.Service.Method(m.Name)
+		od := &terraformOperationData{
+			MethodName: md.VarName,
+			Error:      fmt.Sprintf("Error %s %s", verb, typeName),
+		}
+		if m.Payload.Type != expr.Empty {
+			od.PayloadRef = sd.Service.Scope.GoFullTypeName(m.Payload, sd.Service.PkgName)
+			od.Payload = terraformPayloadCode(m.Payload, attrs, sd)
+			for _, nat := range *expr.AsObject(m.Payload.Type) {
+				c := terraformCredential(nat.Attribute, provider)
+				if c == nil {
+					continue
+				}
+				rd.Credentials = appendTerraformCredential(rd.Credentials, c)
+				if m.Payload.IsPrimitivePointer(nat.Name, true) {
+					od.Payload = append(od.Payload, fmt.Sprintf("if r.%s != \"\" {\n\tv := r.%s\n\tp.%s = &v\n}", c.VarName, c.VarName, codegen.GoifyAtt(nat.Attribute, nat.Name, true)))
+				} else {
+					od.Payload = append(od.Payload, fmt.Sprintf("p.%s = r.%s", codegen.GoifyAtt(nat.Attribute, nat.Name, true), c.VarName))
+				}
+			}
+		}
+		switch {
+		case m.Result.Type == expr.Empty:
+		case name != expr.TerraformDelete && expr.IsObject(m.Result.Type):
+			od.ResultVar = "res"
+			od.Result = terraformResultCode(m.Result, attrs, sd)
+		default:
+			od.ResultVar = "_"
+		}
+		return od
+	}
+	rd.Create = op(expr.TerraformCreate, "creating")
+	rd.Read = op(expr.TerraformRead, "reading")
+	rd.Update = op(expr.TerraformUpdate, "updating")
+	rd.Delete = op(expr.TerraformDelete, "deleting")
+	return rd
+}
+
+// terraformCredential returns the provider credential used to initialize the
+// given payload attribute or nil if the attribute does not hold a credential.
+func terraformCredential(att *expr.AttributeExpr, provider string) *terraformCredentialData {
+	var name, desc string
+	for k := range att.Meta {
+		switch {
+		case k == "security:username":
+			name, desc = "username", "Username used to authenticate the requests"
+		case k == "security:password":
+			name, desc = "password", "Password used to authenticate the requests"
+		case k == "security:token", k == "security:accesstoken", strings.HasPrefix(k, "security:apikey:"):
+			name, desc = "token", "Token or API key used to authenticate the requests"
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	env := strings.ToUpper(provider + "_" + name)
+	return &terraformCredentialData{
+		Name:        name,
+		FieldName:   codegen.Goify(name, true),
+		VarName:     name,
+		Env:         env,
+		Description: fmt.Sprintf("%s, defaults to the value of the %s environment variable.", desc, env),
+	}
+}
+
+// appendTerraformCredential appends c to cs unless cs already contains a
+// credential with the same name.
+func appendTerraformCredential(cs []*terraformCredentialData, c *terraformCredentialData) []*terraformCredentialData {
+	for _, e := range cs {
+		if e.Name == c.Name {
+			return cs
+		}
+	}
+	return append(cs, c)
+}
+
+// terraformKind returns the Terraform type used to represent the attribute or
+// "" if the attribute cannot be represented.
+func terraformKind(att *expr.AttributeExpr) string {
+	if !expr.IsPrimitive(att.Type) {
+		return ""
+	}
+	for k := range att.Meta {
+		if strings.HasPrefix(k, "security:") {
+			return ""
+		}
+	}
+	switch att.Type.Kind() {
+	case expr.StringKind:
+		return "String"
+	case expr.BooleanKind:
+		return "Bool"
+	case expr.IntKind, expr.Int32Kind, expr.Int64Kind, expr.UIntKind, expr.UInt32Kind, expr.UInt64Kind:
+		return "Int64"
+	case expr.Float32Kind, expr.Float64Kind:
+		return "Float64"
+	}
+	return ""
+}
+
+// terraformNativeType returns the Go type of the values of the given
+// Terraform type.
+func terraformNativeType(kind string) string {
+	switch kind {
+	case "String":
+		return "string"
+	case "Bool":
+		return "bool"
+	case "Int64":
+		return "int64"
+	default:
+		return "float64"
+	}
+}
+
+// terraformPayloadCode returns the statements that initialize the fields of
+// the payload "p" from the model "m".
+func terraformPayloadCode(payload *expr.AttributeExpr, attrs map[string]*terraformAttributeData, sd *ServiceData) []string {
+	var code []string
+	for _, nat := range *expr.AsObject(payload.Type) {
+		a, ok := attrs[nat.Name]
+		if !ok || terraformKind(nat.Attribute) != a.Kind {
+			continue
+		}
+		val := fmt.Sprintf("m.%s.Value%s()", a.FieldName, a.Kind)
+		if ref := sd.Service.Scope.GoFullTypeRef(nat.Attribute, sd.Service.PkgName); ref != terraformNativeType(a.Kind) {
+			val = fmt.Sprintf("%s(%s)", ref, val)
+		}
+		field := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		switch {
+		case payload.HasDefaultValue(nat.Name):
+			def := fmt.Sprintf("%#v", nat.Attribute.DefaultValue)
+			if ref := sd.Service.Scope.GoFullTypeRef(nat.Attribute, sd.Service.PkgName); ref != codegen.GoNativeTypeName(nat.Attribute.Type) {
+				def = fmt.Sprintf("%s(%s)", ref, def)
+			}
+			code = append(code, fmt.Sprintf("p.%s = %s\nif !m.%s.IsNull() && !m.%s.IsUnknown() {\n\tp.%s = %s\n}", field, def, a.FieldName, a.FieldName, field, val))
+			continue
+		case !payload.IsPrimitivePointer(nat.Name, true):
+			code = append(code, fmt.Sprintf("p.%s = %s", field, val))
+			continue
+		}
+		code = append(code, fmt.Sprintf("if !m.%s.IsNull() && !m.%s.IsUnknown() {\n\tv := %s\n\tp.%s = &v\n}", a.FieldName, a.FieldName, val, field))
+	}
+	return code
+}
+
+// terraformResultCode returns the statements that initialize the fields of
+// the model "m" from the result "res".
+func terraformResultCode(result *expr.AttributeExpr, attrs map[string]*terraformAttributeData, sd *ServiceData) []string {
+	var code []string
+	for _, nat := range *expr.AsObject(result.Type) {
+		a, ok := attrs[nat.Name]
+		if !ok || terraformKind(nat.Attribute) != a.Kind {
+			continue
+		}
+		field := codegen.GoifyAtt(nat.Attribute, nat.Name, true)
+		ptr := result.IsPrimitivePointer(nat.Name, true)
+		val := "res." + field
+		if ptr {
+			val = "*" + val
+		}
+		if ref := sd.Service.Scope.GoFullTypeRef(nat.Attribute, sd.Service.PkgName); ref != terraformNativeType(a.Kind) {
+			val = fmt.Sprintf("%s(%s)", terraformNativeType(a.Kind), val)
+		}
+		val = fmt.Sprintf("types.%sValue(%s)", a.Kind, val)
+		if !ptr {
+			code = append(code, fmt.Sprintf("m.%s = %s", a.FieldName, val))
+			continue
+		}
+		code = append(code, fmt.Sprintf("if res.%s != nil {\n\tm.%s = %s\n} else {\n\tm.%s = types.%sNull()\n}", field, a.FieldName, val, a.FieldName, a.Kind))
+	}
+	return code
+}
+
+// input: *terraformData
+const terraformProviderT = `type (
+	// Provider is the {{ .TypeName }} Terraform provider.
+	Provider struct {
+		version string
+	}
+
+	// Clients holds the service clients used by the provider resources.
+	Clients struct {
+	{{- range .Resources }}
+		{{ .ClientField }} *{{ .PkgName }}.Client
+	{{- end }}
+	{{- range .Credentials }}
+		{{ .FieldName }} string
+	{{- end }}
+	}
+
+	// providerModel describes the provider configuration.
+	providerModel struct {
+		Endpoint types.String ` + "`" + `tfsdk:"endpoint"` + "`" + `
+	{{- range .Credentials }}
+		{{ .FieldName }} types.String ` + "`" + `tfsdk:"{{ .Name }}"` + "`" + `
+	{{- end }}
+	}
+)
+
+// New returns a function that creates the provider with the given version
+// suitable for providerserver.NewProtocol6.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &Provider{version: version}
+	}
+}
+
+// Metadata returns the provider type name.
+func (p *Provider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = {{ printf "%q" .TypeName }}
+	resp.Version = p.version
+}
+
+// Schema defines the provider configuration.
+func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "URL of the API, e.g. http://localhost:8080.",
+				Required:    true,
+			},
+		{{- range .Credentials }}
+			{{ printf "%q" .Name }}: schema.StringAttribute{
+				Description: {{ printf "%q" .Description }},
+				Optional:    true,
+				Sensitive:   true,
+			},
+		{{- end }}
+		},
+	}
+}
+
+// Configure creates the service clients used by the resources.
+func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config providerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	u, err := url.Parse(config.Endpoint.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid endpoint", err.Error())
+		return
+	}
+	var (
+		doer    = http.DefaultClient
+		clients = &Clients{}
+	)
+	{{- range .Credentials }}
+	clients.{{ .FieldName }} = os.Getenv({{ printf "%q" .Env }})
+	if !config.{{ .FieldName }}.IsNull() {
+		clients.{{ .FieldName }} = config.{{ .FieldName }}.ValueString()
+	}
+	{{- end }}
+	{{- range .Resources }}
+	{
+		hc := {{ .ClientPkgName }}.NewClient(u.Scheme, u.Host, doer, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if .NeedStream }}, nil, nil{{ end }})
+		clients.{{ .ClientField }} = {{ .PkgName }}.NewClient({{ range $i, $e := .Endpoints }}{{ if $i }}, {{ end }}{{ $e }}{{ end }})
+	}
+	{{- end }}
+	resp.ResourceData = clients
+}
+
+// Resources returns the provider resources.
+func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+	{{- range .Resources }}
+		{{ .ConstructorName }},
+	{{- end }}
+	}
+}
+
+// DataSources returns the provider data sources.
+func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+`
+
+// input: *terraformResourceData
+const terraformResourceT = `type (
+	// {{ .StructName }} implements the {{ .TypeName }} resource using the
+	// {{ .PkgName }} service client.
+	{{ .StructName }} struct {
+		client *{{ .PkgName }}.Client
+	{{- range .Credentials }}
+		{{ .VarName }} string
+	{{- end }}
+	}
+
+	// {{ .ModelName }} describes the {{ .TypeName }} resource data.
+	{{ .ModelName }} struct {
+	{{- range .Attributes }}
+		{{ .FieldName }} types.{{ .Kind }} ` + "`" + `tfsdk:"{{ .Name }}"` + "`" + `
+	{{- end }}
+	}
+)
+
+// {{ .ConstructorName }} returns the {{ .TypeName }} resource.
+func {{ .ConstructorName }}() resource.Resource {
+	return &{{ .StructName }}{}
+}
+
+// Metadata returns the resource type name.
+func (r *{{ .StructName }}) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + {{ printf "%q" (printf "_%s" .TypeName) }}
+}
+
+// Schema defines the resource attributes.
+func (r *{{ .StructName }}) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+	{{- if .Description }}
+		Description: {{ printf "%q" .Description }},
+	{{- end }}
+		Attributes: map[string]schema.Attribute{
+		{{- range .Attributes }}
+			{{ printf "%q" .Name }}: schema.{{ .Kind }}Attribute{
+			{{- if .Description }}
+				Description: {{ printf "%q" .Description }},
+			{{- end }}
+			{{- if .Required }}
+				Required: true,
+			{{- end }}
+			{{- if .Optional }}
+				Optional: true,
+			{{- end }}
+			{{- if .Computed }}
+				Computed: true,
+			{{- end }}
+			{{- if .PlanModifier }}
+				PlanModifiers: []planmodifier.{{ .Kind }}{
+					{{ .PlanModifier }}.UseStateForUnknown(),
+				},
+			{{- end }}
+			},
+		{{- end }}
+		},
+	}
+}
+
+// Configure retrieves the service client from the provider.
+func (r *{{ .StructName }}) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clients, ok := req.ProviderData.(*Clients)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *Clients, got %T", req.ProviderData))
+		return
+	}
+	r.client = clients.{{ .ClientField }}
+	{{- range .Credentials }}
+	r.{{ .VarName }} = clients.{{ .FieldName }}
+	{{- end }}
+}
+
+// Create creates the resource and sets the initial state.
+func (r *{{ .StructName }}) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var m {{ .ModelName }}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	{{- template "terraform-call" .Create }}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Read refreshes the state with the current resource data.
+func (r *{{ .StructName }}) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var m {{ .ModelName }}
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	{{- template "terraform-call" .Read }}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Update updates the resource and sets the updated state.
+func (r *{{ .StructName }}) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+{{- if .Update }}
+	var m {{ .ModelName }}
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	{{- template "terraform-call" .Update }}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+{{- else }}
+	resp.Diagnostics.AddError("Update not supported", {{ printf "%q" (printf "the %s resource cannot be updated" .TypeName) }})
+{{- end }}
+}
+
+// Delete deletes the resource.
+func (r *{{ .StructName }}) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var m {{ .ModelName }}
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	{{- template "terraform-call" .Delete }}
+}
+
+{{- define "terraform-call" }}
+	{{- if .PayloadRef }}
+	p := &{{ .PayloadRef }}{}
+		{{- range .Payload }}
+	{{ . }}
+		{{- end }}
+	{{- end }}
+	{{ if .ResultVar }}{{ .ResultVar }}, {{ end }}err := r.client.{{ .MethodName }}(ctx{{ if .PayloadRef }}, p{{ end }})
+	if err != nil {
+		resp.Diagnostics.AddError({{ printf "%q" .Error }}, err.Error())
+		return
+	}
+	{{- range .Result }}
+	{{ . }}
+	{{- end }}
+{{- end }}
+`
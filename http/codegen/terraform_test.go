@@ -0,0 +1,44 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/v3/codegen"
+	"goa.design/goa/v3/http/codegen/testdata"
+)
+
+func TestTerraformFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Provider string
+		Resource string
+	}{
+		{"no-resource", testdata.SimpleDSL, "", ""},
+		{"resource", testdata.TerraformResourceDSL, testdata.TerraformProviderCode, testdata.TerraformResourceCode},
+		{"secured", testdata.TerraformSecuredResourceDSL, testdata.TerraformSecuredProviderCode, testdata.TerraformSecuredResourceCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := RunHTTPDSL(t, c.DSL)
+			fs := TerraformFiles("gen", root)
+			if c.Provider == "" {
+				if len(fs) != 0 {
+					t.Fatalf("got %d files, expected none", len(fs))
+				}
+				return
+			}
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected 2", len(fs))
+			}
+			provider := codegen.SectionCode(t, fs[0].SectionTemplates[1])
+			if provider != c.Provider {
+				t.Errorf("invalid provider code, got:\n%s\ngot vs. expected:\n%s", provider, codegen.Diff(t, provider, c.Provider))
+			}
+			resource := codegen.SectionCode(t, fs[1].SectionTemplates[1])
+			if resource != c.Resource {
+				t.Errorf("invalid resource code, got:\n%s\ngot vs. expected:\n%s", resource, codegen.Diff(t, resource, c.Resource))
+			}
+		})
+	}
+}
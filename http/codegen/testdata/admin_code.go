@@ -0,0 +1,92 @@
+package testdata
+
+const AdminServerCode = `// adminAddrF is the address of the admin server. The admin server exposes
+// operational endpoints that must only be reachable from the internal network.
+var adminAddrF = flag.String("admin-addr", "localhost:8081", "Admin server address (health, metrics, config and toggles)")
+
+// adminFlags holds the feature flags toggled with the admin server, all flags
+// are off initially. adminFlags implements middleware.FlagProvider so that it
+// can be given to the FeatureFlag endpoint middleware:
+//
+//	endpoints.Use(middleware.FeatureFlag(adminFlags, svc.MethodMeta))
+var adminFlags = &adminFlagSet{flags: map[string]bool{
+	"beta":         false,
+	"new-checkout": false,
+}}
+
+// adminMaintenance is the maintenance switch toggled with the admin server.
+// Add the Maintenance middleware to the HTTP service to honor it:
+//
+//	httpSvc.Use(httpmdlwr.Maintenance(adminMaintenance))
+var adminMaintenance = httpmdlwr.NewMaintenanceSwitch("")
+
+// handleAdminServer starts the admin HTTP server on the given address and
+// shuts it down when ctx is done. Call it from main after the other servers
+// are started:
+//
+//	handleAdminServer(ctx, *adminAddrF, &wg, errc, logger)
+//
+// The admin server serves the following endpoints:
+//
+//	GET /healthz                   liveness check
+//	GET /metrics                   variables published with package expvar
+//	GET /config                    values of the command line flags
+//	GET /flags                     state of the feature flags
+//	PUT, DELETE /flags/{name}      turn a feature flag on or off
+//	GET, PUT, DELETE /maintenance  maintenance mode state and toggle
+func handleAdminServer(ctx context.Context, addr string, wg *sync.WaitGroup, errc chan error, logger *log.Logger) {
+	mux := goahttp.NewMuxer()
+	mux.Handle("GET", "/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeAdminJSON(w, map[string]string{"status": "ok"})
+	})
+	mux.Handle("GET", "/metrics", expvar.Handler().ServeHTTP)
+	mux.Handle("GET", "/config", func(w http.ResponseWriter, r *http.Request) {
+		conf := make(map[string]string)
+		flag.VisitAll(func(f *flag.Flag) { conf[f.Name] = f.Value.String() })
+		writeAdminJSON(w, conf)
+	})
+	mux.Handle("GET", "/flags", func(w http.ResponseWriter, r *http.Request) {
+		writeAdminJSON(w, adminFlags.state())
+	})
+	mux.Handle("PUT", "/flags/{name}", func(w http.ResponseWriter, r *http.Request) {
+		adminFlags.set(mux.Vars(r)["name"], true)
+		writeAdminJSON(w, adminFlags.state())
+	})
+	mux.Handle("DELETE", "/flags/{name}", func(w http.ResponseWriter, r *http.Request) {
+		adminFlags.set(mux.Vars(r)["name"], false)
+		writeAdminJSON(w, adminFlags.state())
+	})
+	for _, verb := range []string{"GET", "PUT", "POST", "DELETE"} {
+		mux.Handle(verb, "/maintenance", adminMaintenance.Handler().ServeHTTP)
+	}
+
+	srv := &http.Server{Addr: addr, Handler: mux}
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start admin server in a separate goroutine.
+		go func() {
+			logger.Printf("admin server listening on %q", addr)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down admin server at %q", addr)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(ctx); err != nil {
+			logger.Printf("failed to shutdown admin server: %v", err)
+		}
+	}()
+}
+
+// writeAdminJSON writes v as the JSON response body.
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v) // nolint: errcheck
+}
+`
@@ -0,0 +1,1512 @@
+package testdata
+
+const ClientTypeScriptCode = `// Test API client.
+//
+// Code generated by goa, DO NOT EDIT.
+
+/** Account is a customer account. */
+export interface Account {
+	/** Account ID */
+	id: string;
+	name: string;
+	status?: "active" | "closed";
+	labels?: string[];
+	owner?: AccountOwner;
+}
+
+export interface AccountOwner {
+	email?: string;
+}
+
+export interface CreatePayload {
+	token?: string;
+	org: number;
+	display_name: string;
+	"dry-run"?: boolean;
+}
+
+/** Account not found */
+export interface NotFound {
+	id?: string;
+}
+
+export interface ShowPayload {
+	user?: string;
+	pass?: string;
+	id?: string;
+}
+
+export interface ShowResult {
+	account?: Account;
+	eTag?: string;
+}
+
+export interface UsersShowPayload {
+	login?: string;
+}
+
+/** ClientOptions configures the service clients. */
+export interface ClientOptions {
+	/** baseURL overrides the default URL of the services. */
+	baseURL?: string;
+	/** fetch overrides the function used to send the requests. */
+	fetch?: typeof fetch;
+	/** headers are added to all the requests. */
+	headers?: Record<string, string>;
+}
+
+/** ServiceError is the error thrown when the service returns an error response. */
+export class ServiceError extends Error {
+	constructor(
+		/** errorName is the name of the error in the design, empty if the error is not described in the design. */
+		readonly errorName: string,
+		/** status is the response status code. */
+		readonly status: number,
+		/** body is the decoded response body. */
+		readonly body: unknown,
+	) {
+		super(errorName ? errorName + " (" + status + ")" : "unexpected response status " + status);
+	}
+}
+
+type Query = [string, unknown][];
+
+async function send(options: ClientOptions, baseURL: string, method: string, path: string, query: Query, headers: Record<string, string>, body?: unknown): Promise<Response> {
+	const params = new URLSearchParams();
+	for (const [name, value] of query) {
+		if (value === undefined || value === null) {
+			continue;
+		}
+		for (const v of Array.isArray(value) ? value : [value]) {
+			params.append(name, String(v));
+		}
+	}
+	const qs = params.toString();
+	const init: RequestInit = { method, headers: { ...options.headers, ...headers } };
+	if (body !== undefined) {
+		init.body = JSON.stringify(body);
+		(init.headers as Record<string, string>)["Content-Type"] = "application/json";
+	}
+	return (options.fetch || fetch)((options.baseURL || baseURL) + path + (qs ? "?" + qs : ""), init);
+}
+
+function setHeader(headers: Record<string, string>, name: string, value: unknown) {
+	if (value === undefined || value === null) {
+		return;
+	}
+	headers[name] = Array.isArray(value) ? value.join(",") : String(value);
+}
+
+function bearer(token: unknown): string | undefined {
+	if (token === undefined || token === null) {
+		return undefined;
+	}
+	const s = String(token);
+	return s.includes(" ") ? s : "Bearer " + s;
+}
+
+function parseBoolean(v: string): boolean {
+	return v === "true";
+}
+
+function parseList(v: string): string[] {
+	return v.split(",").map((s) => s.trim());
+}
+
+async function decode(res: Response): Promise<unknown> {
+	const text = await res.text();
+	if (!text) {
+		return undefined;
+	}
+	try {
+		return JSON.parse(text);
+	} catch {
+		return text;
+	}
+}
+
+async function fail(res: Response, errors: [number, string][]): Promise<never> {
+	const body = await decode(res);
+	let name = "";
+	if (body && typeof body === "object" && typeof (body as Record<string, unknown>).name === "string") {
+		name = (body as Record<string, unknown>).name as string;
+	}
+	if (!errors.some(([, n]) => n === name)) {
+		const found = errors.find(([status]) => status === res.status);
+		name = found ? found[1] : "";
+	}
+	throw new ServiceError(name, res.status, body);
+}
+
+/** Manage accounts */
+export class AccountsClient {
+	constructor(private readonly options: ClientOptions = {}) {}
+
+	/** Create an account */
+	async create(payload: CreatePayload): Promise<Account> {
+		const query: Query = [["dry", payload["dry-run"]]];
+		const headers: Record<string, string> = {};
+		setHeader(headers, "Authorization", bearer(payload.token));
+		const res = await send(this.options, "https://api.example.com", "POST", "/orgs/" + encodeURIComponent(String(payload.org)) + "/accounts", query, headers, { display_name: payload.display_name });
+		if (res.status !== 201) {
+			return fail(res, []);
+		}
+		return (await decode(res)) as Account;
+	}
+
+	async show(payload: ShowPayload): Promise<ShowResult> {
+		const query: Query = [];
+		const headers: Record<string, string> = {};
+		setHeader(headers, "Authorization", "Basic " + btoa(payload.user + ":" + payload.pass));
+		const res = await send(this.options, "https://api.example.com", "GET", "/accounts/" + encodeURIComponent(String(payload.id)), query, headers);
+		if (res.status !== 200) {
+			return fail(res, [[404, "not_found"]]);
+		}
+		const result = {} as ShowResult;
+		result["account"] = (await decode(res)) as ShowResult["account"];
+		if (res.headers.has("ETag")) {
+			Object.assign(result, { eTag: String(res.headers.get("ETag") as string) });
+		}
+		return result;
+	}
+
+	async count(payload: string[]): Promise<number> {
+		const query: Query = [["tags", payload?.join(",")]];
+		const headers: Record<string, string> = {};
+		const res = await send(this.options, "https://api.example.com", "GET", "/accounts/count", query, headers);
+		if (res.status !== 200) {
+			return fail(res, []);
+		}
+		return (await decode(res)) as number;
+	}
+
+	async purge(): Promise<void> {
+		const query: Query = [];
+		const headers: Record<string, string> = {};
+		const res = await send(this.options, "https://api.example.com", "DELETE", "/accounts", query, headers);
+		if (res.status !== 204) {
+			return fail(res, []);
+		}
+	}
+}
+
+export class UsersClient {
+	constructor(private readonly options: ClientOptions = {}) {}
+
+	async show(payload: UsersShowPayload): Promise<void> {
+		const query: Query = [];
+		const headers: Record<string, string> = {};
+		const res = await send(this.options, "https://api.example.com", "GET", "/users/" + encodeURIComponent(String(payload.login)), query, headers);
+		if (res.status !== 204) {
+			return fail(res, []);
+		}
+	}
+}
+
+/** Client groups the clients of all the services. */
+export class Client {
+	readonly accounts: AccountsClient;
+	readonly users: UsersClient;
+
+	constructor(options: ClientOptions = {}) {
+		this.accounts = new AccountsClient(options);
+		this.users = new UsersClient(options);
+	}
+}
+`
+
+const ClientPythonProjectCode = `[build-system]
+requires = ["setuptools>=61"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = "test-client"
+version = "0.0.0"
+description = "Test API client"
+requires-python = ">=3.8"
+dependencies = ["requests>=2"]
+
+[tool.setuptools]
+packages = ["test"]
+`
+
+const ClientPythonInitCode = `"""Test API client.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from .client import Client, AccountsClient, UsersClient
+from .errors import ServiceError, NotFoundError
+from .models import (
+    Account,
+    AccountOwner,
+    CreatePayload,
+    NotFound,
+    ShowPayload,
+    ShowResult,
+    UsersShowPayload,
+)
+
+__all__ = [
+    "Client",
+    "AccountsClient",
+    "UsersClient",
+    "ServiceError",
+    "NotFoundError",
+    "Account",
+    "AccountOwner",
+    "CreatePayload",
+    "NotFound",
+    "ShowPayload",
+    "ShowResult",
+    "UsersShowPayload",
+]
+`
+
+const ClientPythonModelsCode = `"""Test API types.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+import dataclasses
+from dataclasses import dataclass
+from typing import Any, Dict, List, Literal, Optional
+
+
+def _to_json(value: Any) -> Any:
+    """Return the JSON representation of value."""
+    if dataclasses.is_dataclass(value):
+        return value.to_dict()
+    if isinstance(value, list):
+        return [_to_json(v) for v in value]
+    if isinstance(value, dict):
+        return {k: _to_json(v) for k, v in value.items()}
+    return value
+
+
+def _decode(value: Any, spec: Any) -> Any:
+    """Return the value described by the JSON value and the decoding spec."""
+    if value is None or spec is None:
+        return value
+    if isinstance(spec, tuple):
+        kind, elem = spec
+        if kind == "list":
+            return [_decode(v, elem) for v in value]
+        return {k: _decode(v, elem) for k, v in value.items()}
+    return spec.from_dict(value)
+
+
+@dataclass
+class Account:
+    """Account is a customer account."""
+    id: str
+    """Account ID"""
+    name: str
+    status: Optional[Literal["active", "closed"]] = None
+    tags: Optional[List[str]] = None
+    owner: Optional[AccountOwner] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.id is not None:
+            d["id"] = _to_json(self.id)
+        if self.name is not None:
+            d["name"] = _to_json(self.name)
+        if self.status is not None:
+            d["status"] = _to_json(self.status)
+        if self.tags is not None:
+            d["labels"] = _to_json(self.tags)
+        if self.owner is not None:
+            d["owner"] = _to_json(self.owner)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> Account:
+        """Return the value described by the JSON object d."""
+        return cls(
+            id=d.get("id"),
+            name=d.get("name"),
+            status=d.get("status"),
+            tags=d.get("labels"),
+            owner=_decode(d.get("owner"), AccountOwner),
+        )
+
+
+@dataclass
+class AccountOwner:
+    email: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.email is not None:
+            d["email"] = _to_json(self.email)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> AccountOwner:
+        """Return the value described by the JSON object d."""
+        return cls(
+            email=d.get("email"),
+        )
+
+
+@dataclass
+class CreatePayload:
+    org: int
+    name: str
+    token: Optional[str] = None
+    dry_run: Optional[bool] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.org is not None:
+            d["org"] = _to_json(self.org)
+        if self.name is not None:
+            d["display_name"] = _to_json(self.name)
+        if self.token is not None:
+            d["token"] = _to_json(self.token)
+        if self.dry_run is not None:
+            d["dry-run"] = _to_json(self.dry_run)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> CreatePayload:
+        """Return the value described by the JSON object d."""
+        return cls(
+            org=d.get("org"),
+            name=d.get("display_name"),
+            token=d.get("token"),
+            dry_run=d.get("dry-run"),
+        )
+
+
+@dataclass
+class NotFound:
+    """Account not found"""
+    id: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.id is not None:
+            d["id"] = _to_json(self.id)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> NotFound:
+        """Return the value described by the JSON object d."""
+        return cls(
+            id=d.get("id"),
+        )
+
+
+@dataclass
+class ShowPayload:
+    user: Optional[str] = None
+    pass_: Optional[str] = None
+    id: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.user is not None:
+            d["user"] = _to_json(self.user)
+        if self.pass_ is not None:
+            d["pass"] = _to_json(self.pass_)
+        if self.id is not None:
+            d["id"] = _to_json(self.id)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> ShowPayload:
+        """Return the value described by the JSON object d."""
+        return cls(
+            user=d.get("user"),
+            pass_=d.get("pass"),
+            id=d.get("id"),
+        )
+
+
+@dataclass
+class ShowResult:
+    account: Optional[Account] = None
+    etag: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.account is not None:
+            d["account"] = _to_json(self.account)
+        if self.etag is not None:
+            d["eTag"] = _to_json(self.etag)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> ShowResult:
+        """Return the value described by the JSON object d."""
+        return cls(
+            account=_decode(d.get("account"), Account),
+            etag=d.get("eTag"),
+        )
+
+
+@dataclass
+class UsersShowPayload:
+    login: Optional[str] = None
+
+    def to_dict(self) -> Dict[str, Any]:
+        """Return the JSON representation of the value."""
+        d: Dict[str, Any] = {}
+        if self.login is not None:
+            d["login"] = _to_json(self.login)
+        return d
+
+    @classmethod
+    def from_dict(cls, d: Dict[str, Any]) -> UsersShowPayload:
+        """Return the value described by the JSON object d."""
+        return cls(
+            login=d.get("login"),
+        )
+`
+
+const ClientPythonErrorsCode = `"""Test API errors.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+
+class ServiceError(Exception):
+    """Raised when the service returns an error response.
+
+    status is the response status code, body the decoded response body and
+    data the error value decoded with the error type described in the design.
+    """
+
+    name = ""
+
+    def __init__(self, status: int, body: Any = None, data: Any = None) -> None:
+        super().__init__(f"{self.name or 'unexpected response'} ({status})")
+        self.status = status
+        self.body = body
+        self.data = data
+
+
+class NotFoundError(ServiceError):
+    name = "not_found"
+`
+
+const ClientPythonClientCode = `"""Test API client.
+
+Code generated by goa, DO NOT EDIT.
+"""
+
+from __future__ import annotations
+
+from typing import Any, Dict, List, NoReturn, Optional, Tuple
+from urllib.parse import quote
+
+from . import errors, models
+from .models import _decode, _to_json
+
+
+class _Transport:
+    """Sends the requests with a requests (or compatible) session."""
+
+    def __init__(self, base_url: str, session: Any, headers: Optional[Dict[str, str]], timeout: Optional[float]) -> None:
+        if session is None:
+            import requests
+
+            session = requests.Session()
+        self.base_url = base_url
+        self.session = session
+        self.headers = dict(headers or {})
+        self.timeout = timeout
+
+    def request(self, method: str, path: str, params: List[Tuple[str, str]], headers: Dict[str, str], body: Any = None, auth: Any = None) -> Any:
+        kwargs: Dict[str, Any] = {"params": params, "headers": {**self.headers, **headers}}
+        if body is not None:
+            kwargs["json"] = body
+        if auth is not None:
+            kwargs["auth"] = auth
+        if self.timeout is not None:
+            kwargs["timeout"] = self.timeout
+        return self.session.request(method, self.base_url + path, **kwargs)
+
+
+def _str(value: Any) -> str:
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    return str(value)
+
+
+def _path(value: Any) -> str:
+    return quote(_str(value), safe="")
+
+
+def _params(pairs: List[Tuple[str, Any]]) -> List[Tuple[str, str]]:
+    params = []
+    for name, value in pairs:
+        if value is None:
+            continue
+        for v in value if isinstance(value, list) else [value]:
+            params.append((name, _str(v)))
+    return params
+
+
+def _csv(value: Any) -> Any:
+    return None if value is None else ",".join(_str(v) for v in value)
+
+
+def _headers(pairs: List[Tuple[str, Any]]) -> Dict[str, str]:
+    headers = {}
+    for name, value in pairs:
+        if value is None:
+            continue
+        headers[name] = ",".join(_str(v) for v in value) if isinstance(value, list) else _str(value)
+    return headers
+
+
+def _bearer(token: Optional[str]) -> Optional[str]:
+    if token is None or " " in token:
+        return token
+    return "Bearer " + token
+
+
+def _compact(d: Dict[str, Any]) -> Dict[str, Any]:
+    return {k: v for k, v in d.items() if v is not None}
+
+
+def _bool(value: str) -> bool:
+    return value == "true"
+
+
+def _list(value: str) -> List[str]:
+    return [v.strip() for v in value.split(",")]
+
+
+def _json(res: Any) -> Any:
+    if not res.content:
+        return None
+    try:
+        return res.json()
+    except ValueError:
+        return res.text
+
+
+def _fail(res: Any, errs: List[Tuple[int, str, Any, Any]]) -> NoReturn:
+    body = _json(res)
+    name = body.get("name") if isinstance(body, dict) else None
+    match = next((e for e in errs if e[1] == name), None) or next((e for e in errs if e[0] == res.status_code), None)
+    if match is None:
+        raise errors.ServiceError(res.status_code, body)
+    _, _, cls, spec = match
+    data = _decode(body, spec) if isinstance(body, dict) or spec is None else body
+    raise cls(res.status_code, body, data)
+
+
+class AccountsClient:
+    """Manage accounts"""
+
+    def __init__(self, base_url: Optional[str] = None, session: Any = None, headers: Optional[Dict[str, str]] = None, timeout: Optional[float] = None) -> None:
+        self._transport = _Transport(base_url or "https://api.example.com", session, headers, timeout)
+
+    def create(self, payload: models.CreatePayload) -> models.Account:
+        """Create an account"""
+        res = self._transport.request(
+            "POST",
+            "/orgs/" + _path(payload.org) + "/accounts",
+            _params([("dry", payload.dry_run)]),
+            _headers([("Authorization", _bearer(payload.token))]),
+            body=_compact({"display_name": _to_json(payload.name)}),
+        )
+        if res.status_code != 201:
+            _fail(res, [])
+        return _decode(_json(res), models.Account)
+
+    def show(self, payload: models.ShowPayload) -> models.ShowResult:
+        res = self._transport.request(
+            "GET",
+            "/accounts/" + _path(payload.id),
+            _params([]),
+            _headers([]),
+            auth=(payload.user, payload.pass_),
+        )
+        if res.status_code != 200:
+            _fail(res, [(404, "not_found", errors.NotFoundError, models.NotFound)])
+        d = {"account": _json(res)}
+        if "ETag" in res.headers:
+            d["eTag"] = str(res.headers["ETag"])
+        return models.ShowResult.from_dict(d)
+
+    def count(self, payload: List[str]) -> int:
+        res = self._transport.request(
+            "GET",
+            "/accounts/count",
+            _params([("tags", _csv(payload))]),
+            _headers([]),
+        )
+        if res.status_code != 200:
+            _fail(res, [])
+        return _decode(_json(res), None)
+
+    def purge(self) -> None:
+        res = self._transport.request(
+            "DELETE",
+            "/accounts",
+            _params([]),
+            _headers([]),
+        )
+        if res.status_code != 204:
+            _fail(res, [])
+
+
+class UsersClient:
+
+    def __init__(self, base_url: Optional[str] = None, session: Any = None, headers: Optional[Dict[str, str]] = None, timeout: Optional[float] = None) -> None:
+        self._transport = _Transport(base_url or "https://api.example.com", session, headers, timeout)
+
+    def show(self, payload: models.UsersShowPayload) -> None:
+        res = self._transport.request(
+            "GET",
+            "/users/" + _path(payload.login),
+            _params([]),
+            _headers([]),
+        )
+        if res.status_code != 204:
+            _fail(res, [])
+
+
+class Client:
+    """Client groups the clients of all the services."""
+
+    def __init__(self, base_url: Optional[str] = None, session: Any = None, headers: Optional[Dict[str, str]] = None, timeout: Optional[float] = None) -> None:
+        if session is None:
+            import requests
+
+            session = requests.Session()
+        self.accounts = AccountsClient(base_url, session, headers, timeout)
+        self.users = UsersClient(base_url, session, headers, timeout)
+`
+
+const ClientJavaPomCode = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Code generated by goa, DO NOT EDIT. -->
+<project xmlns="http://maven.apache.org/POM/4.0.0"
+         xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+         xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 https://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+
+  <groupId>test</groupId>
+  <artifactId>test-client</artifactId>
+  <version>0.0.0</version>
+  <name>Test API client</name>
+
+  <properties>
+    <maven.compiler.release>17</maven.compiler.release>
+    <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+  </properties>
+
+  <dependencies>
+    <dependency>
+      <groupId>com.squareup.okhttp3</groupId>
+      <artifactId>okhttp</artifactId>
+      <version>4.12.0</version>
+    </dependency>
+    <dependency>
+      <groupId>com.fasterxml.jackson.core</groupId>
+      <artifactId>jackson-databind</artifactId>
+      <version>2.17.2</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+const ClientJavaClientCode = `// Code generated by goa, DO NOT EDIT.
+
+package test;
+
+import java.util.Map;
+import okhttp3.OkHttpClient;
+
+/** Client groups the clients of all the Test API services. */
+public final class Client {
+    /** accounts is the client of the accounts service. */
+    public final AccountsClient accounts;
+    /** users is the client of the users service. */
+    public final UsersClient users;
+
+    /** Creates a client that sends the requests to the default URLs with a new OkHttp client. */
+    public Client() {
+        this(null, null, null);
+    }
+
+    /**
+     * Creates a client that sends the requests to baseUrl, or the default
+     * URLs if null, with the given OkHttp client, or a new client if null.
+     * The given headers, if not null, are added to all the requests.
+     */
+    public Client(String baseUrl, OkHttpClient http, Map<String, String> headers) {
+        if (http == null) {
+            http = new OkHttpClient();
+        }
+        this.accounts = new AccountsClient(baseUrl, http, headers);
+        this.users = new UsersClient(baseUrl, http, headers);
+    }
+}
+`
+
+const ClientJavaTransportCode = `// Code generated by goa, DO NOT EDIT.
+
+package test;
+
+import com.fasterxml.jackson.core.JsonProcessingException;
+import com.fasterxml.jackson.core.type.TypeReference;
+import com.fasterxml.jackson.databind.DeserializationFeature;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.node.ArrayNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+import com.fasterxml.jackson.databind.node.TextNode;
+import java.io.IOException;
+import java.net.URLEncoder;
+import java.nio.charset.StandardCharsets;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.stream.Collectors;
+import okhttp3.Credentials;
+import okhttp3.HttpUrl;
+import okhttp3.MediaType;
+import okhttp3.OkHttpClient;
+import okhttp3.Request;
+import okhttp3.RequestBody;
+import okhttp3.Response;
+
+/** Transport sends the requests of the service clients with OkHttp. */
+final class Transport {
+    /** MAPPER encodes and decodes the JSON bodies. */
+    static final ObjectMapper MAPPER = new ObjectMapper()
+            .configure(DeserializationFeature.FAIL_ON_UNKNOWN_PROPERTIES, false);
+
+    private static final MediaType JSON = MediaType.get("application/json; charset=utf-8");
+
+    private final String baseUrl;
+    private final OkHttpClient http;
+    private final Map<String, String> headers;
+
+    Transport(String baseUrl, OkHttpClient http, Map<String, String> headers) {
+        this.baseUrl = baseUrl;
+        this.http = http == null ? new OkHttpClient() : http;
+        this.headers = headers == null ? Map.of() : Map.copyOf(headers);
+    }
+
+    /** Returns a builder of a request with the given method and path. */
+    RequestBuilder request(String method, String path) {
+        return new RequestBuilder(method, path);
+    }
+
+    /** RequestBuilder builds and sends a request. */
+    final class RequestBuilder {
+        private final String method;
+        private final HttpUrl.Builder url;
+        private final Request.Builder req = new Request.Builder();
+        private RequestBody body;
+
+        RequestBuilder(String method, String path) {
+            this.method = method;
+            this.url = HttpUrl.get(baseUrl + path).newBuilder();
+            headers.forEach(req::header);
+        }
+
+        /** Adds the query string parameter unless value is null, one parameter per element for lists. */
+        RequestBuilder query(String name, Object value) {
+            if (value instanceof List<?> list) {
+                for (Object v : list) {
+                    url.addQueryParameter(name, string(v));
+                }
+            } else if (value != null) {
+                url.addQueryParameter(name, string(value));
+            }
+            return this;
+        }
+
+        /** Sets the header unless value is null, lists are joined with commas. */
+        RequestBuilder header(String name, Object value) {
+            if (value instanceof List<?> list) {
+                req.header(name, list.stream().map(Transport::string).collect(Collectors.joining(",")));
+            } else if (value != null) {
+                req.header(name, string(value));
+            }
+            return this;
+        }
+
+        /** Sets the basic auth credentials unless username is null. */
+        RequestBuilder basicAuth(String username, String password) {
+            if (username != null) {
+                req.header("Authorization", Credentials.basic(username, password == null ? "" : password));
+            }
+            return this;
+        }
+
+        /** Sets the JSON body. */
+        RequestBuilder body(Object value) throws IOException {
+            body = RequestBody.create(MAPPER.writeValueAsBytes(value), JSON);
+            return this;
+        }
+
+        /** Sends the request and returns the response that must be closed. */
+        Response send() throws IOException {
+            RequestBody b = body;
+            if (b == null && (method.equals("POST") || method.equals("PUT") || method.equals("PATCH"))) {
+                b = RequestBody.create(new byte[0], null);
+            }
+            return http.newCall(req.url(url.build()).method(method, b).build()).execute();
+        }
+    }
+
+    /** ErrorFactory creates the exception of an error. */
+    interface ErrorFactory {
+        ServiceException create(int status, JsonNode body, Object data);
+    }
+
+    /** ErrorType describes an error returned by a method. */
+    record ErrorType(int status, String name, ErrorFactory factory, TypeReference<?> type) {
+    }
+
+    /**
+     * Returns the exception of the error response: the error whose name is
+     * the value of the body "name" field or else whose status is the
+     * response status, a ServiceException if none matches.
+     */
+    static ServiceException error(Response res, ErrorType... errors) throws IOException {
+        JsonNode body = json(res);
+        String name = body != null && body.hasNonNull("name") ? body.get("name").asText() : null;
+        ErrorType match = null;
+        for (ErrorType e : errors) {
+            if (e.name().equals(name)) {
+                match = e;
+                break;
+            }
+        }
+        if (match == null) {
+            for (ErrorType e : errors) {
+                if (e.status() == res.code()) {
+                    match = e;
+                    break;
+                }
+            }
+        }
+        if (match == null) {
+            return new ServiceException(res.code(), body, null);
+        }
+        Object data;
+        try {
+            data = decode(body, match.type());
+        } catch (IOException e) {
+            data = null;
+        }
+        return match.factory().create(res.code(), body, data);
+    }
+
+    /** Returns the JSON value of the response body, null if the body is empty. */
+    static JsonNode json(Response res) throws IOException {
+        byte[] b = res.body() == null ? new byte[0] : res.body().bytes();
+        if (b.length == 0) {
+            return null;
+        }
+        try {
+            return MAPPER.readTree(b);
+        } catch (JsonProcessingException e) {
+            return TextNode.valueOf(new String(b, StandardCharsets.UTF_8));
+        }
+    }
+
+    /** Returns the value of the given type described by node, null if node is null. */
+    static <T> T decode(JsonNode node, TypeReference<T> type) throws IOException {
+        if (node == null || node.isNull()) {
+            return null;
+        }
+        return MAPPER.readerFor(type).readValue(node);
+    }
+
+    /**
+     * Returns the JSON object of a result: node if field is null and node is
+     * an object, an object whose field is node otherwise.
+     */
+    static ObjectNode object(String field, JsonNode node) {
+        if (field == null && node instanceof ObjectNode o) {
+            return o;
+        }
+        ObjectNode o = MAPPER.createObjectNode();
+        if (field != null && node != null) {
+            o.set(field, node);
+        }
+        return o;
+    }
+
+    /** Sets the field of the result object to the value of the response header if any. */
+    static void header(ObjectNode d, String field, Response res, String name, boolean list) {
+        JsonNode v = headerValue(res.header(name), list);
+        if (v != null) {
+            d.set(field, v);
+        }
+    }
+
+    /** Returns the JSON value of a header, split on commas if list is true. */
+    static JsonNode headerValue(String value, boolean list) {
+        if (value == null) {
+            return null;
+        }
+        if (!list) {
+            return TextNode.valueOf(value);
+        }
+        ArrayNode a = MAPPER.createArrayNode();
+        for (String v : value.split(",")) {
+            a.add(v.trim());
+        }
+        return a;
+    }
+
+    /** Returns the object made of the given pairs of names and values, null values are skipped. */
+    static Map<String, Object> fields(Object... pairs) {
+        Map<String, Object> m = new LinkedHashMap<>();
+        for (int i = 0; i + 1 < pairs.length; i += 2) {
+            if (pairs[i + 1] != null) {
+                m.put((String) pairs[i], pairs[i + 1]);
+            }
+        }
+        return m;
+    }
+
+    /** Returns the path segment that encodes value. */
+    static String path(Object value) {
+        return URLEncoder.encode(string(value), StandardCharsets.UTF_8).replace("+", "%20");
+    }
+
+    /** Returns the elements of the list joined with commas, null if value is null. */
+    static String csv(List<?> value) {
+        if (value == null) {
+            return null;
+        }
+        return value.stream().map(Transport::string).collect(Collectors.joining(","));
+    }
+
+    /** Returns the value of the Authorization header for the given token. */
+    static String bearer(String token) {
+        if (token == null || token.contains(" ")) {
+            return token;
+        }
+        return "Bearer " + token;
+    }
+
+    private static String string(Object value) {
+        return String.valueOf(value);
+    }
+}
+`
+
+const ClientJavaServiceExceptionCode = `// Code generated by goa, DO NOT EDIT.
+
+package test;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import java.io.IOException;
+
+/**
+ * ServiceException is thrown when the service returns an error response.
+ * status is the response status code, body the JSON value of the response
+ * body and data the error value decoded with the error type described in the
+ * design.
+ */
+public class ServiceException extends IOException {
+    private final String name;
+    private final int status;
+    private final transient JsonNode body;
+    private final transient Object data;
+
+    public ServiceException(int status, JsonNode body, Object data) {
+        this(null, status, body, data);
+    }
+
+    protected ServiceException(String name, int status, JsonNode body, Object data) {
+        super((name == null ? "unexpected response" : name) + " (" + status + ")");
+        this.name = name;
+        this.status = status;
+        this.body = body;
+        this.data = data;
+    }
+
+    /** Returns the error name, null if the response matches no error of the design. */
+    public String name() {
+        return name;
+    }
+
+    /** Returns the response status code. */
+    public int status() {
+        return status;
+    }
+
+    /** Returns the JSON value of the response body, null if the body is empty. */
+    public JsonNode body() {
+        return body;
+    }
+
+    /** Returns the decoded error value, null if the body could not be decoded. */
+    public Object data() {
+        return data;
+    }
+}
+`
+
+const ClientJavaAccountsClientCode = `// Code generated by goa, DO NOT EDIT.
+
+package test;
+
+
+import com.fasterxml.jackson.core.type.TypeReference;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+import java.io.IOException;
+import java.util.List;
+import java.util.Map;
+import okhttp3.OkHttpClient;
+import okhttp3.Response;
+import test.model.*;
+
+/** Manage accounts */
+public final class AccountsClient {
+    /** DEFAULT_URL is the default URL of the service. */
+    public static final String DEFAULT_URL = "https://api.example.com";
+
+    private final Transport transport;
+
+    /** Creates a client that sends the requests to DEFAULT_URL with a new OkHttp client. */
+    public AccountsClient() {
+        this(null, null, null);
+    }
+
+    /**
+     * Creates a client that sends the requests to baseUrl, or DEFAULT_URL if
+     * null, with the given OkHttp client, or a new client if null. The given
+     * headers, if not null, are added to all the requests.
+     */
+    public AccountsClient(String baseUrl, OkHttpClient http, Map<String, String> headers) {
+        this.transport = new Transport(baseUrl == null ? DEFAULT_URL : baseUrl, http, headers);
+    }
+    /** Create an account */
+    public Account create(CreatePayload payload) throws IOException {
+        try (Response res = transport.request("POST", "/orgs/" + Transport.path(payload.org()) + "/accounts")
+                .query("dry", payload.dryRun())
+                .header("Authorization", Transport.bearer(payload.token()))
+                .body(Transport.fields("display_name", payload.name()))
+                .send()) {
+            if (res.code() != 201) {
+                throw Transport.error(res);
+            }
+            return Transport.decode(Transport.json(res), new TypeReference<Account>() {});
+        }
+    }
+
+    public ShowResult show(ShowPayload payload) throws IOException {
+        try (Response res = transport.request("GET", "/accounts/" + Transport.path(payload.id()))
+                .basicAuth(payload.user(), payload.pass())
+                .send()) {
+            if (res.code() != 200) {
+                throw Transport.error(res,
+                        new Transport.ErrorType(404, "not_found", NotFoundException::new, new TypeReference<NotFound>() {}));
+            }
+            ObjectNode d = Transport.object("account", Transport.json(res));
+            Transport.header(d, "eTag", res, "ETag", false);
+            return Transport.decode(d, new TypeReference<ShowResult>() {});
+        }
+    }
+
+    public Long count(List<String> payload) throws IOException {
+        try (Response res = transport.request("GET", "/accounts/count")
+                .query("tags", Transport.csv(payload))
+                .send()) {
+            if (res.code() != 200) {
+                throw Transport.error(res);
+            }
+            return Transport.decode(Transport.json(res), new TypeReference<Long>() {});
+        }
+    }
+
+    public void purge() throws IOException {
+        try (Response res = transport.request("DELETE", "/accounts")
+                .send()) {
+            if (res.code() != 204) {
+                throw Transport.error(res);
+            }
+        }
+    }
+}
+`
+
+const ClientJavaNotFoundExceptionCode = `// Code generated by goa, DO NOT EDIT.
+
+package test;
+
+
+import com.fasterxml.jackson.databind.JsonNode;
+import test.model.*;
+
+/** NotFoundException is thrown when the service returns a not_found error. */
+public class NotFoundException extends ServiceException {
+    public NotFoundException(int status, JsonNode body, Object data) {
+        super("not_found", status, body, data);
+    }
+
+    /** Returns the decoded error value, null if the body could not be decoded. */
+    @Override
+    public NotFound data() {
+        return (NotFound) super.data();
+    }
+}
+`
+
+const ClientJavaAccountCode = `// Code generated by goa, DO NOT EDIT.
+
+package test.model;
+
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonInclude;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.util.List;
+
+/**
+ * Account is a customer account.
+ *
+ * @param id Account ID
+ */
+@JsonInclude(JsonInclude.Include.NON_NULL)
+@JsonIgnoreProperties(ignoreUnknown = true)
+public record Account(
+        @JsonProperty(value = "id", required = true) String id,
+        @JsonProperty(value = "name", required = true) String name,
+        @JsonProperty("status") String status,
+        @JsonProperty("labels") List<String> tags,
+        @JsonProperty("owner") AccountOwner owner) {
+}
+`
+
+const ClientJavaShowResultCode = `// Code generated by goa, DO NOT EDIT.
+
+package test.model;
+
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonInclude;
+import com.fasterxml.jackson.annotation.JsonProperty;
+
+/** ShowResult is the ShowResult type. */
+@JsonInclude(JsonInclude.Include.NON_NULL)
+@JsonIgnoreProperties(ignoreUnknown = true)
+public record ShowResult(
+        @JsonProperty("account") Account account,
+        @JsonProperty("eTag") String etag) {
+}
+`
+
+const ClientSwiftModelsCode = `// Test API types.
+//
+// Code generated by goa, DO NOT EDIT.
+
+import Foundation
+
+/// Account is a customer account.
+public struct Account: Codable, Equatable {
+    /// Account ID
+    public var id: String
+    public var name: String
+    public var status: String?
+    public var tags: [String]?
+    public var owner: AccountOwner?
+
+    enum CodingKeys: String, CodingKey {
+        case id
+        case name
+        case status
+        case tags = "labels"
+        case owner
+    }
+
+    public init(id: String, name: String, status: String? = nil, tags: [String]? = nil, owner: AccountOwner? = nil) {
+        self.id = id
+        self.name = name
+        self.status = status
+        self.tags = tags
+        self.owner = owner
+    }
+}
+
+/// AccountOwner is the AccountOwner type.
+public struct AccountOwner: Codable, Equatable {
+    public var email: String?
+
+    public init(email: String? = nil) {
+        self.email = email
+    }
+}
+
+/// CreatePayload is the CreatePayload type.
+public struct CreatePayload: Codable, Equatable {
+    public var token: String?
+    public var org: Int64
+    public var name: String
+    public var dryRun: Bool?
+
+    enum CodingKeys: String, CodingKey {
+        case token
+        case org
+        case name = "display_name"
+        case dryRun = "dry-run"
+    }
+
+    public init(token: String? = nil, org: Int64, name: String, dryRun: Bool? = nil) {
+        self.token = token
+        self.org = org
+        self.name = name
+        self.dryRun = dryRun
+    }
+}
+
+/// Account not found
+public struct NotFound: Codable, Equatable {
+    public var id: String?
+
+    public init(id: String? = nil) {
+        self.id = id
+    }
+}
+
+/// ShowPayload is the ShowPayload type.
+public struct ShowPayload: Codable, Equatable {
+    public var user: String?
+    public var pass: String?
+    public var id: String?
+
+    public init(user: String? = nil, pass: String? = nil, id: String? = nil) {
+        self.user = user
+        self.pass = pass
+        self.id = id
+    }
+}
+
+/// ShowResult is the ShowResult type.
+public struct ShowResult: Codable, Equatable {
+    public var account: Account?
+    public var etag: String?
+
+    enum CodingKeys: String, CodingKey {
+        case account
+        case etag = "eTag"
+    }
+
+    public init(account: Account? = nil, etag: String? = nil) {
+        self.account = account
+        self.etag = etag
+    }
+}
+
+/// UsersShowPayload is the UsersShowPayload type.
+public struct UsersShowPayload: Codable, Equatable {
+    public var login: String?
+
+    public init(login: String? = nil) {
+        self.login = login
+    }
+}
+`
+
+const ClientKotlinModelsCode = `// Test API types.
+//
+// Code generated by goa, DO NOT EDIT.
+
+package test.model
+
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+
+/** Account is a customer account. */
+@Serializable
+data class Account(
+    /** Account ID */
+    val id: String,
+    val name: String,
+    val status: String? = null,
+    @SerialName("labels") val tags: List<String>? = null,
+    val owner: AccountOwner? = null,
+)
+
+/** AccountOwner is the AccountOwner type. */
+@Serializable
+data class AccountOwner(
+    val email: String? = null,
+)
+
+/** CreatePayload is the CreatePayload type. */
+@Serializable
+data class CreatePayload(
+    val token: String? = null,
+    val org: Long,
+    @SerialName("display_name") val name: String,
+    @SerialName("dry-run") val dryRun: Boolean? = null,
+)
+
+/** Account not found */
+@Serializable
+data class NotFound(
+    val id: String? = null,
+)
+
+/** ShowPayload is the ShowPayload type. */
+@Serializable
+data class ShowPayload(
+    val user: String? = null,
+    val pass: String? = null,
+    val id: String? = null,
+)
+
+/** ShowResult is the ShowResult type. */
+@Serializable
+data class ShowResult(
+    val account: Account? = null,
+    @SerialName("eTag") val etag: String? = null,
+)
+
+/** UsersShowPayload is the UsersShowPayload type. */
+@Serializable
+data class UsersShowPayload(
+    val login: String? = null,
+)
+`
+
+const ClientReactQueryCode = `// Test API React Query hooks.
+//
+// Code generated by goa, DO NOT EDIT.
+
+import { createContext, useContext } from "react";
+import { useMutation, useQuery, type UseMutationOptions, type UseQueryOptions } from "@tanstack/react-query";
+import { Client, type Account, type CreatePayload, type ShowPayload, type ShowResult, type UsersShowPayload } from "./client";
+
+/** ClientContext provides the client used by the hooks, a client with the default options if not provided. */
+export const ClientContext = createContext<Client>(new Client());
+
+/** useClient returns the client provided by ClientContext. */
+export function useClient(): Client {
+	return useContext(ClientContext);
+}
+
+/** queryKeys builds the keys of the queries made by the hooks, e.g. to invalidate them. */
+export const queryKeys = {
+	accounts: {
+		all: ["accounts"] as const,
+		show: (payload: ShowPayload) => ["accounts", "show", payload] as const,
+		count: (payload: string[]) => ["accounts", "count", payload] as const,
+	},
+	users: {
+		all: ["users"] as const,
+	},
+};
+
+/** Create an account */
+export function useAccountsCreate(options?: Omit<UseMutationOptions<Account, Error, CreatePayload>, "mutationFn">) {
+	const client = useClient();
+	return useMutation({
+		mutationFn: (payload: CreatePayload) => client.accounts.create(payload),
+		...options,
+	});
+}
+
+export function useAccountsShow(payload: ShowPayload, options?: Omit<UseQueryOptions<ShowResult, Error>, "queryKey" | "queryFn">) {
+	const client = useClient();
+	return useQuery({
+		queryKey: queryKeys.accounts.show(payload),
+		queryFn: () => client.accounts.show(payload),
+		...options,
+	});
+}
+
+export function useAccountsCount(payload: string[], options?: Omit<UseQueryOptions<number, Error>, "queryKey" | "queryFn">) {
+	const client = useClient();
+	return useQuery({
+		queryKey: queryKeys.accounts.count(payload),
+		queryFn: () => client.accounts.count(payload),
+		...options,
+	});
+}
+
+export function useAccountsPurge(options?: Omit<UseMutationOptions<void, Error, void>, "mutationFn">) {
+	const client = useClient();
+	return useMutation({
+		mutationFn: () => client.accounts.purge(),
+		...options,
+	});
+}
+
+export function useUsersShow(options?: Omit<UseMutationOptions<void, Error, UsersShowPayload>, "mutationFn">) {
+	const client = useClient();
+	return useMutation({
+		mutationFn: (payload: UsersShowPayload) => client.users.show(payload),
+		...options,
+	});
+}
+`
+
+const ClientAngularCode = `// Test API Angular services.
+//
+// Code generated by goa, DO NOT EDIT.
+
+import { Injectable, InjectionToken, inject, type Provider } from "@angular/core";
+import { defer, type Observable } from "rxjs";
+import { AccountsClient, UsersClient, type ClientOptions, type Account, type CreatePayload, type ShowPayload, type ShowResult, type UsersShowPayload } from "./client";
+
+/** CLIENT_OPTIONS is the injection token of the options of the service clients. */
+export const CLIENT_OPTIONS = new InjectionToken<ClientOptions>("CLIENT_OPTIONS", { factory: () => ({}) });
+
+/** provideClientOptions returns the provider of the options of the service clients. */
+export function provideClientOptions(options: ClientOptions): Provider {
+	return { provide: CLIENT_OPTIONS, useValue: options };
+}
+
+/** Manage accounts */
+@Injectable({ providedIn: "root" })
+export class AccountsService {
+	private readonly client = new AccountsClient(inject(CLIENT_OPTIONS));
+
+	/** Create an account */
+	create(payload: CreatePayload): Observable<Account> {
+		return defer(() => this.client.create(payload));
+	}
+
+	show(payload: ShowPayload): Observable<ShowResult> {
+		return defer(() => this.client.show(payload));
+	}
+
+	count(payload: string[]): Observable<number> {
+		return defer(() => this.client.count(payload));
+	}
+
+	purge(): Observable<void> {
+		return defer(() => this.client.purge());
+	}
+}
+
+@Injectable({ providedIn: "root" })
+export class UsersService {
+	private readonly client = new UsersClient(inject(CLIENT_OPTIONS));
+
+	show(payload: UsersShowPayload): Observable<void> {
+		return defer(() => this.client.show(payload));
+	}
+}
+`
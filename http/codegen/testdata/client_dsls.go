@@ -0,0 +1,203 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var ClientDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var BasicAuth = BasicAuthSecurity("basic")
+	var Account = Type("Account", func() {
+		Description("Account is a customer account.")
+		Attribute("id", String, "Account ID")
+		Attribute("name", String, func() {
+			MinLength(1)
+		})
+		Attribute("status", String, func() {
+			Enum("active", "closed")
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			WireName("labels")
+		})
+		Attribute("owner", func() {
+			Attribute("email", String)
+		})
+		Required("id", "name")
+	})
+	var _ = API("test", func() {
+		Title("Test API")
+		Server("test", func() {
+			Host("dev", func() {
+				URI("https://api.example.com")
+			})
+		})
+	})
+	Service("accounts", func() {
+		Description("Manage accounts")
+		Method("create", func() {
+			Description("Create an account")
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+				Attribute("org", Int)
+				Attribute("name", String, func() {
+					WireName("display_name")
+				})
+				Attribute("dry-run", Boolean)
+				Required("org", "name")
+			})
+			Result(Account)
+			HTTP(func() {
+				POST("/orgs/{org}/accounts")
+				Param("dry-run:dry")
+				Response(StatusCreated)
+			})
+		})
+		Method("show", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+				Attribute("id", String)
+			})
+			Result(func() {
+				Attribute("account", Account)
+				Attribute("etag", String, func() {
+					WireName("eTag")
+				})
+			})
+			Error("not_found", func() {
+				Description("Account not found")
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/accounts/{id}")
+				Response(StatusOK, func() {
+					Body("account")
+					Header("etag:ETag")
+				})
+				Response("not_found", StatusNotFound)
+			})
+		})
+		Method("count", func() {
+			Payload(ArrayOf(String))
+			Result(Int)
+			HTTP(func() {
+				GET("/accounts/count")
+				Param("tags", func() {
+					Meta("http:query:style", "csv")
+				})
+			})
+		})
+		Method("purge", func() {
+			HTTP(func() {
+				DELETE("/accounts")
+				Response(StatusNoContent)
+			})
+		})
+	})
+	Service("users", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("login", String)
+			})
+			HTTP(func() {
+				GET("/users/{login}")
+			})
+		})
+	})
+}
+
+var ClientSharedTypesDSL = func() {
+	var Bottle = ResultType("application/vnd.bottle", func() {
+		TypeName("Bottle")
+		Attributes(func() {
+			Attribute("id", Int)
+			Attribute("name", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	var Cat = Type("Cat", func() {
+		Attribute("lives", Int)
+	})
+	var Dog = Type("Dog", func() {
+		Attribute("barks", Boolean)
+	})
+	Service("bottles", func() {
+		Method("show", func() {
+			Payload(Int)
+			Result(Bottle)
+			HTTP(func() {
+				GET("/bottles/{id}")
+			})
+		})
+	})
+	Service("cellar", func() {
+		Method("store", func() {
+			Payload(func() {
+				Attribute("bottle", Bottle)
+				OneOf("label", func() {
+					Attribute("text", String)
+					Attribute("code", Int)
+				})
+				OneOf("pet", func() {
+					Discriminator("kind")
+					Attribute("cat", Cat)
+					Attribute("dog", Dog)
+				})
+			})
+			Result(Bottle, func() {
+				View("tiny")
+			})
+			HTTP(func() {
+				POST("/cellar")
+			})
+		})
+	})
+}
+
+var ClientSignatureDSL = func() {
+	var Signed = SignatureSecurity("hmac")
+	Service("ServiceSignature", func() {
+		Method("MethodSigned", func() {
+			Security(Signed)
+			HTTP(func() {
+				POST("/signed")
+			})
+		})
+		Method("MethodUnsigned", func() {
+			HTTP(func() {
+				GET("/unsigned")
+			})
+		})
+		Method("MethodAlsoSigned", func() {
+			Security(Signed)
+			HTTP(func() {
+				PUT("/signed")
+			})
+		})
+	})
+}
+
+var ClientMutualTLSDSL = func() {
+	var MTLS = MutualTLSSecurity("mtls")
+	Service("ServiceMutualTLS", func() {
+		Method("MethodMutualTLS", func() {
+			Security(MTLS)
+			HTTP(func() {
+				GET("/secure")
+			})
+		})
+		Method("MethodPublic", func() {
+			HTTP(func() {
+				GET("/public")
+			})
+		})
+	})
+}
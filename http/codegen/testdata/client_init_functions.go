@@ -21,6 +21,51 @@ func NewClient(
 		encoder:                   enc,
 	}
 }
+`
+
+	EnvelopeClientInitCode = `// NewClient instantiates HTTP clients for all the ServiceBodyEnvelope service
+// servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	return &Client{
+		MethodBodyEnvelopeDoer: doer,
+		RestoreResponseBody:    restoreBody,
+		scheme:                 scheme,
+		host:                   host,
+		decoder:                goahttp.EnvelopeDecoder(dec, "data", "errors"),
+		encoder:                enc,
+	}
+}
+`
+
+	ServerHostsClientCode = `// Hosts lists the HTTP hosts of the servers that expose the ServiceHosts
+// service. Use goahttp.HostURL to compute the scheme and host given to
+// NewClient.
+var Hosts = []*goahttp.ServerHost{
+	{
+		Server: "Regional",
+		Name:   "dev",
+		URIs:   []string{"http://localhost:8080"},
+	},
+	{
+		Server: "Regional",
+		Name:   "prod",
+		URIs:   []string{"https://{region}.example.com/{version}", "http://{region}.example.com/{version}"},
+		Variables: map[string]string{
+			"region":  "us",
+			"version": "v1",
+		},
+		Values: map[string][]string{
+			"region": {"us", "eu"},
+		},
+	},
+}
 `
 
 	StreamingClientInitCode = `// NewClient instantiates HTTP clients for all the StreamingResultService
@@ -49,5 +94,25 @@ func NewClient(
 		configurer:                cfn,
 	}
 }
+`
+
+	ClientSignatureCode = `// SignHmacRequests signs the requests made to the endpoints secured by the
+// "hmac" signature scheme with the given key. The signatures are verified by
+// the middleware.VerifySignature HTTP middleware.
+func (c *Client) SignHmacRequests(keyID string, key []byte) {
+	c.MethodSignedDoer = goahttp.NewSigningDoer(c.MethodSignedDoer, keyID, key)
+	c.MethodAlsoSignedDoer = goahttp.NewSigningDoer(c.MethodAlsoSignedDoer, keyID, key)
+}
+`
+
+	ClientMutualTLSCode = `// UseMtlsCertificate configures the client so that the requests made to the
+// endpoints secured by the "mtls" mutual TLS scheme present the given
+// certificate. Server certificates are verified against rootCAs or the system
+// pool if nil. Call it before the methods that wrap the endpoint doers such as
+// the request signing methods.
+func (c *Client) UseMtlsCertificate(cert tls.Certificate, rootCAs *x509.CertPool) {
+	doer := goahttp.NewMutualTLSClient(cert, rootCAs)
+	c.MethodMutualTLSDoer = doer
+}
 `
 )
@@ -97,6 +97,26 @@ var ServiceErrorResponseDSL = func() {
 	})
 }
 
+var EnvelopeErrorResponseDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Envelope(func() {
+				Attribute("data")
+				Attribute("errors")
+			})
+		})
+	})
+	Service("ServiceEnvelopeErrorResponse", func() {
+		Method("MethodEnvelopeErrorResponse", func() {
+			Error("bad_request")
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
+
 var APIErrorResponseDSL = func() {
 	var _ = API("test", func() {
 		Error("bad_request")
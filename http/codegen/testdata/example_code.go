@@ -13,22 +13,17 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		adapter = middleware.NewLogger(logger)
 	}
 
-	// Provide the transport specific request decoder and response encoder.
-	// The goa http package has built-in support for JSON, XML and gob.
-	// Other encodings can be used by providing the corresponding functions,
-	// see goa.design/implement/encoding.
-	var (
-		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
 	)
 
-	// Build the service HTTP request multiplexer and configure it to serve
-	// HTTP requests to the service endpoints.
-	var mux goahttp.Muxer
-	{
-		mux = goahttp.NewMuxer()
-	}
-
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
 	// the service input and output data structures to HTTP requests and
@@ -37,29 +32,27 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		serviceServer *servicesvr.Server
 	)
 	{
-		eh := errorHandler(logger)
-		serviceServer = servicesvr.New(serviceEndpoints, mux, dec, enc, eh, nil)
+		serviceServer = servicesvr.New(serviceEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
 		if debug {
 			servers := goahttp.Servers{
 				serviceServer,
 			}
-			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
 		}
 	}
-	// Configure the mux.
-	servicesvr.Mount(mux, serviceServer)
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceServer)
 
-	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
-	var handler http.Handler = mux
-	{
-		handler = httpmdlwr.Log(adapter)(handler)
-		handler = httpmdlwr.RequestID()(handler)
-	}
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -112,22 +105,17 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 		adapter = middleware.NewLogger(logger)
 	}
 
-	// Provide the transport specific request decoder and response encoder.
-	// The goa http package has built-in support for JSON, XML and gob.
-	// Other encodings can be used by providing the corresponding functions,
-	// see goa.design/implement/encoding.
-	var (
-		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
 	)
 
-	// Build the service HTTP request multiplexer and configure it to serve
-	// HTTP requests to the service endpoints.
-	var mux goahttp.Muxer
-	{
-		mux = goahttp.NewMuxer()
-	}
-
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
 	// the service input and output data structures to HTTP requests and
@@ -136,29 +124,27 @@ func handleHTTPServer(ctx context.Context, u *url.URL, wg *sync.WaitGroup, errc
 		serviceServer *servicesvr.Server
 	)
 	{
-		eh := errorHandler(logger)
-		serviceServer = servicesvr.New(nil, mux, dec, enc, eh, nil, nil)
+		serviceServer = servicesvr.New(nil, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter, nil)
 		if debug {
 			servers := goahttp.Servers{
 				serviceServer,
 			}
-			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
 		}
 	}
-	// Configure the mux.
-	servicesvr.Mount(mux, serviceServer)
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceServer)
 
-	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
-	var handler http.Handler = mux
-	{
-		handler = httpmdlwr.Log(adapter)(handler)
-		handler = httpmdlwr.RequestID()(handler)
-	}
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -211,22 +197,17 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		adapter = middleware.NewLogger(logger)
 	}
 
-	// Provide the transport specific request decoder and response encoder.
-	// The goa http package has built-in support for JSON, XML and gob.
-	// Other encodings can be used by providing the corresponding functions,
-	// see goa.design/implement/encoding.
-	var (
-		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
 	)
 
-	// Build the service HTTP request multiplexer and configure it to serve
-	// HTTP requests to the service endpoints.
-	var mux goahttp.Muxer
-	{
-		mux = goahttp.NewMuxer()
-	}
-
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
 	// the service input and output data structures to HTTP requests and
@@ -235,29 +216,27 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		serviceServer *servicesvr.Server
 	)
 	{
-		eh := errorHandler(logger)
-		serviceServer = servicesvr.New(serviceEndpoints, mux, dec, enc, eh, nil)
+		serviceServer = servicesvr.New(serviceEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
 		if debug {
 			servers := goahttp.Servers{
 				serviceServer,
 			}
-			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
 		}
 	}
-	// Configure the mux.
-	servicesvr.Mount(mux, serviceServer)
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceServer)
 
-	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
-	var handler http.Handler = mux
-	{
-		handler = httpmdlwr.Log(adapter)(handler)
-		handler = httpmdlwr.RequestID()(handler)
-	}
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -310,22 +289,17 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		adapter = middleware.NewLogger(logger)
 	}
 
-	// Provide the transport specific request decoder and response encoder.
-	// The goa http package has built-in support for JSON, XML and gob.
-	// Other encodings can be used by providing the corresponding functions,
-	// see goa.design/implement/encoding.
-	var (
-		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
 	)
 
-	// Build the service HTTP request multiplexer and configure it to serve
-	// HTTP requests to the service endpoints.
-	var mux goahttp.Muxer
-	{
-		mux = goahttp.NewMuxer()
-	}
-
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
 	// the service input and output data structures to HTTP requests and
@@ -335,32 +309,29 @@ func handleHTTPServer(ctx context.Context, u *url.URL, serviceEndpoints *service
 		anotherServiceServer *anotherservicesvr.Server
 	)
 	{
-		eh := errorHandler(logger)
-		serviceServer = servicesvr.New(serviceEndpoints, mux, dec, enc, eh, nil)
-		anotherServiceServer = anotherservicesvr.New(anotherServiceEndpoints, mux, dec, enc, eh, nil)
+		serviceServer = servicesvr.New(serviceEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
+		anotherServiceServer = anotherservicesvr.New(anotherServiceEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
 		if debug {
 			servers := goahttp.Servers{
 				serviceServer,
 				anotherServiceServer,
 			}
-			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
 		}
 	}
-	// Configure the mux.
-	servicesvr.Mount(mux, serviceServer)
-	anotherservicesvr.Mount(mux, anotherServiceServer)
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceServer, anotherServiceServer)
 
-	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
-	var handler http.Handler = mux
-	{
-		handler = httpmdlwr.Log(adapter)(handler)
-		handler = httpmdlwr.RequestID()(handler)
-	}
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
 	for _, m := range serviceServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -416,22 +387,17 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 		adapter = middleware.NewLogger(logger)
 	}
 
-	// Provide the transport specific request decoder and response encoder.
-	// The goa http package has built-in support for JSON, XML and gob.
-	// Other encodings can be used by providing the corresponding functions,
-	// see goa.design/implement/encoding.
-	var (
-		dec = goahttp.RequestDecoder
-		enc = goahttp.ResponseEncoder
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
 	)
 
-	// Build the service HTTP request multiplexer and configure it to serve
-	// HTTP requests to the service endpoints.
-	var mux goahttp.Muxer
-	{
-		mux = goahttp.NewMuxer()
-	}
-
 	// Wrap the endpoints with the transport specific layers. The generated
 	// server packages contains code generated from the design which maps
 	// the service input and output data structures to HTTP requests and
@@ -441,33 +407,30 @@ func handleHTTPServer(ctx context.Context, u *url.URL, streamingServiceAEndpoint
 		streamingServiceBServer *streamingservicebsvr.Server
 	)
 	{
-		eh := errorHandler(logger)
 		upgrader := &websocket.Upgrader{}
-		streamingServiceAServer = streamingserviceasvr.New(streamingServiceAEndpoints, mux, dec, enc, eh, nil, upgrader, nil)
-		streamingServiceBServer = streamingservicebsvr.New(streamingServiceBEndpoints, mux, dec, enc, eh, nil, upgrader, nil)
+		streamingServiceAServer = streamingserviceasvr.New(streamingServiceAEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter, upgrader, nil)
+		streamingServiceBServer = streamingservicebsvr.New(streamingServiceBEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter, upgrader, nil)
 		if debug {
 			servers := goahttp.Servers{
 				streamingServiceAServer,
 				streamingServiceBServer,
 			}
-			servers.Use(httpmdlwr.Debug(mux, os.Stdout))
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
 		}
 	}
-	// Configure the mux.
-	streamingserviceasvr.Mount(mux, streamingServiceAServer)
-	streamingservicebsvr.Mount(mux, streamingServiceBServer)
+	// Mount the servers on the service mux.
+	httpSvc.Mount(streamingServiceAServer, streamingServiceBServer)
 
-	// Wrap the multiplexer with additional middlewares. Middlewares mounted
-	// here apply to all the service endpoints.
-	var handler http.Handler = mux
-	{
-		handler = httpmdlwr.Log(adapter)(handler)
-		handler = httpmdlwr.RequestID()(handler)
-	}
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
 
 	// Start HTTP server using default configuration, change the code to
 	// configure the server as required by your service.
-	srv := &http.Server{Addr: u.Host, Handler: handler}
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
 	for _, m := range streamingServiceAServer.Mounts {
 		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
 	}
@@ -618,5 +581,350 @@ func httpUsageCommands() string {
 func httpUsageExamples() string {
 	return cli.UsageExamples()
 }
+`
+
+	BatchServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceBatchEndpoints *servicebatch.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
+	)
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceBatchServer *servicebatchsvr.Server
+	)
+	{
+		serviceBatchServer = servicebatchsvr.New(serviceBatchEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
+		if debug {
+			servers := goahttp.Servers{
+				serviceBatchServer,
+			}
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
+		}
+	}
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceBatchServer)
+
+	// Mount the batch endpoint, sub-requests are served by the mux.
+	httpSvc.Mux.Handle("POST", "/batch", goahttp.BatchHandler(httpSvc.Mux, 20))
+	logger.Printf("HTTP batch endpoint mounted on POST %s", "/batch")
+
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
+	for _, m := range serviceBatchServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", u.Host)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", u.Host)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			logger.Printf("failed to shutdown: %v", err)
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		_, _ = w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	RadixMuxerServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceRadixEndpoints *serviceradix.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithMuxer(goahttp.NewRadixMuxer()),
+		goahttp.WithErrorHandler(errorHandler(logger)),
+	)
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceRadixServer *serviceradixsvr.Server
+	)
+	{
+		serviceRadixServer = serviceradixsvr.New(serviceRadixEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
+		if debug {
+			servers := goahttp.Servers{
+				serviceRadixServer,
+			}
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
+		}
+	}
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceRadixServer)
+
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
+	for _, m := range serviceRadixServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", u.Host)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", u.Host)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			logger.Printf("failed to shutdown: %v", err)
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		_, _ = w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+`
+
+	FeaturesServerHandleCode = `// handleHTTPServer starts configures and starts a HTTP server on the given
+// URL. It shuts down the server if any error is received in the error channel.
+func handleHTTPServer(ctx context.Context, u *url.URL, serviceFeaturesEndpoints *servicefeatures.Endpoints, wg *sync.WaitGroup, errc chan error, logger *log.Logger, debug bool) {
+
+	// Setup goa log adapter.
+	var (
+		adapter middleware.Logger
+	)
+	{
+		adapter = middleware.NewLogger(logger)
+	}
+
+	// Build the HTTP service which holds the request multiplexer that serves
+	// HTTP requests to the service endpoints together with the request
+	// decoder, response encoder and error handler shared by the service
+	// servers. The goa http package has built-in support for JSON, XML and
+	// gob. Other encodings can be used by providing the corresponding
+	// functions with the WithDecoder and WithEncoder options, see
+	// goa.design/implement/encoding.
+	httpSvc := goahttp.NewService(
+		goahttp.WithErrorHandler(errorHandler(logger)),
+	)
+
+	// Wrap the endpoints with the transport specific layers. The generated
+	// server packages contains code generated from the design which maps
+	// the service input and output data structures to HTTP requests and
+	// responses.
+	var (
+		serviceFeaturesServer *servicefeaturessvr.Server
+	)
+	{
+		serviceFeaturesServer = servicefeaturessvr.New(serviceFeaturesEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
+		if debug {
+			servers := goahttp.Servers{
+				serviceFeaturesServer,
+			}
+			servers.Use(httpmdlwr.Debug(httpSvc.Mux, os.Stdout))
+		}
+	}
+	// Mount the servers on the service mux.
+	httpSvc.Mount(serviceFeaturesServer)
+
+	// Add the middlewares that apply to all the service endpoints. The last
+	// middleware is the first one invoked when handling a request.
+	httpSvc.Use(
+		httpmdlwr.Log(adapter),
+		httpmdlwr.RequestID(),
+	)
+
+	// Configure the optional features compiled in with build tags, see the
+	// http_*.go files.
+	for _, configure := range httpFeatures {
+		configure(httpSvc, logger)
+	}
+
+	// Start HTTP server using default configuration, change the code to
+	// configure the server as required by your service.
+	srv := &http.Server{Addr: u.Host, Handler: httpSvc.Handler()}
+	for _, m := range serviceFeaturesServer.Mounts {
+		logger.Printf("HTTP %q mounted on %s %s", m.Method, m.Verb, m.Pattern)
+	}
+
+	(*wg).Add(1)
+	go func() {
+		defer (*wg).Done()
+
+		// Start HTTP server in a separate goroutine.
+		go func() {
+			logger.Printf("HTTP server listening on %q", u.Host)
+			errc <- srv.ListenAndServe()
+		}()
+
+		<-ctx.Done()
+		logger.Printf("shutting down HTTP server at %q", u.Host)
+
+		// Shutdown gracefully with a 30s timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		err := srv.Shutdown(ctx)
+		if err != nil {
+			logger.Printf("failed to shutdown: %v", err)
+		}
+	}()
+}
+
+// errorHandler returns a function that writes and logs the given error.
+// The function also writes and logs the error unique ID so that it's possible
+// to correlate.
+func errorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter, error) {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		id := ctx.Value(middleware.RequestIDKey).(string)
+		_, _ = w.Write([]byte("[" + id + "] encoding: " + err.Error()))
+		logger.Printf("[%s] ERROR: %s", id, err.Error())
+	}
+}
+
+// httpFeatures lists the functions that configure the optional features of the
+// HTTP server. The files implementing the features register the functions in
+// their init function and are only compiled when the corresponding build tag
+// is set, e.g. "go build -tags metrics".
+var httpFeatures []func(*goahttp.Service, *log.Logger)
+`
+
+	FeaturesTracingCode = `func init() {
+	httpFeatures = append(httpFeatures, httpTracing)
+}
+
+// httpTracing sends the AWS X-Ray segments of the sampled requests to the
+// daemon listening on the address set in the AWS_XRAY_DAEMON_ADDRESS
+// environment variable, 127.0.0.1:2000 by default.
+func httpTracing(svc *goahttp.Service, logger *log.Logger) {
+	daemon := os.Getenv("AWS_XRAY_DAEMON_ADDRESS")
+	if daemon == "" {
+		daemon = "127.0.0.1:2000"
+	}
+	xrayMiddleware, err := xray.New("FeaturesAPI", daemon)
+	if err != nil {
+		logger.Printf("HTTP tracing disabled: %v", err)
+		return
+	}
+	// The trace middleware must run first to initialize the trace IDs.
+	svc.Use(xrayMiddleware, httpmdlwr.Trace())
+	logger.Printf("HTTP tracing sends X-Ray segments to %s", daemon)
+}
+`
+
+	FeaturesMetricsCode = `func init() {
+	httpFeatures = append(httpFeatures, httpMetrics)
+}
+
+// Counters published by the metrics feature indexed by "service.method".
+var (
+	httpRequests      = expvar.NewMap("http_requests")
+	httpResponseBytes = expvar.NewMap("http_response_bytes")
+	httpEncodeNanos   = expvar.NewMap("http_encode_nanoseconds")
+)
+
+// httpMetrics records the number of requests, the number of bytes written and
+// the time spent encoding the responses of each method and serves the counters
+// as JSON on GET /debug/vars.
+func httpMetrics(svc *goahttp.Service, logger *log.Logger) {
+	svc.Use(httpmdlwr.Metrics(func(r *http.Request, s *goahttp.ResponseStats) {
+		key := "unhandled"
+		if s.Method != "" {
+			key = s.Service + "." + s.Method
+		}
+		httpRequests.Add(key, 1)
+		httpResponseBytes.Add(key, int64(s.Bytes))
+		httpEncodeNanos.Add(key, int64(s.EncodeDuration))
+	}))
+	svc.Mux.Handle("GET", "/debug/vars", expvar.Handler().ServeHTTP)
+	logger.Printf("HTTP metrics mounted on GET /debug/vars")
+}
 `
 )
@@ -0,0 +1,31 @@
+package testdata
+
+const PayloadFuzzCode = `// FuzzDecodeMethodFuzzRequest exercises the decoder of the requests sent to
+// the ServiceFuzz MethodFuzz endpoint with arbitrary bodies.
+func FuzzDecodeMethodFuzzRequest(f *testing.F) {
+	f.Add([]byte("{\"count\":3,\"name\":\"joe\"}"))
+	f.Add([]byte(""))
+	f.Add([]byte("{"))
+	f.Add([]byte("null"))
+	f.Add([]byte("[]"))
+	f.Add([]byte("{\"\":"))
+	f.Add([]byte("{\"count\":1e400}"))
+	f.Add([]byte("{\"count\":-99999999999999999999}"))
+	f.Add([]byte("{\"count\":\"\xff\xfe\"}"))
+	f.Add([]byte("{\"count\":[{}]}"))
+	f.Add([]byte("{\"name\":1e400}"))
+	f.Add([]byte("{\"name\":-99999999999999999999}"))
+	f.Add([]byte("{\"name\":\"\xff\xfe\"}"))
+	f.Add([]byte("{\"name\":[{}]}"))
+	mux := goahttp.NewMuxer()
+	decode := DecodeMethodFuzzRequest(mux, goahttp.RequestDecoder)
+	mux.Handle("POST", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		decode(r) // nolint: errcheck
+	})
+	f.Fuzz(func(t *testing.T, body []byte) {
+		req := httptest.NewRequest("POST", "/items/abc", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
+`
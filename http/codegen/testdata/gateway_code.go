@@ -0,0 +1,298 @@
+package testdata
+
+const GatewayKongCode = `# Test API Kong declarative configuration.
+#
+# Apply with:
+#
+#     deck gateway sync kong.yaml
+#
+# JWT and OAuth2 schemes are enforced with the jwt plugin. The plugins of the
+# routes that accept alternative security requirements must be configured
+# with an anonymous consumer.
+_format_version: "3.0"
+services:
+- name: "items"
+  url: "https://api.example.com"
+  routes:
+  - name: "items-create"
+    methods:
+    - POST
+    paths:
+    - "~/items$"
+    strip_path: false
+    plugins:
+    - name: jwt
+    - name: key-auth
+      config:
+        key_names:
+        - "X-API-Key"
+        key_in_header: true
+        key_in_query: false
+    - name: rate-limiting
+      config:
+        second: 10
+        policy: local
+  - name: "items-show-1"
+    methods:
+    - GET
+    paths:
+    - "~/items/[^/]+$"
+    strip_path: false
+    plugins:
+    - name: rate-limiting
+      config:
+        minute: 100
+        policy: local
+  - name: "items-show-2"
+    methods:
+    - GET
+    paths:
+    - "~/item/[^/]+$"
+    strip_path: false
+    plugins:
+    - name: rate-limiting
+      config:
+        minute: 100
+        policy: local
+- name: "files"
+  url: "https://api.example.com"
+  routes:
+  - name: "files-download"
+    methods:
+    - GET
+    paths:
+    - "~/files/.*$"
+    strip_path: false
+`
+
+const GatewayTraefikCode = `# Test API Traefik IngressRoute and middlewares.
+#
+# Apply with:
+#
+#     kubectl apply -f traefik.yaml
+#
+# The routes forward the requests to the Kubernetes services named after the
+# design services. The basicAuth secrets and forwardAuth addresses of the
+# security middlewares must be adapted to the deployment.
+apiVersion: traefik.io/v1alpha1
+kind: IngressRoute
+metadata:
+  name: "test"
+spec:
+  routes:
+  - kind: Rule
+    match: "Method(` + "`" + `POST` + "`" + `) && Path(` + "`" + `/items` + "`" + `)"
+    middlewares:
+    - name: "jwt-auth"
+    - name: "api-key-auth"
+    - name: "items-create-ratelimit"
+    services:
+    - name: "items"
+      port: 443
+  - kind: Rule
+    match: "Method(` + "`" + `GET` + "`" + `) && PathRegexp(` + "`" + `^/items/[^/]+$` + "`" + `)"
+    middlewares:
+    - name: "items-show-1-ratelimit"
+    services:
+    - name: "items"
+      port: 443
+  - kind: Rule
+    match: "Method(` + "`" + `GET` + "`" + `) && PathRegexp(` + "`" + `^/item/[^/]+$` + "`" + `)"
+    middlewares:
+    - name: "items-show-2-ratelimit"
+    services:
+    - name: "items"
+      port: 443
+  - kind: Rule
+    match: "Method(` + "`" + `GET` + "`" + `) && PathRegexp(` + "`" + `^/files/.*$` + "`" + `)"
+    services:
+    - name: "files"
+      port: 443
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: "jwt-auth"
+spec:
+  forwardAuth:
+    address: "http://jwt-auth/verify"
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: "api-key-auth"
+spec:
+  forwardAuth:
+    address: "http://api-key-auth/verify"
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: "items-create-ratelimit"
+spec:
+  rateLimit:
+    average: 10
+    period: 1s
+    burst: 10
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: "items-show-1-ratelimit"
+spec:
+  rateLimit:
+    average: 100
+    period: 60s
+    burst: 100
+---
+apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: "items-show-2-ratelimit"
+spec:
+  rateLimit:
+    average: 100
+    period: 60s
+    burst: 100
+`
+
+const GatewayEnvoyCode = `# Test API Envoy route configuration.
+#
+# The routes forward the requests to the clusters named after the design
+# services:
+#
+#     items: api.example.com:443
+#     files: api.example.com:443
+#
+# JWT and OAuth2 schemes are enforced by the jwt_authn filter using the
+# requirement named after the scheme. The other schemes are listed in the
+# context extensions given to the ext_authz filter.
+name: "test"
+virtual_hosts:
+- name: "test"
+  domains:
+  - "*"
+  routes:
+  - name: "items-create"
+    match:
+      path: "/items"
+      headers:
+      - name: ":method"
+        string_match:
+          exact: POST
+    route:
+      cluster: "items"
+    typed_per_filter_config:
+      envoy.filters.http.jwt_authn:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.jwt_authn.v3.PerRouteConfig
+        requirement_name: "jwt"
+      envoy.filters.http.ext_authz:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthzPerRoute
+        check_settings:
+          context_extensions:
+            security_schemes: "api-key"
+      envoy.filters.http.local_ratelimit:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit
+        stat_prefix: "items-create"
+        token_bucket:
+          max_tokens: 10
+          tokens_per_fill: 10
+          fill_interval: 1s
+        filter_enabled:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+        filter_enforced:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+  - name: "items-show-1"
+    match:
+      safe_regex:
+        regex: "^/items/[^/]+$"
+      headers:
+      - name: ":method"
+        string_match:
+          exact: GET
+    route:
+      cluster: "items"
+    typed_per_filter_config:
+      envoy.filters.http.local_ratelimit:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit
+        stat_prefix: "items-show-1"
+        token_bucket:
+          max_tokens: 100
+          tokens_per_fill: 100
+          fill_interval: 60s
+        filter_enabled:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+        filter_enforced:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+  - name: "items-show-2"
+    match:
+      safe_regex:
+        regex: "^/item/[^/]+$"
+      headers:
+      - name: ":method"
+        string_match:
+          exact: GET
+    route:
+      cluster: "items"
+    typed_per_filter_config:
+      envoy.filters.http.local_ratelimit:
+        "@type": type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit
+        stat_prefix: "items-show-2"
+        token_bucket:
+          max_tokens: 100
+          tokens_per_fill: 100
+          fill_interval: 60s
+        filter_enabled:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+        filter_enforced:
+          default_value:
+            numerator: 100
+            denominator: HUNDRED
+  - name: "files-download"
+    match:
+      safe_regex:
+        regex: "^/files/.*$"
+      headers:
+      - name: ":method"
+        string_match:
+          exact: GET
+    route:
+      cluster: "files"
+`
+
+const GatewayEnvoyOnlyCode = `# test Envoy route configuration.
+#
+# The routes forward the requests to the clusters named after the design
+# services:
+#
+#     items: localhost:80
+#
+# JWT and OAuth2 schemes are enforced by the jwt_authn filter using the
+# requirement named after the scheme. The other schemes are listed in the
+# context extensions given to the ext_authz filter.
+name: "test"
+virtual_hosts:
+- name: "test"
+  domains:
+  - "*"
+  routes:
+  - name: "items-list"
+    match:
+      path: "/items"
+      headers:
+      - name: ":method"
+        string_match:
+          exact: GET
+    route:
+      cluster: "items"
+`
@@ -241,3 +241,301 @@ func NewMethodPayloadResultErrorHandler(
 	})
 }
 `
+
+var ServerLongRunningHandlerConstructorCode = `// NewMethodLongRunningHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceLongRunning" service "MethodLongRunning"
+// endpoint.
+func NewMethodLongRunningHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodLongRunningRequest(mux, decoder)
+		encodeResponse = EncodeMethodLongRunningResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodLongRunning")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceLongRunning")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		w.Header().Set("Location", goahttp.OperationPath(r, res.(*servicelongrunning.Operation).ID))
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerAcceptRangesHandlerConstructorCode = `// NewMethodAcceptRangesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceAcceptRanges" service "MethodAcceptRanges"
+// endpoint.
+func NewMethodAcceptRangesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodAcceptRangesRequest(mux, decoder)
+		encodeResponse = EncodeMethodAcceptRangesResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodAcceptRanges")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceAcceptRanges")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		o := res.(*serviceacceptranges.MethodAcceptRangesResponseData)
+		defer o.Body.Close()
+		rw := goahttp.NewRangeWriter(w, r, o.Body)
+		w = rw
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+			return
+		}
+		if _, err := io.Copy(w, rw.Body()); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+`
+
+var ServerDownloadHandlerConstructorCode = `// NewMethodDownloadHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceDownload" service "MethodDownload" endpoint.
+func NewMethodDownloadHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodDownloadRequest(mux, decoder)
+		encodeResponse = EncodeMethodDownloadResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodDownload")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceDownload")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		o := res.(*servicedownload.MethodDownloadResponseData)
+		defer o.Body.Close()
+		goahttp.SetDownloadHeaders(w, o.Body, "application/pdf", "inline")
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+			return
+		}
+		if _, err := io.Copy(w, o.Body); err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+`
+
+var ServerPushHandlerConstructorCode = `// NewMethodPushHandler creates a HTTP handler which loads the HTTP request and
+// calls the "ServicePush" service "MethodPush" endpoint.
+func NewMethodPushHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodPushResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPush")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePush")
+		var err error
+		res, err := endpoint(ctx, nil)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		goahttp.Push(w, r, "/assets/app.js", "/assets/app.css")
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerTimeoutHandlerConstructorCode = `// NewMethodTimeoutHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceTimeout" service "MethodTimeout" endpoint.
+func NewMethodTimeoutHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodTimeoutRequest(mux, decoder)
+		encodeResponse = EncodeMethodTimeoutResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodTimeout")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTimeout")
+		ctx, cancel := goahttp.WithRequestTimeout(ctx, r, 1500*time.Millisecond)
+		defer cancel()
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err == nil {
+			err = goahttp.DeadlineError(ctx)
+		}
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerNullableHandlerConstructorCode = `// NewMethodNullableHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceNullable" service "MethodNullable" endpoint.
+func NewMethodNullableHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodNullableRequest(mux, decoder)
+		encodeResponse = EncodeMethodNullableResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNullable")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNullable")
+		ctx = goa.WithSetFields(ctx)
+		r = r.WithContext(ctx)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var ServerProxyHandlerConstructorCode = `// NewMethodProxyHandler creates a HTTP handler which forwards the requests
+// made to the "ServiceProxy" service "MethodProxy" endpoint to
+// http://legacy:8080/api.
+func NewMethodProxyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	return goahttp.ReverseProxy("http://legacy:8080/api",
+		goahttp.ProxyTimeout(10*time.Second),
+		goahttp.ProxyRequestHeader("X-Legacy-Client", "goa"),
+		goahttp.ProxyRequestHeader("Cookie", ""),
+		goahttp.ProxyResponseHeader("Server", ""),
+	)
+}
+`
+
+var ServerProxyNoOptionHandlerConstructorCode = `// NewMethodProxyNoOptionHandler creates a HTTP handler which forwards the
+// requests made to the "ServiceProxyNoOption" service "MethodProxyNoOption"
+// endpoint to http://legacy:8080.
+func NewMethodProxyNoOptionHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	return goahttp.ReverseProxy("http://legacy:8080")
+}
+`
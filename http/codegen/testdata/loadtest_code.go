@@ -0,0 +1,122 @@
+package testdata
+
+const LoadTestK6Code = `// Test API load test scenario.
+//
+// Run with:
+//
+//     k6 run -e BASE_URL=https://api.example.com -e TOKEN=... k6.js
+//
+// Each iteration sends one of the requests below picked randomly according to
+// its weight. The ${NAME} placeholders are replaced with the values of the
+// corresponding environment variables.
+import http from 'k6/http';
+import { check } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || "https://api.example.com";
+
+const requests = [
+	{
+		name: "items.create",
+		weight: 1,
+		method: "POST",
+		path: "/items?dry=true",
+		headers: {
+			"Authorization": "Bearer ${TOKEN}",
+			"Content-Type": "application/json",
+		},
+		body: "{\"name\":\"item 1\"}",
+	},
+	{
+		name: "items.list",
+		weight: 3,
+		method: "GET",
+		path: "/items",
+		headers: {},
+	},
+];
+
+const totalWeight = requests.reduce((sum, req) => sum + req.weight, 0);
+
+export const options = {
+	vus: 10,
+	duration: '30s',
+};
+
+function env(s) {
+	return s.replace(/\$\{(\w+)\}/g, (_, name) => __ENV[name] || '');
+}
+
+export default function () {
+	let n = Math.random() * totalWeight;
+	const req = requests.find((r) => (n -= r.weight) < 0) || requests[requests.length - 1];
+	const headers = {};
+	for (const name in req.headers) {
+		headers[name] = env(req.headers[name]);
+	}
+	const res = http.request(req.method, BASE_URL + env(req.path), req.body ? env(req.body) : null, {
+		headers: headers,
+		tags: { name: req.name },
+	});
+	check(res, {
+		'no server error': (r) => r.status < 500,
+	});
+}
+`
+
+const LoadTestVegetaCode = `{"method":"POST","url":"https://api.example.com/items?dry=true","header":{"Authorization":["Bearer ${TOKEN}"],"Content-Type":["application/json"]},"body":"eyJuYW1lIjoiaXRlbSAxIn0="}
+{"method":"GET","url":"https://api.example.com/items"}
+{"method":"GET","url":"https://api.example.com/items"}
+{"method":"GET","url":"https://api.example.com/items"}
+`
+
+const LoadTestK6OnlyCode = `// test load test scenario.
+//
+// Run with:
+//
+//     k6 run -e BASE_URL=http://localhost:80 -e TOKEN=... k6.js
+//
+// Each iteration sends one of the requests below picked randomly according to
+// its weight. The ${NAME} placeholders are replaced with the values of the
+// corresponding environment variables.
+import http from 'k6/http';
+import { check } from 'k6';
+
+const BASE_URL = __ENV.BASE_URL || "http://localhost:80";
+
+const requests = [
+	{
+		name: "items.list",
+		weight: 1,
+		method: "GET",
+		path: "/items",
+		headers: {},
+	},
+];
+
+const totalWeight = requests.reduce((sum, req) => sum + req.weight, 0);
+
+export const options = {
+	vus: 10,
+	duration: '30s',
+};
+
+function env(s) {
+	return s.replace(/\$\{(\w+)\}/g, (_, name) => __ENV[name] || '');
+}
+
+export default function () {
+	let n = Math.random() * totalWeight;
+	const req = requests.find((r) => (n -= r.weight) < 0) || requests[requests.length - 1];
+	const headers = {};
+	for (const name in req.headers) {
+		headers[name] = env(req.headers[name]);
+	}
+	const res = http.request(req.method, BASE_URL + env(req.path), req.body ? env(req.body) : null, {
+		headers: headers,
+		tags: { name: req.name },
+	});
+	check(res, {
+		'no server error': (r) => r.status < 500,
+	});
+}
+`
@@ -146,9 +146,11 @@ func NewServiceMultipartWithParamMethodMultipartWithParamDecoder(mux goahttp.Mux
 			var (
 				c2  map[int][]string
 				err error
+
+				qp = r.URL.Query()
 			)
 			{
-				c2Raw := r.URL.Query()
+				c2Raw := qp
 				if len(c2Raw) == 0 {
 					err = goa.MergeErrors(err, goa.MissingFieldError("c", "query string"))
 				}
@@ -204,11 +206,12 @@ func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersD
 				err error
 
 				params = mux.Vars(r)
+				qp     = r.URL.Query()
 			)
 			a = params["a"]
 			err = goa.MergeErrors(err, goa.ValidatePattern("a", a, "patterna"))
 			{
-				c2Raw := r.URL.Query()
+				c2Raw := qp
 				if len(c2Raw) == 0 {
 					err = goa.MergeErrors(err, goa.MissingFieldError("c", "query string"))
 				}
@@ -237,7 +240,7 @@ func NewServiceMultipartWithParamsAndHeadersMethodMultipartWithParamsAndHeadersD
 				b = &bRaw
 			}
 			if b != nil {
-				err = goa.MergeErrors(err, goa.ValidatePattern("b", *b, "patternb"))
+				err = goa.MergeErrors(err, goa.ValidatePattern("Authorization", *b, "patternb"))
 			}
 			if err != nil {
 				return err
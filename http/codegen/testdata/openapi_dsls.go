@@ -109,6 +109,270 @@ var MultipleViewsDSL = func() {
 	})
 }
 
+var ResponseExampleDSL = func() {
+	var ResultT = ResultType("application/json", func() {
+		TypeName("Result")
+		Attributes(func() {
+			Attribute("string", String)
+			Attribute("int", Int)
+		})
+		View("default", func() {
+			Attribute("string")
+			Attribute("int")
+		})
+		View("tiny", func() {
+			Attribute("string")
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(ResultT)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Example("tiny", Val{"string": "a"})
+					Example("full", Val{"string": "a", "int": 1})
+				})
+			})
+		})
+	})
+}
+
+var RequestExampleDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("version", String)
+				Attribute("rating", Int)
+				Attribute("comment", String)
+			})
+			Example("Five stars", Val{"id": 42, "version": "v1", "rating": 5, "comment": "Great"})
+			Example("One star", Val{"id": 7, "version": "v2", "rating": 1})
+			HTTP(func() {
+				PUT("/{id}")
+				Header("version:X-Version")
+			})
+		})
+	})
+}
+
+var QueryStyleDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("filter", MapOf(String, String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Meta("http:query:style", "csv")
+				})
+				Param("filter", func() {
+					Meta("http:query:style", "deepObject")
+				})
+			})
+		})
+	})
+}
+
+var QueryObjectDSL = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("name", String)
+		Attribute("tags", ArrayOf(String))
+		Required("name")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+				Required("filter")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter")
+			})
+		})
+	})
+}
+
+var ConsumesDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Consumes("application/json")
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("testEndpointXML", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/xml")
+				Consumes("application/json", "application/xml")
+			})
+		})
+	})
+}
+
+var NamedInlineObjectDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("dimensions", func() {
+					Meta("struct:type:name", "Dimensions")
+					Attribute("w", Int)
+					Attribute("h", Int, func() {
+						Minimum(0)
+					})
+					Required("w")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var UniqueArrayValidationDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int), func() {
+					UniqueItems()
+					MaxItems(10)
+				})
+				Attribute("tags", ArrayOf(String, func() {
+					MinLength(2)
+				}), func() {
+					MinItems(1)
+					UniqueItems()
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Param("ids")
+			})
+		})
+	})
+}
+
+var TimeTypesDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("id", UUID)
+				Attribute("since", DateTime)
+				Attribute("day", Date)
+				Attribute("timeout", Duration)
+				Attribute("created_at", DateTime)
+			})
+			Result(func() {
+				Attribute("id", UUID)
+				Attribute("created_at", DateTime)
+			})
+			HTTP(func() {
+				POST("/{id}")
+				Param("since")
+				Param("day")
+				Header("timeout")
+			})
+		})
+	})
+}
+
+var DecimalDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("amount", Decimal)
+				Attribute("rates", ArrayOf(Decimal))
+			})
+			Result(Decimal)
+			HTTP(func() {
+				POST("/")
+				Param("rates")
+			})
+		})
+	})
+}
+
+var IntEnumDSL = func() {
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(func() {
+				Attribute("priority", Int, func() {
+					Enum(1, 2, 4)
+					EnumNames("Low", "Medium", "High")
+					Meta("enum:type", "Priority")
+				})
+				Attribute("level", Int, func() {
+					Enum(0, 1)
+					EnumNames("Off", "On")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Param("level")
+			})
+		})
+	})
+}
+
+var FieldGroupsDSL = func() {
+	var Location = Type("Location", func() {
+		Attribute("lat", Float64)
+		Attribute("lng", Float64)
+		Attribute("address", String)
+		RequiredTogether("lat", "lng")
+		Mutex("lat", "address")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Payload(Location)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var EnvelopeDSL = func() {
+	var Page = Type("Page", func() {
+		Attribute("total", Int)
+	})
+	var Item = Type("Item", func() {
+		Attribute("name", String, func() {
+			Example("n")
+		})
+	})
+	API("test", func() {
+		HTTP(func() {
+			Envelope(func() {
+				Attribute("data")
+				Attribute("meta", Page)
+				Attribute("errors")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			Result(Item)
+			Error("not_found")
+			HTTP(func() {
+				GET("/")
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
+
 var ExplicitViewDSL = func() {
 	var ResultT = ResultType("application/json", func() {
 		TypeName("Result")
@@ -446,6 +710,35 @@ var ServerHostWithVariablesDSL = func() {
 	})
 }
 
+var ServerMultipleHostsDSL = func() {
+	var _ = API("test", func() {
+		Server("test", func() {
+			Description("Regional servers")
+			Host("regional", func() {
+				Description("Regional host")
+				URI("https://{region}.goa.design/{version}")
+				URI("http://{region}.goa.design/{version}")
+				Variable("region", String, "Region", func() {
+					Enum("us", "eu")
+				})
+				Variable("version", String, "API Version", func() {
+					Default("v1")
+				})
+			})
+			Host("localhost", func() {
+				URI("http://localhost:8080")
+			})
+		})
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
 var WithSpacesDSL = func() {
 	var Bar = Type("bar", func() {
 		Attribute("string", String, func() {
@@ -630,3 +923,218 @@ var WithTagsSwaggerDSL = func() {
 		})
 	})
 }
+
+var DocsiteDSL = func() {
+	var _ = API("test", func() {
+		Description("Descriptions may contain </script> & other markup.")
+		Meta("openapi:docs")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var DocsiteSwaggerUIDSL = func() {
+	var _ = API("test", func() {
+		Meta("openapi:docs", "swagger-ui")
+	})
+	Service("testService", func() {
+		Method("testEndpoint", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var SnippetsDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var _ = API("test", func() {
+		Title("Test API")
+		Meta("http:snippets")
+		Server("test", func() {
+			Host("dev", func() {
+				URI("https://api.example.com")
+			})
+		})
+	})
+	Service("items", func() {
+		Description("Manage items.")
+		Method("update", func() {
+			Description("Update an item.")
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String, func() {
+					Example("item 1")
+				})
+				Attribute("dry", Boolean, func() {
+					Example(true)
+				})
+				Attribute("tags", ArrayOf(String), func() {
+					Example([]string{"a", "b"})
+				})
+				Attribute("trace", String, func() {
+					Example("$trace")
+				})
+				Attribute("name", String, func() {
+					Example("it's \"new\"")
+				})
+			})
+			HTTP(func() {
+				PUT("/items/{id}")
+				Param("dry")
+				Param("tags")
+				Header("trace:X-Trace")
+			})
+		})
+		Method("list", func() {
+			NoSecurity()
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
+
+var SnippetsHTTPieDSL = func() {
+	var BasicAuth = BasicAuthSecurity("basic")
+	var _ = API("test", func() {
+		Meta("http:snippets", "httpie")
+	})
+	Service("items", func() {
+		Method("list", func() {
+			Security(BasicAuth)
+			Payload(func() {
+				Username("user", String)
+				Password("pass", String)
+			})
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
+
+var LoadTestDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var _ = API("test", func() {
+		Title("Test API")
+		Meta("loadtest")
+		Server("test", func() {
+			Host("dev", func() {
+				URI("https://api.example.com")
+			})
+		})
+	})
+	Service("items", func() {
+		Method("create", func() {
+			Security(JWTAuth)
+			Payload(func() {
+				Token("token", String)
+				Attribute("name", String, func() {
+					Example("item 1")
+				})
+				Attribute("dry", Boolean, func() {
+					Example(true)
+				})
+			})
+			HTTP(func() {
+				POST("/items")
+				Param("dry")
+			})
+		})
+		Method("list", func() {
+			Meta("loadtest:weight", "3")
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+		Method("purge", func() {
+			Meta("loadtest:weight", "0")
+			HTTP(func() {
+				DELETE("/items")
+			})
+		})
+	})
+}
+
+var GatewayDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var KeyAuth = APIKeySecurity("api_key")
+	var _ = API("test", func() {
+		Title("Test API")
+		Meta("gateway")
+		Server("test", func() {
+			Host("dev", func() {
+				URI("https://api.example.com")
+			})
+		})
+	})
+	Service("items", func() {
+		Meta("gateway:ratelimit", "10/second")
+		Method("create", func() {
+			Security(JWTAuth)
+			Security(KeyAuth)
+			Payload(func() {
+				Token("token", String)
+				APIKey("api_key", "key", String)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/items")
+				Header("key:X-API-Key")
+			})
+		})
+		Method("show", func() {
+			Meta("gateway:ratelimit", "100/minute")
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/items/{id}")
+				Alias("/item/{id}")
+			})
+		})
+	})
+	Service("files", func() {
+		Method("download", func() {
+			Payload(func() {
+				Attribute("path", String)
+			})
+			HTTP(func() {
+				GET("/files/{*path}")
+			})
+		})
+	})
+}
+
+var GatewayEnvoyDSL = func() {
+	var _ = API("test", func() {
+		Meta("gateway", "envoy")
+	})
+	Service("items", func() {
+		Method("list", func() {
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
+
+var LoadTestK6DSL = func() {
+	var _ = API("test", func() {
+		Meta("loadtest", "k6")
+	})
+	Service("items", func() {
+		Method("list", func() {
+			HTTP(func() {
+				GET("/items")
+			})
+		})
+	})
+}
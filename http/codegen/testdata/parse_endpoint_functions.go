@@ -1374,3 +1374,58 @@ func BuildMethodAPayload(serviceWithParamsAndHeadersBlockMethodABody string, ser
 	return v, nil
 }
 `
+
+var QueryObjectBuildCode = `// BuildMethodQueryObjectPayload builds the payload for the ServiceQueryObject
+// MethodQueryObject endpoint from CLI flags.
+func BuildMethodQueryObjectPayload(serviceQueryObjectMethodQueryObjectFilter string, serviceQueryObjectMethodQueryObjectPage string) (*servicequeryobject.MethodQueryObjectPayload, error) {
+	var err error
+	var filter *servicequeryobject.Filter
+	{
+		var val struct {
+			Name   string   ` + "`" + `json:"name"` + "`" + `
+			MinAge *int     ` + "`" + `json:"min_age"` + "`" + `
+			Tags   []string ` + "`" + `json:"tags"` + "`" + `
+		}
+		err = json.Unmarshal([]byte(serviceQueryObjectMethodQueryObjectFilter), &val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON for filter, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"min_age\": 7595816812588075382,\n      \"name\": \"Quia molestias.\",\n      \"tags\": [\n         \"Quia inventore et.\",\n         \"Et quae sunt itaque.\",\n         \"Optio quia ullam aut.\",\n         \"Iste perspiciatis.\"\n      ]\n   }'")
+		}
+		filter = (*servicequeryobject.Filter)(&val)
+		if filter != nil {
+			if filter.MinAge != nil {
+				if *filter.MinAge < 0 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("filter.min_age", *filter.MinAge, 0, true))
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	var page *struct {
+		Size   *int
+		Cursor *string
+	}
+	{
+		if serviceQueryObjectMethodQueryObjectPage != "" {
+			var val struct {
+				Size   *int    ` + "`" + `json:"size"` + "`" + `
+				Cursor *string ` + "`" + `json:"cursor"` + "`" + `
+			}
+			err = json.Unmarshal([]byte(serviceQueryObjectMethodQueryObjectPage), &val)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON for page, \nerror: %s, \nexample of valid JSON:\n%s", err, "'{\n      \"cursor\": \"Nisi sint sunt beatae quia.\",\n      \"size\": 6368902900236985595\n   }'")
+			}
+			page = (*struct {
+				Size   *int
+				Cursor *string
+			})(&val)
+		}
+	}
+	v := &servicequeryobject.MethodQueryObjectPayload{}
+	v.Filter = filter
+	v.Page = page
+
+	return v, nil
+}
+`
@@ -174,3 +174,30 @@ func MethodPathInterfaceSliceParamServicePathInterfaceSliceParamPath(a []interfa
 	return fmt.Sprintf("/one/%v/two", strings.Join(aSlice, ","))
 }
 `
+
+var PathURLNoParamCode = `// MethodPathNoParamServicePathNoParamURL returns the URL to the ServicePathNoParam service MethodPathNoParam HTTP endpoint
+// built by appending the endpoint path to the path of base.
+func MethodPathNoParamServicePathNoParamURL(base *url.URL) *url.URL {
+	return goahttp.JoinURLPath(base, MethodPathNoParamServicePathNoParamPath())
+}
+`
+
+var PathURLMultipleParamsCode = `// MethodPathMultipleParamServicePathMultipleParamURL returns the URL to the ServicePathMultipleParam service MethodPathMultipleParam HTTP endpoint
+// built by appending the endpoint path to the path of base.
+func MethodPathMultipleParamServicePathMultipleParamURL(base *url.URL, a string, b string) *url.URL {
+	return goahttp.JoinURLPath(base, MethodPathMultipleParamServicePathMultipleParamPath(a, b))
+}
+`
+
+var PathURLAlternativesCode = `// MethodPathAlternativesServicePathAlternativesURL returns the URL to the ServicePathAlternatives service MethodPathAlternatives HTTP endpoint
+// built by appending the endpoint path to the path of base.
+func MethodPathAlternativesServicePathAlternativesURL(base *url.URL, a string, b string) *url.URL {
+	return goahttp.JoinURLPath(base, MethodPathAlternativesServicePathAlternativesPath(a, b))
+}
+
+// MethodPathAlternativesServicePathAlternativesURL2 returns the URL to the ServicePathAlternatives service MethodPathAlternatives HTTP endpoint
+// built by appending the endpoint path to the path of base.
+func MethodPathAlternativesServicePathAlternativesURL2(base *url.URL, b string, a string) *url.URL {
+	return goahttp.JoinURLPath(base, MethodPathAlternativesServicePathAlternativesPath2(b, a))
+}
+`
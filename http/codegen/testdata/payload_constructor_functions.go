@@ -732,6 +732,17 @@ func NewMethodHeaderStringValidatePayload(h *string) *serviceheaderstringvalidat
 }
 `
 
+var PayloadHeaderStringValidateInHeaderConstructorCode = `// NewMethodHeaderStringValidateInHeaderPayload builds a
+// ServiceHeaderStringValidateInHeader service
+// MethodHeaderStringValidateInHeader endpoint payload.
+func NewMethodHeaderStringValidateInHeaderPayload(version string) *serviceheaderstringvalidateinheader.MethodHeaderStringValidateInHeaderPayload {
+	v := &serviceheaderstringvalidateinheader.MethodHeaderStringValidateInHeaderPayload{}
+	v.Version = &version
+
+	return v
+}
+`
+
 var PayloadHeaderArrayStringConstructorCode = `// NewMethodHeaderArrayStringPayload builds a ServiceHeaderArrayString service
 // MethodHeaderArrayString endpoint payload.
 func NewMethodHeaderArrayStringPayload(h []string) *serviceheaderarraystring.MethodHeaderArrayStringPayload {
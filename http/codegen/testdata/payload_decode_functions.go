@@ -7,9 +7,11 @@ func DecodeMethodQueryBoolRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		var (
 			q   *bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseBool(qRaw)
 				if err2 != nil {
@@ -35,9 +37,11 @@ func DecodeMethodQueryBoolValidateRequest(mux goahttp.Muxer, decoder func(*http.
 		var (
 			q   bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -67,9 +71,11 @@ func DecodeMethodQueryIntRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		var (
 			q   *int
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseInt(qRaw, 10, strconv.IntSize)
 				if err2 != nil {
@@ -96,9 +102,11 @@ func DecodeMethodQueryIntValidateRequest(mux goahttp.Muxer, decoder func(*http.R
 		var (
 			q   int
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -128,9 +136,11 @@ func DecodeMethodQueryInt32Request(mux goahttp.Muxer, decoder func(*http.Request
 		var (
 			q   *int32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseInt(qRaw, 10, 32)
 				if err2 != nil {
@@ -157,9 +167,11 @@ func DecodeMethodQueryInt32ValidateRequest(mux goahttp.Muxer, decoder func(*http
 		var (
 			q   int32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -189,9 +201,11 @@ func DecodeMethodQueryInt64Request(mux goahttp.Muxer, decoder func(*http.Request
 		var (
 			q   *int64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseInt(qRaw, 10, 64)
 				if err2 != nil {
@@ -217,9 +231,11 @@ func DecodeMethodQueryInt64ValidateRequest(mux goahttp.Muxer, decoder func(*http
 		var (
 			q   int64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -249,9 +265,11 @@ func DecodeMethodQueryUIntRequest(mux goahttp.Muxer, decoder func(*http.Request)
 		var (
 			q   *uint
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseUint(qRaw, 10, strconv.IntSize)
 				if err2 != nil {
@@ -278,9 +296,11 @@ func DecodeMethodQueryUIntValidateRequest(mux goahttp.Muxer, decoder func(*http.
 		var (
 			q   uint
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -310,9 +330,11 @@ func DecodeMethodQueryUInt32Request(mux goahttp.Muxer, decoder func(*http.Reques
 		var (
 			q   *uint32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseUint(qRaw, 10, 32)
 				if err2 != nil {
@@ -339,9 +361,11 @@ func DecodeMethodQueryUInt32ValidateRequest(mux goahttp.Muxer, decoder func(*htt
 		var (
 			q   uint32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -371,9 +395,11 @@ func DecodeMethodQueryUInt64Request(mux goahttp.Muxer, decoder func(*http.Reques
 		var (
 			q   *uint64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseUint(qRaw, 10, 64)
 				if err2 != nil {
@@ -399,9 +425,11 @@ func DecodeMethodQueryUInt64ValidateRequest(mux goahttp.Muxer, decoder func(*htt
 		var (
 			q   uint64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -431,9 +459,11 @@ func DecodeMethodQueryFloat32Request(mux goahttp.Muxer, decoder func(*http.Reque
 		var (
 			q   *float32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseFloat(qRaw, 32)
 				if err2 != nil {
@@ -460,9 +490,11 @@ func DecodeMethodQueryFloat32ValidateRequest(mux goahttp.Muxer, decoder func(*ht
 		var (
 			q   float32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -492,9 +524,11 @@ func DecodeMethodQueryFloat64Request(mux goahttp.Muxer, decoder func(*http.Reque
 		var (
 			q   *float64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				v, err2 := strconv.ParseFloat(qRaw, 64)
 				if err2 != nil {
@@ -520,9 +554,11 @@ func DecodeMethodQueryFloat64ValidateRequest(mux goahttp.Muxer, decoder func(*ht
 		var (
 			q   float64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -551,8 +587,10 @@ func DecodeMethodQueryStringRequest(mux goahttp.Muxer, decoder func(*http.Reques
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q *string
+
+			qp = r.URL.Query()
 		)
-		qRaw := r.URL.Query().Get("q")
+		qRaw := qp.Get("q")
 		if qRaw != "" {
 			q = &qRaw
 		}
@@ -570,8 +608,10 @@ func DecodeMethodQueryStringValidateRequest(mux goahttp.Muxer, decoder func(*htt
 		var (
 			q   string
 			err error
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query().Get("q")
+		q = qp.Get("q")
 		if q == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 		}
@@ -596,8 +636,10 @@ func DecodeMethodQueryStringNotRequiredValidateRequest(mux goahttp.Muxer, decode
 		var (
 			q   *string
 			err error
+
+			qp = r.URL.Query()
 		)
-		qRaw := r.URL.Query().Get("q")
+		qRaw := qp.Get("q")
 		if qRaw != "" {
 			q = &qRaw
 		}
@@ -622,9 +664,11 @@ func DecodeMethodQueryBytesRequest(mux goahttp.Muxer, decoder func(*http.Request
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q []byte
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw != "" {
 				q = []byte(qRaw)
 			}
@@ -643,9 +687,11 @@ func DecodeMethodQueryBytesValidateRequest(mux goahttp.Muxer, decoder func(*http
 		var (
 			q   []byte
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -670,8 +716,10 @@ func DecodeMethodQueryAnyRequest(mux goahttp.Muxer, decoder func(*http.Request)
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q interface{}
+
+			qp = r.URL.Query()
 		)
-		qRaw := r.URL.Query().Get("q")
+		qRaw := qp.Get("q")
 		if qRaw != "" {
 			q = qRaw
 		}
@@ -689,8 +737,10 @@ func DecodeMethodQueryAnyValidateRequest(mux goahttp.Muxer, decoder func(*http.R
 		var (
 			q   interface{}
 			err error
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query().Get("q")
+		q = qp.Get("q")
 		if q == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 		}
@@ -714,9 +764,11 @@ func DecodeMethodQueryArrayBoolRequest(mux goahttp.Muxer, decoder func(*http.Req
 		var (
 			q   []bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]bool, len(qRaw))
 				for i, rv := range qRaw {
@@ -746,9 +798,11 @@ func DecodeMethodQueryArrayBoolValidateRequest(mux goahttp.Muxer, decoder func(*
 		var (
 			q   []bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -786,9 +840,11 @@ func DecodeMethodQueryArrayIntRequest(mux goahttp.Muxer, decoder func(*http.Requ
 		var (
 			q   []int
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]int, len(qRaw))
 				for i, rv := range qRaw {
@@ -817,9 +873,11 @@ func DecodeMethodQueryArrayIntValidateRequest(mux goahttp.Muxer, decoder func(*h
 		var (
 			q   []int
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -857,9 +915,11 @@ func DecodeMethodQueryArrayInt32Request(mux goahttp.Muxer, decoder func(*http.Re
 		var (
 			q   []int32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]int32, len(qRaw))
 				for i, rv := range qRaw {
@@ -889,9 +949,11 @@ func DecodeMethodQueryArrayInt32ValidateRequest(mux goahttp.Muxer, decoder func(
 		var (
 			q   []int32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -929,9 +991,11 @@ func DecodeMethodQueryArrayInt64Request(mux goahttp.Muxer, decoder func(*http.Re
 		var (
 			q   []int64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]int64, len(qRaw))
 				for i, rv := range qRaw {
@@ -961,9 +1025,11 @@ func DecodeMethodQueryArrayInt64ValidateRequest(mux goahttp.Muxer, decoder func(
 		var (
 			q   []int64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1001,9 +1067,11 @@ func DecodeMethodQueryArrayUIntRequest(mux goahttp.Muxer, decoder func(*http.Req
 		var (
 			q   []uint
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]uint, len(qRaw))
 				for i, rv := range qRaw {
@@ -1033,9 +1101,11 @@ func DecodeMethodQueryArrayUIntValidateRequest(mux goahttp.Muxer, decoder func(*
 		var (
 			q   []uint
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1073,9 +1143,11 @@ func DecodeMethodQueryArrayUInt32Request(mux goahttp.Muxer, decoder func(*http.R
 		var (
 			q   []uint32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]uint32, len(qRaw))
 				for i, rv := range qRaw {
@@ -1105,9 +1177,11 @@ func DecodeMethodQueryArrayUInt32ValidateRequest(mux goahttp.Muxer, decoder func
 		var (
 			q   []uint32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1145,9 +1219,11 @@ func DecodeMethodQueryArrayUInt64Request(mux goahttp.Muxer, decoder func(*http.R
 		var (
 			q   []uint64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]uint64, len(qRaw))
 				for i, rv := range qRaw {
@@ -1177,9 +1253,11 @@ func DecodeMethodQueryArrayUInt64ValidateRequest(mux goahttp.Muxer, decoder func
 		var (
 			q   []uint64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1217,9 +1295,11 @@ func DecodeMethodQueryArrayFloat32Request(mux goahttp.Muxer, decoder func(*http.
 		var (
 			q   []float32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]float32, len(qRaw))
 				for i, rv := range qRaw {
@@ -1249,9 +1329,11 @@ func DecodeMethodQueryArrayFloat32ValidateRequest(mux goahttp.Muxer, decoder fun
 		var (
 			q   []float32
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1289,9 +1371,11 @@ func DecodeMethodQueryArrayFloat64Request(mux goahttp.Muxer, decoder func(*http.
 		var (
 			q   []float64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]float64, len(qRaw))
 				for i, rv := range qRaw {
@@ -1321,9 +1405,11 @@ func DecodeMethodQueryArrayFloat64ValidateRequest(mux goahttp.Muxer, decoder fun
 		var (
 			q   []float64
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1360,8 +1446,10 @@ func DecodeMethodQueryArrayStringRequest(mux goahttp.Muxer, decoder func(*http.R
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q []string
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query()["q"]
+		q = qp["q"]
 		payload := NewMethodQueryArrayStringPayload(q)
 
 		return payload, nil
@@ -1377,8 +1465,10 @@ func DecodeMethodQueryArrayStringValidateRequest(mux goahttp.Muxer, decoder func
 		var (
 			q   []string
 			err error
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query()["q"]
+		q = qp["q"]
 		if q == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 		}
@@ -1406,9 +1496,11 @@ func DecodeMethodQueryArrayBytesRequest(mux goahttp.Muxer, decoder func(*http.Re
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q [][]byte
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([][]byte, len(qRaw))
 				for i, rv := range qRaw {
@@ -1431,9 +1523,11 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 		var (
 			q   [][]byte
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1466,9 +1560,11 @@ func DecodeMethodQueryArrayAnyRequest(mux goahttp.Muxer, decoder func(*http.Requ
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q []interface{}
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw != nil {
 				q = make([]interface{}, len(qRaw))
 				for i, rv := range qRaw {
@@ -1490,9 +1586,11 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 		var (
 			q   []interface{}
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1525,9 +1623,11 @@ func DecodeMethodQueryMapStringStringRequest(mux goahttp.Muxer, decoder func(*ht
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q map[string]string
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -1560,9 +1660,11 @@ func DecodeMethodQueryMapStringStringValidateRequest(mux goahttp.Muxer, decoder
 		var (
 			q   map[string]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1609,9 +1711,11 @@ func DecodeMethodQueryMapStringBoolRequest(mux goahttp.Muxer, decoder func(*http
 		var (
 			q   map[string]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -1656,9 +1760,11 @@ func DecodeMethodQueryMapStringBoolValidateRequest(mux goahttp.Muxer, decoder fu
 		var (
 			q   map[string]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1714,9 +1820,11 @@ func DecodeMethodQueryMapBoolStringRequest(mux goahttp.Muxer, decoder func(*http
 		var (
 			q   map[bool]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -1757,9 +1865,11 @@ func DecodeMethodQueryMapBoolStringValidateRequest(mux goahttp.Muxer, decoder fu
 		var (
 			q   map[bool]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1811,9 +1921,11 @@ func DecodeMethodQueryMapBoolBoolRequest(mux goahttp.Muxer, decoder func(*http.R
 		var (
 			q   map[bool]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -1863,9 +1975,11 @@ func DecodeMethodQueryMapBoolBoolValidateRequest(mux goahttp.Muxer, decoder func
 		var (
 			q   map[bool]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -1926,9 +2040,11 @@ func DecodeMethodQueryMapStringArrayStringRequest(mux goahttp.Muxer, decoder fun
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q map[string][]string
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -1961,9 +2077,11 @@ func DecodeMethodQueryMapStringArrayStringValidateRequest(mux goahttp.Muxer, dec
 		var (
 			q   map[string][]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2011,9 +2129,11 @@ func DecodeMethodQueryMapStringArrayBoolRequest(mux goahttp.Muxer, decoder func(
 		var (
 			q   map[string][]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -2060,9 +2180,11 @@ func DecodeMethodQueryMapStringArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		var (
 			q   map[string][]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2121,9 +2243,11 @@ func DecodeMethodQueryMapBoolArrayStringRequest(mux goahttp.Muxer, decoder func(
 		var (
 			q   map[bool][]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -2164,9 +2288,11 @@ func DecodeMethodQueryMapBoolArrayStringValidateRequest(mux goahttp.Muxer, decod
 		var (
 			q   map[bool][]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -2217,9 +2343,11 @@ func DecodeMethodQueryMapBoolArrayBoolRequest(mux goahttp.Muxer, decoder func(*h
 		var (
 			q   map[bool][]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) != 0 {
 				for keyRaw, valRaw := range qRaw {
 					if strings.HasPrefix(keyRaw, "q[") {
@@ -2271,9 +2399,11 @@ func DecodeMethodQueryMapBoolArrayBoolValidateRequest(mux goahttp.Muxer, decoder
 		var (
 			q   map[bool][]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2337,8 +2467,10 @@ func DecodeMethodQueryPrimitiveStringValidateRequest(mux goahttp.Muxer, decoder
 		var (
 			q   string
 			err error
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query().Get("q")
+		q = qp.Get("q")
 		if q == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 		}
@@ -2363,9 +2495,11 @@ func DecodeMethodQueryPrimitiveBoolValidateRequest(mux goahttp.Muxer, decoder fu
 		var (
 			q   bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query().Get("q")
+			qRaw := qp.Get("q")
 			if qRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2396,8 +2530,10 @@ func DecodeMethodQueryPrimitiveArrayStringValidateRequest(mux goahttp.Muxer, dec
 		var (
 			q   []string
 			err error
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query()["q"]
+		q = qp["q"]
 		if q == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 		}
@@ -2427,9 +2563,11 @@ func DecodeMethodQueryPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		var (
 			q   []bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()["q"]
+			qRaw := qp["q"]
 			if qRaw == nil {
 				return nil, goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2469,9 +2607,11 @@ func DecodeMethodQueryPrimitiveMapStringArrayStringValidateRequest(mux goahttp.M
 		var (
 			q   map[string][]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2520,9 +2660,11 @@ func DecodeMethodQueryPrimitiveMapStringBoolValidateRequest(mux goahttp.Muxer, d
 		var (
 			q   map[string]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2577,9 +2719,11 @@ func DecodeMethodQueryPrimitiveMapBoolArrayBoolValidateRequest(mux goahttp.Muxer
 		var (
 			q   map[bool][]bool
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2648,9 +2792,11 @@ func DecodeMethodQueryMapStringMapIntStringValidateRequest(mux goahttp.Muxer, de
 		var (
 			q   map[string]map[int]string
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2707,9 +2853,11 @@ func DecodeMethodQueryMapIntMapStringArrayIntValidateRequest(mux goahttp.Muxer,
 		var (
 			q   map[int]map[string][]int
 			err error
+
+			qp = r.URL.Query()
 		)
 		{
-			qRaw := r.URL.Query()
+			qRaw := qp
 			if len(qRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
@@ -2775,8 +2923,10 @@ func DecodeMethodQueryStringMappedRequest(mux goahttp.Muxer, decoder func(*http.
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			query *string
+
+			qp = r.URL.Query()
 		)
-		queryRaw := r.URL.Query().Get("q")
+		queryRaw := qp.Get("q")
 		if queryRaw != "" {
 			query = &queryRaw
 		}
@@ -2793,8 +2943,10 @@ func DecodeMethodQueryStringDefaultRequest(mux goahttp.Muxer, decoder func(*http
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q string
+
+			qp = r.URL.Query()
 		)
-		qRaw := r.URL.Query().Get("q")
+		qRaw := qp.Get("q")
 		if qRaw != "" {
 			q = qRaw
 		} else {
@@ -2814,8 +2966,10 @@ func DecodeMethodQueryStringSliceDefaultRequest(mux goahttp.Muxer, decoder func(
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q []string
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query()["q"]
+		q = qp["q"]
 		if q == nil {
 			q = []string{"hello", "goodbye"}
 		}
@@ -2834,8 +2988,10 @@ func DecodeMethodQueryStringDefaultValidateRequest(mux goahttp.Muxer, decoder fu
 		var (
 			q   string
 			err error
+
+			qp = r.URL.Query()
 		)
-		qRaw := r.URL.Query().Get("q")
+		qRaw := qp.Get("q")
 		if qRaw != "" {
 			q = qRaw
 		} else {
@@ -2862,8 +3018,10 @@ func DecodeMethodQueryPrimitiveStringDefaultRequest(mux goahttp.Muxer, decoder f
 		var (
 			q   string
 			err error
+
+			qp = r.URL.Query()
 		)
-		q = r.URL.Query().Get("q")
+		q = qp.Get("q")
 		if q == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 		}
@@ -2884,8 +3042,10 @@ func DecodeMethodQueryStringExtendedPayloadRequest(mux goahttp.Muxer, decoder fu
 	return func(r *http.Request) (interface{}, error) {
 		var (
 			q *string
+
+			qp = r.URL.Query()
 		)
-		qRaw := r.URL.Query().Get("q")
+		qRaw := qp.Get("q")
 		if qRaw != "" {
 			q = &qRaw
 		}
@@ -3171,6 +3331,34 @@ func DecodeMethodHeaderStringValidateRequest(mux goahttp.Muxer, decoder func(*ht
 }
 `
 
+var PayloadHeaderStringValidateInHeaderDecodeCode = `// DecodeMethodHeaderStringValidateInHeaderRequest returns a decoder for
+// requests sent to the ServiceHeaderStringValidateInHeader
+// MethodHeaderStringValidateInHeader endpoint.
+func DecodeMethodHeaderStringValidateInHeaderRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			version string
+			err     error
+		)
+		versionRaw := r.Header.Get("X-Api-Version")
+		if versionRaw != "" {
+			version = versionRaw
+		} else {
+			version = "v1"
+		}
+		if !(version == "v1" || version == "v2") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("X-Api-Version", version, []interface{}{"v1", "v2"}))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodHeaderStringValidateInHeaderPayload(version)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadHeaderArrayStringDecodeCode = `// DecodeMethodHeaderArrayStringRequest returns a decoder for requests sent to
 // the ServiceHeaderArrayString MethodHeaderArrayString endpoint.
 func DecodeMethodHeaderArrayStringRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -3636,6 +3824,279 @@ func DecodeMethodBodyStringRequest(mux goahttp.Muxer, decoder func(*http.Request
 }
 `
 
+var PayloadTimeTypesDecodeCode = `// DecodeMethodTimeTypesRequest returns a decoder for requests sent to the
+// ServiceTimeTypes MethodTimeTypes endpoint.
+func DecodeMethodTimeTypesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			id      uuid.UUID
+			since   *time.Time
+			days    []goa.Date
+			timeout *time.Duration
+			err     error
+
+			params = mux.Vars(r)
+			qp     = r.URL.Query()
+		)
+		{
+			idRaw := params["id"]
+			v, err2 := uuid.Parse(idRaw)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("id", idRaw, "uuid"))
+			}
+			id = v
+		}
+		{
+			sinceRaw := qp.Get("since")
+			if sinceRaw != "" {
+				v, err2 := time.Parse(time.RFC3339, sinceRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("since", sinceRaw, "datetime"))
+				}
+				since = &v
+			}
+		}
+		{
+			daysRaw := qp["days"]
+			if daysRaw != nil {
+				days = make([]goa.Date, len(daysRaw))
+				for i, rv := range daysRaw {
+					v, err2 := goa.ParseDate(rv)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("days", daysRaw, "array of dates"))
+					}
+					days[i] = v
+				}
+			}
+		}
+		{
+			timeoutRaw := r.Header.Get("timeout")
+			if timeoutRaw != "" {
+				v, err2 := goahttp.ParseDuration(timeoutRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("timeout", timeoutRaw, "duration"))
+				}
+				timeout = &v
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodTimeTypesPayload(id, since, days, timeout)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadDecimalDecodeCode = `// DecodeMethodDecimalRequest returns a decoder for requests sent to the
+// ServiceDecimal MethodDecimal endpoint.
+func DecodeMethodDecimalRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodDecimalRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			amount *goa.Decimal
+			rates  []goa.Decimal
+
+			qp = r.URL.Query()
+		)
+		{
+			amountRaw := qp.Get("amount")
+			if amountRaw != "" {
+				v, err2 := goa.ParseDecimal(amountRaw)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("amount", amountRaw, "decimal"))
+				}
+				amount = &v
+			}
+		}
+		{
+			ratesRaw := qp["rates"]
+			if ratesRaw != nil {
+				rates = make([]goa.Decimal, len(ratesRaw))
+				for i, rv := range ratesRaw {
+					v, err2 := goa.ParseDecimal(rv)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("rates", ratesRaw, "array of decimals"))
+					}
+					rates[i] = v
+				}
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodDecimalPayload(&body, amount, rates)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyStrictDecodeCode = `// DecodeMethodBodyStrictRequest returns a decoder for requests sent to the
+// ServiceBodyStrict MethodBodyStrict endpoint.
+func DecodeMethodBodyStrictRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyStrictRequestBody
+			err  error
+		)
+		err = goahttp.DecodeStrict(r, decoder, &body, false)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodBodyStrictPayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyLenientDecodeCode = `// DecodeMethodBodyLenientRequest returns a decoder for requests sent to the
+// ServiceBodyLenient MethodBodyLenient endpoint.
+func DecodeMethodBodyLenientRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyLenientRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyLenientRequestBody(&body)
+		if err != nil {
+			if err = goahttp.LenientValidation(r, err); err != nil {
+				return nil, err
+			}
+		}
+
+		var (
+			b *int
+
+			qp = r.URL.Query()
+		)
+		{
+			bRaw := qp.Get("b")
+			if bRaw != "" {
+				v, err2 := strconv.ParseInt(bRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("b", bRaw, "integer"))
+				}
+				pv := int(v)
+				b = &pv
+			}
+		}
+		if b != nil {
+			if *b < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("b", *b, 1, true))
+			}
+		}
+		if err != nil {
+			if err = goahttp.LenientValidation(r, err); err != nil {
+				return nil, err
+			}
+		}
+		payload := NewMethodBodyLenientPayload(&body, b)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyNullableDecodeCode = `// DecodeMethodBodyNullableRequest returns a decoder for requests sent to the
+// ServiceBodyNullable MethodBodyNullable endpoint.
+func DecodeMethodBodyNullableRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyNullableRequestBody
+			err  error
+		)
+		if err = goahttp.RecordSetFields(r, map[string]string{"b": "b", "cc": "c"}); err != nil {
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateMethodBodyNullableRequestBody(&body)
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodBodyNullablePayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyStrictWarnDecodeCode = `// DecodeMethodBodyStrictWarnRequest returns a decoder for requests sent to the
+// ServiceBodyStrictWarn MethodBodyStrictWarn endpoint.
+func DecodeMethodBodyStrictWarnRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyStrictWarnRequestBody
+			err  error
+		)
+		err = goahttp.DecodeStrict(r, decoder, &body, true)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodBodyStrictWarnPayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyStringConsumesDecodeCode = `// DecodeMethodBodyStringConsumesRequest returns a decoder for requests sent to
+// the ServiceBodyStringConsumes MethodBodyStringConsumes endpoint.
+func DecodeMethodBodyStringConsumesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		if err := goahttp.CheckContentType(r, "application/json"); err != nil {
+			return nil, err
+		}
+		var (
+			body MethodBodyStringConsumesRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodBodyStringConsumesPayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadBodyStringValidateDecodeCode = `// DecodeMethodBodyStringValidateRequest returns a decoder for requests sent to
 // the ServiceBodyStringValidate MethodBodyStringValidate endpoint.
 func DecodeMethodBodyStringValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -4377,8 +4838,10 @@ func DecodeMethodBodyQueryObjectRequest(mux goahttp.Muxer, decoder func(*http.Re
 
 		var (
 			b *string
+
+			qp = r.URL.Query()
 		)
-		bRaw := r.URL.Query().Get("b")
+		bRaw := qp.Get("b")
 		if bRaw != "" {
 			b = &bRaw
 		}
@@ -4412,8 +4875,10 @@ func DecodeMethodBodyQueryObjectValidateRequest(mux goahttp.Muxer, decoder func(
 
 		var (
 			b string
+
+			qp = r.URL.Query()
 		)
-		b = r.URL.Query().Get("b")
+		b = qp.Get("b")
 		if b == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("b", "query string"))
 		}
@@ -4446,8 +4911,10 @@ func DecodeMethodBodyQueryUserRequest(mux goahttp.Muxer, decoder func(*http.Requ
 
 		var (
 			b *string
+
+			qp = r.URL.Query()
 		)
-		bRaw := r.URL.Query().Get("b")
+		bRaw := qp.Get("b")
 		if bRaw != "" {
 			b = &bRaw
 		}
@@ -4480,8 +4947,10 @@ func DecodeMethodBodyQueryUserValidateRequest(mux goahttp.Muxer, decoder func(*h
 
 		var (
 			b string
+
+			qp = r.URL.Query()
 		)
-		b = r.URL.Query().Get("b")
+		b = qp.Get("b")
 		if b == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("b", "query string"))
 		}
@@ -4650,9 +5119,10 @@ func DecodeMethodBodyQueryPathObjectRequest(mux goahttp.Muxer, decoder func(*htt
 			b  *string
 
 			params = mux.Vars(r)
+			qp     = r.URL.Query()
 		)
 		c2 = params["c"]
-		bRaw := r.URL.Query().Get("b")
+		bRaw := qp.Get("b")
 		if bRaw != "" {
 			b = &bRaw
 		}
@@ -4689,10 +5159,11 @@ func DecodeMethodBodyQueryPathObjectValidateRequest(mux goahttp.Muxer, decoder f
 			b  string
 
 			params = mux.Vars(r)
+			qp     = r.URL.Query()
 		)
 		c2 = params["c"]
 		err = goa.MergeErrors(err, goa.ValidatePattern("c2", c2, "patternc"))
-		b = r.URL.Query().Get("b")
+		b = qp.Get("b")
 		if b == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("b", "query string"))
 		}
@@ -4728,9 +5199,10 @@ func DecodeMethodBodyQueryPathUserRequest(mux goahttp.Muxer, decoder func(*http.
 			b  *string
 
 			params = mux.Vars(r)
+			qp     = r.URL.Query()
 		)
 		c2 = params["c"]
-		bRaw := r.URL.Query().Get("b")
+		bRaw := qp.Get("b")
 		if bRaw != "" {
 			b = &bRaw
 		}
@@ -4767,10 +5239,11 @@ func DecodeMethodBodyQueryPathUserValidateRequest(mux goahttp.Muxer, decoder fun
 			b  string
 
 			params = mux.Vars(r)
+			qp     = r.URL.Query()
 		)
 		c2 = params["c"]
 		err = goa.MergeErrors(err, goa.ValidatePattern("c2", c2, "patternc"))
-		b = r.URL.Query().Get("b")
+		b = qp.Get("b")
 		if b == "" {
 			err = goa.MergeErrors(err, goa.MissingFieldError("b", "query string"))
 		}
@@ -4792,9 +5265,11 @@ func DecodeMapQueryPrimitivePrimitiveRequest(mux goahttp.Muxer, decoder func(*ht
 		var (
 			query map[string]string
 			err   error
+
+			qp = r.URL.Query()
 		)
 		{
-			queryRaw := r.URL.Query()
+			queryRaw := qp
 			if len(queryRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("query", "query string"))
 			}
@@ -4830,9 +5305,11 @@ func DecodeMapQueryPrimitiveArrayRequest(mux goahttp.Muxer, decoder func(*http.R
 		var (
 			query map[string][]uint
 			err   error
+
+			qp = r.URL.Query()
 		)
 		{
-			queryRaw := r.URL.Query()
+			queryRaw := qp
 			if len(queryRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("query", "query string"))
 			}
@@ -4897,11 +5374,12 @@ func DecodeMethodMapQueryObjectRequest(mux goahttp.Muxer, decoder func(*http.Req
 			c map[int][]string
 
 			params = mux.Vars(r)
+			qp     = r.URL.Query()
 		)
 		a = params["a"]
 		err = goa.MergeErrors(err, goa.ValidatePattern("a", a, "patterna"))
 		{
-			cRaw := r.URL.Query()
+			cRaw := qp
 			if len(cRaw) == 0 {
 				err = goa.MergeErrors(err, goa.MissingFieldError("c", "query string"))
 			}
@@ -5015,6 +5493,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			optionalButRequiredHeader float32
 
 			params = mux.Vars(r)
+			qp     = r.URL.Query()
 		)
 		{
 			pathRaw := params["path"]
@@ -5025,7 +5504,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			path = uint(v)
 		}
 		{
-			optionalRaw := r.URL.Query().Get("optional")
+			optionalRaw := qp.Get("optional")
 			if optionalRaw != "" {
 				v, err2 := strconv.ParseInt(optionalRaw, 10, strconv.IntSize)
 				if err2 != nil {
@@ -5036,7 +5515,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			}
 		}
 		{
-			optionalButRequiredParamRaw := r.URL.Query().Get("optional_but_required_param")
+			optionalButRequiredParamRaw := qp.Get("optional_but_required_param")
 			if optionalButRequiredParamRaw == "" {
 				err = goa.MergeErrors(err, goa.MissingFieldError("optional_but_required_param", "query string"))
 			}
@@ -5080,9 +5559,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			int32_ *int32
 			int64_ *int64
 			err    error
+
+			qp = r.URL.Query()
 		)
 		{
-			int_Raw := r.URL.Query().Get("int")
+			int_Raw := qp.Get("int")
 			if int_Raw != "" {
 				v, err2 := strconv.ParseInt(int_Raw, 10, strconv.IntSize)
 				if err2 != nil {
@@ -5093,7 +5574,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			}
 		}
 		{
-			int32_Raw := r.URL.Query().Get("int32")
+			int32_Raw := qp.Get("int32")
 			if int32_Raw != "" {
 				v, err2 := strconv.ParseInt(int32_Raw, 10, 32)
 				if err2 != nil {
@@ -5104,7 +5585,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			}
 		}
 		{
-			int64_Raw := r.URL.Query().Get("int64")
+			int64_Raw := qp.Get("int64")
 			if int64_Raw != "" {
 				v, err2 := strconv.ParseInt(int64_Raw, 10, 64)
 				if err2 != nil {
@@ -5132,9 +5613,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			int32_ *int32
 			int64_ *int64
 			err    error
+
+			qp = r.URL.Query()
 		)
 		{
-			int_Raw := r.URL.Query().Get("int")
+			int_Raw := qp.Get("int")
 			if int_Raw != "" {
 				v, err2 := strconv.ParseInt(int_Raw, 10, strconv.IntSize)
 				if err2 != nil {
@@ -5150,7 +5633,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			}
 		}
 		{
-			int32_Raw := r.URL.Query().Get("int32")
+			int32_Raw := qp.Get("int32")
 			if int32_Raw != "" {
 				v, err2 := strconv.ParseInt(int32_Raw, 10, 32)
 				if err2 != nil {
@@ -5166,7 +5649,7 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 			}
 		}
 		{
-			int64_Raw := r.URL.Query().Get("int64")
+			int64_Raw := qp.Get("int64")
 			if int64_Raw != "" {
 				v, err2 := strconv.ParseInt(int64_Raw, 10, 64)
 				if err2 != nil {
@@ -5197,9 +5680,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		var (
 			array []uint
 			err   error
+
+			qp = r.URL.Query()
 		)
 		{
-			arrayRaw := r.URL.Query()["array"]
+			arrayRaw := qp["array"]
 			if arrayRaw != nil {
 				array = make([]uint, len(arrayRaw))
 				for i, rv := range arrayRaw {
@@ -5228,9 +5713,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		var (
 			array []uint
 			err   error
+
+			qp = r.URL.Query()
 		)
 		{
-			arrayRaw := r.URL.Query()["array"]
+			arrayRaw := qp["array"]
 			if arrayRaw != nil {
 				array = make([]uint, len(arrayRaw))
 				for i, rv := range arrayRaw {
@@ -5266,9 +5753,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		var (
 			map_ map[float32]bool
 			err  error
+
+			qp = r.URL.Query()
 		)
 		{
-			map_Raw := r.URL.Query()
+			map_Raw := qp
 			if len(map_Raw) != 0 {
 				for keyRaw, valRaw := range map_Raw {
 					if strings.HasPrefix(keyRaw, "map[") {
@@ -5317,9 +5806,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		var (
 			map_ map[float32]bool
 			err  error
+
+			qp = r.URL.Query()
 		)
 		{
-			map_Raw := r.URL.Query()
+			map_Raw := qp
 			if len(map_Raw) != 0 {
 				for keyRaw, valRaw := range map_Raw {
 					if strings.HasPrefix(keyRaw, "map[") {
@@ -5371,9 +5862,11 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 		var (
 			array []float64
 			err   error
+
+			qp = r.URL.Query()
 		)
 		{
-			arrayRaw := r.URL.Query()["array"]
+			arrayRaw := qp["array"]
 			if arrayRaw != nil {
 				array = make([]float64, len(arrayRaw))
 				for i, rv := range arrayRaw {
@@ -5497,3 +5990,258 @@ func DecodeMethodARequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp
 	}
 }
 `
+
+var PayloadQueryArrayCSVDecodeCode = `// DecodeMethodQueryArrayCSVRequest returns a decoder for requests sent to the
+// ServiceQueryArrayCSV MethodQueryArrayCSV endpoint.
+func DecodeMethodQueryArrayCSVRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			ids  []int
+			tags []string
+			err  error
+
+			qp = r.URL.Query()
+		)
+		{
+			idsRaw := goahttp.SplitCSV(qp["ids"])
+			if idsRaw != nil {
+				ids = make([]int, len(idsRaw))
+				for i, rv := range idsRaw {
+					v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+					if err2 != nil {
+						err = goa.MergeErrors(err, goa.InvalidFieldTypeError("ids", idsRaw, "array of integers"))
+					}
+					ids[i] = int(v)
+				}
+			}
+		}
+		tags = goahttp.SplitCSV(qp["tags"])
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryArrayCSVPayload(ids, tags)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadBodyNDJSONDecodeCode = `// DecodeMethodBodyNDJSONRequest returns a decoder for requests sent to the
+// ServiceBodyNDJSON MethodBodyNDJSON endpoint.
+func DecodeMethodBodyNDJSONRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		if err := goahttp.CheckContentType(r, "application/json", "application/x-ndjson"); err != nil {
+			return nil, err
+		}
+		var (
+			body []*ItemRequestBody
+			err  error
+		)
+		err = goahttp.NDJSONRequestDecoder(decoder)(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodBodyNDJSONItem(body)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryObjectDecodeCode = `// DecodeMethodQueryObjectRequest returns a decoder for requests sent to the
+// ServiceQueryObject MethodQueryObject endpoint.
+func DecodeMethodQueryObjectRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			filter *servicequeryobject.Filter
+			page   *struct {
+				Size   *int
+				Cursor *string
+			}
+			err error
+
+			qp = r.URL.Query()
+		)
+		{
+			filterRaw := &servicequeryobject.Filter{}
+			var filterFound bool
+			if filterNameRaw := qp.Get("filter[name]"); filterNameRaw != "" {
+				filterRaw.Name = filterNameRaw
+				filterFound = true
+			}
+			if filterMinAgeRaw := qp.Get("filter[min_age]"); filterMinAgeRaw != "" {
+				var filterMinAge *int
+				v, err2 := strconv.ParseInt(filterMinAgeRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("filterMinAge", filterMinAgeRaw, "integer"))
+				}
+				pv := int(v)
+				filterMinAge = &pv
+				filterRaw.MinAge = filterMinAge
+				filterFound = true
+			}
+			if filterTags, ok := qp["filter[tags]"]; ok {
+				filterRaw.Tags = filterTags
+				filterFound = true
+			}
+			if filterFound {
+				filter = filterRaw
+				if qp["filter[name]"] == nil {
+					err = goa.MergeErrors(err, goa.MissingFieldError("filter[name]", "query string"))
+				}
+			} else {
+				err = goa.MergeErrors(err, goa.MissingFieldError("filter", "query string"))
+			}
+		}
+		if filter != nil {
+			if filter.MinAge != nil {
+				if *filter.MinAge < 0 {
+					err = goa.MergeErrors(err, goa.InvalidRangeError("filter.min_age", *filter.MinAge, 0, true))
+				}
+			}
+		}
+		{
+			pageRaw := &struct {
+				Size   *int
+				Cursor *string
+			}{}
+			var pageFound bool
+			if pageSizeRaw := qp.Get("page[size]"); pageSizeRaw != "" {
+				var pageSize *int
+				v, err2 := strconv.ParseInt(pageSizeRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("pageSize", pageSizeRaw, "integer"))
+				}
+				pv := int(v)
+				pageSize = &pv
+				pageRaw.Size = pageSize
+				pageFound = true
+			}
+			if pageCursorRaw := qp.Get("page[cursor]"); pageCursorRaw != "" {
+				pageRaw.Cursor = &pageCursorRaw
+				pageFound = true
+			}
+			if pageFound {
+				page = pageRaw
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryObjectPayload(filter, page)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadMultipartConsumesDecodeCode = `// DecodeMethodMultipartConsumesRequest returns a decoder for requests sent to
+// the ServiceMultipartConsumes MethodMultipartConsumes endpoint.
+func DecodeMethodMultipartConsumesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		if err := goahttp.CheckContentType(r, "multipart/form-data"); err != nil {
+			return nil, err
+		}
+		var payload string
+		if err := decoder(r).Decode(&payload); err != nil {
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		return payload, nil
+	}
+}
+`
+
+var ServerLenientFileCode = `// EncodeMethodLenientResponse returns an encoder for responses returned by the
+// ServiceLenientFile MethodLenient endpoint.
+func EncodeMethodLenientResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+// DecodeMethodLenientRequest returns a decoder for requests sent to the
+// ServiceLenientFile MethodLenient endpoint.
+func DecodeMethodLenientRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			b   *int
+			err error
+
+			qp = r.URL.Query()
+		)
+		{
+			bRaw := qp.Get("b")
+			if bRaw != "" {
+				v, err2 := strconv.ParseInt(bRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("b", bRaw, "integer"))
+				}
+				pv := int(v)
+				b = &pv
+			}
+		}
+		if b != nil {
+			if *b < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("b", *b, 1, true))
+			}
+		}
+		if err != nil {
+			if err = goahttp.LenientValidation(r, err); err != nil {
+				return nil, err
+			}
+		}
+		payload := NewMethodLenientPayload(b)
+
+		return payload, nil
+	}
+}
+
+// EncodeMethodStrictResponse returns an encoder for responses returned by the
+// ServiceLenientFile MethodStrict endpoint.
+func EncodeMethodStrictResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+// DecodeMethodStrictRequest returns a decoder for requests sent to the
+// ServiceLenientFile MethodStrict endpoint.
+func DecodeMethodStrictRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			b   *int
+			err error
+
+			qp = r.URL.Query()
+		)
+		{
+			bRaw := qp.Get("b")
+			if bRaw != "" {
+				v, err2 := strconv.ParseInt(bRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("b", bRaw, "integer"))
+				}
+				pv := int(v)
+				b = &pv
+			}
+		}
+		if b != nil {
+			if *b < 1 {
+				err = goa.MergeErrors(err, goa.InvalidRangeError("b", *b, 1, true))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodStrictPayload(b)
+
+		return payload, nil
+	}
+}
+`
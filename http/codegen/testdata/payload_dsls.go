@@ -446,6 +446,54 @@ var PayloadQueryArrayIntDSL = func() {
 	})
 }
 
+var PayloadQueryArrayCSVDSL = func() {
+	Service("ServiceQueryArrayCSV", func() {
+		Method("MethodQueryArrayCSV", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(Int))
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("ids", func() {
+					Meta("http:query:style", "csv")
+				})
+				Param("tags", func() {
+					Meta("http:query:style", "csv")
+				})
+			})
+		})
+	})
+}
+
+var PayloadQueryObjectDSL = func() {
+	var Filter = Type("Filter", func() {
+		Attribute("name", String)
+		Attribute("min_age", Int, func() {
+			Minimum(0)
+		})
+		Attribute("tags", ArrayOf(String))
+		Required("name")
+	})
+	Service("ServiceQueryObject", func() {
+		Method("MethodQueryObject", func() {
+			Payload(func() {
+				Attribute("filter", Filter)
+				Attribute("page", func() {
+					Attribute("size", Int)
+					Attribute("cursor", String)
+				})
+				Required("filter")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter")
+				Param("page")
+			})
+		})
+	})
+}
+
 var PayloadQueryArrayIntValidateDSL = func() {
 	Service("ServiceQueryArrayIntValidate", func() {
 		Method("MethodQueryArrayIntValidate", func() {
@@ -1570,6 +1618,23 @@ var PayloadHeaderStringValidateDSL = func() {
 	})
 }
 
+var PayloadHeaderStringValidateInHeaderDSL = func() {
+	Service("ServiceHeaderStringValidateInHeader", func() {
+		Method("MethodHeaderStringValidateInHeader", func() {
+			Payload(func() {
+				Attribute("version", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Header("version:X-Api-Version", String, func() {
+					Enum("v1", "v2")
+					Default("v1")
+				})
+			})
+		})
+	})
+}
+
 var PayloadHeaderArrayStringDSL = func() {
 	Service("ServiceHeaderArrayString", func() {
 		Method("MethodHeaderArrayString", func() {
@@ -1923,6 +1988,135 @@ var PayloadBodyStringDSL = func() {
 	})
 }
 
+var PayloadBodyStringConsumesDSL = func() {
+	Service("ServiceBodyStringConsumes", func() {
+		HTTP(func() {
+			Consumes("application/json", "application/xml")
+		})
+		Method("MethodBodyStringConsumes", func() {
+			Payload(func() {
+				Attribute("b", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Consumes("application/json")
+			})
+		})
+	})
+}
+
+var PayloadBodyStrictDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			StrictPayload()
+		})
+	})
+	Service("ServiceBodyStrict", func() {
+		Method("MethodBodyStrict", func() {
+			Payload(func() {
+				Attribute("b", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadBodyStrictWarnDSL = func() {
+	Service("ServiceBodyStrictWarn", func() {
+		HTTP(func() {
+			StrictPayload()
+		})
+		Method("MethodBodyStrictWarn", func() {
+			Payload(func() {
+				Attribute("b", String)
+			})
+			HTTP(func() {
+				POST("/")
+				StrictPayload("warn")
+			})
+		})
+	})
+}
+
+var PayloadBodyLenientDSL = func() {
+	Service("ServiceBodyLenient", func() {
+		Method("MethodBodyLenient", func() {
+			Meta("validation:lenient")
+			Payload(func() {
+				Attribute("a", String, func() {
+					Pattern("pattern")
+				})
+				Attribute("b", Int, func() {
+					Minimum(1)
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Param("b")
+			})
+		})
+	})
+}
+
+var PayloadBodyNullableDSL = func() {
+	Service("ServiceBodyNullable", func() {
+		Method("MethodBodyNullable", func() {
+			Payload(func() {
+				Attribute("a", String)
+				Attribute("b", String, func() {
+					Nullable()
+				})
+				Attribute("c", Int, func() {
+					Nullable()
+					WireName("cc")
+				})
+				Required("a")
+			})
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
+
+var PayloadTimeTypesDSL = func() {
+	Service("ServiceTimeTypes", func() {
+		Method("MethodTimeTypes", func() {
+			Payload(func() {
+				Attribute("id", UUID)
+				Attribute("since", DateTime)
+				Attribute("days", ArrayOf(Date))
+				Attribute("timeout", Duration)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Param("since")
+				Param("days")
+				Header("timeout")
+			})
+		})
+	})
+}
+
+var PayloadDecimalDSL = func() {
+	Service("ServiceDecimal", func() {
+		Method("MethodDecimal", func() {
+			Payload(func() {
+				Attribute("amount", Decimal)
+				Attribute("rates", ArrayOf(Decimal))
+				Attribute("total", Decimal)
+			})
+			HTTP(func() {
+				POST("/")
+				Param("amount")
+				Param("rates")
+			})
+		})
+	})
+}
+
 var PayloadBodyStringValidateDSL = func() {
 	Service("ServiceBodyStringValidate", func() {
 		Method("MethodBodyStringValidate", func() {
@@ -2159,6 +2353,34 @@ var PayloadBodyArrayStringValidateDSL = func() {
 	})
 }
 
+var PayloadBodyNDJSONDSL = func() {
+	var _ = API("test", func() {
+		HTTP(func() {
+			Produces("application/json", "application/x-ndjson")
+		})
+	})
+	var Item = Type("Item", func() {
+		Attribute("sku", String)
+		Required("sku")
+	})
+	var Stored = ResultType("application/vnd.stored", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("sku", String)
+		})
+	})
+	Service("ServiceBodyNDJSON", func() {
+		Method("MethodBodyNDJSON", func() {
+			Payload(ArrayOf(Item))
+			Result(CollectionOf(Stored))
+			HTTP(func() {
+				POST("/import")
+				Consumes("application/json", "application/x-ndjson")
+			})
+		})
+	})
+}
+
 var PayloadBodyArrayUserDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String, func() {
@@ -2944,6 +3166,23 @@ var PayloadMultipartPrimitiveDSL = func() {
 	})
 }
 
+var PayloadMultipartConsumesDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Consumes("application/json")
+		})
+	})
+	Service("ServiceMultipartConsumes", func() {
+		Method("MethodMultipartConsumes", func() {
+			Payload(String)
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
 var PayloadMultipartUserTypeDSL = func() {
 	Service("ServiceMultipartUserType", func() {
 		Method("MethodMultipartUserType", func() {
@@ -3363,3 +3602,87 @@ var QueryArrayNestedAliasValidateDSL = func() {
 		})
 	})
 }
+
+var PayloadWireNameDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("self_url", String, func() {
+			WireName("selfURL")
+			Format(FormatURI)
+		})
+		Attribute("display_name", String)
+		Required("self_url")
+	})
+	Service("ServiceWireName", func() {
+		Method("MethodWireName", func() {
+			Payload(Account)
+			Result(Account)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadUnionDiscriminatorDSL = func() {
+	var Cat = Type("Cat", func() {
+		Attribute("name", String)
+		Attribute("lives", Int)
+		Required("name")
+	})
+	var Dog = Type("Dog", func() {
+		Attribute("name", String)
+		Attribute("barks", Boolean)
+	})
+	var Pet = Type("Pet", func() {
+		OneOf("pet", func() {
+			Discriminator("kind")
+			Attribute("cat", Cat)
+			Attribute("dog", Dog)
+		})
+	})
+	var Pets = Type("Pets", func() {
+		Attribute("pets", ArrayOf(Pet))
+	})
+	Service("ServiceUnionDiscriminator", func() {
+		Method("MethodUnionDiscriminator", func() {
+			Payload(Pets)
+			Result(Pets)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadFuzzDSL = func() {
+	var _ = API("test", func() {
+		Meta("http:fuzz")
+	})
+	Service("ServiceFuzz", func() {
+		Method("MethodFuzz", func() {
+			Payload(func() {
+				Attribute("id", String, func() {
+					Example("abc")
+				})
+				Attribute("count", Int, func() {
+					Example(3)
+				})
+				Attribute("name", String, func() {
+					Example("joe")
+					MaxLength(10)
+				})
+				Required("id", "count")
+			})
+			HTTP(func() {
+				POST("/items/{id}")
+			})
+		})
+		Method("MethodNoBody", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/items")
+				Param("p")
+			})
+		})
+	})
+}
@@ -2613,3 +2613,65 @@ func EncodeMethodARequest(encoder func(*http.Request) goahttp.Encoder) func(*htt
 	}
 }
 `
+
+var PayloadQueryArrayCSVEncodeCode = `// EncodeMethodQueryArrayCSVRequest returns an encoder for requests sent to the
+// ServiceQueryArrayCSV MethodQueryArrayCSV server.
+func EncodeMethodQueryArrayCSVRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryarraycsv.MethodQueryArrayCSVPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryArrayCSV", "MethodQueryArrayCSV", "*servicequeryarraycsv.MethodQueryArrayCSVPayload", v)
+		}
+		values := req.URL.Query()
+		if len(p.Ids) > 0 {
+			idsStrs := make([]string, len(p.Ids))
+			for i, value := range p.Ids {
+				valueStr := strconv.Itoa(value)
+				idsStrs[i] = valueStr
+			}
+			values.Add("ids", strings.Join(idsStrs, ","))
+		}
+		if len(p.Tags) > 0 {
+			values.Add("tags", strings.Join(p.Tags, ","))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadQueryObjectEncodeCode = `// EncodeMethodQueryObjectRequest returns an encoder for requests sent to the
+// ServiceQueryObject MethodQueryObject server.
+func EncodeMethodQueryObjectRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryobject.MethodQueryObjectPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryObject", "MethodQueryObject", "*servicequeryobject.MethodQueryObjectPayload", v)
+		}
+		values := req.URL.Query()
+		if p.Filter != nil {
+			filterNameStr := p.Filter.Name
+			values.Add("filter[name]", filterNameStr)
+			if p.Filter.MinAge != nil {
+				filterMinAgeStr := strconv.Itoa((*p.Filter.MinAge))
+				values.Add("filter[min_age]", filterMinAgeStr)
+			}
+			for _, value := range p.Filter.Tags {
+				values.Add("filter[tags]", value)
+			}
+		}
+		if p.Page != nil {
+			if p.Page.Size != nil {
+				pageSizeStr := strconv.Itoa((*p.Page.Size))
+				values.Add("page[size]", pageSizeStr)
+			}
+			if p.Page.Cursor != nil {
+				pageCursorStr := (*p.Page.Cursor)
+				values.Add("page[cursor]", pageCursorStr)
+			}
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
@@ -811,7 +811,7 @@ func DecodeMethodAResponse(decoder func(*http.Response) goahttp.Decoder, restore
 			}
 			if numOccur != nil {
 				if *numOccur < 1 {
-					err = goa.MergeErrors(err, goa.InvalidRangeError("numOccur", *numOccur, 1, true))
+					err = goa.MergeErrors(err, goa.InvalidRangeError("X-Occur", *numOccur, 1, true))
 				}
 			}
 			if err != nil {
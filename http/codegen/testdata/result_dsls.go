@@ -568,6 +568,28 @@ var ResultBodyObjectDSL = func() {
 	})
 }
 
+var ResultBodyEnvelopeDSL = func() {
+	API("test", func() {
+		HTTP(func() {
+			Envelope(func() {
+				Attribute("data")
+				Attribute("meta", MapOf(String, String))
+				Attribute("errors")
+			})
+		})
+	})
+	Service("ServiceBodyEnvelope", func() {
+		Method("MethodBodyEnvelope", func() {
+			Result(func() {
+				Attribute("b", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var ResultBodyObjectHeaderDSL = func() {
 	Service("ServiceBodyObjectHeader", func() {
 		Method("MethodBodyObjectHeader", func() {
@@ -735,6 +757,46 @@ var ResultBodyCollectionExplicitViewDSL = func() {
 	})
 }
 
+var ResultCollectionTableDSL = func() {
+	var _ = API("test", func() {
+		HTTP(func() {
+			Produces("application/json", "text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		})
+	})
+	var RT = ResultType("ResultTypeTable", func() {
+		Attributes(func() {
+			Attribute("id", Int, func() {
+				Meta("csv:column", "ID")
+			})
+			Attribute("name", String)
+			Attribute("score", Float64, func() {
+				Default(1.5)
+			})
+			Attribute("tags", ArrayOf(String))
+			Required("id")
+		})
+		Meta("csv:columns", "name", "id", "score")
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+			Attribute("score")
+			Attribute("tags")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("ServiceCollectionTable", func() {
+		Method("MethodCollectionTable", func() {
+			Result(CollectionOf(RT))
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
 var ResultWithResultCollectionDSL = func() {
 	var RT = ResultType("RT", func() {
 		Attributes(func() {
@@ -1579,3 +1641,96 @@ var ValidateErrorResponseTypeDSL = func() {
 		})
 	})
 }
+
+var ResultBodySensitiveDSL = func() {
+	Service("ServiceBodySensitive", func() {
+		Method("MethodBodySensitive", func() {
+			Result(func() {
+				Attribute("name", String)
+				Attribute("password", String, func() {
+					Sensitive()
+				})
+				Attribute("card", String, func() {
+					Mask("****")
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ResultBodyNestedSensitiveDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("number", String, func() {
+			Mask("****")
+		})
+		Attribute("expiry", String)
+	})
+	Service("ServiceBodyNestedSensitive", func() {
+		Method("MethodBodyNestedSensitive", func() {
+			Result(func() {
+				Attribute("number", String)
+				Attribute("cards", ArrayOf(Card))
+				Attribute("primary", Card)
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ResultBodySensitiveWireNameDSL = func() {
+	var Card = Type("Card", func() {
+		Attribute("card_number", String, func() {
+			WireName("cardNumber")
+			Mask("****")
+		})
+	})
+	Service("ServiceBodySensitiveWireName", func() {
+		Method("MethodBodySensitiveWireName", func() {
+			Result(func() {
+				Attribute("access_token", String, func() {
+					WireName("accessToken")
+					Sensitive()
+				})
+				Attribute("card", Card)
+			})
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var ResultBodySensitiveViewsDSL = func() {
+	var RT = ResultType("ResultTypeSensitive", func() {
+		Attribute("name", String)
+		Attribute("ssn", String, func() {
+			Sensitive()
+		})
+		View("default", func() {
+			Attribute("name")
+			Attribute("ssn")
+		})
+		View("admin", func() {
+			Attribute("name")
+			Attribute("ssn")
+			Meta("sensitive:unmask")
+		})
+	})
+	Service("ServiceBodySensitiveViews", func() {
+		Method("MethodBodySensitiveViews", func() {
+			Result(RT)
+			HTTP(func() {
+				POST("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
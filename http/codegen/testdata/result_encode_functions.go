@@ -608,6 +608,19 @@ func EncodeMethodBodyObjectResponse(encoder func(context.Context, http.ResponseW
 }
 `
 
+var ResultBodyEnvelopeEncodeCode = `// EncodeMethodBodyEnvelopeResponse returns an encoder for responses returned
+// by the ServiceBodyEnvelope MethodBodyEnvelope endpoint.
+func EncodeMethodBodyEnvelopeResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*servicebodyenvelope.MethodBodyEnvelopeResult)
+		enc := goahttp.EnvelopeEncoder(ctx, encoder(ctx, w), "data")
+		body := NewMethodBodyEnvelopeResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
 var ResultBodyUserEncodeCode = `// EncodeMethodBodyUserResponse returns an encoder for responses returned by
 // the ServiceBodyUser MethodBodyUser endpoint.
 func EncodeMethodBodyUserResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
@@ -1063,3 +1076,108 @@ func EncodeMethodResultWithEmbeddedCustomPkgTypeDSLResponse(encoder func(context
 	}
 }
 `
+
+var ResultBodySensitiveEncodeCode = `// EncodeMethodBodySensitiveResponse returns an encoder for responses returned
+// by the ServiceBodySensitive MethodBodySensitive endpoint.
+func EncodeMethodBodySensitiveResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*servicebodysensitive.MethodBodySensitiveResult)
+		enc := encoder(ctx, w)
+		body := NewMethodBodySensitiveResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		if !goahttp.IsUnmasked(ctx) {
+			goahttp.MaskSensitive(body, map[string]string{"card": "****", "password": ""})
+		}
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultBodySensitiveViewsEncodeCode = `// EncodeMethodBodySensitiveViewsResponse returns an encoder for responses
+// returned by the ServiceBodySensitiveViews MethodBodySensitiveViews endpoint.
+func EncodeMethodBodySensitiveViewsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicebodysensitiveviewsviews.Resulttypesensitive)
+		w.Header().Set("goa-view", res.View)
+		enc := encoder(ctx, w)
+		var body interface{}
+		switch res.View {
+		case "default", "":
+			body = NewMethodBodySensitiveViewsResponseBody(res.Projected)
+		case "admin":
+			body = NewMethodBodySensitiveViewsResponseBodyAdmin(res.Projected)
+		}
+		w.WriteHeader(http.StatusOK)
+		if !goahttp.IsUnmasked(ctx) && res.View != "admin" {
+			goahttp.MaskSensitive(body, map[string]string{"ssn": ""})
+		}
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCollectionTableEncodeCode = `// EncodeMethodCollectionTableResponse returns an encoder for responses
+// returned by the ServiceCollectionTable MethodCollectionTable endpoint.
+func EncodeMethodCollectionTableResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(servicecollectiontableviews.ResulttypetableCollection)
+		w.Header().Set("goa-view", res.View)
+		enc := goahttp.TableEncoder(ctx, w, encoder, "text/csv", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+		var body interface{}
+		switch res.View {
+		case "default", "":
+			body = NewResulttypetableResponseCollection(res.Projected)
+		case "tiny":
+			body = NewResulttypetableResponseTinyCollection(res.Projected)
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCollectionNDJSONEncodeCode = `// EncodeMethodBodyNDJSONResponse returns an encoder for responses returned by
+// the ServiceBodyNDJSON MethodBodyNDJSON endpoint.
+func EncodeMethodBodyNDJSONResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(servicebodyndjsonviews.StoredCollection)
+		enc := goahttp.NDJSONResponseEncoder(encoder)(ctx, w)
+		body := NewStoredResponseCollection(res.Projected)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+var ResultBodyNestedSensitiveEncodeCode = `// EncodeMethodBodyNestedSensitiveResponse returns an encoder for responses
+// returned by the ServiceBodyNestedSensitive MethodBodyNestedSensitive
+// endpoint.
+func EncodeMethodBodyNestedSensitiveResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*servicebodynestedsensitive.MethodBodyNestedSensitiveResult)
+		enc := encoder(ctx, w)
+		body := NewMethodBodyNestedSensitiveResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		if !goahttp.IsUnmasked(ctx) {
+			goahttp.MaskSensitive(body, map[string]string{"cards.number": "****", "primary.number": "****"})
+		}
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultBodySensitiveWireNameEncodeCode = `// EncodeMethodBodySensitiveWireNameResponse returns an encoder for responses
+// returned by the ServiceBodySensitiveWireName MethodBodySensitiveWireName
+// endpoint.
+func EncodeMethodBodySensitiveWireNameResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res, _ := v.(*servicebodysensitivewirename.MethodBodySensitiveWireNameResult)
+		enc := encoder(ctx, w)
+		body := NewMethodBodySensitiveWireNameResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		if !goahttp.IsUnmasked(ctx) {
+			goahttp.MaskSensitive(body, map[string]string{"accessToken": "", "card.cardNumber": "****"})
+		}
+		return enc.Encode(body)
+	}
+}
+`
@@ -268,3 +268,251 @@ var ServerSimpleRoutingWithRedirectDSL = func() {
 		})
 	})
 }
+
+var ServerHostsDSL = func() {
+	API("test", func() {
+		Server("Regional", func() {
+			Host("dev", func() {
+				URI("http://localhost:8080")
+				URI("grpc://localhost:8081")
+			})
+			Host("prod", func() {
+				URI("https://{region}.example.com/{version}")
+				URI("http://{region}.example.com/{version}")
+				Variable("region", String, func() {
+					Enum("us", "eu")
+				})
+				Variable("version", String, func() {
+					Default("v1")
+				})
+			})
+		})
+	})
+	Service("ServiceHosts", func() {
+		Method("MethodHosts", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var BatchDSL = func() {
+	API("BatchAPI", func() {
+		HTTP(func() {
+			Batch()
+		})
+	})
+	Service("ServiceBatch", func() {
+		Method("MethodBatch", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var RadixMuxerDSL = func() {
+	API("RadixAPI", func() {
+		Meta("http:muxer", "radix")
+	})
+	Service("ServiceRadix", func() {
+		Method("MethodRadix", func() {
+			HTTP(func() {
+				GET("/{id}")
+			})
+			Payload(func() {
+				Attribute("id", String)
+			})
+		})
+	})
+}
+
+var FeaturesDSL = func() {
+	API("FeaturesAPI", func() {
+		Meta("http:features", "tracing", "metrics", "unknown", "metrics")
+	})
+	Service("ServiceFeatures", func() {
+		Method("MethodFeatures", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var AdminDSL = func() {
+	Service("ServiceAdmin", func() {
+		Method("Checkout", func() {
+			FeatureFlag("new-checkout")
+			FeatureFlag("beta")
+			HTTP(func() {
+				POST("/checkout")
+			})
+		})
+		Method("Preview", func() {
+			FeatureFlag("beta")
+			HTTP(func() {
+				GET("/preview")
+			})
+		})
+	})
+}
+
+var ServerLongRunningDSL = func() {
+	var Operation = Type("Operation", func() {
+		Attribute("id", String)
+		Attribute("done", Boolean)
+		Required("id", "done")
+	})
+	Service("ServiceLongRunning", func() {
+		Method("MethodLongRunning", func() {
+			Payload(func() {
+				Attribute("p", String)
+				Attribute("q", String)
+				Required("p")
+			})
+			LongRunning(Operation)
+			HTTP(func() {
+				POST("/{p}")
+			})
+		})
+	})
+}
+
+var ServerAcceptRangesDSL = func() {
+	Service("ServiceAcceptRanges", func() {
+		Method("MethodAcceptRanges", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/{*p}")
+				SkipResponseBodyEncodeDecode()
+				AcceptRanges()
+			})
+		})
+	})
+}
+
+var ServerDownloadDSL = func() {
+	Service("ServiceDownload", func() {
+		Method("MethodDownload", func() {
+			Payload(String)
+			HTTP(func() {
+				GET("/{p}")
+				Download("application/pdf", func() {
+					Disposition("inline")
+				})
+			})
+		})
+	})
+}
+
+var ServerAliasDSL = func() {
+	Service("ServiceAlias", func() {
+		Method("MethodAlias", func() {
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			HTTP(func() {
+				GET("/bottles/{id}")
+				Alias("/bottle/:id")
+				Alias("//v1/bottles/{id}", StatusPermanentRedirect)
+			})
+		})
+	})
+}
+
+var ServerProxyDSL = func() {
+	Service("ServiceProxy", func() {
+		Method("MethodProxy", func() {
+			HTTP(func() {
+				GET("/bottles")
+				Proxy("http://legacy:8080/api", func() {
+					Timeout("10s")
+					ProxyRequestHeader("X-Legacy-Client", "goa")
+					ProxyRequestHeader("Cookie", "")
+					ProxyResponseHeader("Server", "")
+				})
+			})
+		})
+	})
+}
+
+var ServerProxyNoOptionDSL = func() {
+	Service("ServiceProxyNoOption", func() {
+		Method("MethodProxyNoOption", func() {
+			HTTP(func() {
+				GET("/bottles")
+				Proxy("http://legacy:8080")
+			})
+		})
+	})
+}
+
+var ServerPushDSL = func() {
+	Service("ServicePush", func() {
+		Method("MethodPush", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				Push("/assets/app.js", "/assets/app.css")
+			})
+		})
+	})
+}
+
+var ServerTimeoutDSL = func() {
+	Service("ServiceTimeout", func() {
+		Method("MethodTimeout", func() {
+			Payload(String)
+			Result(String)
+			Timeout("1500ms")
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var ServerNullableDSL = func() {
+	Service("ServiceNullable", func() {
+		Method("MethodNullable", func() {
+			Payload(func() {
+				Attribute("name", String, func() {
+					Nullable()
+				})
+			})
+			HTTP(func() {
+				PATCH("/")
+			})
+		})
+	})
+}
+
+var ServerLenientFileDSL = func() {
+	Service("ServiceLenientFile", func() {
+		Method("MethodLenient", func() {
+			Meta("validation:lenient")
+			Payload(func() {
+				Attribute("b", Int, func() {
+					Minimum(1)
+				})
+			})
+			HTTP(func() {
+				POST("/")
+				Param("b")
+			})
+		})
+		Method("MethodStrict", func() {
+			Payload(func() {
+				Attribute("b", Int, func() {
+					Minimum(1)
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Param("b")
+			})
+		})
+	})
+}
@@ -273,3 +273,43 @@ func MountServerTrailingSlashRoutingHandler(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("GET", "/trailing/slash/", f)
 }
 `
+
+var ServerAliasConstructorCode = `// New instantiates HTTP handlers for all the ServiceAlias service endpoints
+// using the provided encoder and decoder. The handlers are mounted on the
+// given mux using the HTTP verb and path defined in the design. errhandler is
+// called whenever a response fails to be encoded. formatter is used to format
+// errors returned by the service methods prior to encoding. Both errhandler
+// and formatter are optional and can be nil.
+func New(
+	e *servicealias.Endpoints,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodAlias", "GET", "/bottles/{id}"},
+			{"MethodAlias", "GET", "/bottle/{id}"},
+			{"MethodAlias", "GET", "/v1/bottles/{id}"},
+		},
+		MethodAlias: NewMethodAliasHandler(e.MethodAlias, mux, decoder, encoder, errhandler, formatter),
+	}
+}
+`
+
+var ServerAliasHandlerMounterCode = `// MountMethodAliasHandler configures the mux to serve the "ServiceAlias"
+// service "MethodAlias" endpoint.
+func MountMethodAliasHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/bottles/{id}", f)
+	mux.Handle("GET", "/bottle/{id}", f)
+	mux.Handle("GET", "/v1/bottles/{id}", goahttp.AliasRedirect(mux, "/bottles/{id}", http.StatusPermanentRedirect))
+}
+`
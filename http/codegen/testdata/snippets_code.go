@@ -0,0 +1,52 @@
+package testdata
+
+const SnippetsFileCode = `# Test API HTTP request snippets
+
+## items
+
+Manage items.
+
+### update: ` + "`" + `PUT /items/{id}` + "`" + `
+
+Update an item.
+
+` + "```" + `sh
+curl -X PUT "https://api.example.com/items/item%201?dry=true&tags=a&tags=b" \
+  -H "X-Trace: \$trace" \
+  -H "Authorization: Bearer $TOKEN" \
+  -H "Content-Type: application/json" \
+  -d '{"name":"it'\''s \"new\""}'
+` + "```" + `
+
+` + "```" + `sh
+http PUT "https://api.example.com/items/item%201" \
+  "dry==true" \
+  "tags==a" \
+  "tags==b" \
+  "X-Trace:\$trace" \
+  "Authorization:Bearer $TOKEN" \
+  --raw '{"name":"it'\''s \"new\""}'
+` + "```" + `
+
+### list: ` + "`" + `GET /items` + "`" + `
+
+` + "```" + `sh
+curl "https://api.example.com/items"
+` + "```" + `
+
+` + "```" + `sh
+http GET "https://api.example.com/items"
+` + "```" + `
+`
+
+const SnippetsHTTPieFileCode = `# test HTTP request snippets
+
+## items
+
+### list: ` + "`" + `GET /items` + "`" + `
+
+` + "```" + `sh
+http GET "http://localhost:80/items" \
+  -a "$USERNAME:$PASSWORD"
+` + "```" + `
+`
@@ -0,0 +1,313 @@
+package testdata
+
+const SOAPWSDLCode = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- Code generated by goa, DO NOT EDIT. -->
+<definitions name="Accounts" targetNamespace="urn:test_api:accounts"
+    xmlns="http://schemas.xmlsoap.org/wsdl/"
+    xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/"
+    xmlns:tns="urn:test_api:accounts"
+    xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+  <documentation>Accounts manages the customer accounts.</documentation>
+  <types>
+    <xsd:schema targetNamespace="urn:test_api:accounts" elementFormDefault="qualified">
+      <xsd:complexType name="Address">
+        <xsd:sequence>
+          <xsd:element name="Street" type="xsd:string" minOccurs="0"/>
+          <xsd:element name="City" type="xsd:string"/>
+        </xsd:sequence>
+      </xsd:complexType>
+      <xsd:complexType name="Account">
+        <xsd:sequence>
+          <xsd:element name="ID" type="xsd:long"/>
+          <xsd:element name="Name" type="xsd:string"/>
+          <xsd:element name="Address" type="tns:Address" minOccurs="0"/>
+          <xsd:element name="Tags" type="xsd:string" minOccurs="0" maxOccurs="unbounded"/>
+        </xsd:sequence>
+      </xsd:complexType>
+      <xsd:element name="create">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="AccountName" type="xsd:string"/>
+            <xsd:element name="Address" type="tns:Address" minOccurs="0"/>
+            <xsd:element name="Tags" type="xsd:string" minOccurs="0" maxOccurs="unbounded"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="createResponse">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="ID" type="xsd:long"/>
+            <xsd:element name="Name" type="xsd:string"/>
+            <xsd:element name="Address" type="tns:Address" minOccurs="0"/>
+            <xsd:element name="Tags" type="xsd:string" minOccurs="0" maxOccurs="unbounded"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="show">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="Payload" type="xsd:long"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="showResponse">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="ID" type="xsd:long"/>
+            <xsd:element name="Name" type="xsd:string"/>
+            <xsd:element name="Address" type="tns:Address" minOccurs="0"/>
+            <xsd:element name="Tags" type="xsd:string" minOccurs="0" maxOccurs="unbounded"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="list">
+        <xsd:complexType>
+          <xsd:sequence>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="listResponse">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="Result" type="tns:Account" minOccurs="0" maxOccurs="unbounded"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="count">
+        <xsd:complexType>
+          <xsd:sequence>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="countResponse">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="Result" type="xsd:long"/>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="purge">
+        <xsd:complexType>
+          <xsd:sequence>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+      <xsd:element name="purgeResponse">
+        <xsd:complexType>
+          <xsd:sequence>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+    </xsd:schema>
+  </types>
+  <message name="createRequest">
+    <part name="parameters" element="tns:create"/>
+  </message>
+  <message name="createResponse">
+    <part name="parameters" element="tns:createResponse"/>
+  </message>
+  <message name="showRequest">
+    <part name="parameters" element="tns:show"/>
+  </message>
+  <message name="showResponse">
+    <part name="parameters" element="tns:showResponse"/>
+  </message>
+  <message name="listRequest">
+    <part name="parameters" element="tns:list"/>
+  </message>
+  <message name="listResponse">
+    <part name="parameters" element="tns:listResponse"/>
+  </message>
+  <message name="countRequest">
+    <part name="parameters" element="tns:count"/>
+  </message>
+  <message name="countResponse">
+    <part name="parameters" element="tns:countResponse"/>
+  </message>
+  <message name="purgeRequest">
+    <part name="parameters" element="tns:purge"/>
+  </message>
+  <message name="purgeResponse">
+    <part name="parameters" element="tns:purgeResponse"/>
+  </message>
+  <portType name="AccountsPortType">
+    <operation name="create">
+      <documentation>Create an account &amp; return it.</documentation>
+      <input message="tns:createRequest"/>
+      <output message="tns:createResponse"/>
+    </operation>
+    <operation name="show">
+      <documentation>Show implements show.</documentation>
+      <input message="tns:showRequest"/>
+      <output message="tns:showResponse"/>
+    </operation>
+    <operation name="list">
+      <documentation>List implements list.</documentation>
+      <input message="tns:listRequest"/>
+      <output message="tns:listResponse"/>
+    </operation>
+    <operation name="count">
+      <documentation>Count implements count.</documentation>
+      <input message="tns:countRequest"/>
+      <output message="tns:countResponse"/>
+    </operation>
+    <operation name="purge">
+      <documentation>Purge implements purge.</documentation>
+      <input message="tns:purgeRequest"/>
+      <output message="tns:purgeResponse"/>
+    </operation>
+  </portType>
+  <binding name="AccountsBinding" type="tns:AccountsPortType">
+    <soap:binding style="document" transport="http://schemas.xmlsoap.org/soap/http"/>
+    <operation name="create">
+      <soap:operation soapAction="urn:test_api:accounts#create"/>
+      <input><soap:body use="literal"/></input>
+      <output><soap:body use="literal"/></output>
+    </operation>
+    <operation name="show">
+      <soap:operation soapAction="urn:test_api:accounts#show"/>
+      <input><soap:body use="literal"/></input>
+      <output><soap:body use="literal"/></output>
+    </operation>
+    <operation name="list">
+      <soap:operation soapAction="urn:test_api:accounts#list"/>
+      <input><soap:body use="literal"/></input>
+      <output><soap:body use="literal"/></output>
+    </operation>
+    <operation name="count">
+      <soap:operation soapAction="urn:test_api:accounts#count"/>
+      <input><soap:body use="literal"/></input>
+      <output><soap:body use="literal"/></output>
+    </operation>
+    <operation name="purge">
+      <soap:operation soapAction="urn:test_api:accounts#purge"/>
+      <input><soap:body use="literal"/></input>
+      <output><soap:body use="literal"/></output>
+    </operation>
+  </binding>
+  <service name="Accounts">
+    <port name="AccountsPort" binding="tns:AccountsBinding">
+      <soap:address location="http://localhost:80/soap/accounts"/>
+    </port>
+  </service>
+</definitions>
+`
+
+const SOAPServerCode = `// WSDL is the WSDL document that describes the Accounts SOAP operations.
+//
+//go:embed accounts.wsdl
+var WSDL []byte
+
+// Namespace is the target namespace of the WSDL document.
+const Namespace = "urn:test_api:accounts"
+
+// Path is the path of the SOAP endpoint.
+const Path = "/soap/accounts"
+
+// New returns the SOAP server that exposes the Accounts service
+// endpoints. errhandler is called with the errors that prevent writing the
+// responses.
+func New(e *accounts.Endpoints, errhandler func(context.Context, http.ResponseWriter, error)) *goahttp.SOAPServer {
+	return goahttp.NewSOAPServer("Accounts", Namespace, WSDL, []*goahttp.SOAPOperation{
+		{
+			Name:     "create",
+			Endpoint: e.Create,
+			Decode:   DecodeCreateRequest,
+			Encode:   EncodeCreateResponse,
+		},
+		{
+			Name:     "show",
+			Endpoint: e.Show,
+			Decode:   DecodeShowRequest,
+			Encode:   EncodeShowResponse,
+		},
+		{
+			Name:     "list",
+			Endpoint: e.List,
+			Encode:   EncodeListResponse,
+		},
+		{
+			Name:     "count",
+			Endpoint: e.Count,
+			Encode:   EncodeCountResponse,
+		},
+		{
+			Name:     "purge",
+			Endpoint: e.Purge,
+		},
+	}, errhandler)
+}
+
+// Mount configures the mux to serve the SOAP requests and the WSDL document
+// at Path.
+func Mount(mux goahttp.Muxer, srv *goahttp.SOAPServer) {
+	mux.Handle("GET", Path, srv.ServeHTTP)
+	mux.Handle("POST", Path, srv.ServeHTTP)
+}
+
+// DecodeCreateRequest decodes the create request element into the
+// method payload.
+func DecodeCreateRequest(d *xml.Decoder, start *xml.StartElement) (interface{}, error) {
+	p := &accounts.CreatePayload{}
+	if err := d.DecodeElement(p, start); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// EncodeCreateResponse returns the content of the create response
+// element given the method result.
+func EncodeCreateResponse(v interface{}) interface{} {
+	vres, ok := v.(*accountsviews.Account)
+	if !ok || vres == nil {
+		return struct{}{}
+	}
+	res := accounts.NewAccount(vres)
+	return res
+}
+
+// DecodeShowRequest decodes the show request element into the
+// method payload.
+func DecodeShowRequest(d *xml.Decoder, start *xml.StartElement) (interface{}, error) {
+	var body struct {
+		Payload int64 ` + "`" + `xml:"Payload"` + "`" + `
+	}
+	if err := d.DecodeElement(&body, start); err != nil {
+		return nil, err
+	}
+	return body.Payload, nil
+}
+
+// EncodeShowResponse returns the content of the show response
+// element given the method result.
+func EncodeShowResponse(v interface{}) interface{} {
+	vres, ok := v.(*accountsviews.Account)
+	if !ok || vres == nil {
+		return struct{}{}
+	}
+	res := accounts.NewAccount(vres)
+	return res
+}
+
+// EncodeListResponse returns the content of the list response
+// element given the method result.
+func EncodeListResponse(v interface{}) interface{} {
+	vres, ok := v.(accountsviews.AccountCollection)
+	if !ok {
+		return struct{}{}
+	}
+	res := accounts.NewAccountCollection(vres)
+	return struct {
+		Result accounts.AccountCollection ` + "`" + `xml:"Result"` + "`" + `
+	}{res}
+}
+
+// EncodeCountResponse returns the content of the count response
+// element given the method result.
+func EncodeCountResponse(v interface{}) interface{} {
+	res, _ := v.(int)
+	return struct {
+		Result int ` + "`" + `xml:"Result"` + "`" + `
+	}{res}
+}
+`
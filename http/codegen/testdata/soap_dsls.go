@@ -0,0 +1,72 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var SOAPDSL = func() {
+	var Address = Type("Address", func() {
+		Attribute("street", String)
+		Attribute("city", String)
+		Required("city")
+	})
+	var Account = ResultType("application/vnd.account", func() {
+		Attributes(func() {
+			Attribute("id", Int64, "Account ID")
+			Attribute("name", String)
+			Attribute("address", Address)
+			Attribute("tags", ArrayOf(String))
+			Required("id", "name")
+		})
+	})
+	Service("Accounts", func() {
+		Description("Accounts manages the customer accounts.")
+		Meta("soap:path", "/soap/accounts")
+		Error("not_found")
+		Method("create", func() {
+			Description("Create an account & return it.")
+			Payload(func() {
+				Attribute("name", String, func() {
+					Meta("struct:tag:xml", "AccountName")
+				})
+				Attribute("address", Address)
+				Attribute("tags", ArrayOf(String))
+				Required("name")
+			})
+			Result(Account)
+			HTTP(func() {
+				POST("/accounts")
+			})
+		})
+		Method("show", func() {
+			Payload(Int64)
+			Result(Account)
+			HTTP(func() {
+				GET("/accounts/{id}")
+			})
+		})
+		Method("list", func() {
+			Result(CollectionOf(Account))
+			HTTP(func() {
+				GET("/accounts")
+			})
+		})
+		Method("count", func() {
+			Result(Int)
+			HTTP(func() {
+				GET("/accounts/count")
+			})
+		})
+		Method("purge", func() {
+			HTTP(func() {
+				DELETE("/accounts")
+			})
+		})
+		Method("labels", func() {
+			Result(MapOf(String, String))
+			HTTP(func() {
+				GET("/accounts/labels")
+			})
+		})
+	})
+}
@@ -3324,3 +3324,213 @@ func (s *BidirectionalStreamingUserTypeMapMethodClientStream) Recv() (map[string
 	return res, nil
 }
 `
+
+var StreamRequestBodyServerHandlerInitCode = `// NewStreamRequestBodyMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamRequestBodyService" service
+// "StreamRequestBodyMethod" endpoint.
+func NewStreamRequestBodyMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeStreamRequestBodyMethodRequest(mux, decoder)
+		encodeResponse = EncodeStreamRequestBodyMethodResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamRequestBodyMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamRequestBodyService")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		v := &streamrequestbodyservice.StreamRequestBodyMethodEndpointInput{
+			Stream: &StreamRequestBodyMethodServerStream{
+				ctx:            ctx,
+				w:              w,
+				dec:            goahttp.NewArrayDecoder(r.Body),
+				encodeResponse: encodeResponse,
+			},
+			Payload: payload.(*streamrequestbodyservice.StreamRequestBodyMethodPayload),
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamRequestBodyServerStreamRecvCode = `// Recv reads instances of "streamrequestbodyservice.Request" from the
+// "StreamRequestBodyMethod" endpoint request body.
+func (s *StreamRequestBodyMethodServerStream) Recv() (*streamrequestbodyservice.Request, error) {
+	var (
+		rv   *streamrequestbodyservice.Request
+		body StreamRequestBodyMethodStreamingBody
+		err  error
+	)
+	if err = s.dec.Decode(&body); err != nil {
+		if err == io.EOF {
+			return rv, err
+		}
+		return rv, goa.DecodePayloadError(err.Error())
+	}
+	err = ValidateStreamRequestBodyMethodStreamingBody(&body)
+	if err != nil {
+		return rv, err
+	}
+	return NewStreamRequestBodyMethodStreamingBody(&body), nil
+}
+`
+
+var StreamRequestBodyServerStreamSendCode = `// SendAndClose writes the "StreamRequestBodyMethod" endpoint response with the
+// given "streamrequestbodyservice.Usertype".
+func (s *StreamRequestBodyMethodServerStream) SendAndClose(v *streamrequestbodyservice.Usertype) error {
+	res := streamrequestbodyservice.NewViewedUsertype(v, s.view)
+	return s.encodeResponse(s.ctx, s.w, res)
+}
+`
+
+var StreamRequestBodyServerStreamSetViewCode = `// SetView sets the view to render the streamrequestbodyservice.Usertype type
+// before writing the "StreamRequestBodyMethod" endpoint response.
+func (s *StreamRequestBodyMethodServerStream) SetView(view string) {
+	s.view = view
+}
+`
+
+var StreamRequestBodyNoResultServerHandlerInitCode = `// NewStreamRequestBodyNoResultMethodHandler creates a HTTP handler which loads
+// the HTTP request and calls the "StreamRequestBodyNoResultService" service
+// "StreamRequestBodyNoResultMethod" endpoint.
+func NewStreamRequestBodyNoResultMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		encodeResponse = EncodeStreamRequestBodyNoResultMethodResponse(encoder)
+		encodeError    = goahttp.ErrorEncoder(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamRequestBodyNoResultMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamRequestBodyNoResultService")
+		var err error
+		v := &streamrequestbodynoresultservice.StreamRequestBodyNoResultMethodEndpointInput{
+			Stream: &StreamRequestBodyNoResultMethodServerStream{
+				ctx:            ctx,
+				w:              w,
+				dec:            goahttp.NewArrayDecoder(r.Body),
+				encodeResponse: encodeResponse,
+			},
+		}
+		_, err = endpoint(ctx, v)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, nil); err != nil {
+			errhandler(ctx, w, err)
+		}
+	})
+}
+`
+
+var StreamRequestBodyNoResultServerStreamRecvCode = `// Recv reads instances of "string" from the "StreamRequestBodyNoResultMethod"
+// endpoint request body.
+func (s *StreamRequestBodyNoResultMethodServerStream) Recv() (string, error) {
+	var (
+		rv   string
+		body string
+		err  error
+	)
+	if err = s.dec.Decode(&body); err != nil {
+		if err == io.EOF {
+			return rv, err
+		}
+		return rv, goa.DecodePayloadError(err.Error())
+	}
+	return body, nil
+}
+`
+
+var StreamRequestBodyNoResultServerStreamCloseCode = `// Close terminates the "StreamRequestBodyNoResultMethod" endpoint request body
+// stream.
+func (s *StreamRequestBodyNoResultMethodServerStream) Close() error {
+	return nil
+}
+`
+
+var StreamRequestBodyClientEndpointCode = `// StreamRequestBodyMethod returns an endpoint that makes HTTP requests to the
+// StreamRequestBodyService service StreamRequestBodyMethod server.
+func (c *Client) StreamRequestBodyMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamRequestBodyMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamRequestBodyMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		stream := goahttp.NewRequestBodyStream(c.StreamRequestBodyMethodDoer, req)
+		return &StreamRequestBodyMethodClientStream{stream: stream, decodeResponse: decodeResponse}, nil
+	}
+}
+`
+
+var StreamRequestBodyClientStreamSendCode = `// Send streams instances of "streamrequestbodyservice.Request" to the
+// "StreamRequestBodyMethod" endpoint request body.
+func (s *StreamRequestBodyMethodClientStream) Send(v *streamrequestbodyservice.Request) error {
+	return s.stream.Send(NewStreamRequestBodyMethodStreamingBody(v))
+}
+`
+
+var StreamRequestBodyClientStreamRecvCode = `// CloseAndRecv terminates the "StreamRequestBodyMethod" endpoint request body
+// and reads the "streamrequestbodyservice.Usertype" from the response.
+func (s *StreamRequestBodyMethodClientStream) CloseAndRecv() (*streamrequestbodyservice.Usertype, error) {
+	var rv *streamrequestbodyservice.Usertype
+	resp, err := s.stream.Close()
+	if err != nil {
+		return rv, goahttp.ErrRequestError("StreamRequestBodyService", "StreamRequestBodyMethod", err)
+	}
+	res, err := s.decodeResponse(resp)
+	if err != nil {
+		return rv, err
+	}
+	return res.(*streamrequestbodyservice.Usertype), nil
+}
+`
+
+var StreamRequestBodyNoResultClientStreamSendCode = `// Send streams instances of "string" to the "StreamRequestBodyNoResultMethod"
+// endpoint request body.
+func (s *StreamRequestBodyNoResultMethodClientStream) Send(v string) error {
+	return s.stream.Send(v)
+}
+`
+
+var StreamRequestBodyNoResultClientStreamCloseCode = `// Close terminates the "StreamRequestBodyNoResultMethod" endpoint request body
+// stream.
+func (s *StreamRequestBodyNoResultMethodClientStream) Close() error {
+	resp, err := s.stream.Close()
+	if err != nil {
+		return goahttp.ErrRequestError("StreamRequestBodyNoResultService", "StreamRequestBodyNoResultMethod", err)
+	}
+	_, err = s.decodeResponse(resp)
+	return err
+}
+`
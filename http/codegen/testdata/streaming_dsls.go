@@ -754,3 +754,46 @@ var BidirectionalStreamingResultCollectionWithExplicitViewDSL = func() {
 		})
 	})
 }
+
+var StreamRequestBodyDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("a", String, func() {
+			MinLength(2)
+		})
+		Required("a")
+	})
+	var ResultT = ResultType("UserType", func() {
+		Attributes(func() {
+			Attribute("a", String)
+			Attribute("b", Int)
+		})
+		View("tiny", func() {
+			Attribute("a")
+		})
+	})
+	Service("StreamRequestBodyService", func() {
+		Method("StreamRequestBodyMethod", func() {
+			Payload(func() {
+				Attribute("p", String)
+			})
+			StreamingPayload(Request)
+			Result(ResultT)
+			HTTP(func() {
+				POST("/{p}")
+				StreamRequestBody()
+			})
+		})
+	})
+}
+
+var StreamRequestBodyNoResultDSL = func() {
+	Service("StreamRequestBodyNoResultService", func() {
+		Method("StreamRequestBodyNoResultMethod", func() {
+			StreamingPayload(String)
+			HTTP(func() {
+				PUT("/")
+				StreamRequestBody()
+			})
+		})
+	})
+}
@@ -0,0 +1,493 @@
+package testdata
+
+const TerraformProviderCode = `type (
+	// Provider is the test_api Terraform provider.
+	Provider struct {
+		version string
+	}
+
+	// Clients holds the service clients used by the provider resources.
+	Clients struct {
+		Widgets *widgets.Client
+	}
+
+	// providerModel describes the provider configuration.
+	providerModel struct {
+		Endpoint types.String ` + "`" + `tfsdk:"endpoint"` + "`" + `
+	}
+)
+
+// New returns a function that creates the provider with the given version
+// suitable for providerserver.NewProtocol6.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &Provider{version: version}
+	}
+}
+
+// Metadata returns the provider type name.
+func (p *Provider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "test_api"
+	resp.Version = p.version
+}
+
+// Schema defines the provider configuration.
+func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "URL of the API, e.g. http://localhost:8080.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// Configure creates the service clients used by the resources.
+func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config providerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	u, err := url.Parse(config.Endpoint.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid endpoint", err.Error())
+		return
+	}
+	var (
+		doer    = http.DefaultClient
+		clients = &Clients{}
+	)
+	{
+		hc := widgetsc.NewClient(u.Scheme, u.Host, doer, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+		clients.Widgets = widgets.NewClient(hc.Create(), hc.Show(), hc.Rename(), hc.Delete())
+	}
+	resp.ResourceData = clients
+}
+
+// Resources returns the provider resources.
+func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewWidgetResource,
+	}
+}
+
+// DataSources returns the provider data sources.
+func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+`
+
+const TerraformResourceCode = `type (
+	// widgetResource implements the widget resource using the
+	// widgets service client.
+	widgetResource struct {
+		client *widgets.Client
+	}
+
+	// widgetResourceModel describes the widget resource data.
+	widgetResourceModel struct {
+		Name      types.String ` + "`" + `tfsdk:"name"` + "`" + `
+		Size      types.Int64  ` + "`" + `tfsdk:"size"` + "`" + `
+		Enabled   types.Bool   ` + "`" + `tfsdk:"enabled"` + "`" + `
+		ID        types.String ` + "`" + `tfsdk:"id"` + "`" + `
+		CreatedAt types.String ` + "`" + `tfsdk:"created_at"` + "`" + `
+	}
+)
+
+// NewWidgetResource returns the widget resource.
+func NewWidgetResource() resource.Resource {
+	return &widgetResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *widgetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_widget"
+}
+
+// Schema defines the resource attributes.
+func (r *widgetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Widgets are infrastructure objects.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"size": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Widget ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure retrieves the service client from the provider.
+func (r *widgetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clients, ok := req.ProviderData.(*Clients)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *Clients, got %T", req.ProviderData))
+		return
+	}
+	r.client = clients.Widgets
+}
+
+// Create creates the resource and sets the initial state.
+func (r *widgetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var m widgetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &widgets.CreatePayload{}
+	p.Name = m.Name.ValueString()
+	if !m.Size.IsNull() && !m.Size.IsUnknown() {
+		v := int32(m.Size.ValueInt64())
+		p.Size = &v
+	}
+	p.Enabled = true
+	if !m.Enabled.IsNull() && !m.Enabled.IsUnknown() {
+		p.Enabled = m.Enabled.ValueBool()
+	}
+	res, err := r.client.Create(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating widget", err.Error())
+		return
+	}
+	m.ID = types.StringValue(res.ID)
+	m.Name = types.StringValue(res.Name)
+	if res.Size != nil {
+		m.Size = types.Int64Value(int64(*res.Size))
+	} else {
+		m.Size = types.Int64Null()
+	}
+	if res.Enabled != nil {
+		m.Enabled = types.BoolValue(*res.Enabled)
+	} else {
+		m.Enabled = types.BoolNull()
+	}
+	if res.CreatedAt != nil {
+		m.CreatedAt = types.StringValue(*res.CreatedAt)
+	} else {
+		m.CreatedAt = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Read refreshes the state with the current resource data.
+func (r *widgetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var m widgetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &widgets.ShowPayload{}
+	p.ID = m.ID.ValueString()
+	res, err := r.client.Show(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading widget", err.Error())
+		return
+	}
+	m.ID = types.StringValue(res.ID)
+	m.Name = types.StringValue(res.Name)
+	if res.Size != nil {
+		m.Size = types.Int64Value(int64(*res.Size))
+	} else {
+		m.Size = types.Int64Null()
+	}
+	if res.Enabled != nil {
+		m.Enabled = types.BoolValue(*res.Enabled)
+	} else {
+		m.Enabled = types.BoolNull()
+	}
+	if res.CreatedAt != nil {
+		m.CreatedAt = types.StringValue(*res.CreatedAt)
+	} else {
+		m.CreatedAt = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Update updates the resource and sets the updated state.
+func (r *widgetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var m widgetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &widgets.RenamePayload{}
+	p.ID = m.ID.ValueString()
+	p.Name = m.Name.ValueString()
+	res, err := r.client.Rename(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating widget", err.Error())
+		return
+	}
+	m.ID = types.StringValue(res.ID)
+	m.Name = types.StringValue(res.Name)
+	if res.Size != nil {
+		m.Size = types.Int64Value(int64(*res.Size))
+	} else {
+		m.Size = types.Int64Null()
+	}
+	if res.Enabled != nil {
+		m.Enabled = types.BoolValue(*res.Enabled)
+	} else {
+		m.Enabled = types.BoolNull()
+	}
+	if res.CreatedAt != nil {
+		m.CreatedAt = types.StringValue(*res.CreatedAt)
+	} else {
+		m.CreatedAt = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Delete deletes the resource.
+func (r *widgetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var m widgetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &widgets.DeletePayload{}
+	p.ID = m.ID.ValueString()
+	err := r.client.Delete(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting widget", err.Error())
+		return
+	}
+}
+`
+
+const TerraformSecuredProviderCode = `type (
+	// Provider is the test_api Terraform provider.
+	Provider struct {
+		version string
+	}
+
+	// Clients holds the service clients used by the provider resources.
+	Clients struct {
+		Gadgets *gadgets.Client
+		Token   string
+	}
+
+	// providerModel describes the provider configuration.
+	providerModel struct {
+		Endpoint types.String ` + "`" + `tfsdk:"endpoint"` + "`" + `
+		Token    types.String ` + "`" + `tfsdk:"token"` + "`" + `
+	}
+)
+
+// New returns a function that creates the provider with the given version
+// suitable for providerserver.NewProtocol6.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &Provider{version: version}
+	}
+}
+
+// Metadata returns the provider type name.
+func (p *Provider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "test_api"
+	resp.Version = p.version
+}
+
+// Schema defines the provider configuration.
+func (p *Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "URL of the API, e.g. http://localhost:8080.",
+				Required:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "Token or API key used to authenticate the requests, defaults to the value of the TEST_API_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+// Configure creates the service clients used by the resources.
+func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config providerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	u, err := url.Parse(config.Endpoint.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid endpoint", err.Error())
+		return
+	}
+	var (
+		doer    = http.DefaultClient
+		clients = &Clients{}
+	)
+	clients.Token = os.Getenv("TEST_API_TOKEN")
+	if !config.Token.IsNull() {
+		clients.Token = config.Token.ValueString()
+	}
+	{
+		hc := gadgetsc.NewClient(u.Scheme, u.Host, doer, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+		clients.Gadgets = gadgets.NewClient(hc.Create(), hc.Show(), hc.Delete())
+	}
+	resp.ResourceData = clients
+}
+
+// Resources returns the provider resources.
+func (p *Provider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewGadgetResource,
+	}
+}
+
+// DataSources returns the provider data sources.
+func (p *Provider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+`
+
+const TerraformSecuredResourceCode = `type (
+	// gadgetResource implements the gadget resource using the
+	// gadgets service client.
+	gadgetResource struct {
+		client *gadgets.Client
+		token  string
+	}
+
+	// gadgetResourceModel describes the gadget resource data.
+	gadgetResourceModel struct {
+		Name types.String ` + "`" + `tfsdk:"name"` + "`" + `
+		ID   types.String ` + "`" + `tfsdk:"id"` + "`" + `
+	}
+)
+
+// NewGadgetResource returns the gadget resource.
+func NewGadgetResource() resource.Resource {
+	return &gadgetResource{}
+}
+
+// Metadata returns the resource type name.
+func (r *gadgetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gadget"
+}
+
+// Schema defines the resource attributes.
+func (r *gadgetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure retrieves the service client from the provider.
+func (r *gadgetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clients, ok := req.ProviderData.(*Clients)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *Clients, got %T", req.ProviderData))
+		return
+	}
+	r.client = clients.Gadgets
+	r.token = clients.Token
+}
+
+// Create creates the resource and sets the initial state.
+func (r *gadgetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var m gadgetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &gadgets.CreatePayload{}
+	p.Name = m.Name.ValueString()
+	if r.token != "" {
+		v := r.token
+		p.Token = &v
+	}
+	res, err := r.client.Create(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating gadget", err.Error())
+		return
+	}
+	m.ID = types.StringValue(res.ID)
+	m.Name = types.StringValue(res.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Read refreshes the state with the current resource data.
+func (r *gadgetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var m gadgetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &gadgets.ShowPayload{}
+	p.ID = m.ID.ValueString()
+	p.Token = r.token
+	res, err := r.client.Show(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading gadget", err.Error())
+		return
+	}
+	m.ID = types.StringValue(res.ID)
+	m.Name = types.StringValue(res.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &m)...)
+}
+
+// Update updates the resource and sets the updated state.
+func (r *gadgetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Update not supported", "the gadget resource cannot be updated")
+}
+
+// Delete deletes the resource.
+func (r *gadgetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var m gadgetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &m)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	p := &gadgets.DeletePayload{}
+	p.ID = m.ID.ValueString()
+	if r.token != "" {
+		v := r.token
+		p.Token = &v
+	}
+	err := r.client.Delete(ctx, p)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting gadget", err.Error())
+		return
+	}
+}
+`
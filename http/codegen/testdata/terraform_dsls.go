@@ -0,0 +1,115 @@
+package testdata
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var TerraformResourceDSL = func() {
+	var Widget = ResultType("application/vnd.widget", func() {
+		Attributes(func() {
+			Attribute("id", String, "Widget ID")
+			Attribute("name", String, "Widget name")
+			Attribute("size", Int32)
+			Attribute("enabled", Boolean)
+			Attribute("created_at", String)
+			Attribute("tags", ArrayOf(String))
+			Required("id", "name")
+		})
+	})
+	Service("Widgets", func() {
+		Description("Widgets are infrastructure objects.")
+		Meta("terraform:resource", "widget")
+		Method("create", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("size", Int32)
+				Attribute("enabled", Boolean, func() {
+					Default(true)
+				})
+				Required("name")
+			})
+			Result(Widget)
+			HTTP(func() {
+				POST("/widgets")
+			})
+		})
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			Result(Widget)
+			HTTP(func() {
+				GET("/widgets/{id}")
+			})
+		})
+		Method("rename", func() {
+			Meta("terraform:operation", "update")
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+				Required("id", "name")
+			})
+			Result(Widget)
+			HTTP(func() {
+				PUT("/widgets/{id}")
+			})
+		})
+		Method("delete", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			HTTP(func() {
+				DELETE("/widgets/{id}")
+			})
+		})
+	})
+}
+
+var TerraformSecuredResourceDSL = func() {
+	var JWTAuth = JWTSecurity("jwt")
+	var Gadget = ResultType("application/vnd.gadget", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+			Required("id", "name")
+		})
+	})
+	Service("Gadgets", func() {
+		Meta("terraform:resource", "gadget")
+		Security(JWTAuth)
+		Method("create", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("name", String)
+				Required("name")
+			})
+			Result(Gadget)
+			HTTP(func() {
+				POST("/gadgets")
+			})
+		})
+		Method("show", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+				Required("token", "id")
+			})
+			Result(Gadget)
+			HTTP(func() {
+				GET("/gadgets/{id}")
+			})
+		})
+		Method("delete", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+				Required("id")
+			})
+			HTTP(func() {
+				DELETE("/gadgets/{id}")
+			})
+		})
+	})
+}
@@ -12,7 +12,8 @@ import (
 // It differs from the function defined in the codegen package in the following
 // ways:
 //
-//    - It defines marshaler tags on each fields using the HTTP element names.
+//    - It defines marshaler tags on each fields using the HTTP element names
+//      or the attribute wire names.
 //
 //    - It produced fields with pointers even if the corresponding attribute is
 //      required when ptr is true so that the generated code may validate
@@ -82,6 +83,9 @@ func goTypeDef(scope *codegen.NameScope, att *expr.AttributeExpr, ptr, useDefaul
 						optional = !ma.IsRequired(name)
 					}
 				}
+				if elem == name {
+					elem = mat.WireName(name)
+				}
 				tags = attributeTags(mat, at, elem, optional)
 			}
 			ss = append(ss, fmt.Sprintf("\t%s%s %s%s", desc, fn, tdef, tags))
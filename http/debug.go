@@ -0,0 +1,89 @@
+package http
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+)
+
+// debugConfig holds the configuration of the debug endpoints.
+type debugConfig struct {
+	prefix    string
+	authorize func(*http.Request) error
+}
+
+// EnableDebug returns a service option that mounts the following debug
+// endpoints under prefix, e.g. "/debug":
+//
+//     * <prefix>/pprof/ serves the runtime profiles of package net/http/pprof
+//     * <prefix>/vars serves the variables published with package expvar
+//     * <prefix>/routes lists the routes mounted with Mount as JSON including
+//       the names of the services and methods that serve them, see Routes
+//
+// authorize is called with each request made to the debug endpoints. The
+// endpoints respond with 403 Forbidden, or the status code returned by the
+// error StatusCode method if it implements Statuser, when authorize returns an
+// error. The endpoints expose sensitive information and should not be
+// reachable without authorization in production, authorize may only be nil
+// when the server listens on a private network.
+//
+//    httpSvc := goahttp.NewService(goahttp.EnableDebug("/debug", func(r *http.Request) error {
+//        if r.Header.Get("Authorization") != "Bearer "+debugToken {
+//            return errors.New("unauthorized")
+//        }
+//        return nil
+//    }))
+//
+func EnableDebug(prefix string, authorize func(*http.Request) error) ServiceOption {
+	return func(s *Service) {
+		s.debug = &debugConfig{prefix: strings.TrimSuffix(prefix, "/"), authorize: authorize}
+	}
+}
+
+// mountDebug mounts the debug endpoints on the service muxer.
+func (s *Service) mountDebug() {
+	prefix := s.debug.prefix
+	pprofHandler := s.debugHandler(func(w http.ResponseWriter, r *http.Request) {
+		switch name := s.Mux.Vars(r)["profile"]; name {
+		case "":
+			pprof.Index(w, r)
+		case "cmdline":
+			pprof.Cmdline(w, r)
+		case "profile":
+			pprof.Profile(w, r)
+		case "symbol":
+			pprof.Symbol(w, r)
+		case "trace":
+			pprof.Trace(w, r)
+		default:
+			pprof.Handler(name).ServeHTTP(w, r)
+		}
+	})
+	// The default muxer catch-all wildcards do not match empty values.
+	s.Mux.Handle("GET", prefix+"/pprof/", pprofHandler)
+	s.Mux.Handle("GET", prefix+"/pprof/{*profile}", pprofHandler)
+	s.Mux.Handle("POST", prefix+"/pprof/{*profile}", pprofHandler)
+	s.Mux.Handle("GET", prefix+"/vars", s.debugHandler(expvar.Handler().ServeHTTP))
+	s.Mux.Handle("GET", prefix+"/routes", s.debugHandler(s.RoutesHandler()))
+}
+
+// debugHandler wraps h so that it is only called if the request is
+// authorized.
+func (s *Service) debugHandler(h http.HandlerFunc) http.HandlerFunc {
+	authorize := s.debug.authorize
+	if authorize == nil {
+		return h
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := authorize(r); err != nil {
+			status := http.StatusForbidden
+			if st, ok := err.(Statuser); ok {
+				status = st.StatusCode()
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		h(w, r)
+	}
+}
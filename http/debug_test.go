@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type (
+	debugMountPoint struct {
+		Method  string
+		Verb    string
+		Pattern string
+	}
+
+	debugServer struct {
+		testServer
+		Mounts []*debugMountPoint
+	}
+)
+
+func (s *debugServer) Service() string { return "ping" }
+
+func TestEnableDebug(t *testing.T) {
+	authorize := func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "secret" {
+			return errors.New("unauthorized")
+		}
+		return nil
+	}
+	for _, muxer := range []Muxer{NewMuxer(), NewRadixMuxer()} {
+		s := NewService(WithMuxer(muxer), EnableDebug("/debug/", authorize))
+		s.Mount(&debugServer{Mounts: []*debugMountPoint{{"Ping", "GET", "/ping"}}})
+		cases := []struct {
+			Name     string
+			Method   string
+			Path     string
+			Auth     string
+			Status   int
+			Contains string
+		}{
+			{"routes", "GET", "/debug/routes", "secret", http.StatusOK, `[{"service":"ping","method":"Ping","verb":"GET","pattern":"/ping"}]`},
+			{"vars", "GET", "/debug/vars", "secret", http.StatusOK, `"memstats"`},
+			{"pprof-index", "GET", "/debug/pprof/", "secret", http.StatusOK, "goroutine"},
+			{"pprof-profile", "GET", "/debug/pprof/goroutine?debug=1", "secret", http.StatusOK, "goroutine profile"},
+			{"pprof-cmdline", "GET", "/debug/pprof/cmdline", "secret", http.StatusOK, ""},
+			{"unauthorized", "GET", "/debug/routes", "", http.StatusForbidden, "unauthorized"},
+			{"unauthorized-pprof", "GET", "/debug/pprof/", "wrong", http.StatusForbidden, "unauthorized"},
+			{"service", "GET", "/ping", "", http.StatusOK, "pong"},
+		}
+		for _, c := range cases {
+			t.Run(c.Name, func(t *testing.T) {
+				r := httptest.NewRequest(c.Method, c.Path, nil)
+				if c.Auth != "" {
+					r.Header.Set("Authorization", c.Auth)
+				}
+				w := httptest.NewRecorder()
+				s.Handler().ServeHTTP(w, r)
+				if w.Code != c.Status {
+					t.Errorf("got status %d, expected %d", w.Code, c.Status)
+				}
+				if !strings.Contains(w.Body.String(), c.Contains) {
+					t.Errorf("got body %q, expected it to contain %q", w.Body.String(), c.Contains)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// SetDownloadHeaders sets the Content-Type, Content-Disposition and, when the
+// size of body can be determined, the Content-Length headers of the response
+// used to stream body. The file name set in the Content-Disposition header is
+// the base name returned by the Name method of body if it implements one (e.g.
+// *os.File). The size is computed with the Stat or Len methods of body or by
+// seeking if body implements io.Seeker. The generated servers call
+// SetDownloadHeaders for the endpoints that use the Download DSL before
+// writing the result headers so that the headers mapped from the result
+// attributes take precedence.
+func SetDownloadHeaders(w http.ResponseWriter, body io.Reader, contentType, disposition string) {
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	params := map[string]string{}
+	if n, ok := body.(interface{ Name() string }); ok {
+		if name := filepath.Base(n.Name()); name != "." && name != string(filepath.Separator) {
+			params["filename"] = name
+		}
+	}
+	if cd := mime.FormatMediaType(disposition, params); cd != "" {
+		h.Set("Content-Disposition", cd)
+	} else {
+		h.Set("Content-Disposition", disposition)
+	}
+	if size := bodySize(body); size >= 0 {
+		h.Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+}
+
+// bodySize returns the number of bytes left to read from body, -1 if unknown.
+func bodySize(body io.Reader) int64 {
+	switch b := body.(type) {
+	case interface{ Stat() (os.FileInfo, error) }:
+		fi, err := b.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return -1
+		}
+		if s, ok := body.(io.Seeker); ok {
+			if cur, err := s.Seek(0, io.SeekCurrent); err == nil {
+				return fi.Size() - cur
+			}
+		}
+		return fi.Size()
+	case interface{ Len() int }:
+		return int64(b.Len())
+	case io.Seeker:
+		cur, err := b.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		end, err := b.Seek(0, io.SeekEnd)
+		if _, err2 := b.Seek(cur, io.SeekStart); err != nil || err2 != nil {
+			return -1
+		}
+		return end - cur
+	}
+	return -1
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetDownloadHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cases := map[string]struct {
+		body        io.Reader
+		disposition string
+		length      string
+	}{
+		"file":      {f, `attachment; filename=report.pdf`, "8"},
+		"reader":    {bytes.NewReader([]byte("abc")), "attachment", "3"},
+		"no length": {io.MultiReader(strings.NewReader("abc")), "attachment", ""},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SetDownloadHeaders(w, c.body, "application/pdf", "attachment")
+			if ct := w.Header().Get("Content-Type"); ct != "application/pdf" {
+				t.Errorf("got Content-Type %q, expected application/pdf", ct)
+			}
+			if cd := w.Header().Get("Content-Disposition"); cd != c.disposition {
+				t.Errorf("got Content-Disposition %q, expected %q", cd, c.disposition)
+			}
+			if cl := w.Header().Get("Content-Length"); cl != c.length {
+				t.Errorf("got Content-Length %q, expected %q", cl, c.length)
+			}
+		})
+	}
+}
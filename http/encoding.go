@@ -6,11 +6,17 @@ import (
 	"encoding/gob"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"mime"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 const (
@@ -23,6 +29,19 @@ const (
 	// response Content-Type header when explicitly set in the DSL. The value
 	// may be used by encoders to set the header appropriately.
 	ContentTypeKey
+
+	// unmaskKey is the context key used to disable the masking of
+	// sensitive attributes, see Unmask.
+	unmaskKey
+
+	// unknownFieldsKey is the context key used to store the handler of
+	// unknown request body fields, see WithUnknownFieldsHandler.
+	unknownFieldsKey
+
+	// lenientValidationKey is the context key used to store the handler
+	// of the validation errors downgraded to warnings, see
+	// WithLenientValidation.
+	lenientValidationKey
 )
 
 type (
@@ -54,6 +73,8 @@ type (
 //     * application/json using package encoding/json
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
+//     * application/yaml using package gopkg.in/yaml.v3, see NewYAMLDecoder
+//     * application/cbor, see NewCBORDecoder
 //     * text/html and text/plain for strings
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
@@ -80,10 +101,45 @@ func RequestDecoder(r *http.Request) Decoder {
 	case "text/html", "text/plain":
 		return newTextDecoder(r.Body, contentType)
 	default:
+		if isYAML(contentType) {
+			return NewYAMLDecoder(r.Body)
+		}
+		if isCBOR(contentType) {
+			return NewCBORDecoder(r.Body)
+		}
 		return json.NewDecoder(r.Body)
 	}
 }
 
+// CheckContentType returns an error if the Content-Type header of a request
+// that has a body does not match one of the given mime types. Mime types may
+// use a wildcard subtype, e.g. "text/*". Requests without a Content-Type
+// header are handled as JSON requests consistently with RequestDecoder. The
+// generated server code calls CheckContentType with the mime types listed with
+// Consumes in the design before decoding the request body, the error results
+// in a 415 Unsupported Media Type response.
+func CheckContentType(r *http.Request, mimeTypes ...string) error {
+	if len(mimeTypes) == 0 || r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	} else if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	for _, mt := range mimeTypes {
+		if strings.EqualFold(mt, contentType) {
+			return nil
+		}
+		if strings.HasSuffix(mt, "/*") && len(contentType) > len(mt)-1 &&
+			strings.EqualFold(mt[:len(mt)-1], contentType[:len(mt)-1]) {
+			return nil
+		}
+	}
+	return goa.UnsupportedMediaTypeError(contentType, mimeTypes)
+}
+
 // ResponseEncoder returns a HTTP response encoder leveraging the mime type
 // set in the context under the AcceptTypeKey or the ContentTypeKey if any.
 // The encoder supports the following mime types:
@@ -91,17 +147,47 @@ func RequestDecoder(r *http.Request) Decoder {
 //     * application/json using package encoding/json
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
+//     * application/yaml using package gopkg.in/yaml.v3, see NewYAMLEncoder
+//     * application/cbor, see NewCBOREncoder
 //     * text/html and text/plain for strings
 //
 // ResponseEncoder defaults to the JSON encoder if the context AcceptTypeKey or
 // ContentTypeKey value does not match any of the supported mime types or is
-// missing altogether.
+// missing altogether. The JSON encoder is a *json.Encoder. The returned
+// encoder records the time spent encoding if ctx was created with
+// WithResponseStats.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
+	return MeasureEncoder(ctx, responseEncoder(ctx, w, newJSONEncoder))
+}
+
+// StreamingResponseEncoder is a response encoder that behaves like
+// ResponseEncoder except that the JSON encoder writes slices and arrays to
+// the response in chunks flushed as their elements are encoded. This bounds
+// the memory used to encode large collections. The trade-off is that the
+// response status and the beginning of the body may already have been sent
+// when an element fails to encode in which case the client receives a
+// truncated body instead of an error response. StreamingResponseEncoder may
+// be given to the generated server constructor in place of ResponseEncoder:
+//
+//    server := svcsvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.StreamingResponseEncoder, nil, nil)
+//
+func StreamingResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
+	return MeasureEncoder(ctx, responseEncoder(ctx, w, newStreamingJSONEncoder))
+}
+
+// newJSONEncoder returns the JSON encoder used by ResponseEncoder.
+func newJSONEncoder(w io.Writer) Encoder {
+	return json.NewEncoder(w)
+}
+
+// responseEncoder implements ResponseEncoder and StreamingResponseEncoder
+// using newJSON to create JSON encoders.
+func responseEncoder(ctx context.Context, w http.ResponseWriter, newJSON func(io.Writer) Encoder) Encoder {
 	negotiate := func(a string) (Encoder, string) {
 		switch a {
 		case "", "application/json":
 			// default to JSON
-			return json.NewEncoder(w), "application/json"
+			return newJSON(w), "application/json"
 		case "application/xml":
 			return xml.NewEncoder(w), "application/xml"
 		case "application/gob":
@@ -109,6 +195,12 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 		case "text/html", "text/plain":
 			return newTextEncoder(w, a), a
 		}
+		if isYAML(a) {
+			return NewYAMLEncoder(w), a
+		}
+		if isCBOR(a) {
+			return NewCBOREncoder(w), a
+		}
 		return nil, ""
 	}
 	var accept string
@@ -135,16 +227,20 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 			if mt, _, err = mime.ParseMediaType(ct); err == nil {
 				switch {
 				case mt == "application/json" || strings.HasSuffix(mt, "+json"):
-					enc = json.NewEncoder(w)
+					enc = newJSON(w)
 				case mt == "application/xml" || strings.HasSuffix(mt, "+xml"):
 					enc = xml.NewEncoder(w)
 				case mt == "application/gob" || strings.HasSuffix(mt, "+gob"):
 					enc = gob.NewEncoder(w)
+				case isYAML(mt):
+					enc = NewYAMLEncoder(w)
+				case isCBOR(mt):
+					enc = NewCBOREncoder(w)
 				case mt == "text/html" || mt == "text/plain" ||
 					strings.HasSuffix(mt, "+html") || strings.HasSuffix(mt, "+txt"):
 					enc = newTextEncoder(w, mt)
 				default:
-					enc = json.NewEncoder(w)
+					enc = newJSON(w)
 				}
 			}
 			SetContentType(w, mt)
@@ -167,14 +263,25 @@ func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
 }
 
 // RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// The encoder uses package encoding/json unless the request "Content-Type"
+// header is set to a YAML or CBOR mime type in which case it uses
+// NewYAMLEncoder or NewCBOREncoder respectively.
 func RequestEncoder(r *http.Request) Encoder {
 	const k = "Content-Type"
-	if h := r.Header.Get(k); h == "" {
+	h := r.Header.Get(k)
+	if h == "" {
 		r.Header.Set(k, "application/json")
 	}
 	var buf bytes.Buffer
 	r.Body = io.NopCloser(&buf)
+	if mt, _, err := mime.ParseMediaType(h); err == nil {
+		switch {
+		case isYAML(mt):
+			return NewYAMLEncoder(&buf)
+		case isCBOR(mt):
+			return NewCBOREncoder(&buf)
+		}
+	}
 	return json.NewEncoder(&buf)
 }
 
@@ -184,6 +291,8 @@ func RequestEncoder(r *http.Request) Encoder {
 //   * application/json using package encoding/json (default)
 //   * application/xml using package encoding/xml
 //   * application/gob using package encoding/gob
+//   * application/yaml using package gopkg.in/yaml.v3, see NewYAMLDecoder
+//   * application/cbor, see NewCBORDecoder
 //   * text/html and text/plain for strings
 //
 func ResponseDecoder(resp *http.Response) Decoder {
@@ -201,6 +310,10 @@ func ResponseDecoder(resp *http.Response) Decoder {
 		return xml.NewDecoder(resp.Body)
 	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
 		return gob.NewDecoder(resp.Body)
+	case isYAML(ct):
+		return NewYAMLDecoder(resp.Body)
+	case isCBOR(ct):
+		return NewCBORDecoder(resp.Body)
 	case ct == "text/html" || ct == "text/plain" ||
 		strings.HasSuffix(ct, "+html") || strings.HasSuffix(ct, "+txt"):
 		return newTextDecoder(resp.Body, ct)
@@ -216,13 +329,25 @@ func ResponseDecoder(resp *http.Response) Decoder {
 // provided encoder. If the error is not a goa ServiceError struct then it is
 // encoded as a permanent internal server error. This behavior as well as the
 // shape of the response can be overridden by providing a non-nil formatter.
+// The response is wrapped in an envelope if the context was created with
+// WithEnvelope, see EnvelopeEncoder. The Retry-After response header is set if
+// the error wraps an error that implements a RetryAfter() time.Duration method
+// such as the errors returned by the middleware.Queue endpoint middleware.
 func ErrorEncoder(encoder func(context.Context, http.ResponseWriter) Encoder, formatter func(err error) Statuser) func(context.Context, http.ResponseWriter, error) error {
 	return func(ctx context.Context, w http.ResponseWriter, err error) error {
 		enc := encoder(ctx, w)
+		if _, ok := ctx.Value(envelopeKey).(*envelope); ok {
+			enc = EnvelopeEncoder(ctx, enc, "")
+		}
 		if formatter == nil {
 			formatter = NewErrorResponse
 		}
 		resp := formatter(err)
+		var ra interface{ RetryAfter() time.Duration }
+		if errors.As(err, &ra) {
+			secs := int64(math.Ceil(ra.RetryAfter().Seconds()))
+			w.Header().Set("Retry-After", strconv.FormatInt(secs, 10))
+		}
 		w.WriteHeader(resp.StatusCode())
 		return enc.Encode(resp)
 	}
@@ -307,3 +432,27 @@ func (e *textDecoder) Decode(v interface{}) error {
 	}
 	return nil
 }
+
+// negotiateContentType returns the first content type given in the response
+// content type set in the design or else in the request Accept header that is
+// one of the given content types, an empty string if there is none.
+func negotiateContentType(ctx context.Context, contentTypes ...string) string {
+	var accept string
+	if ct, ok := ctx.Value(ContentTypeKey).(string); ok && ct != "" {
+		accept = ct
+	} else if a, ok := ctx.Value(AcceptTypeKey).(string); ok {
+		accept = a
+	}
+	for _, a := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(a))
+		if err != nil {
+			continue
+		}
+		for _, ct := range contentTypes {
+			if mt == ct {
+				return ct
+			}
+		}
+	}
+	return ""
+}
@@ -7,6 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
 )
 
 var (
@@ -18,16 +21,20 @@ func TestRequestEncoder(t *testing.T) {
 		ct      = "Content-Type"
 		ctJSON  = "application/json"
 		ctOther = "<other>"
+		ctYAML  = "application/yaml"
 		wantT   = "*json.Encoder"
 	)
 	cases := []struct {
 		name      string
 		requestCT string
 		wantCT    string
+		wantT     string
 	}{
-		{"no ct", "", ctJSON},
-		{"json ct", ctJSON, ctJSON},
-		{"other ct", ctOther, ctOther},
+		{"no ct", "", ctJSON, wantT},
+		{"json ct", ctJSON, ctJSON, wantT},
+		{"other ct", ctOther, ctOther, wantT},
+		{"yaml ct", ctYAML, ctYAML, "*http.yamlEncoder"},
+		{"cbor ct", "application/cbor", "application/cbor", "*http.cborEncoder"},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -40,8 +47,8 @@ func TestRequestEncoder(t *testing.T) {
 
 			encoder := RequestEncoder(r)
 
-			if gotT := fmt.Sprintf("%T", encoder); gotT != wantT {
-				t.Errorf("got encoder type %s, want %s", gotT, wantT)
+			if gotT := fmt.Sprintf("%T", encoder); gotT != c.wantT {
+				t.Errorf("got encoder type %s, want %s", gotT, c.wantT)
 			}
 			if gotCT := r.Header.Get(ct); gotCT != c.wantCT {
 				t.Errorf("got Content-Type %q, want %q", gotCT, c.wantCT)
@@ -50,6 +57,43 @@ func TestRequestEncoder(t *testing.T) {
 	}
 }
 
+func TestCheckContentType(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		mimeTypes   []string
+		wantErr     bool
+	}{
+		{"no mime types", "text/csv", "a,b", nil, false},
+		{"no body", "text/csv", "", []string{"application/json"}, false},
+		{"match", "application/json", "{}", []string{"application/xml", "application/json"}, false},
+		{"match with params", "application/json; charset=utf-8", "{}", []string{"application/json"}, false},
+		{"match wildcard", "text/csv", "a,b", []string{"text/*"}, false},
+		{"default json", "", "{}", []string{"application/json"}, false},
+		{"mismatch", "application/xml", "<a/>", []string{"application/json"}, true},
+		{"mismatch wildcard", "application/csv", "a,b", []string{"text/*"}, true},
+		{"missing default json", "", "{}", []string{"application/xml"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", bytes.NewBufferString(c.body))
+			if c.contentType != "" {
+				r.Header.Set("Content-Type", c.contentType)
+			}
+			err := CheckContentType(r, c.mimeTypes...)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("got error %v, want error %v", err, c.wantErr)
+			}
+			if err != nil {
+				if code := NewErrorResponse(err).StatusCode(); code != http.StatusUnsupportedMediaType {
+					t.Errorf("got status code %d, want %d", code, http.StatusUnsupportedMediaType)
+				}
+			}
+		})
+	}
+}
+
 func TestResponseEncoder(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -63,6 +107,9 @@ func TestResponseEncoder(t *testing.T) {
 		{"no ct, at gob", "", "application/gob", "*gob.Encoder"},
 		{"no ct, at html", "", "text/html", "*http.textEncoder"},
 		{"no ct, at plain", "", "text/plain", "*http.textEncoder"},
+		{"no ct, at yaml", "", "application/yaml", "*http.yamlEncoder"},
+		{"no ct, at x-yaml", "", "application/x-yaml", "*http.yamlEncoder"},
+		{"no ct, at cbor", "", "application/cbor", "*http.cborEncoder"},
 		{"ct json", "application/json", "application/gob", "*json.Encoder"},
 		{"ct +json", "+json", "application/gob", "*json.Encoder"},
 		{"ct xml", "application/xml", "application/gob", "*xml.Encoder"},
@@ -73,11 +120,16 @@ func TestResponseEncoder(t *testing.T) {
 		{"ct +html", "+html", "application/gob", "*http.textEncoder"},
 		{"ct plain", "text/plain", "application/gob", "*http.textEncoder"},
 		{"ct +txt", "+txt", "application/gob", "*http.textEncoder"},
+		{"ct yaml", "application/yaml", "application/gob", "*http.yamlEncoder"},
+		{"ct +yaml", "+yaml", "application/gob", "*http.yamlEncoder"},
+		{"ct cbor", "application/cbor", "application/gob", "*http.cborEncoder"},
+		{"ct +cbor", "application/vnd.reading+cbor", "application/gob", "*http.cborEncoder"},
 		{"no ct, at json with params", "", "application/json; charset=utf-8", "*json.Encoder"},
 		{"no ct, at xml with params", "", "application/xml; charset=utf-8", "*xml.Encoder"},
 		{"no ct, at gob with params", "", "application/gob; charset=utf-8", "*gob.Encoder"},
 		{"no ct, at html with params", "", "text/html; charset=utf-8", "*http.textEncoder"},
 		{"no ct, at plain with params", "", "text/plain; charset=utf-8", "*http.textEncoder"},
+		{"no ct, at yaml with params", "", "application/yaml; charset=utf-8", "*http.yamlEncoder"},
 		{"ct json with params", "application/json; charset=utf-8", "application/gob", "*json.Encoder"},
 		{"ct +json with params", "+json; charset=utf-8", "application/gob", "*json.Encoder"},
 		{"ct xml with params", "application/xml; charset=utf-8", "application/gob", "*xml.Encoder"},
@@ -104,6 +156,64 @@ func TestResponseEncoder(t *testing.T) {
 	}
 }
 
+func TestStreamingResponseEncoder(t *testing.T) {
+	cases := []struct {
+		name        string
+		contentType string
+		acceptType  string
+		encoderType string
+	}{
+		{"no ct, no at", "", "", "*http.streamingJSONEncoder"},
+		{"no ct, at xml", "", "application/xml", "*xml.Encoder"},
+		{"ct +json", "+json", "application/gob", "*http.streamingJSONEncoder"},
+		{"ct gob", "application/gob", "application/json", "*gob.Encoder"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, AcceptTypeKey, c.acceptType)
+			ctx = context.WithValue(ctx, ContentTypeKey, c.contentType)
+			w := httptest.NewRecorder()
+			encoder := StreamingResponseEncoder(ctx, w)
+			if c.encoderType != fmt.Sprintf("%T", encoder) {
+				t.Errorf("got encoder type %s, expected %s", fmt.Sprintf("%T", encoder), c.encoderType)
+			}
+		})
+	}
+}
+
+type retryAfterError time.Duration
+
+func (e retryAfterError) Error() string             { return "overloaded" }
+func (e retryAfterError) RetryAfter() time.Duration { return time.Duration(e) }
+
+func TestErrorEncoderRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		Err      error
+		Status   int
+		Expected string
+	}{
+		"no retry after": {goa.TemporaryError("overloaded", "overloaded"), http.StatusServiceUnavailable, ""},
+		"seconds":        {goa.NewServiceError(retryAfterError(2*time.Second), "overloaded", false, true, false), http.StatusServiceUnavailable, "2"},
+		"rounded up":     {goa.NewServiceError(retryAfterError(1500*time.Millisecond), "overloaded", false, true, false), http.StatusServiceUnavailable, "2"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := ErrorEncoder(ResponseEncoder, nil)(context.Background(), w, c.Err); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if actual := w.Header().Get("Retry-After"); actual != c.Expected {
+				t.Errorf("got Retry-After %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
 func TestResponseDecoder(t *testing.T) {
 	cases := []struct {
 		contentType string
@@ -119,6 +229,10 @@ func TestResponseDecoder(t *testing.T) {
 		{"+html", "*http.textDecoder"},
 		{"text/plain", "*http.textDecoder"},
 		{"+txt", "*http.textDecoder"},
+		{"application/yaml", "*http.yamlDecoder"},
+		{"+yaml", "*http.yamlDecoder"},
+		{"application/cbor", "*http.cborDecoder"},
+		{"+cbor", "*http.cborDecoder"},
 		{"application/json; charset=utf-8", "*json.Decoder"},
 		{"+json; charset=utf-8", "*json.Decoder"},
 		{"application/xml; charset=utf-8", "*xml.Decoder"},
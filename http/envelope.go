@@ -0,0 +1,154 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+type (
+	// envelope holds the envelope fields set by the service methods.
+	envelope struct {
+		errorKey string
+		mu       sync.Mutex
+		keys     []string
+		values   map[string]interface{}
+	}
+
+	// envelopeKeyType is the type of the context key used to store the
+	// envelope.
+	envelopeKeyType int
+)
+
+// envelopeKey is the context key used to store the envelope.
+const envelopeKey envelopeKeyType = 0
+
+// WithEnvelope returns a context that records the envelope fields set with
+// SetEnvelopeField. errorKey is the name of the envelope field that wraps error
+// response bodies, error responses are not wrapped if errorKey is empty. The
+// generated server code calls WithEnvelope before invoking the endpoint when
+// the design defines an envelope.
+func WithEnvelope(ctx context.Context, errorKey string) context.Context {
+	return context.WithValue(ctx, envelopeKey, &envelope{errorKey: errorKey, values: make(map[string]interface{})})
+}
+
+// SetEnvelopeField sets the value of the envelope field with the given key for
+// the response written for the request with the given context, for example:
+//
+//    goahttp.SetEnvelopeField(ctx, "meta", &Meta{Total: total})
+//
+// SetEnvelopeField does nothing if the context was not created with
+// WithEnvelope.
+func SetEnvelopeField(ctx context.Context, key string, v interface{}) {
+	env, ok := ctx.Value(envelopeKey).(*envelope)
+	if !ok {
+		return
+	}
+	env.mu.Lock()
+	defer env.mu.Unlock()
+	if _, ok := env.values[key]; !ok {
+		env.keys = append(env.keys, key)
+	}
+	env.values[key] = v
+}
+
+// EnvelopeEncoder returns an encoder that wraps the encoded values in an
+// envelope under the given key. The envelope also contains the fields set with
+// SetEnvelopeField on ctx. If key is empty the values are wrapped under the
+// error key given to WithEnvelope if any.
+func EnvelopeEncoder(ctx context.Context, enc Encoder, key string) Encoder {
+	return EncodingFunc(func(v interface{}) error {
+		return enc.Encode(wrapEnvelope(ctx, key, v))
+	})
+}
+
+// EnvelopeDecoder returns a HTTP response decoder that unwraps the response
+// bodies encoded by EnvelopeEncoder. The decoder reads the value of dataKey for
+// responses with a status code lower than 400 and the value of errorKey for
+// the other responses. The decoder does not unwrap error responses if
+// errorKey is empty.
+func EnvelopeDecoder(decoder func(*http.Response) Decoder, dataKey, errorKey string) func(*http.Response) Decoder {
+	return func(resp *http.Response) Decoder {
+		dec := decoder(resp)
+		key := dataKey
+		if resp.StatusCode >= http.StatusBadRequest {
+			key = errorKey
+		}
+		if key == "" {
+			return dec
+		}
+		return EncodingFunc(func(v interface{}) error {
+			rv := reflect.ValueOf(v)
+			if rv.Kind() != reflect.Ptr || rv.IsNil() {
+				return fmt.Errorf("cannot decode envelope into %T", v)
+			}
+			st := reflect.StructOf([]reflect.StructField{envelopeField(0, key, rv.Type(), false)})
+			w := reflect.New(st)
+			w.Elem().Field(0).Set(rv)
+			return dec.Decode(w.Interface())
+		})
+	}
+}
+
+// wrapEnvelope returns a struct whose first field is v encoded under key
+// followed by the fields recorded in ctx. v is wrapped under the error key
+// recorded in ctx if key is empty and returned as is if there is no such key.
+func wrapEnvelope(ctx context.Context, key string, v interface{}) interface{} {
+	var (
+		keys   []string
+		values []interface{}
+	)
+	if env, ok := ctx.Value(envelopeKey).(*envelope); ok {
+		env.mu.Lock()
+		if key == "" {
+			key = env.errorKey
+		}
+		for _, k := range env.keys {
+			if k == key {
+				continue
+			}
+			keys = append(keys, k)
+			values = append(values, env.values[k])
+		}
+		env.mu.Unlock()
+	}
+	if key == "" {
+		return v
+	}
+	var typ reflect.Type
+	if v == nil {
+		typ = reflect.TypeOf((*interface{})(nil)).Elem()
+	} else {
+		typ = reflect.TypeOf(v)
+	}
+	fields := []reflect.StructField{envelopeField(0, key, typ, false)}
+	for i, k := range keys {
+		fields = append(fields, envelopeField(i+1, k, reflect.TypeOf((*interface{})(nil)).Elem(), true))
+	}
+	w := reflect.New(reflect.StructOf(fields)).Elem()
+	if v != nil {
+		w.Field(0).Set(reflect.ValueOf(v))
+	}
+	for i, val := range values {
+		if val != nil {
+			w.Field(i + 1).Set(reflect.ValueOf(val))
+		}
+	}
+	return w.Addr().Interface()
+}
+
+// envelopeField returns the definition of the envelope struct field at index i
+// encoded under key.
+func envelopeField(i int, key string, typ reflect.Type, omitEmpty bool) reflect.StructField {
+	opt := ""
+	if omitEmpty {
+		opt = ",omitempty"
+	}
+	return reflect.StructField{
+		Name: fmt.Sprintf("F%d", i),
+		Type: typ,
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s%s" xml:"%s%s" form:"%s%s"`, key, opt, key, opt, key, opt)),
+	}
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnvelopeEncoder(t *testing.T) {
+	type body struct {
+		Name string `json:"name"`
+	}
+	cases := []struct {
+		Name     string
+		Key      string
+		ErrorKey string
+		Fields   map[string]interface{}
+		Expected string
+	}{
+		{"data", "data", "", nil, `{"data":{"name":"a"}}`},
+		{"fields", "data", "", map[string]interface{}{"meta": 1}, `{"data":{"name":"a"},"meta":1}`},
+		{"error", "", "errors", nil, `{"errors":{"name":"a"}}`},
+		{"no error key", "", "", map[string]interface{}{"meta": 1}, `{"name":"a"}`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := WithEnvelope(context.Background(), c.ErrorKey)
+			for k, v := range c.Fields {
+				SetEnvelopeField(ctx, k, v)
+			}
+			w := httptest.NewRecorder()
+			enc := EnvelopeEncoder(ctx, ResponseEncoder(ctx, w), c.Key)
+			if err := enc.Encode(&body{Name: "a"}); err != nil {
+				t.Fatal(err)
+			}
+			if got := string(bytes.TrimSpace(w.Body.Bytes())); got != c.Expected {
+				t.Errorf("got %s, expected %s", got, c.Expected)
+			}
+		})
+	}
+}
+
+func TestEnvelopeDecoder(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Status   int
+		Body     string
+		Expected string
+	}{
+		{"data", http.StatusOK, `{"data":{"name":"a"},"meta":1}`, "a"},
+		{"error", http.StatusBadRequest, `{"errors":{"name":"b"}}`, "b"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: c.Status,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       ioutil.NopCloser(bytes.NewBufferString(c.Body)),
+			}
+			var v struct {
+				Name string `json:"name"`
+			}
+			if err := EnvelopeDecoder(ResponseDecoder, "data", "errors")(resp).Decode(&v); err != nil {
+				t.Fatal(err)
+			}
+			if v.Name != c.Expected {
+				t.Errorf("got %q, expected %q", v.Name, c.Expected)
+			}
+		})
+	}
+}
+
+func TestErrorEncoderEnvelope(t *testing.T) {
+	ctx := WithEnvelope(context.Background(), "errors")
+	w := httptest.NewRecorder()
+	if err := ErrorEncoder(ResponseEncoder, nil)(ctx, w, ErrInvalidURL("svc", "m", "u", nil)); err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v["errors"]; !ok || len(v) != 1 {
+		t.Errorf("got %s, expected error wrapped in envelope", w.Body.String())
+	}
+}
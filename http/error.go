@@ -51,9 +51,19 @@ func NewErrorResponse(err error) Statuser {
 
 // StatusCode implements a heuristic that computes a HTTP response status code
 // appropriate for the timeout, temporary and fault characteristics of the
-// error. This method is used by the generated server code when the error is not
-// described explicitly in the design.
+// error. Errors named goa.UnsupportedMediaType map to 415 Unsupported Media
+// Type, goa.FeatureDisabled to 404 Not Found and goa.FeatureForbidden and
+// goa.PermissionDenied to 403 Forbidden. This method is used by the generated
+// server code when the error is not described explicitly in the design.
 func (resp *ErrorResponse) StatusCode() int {
+	switch resp.Name {
+	case goa.UnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+	case goa.FeatureDisabled:
+		return http.StatusNotFound
+	case goa.FeatureForbidden, goa.PermissionDenied:
+		return http.StatusForbidden
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
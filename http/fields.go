@@ -0,0 +1,41 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// RecordSetFields records the fields present in the body of r with goa.MarkSet
+// so that the service methods may call goa.WasSet to tell omitted fields apart
+// from fields explicitly set to null. fields maps the names of the recorded
+// fields on the wire to the names of the corresponding payload attributes, the
+// other fields are ignored. The request context must be initialized with
+// goa.WithSetFields. RecordSetFields only inspects JSON object bodies and
+// leaves the body of r ready to be decoded. The generated server code calls
+// RecordSetFields for the methods whose payload define nullable attributes.
+func RecordSetFields(r *http.Request, fields map[string]string) error {
+	if r.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(body, &obj); err != nil {
+		// Not a JSON object, let the decoder report errors if any.
+		return nil
+	}
+	ctx := r.Context()
+	for wire, name := range fields {
+		if _, ok := obj[wire]; ok {
+			goa.MarkSet(ctx, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestRecordSetFields(t *testing.T) {
+	fields := map[string]string{"name": "name", "nick": "nickname"}
+	cases := []struct {
+		Name string
+		Body string
+		Set  []string
+	}{
+		{"omitted", `{"id":"1"}`, nil},
+		{"null", `{"id":"1","name":null}`, []string{"name"}},
+		{"value", `{"name":"joe","nick":"jo"}`, []string{"name", "nickname"}},
+		{"wire name only", `{"nickname":"jo"}`, nil},
+		{"not an object", `"joe"`, nil},
+		{"invalid", `{"name"`, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, _ := http.NewRequest("PATCH", "/", strings.NewReader(c.Body))
+			r = r.WithContext(goa.WithSetFields(r.Context()))
+			if err := RecordSetFields(r, fields); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, n := range []string{"name", "nickname"} {
+				expected := false
+				for _, s := range c.Set {
+					expected = expected || s == n
+				}
+				if got := goa.WasSet(r.Context(), n); got != expected {
+					t.Errorf("%s: got %v, expected %v", n, got, expected)
+				}
+			}
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != c.Body {
+				t.Errorf("got body %q, expected %q", string(body), c.Body)
+			}
+		})
+	}
+}
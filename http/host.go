@@ -0,0 +1,95 @@
+package http
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type (
+	// ServerHost describes a HTTP host of a server defined in the design. The
+	// generated client packages list the hosts of the servers that expose the
+	// service so that clients may select the host to connect to.
+	ServerHost struct {
+		// Server is the name of the server.
+		Server string
+		// Name is the name of the host.
+		Name string
+		// URIs lists the HTTP URIs of the host. The URIs may contain
+		// variables using the "{name}" syntax.
+		URIs []string
+		// Variables contains the default values of the URI variables
+		// indexed by name.
+		Variables map[string]string
+		// Values contains the allowed values of the URI variables indexed by
+		// name if any.
+		Values map[string][]string
+	}
+)
+
+// URL returns the URL of the first host URI. The URI variables are replaced
+// with the values in vars or their default values if not set. URL returns an
+// error if a value is not one of the allowed values for the variable.
+func (h *ServerHost) URL(vars map[string]string) (*url.URL, error) {
+	if len(h.URIs) == 0 {
+		return nil, fmt.Errorf("host %q does not define any HTTP URI", h.Name)
+	}
+	u := h.URIs[0]
+	for name, def := range h.Variables {
+		val, ok := vars[name]
+		if !ok {
+			val = def
+		}
+		if allowed, ok := h.Values[name]; ok && !contains(allowed, val) {
+			return nil, fmt.Errorf("invalid value %q for host %q variable %q, value must be one of %s", val, h.Name, name, strings.Join(allowed, ", "))
+		}
+		u = strings.ReplaceAll(u, "{"+name+"}", val)
+	}
+	return url.Parse(u)
+}
+
+// HostURL returns the URL of the host with the given name. The URI variables
+// are replaced with the values in vars or their default values if not set.
+// HostURL makes it possible to compute the scheme and host given to the
+// generated NewClient functions from the generated list of hosts:
+//
+//    u, err := goahttp.HostURL(calcc.Hosts, "production", map[string]string{"version": "v2"})
+//    if err != nil {
+//        return err
+//    }
+//    c := calcc.NewClient(u.Scheme, u.Host, doer, enc, dec, false)
+//
+func HostURL(hosts []*ServerHost, name string, vars map[string]string) (*url.URL, error) {
+	names := make([]string, len(hosts))
+	for i, h := range hosts {
+		if h.Name == name {
+			return h.URL(vars)
+		}
+		names[i] = h.Name
+	}
+	return nil, fmt.Errorf("unknown host %q, valid hosts are %s", name, strings.Join(names, ", "))
+}
+
+// JoinURLPath returns a copy of base whose path is the concatenation of the
+// base path and the given path. The generated server packages use JoinURLPath
+// to build the URLs to the service endpoints, for example to render links in
+// responses:
+//
+//    href := server.ShowBottleURL(base, accountID, bottleID).String()
+//
+func JoinURLPath(base *url.URL, path string) *url.URL {
+	u := *base
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawPath = ""
+	return &u
+}
+
+// contains returns true if vals contains val.
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestHostURL(t *testing.T) {
+	hosts := []*ServerHost{
+		{
+			Server: "calc",
+			Name:   "development",
+			URIs:   []string{"http://localhost:8000/calc"},
+		},
+		{
+			Server:    "calc",
+			Name:      "production",
+			URIs:      []string{"https://{region}.example.com/{version}", "http://{region}.example.com/{version}"},
+			Variables: map[string]string{"region": "us", "version": "v1"},
+			Values:    map[string][]string{"region": {"us", "eu"}},
+		},
+	}
+	cases := []struct {
+		Name     string
+		Host     string
+		Vars     map[string]string
+		Expected string
+		Error    string
+	}{
+		{"no-variable", "development", nil, "http://localhost:8000/calc", ""},
+		{"default", "production", nil, "https://us.example.com/v1", ""},
+		{"override", "production", map[string]string{"region": "eu", "version": "v2"}, "https://eu.example.com/v2", ""},
+		{"invalid-value", "production", map[string]string{"region": "ap"}, "", `invalid value "ap" for host "production" variable "region", value must be one of us, eu`},
+		{"unknown-host", "staging", nil, "", `unknown host "staging", valid hosts are development, production`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			u, err := HostURL(hosts, c.Host, c.Vars)
+			if c.Error != "" {
+				if err == nil || err.Error() != c.Error {
+					t.Fatalf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.String() != c.Expected {
+				t.Errorf("got %q, expected %q", u.String(), c.Expected)
+			}
+		})
+	}
+}
+
+func TestJoinURLPath(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Base     string
+		Path     string
+		Expected string
+	}{
+		{"root", "http://localhost:8000", "/bottles/1", "http://localhost:8000/bottles/1"},
+		{"base-path", "https://example.com/v1", "/bottles/1", "https://example.com/v1/bottles/1"},
+		{"trailing-slash", "https://example.com/v1/", "/bottles/1", "https://example.com/v1/bottles/1"},
+		{"query", "https://example.com/v1?lang=en", "/bottles/1", "https://example.com/v1/bottles/1?lang=en"},
+		{"escaped", "https://example.com", "/bottles/a b", "https://example.com/bottles/a%20b"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			base, err := url.Parse(c.Base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			u := JoinURLPath(base, c.Path)
+			if u.String() != c.Expected {
+				t.Errorf("got %q, expected %q", u.String(), c.Expected)
+			}
+			if base.String() != c.Base {
+				t.Errorf("base modified: got %q, expected %q", base.String(), c.Base)
+			}
+		})
+	}
+}
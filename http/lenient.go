@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// LenientValidation returns nil if lenient validation is enabled for the
+// request and err only contains validation errors, err otherwise. The
+// generated server code calls LenientValidation with the request validation
+// errors of the methods whose "validation:lenient" metadata is set in the
+// design. Downgraded errors are reported to the handler given to
+// WithLenientValidation.
+//
+// Errors caused by values that cannot be decoded or by missing required
+// fields are never downgraded as the payload could not be built.
+func LenientValidation(r *http.Request, err error) error {
+	handler, _ := r.Context().Value(lenientValidationKey).(func(context.Context, error))
+	if handler == nil || !isValidationError(err) {
+		return err
+	}
+	handler(r.Context(), err)
+	return nil
+}
+
+// WithLenientValidation returns a copy of ctx that enables lenient
+// validation: the request validation errors of the methods whose
+// "validation:lenient" metadata is set in the design are reported to handler
+// instead of causing 400 Bad Request responses. This makes it possible to
+// roll out stricter validations against existing traffic incrementally. It is
+// typically called by a middleware enabled via the deployment configuration:
+//
+//    func Lenient(enabled bool, logger *log.Logger) func(http.Handler) http.Handler {
+//        return func(h http.Handler) http.Handler {
+//            if !enabled {
+//                return h
+//            }
+//            return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//                ctx := goahttp.WithLenientValidation(r.Context(), func(_ context.Context, err error) {
+//                    logger.Printf("%s %s: validation warning: %s", r.Method, r.URL.Path, err)
+//                })
+//                h.ServeHTTP(w, r.WithContext(ctx))
+//            })
+//        }
+//    }
+func WithLenientValidation(ctx context.Context, handler func(context.Context, error)) context.Context {
+	return context.WithValue(ctx, lenientValidationKey, handler)
+}
+
+// isValidationError returns true if err is a goa service error made only of
+// validation errors that may be safely ignored.
+func isValidationError(err error) bool {
+	var serr *goa.ServiceError
+	if !errors.As(err, &serr) {
+		return false
+	}
+	for _, e := range serr.History() {
+		switch e.Name {
+		case goa.InvalidEnumValue, goa.InvalidFormat, goa.InvalidPattern,
+			goa.InvalidRange, goa.InvalidLength, goa.InvalidUniqueItems,
+			goa.MissingFieldGroup, goa.ConflictingFields, goa.InvalidValue:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestLenientValidation(t *testing.T) {
+	var (
+		rangeErr  = goa.InvalidRangeError("count", 0, 1, true)
+		merged    = goa.MergeErrors(goa.InvalidRangeError("count", 0, 1, true), goa.InvalidEnumValueError("status", "x", []interface{}{"a", "b"}))
+		missing   = goa.MergeErrors(goa.InvalidRangeError("count", 0, 1, true), goa.MissingFieldError("name", "body"))
+		fieldType = goa.InvalidFieldTypeError("count", "x", "integer")
+	)
+	cases := map[string]struct {
+		enabled bool
+		err     error
+		lenient bool
+	}{
+		"disabled":      {false, rangeErr, false},
+		"range":         {true, rangeErr, true},
+		"merged":        {true, merged, true},
+		"missing field": {true, missing, false},
+		"field type":    {true, fieldType, false},
+		"other":         {true, errors.New("boom"), false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var warned error
+			r, _ := http.NewRequest("GET", "/", nil)
+			if c.enabled {
+				r = r.WithContext(WithLenientValidation(r.Context(), func(_ context.Context, err error) {
+					warned = err
+				}))
+			}
+			err := LenientValidation(r, c.err)
+			if c.lenient {
+				if err != nil {
+					t.Errorf("got error %v, expected nil", err)
+				}
+				if warned != c.err {
+					t.Errorf("got warning %v, expected %v", warned, c.err)
+				}
+				return
+			}
+			if err != c.err {
+				t.Errorf("got error %v, expected %v", err, c.err)
+			}
+			if warned != nil {
+				t.Errorf("got warning %v, expected none", warned)
+			}
+		})
+	}
+}
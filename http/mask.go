@@ -0,0 +1,197 @@
+package http
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type (
+	// maskPlan lists the fields of a struct type considered when masking.
+	maskPlan struct {
+		fields []maskField
+	}
+
+	// maskNode is a node of the tree built from the paths of the fields to
+	// mask. The children are indexed by JSON field name.
+	maskNode struct {
+		// masked is true if the field at the node path must be masked.
+		masked bool
+		// mask is the value used to mask string fields.
+		mask string
+		// children lists the nodes of the nested fields.
+		children map[string]*maskNode
+	}
+
+	// maskField describes a struct field considered when masking.
+	maskField struct {
+		// index is the index of the field in the struct.
+		index int
+		// name is the name of the field as encoded in JSON.
+		name string
+		// nested is true if the field value may contain structs.
+		nested bool
+	}
+)
+
+// maskPlans caches the masking plans indexed by struct type. The generated
+// code defines a response body type per result type and view so that the
+// plans are effectively computed once per result type and view.
+var maskPlans sync.Map
+
+// Unmask returns a context that causes the generated response encoders to
+// render the attributes marked as sensitive in the design unmasked. It is
+// typically called by a middleware that authorizes privileged callers:
+//
+//    func AllowPII(h http.Handler) http.Handler {
+//        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//            if isAdmin(r) {
+//                r = r.WithContext(goahttp.Unmask(r.Context()))
+//            }
+//            h.ServeHTTP(w, r)
+//        })
+//    }
+func Unmask(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unmaskKey, true)
+}
+
+// IsUnmasked returns true if ctx was created with Unmask.
+func IsUnmasked(ctx context.Context) bool {
+	u, _ := ctx.Value(unmaskKey).(bool)
+	return u
+}
+
+// MaskSensitive masks the fields of v whose paths are keys of masks. v must be
+// a pointer to a struct, a slice or a map of such. A path lists the JSON names
+// of the fields from the top level struct to the masked field separated with
+// dots, slices and maps are traversed transparently so that "cards.number"
+// refers to the field "number" of the elements of the field "cards". String
+// fields are set to the corresponding mask value. Other fields as well as
+// string fields whose mask is empty are set to their zero value: they are
+// omitted from the encoded body if optional (pointer or omitempty) and
+// rendered with the zero value otherwise. Pointer fields are replaced rather
+// than written through so that the values they used to point to are left
+// untouched. The struct fields considered for masking are computed once per
+// type and cached.
+//
+// The generated response encoders call MaskSensitive on the response bodies
+// of endpoints whose results define attributes marked as sensitive unless the
+// request context was created with Unmask.
+func MaskSensitive(v interface{}, masks map[string]string) {
+	if v == nil || len(masks) == 0 {
+		return
+	}
+	root := &maskNode{}
+	for path, mask := range masks {
+		n := root
+		for _, name := range strings.Split(path, ".") {
+			c, ok := n.children[name]
+			if !ok {
+				if n.children == nil {
+					n.children = make(map[string]*maskNode)
+				}
+				c = &maskNode{}
+				n.children[name] = c
+			}
+			n = c
+		}
+		n.masked, n.mask = true, mask
+	}
+	maskValue(reflect.ValueOf(v), root)
+}
+
+// maskValue masks the fields of v described by the children of node
+// recursively. The recursion is bounded by the depth of the mask tree.
+func maskValue(v reflect.Value, node *maskNode) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			maskValue(v.Elem(), node)
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			maskValue(v.Elem(), node)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			maskValue(v.Index(i), node)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			maskValue(iter.Value(), node)
+		}
+	case reflect.Struct:
+		for _, mf := range planMask(v.Type()).fields {
+			child, ok := node.children[mf.name]
+			if !ok {
+				continue
+			}
+			f := v.Field(mf.index)
+			if !f.CanSet() {
+				continue
+			}
+			if !child.masked {
+				if mf.nested {
+					maskValue(f, child)
+				}
+				continue
+			}
+			switch {
+			case child.mask != "" && f.Kind() == reflect.String:
+				f.SetString(child.mask)
+			case child.mask != "" && f.Kind() == reflect.Ptr && f.Type().Elem().Kind() == reflect.String:
+				if !f.IsNil() {
+					m := reflect.New(f.Type().Elem())
+					m.Elem().SetString(child.mask)
+					f.Set(m)
+				}
+			default:
+				f.Set(reflect.Zero(f.Type()))
+			}
+		}
+	}
+}
+
+// planMask returns the masking plan of the struct type t. The plan is computed
+// on first use and cached so that masking values of the same type does not
+// need to parse the struct tags again.
+func planMask(t reflect.Type) *maskPlan {
+	if p, ok := maskPlans.Load(t); ok {
+		return p.(*maskPlan)
+	}
+	p := &maskPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		p.fields = append(p.fields, maskField{index: i, name: jsonName(f), nested: mayContainStruct(f.Type)})
+	}
+	actual, _ := maskPlans.LoadOrStore(t, p)
+	return actual.(*maskPlan)
+}
+
+// mayContainStruct returns true if values of type t may contain struct values.
+func mayContainStruct(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return mayContainStruct(t.Elem())
+	}
+	return false
+}
+
+// jsonName returns the name of the field as encoded in JSON.
+func jsonName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if tag == "" {
+		return f.Name
+	}
+	if n := strings.Split(tag, ",")[0]; n != "" {
+		return n
+	}
+	return f.Name
+}
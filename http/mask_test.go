@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestMaskSensitive(t *testing.T) {
+	type (
+		card struct {
+			Number *string `json:"number,omitempty"`
+			Expiry *string `json:"expiry,omitempty"`
+		}
+		body struct {
+			Name     string  `json:"name"`
+			Number   *string `json:"number,omitempty"`
+			Password *string `json:"password,omitempty"`
+			PIN      *int    `json:"pin,omitempty"`
+			Cards    []*card `json:"cards,omitempty"`
+		}
+	)
+	var (
+		account  = "A-42"
+		password = "secret"
+		number   = "4111111111111111"
+		expiry   = "12/30"
+		pin      = 1234
+		masks    = map[string]string{"password": "", "pin": "", "cards.number": "****"}
+	)
+	b := &body{Name: "joe", Number: &account, Password: &password, PIN: &pin, Cards: []*card{{Number: &number, Expiry: &expiry}}}
+	MaskSensitive(b, masks)
+	js, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"name":"joe","number":"A-42","cards":[{"number":"****","expiry":"12/30"}]}`
+	if string(js) != expected {
+		t.Errorf("got %s, expected %s", js, expected)
+	}
+	if password != "secret" || number != "4111111111111111" {
+		t.Errorf("masking modified the original values")
+	}
+}
+
+func TestMaskSensitiveRequired(t *testing.T) {
+	type body struct {
+		Name   string `json:"name"`
+		Secret string `json:"secret"`
+		Token  string `json:"token"`
+	}
+	b := &body{Name: "joe", Secret: "secret", Token: "token"}
+	MaskSensitive(b, map[string]string{"secret": "", "token": "****"})
+	js, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"name":"joe","secret":"","token":"****"}`
+	if string(js) != expected {
+		t.Errorf("got %s, expected %s", js, expected)
+	}
+}
+
+func TestMaskSensitiveUnaddressable(t *testing.T) {
+	type (
+		item struct {
+			Secret string `json:"secret"`
+		}
+		body struct {
+			Items map[string]item `json:"items"`
+		}
+	)
+	b := &body{Items: map[string]item{"a": {Secret: "secret"}}}
+	MaskSensitive(b, map[string]string{"items.secret": "****"})
+	if b.Items["a"].Secret != "secret" {
+		t.Errorf("got %q, expected unaddressable value to be left untouched", b.Items["a"].Secret)
+	}
+}
+
+func BenchmarkMaskSensitive(b *testing.B) {
+	type (
+		card struct {
+			Number *string `json:"number,omitempty"`
+			Expiry *string `json:"expiry,omitempty"`
+		}
+		body struct {
+			Name     string   `json:"name"`
+			Password *string  `json:"password,omitempty"`
+			Tags     []string `json:"tags,omitempty"`
+			Cards    []*card  `json:"cards,omitempty"`
+		}
+	)
+	var (
+		password = "secret"
+		number   = "4111111111111111"
+		masks    = map[string]string{"password": "", "cards.number": "****"}
+	)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		v := &body{Name: "joe", Password: &password, Tags: []string{"a", "b"}, Cards: []*card{{Number: &number}}}
+		MaskSensitive(v, masks)
+	}
+}
+
+func TestIsUnmasked(t *testing.T) {
+	if IsUnmasked(context.Background()) {
+		t.Errorf("got unmasked background context")
+	}
+	if !IsUnmasked(Unmask(context.Background())) {
+		t.Errorf("got masked context after Unmask")
+	}
+}
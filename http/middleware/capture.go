@@ -52,6 +52,12 @@ func (w *ResponseCapture) Push(target string, opts *http.PushOptions) error {
 	return errors.New("push not supported")
 }
 
+// Unwrap returns the underlying response writer so that http.ResponseController
+// may access the features it supports.
+func (w *ResponseCapture) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // Hijack supports the http.Hijacker interface.
 func (w *ResponseCapture) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
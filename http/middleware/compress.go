@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type (
+	// CompressOption configures the Compress middleware.
+	CompressOption func(*compressOptions)
+
+	compressOptions struct {
+		level       int
+		streamTypes map[string]bool
+	}
+
+	// compressWriter is the response writer that compresses the response
+	// body.
+	compressWriter struct {
+		http.ResponseWriter
+		opts        *compressOptions
+		pool        *sync.Pool
+		gz          *gzip.Writer
+		wroteHeader bool
+		stream      bool
+	}
+)
+
+// defaultStreamTypes lists the media types of the responses flushed after each
+// write by default.
+var defaultStreamTypes = []string{"text/event-stream", "application/x-ndjson", "application/stream+json"}
+
+// Compress returns a middleware that compresses the response bodies with gzip
+// when the request Accept-Encoding header accepts it:
+//
+//    handler = middleware.Compress()(handler)
+//
+// Responses whose content type is a streaming media type such as server-sent
+// events (text/event-stream) or newline delimited JSON (application/x-ndjson)
+// are compressed in a streaming-safe way: the compressor is flushed after each
+// write so that each event reaches the client as soon as it is written and
+// the stream is never buffered. The handlers of other responses may also call
+// Flush to send the data compressed so far. Responses that already define a
+// Content-Encoding header, responses without body and responses to HEAD
+// requests are not compressed.
+func Compress(opts ...CompressOption) func(http.Handler) http.Handler {
+	o := &compressOptions{level: gzip.DefaultCompression}
+	CompressStreamTypes(defaultStreamTypes...)(o)
+	for _, opt := range opts {
+		opt(o)
+	}
+	if _, err := gzip.NewWriterLevel(nil, o.level); err != nil {
+		panic(fmt.Sprintf("invalid compression level %d", o.level))
+	}
+	pool := &sync.Pool{New: func() interface{} {
+		gz, _ := gzip.NewWriterLevel(nil, o.level)
+		return gz
+	}}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, opts: o, pool: pool}
+			defer cw.close()
+			h.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// CompressLevel sets the gzip compression level, see the compress/gzip
+// package constants. The default is gzip.DefaultCompression.
+func CompressLevel(level int) CompressOption {
+	return func(o *compressOptions) {
+		o.level = level
+	}
+}
+
+// CompressStreamTypes sets the media types of the streamed responses whose
+// compressed body is flushed after each write. The default is
+// text/event-stream, application/x-ndjson and application/stream+json.
+func CompressStreamTypes(types ...string) CompressOption {
+	return func(o *compressOptions) {
+		o.streamTypes = make(map[string]bool, len(types))
+		for _, t := range types {
+			o.streamTypes[strings.ToLower(t)] = true
+		}
+	}
+}
+
+// WriteHeader sets the Content-Encoding header and starts the compression if
+// the response is compressed.
+func (w *compressWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	h := w.Header()
+	if h.Get("Content-Encoding") == "" && code >= http.StatusOK && code != http.StatusNoContent && code != http.StatusNotModified {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+		mt, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+		w.stream = w.opts.streamTypes[mt]
+		w.gz = w.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write compresses b and flushes the compressed data if the response is a
+// stream.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.gz == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	n, err := w.gz.Write(b)
+	if err == nil && w.stream {
+		err = w.flush()
+	}
+	return n, err
+}
+
+// Flush implements the http.Flusher interface. It writes the data compressed
+// so far and flushes the underlying response writer if it supports it.
+func (w *compressWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.flush() // nolint: errcheck
+}
+
+// Unwrap returns the underlying response writer so that http.ResponseController
+// may access the features it supports.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack supports the http.Hijacker interface.
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", w.ResponseWriter)
+}
+
+// flush writes the pending compressed data to the underlying writer.
+func (w *compressWriter) flush() error {
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// close terminates the compressed stream and releases the compressor.
+func (w *compressWriter) close() {
+	if w.gz == nil {
+		return
+	}
+	w.gz.Close() // nolint: errcheck
+	w.gz.Reset(nil)
+	w.pool.Put(w.gz)
+	w.gz = nil
+}
+
+// acceptsGzip returns true if the given Accept-Encoding header value accepts
+// the gzip encoding.
+func acceptsGzip(header string) bool {
+	for _, enc := range strings.Split(header, ",") {
+		parts := strings.Split(enc, ";")
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if q, err := strconv.ParseFloat(p[2:], 64); err == nil && q == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompress(t *testing.T) {
+	cases := map[string]struct {
+		acceptEncoding string
+		method         string
+		status         int
+		encoding       string
+		wantGzip       bool
+	}{
+		"gzip":             {"gzip, deflate", "GET", http.StatusOK, "", true},
+		"wildcard":         {"*", "GET", http.StatusOK, "", true},
+		"not accepted":     {"deflate", "GET", http.StatusOK, "", false},
+		"refused":          {"gzip;q=0, deflate", "GET", http.StatusOK, "", false},
+		"head":             {"gzip", "HEAD", http.StatusOK, "", false},
+		"no content":       {"gzip", "GET", http.StatusNoContent, "", false},
+		"already encoded":  {"gzip", "GET", http.StatusOK, "br", false},
+		"no accept header": {"", "GET", http.StatusOK, "", false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			handler := Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if c.encoding != "" {
+					w.Header().Set("Content-Encoding", c.encoding)
+				}
+				w.Header().Set("Content-Length", "11")
+				w.WriteHeader(c.status)
+				if c.status != http.StatusNoContent {
+					w.Write([]byte("hello world")) // nolint: errcheck
+				}
+			}))
+			req := httptest.NewRequest(c.method, "/", nil)
+			if c.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", c.acceptEncoding)
+			}
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			if rw.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected Accept-Encoding", rw.Header().Get("Vary"))
+			}
+			gotGzip := rw.Header().Get("Content-Encoding") == "gzip"
+			if gotGzip != c.wantGzip {
+				t.Fatalf("got gzip %v, expected %v", gotGzip, c.wantGzip)
+			}
+			if !gotGzip {
+				return
+			}
+			if rw.Header().Get("Content-Length") != "" {
+				t.Errorf("got Content-Length %q, expected none", rw.Header().Get("Content-Length"))
+			}
+			gz, err := gzip.NewReader(rw.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := ioutil.ReadAll(gz)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != "hello world" {
+				t.Errorf("got body %q, expected %q", b, "hello world")
+			}
+		})
+	}
+}
+
+func TestCompressStream(t *testing.T) {
+	events := []string{"data: one\n\n", "data: two\n\n", "data: three\n\n"}
+	rw := httptest.NewRecorder()
+	handler := Compress()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		var received string
+		for _, e := range events {
+			w.Write([]byte(e)) // nolint: errcheck
+			received += e
+			// Each event must be decodable as soon as it is written.
+			gz, err := gzip.NewReader(bytes.NewReader(rw.Body.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := make([]byte, len(received))
+			if _, err := io.ReadFull(gz, b); err != nil {
+				t.Fatalf("event %q not flushed: %s", e, err)
+			}
+			if string(b) != received {
+				t.Errorf("got %q, expected %q", b, received)
+			}
+			if !rw.Flushed {
+				t.Error("response writer not flushed")
+			}
+		}
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	handler.ServeHTTP(rw, req)
+}
+
+func TestCompressInvalidLevel(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Compress(CompressLevel(42))
+}
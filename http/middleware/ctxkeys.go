@@ -65,4 +65,22 @@ const (
 	// RequestXCSRFTokenKey is the request context key used to store X-Csrf-Token header
 	// created by the PopulateRequestContext middleware.
 	RequestXCSRFTokenKey
+
+	// SignatureKeyIDKey is the request context key used to store the ID of
+	// the key used to sign the request by the VerifySignature and
+	// VerifySignedURL middlewares.
+	SignatureKeyIDKey
+
+	// ClientIdentityKey is the request context key used to store the
+	// identity of the client certificate verified by the MutualTLS
+	// middleware.
+	ClientIdentityKey
+
+	// RawRequestKey is the request context key used to store the raw HTTP
+	// request by the Raw middleware.
+	RawRequestKey
+
+	// RawResponseKey is the request context key used to store the response
+	// writer by the Raw middleware.
+	RawResponseKey
 )
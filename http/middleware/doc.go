@@ -8,6 +8,13 @@ The package contains the following middlewares:
     a HTTP request.
   * Tracing middleware for server and client.
   * AWS X-Ray middleware for server and client that produce X-Ray segments.
+  * Metrics server middleware reporting response sizes and encoding times.
+  * Compress server middleware compressing responses with gzip including
+    streamed responses such as server-sent events.
+  * Maintenance server middleware responding with 503 Service Unavailable
+    while a MaintenanceSwitch is enabled for all or some of the resources.
+  * RequestVariant server middleware reading the variant requested by the
+    client from a header for the Variants endpoint middleware.
 
 Example to use the server middleware:
 
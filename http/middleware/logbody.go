@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// LogBodiesOption configures the LogBodies middleware.
+	LogBodiesOption func(*logBodiesOptions)
+
+	logBodiesOptions struct {
+		limit  int
+		redact map[string]struct{}
+		re     *regexp.Regexp
+	}
+
+	// bodyCapture is a ResponseCapture that also records the first bytes
+	// of the response body.
+	bodyCapture struct {
+		*ResponseCapture
+		buf   bytes.Buffer
+		limit int
+	}
+)
+
+// Redacted is the value logged in place of redacted fields.
+const Redacted = "[REDACTED]"
+
+// LogBodies returns a middleware that logs incoming HTTP requests and outgoing
+// responses similarly to Log and that also logs the request and response
+// bodies. Bodies are captured up to a limit (4KB by default, see LogBodyLimit)
+// and the values of the JSON fields listed with RedactFields are replaced with
+// "[REDACTED]" before being logged. The SensitiveFields variable generated in
+// the service package lists the attributes marked with the "sensitive"
+// metadata in the design:
+//
+//    handler = middleware.LogBodies(logger, middleware.RedactFields(svc.SensitiveFields...))(handler)
+//
+// Fields are redacted at any depth. Bodies that are truncated or that are not
+// valid JSON are redacted on a best effort basis: only string, number and
+// boolean values are replaced.
+func LogBodies(l middleware.Logger, opts ...LogBodiesOption) func(http.Handler) http.Handler {
+	o := &logBodiesOptions{limit: 4096, redact: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.compile()
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reqID := r.Context().Value(middleware.RequestIDKey)
+			if reqID == nil {
+				reqID = shortID()
+			}
+			started := time.Now()
+
+			var reqBody []byte
+			if r.Body != nil && r.Body != http.NoBody {
+				head, err := io.ReadAll(io.LimitReader(r.Body, int64(o.limit)))
+				if err != nil {
+					head = []byte("failed to read body: " + err.Error())
+				}
+				reqBody = head
+				r.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(head), r.Body), Closer: r.Body}
+			}
+			l.Log("id", reqID,
+				"req", r.Method+" "+r.URL.String(),
+				"from", from(r),
+				"body", o.redactBody(reqBody))
+
+			bc := &bodyCapture{ResponseCapture: CaptureResponse(w), limit: o.limit}
+			h.ServeHTTP(bc, r)
+
+			l.Log("id", reqID,
+				"status", bc.StatusCode,
+				"bytes", bc.ContentLength,
+				"time", time.Since(started).String(),
+				"body", o.redactBody(bc.buf.Bytes()))
+		})
+	}
+}
+
+// LogBodyLimit sets the maximum number of bytes of the request and response
+// bodies that get logged. The default is 4096.
+func LogBodyLimit(n int) LogBodiesOption {
+	return func(o *logBodiesOptions) {
+		o.limit = n
+	}
+}
+
+// RedactFields adds the names of the JSON fields whose values must not be
+// logged.
+func RedactFields(names ...string) LogBodiesOption {
+	return func(o *logBodiesOptions) {
+		for _, n := range names {
+			o.redact[n] = struct{}{}
+		}
+	}
+}
+
+// Write records the first bytes of the response body and writes them to the
+// underlying response writer.
+func (w *bodyCapture) Write(b []byte) (int, error) {
+	if rem := w.limit - w.buf.Len(); rem > 0 {
+		if len(b) < rem {
+			rem = len(b)
+		}
+		w.buf.Write(b[:rem])
+	}
+	return w.ResponseCapture.Write(b)
+}
+
+// compile builds the regular expression used to redact the bodies that are
+// not valid JSON.
+func (o *logBodiesOptions) compile() {
+	if len(o.redact) == 0 {
+		return
+	}
+	names := make([]string, 0, len(o.redact))
+	for n := range o.redact {
+		names = append(names, regexp.QuoteMeta(n))
+	}
+	o.re = regexp.MustCompile(`("(?:` + strings.Join(names, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[-+.\w]+)`)
+}
+
+// redactBody returns the body with the values of the sensitive fields
+// replaced.
+func (o *logBodiesOptions) redactBody(b []byte) string {
+	if len(b) == 0 || o.re == nil {
+		return string(b)
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err == nil {
+		if red, err := json.Marshal(o.redactValue(v)); err == nil {
+			return string(red)
+		}
+	}
+	return o.re.ReplaceAllString(string(b), `${1}"`+Redacted+`"`)
+}
+
+// redactValue replaces the values of the sensitive fields found in the
+// decoded JSON value v.
+func (o *logBodiesOptions) redactValue(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		for k, val := range actual {
+			if _, ok := o.redact[k]; ok {
+				actual[k] = Redacted
+				continue
+			}
+			actual[k] = o.redactValue(val)
+		}
+	case []interface{}:
+		for i, val := range actual {
+			actual[i] = o.redactValue(val)
+		}
+	}
+	return v
+}
+
+// multiReadCloser reads from Reader and closes Closer.
+type multiReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+type logEntries [][]interface{}
+
+func (l *logEntries) Log(keyvals ...interface{}) error {
+	*l = append(*l, keyvals)
+	return nil
+}
+
+// body returns the value logged under the "body" key of the i-th entry.
+func (l logEntries) body(i int) string {
+	kv := l[i]
+	for j := 0; j < len(kv)-1; j += 2 {
+		if kv[j] == "body" {
+			return fmt.Sprint(kv[j+1])
+		}
+	}
+	return ""
+}
+
+func TestLogBodies(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Options  []httpm.LogBodiesOption
+		Request  string
+		Response string
+		ExpReq   string
+		ExpResp  string
+	}{
+		{"no-redaction", nil, `{"password":"secret"}`, `{"token":"abc"}`,
+			`{"password":"secret"}`, `{"token":"abc"}`},
+		{"redacted", []httpm.LogBodiesOption{httpm.RedactFields("password", "token")},
+			`{"user":"joe","password":"secret"}`, `{"items":[{"token":"abc","n":1}]}`,
+			`{"password":"[REDACTED]","user":"joe"}`, `{"items":[{"n":1,"token":"[REDACTED]"}]}`},
+		{"truncated", []httpm.LogBodiesOption{httpm.RedactFields("password"), httpm.LogBodyLimit(24)},
+			`{"password":"secret","user":"joe"}`, `{"password":12345678901234567890}`,
+			`{"password":"[REDACTED]","us`, `{"password":"[REDACTED]"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var logs logEntries
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				if string(b) != c.Request {
+					t.Errorf("got request body %q, expected %q", string(b), c.Request)
+				}
+				w.Write([]byte(c.Response)) // nolint: errcheck
+			})
+			rw := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(c.Request))
+			httpm.LogBodies(&logs, c.Options...)(h).ServeHTTP(rw, req)
+			if rw.Body.String() != c.Response {
+				t.Errorf("got response body %q, expected %q", rw.Body.String(), c.Response)
+			}
+			if len(logs) != 2 {
+				t.Fatalf("got %d log entries, expected 2", len(logs))
+			}
+			if got := logs.body(0); got != c.ExpReq {
+				t.Errorf("got logged request body %q, expected %q", got, c.ExpReq)
+			}
+			if got := logs.body(1); got != c.ExpResp {
+				t.Errorf("got logged response body %q, expected %q", got, c.ExpResp)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+// UnderMaintenance is the name of the error used by the Maintenance middleware
+// to reject requests.
+const UnderMaintenance = "maintenance"
+
+type (
+	// MaintenanceSwitch is a kill switch that puts the HTTP server, or the
+	// resources served under given path prefixes, in maintenance mode. The
+	// switch is toggled with the Enable and Disable methods, e.g. from a
+	// command line flag or a configuration watcher, with the admin endpoint
+	// returned by Handler or with the file given to NewMaintenanceSwitch.
+	MaintenanceSwitch struct {
+		file string
+
+		mu       sync.RWMutex
+		enabled  bool
+		prefixes []string
+
+		// file state cache
+		fileMu       sync.Mutex
+		fileChecked  time.Time
+		fileEnabled  bool
+		filePrefixes []string
+	}
+
+	// MaintenanceOption configures the Maintenance middleware.
+	MaintenanceOption func(*maintenanceOptions)
+
+	maintenanceOptions struct {
+		contentType string
+		retryAfter  time.Duration
+		encoder     func(context.Context, http.ResponseWriter) goahttp.Encoder
+		formatter   func(err error) goahttp.Statuser
+	}
+
+	// maintenanceError is the error wrapped by the service error written by
+	// the Maintenance middleware. It implements RetryAfter so that the HTTP
+	// error encoder sets the Retry-After response header.
+	maintenanceError struct {
+		retryAfter time.Duration
+	}
+
+	// maintenanceState is the representation of the switch state used by
+	// the admin endpoint.
+	maintenanceState struct {
+		Enabled  bool     `json:"enabled"`
+		Prefixes []string `json:"prefixes,omitempty"`
+	}
+)
+
+// errMaintenance is the error written by the Maintenance middleware when no
+// Retry-After duration is set.
+var errMaintenance = errors.New("the service is down for maintenance")
+
+// maintenanceFileInterval is the minimum interval between two checks of the
+// maintenance file.
+const maintenanceFileInterval = time.Second
+
+// NewMaintenanceSwitch returns a disabled maintenance switch. If file is not
+// empty the maintenance mode is also enabled while the file exists so that it
+// can be toggled with "touch" and "rm" across deployments. The file may list
+// the path prefixes of the resources under maintenance, one per line, all the
+// resources are under maintenance if it is empty. The file is checked at most
+// once per second.
+func NewMaintenanceSwitch(file string) *MaintenanceSwitch {
+	return &MaintenanceSwitch{file: file}
+}
+
+// Maintenance returns a middleware that responds with 503 Service Unavailable
+// to the requests made to the resources under maintenance according to sw:
+//
+//    sw := middleware.NewMaintenanceSwitch("/var/run/myapi/maintenance")
+//    handler = middleware.Maintenance(sw, middleware.MaintenanceRetryAfter(10*time.Minute))(handler)
+//
+// The response body is the goa error response of a temporary "maintenance"
+// error encoded with goahttp.ResponseEncoder using the content type set with
+// MaintenanceContentType if any. The Retry-After header is set if a duration
+// is given with MaintenanceRetryAfter.
+func Maintenance(sw *MaintenanceSwitch, opts ...MaintenanceOption) func(http.Handler) http.Handler {
+	o := &maintenanceOptions{encoder: goahttp.ResponseEncoder}
+	for _, opt := range opts {
+		opt(o)
+	}
+	encodeError := goahttp.ErrorEncoder(o.encoder, o.formatter)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !sw.Active(r) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+			if o.contentType != "" {
+				ctx = context.WithValue(ctx, goahttp.ContentTypeKey, o.contentType)
+			}
+			var err error = errMaintenance
+			if o.retryAfter > 0 {
+				err = &maintenanceError{retryAfter: o.retryAfter}
+			}
+			encodeError(ctx, w, goa.NewServiceError(err, UnderMaintenance, false, true, false)) // nolint: errcheck
+		})
+	}
+}
+
+// MaintenanceContentType sets the content type of the responses written by the
+// Maintenance middleware, e.g. "application/vnd.maintenance+json". The
+// content type also selects the encoder, the encoder is negotiated with the
+// request Accept header by default.
+func MaintenanceContentType(ct string) MaintenanceOption {
+	return func(o *maintenanceOptions) {
+		o.contentType = ct
+	}
+}
+
+// MaintenanceRetryAfter sets the value of the Retry-After header of the
+// responses written by the Maintenance middleware.
+func MaintenanceRetryAfter(d time.Duration) MaintenanceOption {
+	return func(o *maintenanceOptions) {
+		o.retryAfter = d
+	}
+}
+
+// MaintenanceEncoder sets the encoder and error formatter used to write the
+// responses of the Maintenance middleware, typically to the encoder and
+// formatter given to the generated servers.
+func MaintenanceEncoder(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(err error) goahttp.Statuser) MaintenanceOption {
+	return func(o *maintenanceOptions) {
+		o.encoder = encoder
+		o.formatter = formatter
+	}
+}
+
+// Enable enables the maintenance mode for the resources whose request paths
+// start with one of the given prefixes, e.g. "/orders", or for all the
+// resources if no prefix is given.
+func (sw *MaintenanceSwitch) Enable(prefixes ...string) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.enabled = true
+	sw.prefixes = prefixes
+}
+
+// Disable disables the maintenance mode enabled with Enable. The mode remains
+// enabled while the maintenance file exists.
+func (sw *MaintenanceSwitch) Disable() {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sw.enabled = false
+	sw.prefixes = nil
+}
+
+// Active returns true if the resource requested by r is under maintenance.
+func (sw *MaintenanceSwitch) Active(r *http.Request) bool {
+	sw.mu.RLock()
+	enabled, prefixes := sw.enabled, sw.prefixes
+	sw.mu.RUnlock()
+	if enabled && matchPrefixes(r.URL.Path, prefixes) {
+		return true
+	}
+	if enabled, prefixes := sw.checkFile(); enabled && matchPrefixes(r.URL.Path, prefixes) {
+		return true
+	}
+	return false
+}
+
+// Handler returns the admin endpoint that toggles the switch. GET requests
+// return the switch state as a JSON object with "enabled" and "prefixes"
+// fields, PUT and POST requests enable the maintenance mode for the prefixes
+// listed in the optional JSON request body, e.g. {"prefixes":["/orders"]},
+// and DELETE requests disable it. The endpoint must only be reachable by the
+// operators, for example by serving it on an internal port.
+func (sw *MaintenanceSwitch) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			var state maintenanceState
+			if r.Body != nil && r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+					http.Error(w, fmt.Sprintf("invalid request body: %s", err), http.StatusBadRequest)
+					return
+				}
+			}
+			sw.Enable(state.Prefixes...)
+		case http.MethodDelete:
+			sw.Disable()
+		default:
+			w.Header().Set("Allow", "GET, HEAD, POST, PUT, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		sw.mu.RLock()
+		state := maintenanceState{Enabled: sw.enabled, Prefixes: sw.prefixes}
+		sw.mu.RUnlock()
+		if enabled, prefixes := sw.checkFile(); enabled && !state.Enabled {
+			state = maintenanceState{Enabled: true, Prefixes: prefixes}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state) // nolint: errcheck
+	})
+}
+
+// checkFile returns whether the maintenance file exists and the prefixes it
+// lists.
+func (sw *MaintenanceSwitch) checkFile() (bool, []string) {
+	if sw.file == "" {
+		return false, nil
+	}
+	sw.fileMu.Lock()
+	defer sw.fileMu.Unlock()
+	if now := time.Now(); now.Sub(sw.fileChecked) >= maintenanceFileInterval {
+		sw.fileChecked = now
+		sw.fileEnabled, sw.filePrefixes = false, nil
+		if b, err := os.ReadFile(sw.file); err == nil {
+			sw.fileEnabled = true
+			for _, line := range strings.Split(string(b), "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					sw.filePrefixes = append(sw.filePrefixes, line)
+				}
+			}
+		}
+	}
+	return sw.fileEnabled, sw.filePrefixes
+}
+
+// matchPrefixes returns true if prefixes is empty or if path is or is under
+// one of the prefixes.
+func matchPrefixes(path string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		p = strings.TrimSuffix(p, "/")
+		if p == "" || path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Error returns the error message.
+func (e *maintenanceError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", errMaintenance, e.retryAfter)
+}
+
+// RetryAfter returns the duration after which the request may be retried.
+func (e *maintenanceError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
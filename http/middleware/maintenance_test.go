@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaintenance(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok")) // nolint: errcheck
+	})
+	sw := NewMaintenanceSwitch("")
+	h := Maintenance(sw, MaintenanceRetryAfter(90*time.Second), MaintenanceContentType("application/vnd.maintenance+json"))(ok)
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	if w := serve("/orders/1"); w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Errorf("got %d %q while disabled, expected 200 \"ok\"", w.Code, w.Body.String())
+	}
+
+	sw.Enable()
+	w := serve("/orders/1")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, expected 503", w.Code)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "90" {
+		t.Errorf("got Retry-After %q, expected \"90\"", ra)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/vnd.maintenance+json" {
+		t.Errorf("got content type %q, expected the maintenance media type", ct)
+	}
+	if !strings.Contains(w.Body.String(), `"name":"maintenance"`) {
+		t.Errorf("got body %q, expected a maintenance error", w.Body.String())
+	}
+
+	sw.Enable("/orders")
+	if w := serve("/orders"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d for /orders, expected 503", w.Code)
+	}
+	if w := serve("/orders/1"); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d for /orders/1, expected 503", w.Code)
+	}
+	if w := serve("/ordersx"); w.Code != http.StatusOK {
+		t.Errorf("got status %d for /ordersx, expected 200", w.Code)
+	}
+
+	sw.Disable()
+	if w := serve("/orders/1"); w.Code != http.StatusOK {
+		t.Errorf("got status %d after Disable, expected 200", w.Code)
+	}
+}
+
+func TestMaintenanceNoRetryAfter(t *testing.T) {
+	sw := NewMaintenanceSwitch("")
+	sw.Enable()
+	w := httptest.NewRecorder()
+	Maintenance(sw)(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, expected 503", w.Code)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "" {
+		t.Errorf("got Retry-After %q, expected none", ra)
+	}
+}
+
+func TestMaintenanceFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "maintenance")
+	sw := NewMaintenanceSwitch(file)
+	active := func(path string) bool {
+		sw.fileChecked = time.Time{} // bypass the check interval
+		return sw.Active(httptest.NewRequest("GET", path, nil))
+	}
+	if active("/orders") {
+		t.Error("got active without file")
+	}
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !active("/orders") || !active("/users") {
+		t.Error("got inactive with empty file, expected all resources under maintenance")
+	}
+	if err := os.WriteFile(file, []byte("/orders\n\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !active("/orders/1") || active("/users") {
+		t.Error("expected only /orders to be under maintenance")
+	}
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if active("/orders") {
+		t.Error("got active after removing the file")
+	}
+}
+
+func TestMaintenanceSwitchHandler(t *testing.T) {
+	sw := NewMaintenanceSwitch("")
+	h := sw.Handler()
+	cases := []struct {
+		Method string
+		Body   string
+		Status int
+		State  string
+	}{
+		{"GET", "", http.StatusOK, `{"enabled":false}`},
+		{"PUT", `{"prefixes":["/orders"]}`, http.StatusOK, `{"enabled":true,"prefixes":["/orders"]}`},
+		{"GET", "", http.StatusOK, `{"enabled":true,"prefixes":["/orders"]}`},
+		{"POST", "", http.StatusOK, `{"enabled":true}`},
+		{"DELETE", "", http.StatusOK, `{"enabled":false}`},
+		{"PUT", `{`, http.StatusBadRequest, ""},
+		{"PATCH", "", http.StatusMethodNotAllowed, ""},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(c.Method, "/maintenance", strings.NewReader(c.Body)))
+		if w.Code != c.Status {
+			t.Errorf("%s %s: got status %d, expected %d", c.Method, c.Body, w.Code, c.Status)
+			continue
+		}
+		if c.State != "" && strings.TrimSpace(w.Body.String()) != c.State {
+			t.Errorf("%s %s: got state %s, expected %s", c.Method, c.Body, w.Body.String(), c.State)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// Metrics returns a middleware that calls report with the statistics of each
+// response once it has been written: the service and method that handled the
+// request, the view used to render the response, the status code, the number
+// of bytes written and the time spent encoding the body. The function may
+// record the statistics with any metrics library to find the endpoints and
+// views that are expensive to render:
+//
+//    handler = middleware.Metrics(func(r *http.Request, s *goahttp.ResponseStats) {
+//        bytes.WithLabelValues(s.Service, s.Method, s.View).Observe(float64(s.Bytes))
+//        encode.WithLabelValues(s.Service, s.Method, s.View).Observe(s.EncodeDuration.Seconds())
+//    })(handler)
+//
+// The encoding duration is only recorded by the encoders returned by
+// goahttp.ResponseEncoder or wrapped with goahttp.MeasureEncoder. The service
+// and method names are empty for requests that are not handled by the
+// generated handlers.
+func Metrics(report func(*http.Request, *goahttp.ResponseStats)) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, stats := goahttp.WithResponseStats(r.Context())
+			rw := CaptureResponse(w)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+			stats.View = rw.Header().Get("goa-view")
+			stats.Status = rw.StatusCode
+			if stats.Status == 0 {
+				stats.Status = http.StatusOK
+			}
+			stats.Bytes = rw.ContentLength
+			report(r, stats)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goahttp "goa.design/goa/v3/http"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestMetrics(t *testing.T) {
+	cases := map[string]struct {
+		handler  http.HandlerFunc
+		expected goahttp.ResponseStats
+	}{
+		"generated": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), goa.ServiceKey, "svc")
+				ctx = context.WithValue(ctx, goa.MethodKey, "show")
+				w.Header().Set("goa-view", "tiny")
+				enc := goahttp.ResponseEncoder(ctx, w)
+				w.WriteHeader(http.StatusCreated)
+				enc.Encode(map[string]string{"id": "1"}) // nolint: errcheck
+			},
+			expected: goahttp.ResponseStats{Service: "svc", Method: "show", View: "tiny", Status: http.StatusCreated, Bytes: 11},
+		},
+		"plain": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("hello")) // nolint: errcheck
+			},
+			expected: goahttp.ResponseStats{Status: http.StatusOK, Bytes: 5},
+		},
+	}
+	for k, c := range cases {
+		handler, expected := c.handler, c.expected
+		t.Run(k, func(t *testing.T) {
+			var stats *goahttp.ResponseStats
+			h := Metrics(func(r *http.Request, s *goahttp.ResponseStats) {
+				stats = s
+			})(handler)
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+			if stats == nil {
+				t.Fatal("stats not reported")
+			}
+			actual := *stats
+			if expected.Service != "" && actual.EncodeDuration <= 0 {
+				t.Errorf("got encode duration %s, expected a positive duration", actual.EncodeDuration)
+			}
+			actual.EncodeDuration = 0
+			if actual != expected {
+				t.Errorf("got %+v, expected %+v", actual, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+)
+
+type (
+	// ClientIdentity is the identity of a client authenticated with a
+	// verified TLS certificate.
+	ClientIdentity struct {
+		// CommonName is the certificate subject common name.
+		CommonName string
+		// Organization is the certificate subject organization.
+		Organization []string
+		// DNSNames lists the DNS subject alternative names.
+		DNSNames []string
+		// URIs lists the URI subject alternative names, e.g. SPIFFE IDs.
+		URIs []string
+		// SerialNumber is the certificate serial number.
+		SerialNumber string
+		// Certificate is the verified client certificate.
+		Certificate *x509.Certificate
+	}
+
+	// MutualTLSOption configures the MutualTLS middleware.
+	MutualTLSOption func(*mtlsOptions)
+
+	mtlsOptions struct {
+		authorize func(context.Context, *ClientIdentity) error
+	}
+)
+
+// MutualTLS returns a middleware that authenticates requests made to
+// endpoints secured with a MutualTLSSecurity scheme. The server must be
+// configured to verify client certificates (see goahttp.MutualTLSConfig). The
+// middleware responds with 401 Unauthorized if the request was not made with
+// a verified client certificate and with 403 Forbidden if the authorization
+// function configured with MutualTLSAuthorize returns an error. On success the
+// client identity is stored in the request context under the
+// ClientIdentityKey key.
+func MutualTLS(opts ...MutualTLSOption) func(http.Handler) http.Handler {
+	o := new(mtlsOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+				http.Error(w, "missing verified client certificate", http.StatusUnauthorized)
+				return
+			}
+			id := NewClientIdentity(r.TLS.VerifiedChains[0][0])
+			if o.authorize != nil {
+				if err := o.authorize(r.Context(), id); err != nil {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+			}
+			ctx := context.WithValue(r.Context(), ClientIdentityKey, id)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// MutualTLSAuthorize sets a function that authorizes verified clients, for
+// example by checking the certificate common name against an allow list.
+func MutualTLSAuthorize(fn func(context.Context, *ClientIdentity) error) MutualTLSOption {
+	return func(o *mtlsOptions) {
+		o.authorize = fn
+	}
+}
+
+// NewClientIdentity builds the client identity from the given certificate.
+func NewClientIdentity(cert *x509.Certificate) *ClientIdentity {
+	uris := make([]string, len(cert.URIs))
+	for i, u := range cert.URIs {
+		uris[i] = u.String()
+	}
+	return &ClientIdentity{
+		CommonName:   cert.Subject.CommonName,
+		Organization: cert.Subject.Organization,
+		DNSNames:     cert.DNSNames,
+		URIs:         uris,
+		SerialNumber: cert.SerialNumber.String(),
+		Certificate:  cert,
+	}
+}
+
+// ContextClientIdentity returns the client identity stored in ctx by the
+// MutualTLS middleware, nil if there is none.
+func ContextClientIdentity(ctx context.Context) *ClientIdentity {
+	id, _ := ctx.Value(ClientIdentityKey).(*ClientIdentity)
+	return id
+}
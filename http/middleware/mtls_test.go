@@ -0,0 +1,57 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestMutualTLS(t *testing.T) {
+	var (
+		cert = &x509.Certificate{
+			Subject:      pkix.Name{CommonName: "billing"},
+			SerialNumber: big.NewInt(42),
+		}
+		verified = func() *http.Request {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+			return r
+		}
+		denyAll = httpm.MutualTLSAuthorize(func(context.Context, *httpm.ClientIdentity) error {
+			return errors.New("denied")
+		})
+	)
+	cases := map[string]struct {
+		request *http.Request
+		options []httpm.MutualTLSOption
+		status  int
+	}{
+		"no TLS":       {httptest.NewRequest("GET", "/", nil), nil, http.StatusUnauthorized},
+		"unauthorized": {verified(), []httpm.MutualTLSOption{denyAll}, http.StatusForbidden},
+		"verified":     {verified(), nil, http.StatusOK},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var id *httpm.ClientIdentity
+			h := httpm.MutualTLS(c.options...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id = httpm.ContextClientIdentity(r.Context())
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, c.request)
+			if w.Code != c.status {
+				t.Fatalf("got status %d, expected %d", w.Code, c.status)
+			}
+			if c.status == http.StatusOK && (id == nil || id.CommonName != "billing" || id.SerialNumber != "42") {
+				t.Errorf("got identity %+v, expected common name billing and serial 42", id)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// Raw returns a middleware that makes the raw HTTP request and the response
+// writer available to the service methods via the request context. The
+// response writer is a ResponseCapture which tracks the response status code
+// and length and passes through the http.Flusher, http.Pusher and
+// http.Hijacker interfaces of the underlying writer:
+//
+//    handler = middleware.Raw()(handler)
+//
+// The service methods retrieve them with RawRequest and RawResponse:
+//
+//    func (s *svc) Watch(ctx context.Context) error {
+//        w := middleware.RawResponse(ctx)
+//        conn, buf, err := w.Hijack()
+//        ...
+//    }
+//
+// Methods that write the response body themselves should use the
+// SkipResponseBodyEncodeDecode DSL so that the generated code does not
+// encode a response as well.
+func Raw() func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := CaptureResponse(w)
+			ctx := context.WithValue(r.Context(), RawRequestKey, r)
+			ctx = context.WithValue(ctx, RawResponseKey, rw)
+			h.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
+
+// RawRequest returns the HTTP request stored in the context by the Raw
+// middleware, nil if there is none. The request context is the context of the
+// request as received by the middleware.
+func RawRequest(ctx context.Context) *http.Request {
+	r, _ := ctx.Value(RawRequestKey).(*http.Request)
+	return r
+}
+
+// RawResponse returns the response writer stored in the context by the Raw
+// middleware, nil if there is none.
+func RawResponse(ctx context.Context) *ResponseCapture {
+	w, _ := ctx.Value(RawResponseKey).(*ResponseCapture)
+	return w
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	var (
+		req *http.Request
+		rw  *ResponseCapture
+	)
+	h := Raw()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = RawRequest(r.Context())
+		rw = RawResponse(r.Context())
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("hello")) // nolint: errcheck
+		rw.Flush()
+	}))
+	r := httptest.NewRequest("GET", "/watch", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if req == nil || req.URL.Path != "/watch" {
+		t.Fatalf("got request %v, expected /watch", req)
+	}
+	if rw == nil {
+		t.Fatal("got no response writer")
+	}
+	if rw.StatusCode != http.StatusAccepted || rw.ContentLength != 5 {
+		t.Errorf("got status %d and length %d, expected %d and 5", rw.StatusCode, rw.ContentLength, http.StatusAccepted)
+	}
+	if !w.Flushed {
+		t.Error("response was not flushed")
+	}
+	if rw.Unwrap() != w {
+		t.Error("Unwrap did not return the underlying writer")
+	}
+	if _, _, err := rw.Hijack(); err == nil {
+		t.Error("expected hijack to fail on a recorder")
+	}
+}
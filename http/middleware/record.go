@@ -0,0 +1,321 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type (
+	// RecordOption configures the Record middleware.
+	RecordOption func(*recordOptions)
+
+	recordOptions struct {
+		logBodiesOptions
+		headers map[string]struct{}
+		onError func(*http.Request, error)
+	}
+
+	// Recording is a request and response pair recorded by the Record
+	// middleware.
+	Recording struct {
+		// Time is the time the request was received.
+		Time time.Time `json:"time"`
+		// Request is the recorded request.
+		Request *RecordedRequest `json:"request"`
+		// Response is the recorded response.
+		Response *RecordedResponse `json:"response"`
+		// Redacted lists the names of the JSON fields whose values were
+		// redacted in the request and response bodies.
+		Redacted []string `json:"redacted,omitempty"`
+	}
+
+	// RecordedRequest is a recorded HTTP request.
+	RecordedRequest struct {
+		// Method is the request method.
+		Method string `json:"method"`
+		// URL is the request path and query string.
+		URL string `json:"url"`
+		// Header contains the request headers.
+		Header http.Header `json:"header,omitempty"`
+		// Body is the request body.
+		Body string `json:"body,omitempty"`
+		// Truncated is true if Body only contains the first bytes of the
+		// request body.
+		Truncated bool `json:"truncated,omitempty"`
+	}
+
+	// RecordedResponse is a recorded HTTP response.
+	RecordedResponse struct {
+		// Status is the response status code.
+		Status int `json:"status"`
+		// Header contains the response headers.
+		Header http.Header `json:"header,omitempty"`
+		// Body is the response body.
+		Body string `json:"body,omitempty"`
+		// Truncated is true if Body only contains the first bytes of the
+		// response body.
+		Truncated bool `json:"truncated,omitempty"`
+	}
+)
+
+// Record returns a middleware that records the incoming requests and their
+// responses to files in dir, one JSON file per request. The recordings may be
+// replayed against another deployment of the service with the "goa replay"
+// command which reports the responses that differ, e.g. to verify that a
+// rewrite behaves like the service it replaces:
+//
+//    handler = middleware.Record("/var/recordings", middleware.RecordRedact(svc.SensitiveFields...))(handler)
+//
+// The values of the JSON fields listed with RecordRedact and the values of the
+// Authorization, Proxy-Authorization, Cookie and Set-Cookie headers (see
+// RecordRedactHeaders) are replaced with "[REDACTED]" before being written.
+// Bodies are recorded up to a limit (1MB by default, see RecordBodyLimit).
+//
+// Record panics if dir cannot be created.
+func Record(dir string, opts ...RecordOption) func(http.Handler) http.Handler {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		panic(fmt.Sprintf("failed to create recording directory: %s", err))
+	}
+	o := &recordOptions{
+		logBodiesOptions: logBodiesOptions{limit: 1 << 20, redact: make(map[string]struct{})},
+		headers:          make(map[string]struct{}),
+	}
+	RecordRedactHeaders("Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie")(o)
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.compile()
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &Recording{
+				Time:    time.Now(),
+				Request: &RecordedRequest{Method: r.Method, URL: r.URL.RequestURI(), Header: o.redactHeader(r.Header)},
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				head, err := io.ReadAll(io.LimitReader(r.Body, int64(o.limit)+1))
+				r.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(head), r.Body), Closer: r.Body}
+				if err != nil {
+					o.fail(r, fmt.Errorf("failed to read request body: %w", err))
+					h.ServeHTTP(w, r)
+					return
+				}
+				if len(head) > o.limit {
+					head = head[:o.limit]
+					rec.Request.Truncated = true
+				}
+				rec.Request.Body = o.redactBody(head)
+			}
+
+			bc := &bodyCapture{ResponseCapture: CaptureResponse(w), limit: o.limit}
+			h.ServeHTTP(bc, r)
+
+			status := bc.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			rec.Response = &RecordedResponse{
+				Status:    status,
+				Header:    o.redactHeader(w.Header()),
+				Body:      o.redactBody(bc.buf.Bytes()),
+				Truncated: bc.ContentLength > bc.buf.Len(),
+			}
+			for n := range o.redact {
+				rec.Redacted = append(rec.Redacted, n)
+			}
+			sort.Strings(rec.Redacted)
+			if err := writeRecording(dir, rec); err != nil {
+				o.fail(r, err)
+			}
+		})
+	}
+}
+
+// RecordRedact adds the names of the JSON fields whose values must not be
+// recorded.
+func RecordRedact(names ...string) RecordOption {
+	return func(o *recordOptions) {
+		for _, n := range names {
+			o.redact[n] = struct{}{}
+		}
+	}
+}
+
+// RecordRedactHeaders adds the names of the headers whose values must not be
+// recorded.
+func RecordRedactHeaders(names ...string) RecordOption {
+	return func(o *recordOptions) {
+		for _, n := range names {
+			o.headers[http.CanonicalHeaderKey(n)] = struct{}{}
+		}
+	}
+}
+
+// RecordBodyLimit sets the maximum number of bytes of the request and response
+// bodies that get recorded. The default is 1MB.
+func RecordBodyLimit(n int) RecordOption {
+	return func(o *recordOptions) {
+		o.limit = n
+	}
+}
+
+// RecordOnError sets a function called with the requests that could not be
+// recorded. Errors are ignored by default.
+func RecordOnError(fn func(*http.Request, error)) RecordOption {
+	return func(o *recordOptions) {
+		o.onError = fn
+	}
+}
+
+// ReadRecording reads the recording stored in the file at path.
+func ReadRecording(path string) (*Recording, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rec Recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+	}
+	if rec.Request == nil || rec.Response == nil {
+		return nil, fmt.Errorf("invalid recording %s: missing request or response", path)
+	}
+	return &rec, nil
+}
+
+// Diff compares the status and body of a response to the replayed request with
+// the recorded response and returns the differences, nil if there are none.
+// The fields redacted in the recording and the fields listed in ignore are
+// ignored. JSON bodies are compared structurally, the differences are
+// reported using the JSON paths of the values that differ e.g.
+// "$.items[0].name". Bodies are not compared if the recorded body is
+// truncated.
+func (rec *Recording) Diff(status int, body []byte, ignore ...string) []string {
+	var diffs []string
+	if status != rec.Response.Status {
+		diffs = append(diffs, fmt.Sprintf("status: %d != %d", rec.Response.Status, status))
+	}
+	if rec.Response.Truncated {
+		return diffs
+	}
+	o := &logBodiesOptions{redact: make(map[string]struct{})}
+	for _, n := range rec.Redacted {
+		o.redact[n] = struct{}{}
+	}
+	for _, n := range ignore {
+		o.redact[n] = struct{}{}
+	}
+	o.compile()
+	expected, actual := o.redactBody([]byte(rec.Response.Body)), o.redactBody(body)
+	var ev, av interface{}
+	if json.Unmarshal([]byte(expected), &ev) != nil || json.Unmarshal([]byte(actual), &av) != nil {
+		if expected != actual {
+			diffs = append(diffs, "body differs")
+		}
+		return diffs
+	}
+	return append(diffs, diffJSON("$", ev, av)...)
+}
+
+// diffJSON returns the differences between the decoded JSON values expected
+// and actual found at path.
+func diffJSON(path string, expected, actual interface{}) []string {
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := make([]string, 0, len(e)+len(a))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		for k := range a {
+			if _, ok := e[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		var diffs []string
+		for _, k := range keys {
+			ev, eok := e[k]
+			av, aok := a[k]
+			switch {
+			case !aok:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: missing", path, k))
+			case !eok:
+				diffs = append(diffs, fmt.Sprintf("%s.%s: unexpected %s", path, k, jsonString(av)))
+			default:
+				diffs = append(diffs, diffJSON(path+"."+k, ev, av)...)
+			}
+		}
+		return diffs
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		if len(e) != len(a) {
+			return []string{fmt.Sprintf("%s: length %d != %d", path, len(e), len(a))}
+		}
+		var diffs []string
+		for i := range e {
+			diffs = append(diffs, diffJSON(path+"["+strconv.Itoa(i)+"]", e[i], a[i])...)
+		}
+		return diffs
+	}
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: %s != %s", path, jsonString(expected), jsonString(actual))}
+}
+
+// jsonString returns the JSON representation of v.
+func jsonString(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// redactHeader returns a copy of h with the values of the sensitive headers
+// replaced.
+func (o *recordOptions) redactHeader(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	red := h.Clone()
+	for n := range red {
+		if _, ok := o.headers[n]; ok {
+			red[n] = []string{Redacted}
+		}
+	}
+	return red
+}
+
+// fail reports the recording error err.
+func (o *recordOptions) fail(r *http.Request, err error) {
+	if o.onError != nil {
+		o.onError(r, err)
+	}
+}
+
+// writeRecording writes rec to a new file in dir. The file names sort in the
+// order the requests were received.
+func writeRecording(dir string, rec *Recording) error {
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recording: %w", err)
+	}
+	name := fmt.Sprintf("%019d-%s.json", rec.Time.UnixNano(), shortID())
+	if err := os.WriteFile(filepath.Join(dir, name), b, 0644); err != nil {
+		return fmt.Errorf("failed to write recording: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	var body string
+	h := Record(dir, RecordRedact("password"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1,"password":"secret"}`)) // nolint: errcheck
+	}))
+	req := httptest.NewRequest("POST", "/users?notify=true", strings.NewReader(`{"name":"joe","password":"secret"}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Custom", "foo")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if body != `{"name":"joe","password":"secret"}` {
+		t.Errorf("got body %q, expected the original request body", body)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("got %d recordings, expected 1", len(files))
+	}
+	rec, err := ReadRecording(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Request.Method != "POST" || rec.Request.URL != "/users?notify=true" {
+		t.Errorf("got request %s %s, expected POST /users?notify=true", rec.Request.Method, rec.Request.URL)
+	}
+	if rec.Request.Body != `{"name":"joe","password":"[REDACTED]"}` {
+		t.Errorf("got request body %q", rec.Request.Body)
+	}
+	if got := rec.Request.Header.Get("Authorization"); got != Redacted {
+		t.Errorf("got Authorization header %q, expected %q", got, Redacted)
+	}
+	if got := rec.Request.Header.Get("X-Custom"); got != "foo" {
+		t.Errorf("got X-Custom header %q, expected %q", got, "foo")
+	}
+	if rec.Response.Status != http.StatusCreated {
+		t.Errorf("got status %d, expected %d", rec.Response.Status, http.StatusCreated)
+	}
+	if rec.Response.Body != `{"id":1,"password":"[REDACTED]"}` {
+		t.Errorf("got response body %q", rec.Response.Body)
+	}
+	if got := rec.Response.Header.Get("Set-Cookie"); got != Redacted {
+		t.Errorf("got Set-Cookie header %q, expected %q", got, Redacted)
+	}
+	if !reflect.DeepEqual(rec.Redacted, []string{"password"}) {
+		t.Errorf("got redacted fields %v, expected [password]", rec.Redacted)
+	}
+}
+
+func TestRecordingDiff(t *testing.T) {
+	rec := &Recording{
+		Response: &RecordedResponse{Status: 200, Body: `{"id":1,"name":"joe","token":"[REDACTED]","tags":["a","b"]}`},
+		Redacted: []string{"token"},
+	}
+	cases := map[string]struct {
+		status   int
+		body     string
+		ignore   []string
+		expected []string
+	}{
+		"same":     {200, `{"tags":["a","b"],"token":"xyz","name":"joe","id":1}`, nil, nil},
+		"status":   {404, `{"id":1,"name":"joe","token":"xyz","tags":["a","b"]}`, nil, []string{"status: 200 != 404"}},
+		"value":    {200, `{"id":2,"name":"jim","token":"xyz","tags":["a","c"]}`, nil, []string{`$.id: 1 != 2`, `$.name: "joe" != "jim"`, `$.tags[1]: "b" != "c"`}},
+		"ignored":  {200, `{"id":2,"name":"joe","token":"xyz","tags":["a","b"]}`, []string{"id"}, nil},
+		"missing":  {200, `{"id":1,"token":"xyz","tags":["a"],"extra":true}`, nil, []string{`$.extra: unexpected true`, `$.name: missing`, `$.tags: length 2 != 1`}},
+		"not json": {200, `joe`, nil, []string{"body differs"}},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			diffs := rec.Diff(c.status, []byte(c.body), c.ignore...)
+			if !reflect.DeepEqual(diffs, c.expected) {
+				t.Errorf("got diffs %q, expected %q", diffs, c.expected)
+			}
+		})
+	}
+}
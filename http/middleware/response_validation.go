@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"goa.design/goa/v3/middleware"
+)
+
+type (
+	// ResponseValidationOption configures the ValidateResponses middleware.
+	ResponseValidationOption func(*responseValidationOptions)
+
+	responseValidationOptions struct {
+		fail bool
+	}
+
+	// specRoute is an operation of the OpenAPI specification.
+	specRoute struct {
+		method   string
+		segments []string
+		op       *openapi3.Operation
+	}
+
+	// bufferedResponse is a http.ResponseWriter that buffers the response
+	// until it has been validated.
+	bufferedResponse struct {
+		http.ResponseWriter
+		status   int
+		body     bytes.Buffer
+		hijacked bool
+	}
+)
+
+// ValidateResponses returns a middleware that validates the JSON bodies of the
+// responses against the OpenAPI 3 specification generated by goa in
+// gen/http/openapi3.json. The response schemas are derived from the result
+// types and views used in the design so that the middleware catches the
+// service implementations that return results whose shape violates the
+// design. Mismatches are logged with l, use ResponseValidationFail to also
+// replace the invalid responses with 500 Internal Server Error responses:
+//
+//    //go:embed gen/http/openapi3.json
+//    var spec []byte
+//
+//    if dev {
+//        handler = middleware.ValidateResponses(spec, adapter, middleware.ResponseValidationFail())(handler)
+//    }
+//
+// The middleware buffers the responses until they have been validated and is
+// thus intended for development and test environments. Responses to requests
+// that do not match an operation of the specification are not validated.
+//
+// ValidateResponses panics if spec is not a valid OpenAPI 3 specification.
+func ValidateResponses(spec []byte, l middleware.Logger, opts ...ResponseValidationOption) func(http.Handler) http.Handler {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		panic(fmt.Sprintf("invalid OpenAPI specification: %s", err))
+	}
+	o := new(responseValidationOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	var routes []*specRoute
+	for path, item := range doc.Paths {
+		for method, op := range item.Operations() {
+			routes = append(routes, &specRoute{method: method, segments: strings.Split(strings.Trim(path, "/"), "/"), op: op})
+		}
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op := matchRoute(routes, r)
+			if op == nil {
+				h.ServeHTTP(w, r)
+				return
+			}
+			buf := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(buf, r)
+			if buf.hijacked {
+				return
+			}
+			if err := validateResponse(op, buf); err != nil {
+				reqID := r.Context().Value(middleware.RequestIDKey)
+				if reqID == nil {
+					reqID = shortID()
+				}
+				l.Log("id", reqID, "req", r.Method+" "+r.URL.String(), "status", buf.status, "invalid-response", err.Error()) // nolint: errcheck
+				if o.fail {
+					w.Header().Del("Content-Length")
+					http.Error(w, "invalid response: "+err.Error(), http.StatusInternalServerError)
+					return
+				}
+			}
+			w.WriteHeader(buf.status)
+			w.Write(buf.body.Bytes()) // nolint: errcheck
+		})
+	}
+}
+
+// ResponseValidationFail makes the ValidateResponses middleware replace the
+// invalid responses with 500 Internal Server Error responses.
+func ResponseValidationFail() ResponseValidationOption {
+	return func(o *responseValidationOptions) {
+		o.fail = true
+	}
+}
+
+// WriteHeader records the status code.
+func (b *bufferedResponse) WriteHeader(code int) {
+	b.status = code
+}
+
+// Write buffers the response body.
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
+
+// Hijack supports the http.Hijacker interface so that websocket connections
+// can be established.
+func (b *bufferedResponse) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := b.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking: %T", b.ResponseWriter)
+	}
+	b.hijacked = true
+	return h.Hijack()
+}
+
+// matchRoute returns the operation whose path and method match the request,
+// nil if there is none. Paths with fewer parameters take precedence.
+func matchRoute(routes []*specRoute, r *http.Request) *openapi3.Operation {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var (
+		match  *openapi3.Operation
+		params = -1
+	)
+	for _, rt := range routes {
+		if rt.method != r.Method || len(rt.segments) != len(segments) {
+			continue
+		}
+		n := 0
+		for i, s := range rt.segments {
+			if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+				n++
+				continue
+			}
+			if s != segments[i] {
+				n = -1
+				break
+			}
+		}
+		if n >= 0 && (params < 0 || n < params) {
+			match, params = rt.op, n
+		}
+	}
+	return match
+}
+
+// validateResponse validates the buffered response body against the schema
+// of the operation response.
+func validateResponse(op *openapi3.Operation, b *bufferedResponse) error {
+	ref := op.Responses.Get(b.status)
+	if ref == nil {
+		ref = op.Responses.Default()
+	}
+	if ref == nil || ref.Value == nil {
+		return fmt.Errorf("status %d is not described in the design", b.status)
+	}
+	if b.body.Len() == 0 || len(ref.Value.Content) == 0 {
+		return nil
+	}
+	ct, _, _ := mime.ParseMediaType(b.Header().Get("Content-Type"))
+	if !strings.Contains(ct, "json") {
+		return nil
+	}
+	mt := ref.Value.Content.Get(ct)
+	if mt == nil {
+		mt = ref.Value.Content.Get("application/json")
+	}
+	if mt == nil || mt.Schema == nil || mt.Schema.Value == nil {
+		return nil
+	}
+	var val interface{}
+	if err := json.Unmarshal(b.body.Bytes(), &val); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return mt.Schema.Value.VisitJSON(val, openapi3.VisitAsResponse(), openapi3.MultiErrors())
+}
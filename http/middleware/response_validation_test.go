@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type bufferLogger struct {
+	strings.Builder
+}
+
+func (l *bufferLogger) Log(keyvals ...interface{}) error {
+	fmt.Fprintln(l, keyvals...)
+	return nil
+}
+
+const validationSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "test", "version": "1.0"},
+  "paths": {
+    "/bottles/{id}": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "id": {"type": "integer"},
+                    "name": {"type": "string", "maxLength": 10}
+                  },
+                  "required": ["id", "name"]
+                }
+              }
+            }
+          },
+          "404": {"description": "Not Found"}
+        }
+      }
+    }
+  }
+}`
+
+func TestValidateResponses(t *testing.T) {
+	cases := map[string]struct {
+		path   string
+		status int
+		body   string
+		fail   bool
+		code   int
+		logged string
+	}{
+		"valid":            {"/bottles/1", http.StatusOK, `{"id":1,"name":"merlot"}`, true, http.StatusOK, ""},
+		"no route":         {"/wines", http.StatusOK, `{"id":"x"}`, true, http.StatusOK, ""},
+		"no body":          {"/bottles/1", http.StatusNotFound, "", true, http.StatusNotFound, ""},
+		"missing property": {"/bottles/1", http.StatusOK, `{"id":1}`, true, http.StatusInternalServerError, `property "name" is missing`},
+		"invalid type":     {"/bottles/1", http.StatusOK, `{"id":"1","name":"merlot"}`, false, http.StatusOK, "must be set to integer"},
+		"undescribed":      {"/bottles/1", http.StatusTeapot, "", false, http.StatusTeapot, "status 418 is not described"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var buf bufferLogger
+			var opts []ResponseValidationOption
+			if c.fail {
+				opts = append(opts, ResponseValidationFail())
+			}
+			h := ValidateResponses([]byte(validationSpec), &buf, opts...)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if c.body != "" {
+					w.Header().Set("Content-Type", "application/json")
+				}
+				w.WriteHeader(c.status)
+				w.Write([]byte(c.body)) // nolint: errcheck
+			}))
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, httptest.NewRequest("GET", c.path, nil))
+			if rw.Code != c.code {
+				t.Errorf("got status %d, expected %d", rw.Code, c.code)
+			}
+			if c.code == c.status && rw.Body.String() != c.body {
+				t.Errorf("got body %q, expected %q", rw.Body.String(), c.body)
+			}
+			if c.logged == "" {
+				if buf.Len() > 0 {
+					t.Errorf("got log %q, expected none", buf.String())
+				}
+			} else if !strings.Contains(buf.String(), c.logged) {
+				t.Errorf("got log %q, expected it to contain %q", buf.String(), c.logged)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// ShadowOption configures the Shadow middleware.
+	ShadowOption func(*shadowOptions)
+
+	shadowOptions struct {
+		client      *http.Client
+		limit       int
+		timeout     time.Duration
+		concurrency int
+		onError     func(*http.Request, error)
+	}
+)
+
+// ShadowHeader is the header set on the requests sent to the shadow backend
+// by the Shadow middleware.
+const ShadowHeader = "X-Shadow-Request"
+
+// hopHeaders lists the hop-by-hop headers which only apply to the connection
+// of the original request and are thus removed from the mirrored requests, see
+// RFC 7230 section 6.1.
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Shadow returns a middleware that mirrors percent percent of the incoming
+// requests to the backend at target, e.g. "http://new-backend:8080". The
+// mirrored requests have the same method, path, query, headers and body as the
+// original requests minus the hop-by-hop headers (Connection, Upgrade etc.)
+// and set the X-Shadow-Request header. They are sent
+// asynchronously once the original request has been handled and their
+// responses are discarded so that the latency and the responses of the
+// original requests are unaffected:
+//
+//    handler = middleware.Shadow("http://orders-v2.internal", 10)(handler)
+//
+// Requests whose body is larger than the body limit (1MB by default, see
+// ShadowBodyLimit) are not mirrored. Requests are also not mirrored if the
+// maximum number of mirrored requests in flight (100 by default, see
+// ShadowConcurrency) is reached.
+//
+// Shadow panics if target is not a valid absolute URL.
+func Shadow(target string, percent int, opts ...ShadowOption) func(http.Handler) http.Handler {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		panic(fmt.Sprintf("invalid shadow target %q", target))
+	}
+	o := &shadowOptions{
+		client:      http.DefaultClient,
+		limit:       1 << 20,
+		timeout:     10 * time.Second,
+		concurrency: 100,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	slots := make(chan struct{}, o.concurrency)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if percent <= 0 || (percent < 100 && rand.Intn(100) >= percent) {
+				h.ServeHTTP(w, r)
+				return
+			}
+			var body []byte
+			if r.Body != nil && r.Body != http.NoBody {
+				head, err := io.ReadAll(io.LimitReader(r.Body, int64(o.limit)+1))
+				r.Body = &multiReadCloser{Reader: io.MultiReader(bytes.NewReader(head), r.Body), Closer: r.Body}
+				if err != nil || len(head) > o.limit {
+					h.ServeHTTP(w, r)
+					return
+				}
+				body = head
+			}
+			mirror := shadowRequest(u, r, body)
+			h.ServeHTTP(w, r)
+			// Acquire the slot once the original request has been handled
+			// so that a panicking handler cannot leak it.
+			select {
+			case slots <- struct{}{}:
+			default:
+				return
+			}
+			go func() {
+				defer func() { <-slots }()
+				o.send(mirror)
+			}()
+		})
+	}
+}
+
+// ShadowClient sets the HTTP client used to send the mirrored requests. The
+// default is http.DefaultClient.
+func ShadowClient(c *http.Client) ShadowOption {
+	return func(o *shadowOptions) {
+		o.client = c
+	}
+}
+
+// ShadowBodyLimit sets the maximum size in bytes of the bodies of the mirrored
+// requests. The default is 1MB.
+func ShadowBodyLimit(n int) ShadowOption {
+	return func(o *shadowOptions) {
+		o.limit = n
+	}
+}
+
+// ShadowTimeout sets the maximum duration of the mirrored requests. The
+// default is 10s.
+func ShadowTimeout(d time.Duration) ShadowOption {
+	return func(o *shadowOptions) {
+		o.timeout = d
+	}
+}
+
+// ShadowConcurrency sets the maximum number of mirrored requests in flight.
+// The default is 100.
+func ShadowConcurrency(n int) ShadowOption {
+	return func(o *shadowOptions) {
+		o.concurrency = n
+	}
+}
+
+// ShadowOnError sets a function called with the mirrored requests that could
+// not be sent. Errors are ignored by default.
+func ShadowOnError(fn func(*http.Request, error)) ShadowOption {
+	return func(o *shadowOptions) {
+		o.onError = fn
+	}
+}
+
+// shadowRequest returns the copy of r sent to target.
+func shadowRequest(target *url.URL, r *http.Request, body []byte) *http.Request {
+	u := *target
+	u.Path = strings.TrimSuffix(u.Path, "/") + r.URL.Path
+	u.RawPath = ""
+	u.RawQuery = r.URL.RawQuery
+	req := &http.Request{
+		Method:        r.Method,
+		URL:           &u,
+		Header:        r.Header.Clone(),
+		Host:          u.Host,
+		ContentLength: int64(len(body)),
+		Body:          http.NoBody,
+	}
+	if len(body) > 0 {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	for _, v := range req.Header["Connection"] {
+		for _, h := range strings.Split(v, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				req.Header.Del(h)
+			}
+		}
+	}
+	for _, h := range hopHeaders {
+		req.Header.Del(h)
+	}
+	req.Header.Set(ShadowHeader, "true")
+	return req
+}
+
+// send sends the mirrored request and discards the response.
+func (o *shadowOptions) send(req *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	resp, err := o.client.Do(req.WithContext(ctx))
+	if err != nil {
+		if o.onError != nil {
+			o.onError(req, err)
+		}
+		return
+	}
+	io.Copy(io.Discard, resp.Body) // nolint: errcheck
+	resp.Body.Close()
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShadow(t *testing.T) {
+	type mirrored struct {
+		method, uri, body, header, hop string
+	}
+	cases := map[string]struct {
+		percent  int
+		body     string
+		mirrored bool
+	}{
+		"none":      {0, "hello", false},
+		"all":       {100, "hello", true},
+		"too large": {100, strings.Repeat("x", 11), false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			received := make(chan mirrored, 1)
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				hop := r.Header.Get("X-Hop") + r.Header.Get("Upgrade") + r.Header.Get("Proxy-Authorization")
+				received <- mirrored{r.Method, r.URL.RequestURI(), string(b), r.Header.Get("X-Custom"), hop}
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer backend.Close()
+			var body string
+			h := Shadow(backend.URL+"/v2", c.percent, ShadowBodyLimit(10))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			req := httptest.NewRequest("POST", "/orders?id=1", strings.NewReader(c.body))
+			req.Header.Set("X-Custom", "foo")
+			req.Header.Set("Connection", "Upgrade, X-Hop")
+			req.Header.Set("X-Hop", "hop")
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Proxy-Authorization", "Basic Zm9vOmJhcg==")
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, req)
+			if rw.Code != http.StatusCreated {
+				t.Errorf("got status %d, expected %d", rw.Code, http.StatusCreated)
+			}
+			if body != c.body {
+				t.Errorf("got body %q, expected %q", body, c.body)
+			}
+			select {
+			case m := <-received:
+				if !c.mirrored {
+					t.Fatalf("unexpected mirrored request %v", m)
+				}
+				expected := mirrored{"POST", "/v2/orders?id=1", c.body, "foo", ""}
+				if m != expected {
+					t.Errorf("got mirrored request %v, expected %v", m, expected)
+				}
+			case <-time.After(200 * time.Millisecond):
+				if c.mirrored {
+					t.Error("request was not mirrored")
+				}
+			}
+		})
+	}
+}
+
+func TestShadowPanic(t *testing.T) {
+	received := make(chan struct{}, 1)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	}))
+	defer backend.Close()
+	panics := true
+	h := Shadow(backend.URL, 100, ShadowConcurrency(1))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if panics {
+			panic("boom")
+		}
+	}))
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("handler did not panic")
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	}()
+	panics = false
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Error("request was not mirrored after a handler panic")
+	}
+}
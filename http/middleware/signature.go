@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+type (
+	// SignatureKeyFunc returns the secret key with the given ID. It returns
+	// an error if the key is unknown.
+	SignatureKeyFunc func(ctx context.Context, keyID string) ([]byte, error)
+
+	// NonceStore records the nonces of signed requests to detect replays.
+	NonceStore interface {
+		// Add records the nonce until the given expiry. It returns false
+		// if the nonce was already recorded and has not expired yet.
+		Add(nonce string, expiry time.Time) bool
+	}
+
+	// SignatureOption configures the VerifySignature middleware.
+	SignatureOption func(*signatureOptions)
+
+	signatureOptions struct {
+		maxSkew time.Duration
+		maxBody int64
+		nonces  NonceStore
+	}
+
+	// memoryNonceStore is a NonceStore that keeps nonces in memory.
+	memoryNonceStore struct {
+		mu     sync.Mutex
+		nonces map[string]time.Time
+		sweep  time.Time
+	}
+)
+
+// VerifySignature returns a middleware that verifies the signature of requests
+// made to endpoints secured with a SignatureSecurity scheme. The signature must
+// be computed as described in goahttp.RequestSignature, for example using
+// goahttp.NewSigningDoer on the client side. The middleware rejects requests
+// with a 401 Unauthorized response if:
+//
+//   - the Authorization header is missing or malformed,
+//   - the key ID is unknown,
+//   - the Date header is missing or differs from the current time by more
+//     than the maximum skew (5 minutes by default),
+//   - the nonce was already used within the skew window (replay),
+//   - the signature does not match.
+//
+// The middleware reads the request body to compute the signature only once
+// the key is found and rejects bodies larger than the maximum body size (10MB
+// by default) with a 413 Request Entity Too Large response.
+//
+// On success the ID of the key used to sign the request is stored in the
+// request context under the SignatureKeyIDKey key.
+func VerifySignature(keys SignatureKeyFunc, opts ...SignatureOption) func(http.Handler) http.Handler {
+	o := &signatureOptions{maxSkew: 5 * time.Minute, maxBody: 10 << 20}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.nonces == nil {
+		o.nonces = NewMemoryNonceStore()
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := goahttp.ParseSignature(r.Header.Get("Authorization"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			date, err := http.ParseTime(r.Header.Get("Date"))
+			if err != nil {
+				http.Error(w, "missing or invalid Date header", http.StatusUnauthorized)
+				return
+			}
+			if skew := time.Since(date); skew > o.maxSkew || skew < -o.maxSkew {
+				http.Error(w, "request date outside of allowed window", http.StatusUnauthorized)
+				return
+			}
+			key, err := keys(r.Context(), p.KeyID)
+			if err != nil {
+				http.Error(w, "unknown signature key", http.StatusUnauthorized)
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, o.maxBody)
+			}
+			sig, err := goahttp.RequestSignature(r, p.Nonce, key)
+			if err != nil {
+				var maxErr *http.MaxBytesError
+				if errors.As(err, &maxErr) {
+					http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+				return
+			}
+			if !hmac.Equal([]byte(sig), []byte(p.Signature)) {
+				http.Error(w, "invalid signature", http.StatusUnauthorized)
+				return
+			}
+			if !o.nonces.Add(p.KeyID+":"+p.Nonce, date.Add(o.maxSkew)) {
+				http.Error(w, "replayed request", http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), SignatureKeyIDKey, p.KeyID)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// SignatureMaxSkew sets the maximum difference allowed between the request
+// Date header and the server clock. The default is 5 minutes.
+func SignatureMaxSkew(d time.Duration) SignatureOption {
+	return func(o *signatureOptions) {
+		o.maxSkew = d
+	}
+}
+
+// SignatureMaxBodySize sets the maximum size in bytes of the bodies of signed
+// requests. The default is 10MB.
+func SignatureMaxBodySize(n int64) SignatureOption {
+	return func(o *signatureOptions) {
+		o.maxBody = n
+	}
+}
+
+// SignatureNonceStore sets the store used to detect replayed requests. The
+// default is an in-memory store which is only suitable for single instance
+// deployments.
+func SignatureNonceStore(s NonceStore) SignatureOption {
+	return func(o *signatureOptions) {
+		o.nonces = s
+	}
+}
+
+// NewMemoryNonceStore returns a NonceStore that keeps nonces in memory.
+func NewMemoryNonceStore() NonceStore {
+	return &memoryNonceStore{nonces: make(map[string]time.Time)}
+}
+
+// Add records the nonce, it removes expired nonces at most once a minute.
+func (s *memoryNonceStore) Add(nonce string, expiry time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.After(s.sweep) {
+		for n, exp := range s.nonces {
+			if now.After(exp) {
+				delete(s.nonces, n)
+			}
+		}
+		s.sweep = now.Add(time.Minute)
+	}
+	if exp, ok := s.nonces[nonce]; ok && now.Before(exp) {
+		return false
+	}
+	s.nonces[nonce] = expiry
+	return true
+}
@@ -0,0 +1,109 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+	httpm "goa.design/goa/v3/http/middleware"
+)
+
+func TestVerifySignature(t *testing.T) {
+	var (
+		key  = []byte("s3cr3t")
+		keys = func(_ context.Context, id string) ([]byte, error) {
+			if id != "client" {
+				return nil, errors.New("unknown key")
+			}
+			return key, nil
+		}
+		newRequest = func(body string) *http.Request {
+			return httptest.NewRequest("POST", "/path?q=1", strings.NewReader(body))
+		}
+		signed = func(body string) *http.Request {
+			r := newRequest(body)
+			if err := goahttp.SignRequest(r, "client", key); err != nil {
+				t.Fatal(err)
+			}
+			return r
+		}
+		tampered = func() *http.Request {
+			r := signed("body")
+			r2 := newRequest("other")
+			r2.Header = r.Header
+			return r2
+		}
+		stale = func() *http.Request {
+			r := newRequest("body")
+			r.Header.Set("Date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+			if err := goahttp.SignRequest(r, "client", key); err != nil {
+				t.Fatal(err)
+			}
+			return r
+		}
+		unknownKey = func() *http.Request {
+			r := newRequest("body")
+			if err := goahttp.SignRequest(r, "other", key); err != nil {
+				t.Fatal(err)
+			}
+			return r
+		}
+	)
+	cases := map[string]struct {
+		request *http.Request
+		status  int
+	}{
+		"unsigned":    {newRequest("body"), http.StatusUnauthorized},
+		"tampered":    {tampered(), http.StatusUnauthorized},
+		"stale":       {stale(), http.StatusUnauthorized},
+		"unknown key": {unknownKey(), http.StatusUnauthorized},
+		"valid":       {signed("body"), http.StatusOK},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var keyID string
+			h := httpm.VerifySignature(keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keyID, _ = r.Context().Value(httpm.SignatureKeyIDKey).(string)
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, c.request)
+			if w.Code != c.status {
+				t.Fatalf("got status %d, expected %d: %s", w.Code, c.status, w.Body.String())
+			}
+			if c.status == http.StatusOK && keyID != "client" {
+				t.Errorf("got key ID %q, expected %q", keyID, "client")
+			}
+		})
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		h := httpm.VerifySignature(keys)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		r := signed("body")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+		}
+		replay := newRequest("body")
+		replay.Header = r.Header
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, replay)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("got status %d for replayed request, expected %d", w.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		h := httpm.VerifySignature(keys, httpm.SignatureMaxBodySize(4))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, signed("large body"))
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("got status %d, expected %d", w.Code, http.StatusRequestEntityTooLarge)
+		}
+	})
+}
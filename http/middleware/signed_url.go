@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// VerifySignedURL returns a middleware that only lets through requests made
+// with URLs signed with goahttp.SignURL that have not expired. keys returns
+// the key with the ID recorded in the signed URL. Requests with a missing,
+// expired or invalid signature are rejected with a 403 Forbidden response.
+// Since signed URLs are usually limited to a few endpoints the middleware is
+// typically applied to the corresponding handlers only:
+//
+//    server := reportssvr.New(endpoints, mux, dec, enc, eh, nil)
+//    server.Download = middleware.VerifySignedURL(keys)(server.Download)
+//
+// On success the ID of the key used to sign the URL is stored in the request
+// context under the SignatureKeyIDKey key.
+func VerifySignedURL(keys SignatureKeyFunc) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyID := goahttp.SignedURLKeyID(r.URL)
+			if keyID == "" {
+				http.Error(w, goahttp.ErrURLNotSigned.Error(), http.StatusForbidden)
+				return
+			}
+			key, err := keys(r.Context(), keyID)
+			if err != nil {
+				http.Error(w, "unknown signature key", http.StatusForbidden)
+				return
+			}
+			if err := goahttp.VerifyURL(r.URL, key, time.Now()); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			ctx := context.WithValue(r.Context(), SignatureKeyIDKey, keyID)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+func TestVerifySignedURL(t *testing.T) {
+	keys := func(_ context.Context, id string) ([]byte, error) {
+		if id != "k1" {
+			return nil, errors.New("unknown key")
+		}
+		return []byte("secret"), nil
+	}
+	u, _ := url.Parse("/reports/42")
+	cases := map[string]struct {
+		url    string
+		status int
+	}{
+		"valid":       {goahttp.SignURL(u, "k1", []byte("secret"), time.Now().Add(time.Minute)).String(), http.StatusOK},
+		"expired":     {goahttp.SignURL(u, "k1", []byte("secret"), time.Now().Add(-time.Minute)).String(), http.StatusForbidden},
+		"unknown key": {goahttp.SignURL(u, "k2", []byte("secret"), time.Now().Add(time.Minute)).String(), http.StatusForbidden},
+		"not signed":  {"/reports/42", http.StatusForbidden},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var keyID interface{}
+			h := VerifySignedURL(keys)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keyID = r.Context().Value(SignatureKeyIDKey)
+			}))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", c.url, nil))
+			if w.Code != c.status {
+				t.Errorf("got status %d, expected %d", w.Code, c.status)
+			}
+			if c.status == http.StatusOK && keyID != "k1" {
+				t.Errorf("got key ID %v, expected k1", keyID)
+			}
+		})
+	}
+}
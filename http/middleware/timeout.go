@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+// RequestTimeout returns a middleware which sets the deadline of the request
+// context according to the request X-Request-Timeout header. max caps the
+// duration requested by clients, zero means no cap. Requests that do not set
+// the header are not affected.
+//
+// The generated handlers of the methods that define a Timeout in the design
+// apply both the design timeout and the header so that this middleware is
+// only needed to let clients set deadlines on the other methods:
+//
+//    handler = middleware.RequestTimeout(30 * time.Second)(handler)
+//
+func RequestTimeout(max time.Duration) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d, ok := goahttp.RequestTimeout(r)
+			if !ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+			if max > 0 && d > max {
+				d = max
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goahttp "goa.design/goa/v3/http"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	cases := map[string]struct {
+		header   string
+		max      time.Duration
+		expected time.Duration
+	}{
+		"no header": {"", time.Second, 0},
+		"header":    {"2s", 0, 2 * time.Second},
+		"capped":    {"1m", time.Second, time.Second},
+	}
+	for k, c := range cases {
+		header, max, expected := c.header, c.max, c.expected
+		t.Run(k, func(t *testing.T) {
+			var (
+				deadline time.Time
+				ok       bool
+			)
+			h := RequestTimeout(max)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok = r.Context().Deadline()
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if header != "" {
+				req.Header.Set(goahttp.RequestTimeoutHeader, header)
+			}
+			start := time.Now()
+			h.ServeHTTP(httptest.NewRecorder(), req)
+			if expected == 0 {
+				if ok {
+					t.Errorf("got deadline %s, expected none", deadline)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("got no deadline")
+			}
+			if d := deadline.Sub(start); d < expected || d > expected+time.Second/2 {
+				t.Errorf("got deadline in %s, expected %s", d, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"goa.design/goa/v3/middleware"
+)
+
+// VariantHeader is the default name of the request header read by the
+// RequestVariant middleware.
+const VariantHeader = "X-Variant"
+
+// RequestVariant returns a middleware that stores the value of the given
+// request header, VariantHeader if empty, in the request context under the
+// middleware.RequestedVariantKey key. The value is used by the
+// middleware.RequestedVariant rule to route the request to the requested
+// variant of the method, see middleware.Variants:
+//
+//    handler = middleware.RequestVariant("")(handler)
+//
+func RequestVariant(header string) func(http.Handler) http.Handler {
+	if header == "" {
+		header = VariantHeader
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if v := r.Header.Get(header); v != "" {
+				r = r.WithContext(context.WithValue(r.Context(), middleware.RequestedVariantKey, v))
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
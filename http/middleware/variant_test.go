@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"goa.design/goa/v3/middleware"
+)
+
+func TestRequestVariant(t *testing.T) {
+	var requested interface{}
+	h := RequestVariant("")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.Context().Value(middleware.RequestedVariantKey)
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if requested != nil {
+		t.Errorf("got requested variant %v without header, expected none", requested)
+	}
+
+	req.Header.Set(VariantHeader, "green")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if requested != "green" {
+		t.Errorf("got requested variant %v, expected green", requested)
+	}
+}
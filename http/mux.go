@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/dimfeld/httptreemux/v5"
 )
@@ -59,17 +61,40 @@ type (
 		Use(func(http.Handler) http.Handler)
 	}
 
+	// MuxerOption configures the Muxer returned by NewMuxer.
+	MuxerOption func(*mux)
+
 	// mux is the default Muxer implementation. It leverages the
 	// httptreemux router and simply substitutes the syntax used to define
 	// wildcards from ":wildcard" and "*wildcard" to "{wildcard}" and
 	// "{*wildcard}" respectively.
 	mux struct {
 		*httptreemux.ContextMux
+		// caseInsensitive is true if the static segments of the
+		// patterns match request paths regardless of case.
+		caseInsensitive bool
+		// patterns lists the segments of the registered patterns.
+		patterns [][]string
+		// methods lists the registered HTTP methods.
+		methods []string
 	}
 )
 
-// NewMuxer returns a Muxer implementation based on the httptreemux router.
-func NewMuxer() MiddlewareMuxer {
+// NewMuxer returns a Muxer implementation based on the httptreemux router. The
+// muxer handles HEAD requests made to patterns registered with GET using the GET
+// handlers. It responds to OPTIONS requests made to patterns that do not have
+// an OPTIONS handler with a 204 No Content response whose Allow header lists
+// the methods registered for the pattern. Requests made with methods that are
+// not registered for the pattern result in 405 Method Not Allowed responses
+// that list the allowed methods in the Allow header.
+//
+// By default the muxer redirects requests whose path only differs from a
+// registered pattern by a trailing slash using a 301 status code and matches
+// paths case sensitively, use the options to change this behavior:
+//
+//    mux := goahttp.NewMuxer(goahttp.MuxStrictSlash(), goahttp.MuxCaseInsensitive())
+//
+func NewMuxer(opts ...MuxerOption) MiddlewareMuxer {
 	r := httptreemux.NewContextMux()
 	r.EscapeAddedRoutes = true
 	r.NotFoundHandler = func(w http.ResponseWriter, req *http.Request) {
@@ -78,14 +103,181 @@ func NewMuxer() MiddlewareMuxer {
 		w.WriteHeader(http.StatusNotFound)
 		enc.Encode(NewErrorResponse(fmt.Errorf("404 page not found")))
 	}
-	return &mux{r}
+	m := &mux{ContextMux: r}
+	r.MethodNotAllowedHandler = func(w http.ResponseWriter, req *http.Request, methods map[string]httptreemux.HandlerFunc) {
+		allowed := make([]string, 0, len(methods)+1)
+		for meth := range methods {
+			allowed = append(allowed, meth)
+		}
+		if _, ok := methods["OPTIONS"]; !ok {
+			allowed = append(allowed, "OPTIONS")
+		}
+		sort.Strings(allowed)
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		ctx := context.WithValue(req.Context(), AcceptTypeKey, req.Header.Get("Accept"))
+		enc := ResponseEncoder(ctx, w)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		enc.Encode(NewErrorResponse(fmt.Errorf("405 method %s not allowed", req.Method)))
+	}
+	r.OptionsHandler = func(w http.ResponseWriter, req *http.Request, _ map[string]string) {
+		w.Header().Set("Allow", strings.Join(m.allowed(w, req), ", "))
+		w.WriteHeader(http.StatusNoContent)
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// MuxRedirectTrailingSlash configures the muxer to redirect requests whose path
+// only differs from a registered pattern by a trailing slash using the given
+// status code. The status code must be one of http.StatusMovedPermanently
+// (default), http.StatusTemporaryRedirect or http.StatusPermanentRedirect.
+func MuxRedirectTrailingSlash(status int) MuxerOption {
+	return func(m *mux) {
+		m.RedirectTrailingSlash = true
+		switch status {
+		case http.StatusTemporaryRedirect:
+			m.RedirectBehavior = httptreemux.Redirect307
+		case http.StatusPermanentRedirect:
+			m.RedirectBehavior = httptreemux.Redirect308
+		default:
+			m.RedirectBehavior = httptreemux.Redirect301
+		}
+	}
+}
+
+// MuxStrictSlash configures the muxer to only match request paths that have a
+// trailing slash if and only if the registered pattern has one. Requests whose
+// path only differs from a registered pattern by a trailing slash result in
+// 404 Not Found responses.
+func MuxStrictSlash() MuxerOption {
+	return func(m *mux) {
+		m.RedirectTrailingSlash = false
+	}
+}
+
+// MuxCaseInsensitive configures the muxer to match the static segments of the
+// registered patterns regardless of case. The values captured by wildcards
+// are left untouched.
+func MuxCaseInsensitive() MuxerOption {
+	return func(m *mux) {
+		m.caseInsensitive = true
+	}
 }
 
 // Handle maps the wildcard format used by goa to the one used by httptreemux.
 func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
+	found := false
+	for _, meth := range m.methods {
+		if meth == method {
+			found = true
+			break
+		}
+	}
+	if !found {
+		m.methods = append(m.methods, method)
+	}
+	if m.caseInsensitive {
+		m.patterns = append(m.patterns, strings.Split(strings.Trim(pattern, "/"), "/"))
+	}
 	m.ContextMux.Handle(method, treemuxify(pattern), handler)
 }
 
+// ServeHTTP dispatches the request to the handler whose pattern most closely
+// matches the request URL. The request path is first rewritten to use the case
+// of the matching pattern if the muxer is case insensitive and the path does
+// not match any pattern as is.
+func (m *mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.caseInsensitive {
+		if res, _ := m.ContextMux.Lookup(w, r); res.StatusCode == http.StatusNotFound {
+			m.canonicalize(r)
+		}
+	}
+	m.ContextMux.ServeHTTP(w, r)
+}
+
+// allowed returns the sorted list of HTTP methods registered for the pattern
+// that matches the request path.
+func (m *mux) allowed(w http.ResponseWriter, r *http.Request) []string {
+	allowed := map[string]struct{}{"OPTIONS": {}}
+	probe := r.Clone(r.Context())
+	for _, meth := range m.methods {
+		probe.Method = meth
+		if res, _ := m.ContextMux.Lookup(w, probe); res.StatusCode == http.StatusOK {
+			allowed[meth] = struct{}{}
+			if meth == "GET" {
+				allowed["HEAD"] = struct{}{}
+			}
+		}
+	}
+	res := make([]string, 0, len(allowed))
+	for meth := range allowed {
+		res = append(res, meth)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// canonicalize rewrites the request path using the case of the static
+// segments of the pattern that matches it case insensitively with the most
+// static segments if any.
+func (m *mux) canonicalize(r *http.Request) {
+	path := r.URL.Path
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	var (
+		best  []string
+		score = -1
+	)
+	for _, pat := range m.patterns {
+		if c, n := matchSegments(pat, segs); c != nil && n > score {
+			best, score = c, n
+		}
+	}
+	if best == nil {
+		return
+	}
+	canonical := "/" + strings.Join(best, "/")
+	if len(path) > 1 && strings.HasSuffix(path, "/") {
+		canonical += "/"
+	}
+	r.URL.Path = canonical
+	r.URL.RawPath = ""
+	r.RequestURI = r.URL.RequestURI()
+}
+
+// matchSegments returns the request path segments using the case of the
+// pattern static segments and the number of static segments if the pattern
+// matches the path case insensitively, nil otherwise.
+func matchSegments(pat, segs []string) ([]string, int) {
+	res := make([]string, 0, len(segs))
+	static := 0
+	for i, p := range pat {
+		if strings.HasPrefix(p, "{*") {
+			return append(res, segs[i:]...), static
+		}
+		if i >= len(segs) {
+			return nil, 0
+		}
+		switch {
+		case strings.HasPrefix(p, "{"):
+			if segs[i] == "" {
+				return nil, 0
+			}
+			res = append(res, segs[i])
+		case strings.EqualFold(p, segs[i]):
+			res = append(res, p)
+			static++
+		default:
+			return nil, 0
+		}
+	}
+	if len(pat) != len(segs) {
+		return nil, 0
+	}
+	return res, static
+}
+
 // Vars extracts the path variables from the request context.
 func (m *mux) Vars(r *http.Request) map[string]string {
 	return httptreemux.ContextParams(r.Context())
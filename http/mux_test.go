@@ -68,3 +68,96 @@ func TestMiddlewares(t *testing.T) {
 		}
 	}
 }
+
+func TestMuxOptions(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Options  []MuxerOption
+		Path     string
+		Status   int
+		Body     string
+		Location string
+	}{
+		{"default", nil, "/Users/Bob", http.StatusOK, "users Bob", ""},
+		{"default-trailing-slash", nil, "/Users/Bob/", http.StatusMovedPermanently, "", "/Users/Bob"},
+		{"default-case", nil, "/users/Bob", http.StatusNotFound, "", ""},
+		{"redirect-307", []MuxerOption{MuxRedirectTrailingSlash(http.StatusTemporaryRedirect)}, "/Users/Bob/", http.StatusTemporaryRedirect, "", "/Users/Bob"},
+		{"strict-slash", []MuxerOption{MuxStrictSlash()}, "/Users/Bob/", http.StatusNotFound, "", ""},
+		{"strict-slash-match", []MuxerOption{MuxStrictSlash()}, "/Users/Bob", http.StatusOK, "users Bob", ""},
+		{"case-insensitive", []MuxerOption{MuxCaseInsensitive()}, "/USERS/Bob", http.StatusOK, "users Bob", ""},
+		{"case-insensitive-static", []MuxerOption{MuxCaseInsensitive()}, "/users/ME", http.StatusOK, "me", ""},
+		{"case-insensitive-catch-all", []MuxerOption{MuxCaseInsensitive()}, "/FILES/A/b.txt", http.StatusOK, "files A/b.txt", ""},
+		{"case-insensitive-trailing-slash", []MuxerOption{MuxCaseInsensitive()}, "/users/Bob/", http.StatusMovedPermanently, "", "/Users/Bob"},
+		{"case-insensitive-not-found", []MuxerOption{MuxCaseInsensitive()}, "/accounts/Bob", http.StatusNotFound, "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			m := NewMuxer(c.Options...)
+			m.Handle("GET", "/Users/{name}", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("users " + m.Vars(r)["name"]))
+			})
+			m.Handle("GET", "/Users/me", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("me"))
+			})
+			m.Handle("GET", "/files/{*path}", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("files " + m.Vars(r)["path"]))
+			})
+			r, _ := http.NewRequest("GET", c.Path, nil)
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Fatalf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if c.Body != "" && w.Body.String() != c.Body {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+			if loc := w.Header().Get("Location"); loc != c.Location {
+				t.Errorf("got location %q, expected %q", loc, c.Location)
+			}
+		})
+	}
+}
+
+func TestMuxMethods(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Method string
+		Path   string
+		Status int
+		Allow  string
+		Body   string
+	}{
+		{"custom", "PROPFIND", "/files/a", http.StatusOK, "", "propfind a"},
+		{"head", "HEAD", "/files/a", http.StatusOK, "", ""},
+		{"options", "OPTIONS", "/files/a", http.StatusNoContent, "DELETE, GET, HEAD, OPTIONS, PROPFIND", ""},
+		{"options-explicit", "OPTIONS", "/cors", http.StatusOK, "", "options"},
+		{"options-not-found", "OPTIONS", "/unknown", http.StatusNotFound, "", ""},
+		{"not-allowed", "POST", "/files/a", http.StatusMethodNotAllowed, "DELETE, GET, HEAD, OPTIONS, PROPFIND", ""},
+	}
+	m := NewMuxer()
+	m.Handle("GET", "/files/{name}", func(w http.ResponseWriter, r *http.Request) {})
+	m.Handle("DELETE", "/files/{name}", func(w http.ResponseWriter, r *http.Request) {})
+	m.Handle("PROPFIND", "/files/{name}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("propfind " + m.Vars(r)["name"]))
+	})
+	m.Handle("POST", "/cors", func(w http.ResponseWriter, r *http.Request) {})
+	m.Handle("OPTIONS", "/cors", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("options"))
+	})
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, _ := http.NewRequest(c.Method, c.Path, nil)
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Fatalf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if allow := w.Header().Get("Allow"); allow != c.Allow {
+				t.Errorf("got Allow header %q, expected %q", allow, c.Allow)
+			}
+			if c.Body != "" && w.Body.String() != c.Body {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+		})
+	}
+}
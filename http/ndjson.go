@@ -0,0 +1,142 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+)
+
+// NDJSONContentType is the content type of newline-delimited JSON, the
+// representation of collections that holds one JSON value per line.
+const NDJSONContentType = "application/x-ndjson"
+
+type (
+	// ndjsonEncoder writes the elements of slices and arrays as
+	// newline-delimited JSON.
+	ndjsonEncoder struct {
+		w io.Writer
+	}
+
+	// ndjsonDecoder reads newline-delimited JSON.
+	ndjsonDecoder struct {
+		dec *json.Decoder
+	}
+)
+
+// NDJSONResponseEncoder wraps the given response encoder constructor so that
+// the returned encoders write collections as newline-delimited JSON if the
+// response content type set in the design or else the request Accept header
+// is application/x-ndjson. The generated server code uses it to encode the
+// collection response bodies when the design lists application/x-ndjson in
+// Produces.
+func NDJSONResponseEncoder(encoder func(context.Context, http.ResponseWriter) Encoder) func(context.Context, http.ResponseWriter) Encoder {
+	return func(ctx context.Context, w http.ResponseWriter) Encoder {
+		if negotiateContentType(ctx, NDJSONContentType) == "" {
+			return encoder(ctx, w)
+		}
+		SetContentType(w, NDJSONContentType)
+		return NewNDJSONEncoder(w)
+	}
+}
+
+// NDJSONRequestDecoder wraps the given request decoder constructor so that the
+// returned decoders read newline-delimited JSON if the request Content-Type
+// header is application/x-ndjson. The generated server code uses it to decode
+// the collection request bodies when the design lists application/x-ndjson in
+// Consumes.
+func NDJSONRequestDecoder(decoder func(*http.Request) Decoder) func(*http.Request) Decoder {
+	return func(r *http.Request) Decoder {
+		if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != NDJSONContentType {
+			return decoder(r)
+		}
+		return NewNDJSONDecoder(r.Body)
+	}
+}
+
+// NewNDJSONEncoder returns an encoder that writes the elements of slices and
+// arrays to w as newline-delimited JSON and the other values as a single line.
+// The lines are buffered and written each time the buffer grows past 32KB, w is
+// flushed after each write if it implements http.Flusher so that the clients
+// may process large collections as they are received.
+func NewNDJSONEncoder(w io.Writer) Encoder {
+	return &ndjsonEncoder{w: w}
+}
+
+// NewNDJSONDecoder returns a decoder that reads newline-delimited JSON from r
+// into slices: decoding into a pointer to a slice appends a value per line.
+// Decoding into other values reads a single line. Decode returns io.EOF if r
+// does not contain any value.
+func NewNDJSONDecoder(r io.Reader) Decoder {
+	return &ndjsonDecoder{dec: json.NewDecoder(r)}
+}
+
+// Encode writes v as newline-delimited JSON.
+func (e *ndjsonEncoder) Encode(v interface{}) error {
+	be := jsonEncoders.Get().(*bufferedJSONEncoder)
+	defer func() {
+		if be.buf.Cap() <= maxPooledBufferSize {
+			be.buf.Reset()
+			jsonEncoders.Put(be)
+		}
+	}()
+	rv, ok := streamable(v)
+	if !ok {
+		if err := be.enc.Encode(v); err != nil {
+			return err
+		}
+		_, err := e.w.Write(be.buf.Bytes())
+		return err
+	}
+	flusher, _ := e.w.(http.Flusher)
+	for i := 0; i < rv.Len(); i++ {
+		if err := be.enc.Encode(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+		if be.buf.Len() >= streamChunkSize {
+			if _, err := e.w.Write(be.buf.Bytes()); err != nil {
+				return err
+			}
+			be.buf.Reset()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+	_, err := e.w.Write(be.buf.Bytes())
+	return err
+}
+
+// Decode reads newline-delimited JSON into v.
+func (d *ndjsonDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice || rv.Elem().Type().Elem().Kind() == reflect.Uint8 {
+		return d.dec.Decode(v)
+	}
+	s := rv.Elem()
+	et := s.Type().Elem()
+	for n := 0; ; n++ {
+		elem := reflect.New(et)
+		err := d.dec.Decode(elem.Interface())
+		if errors.Is(err, io.EOF) {
+			if n == 0 {
+				return io.EOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.Set(reflect.Append(s, elem.Elem()))
+	}
+}
+
+// DisallowUnknownFields causes the decoder to return an error when a line
+// contains object keys that do not match the destination fields, see
+// DecodeStrict.
+func (d *ndjsonDecoder) DisallowUnknownFields() {
+	d.dec.DisallowUnknownFields()
+}
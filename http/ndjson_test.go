@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ndjsonItem struct {
+	Name string `json:"name"`
+	Qty  int    `json:"qty,omitempty"`
+}
+
+func TestNDJSONResponseEncoder(t *testing.T) {
+	items := []*ndjsonItem{{Name: "a", Qty: 1}, {Name: "b"}}
+	cases := []struct {
+		Name        string
+		Accept      string
+		Value       interface{}
+		ContentType string
+		Body        string
+	}{
+		{"json", "application/json", items, "application/json", `[{"name":"a","qty":1},{"name":"b"}]` + "\n"},
+		{"ndjson", "application/x-ndjson", items, NDJSONContentType, `{"name":"a","qty":1}` + "\n" + `{"name":"b"}` + "\n"},
+		{"ndjson-list", "text/html;q=0.5, application/x-ndjson", items, NDJSONContentType, `{"name":"a","qty":1}` + "\n" + `{"name":"b"}` + "\n"},
+		{"ndjson-empty", "application/x-ndjson", []*ndjsonItem{}, NDJSONContentType, ""},
+		{"ndjson-single", "application/x-ndjson", items[0], NDJSONContentType, `{"name":"a","qty":1}` + "\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.Accept)
+			w := httptest.NewRecorder()
+			if err := NDJSONResponseEncoder(ResponseEncoder)(ctx, w).Encode(c.Value); err != nil {
+				t.Fatal(err)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != c.ContentType {
+				t.Errorf("got content type %q, expected %q", ct, c.ContentType)
+			}
+			if w.Body.String() != c.Body {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+		})
+	}
+}
+
+func TestNDJSONRequestDecoder(t *testing.T) {
+	cases := []struct {
+		Name        string
+		ContentType string
+		Body        string
+		Expected    []*ndjsonItem
+		Err         error
+	}{
+		{"json", "application/json", `[{"name":"a"}]`, []*ndjsonItem{{Name: "a"}}, nil},
+		{"ndjson", "application/x-ndjson", `{"name":"a","qty":1}` + "\n\n" + `{"name":"b"}` + "\n", []*ndjsonItem{{Name: "a", Qty: 1}, {Name: "b"}}, nil},
+		{"ndjson-no-trailing-newline", "application/x-ndjson; charset=utf-8", `{"name":"a"}`, []*ndjsonItem{{Name: "a"}}, nil},
+		{"ndjson-empty", "application/x-ndjson", "", nil, io.EOF},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			r.Header.Set("Content-Type", c.ContentType)
+			var body []*ndjsonItem
+			err := NDJSONRequestDecoder(RequestDecoder)(r).Decode(&body)
+			if err != c.Err {
+				t.Fatalf("got error %v, expected %v", err, c.Err)
+			}
+			if !reflect.DeepEqual(body, c.Expected) {
+				t.Errorf("got %v, expected %v", body, c.Expected)
+			}
+		})
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`+"\n"+`{"name":`))
+	r.Header.Set("Content-Type", NDJSONContentType)
+	var body []*ndjsonItem
+	if err := NDJSONRequestDecoder(RequestDecoder)(r).Decode(&body); err == nil {
+		t.Error("expected an error for a truncated line")
+	}
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OperationPath returns the path of the status resource of the long running
+// operation with the given ID started by the request r. The generated servers
+// set the Location header of the responses of long running methods to this
+// path, see the LongRunning DSL.
+func OperationPath(r *http.Request, id string) string {
+	return strings.TrimSuffix(r.URL.Path, "/") + "/operations/" + url.PathEscape(id)
+}
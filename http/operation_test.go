@@ -0,0 +1,27 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOperationPath(t *testing.T) {
+	cases := map[string]struct {
+		URL      string
+		ID       string
+		Expected string
+	}{
+		"simple":         {"/exports", "42", "/exports/operations/42"},
+		"trailing slash": {"/exports/", "42", "/exports/operations/42"},
+		"query":          {"/exports?format=csv", "42", "/exports/operations/42"},
+		"escaped id":     {"/exports", "a/b c", "/exports/operations/a%2Fb%20c"},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("POST", tc.URL, nil)
+			if actual := OperationPath(r, tc.ID); actual != tc.Expected {
+				t.Errorf("got %q, expected %q", actual, tc.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to
+// the pool so that a few large responses do not pin memory.
+const maxPooledBufferSize = 64 << 10
+
+type (
+	// pooledJSONEncoder is a JSON encoder that marshals values into a buffer
+	// taken from a pool and writes the result with a single call to the
+	// underlying writer.
+	pooledJSONEncoder struct {
+		w io.Writer
+	}
+
+	// bufferedJSONEncoder is a JSON encoder writing to its own buffer.
+	bufferedJSONEncoder struct {
+		buf bytes.Buffer
+		enc *json.Encoder
+	}
+)
+
+// jsonEncoders is the pool of buffered JSON encoders.
+var jsonEncoders = sync.Pool{
+	New: func() interface{} {
+		e := &bufferedJSONEncoder{}
+		e.enc = json.NewEncoder(&e.buf)
+		return e
+	},
+}
+
+// newPooledJSONEncoder returns a JSON encoder that writes to w using buffers
+// and encoders taken from a pool. ResponseEncoder uses it so that encoding
+// responses does not allocate a new encoder and buffer for each request.
+func newPooledJSONEncoder(w io.Writer) Encoder {
+	return &pooledJSONEncoder{w: w}
+}
+
+// Encode marshals v into a pooled buffer and writes the result to the
+// underlying writer. Nothing is written if v cannot be marshaled.
+func (e *pooledJSONEncoder) Encode(v interface{}) error {
+	be := jsonEncoders.Get().(*bufferedJSONEncoder)
+	defer func() {
+		if be.buf.Cap() <= maxPooledBufferSize {
+			be.buf.Reset()
+			jsonEncoders.Put(be)
+		}
+	}()
+	if err := be.enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := e.w.Write(be.buf.Bytes())
+	return err
+}
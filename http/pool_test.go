@@ -0,0 +1,61 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPooledJSONEncoder(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    interface{}
+		expected string
+		err      bool
+	}{
+		{"string", "foo", "\"foo\"\n", false},
+		{"object", map[string]int{"a": 1}, "{\"a\":1}\n", false},
+		{"large", strings.Repeat("a", 2*maxPooledBufferSize), "\"" + strings.Repeat("a", 2*maxPooledBufferSize) + "\"\n", false},
+		{"invalid", make(chan int), "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := newPooledJSONEncoder(&buf).Encode(c.value)
+			if c.err && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !c.err && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if buf.String() != c.expected {
+				t.Errorf("got %q, expected %q", buf.String(), c.expected)
+			}
+		})
+	}
+}
+
+func TestPooledJSONEncoderWriteError(t *testing.T) {
+	werr := errors.New("write failed")
+	err := newPooledJSONEncoder(failingWriter{werr}).Encode("foo")
+	if err != werr {
+		t.Errorf("got error %v, expected %v", err, werr)
+	}
+}
+
+func BenchmarkPooledJSONEncoder(b *testing.B) {
+	v := map[string]interface{}{"id": 1, "name": "foo", "tags": []string{"a", "b", "c"}}
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := newPooledJSONEncoder(&buf).Encode(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write([]byte) (int, error) { return 0, w.err }
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+)
+
+type (
+	// ProxyOption configures the handler returned by ReverseProxy.
+	ProxyOption func(*proxyOptions)
+
+	proxyOptions struct {
+		timeout         time.Duration
+		transport       http.RoundTripper
+		requestHeaders  []proxyHeader
+		responseHeaders []proxyHeader
+	}
+
+	// proxyHeader is a header set or removed by the proxy.
+	proxyHeader struct {
+		name  string
+		value string
+	}
+)
+
+// ReverseProxy returns a handler that forwards the requests to the backend at
+// target, e.g. "http://legacy:8080", and copies the backend responses. The
+// request path is appended to the target path and the query string is
+// preserved. The Host header of the forwarded requests is set to the target
+// host and the original host is sent in the X-Forwarded-Host header. The
+// generated servers use ReverseProxy to serve the endpoints defined with the
+// Proxy DSL so that a service can front a legacy backend while its endpoints
+// are migrated.
+//
+// The handler responds with 504 Gateway Timeout if the backend does not
+// respond within the timeout set with ProxyTimeout and with 502 Bad Gateway if
+// the request cannot be forwarded.
+//
+// ReverseProxy panics if target is not a valid absolute URL.
+func ReverseProxy(target string, opts ...ProxyOption) http.Handler {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		panic(fmt.Sprintf("invalid proxy target %q", target))
+	}
+	o := new(proxyOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	proxy := httputil.NewSingleHostReverseProxy(u)
+	direct := proxy.Director
+	proxy.Director = func(r *http.Request) {
+		host := r.Host
+		direct(r)
+		r.Host = u.Host
+		if host != "" {
+			r.Header.Set("X-Forwarded-Host", host)
+		}
+		for _, h := range o.requestHeaders {
+			setProxyHeader(r.Header, h)
+		}
+	}
+	if len(o.responseHeaders) > 0 {
+		proxy.ModifyResponse = func(res *http.Response) error {
+			for _, h := range o.responseHeaders {
+				setProxyHeader(res.Header, h)
+			}
+			return nil
+		}
+	}
+	if o.transport != nil {
+		proxy.Transport = o.transport
+	}
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		status := http.StatusBadGateway
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, http.StatusText(status), status)
+	}
+	if o.timeout <= 0 {
+		return proxy
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), o.timeout)
+		defer cancel()
+		proxy.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ProxyTimeout sets the maximum duration of the forwarded requests including
+// the time spent reading the backend response body. There is no timeout by
+// default.
+func ProxyTimeout(d time.Duration) ProxyOption {
+	return func(o *proxyOptions) {
+		o.timeout = d
+	}
+}
+
+// ProxyTransport sets the transport used to forward the requests. The default
+// is http.DefaultTransport.
+func ProxyTransport(rt http.RoundTripper) ProxyOption {
+	return func(o *proxyOptions) {
+		o.transport = rt
+	}
+}
+
+// ProxyRequestHeader sets the header with the given name on the forwarded
+// requests. The header is removed if value is empty.
+func ProxyRequestHeader(name, value string) ProxyOption {
+	return func(o *proxyOptions) {
+		o.requestHeaders = append(o.requestHeaders, proxyHeader{name, value})
+	}
+}
+
+// ProxyResponseHeader sets the header with the given name on the backend
+// responses. The header is removed if value is empty.
+func ProxyResponseHeader(name, value string) ProxyOption {
+	return func(o *proxyOptions) {
+		o.responseHeaders = append(o.responseHeaders, proxyHeader{name, value})
+	}
+}
+
+// setProxyHeader sets or removes the header h in header.
+func setProxyHeader(header http.Header, h proxyHeader) {
+	if h.value == "" {
+		header.Del(h.name)
+		return
+	}
+	header.Set(h.name, h.value)
+}
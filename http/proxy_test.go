@@ -0,0 +1,72 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReverseProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.RequestURI())
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-Forwarded", r.Header.Get("X-Forwarded-Host"))
+		w.Header().Set("X-Legacy", r.Header.Get("X-Legacy"))
+		w.Header().Set("X-Cookie", r.Header.Get("Cookie"))
+		w.Header().Set("Server", "legacy")
+		if r.URL.Path == "/api/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte("legacy")) // nolint: errcheck
+	}))
+	defer backend.Close()
+
+	h := ReverseProxy(backend.URL+"/api",
+		ProxyTimeout(50*time.Millisecond),
+		ProxyRequestHeader("X-Legacy", "true"),
+		ProxyRequestHeader("Cookie", ""),
+		ProxyResponseHeader("Server", ""),
+	)
+
+	req := httptest.NewRequest("GET", "http://public.example.com/bottles/1?view=full", nil)
+	req.Header.Set("Cookie", "session=secret")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("got status %d, expected 200", w.Code)
+	}
+	body, _ := io.ReadAll(w.Body)
+	if string(body) != "legacy" {
+		t.Errorf("got body %q, expected legacy", body)
+	}
+	expected := map[string]string{
+		"X-Path":      "/api/bottles/1?view=full",
+		"X-Host":      backend.Listener.Addr().String(),
+		"X-Forwarded": "public.example.com",
+		"X-Legacy":    "true",
+		"X-Cookie":    "",
+		"Server":      "",
+	}
+	for k, v := range expected {
+		if g := w.Header().Get(k); g != v {
+			t.Errorf("got %s %q, expected %q", k, g, v)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/slow", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Errorf("got status %d for slow backend, expected 504", w.Code)
+	}
+}
+
+func TestReverseProxyInvalidTarget(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	ReverseProxy("legacy:8080")
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+)
+
+// Push initiates HTTP/2 server pushes of the resources with the given paths
+// if the response writer supports it. The pushed requests carry the
+// Accept-Encoding and Accept-Language headers of the request r. Push is a no-op
+// for HTTP/1 connections and for clients that disabled server push. The
+// generated servers call Push before writing the responses of the endpoints
+// that use the Push DSL.
+func Push(w http.ResponseWriter, r *http.Request, paths ...string) {
+	p, ok := w.(http.Pusher)
+	if !ok {
+		return
+	}
+	var opts *http.PushOptions
+	for _, h := range []string{"Accept-Encoding", "Accept-Language"} {
+		if v := r.Header.Get(h); v != "" {
+			if opts == nil {
+				opts = &http.PushOptions{Header: make(http.Header)}
+			}
+			opts.Header.Set(h, v)
+		}
+	}
+	for _, path := range paths {
+		if err := p.Push(path, opts); err != nil {
+			// e.g. http.ErrNotSupported if the client disabled push.
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type pushRecorder struct {
+	*httptest.ResponseRecorder
+	targets []string
+	opts    []*http.PushOptions
+	err     error
+}
+
+func (p *pushRecorder) Push(target string, opts *http.PushOptions) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.targets = append(p.targets, target)
+	p.opts = append(p.opts, opts)
+	return nil
+}
+
+func TestPush(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	w := &pushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	Push(w, r, "/app.js", "/app.css")
+	if len(w.targets) != 2 || w.targets[0] != "/app.js" || w.targets[1] != "/app.css" {
+		t.Errorf("got pushed targets %v, expected [/app.js /app.css]", w.targets)
+	}
+	if w.opts[0] == nil || w.opts[0].Header.Get("Accept-Encoding") != "gzip" {
+		t.Errorf("got push options %v, expected Accept-Encoding header", w.opts[0])
+	}
+
+	w = &pushRecorder{ResponseRecorder: httptest.NewRecorder(), err: http.ErrNotSupported}
+	Push(w, r, "/app.js")
+	if len(w.targets) != 0 {
+		t.Errorf("got pushed targets %v, expected none", w.targets)
+	}
+
+	// Must not panic with writers that do not support push.
+	Push(httptest.NewRecorder(), r, "/app.js")
+}
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SplitCSV splits the values of a query string parameter serialized using the
+// "csv" style (e.g. "ids=1,2,3") into individual values. Multiple occurrences
+// of the parameter are concatenated. SplitCSV returns nil if vals is nil so
+// that missing parameters can be detected.
+func SplitCSV(vals []string) []string {
+	if vals == nil {
+		return nil
+	}
+	// Count the values first so that the result is allocated once.
+	n := 0
+	for _, v := range vals {
+		if v != "" {
+			n += strings.Count(v, ",") + 1
+		}
+	}
+	res := make([]string, 0, n)
+	for _, v := range vals {
+		if v == "" {
+			continue
+		}
+		for {
+			i := strings.IndexByte(v, ',')
+			if i < 0 {
+				res = append(res, v)
+				break
+			}
+			res = append(res, v[:i])
+			v = v[i+1:]
+		}
+	}
+	return res
+}
+
+// ParseDuration parses the value of a path, query string or header parameter
+// of type Duration. The value is either a number of nanoseconds or a string
+// accepted by time.ParseDuration such as "1h30m".
+func ParseDuration(s string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitCSV(t *testing.T) {
+	cases := map[string]struct {
+		Values   []string
+		Expected []string
+	}{
+		"nil":      {nil, nil},
+		"empty":    {[]string{""}, []string{}},
+		"single":   {[]string{"a"}, []string{"a"}},
+		"csv":      {[]string{"a,b,c"}, []string{"a", "b", "c"}},
+		"multiple": {[]string{"a,b", "c"}, []string{"a", "b", "c"}},
+		"blanks":   {[]string{"a,,b,", ""}, []string{"a", "", "b", ""}},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			got := SplitCSV(c.Values)
+			if !reflect.DeepEqual(got, c.Expected) {
+				t.Errorf("got %#v, expected %#v", got, c.Expected)
+			}
+		})
+	}
+}
+
+func BenchmarkSplitCSV(b *testing.B) {
+	vals := []string{"1,2,3,4,5", "6,7,8"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if res := SplitCSV(vals); len(res) != 8 {
+			b.Fatalf("got %d values, expected 8", len(res))
+		}
+	}
+}
+
+// BenchmarkQueryParams compares reading the parameters of a request query
+// string the way the generated decoders do: by parsing the query string once
+// per parameter or once per request.
+func BenchmarkQueryParams(b *testing.B) {
+	r, err := http.NewRequest("GET", "/?limit=10&offset=20&sort=name&ids=1,2,3&tags=a&tags=b", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("per-param", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = r.URL.Query().Get("limit")
+			_ = r.URL.Query().Get("offset")
+			_ = r.URL.Query().Get("sort")
+			_ = SplitCSV(r.URL.Query()["ids"])
+			_ = r.URL.Query()["tags"]
+		}
+	})
+	b.Run("once", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			qp := r.URL.Query()
+			_ = qp.Get("limit")
+			_ = qp.Get("offset")
+			_ = qp.Get("sort")
+			_ = SplitCSV(qp["ids"])
+			_ = qp["tags"]
+		}
+	})
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]struct {
+		Value    string
+		Expected time.Duration
+		Err      bool
+	}{
+		"nanoseconds": {"1500", 1500 * time.Nanosecond, false},
+		"string":      {"1h30m", 90 * time.Minute, false},
+		"negative":    {"-2s", -2 * time.Second, false},
+		"invalid":     {"soon", 0, true},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			got, err := ParseDuration(c.Value)
+			if (err != nil) != c.Err {
+				t.Fatalf("got error %v, expected error %v", err, c.Err)
+			}
+			if got != c.Expected {
+				t.Errorf("got %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
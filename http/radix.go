@@ -0,0 +1,371 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// radixMux is a Muxer implementation based on a radix tree per HTTP
+	// method. The path variables captured while matching a request are
+	// stored in pooled slices so that routing does not allocate.
+	radixMux struct {
+		// trees maps HTTP methods to the root of the corresponding tree.
+		trees map[string]*radixNode
+		// middlewares lists the middlewares applied to the handlers.
+		middlewares []func(http.Handler) http.Handler
+		// maxParams is the maximum number of wildcards of a pattern.
+		maxParams int
+		// params is the pool of path variables.
+		params sync.Pool
+	}
+
+	// radixNode is a node of the radix tree. Static children are indexed
+	// by the first byte of their path.
+	radixNode struct {
+		// path is the static prefix matched by the node.
+		path string
+		// indices lists the first bytes of the static children paths.
+		indices []byte
+		// children lists the static children.
+		children []*radixNode
+		// param is the child matching a "{name}" wildcard if any.
+		param *radixNode
+		// catchAll is the child matching a "{*name}" wildcard if any.
+		catchAll *radixNode
+		// route is the route registered for the pattern ending at the
+		// node if any.
+		route *radixRoute
+	}
+
+	// radixRoute is a route registered with the muxer.
+	radixRoute struct {
+		// names lists the names of the pattern wildcards in order.
+		// Patterns may use different names for wildcards at the same
+		// position.
+		names []string
+		// handler is the handler registered with the route.
+		handler http.Handler
+		// wrapped is the handler wrapped with the muxer middlewares.
+		wrapped http.Handler
+	}
+
+	// radixParams holds the values captured for the wildcards of a route.
+	radixParams struct {
+		names  []string
+		values []string
+	}
+
+	// radixParamsKeyType is the type of the context key used to store the
+	// path variables.
+	radixParamsKeyType struct{}
+)
+
+// radixParamsKey is the context key used to store the path variables.
+var radixParamsKey = radixParamsKeyType{}
+
+// NewRadixMuxer returns a Muxer implementation based on a radix tree router.
+// The router stores the path variables captured for each request in pooled
+// slices and only builds the map returned by Vars when called so that routing
+// requests to patterns without wildcards does not allocate. NewRadixMuxer
+// handles HEAD, OPTIONS and unknown methods like the muxer returned by
+// NewMuxer. Static segments take precedence over "{name}" wildcards which take
+// precedence over "{*name}" wildcards. Request paths must match the patterns
+// exactly including trailing slashes and case.
+//
+// The generated example server uses the muxer when the API defines the
+// "http:muxer" meta with value "radix":
+//
+//    var _ = API("calc", func() {
+//        Meta("http:muxer", "radix")
+//    })
+//
+func NewRadixMuxer() MiddlewareMuxer {
+	m := &radixMux{trees: make(map[string]*radixNode)}
+	m.params.New = func() interface{} {
+		return &radixParams{values: make([]string, 0, m.maxParams)}
+	}
+	return m
+}
+
+// Handle registers the handler function for the given method and pattern.
+// Handle panics if the pattern is invalid or already registered.
+func (m *radixMux) Handle(method, pattern string, handler http.HandlerFunc) {
+	if !strings.HasPrefix(pattern, "/") {
+		panic(fmt.Sprintf("goa: invalid pattern %q, pattern must start with /", pattern))
+	}
+	root, ok := m.trees[method]
+	if !ok {
+		root = &radixNode{}
+		m.trees[method] = root
+	}
+	route := &radixRoute{handler: handler, wrapped: m.wrap(handler)}
+	n := root
+	for rest := pattern; ; {
+		i := strings.IndexByte(rest, '{')
+		if i < 0 {
+			n = n.insert(rest)
+			break
+		}
+		n = n.insert(rest[:i])
+		j := strings.IndexByte(rest[i:], '}')
+		if j < 0 {
+			panic(fmt.Sprintf("goa: invalid pattern %q, missing closing }", pattern))
+		}
+		name := rest[i+1 : i+j]
+		rest = rest[i+j+1:]
+		if strings.HasPrefix(name, "*") {
+			if rest != "" {
+				panic(fmt.Sprintf("goa: invalid pattern %q, {%s} must end the pattern", pattern, name))
+			}
+			if n.catchAll == nil {
+				n.catchAll = &radixNode{}
+			}
+			route.names = append(route.names, name[1:])
+			n = n.catchAll
+			break
+		}
+		if n.param == nil {
+			n.param = &radixNode{}
+		}
+		route.names = append(route.names, name)
+		n = n.param
+	}
+	if n.route != nil {
+		panic(fmt.Sprintf("goa: pattern %q is already registered for %s", pattern, method))
+	}
+	n.route = route
+	if len(route.names) > m.maxParams {
+		m.maxParams = len(route.names)
+	}
+}
+
+// ServeHTTP dispatches the request to the handler whose method matches the
+// request method and whose pattern most closely matches the request URL.
+func (m *radixMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if r.URL.RawPath != "" {
+		path = r.URL.RawPath
+	}
+	method := r.Method
+	if method == http.MethodHead {
+		if _, ok := m.trees[method]; !ok {
+			method = http.MethodGet
+		}
+	}
+	if root, ok := m.trees[method]; ok {
+		p := m.params.Get().(*radixParams)
+		p.values = p.values[:0]
+		if route := root.lookup(path, p); route != nil {
+			if len(route.names) == 0 {
+				m.params.Put(p)
+				route.wrapped.ServeHTTP(w, r)
+				return
+			}
+			p.names = route.names
+			if r.URL.RawPath != "" {
+				unescapeParams(p.values)
+			}
+			route.wrapped.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), radixParamsKey, p)))
+			p.names = nil
+			m.params.Put(p)
+			return
+		}
+		m.params.Put(p)
+	}
+	allowed := m.allowed(path)
+	if len(allowed) == 0 {
+		ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+		enc := ResponseEncoder(ctx, w)
+		w.WriteHeader(http.StatusNotFound)
+		enc.Encode(NewErrorResponse(fmt.Errorf("404 page not found"))) // nolint: errcheck
+		return
+	}
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	ctx := context.WithValue(r.Context(), AcceptTypeKey, r.Header.Get("Accept"))
+	enc := ResponseEncoder(ctx, w)
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	enc.Encode(NewErrorResponse(fmt.Errorf("405 method %s not allowed", r.Method))) // nolint: errcheck
+}
+
+// Vars returns the path variables captured for the given request.
+func (m *radixMux) Vars(r *http.Request) map[string]string {
+	p, ok := r.Context().Value(radixParamsKey).(*radixParams)
+	if !ok {
+		return nil
+	}
+	vars := make(map[string]string, len(p.names))
+	for i, name := range p.names {
+		vars[name] = p.values[i]
+	}
+	return vars
+}
+
+// Use appends a middleware to the list of middlewares to be applied
+// downstream the Muxer.
+func (m *radixMux) Use(f func(http.Handler) http.Handler) {
+	m.middlewares = append(m.middlewares, f)
+	for _, root := range m.trees {
+		root.walk(func(r *radixRoute) { r.wrapped = m.wrap(r.handler) })
+	}
+}
+
+// wrap applies the muxer middlewares to h.
+func (m *radixMux) wrap(h http.Handler) http.Handler {
+	for i := len(m.middlewares) - 1; i >= 0; i-- {
+		h = m.middlewares[i](h)
+	}
+	return h
+}
+
+// allowed returns the sorted list of HTTP methods registered for a pattern
+// that matches path or nil if there is none.
+func (m *radixMux) allowed(path string) []string {
+	var (
+		allowed []string
+		p       = &radixParams{}
+	)
+	for meth, root := range m.trees {
+		p.values = p.values[:0]
+		if root.lookup(path, p) != nil {
+			allowed = append(allowed, meth)
+			if meth == http.MethodGet {
+				if _, ok := m.trees[http.MethodHead]; !ok {
+					allowed = append(allowed, http.MethodHead)
+				}
+			}
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	if _, ok := m.trees[http.MethodOptions]; !ok {
+		allowed = append(allowed, http.MethodOptions)
+	}
+	sort.Strings(allowed)
+	return allowed
+}
+
+// insert adds the static path to the tree rooted at n, splitting nodes as
+// needed, and returns the node matching the end of the path.
+func (n *radixNode) insert(path string) *radixNode {
+	for path != "" {
+		i := n.index(path[0])
+		if i < 0 {
+			child := &radixNode{path: path}
+			n.indices = append(n.indices, path[0])
+			n.children = append(n.children, child)
+			return child
+		}
+		child := n.children[i]
+		l := commonPrefix(path, child.path)
+		if l < len(child.path) {
+			split := &radixNode{
+				path:     child.path[:l],
+				indices:  []byte{child.path[l]},
+				children: []*radixNode{child},
+			}
+			child.path = child.path[l:]
+			n.children[i] = split
+			child = split
+		}
+		n = child
+		path = path[l:]
+	}
+	return n
+}
+
+// lookup returns the route whose pattern matches path and appends the values
+// of the captured wildcards to p. Static children are tried first, then
+// "{name}" wildcards and finally "{*name}" wildcards.
+func (n *radixNode) lookup(path string, p *radixParams) *radixRoute {
+	if path == "" {
+		if n.route != nil {
+			return n.route
+		}
+		if n.catchAll != nil && n.catchAll.route != nil {
+			p.values = append(p.values, "")
+			return n.catchAll.route
+		}
+		return nil
+	}
+	if i := n.index(path[0]); i >= 0 {
+		child := n.children[i]
+		if strings.HasPrefix(path, child.path) {
+			if r := child.lookup(path[len(child.path):], p); r != nil {
+				return r
+			}
+		}
+	}
+	if n.param != nil {
+		end := strings.IndexByte(path, '/')
+		if end < 0 {
+			end = len(path)
+		}
+		if end > 0 {
+			p.values = append(p.values, path[:end])
+			if r := n.param.lookup(path[end:], p); r != nil {
+				return r
+			}
+			p.values = p.values[:len(p.values)-1]
+		}
+	}
+	if n.catchAll != nil && n.catchAll.route != nil {
+		p.values = append(p.values, path)
+		return n.catchAll.route
+	}
+	return nil
+}
+
+// index returns the index of the static child whose path starts with c or -1.
+func (n *radixNode) index(c byte) int {
+	for i, b := range n.indices {
+		if b == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// walk calls fn with all the routes of the tree rooted at n.
+func (n *radixNode) walk(fn func(*radixRoute)) {
+	if n.route != nil {
+		fn(n.route)
+	}
+	for _, c := range n.children {
+		c.walk(fn)
+	}
+	if n.param != nil {
+		n.param.walk(fn)
+	}
+	if n.catchAll != nil {
+		n.catchAll.walk(fn)
+	}
+}
+
+// commonPrefix returns the length of the longest common prefix of a and b.
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// unescapeParams unescapes the given values in place.
+func unescapeParams(values []string) {
+	for i, v := range values {
+		if u, err := url.PathUnescape(v); err == nil {
+			values[i] = u
+		}
+	}
+}
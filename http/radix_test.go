@@ -0,0 +1,186 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRadixMuxer(t *testing.T) {
+	routes := []struct{ Method, Pattern string }{
+		{"GET", "/"},
+		{"GET", "/users"},
+		{"GET", "/users/me"},
+		{"GET", "/users/{id}"},
+		{"PUT", "/users/{id}"},
+		{"GET", "/users/{id}/posts/{post}"},
+		{"GET", "/users/{name}/avatar"},
+		{"GET", "/uploads"},
+		{"GET", "/files/{*path}"},
+		{"GET", "/files/static/logo.png"},
+	}
+	cases := []struct {
+		Name   string
+		Method string
+		Path   string
+		Status int
+		Body   string
+		Allow  string
+	}{
+		{"root", "GET", "/", http.StatusOK, "GET / map[]", ""},
+		{"static", "GET", "/users", http.StatusOK, "GET /users map[]", ""},
+		{"static over wildcard", "GET", "/users/me", http.StatusOK, "GET /users/me map[]", ""},
+		{"wildcard", "GET", "/users/42", http.StatusOK, "GET /users/{id} map[id:42]", ""},
+		{"wildcard prefix of static", "GET", "/users/mel", http.StatusOK, "GET /users/{id} map[id:mel]", ""},
+		{"wildcard other method", "PUT", "/users/42", http.StatusOK, "PUT /users/{id} map[id:42]", ""},
+		{"wildcards", "GET", "/users/42/posts/7", http.StatusOK, "GET /users/{id}/posts/{post} map[id:42 post:7]", ""},
+		{"wildcard other name", "GET", "/users/bob/avatar", http.StatusOK, "GET /users/{name}/avatar map[name:bob]", ""},
+		{"escaped wildcard", "GET", "/users/a%2Fb", http.StatusOK, "GET /users/{id} map[id:a/b]", ""},
+		{"split static", "GET", "/uploads", http.StatusOK, "GET /uploads map[]", ""},
+		{"catch all", "GET", "/files/a/b/c.txt", http.StatusOK, "GET /files/{*path} map[path:a/b/c.txt]", ""},
+		{"static over catch all", "GET", "/files/static/logo.png", http.StatusOK, "GET /files/static/logo.png map[]", ""},
+		{"catch all backtrack", "GET", "/files/static/other.png", http.StatusOK, "GET /files/{*path} map[path:static/other.png]", ""},
+		{"head", "HEAD", "/users/42", http.StatusOK, "HEAD /users/{id} map[id:42]", ""},
+		{"options", "OPTIONS", "/users/42", http.StatusNoContent, "", "GET, HEAD, OPTIONS, PUT"},
+		{"not allowed", "DELETE", "/users/42", http.StatusMethodNotAllowed, "405 method DELETE not allowed", "GET, HEAD, OPTIONS, PUT"},
+		{"not found", "GET", "/posts", http.StatusNotFound, "404 page not found", ""},
+		{"empty wildcard", "GET", "/users//posts/7", http.StatusNotFound, "404 page not found", ""},
+		{"trailing slash", "GET", "/users/", http.StatusNotFound, "404 page not found", ""},
+	}
+	m := NewRadixMuxer()
+	for _, r := range routes {
+		pattern := r.Pattern
+		m.Handle(r.Method, pattern, func(w http.ResponseWriter, req *http.Request) {
+			fmt.Fprintf(w, "%s %s %s", req.Method, pattern, formatVars(m.Vars(req)))
+		})
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest(c.Method, c.Path, nil)
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, r)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if !strings.Contains(w.Body.String(), c.Body) {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+			if allow := w.Header().Get("Allow"); allow != c.Allow {
+				t.Errorf("got Allow header %q, expected %q", allow, c.Allow)
+			}
+		})
+	}
+}
+
+func TestRadixMuxerMiddlewares(t *testing.T) {
+	m := NewRadixMuxer()
+	m.Use(func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("m1"))
+			h.ServeHTTP(w, r)
+		})
+	})
+	m.Handle("GET", "/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(m.Vars(r)["id"]))
+	})
+	m.Use(func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("m2" + m.Vars(r)["id"]))
+			h.ServeHTTP(w, r)
+		})
+	})
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", "/x", nil))
+	if w.Body.String() != "m1m2xx" {
+		t.Errorf("got %q, expected %q", w.Body.String(), "m1m2xx")
+	}
+}
+
+func TestRadixMuxerConflicts(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Patterns []string
+	}{
+		{"duplicate", []string{"/a/{id}", "/a/{id}"}},
+		{"duplicate with other names", []string{"/a/{id}", "/a/{name}"}},
+		{"catch all not last", []string{"/a/{*path}/b"}},
+		{"missing brace", []string{"/a/{id"}},
+		{"relative", []string{"a"}},
+	}
+	for _, c := range cases {
+		patterns := c.Patterns
+		t.Run(c.Name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected a panic")
+				}
+			}()
+			m := NewRadixMuxer()
+			for _, p := range patterns {
+				m.Handle("GET", p, func(http.ResponseWriter, *http.Request) {})
+			}
+		})
+	}
+}
+
+func BenchmarkMuxers(b *testing.B) {
+	muxers := []struct {
+		Name string
+		New  func() Muxer
+	}{
+		{"httptreemux", func() Muxer { return NewMuxer() }},
+		{"radix", func() Muxer { return NewRadixMuxer() }},
+	}
+	patterns := []string{
+		"/",
+		"/users",
+		"/users/{id}",
+		"/users/{id}/posts",
+		"/users/{id}/posts/{post}",
+		"/users/{id}/posts/{post}/comments",
+		"/posts",
+		"/posts/{post}",
+		"/files/{*path}",
+	}
+	paths := []struct{ Name, Path string }{
+		{"static", "/users"},
+		{"one param", "/users/42"},
+		{"two params", "/users/42/posts/7"},
+		{"catch all", "/files/a/b/c.txt"},
+	}
+	for _, mx := range muxers {
+		m := mx.New()
+		for _, p := range patterns {
+			m.Handle("GET", p, func(w http.ResponseWriter, r *http.Request) {
+				_ = m.Vars(r)
+			})
+		}
+		for _, p := range paths {
+			r := httptest.NewRequest("GET", p.Path, nil)
+			w := httptest.NewRecorder()
+			b.Run(mx.Name+"/"+p.Name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					m.ServeHTTP(w, r)
+				}
+			})
+		}
+	}
+}
+
+// formatVars returns a deterministic representation of vars.
+func formatVars(vars map[string]string) string {
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	elems := make([]string, len(keys))
+	for i, k := range keys {
+		elems[i] = k + ":" + vars[k]
+	}
+	return "map[" + strings.Join(elems, " ") + "]"
+}
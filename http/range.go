@@ -0,0 +1,210 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RangeWriter is a response writer that serves the byte range requested with
+// the Range header of a request. The generated servers use a RangeWriter to
+// write the responses of the endpoints that use the AcceptRanges DSL.
+//
+// The range is applied when the response is a 200 OK response and its size is
+// known, either because the response body is an io.Seeker or because the
+// response sets the Content-Length header. RangeWriter then writes a 206
+// Partial Content response with the Content-Range header set, or a 416 Range
+// Not Satisfiable response if the range does not overlap the body. Requests
+// with multiple ranges, an invalid Range header or an If-Range header that
+// does not match the response ETag or Last-Modified headers are served the
+// complete body.
+//
+// The body must be read through Body so that only the requested range gets
+// written.
+type RangeWriter struct {
+	http.ResponseWriter
+	r       *http.Request
+	body    io.Reader
+	written bool
+	// skip is the number of bytes to discard before the range.
+	skip int64
+	// length is the length of the range, -1 for the complete body.
+	length int64
+	// discard is true if the body must not be written.
+	discard bool
+}
+
+// NewRangeWriter returns a RangeWriter that writes the range of body requested
+// by r to w.
+func NewRangeWriter(w http.ResponseWriter, r *http.Request, body io.Reader) *RangeWriter {
+	return &RangeWriter{ResponseWriter: w, r: r, body: body, length: -1}
+}
+
+// WriteHeader applies the requested range to responses with status code 200
+// and writes the header.
+func (w *RangeWriter) WriteHeader(code int) {
+	if w.written {
+		return
+	}
+	w.written = true
+	if code != http.StatusOK {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	h := w.Header()
+	h.Set("Accept-Ranges", "bytes")
+	spec := w.r.Header.Get("Range")
+	if spec == "" || !w.ifRange() {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	size := w.size()
+	if size < 0 {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	start, length, ok := parseRange(spec, size)
+	if !ok {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if length == 0 {
+		h.Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		h.Del("Content-Length")
+		w.discard = true
+		w.ResponseWriter.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	h.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size))
+	h.Set("Content-Length", strconv.FormatInt(length, 10))
+	w.length = length
+	w.skip = start
+	if s, ok := w.body.(io.Seeker); ok {
+		if _, err := s.Seek(start, io.SeekCurrent); err == nil {
+			w.skip = 0
+		}
+	}
+	w.ResponseWriter.WriteHeader(http.StatusPartialContent)
+}
+
+// Write writes the header if not written yet then writes b unless the range
+// is not satisfiable.
+func (w *RangeWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.discard {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Body returns the reader of the requested range of the body. Body must be
+// called after the header has been written.
+func (w *RangeWriter) Body() io.Reader {
+	if w.discard {
+		return strings.NewReader("")
+	}
+	body := w.body
+	if w.skip > 0 {
+		if _, err := io.CopyN(io.Discard, body, w.skip); err != nil {
+			return strings.NewReader("")
+		}
+		w.skip = 0
+	}
+	if w.length >= 0 {
+		body = io.LimitReader(body, w.length)
+	}
+	return body
+}
+
+// Flush implements the http.Flusher interface if the underlying response
+// writer supports it.
+func (w *RangeWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// size returns the size of the response body, -1 if unknown.
+func (w *RangeWriter) size() int64 {
+	if _, ok := w.body.(io.Seeker); ok {
+		if n := bodySize(w.body); n >= 0 {
+			return n
+		}
+	}
+	if n, err := strconv.ParseInt(w.Header().Get("Content-Length"), 10, 64); err == nil && n >= 0 {
+		return n
+	}
+	return -1
+}
+
+// ifRange returns true if the request does not set the If-Range header or if
+// the header matches the response ETag or Last-Modified headers.
+func (w *RangeWriter) ifRange() bool {
+	ir := w.r.Header.Get("If-Range")
+	if ir == "" {
+		return true
+	}
+	if strings.HasPrefix(ir, `"`) {
+		etag := w.Header().Get("ETag")
+		return etag != "" && etag == ir
+	}
+	lm, err := http.ParseTime(w.Header().Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	t, err := http.ParseTime(ir)
+	return err == nil && lm.Truncate(time.Second).Equal(t)
+}
+
+// parseRange parses a single byte range specification and returns the start
+// offset and length of the range given the size of the body. ok is false if
+// the specification is invalid or lists multiple ranges, length is zero if the
+// range is not satisfiable.
+func parseRange(spec string, size int64) (start, length int64, ok bool) {
+	if !strings.HasPrefix(spec, "bytes=") {
+		return 0, 0, false
+	}
+	spec = strings.TrimSpace(strings.TrimPrefix(spec, "bytes="))
+	if strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	i := strings.Index(spec, "-")
+	if i < 0 {
+		return 0, 0, false
+	}
+	first, last := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+	if first == "" {
+		// Suffix range: last bytes of the body.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return 0, 0, false
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, n, true
+	}
+	s, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || s < 0 {
+		return 0, 0, false
+	}
+	if s >= size {
+		return 0, 0, true
+	}
+	end := size - 1
+	if last != "" {
+		e, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || e < s {
+			return 0, 0, false
+		}
+		if e < end {
+			end = e
+		}
+	}
+	return s, end - s + 1, true
+}
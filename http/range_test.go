@@ -0,0 +1,69 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRangeWriter(t *testing.T) {
+	const body = "0123456789"
+	cases := map[string]struct {
+		rng, ifRange string
+		seeker       bool
+		status       int
+		contentRange string
+		expected     string
+	}{
+		"no range":          {"", "", true, http.StatusOK, "", body},
+		"range":             {"bytes=2-4", "", true, http.StatusPartialContent, "bytes 2-4/10", "234"},
+		"open range":        {"bytes=7-", "", true, http.StatusPartialContent, "bytes 7-9/10", "789"},
+		"suffix range":      {"bytes=-3", "", true, http.StatusPartialContent, "bytes 7-9/10", "789"},
+		"capped range":      {"bytes=8-20", "", true, http.StatusPartialContent, "bytes 8-9/10", "89"},
+		"content length":    {"bytes=2-4", "", false, http.StatusPartialContent, "bytes 2-4/10", "234"},
+		"multiple ranges":   {"bytes=1-2,4-5", "", true, http.StatusOK, "", body},
+		"invalid range":     {"bytes=5-2", "", true, http.StatusOK, "", body},
+		"unsatisfiable":     {"bytes=10-", "", true, http.StatusRequestedRangeNotSatisfiable, "bytes */10", ""},
+		"if-range match":    {"bytes=2-4", `"v1"`, true, http.StatusPartialContent, "bytes 2-4/10", "234"},
+		"if-range mismatch": {"bytes=2-4", `"v2"`, true, http.StatusOK, "", body},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.rng != "" {
+				r.Header.Set("Range", c.rng)
+			}
+			if c.ifRange != "" {
+				r.Header.Set("If-Range", c.ifRange)
+			}
+			var src io.Reader = bytes.NewReader([]byte(body))
+			if !c.seeker {
+				src = bytes.NewBufferString(body)
+			}
+			rec := httptest.NewRecorder()
+			w := NewRangeWriter(rec, r, src)
+			w.Header().Set("ETag", `"v1"`)
+			if !c.seeker {
+				w.Header().Set("Content-Length", "10")
+			}
+			w.WriteHeader(http.StatusOK)
+			if _, err := io.Copy(w, w.Body()); err != nil {
+				t.Fatal(err)
+			}
+			if rec.Code != c.status {
+				t.Errorf("got status %d, expected %d", rec.Code, c.status)
+			}
+			if cr := rec.Header().Get("Content-Range"); cr != c.contentRange {
+				t.Errorf("got Content-Range %q, expected %q", cr, c.contentRange)
+			}
+			if got := rec.Body.String(); got != c.expected {
+				t.Errorf("got body %q, expected %q", got, c.expected)
+			}
+			if ar := rec.Header().Get("Accept-Ranges"); ar != "bytes" {
+				t.Errorf("got Accept-Ranges %q, expected bytes", ar)
+			}
+		})
+	}
+}
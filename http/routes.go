@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+)
+
+// Route describes a route mounted on the muxer of a Service.
+type Route struct {
+	// Service is the name of the service that serves the route.
+	Service string `json:"service"`
+	// Method is the name of the service method served by the route or the
+	// path of the served file for file servers.
+	Method string `json:"method"`
+	// Verb is the HTTP method of the route.
+	Verb string `json:"verb"`
+	// Pattern is the request path pattern of the route.
+	Pattern string `json:"pattern"`
+	// Handler is the HTTP handler mounted on the route, nil for file
+	// servers.
+	Handler http.Handler `json:"-"`
+}
+
+// Routes returns the routes mounted with Mount in order. The routes are read
+// from the Mounts field of the servers generated by goa, the routes mounted
+// directly on the muxer are not listed. Routes makes it possible for
+// operational tooling such as gateways to discover the endpoints served by a
+// binary.
+func (s *Service) Routes() []*Route {
+	routes := make([]*Route, len(s.routes))
+	copy(routes, s.routes)
+	return routes
+}
+
+// RoutesHandler returns a HTTP handler that writes the routes returned by
+// Routes as a JSON array of objects with "service", "method", "verb" and
+// "pattern" fields. The handler may be mounted on the service muxer:
+//
+//    httpSvc.Mux.Handle("GET", "/routes", httpSvc.RoutesHandler())
+//
+func (s *Service) RoutesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.Routes()) // nolint: errcheck
+	}
+}
+
+// mountedRoutes returns the routes listed in the Mounts field of the servers
+// generated by goa. The handlers are read from the server fields named after
+// the methods.
+func mountedRoutes(svr Server) []*Route {
+	var service string
+	if s, ok := svr.(interface{ Service() string }); ok {
+		service = s.Service()
+	}
+	v := reflect.Indirect(reflect.ValueOf(svr))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	mounts := v.FieldByName("Mounts")
+	if mounts.Kind() != reflect.Slice {
+		return nil
+	}
+	field := func(v reflect.Value, name string) string {
+		if f := v.FieldByName(name); f.Kind() == reflect.String {
+			return f.String()
+		}
+		return ""
+	}
+	routes := make([]*Route, 0, mounts.Len())
+	for i := 0; i < mounts.Len(); i++ {
+		m := reflect.Indirect(mounts.Index(i))
+		if m.Kind() != reflect.Struct {
+			continue
+		}
+		route := &Route{
+			Service: service,
+			Method:  field(m, "Method"),
+			Verb:    field(m, "Verb"),
+			Pattern: field(m, "Pattern"),
+		}
+		if f := v.FieldByName(route.Method); f.IsValid() && f.CanInterface() {
+			route.Handler, _ = f.Interface().(http.Handler)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type routesServer struct {
+	testServer
+	Mounts []*debugMountPoint
+	Ping   http.Handler
+}
+
+func (s *routesServer) Service() string { return "ping" }
+
+func TestServiceRoutes(t *testing.T) {
+	ping := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	s := NewService()
+	s.Mount(&routesServer{Mounts: []*debugMountPoint{{"Ping", "GET", "/ping"}, {"Ping", "HEAD", "/ping"}, {"index.html", "GET", "/"}}, Ping: ping})
+	routes := s.Routes()
+	if len(routes) != 3 {
+		t.Fatalf("got %d routes, expected 3", len(routes))
+	}
+	expected := []Route{
+		{Service: "ping", Method: "Ping", Verb: "GET", Pattern: "/ping"},
+		{Service: "ping", Method: "Ping", Verb: "HEAD", Pattern: "/ping"},
+		{Service: "ping", Method: "index.html", Verb: "GET", Pattern: "/"},
+	}
+	for i, r := range routes {
+		if r.Service != expected[i].Service || r.Method != expected[i].Method || r.Verb != expected[i].Verb || r.Pattern != expected[i].Pattern {
+			t.Errorf("got route %d %+v, expected %+v", i, *r, expected[i])
+		}
+		if hasHandler := r.Handler != nil; hasHandler != (i < 2) {
+			t.Errorf("got handler %v for route %d", r.Handler, i)
+		}
+	}
+	routes[0] = nil
+	if s.Routes()[0] == nil {
+		t.Error("Routes returned the internal slice")
+	}
+
+	w := httptest.NewRecorder()
+	s.RoutesHandler().ServeHTTP(w, httptest.NewRequest("GET", "/routes", nil))
+	const body = `[{"service":"ping","method":"Ping","verb":"GET","pattern":"/ping"},{"service":"ping","method":"Ping","verb":"HEAD","pattern":"/ping"},{"service":"ping","method":"index.html","verb":"GET","pattern":"/"}]` + "\n"
+	if w.Body.String() != body {
+		t.Errorf("got body %s, expected %s", w.Body.String(), body)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got content type %q, expected application/json", ct)
+	}
+
+	empty := NewService()
+	empty.Mount(&testServer{})
+	w = httptest.NewRecorder()
+	empty.RoutesHandler().ServeHTTP(w, httptest.NewRequest("GET", "/routes", nil))
+	if w.Body.String() != "[]\n" {
+		t.Errorf("got body %q, expected an empty array", w.Body.String())
+	}
+}
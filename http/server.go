@@ -3,6 +3,7 @@ package http
 import (
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 )
 
@@ -67,3 +68,32 @@ func Replace(old, nw string, h http.Handler) http.Handler {
 		}
 	})
 }
+
+// AliasRedirect returns a handler that redirects the requests made to a legacy
+// path mounted on mux to target with the given status code, e.g.
+// http.StatusPermanentRedirect. The wildcards of target, e.g. "/bottles/{id}",
+// are replaced with the values of the wildcards with the same names captured
+// by mux in the request path. The request query string is preserved. The
+// generated servers use AliasRedirect to mount the paths defined with the
+// Alias DSL.
+func AliasRedirect(mux Muxer, target string, code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		u := aliasWildcardRegex.ReplaceAllStringFunc(target, func(w string) string {
+			m := aliasWildcardRegex.FindStringSubmatch(w)
+			segs := strings.Split(vars[m[1]], "/")
+			for i, seg := range segs {
+				segs[i] = url.PathEscape(seg)
+			}
+			return "/" + strings.Join(segs, "/")
+		})
+		if r.URL.RawQuery != "" {
+			u += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, u, code)
+	}
+}
+
+// aliasWildcardRegex matches the wildcards of the paths given to
+// AliasRedirect.
+var aliasWildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)}`)
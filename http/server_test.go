@@ -61,3 +61,32 @@ func TestReplace(t *testing.T) {
 		})
 	}
 }
+
+func TestAliasRedirect(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		target   string
+		reqPath  string
+		location string
+	}{
+		{"/old/bottles/{id}", "/bottles/{id}", "/old/bottles/42", "/bottles/42"},
+		{"/old/bottles/{id}", "/bottles/{id}", "/old/bottles/42?view=full", "/bottles/42?view=full"},
+		{"/old/bottles/{id}", "/bottles/{id}", "/old/bottles/a%20b", "/bottles/a%20b"},
+		{"/old/files/{*path}", "/files/{*path}", "/old/files/a/b.txt", "/files/a/b.txt"},
+		{"/old/bottles", "/bottles", "/old/bottles", "/bottles"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.reqPath, func(t *testing.T) {
+			mux := NewMuxer()
+			mux.Handle("GET", tc.pattern, AliasRedirect(mux, tc.target, http.StatusPermanentRedirect))
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", tc.reqPath, nil))
+			if w.Code != http.StatusPermanentRedirect {
+				t.Fatalf("got status %d, want 308", w.Code)
+			}
+			if g := w.Header().Get("Location"); g != tc.location {
+				t.Errorf("got Location %q, want %q", g, tc.location)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Service bundles the HTTP transport components shared by the servers
+	// generated for the services exposed by a HTTP server: the request
+	// multiplexer, the request decoder, the response encoder, the error
+	// handler and the error formatter. Service also holds the middlewares
+	// that apply to all the requests. The example main generated by goa
+	// uses a Service to build the HTTP server handler:
+	//
+	//    httpSvc := goahttp.NewService(goahttp.WithErrorHandler(eh))
+	//    usersServer := userssvr.New(usersEndpoints, httpSvc.Mux, httpSvc.Decoder, httpSvc.Encoder, httpSvc.ErrorHandler, httpSvc.Formatter)
+	//    httpSvc.Mount(usersServer)
+	//    httpSvc.Use(httpmdlwr.Log(adapter), httpmdlwr.RequestID())
+	//    srv := &http.Server{Addr: ":8080", Handler: httpSvc.Handler()}
+	//
+	Service struct {
+		// Mux is the request multiplexer the servers are mounted on.
+		Mux Muxer
+		// Decoder creates the request decoders.
+		Decoder func(*http.Request) Decoder
+		// Encoder creates the response encoders.
+		Encoder func(context.Context, http.ResponseWriter) Encoder
+		// ErrorHandler is called when encoding a response fails.
+		ErrorHandler func(context.Context, http.ResponseWriter, error)
+		// Formatter formats the errors returned by the service methods
+		// that are not described in the design, nil means the default
+		// formatter NewErrorResponse.
+		Formatter func(err error) Statuser
+
+		middlewares []func(http.Handler) http.Handler
+		debug       *debugConfig
+		routes      []*Route
+	}
+
+	// ServiceOption configures a Service.
+	ServiceOption func(*Service)
+)
+
+// NewService returns a HTTP service configured with the given options. The
+// service uses the default muxer, the RequestDecoder and ResponseEncoder
+// functions and an error handler that writes the error message to the
+// response by default.
+func NewService(opts ...ServiceOption) *Service {
+	s := &Service{
+		Decoder:      RequestDecoder,
+		Encoder:      ResponseEncoder,
+		ErrorHandler: writeError,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.Mux == nil {
+		s.Mux = NewMuxer()
+	}
+	if s.debug != nil {
+		s.mountDebug()
+	}
+	return s
+}
+
+// WithMuxer sets the service request multiplexer.
+func WithMuxer(mux Muxer) ServiceOption {
+	return func(s *Service) {
+		s.Mux = mux
+	}
+}
+
+// WithDecoder sets the function used to create the request decoders.
+func WithDecoder(dec func(*http.Request) Decoder) ServiceOption {
+	return func(s *Service) {
+		s.Decoder = dec
+	}
+}
+
+// WithEncoder sets the function used to create the response encoders.
+func WithEncoder(enc func(context.Context, http.ResponseWriter) Encoder) ServiceOption {
+	return func(s *Service) {
+		s.Encoder = enc
+	}
+}
+
+// WithErrorHandler sets the function called when encoding a response fails.
+func WithErrorHandler(eh func(context.Context, http.ResponseWriter, error)) ServiceOption {
+	return func(s *Service) {
+		s.ErrorHandler = eh
+	}
+}
+
+// WithFormatter sets the function used to format the errors that are not
+// described in the design.
+func WithFormatter(f func(err error) Statuser) ServiceOption {
+	return func(s *Service) {
+		s.Formatter = f
+	}
+}
+
+// WithMiddleware adds middlewares that apply to all the requests, see Use.
+func WithMiddleware(m ...func(http.Handler) http.Handler) ServiceOption {
+	return func(s *Service) {
+		s.middlewares = append(s.middlewares, m...)
+	}
+}
+
+// Mount mounts the given servers on the service muxer. It panics unless all
+// servers satisfy the Mounter interface.
+func (s *Service) Mount(servers ...Server) {
+	Servers(servers).Mount(s.Mux)
+	for _, svr := range servers {
+		s.routes = append(s.routes, mountedRoutes(svr)...)
+	}
+}
+
+// Use adds middlewares that apply to all the requests. The middlewares wrap
+// the muxer in order so that the last middleware added is the first one
+// invoked when handling a request.
+func (s *Service) Use(m ...func(http.Handler) http.Handler) {
+	s.middlewares = append(s.middlewares, m...)
+}
+
+// Handler returns the handler that serves the requests with the service muxer
+// wrapped with the service middlewares.
+func (s *Service) Handler() http.Handler {
+	var h http.Handler = s.Mux
+	for _, m := range s.middlewares {
+		h = m(h)
+	}
+	return h
+}
+
+// writeError is the default service error handler.
+func writeError(_ context.Context, w http.ResponseWriter, err error) {
+	http.Error(w, "encoding: "+err.Error(), http.StatusInternalServerError)
+}
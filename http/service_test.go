@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testServer struct {
+	middlewares int
+}
+
+func (s *testServer) Use(func(http.Handler) http.Handler) {
+	s.middlewares++
+}
+
+func (s *testServer) Mount(mux Muxer) {
+	mux.Handle("GET", "/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong")) // nolint: errcheck
+	})
+}
+
+func TestService(t *testing.T) {
+	mw := func(name string) func(http.Handler) http.Handler {
+		return func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(name + ",")) // nolint: errcheck
+				h.ServeHTTP(w, r)
+			})
+		}
+	}
+	s := NewService(WithMiddleware(mw("inner")))
+	if s.Mux == nil || s.Decoder == nil || s.Encoder == nil || s.ErrorHandler == nil {
+		t.Fatal("got missing defaults")
+	}
+	s.Mount(&testServer{})
+	s.Use(mw("outer"))
+
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/ping", nil))
+	if got := w.Body.String(); got != "outer,inner,pong" {
+		t.Errorf("got body %q, expected %q", got, "outer,inner,pong")
+	}
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignURL(t *testing.T) {
+	var (
+		key = []byte("secret")
+		now = time.Unix(1700000000, 0)
+	)
+	u, err := url.Parse("https://api.example.com/reports/42?format=pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := SignURL(u, "k1", key, now.Add(time.Minute))
+	if id := SignedURLKeyID(signed); id != "k1" {
+		t.Errorf("got key ID %q, expected k1", id)
+	}
+	if u.RawQuery != "format=pdf" {
+		t.Errorf("original URL was modified: %s", u)
+	}
+	tampered := *signed
+	tampered.Path = "/reports/43"
+	altered, _ := url.Parse(signed.String())
+	q := altered.Query()
+	q.Set("format", "csv")
+	altered.RawQuery = q.Encode()
+	cases := map[string]struct {
+		url      *url.URL
+		key      []byte
+		now      time.Time
+		expected error
+	}{
+		"valid":          {signed, key, now, nil},
+		"expired":        {signed, key, now.Add(2 * time.Minute), ErrURLExpired},
+		"wrong key":      {signed, []byte("other"), now, ErrInvalidURLSignature},
+		"tampered path":  {&tampered, key, now, ErrInvalidURLSignature},
+		"tampered query": {altered, key, now, ErrInvalidURLSignature},
+		"not signed":     {u, key, now, ErrURLNotSigned},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			if err := VerifyURL(c.url, c.key, c.now); err != c.expected {
+				t.Errorf("got error %v, expected %v", err, c.expected)
+			}
+		})
+	}
+}
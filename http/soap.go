@@ -0,0 +1,204 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// SOAPEnvelopeNamespace is the namespace of the SOAP 1.1 envelopes.
+const SOAPEnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+
+type (
+	// SOAPServer serves the SOAP facade of a service generated for the
+	// services that define the "soap:path" meta. The server decodes the
+	// operation element of the request envelopes into the method payloads,
+	// calls the service endpoints and encodes the results in the response
+	// envelopes. Errors are written as SOAP faults. GET requests are served
+	// the WSDL document describing the operations.
+	SOAPServer struct {
+		// Service is the name of the service.
+		Service string
+		// Namespace is the target namespace of the WSDL document, the
+		// namespace of the response elements.
+		Namespace string
+		// WSDL is the WSDL document.
+		WSDL []byte
+
+		ops        map[string]*SOAPOperation
+		errhandler func(context.Context, http.ResponseWriter, error)
+	}
+
+	// SOAPOperation describes a SOAP operation implemented by a service
+	// method.
+	SOAPOperation struct {
+		// Name is the name of the method, the local name of the request
+		// element. The response element is Name followed by "Response".
+		Name string
+		// Endpoint is the method endpoint.
+		Endpoint goa.Endpoint
+		// Decode decodes the request element into the method payload.
+		// The request element is skipped and the payload is nil if
+		// Decode is nil.
+		Decode func(d *xml.Decoder, start *xml.StartElement) (interface{}, error)
+		// Encode returns the value encoded as the content of the
+		// response element given the method result. The response
+		// element is empty if Encode is nil.
+		Encode func(res interface{}) interface{}
+	}
+
+	// soapFault is the SOAP 1.1 fault element. The prefixed name relies on
+	// the "soap" prefix declared by the response envelope.
+	soapFault struct {
+		XMLName xml.Name         `xml:"soap:Fault"`
+		Code    string           `xml:"faultcode"`
+		String  string           `xml:"faultstring"`
+		Detail  *soapFaultDetail `xml:"detail,omitempty"`
+	}
+
+	// soapFaultDetail is the detail of the faults caused by service errors.
+	soapFaultDetail struct {
+		// ErrorName is the name of the error in the design.
+		ErrorName string `xml:"errorName"`
+	}
+
+	// errorNamer is implemented by the errors that have a name in the
+	// design.
+	errorNamer interface {
+		ErrorName() string
+	}
+)
+
+// NewSOAPServer returns a SOAP server for the given service operations. The
+// optional errhandler is called with the errors that prevent writing the
+// response.
+func NewSOAPServer(service, namespace string, wsdl []byte, ops []*SOAPOperation, errhandler func(context.Context, http.ResponseWriter, error)) *SOAPServer {
+	m := make(map[string]*SOAPOperation, len(ops))
+	for _, op := range ops {
+		m[op.Name] = op
+	}
+	return &SOAPServer{Service: service, Namespace: namespace, WSDL: wsdl, ops: m, errhandler: errhandler}
+}
+
+// ServeHTTP serves the WSDL document on GET requests and the SOAP operations
+// on POST requests.
+func (s *SOAPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.Write(s.WSDL) // nolint: errcheck
+		return
+	case http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	op, payload, err := s.decode(r.Body)
+	if err != nil {
+		s.write(ctx, w, http.StatusInternalServerError, xml.Name{}, &soapFault{Code: "soap:Client", String: err.Error()})
+		return
+	}
+	ctx = context.WithValue(ctx, goa.MethodKey, op.Name)
+	ctx = context.WithValue(ctx, goa.ServiceKey, s.Service)
+	res, err := op.Endpoint(ctx, payload)
+	if err != nil {
+		s.write(ctx, w, http.StatusInternalServerError, xml.Name{}, newSOAPFault(err))
+		return
+	}
+	var v interface{} = struct{}{}
+	if op.Encode != nil {
+		v = op.Encode(res)
+	}
+	s.write(ctx, w, http.StatusOK, xml.Name{Space: s.Namespace, Local: op.Name + "Response"}, v)
+}
+
+// decode reads the request envelope up to the operation element and decodes
+// it into the method payload.
+func (s *SOAPServer) decode(body io.Reader) (*SOAPOperation, interface{}, error) {
+	d := xml.NewDecoder(body)
+	inBody := false
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil, nil, errors.New("missing SOAP body")
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid SOAP envelope: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if !inBody {
+			inBody = start.Name.Local == "Body"
+			continue
+		}
+		op, ok := s.ops[start.Name.Local]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown operation %q", start.Name.Local)
+		}
+		if op.Decode == nil {
+			return op, nil, d.Skip()
+		}
+		payload, err := op.Decode(d, &start)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s request: %w", op.Name, err)
+		}
+		return op, payload, nil
+	}
+}
+
+// write writes the response envelope whose body contains v encoded in an
+// element with the given name, or v itself if the name is empty.
+func (s *SOAPServer) write(ctx context.Context, w http.ResponseWriter, status int, name xml.Name, v interface{}) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<soap:Envelope xmlns:soap="` + SOAPEnvelopeNamespace + `"><soap:Body>`)
+	enc := xml.NewEncoder(&buf)
+	var err error
+	if name.Local == "" {
+		err = enc.Encode(v)
+	} else {
+		err = enc.EncodeElement(v, xml.StartElement{Name: name})
+	}
+	if err != nil {
+		if s.errhandler != nil {
+			s.errhandler(ctx, w, err)
+		}
+		if _, ok := v.(*soapFault); !ok {
+			s.write(ctx, w, http.StatusInternalServerError, xml.Name{}, &soapFault{Code: "soap:Server", String: "failed to encode response"})
+		}
+		return
+	}
+	buf.WriteString(`</soap:Body></soap:Envelope>`)
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := w.Write(buf.Bytes()); err != nil && s.errhandler != nil {
+		s.errhandler(ctx, w, err)
+	}
+}
+
+// newSOAPFault returns the fault that describes the given endpoint error. The
+// errors defined in the design are client faults unless they are faults in the
+// design, the other errors are server faults.
+func newSOAPFault(err error) *soapFault {
+	f := &soapFault{Code: "soap:Server", String: err.Error()}
+	var namer errorNamer
+	if errors.As(err, &namer) && namer.ErrorName() != "" {
+		f.Detail = &soapFaultDetail{ErrorName: namer.ErrorName()}
+		f.Code = "soap:Client"
+	}
+	var serr *goa.ServiceError
+	if errors.As(err, &serr) && serr.Fault {
+		f.Code = "soap:Server"
+	}
+	return f
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type soapGreetPayload struct {
+	Name string
+}
+
+type soapGreetResult struct {
+	Greeting string
+}
+
+func newTestSOAPServer() *SOAPServer {
+	return NewSOAPServer("greeter", "urn:greeter", []byte("<definitions/>"), []*SOAPOperation{
+		{
+			Name: "greet",
+			Endpoint: func(ctx context.Context, v interface{}) (interface{}, error) {
+				p := v.(*soapGreetPayload)
+				if p.Name == "" {
+					return nil, goa.PermanentError("missing_name", "name is required")
+				}
+				if p.Name == "panic" {
+					return nil, goa.Fault("boom")
+				}
+				return &soapGreetResult{Greeting: "hello " + p.Name + " from " + ctx.Value(goa.ServiceKey).(string)}, nil
+			},
+			Decode: func(d *xml.Decoder, start *xml.StartElement) (interface{}, error) {
+				p := &soapGreetPayload{}
+				if err := d.DecodeElement(p, start); err != nil {
+					return nil, err
+				}
+				return p, nil
+			},
+			Encode: func(res interface{}) interface{} { return res },
+		},
+		{
+			Name: "ping",
+			Endpoint: func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	}, nil)
+}
+
+func soapRequest(op string) *http.Request {
+	body := `<soap:Envelope xmlns:soap="` + SOAPEnvelopeNamespace + `"><soap:Header/><soap:Body>` + op + `</soap:Body></soap:Envelope>`
+	return httptest.NewRequest("POST", "/soap", strings.NewReader(body))
+}
+
+func TestSOAPServer(t *testing.T) {
+	const envelope = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>`
+	cases := []struct {
+		Name   string
+		Req    *http.Request
+		Status int
+		Body   string
+	}{
+		{"wsdl", httptest.NewRequest("GET", "/soap", nil), http.StatusOK, "<definitions/>"},
+		{"method-not-allowed", httptest.NewRequest("PUT", "/soap", nil), http.StatusMethodNotAllowed, "Method Not Allowed\n"},
+		{"success", soapRequest(`<greet xmlns="urn:greeter"><Name>joe</Name></greet>`), http.StatusOK,
+			envelope + `<greetResponse xmlns="urn:greeter"><Greeting>hello joe from greeter</Greeting></greetResponse></soap:Body></soap:Envelope>`},
+		{"no-payload", soapRequest(`<ping xmlns="urn:greeter"/>`), http.StatusOK,
+			envelope + `<pingResponse xmlns="urn:greeter"></pingResponse></soap:Body></soap:Envelope>`},
+		{"unknown-operation", soapRequest(`<unknown/>`), http.StatusInternalServerError,
+			envelope + `<soap:Fault><faultcode>soap:Client</faultcode><faultstring>unknown operation &#34;unknown&#34;</faultstring></soap:Fault></soap:Body></soap:Envelope>`},
+		{"missing-body", httptest.NewRequest("POST", "/soap", strings.NewReader(`<soap:Envelope xmlns:soap="`+SOAPEnvelopeNamespace+`"/>`)), http.StatusInternalServerError,
+			envelope + `<soap:Fault><faultcode>soap:Client</faultcode><faultstring>missing SOAP body</faultstring></soap:Fault></soap:Body></soap:Envelope>`},
+		{"client-fault", soapRequest(`<greet xmlns="urn:greeter"/>`), http.StatusInternalServerError,
+			envelope + `<soap:Fault><faultcode>soap:Client</faultcode><faultstring>name is required</faultstring><detail><errorName>missing_name</errorName></detail></soap:Fault></soap:Body></soap:Envelope>`},
+		{"server-fault", soapRequest(`<greet xmlns="urn:greeter"><Name>panic</Name></greet>`), http.StatusInternalServerError,
+			envelope + `<soap:Fault><faultcode>soap:Server</faultcode><faultstring>boom</faultstring><detail><errorName>fault</errorName></detail></soap:Fault></soap:Body></soap:Envelope>`},
+	}
+	srv := newTestSOAPServer()
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			srv.ServeHTTP(w, c.Req)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if w.Body.String() != c.Body {
+				t.Errorf("got body:\n%s\nexpected:\n%s", w.Body.String(), c.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// ResponseStats describes how a response was produced. The stats are
+	// collected by the encoders returned by MeasureEncoder and typically
+	// reported by the HTTP middleware.Metrics middleware.
+	ResponseStats struct {
+		// Service is the name of the service that handled the request.
+		Service string
+		// Method is the name of the method that handled the request.
+		Method string
+		// View is the view used to render the response if any.
+		View string
+		// Status is the response status code.
+		Status int
+		// Bytes is the number of bytes written in the response body.
+		Bytes int
+		// EncodeDuration is the time spent marshaling and writing the
+		// response body.
+		EncodeDuration time.Duration
+	}
+
+	// measuredEncoder is an encoder that records the time spent encoding.
+	measuredEncoder struct {
+		enc   Encoder
+		stats *ResponseStats
+	}
+
+	// responseStatsKeyType is the type of the context key used to store
+	// the response stats.
+	responseStatsKeyType struct{}
+)
+
+// responseStatsKey is the context key used to store the response stats.
+var responseStatsKey = responseStatsKeyType{}
+
+// WithResponseStats returns a copy of ctx that causes the encoders returned
+// by MeasureEncoder to record their statistics in the returned stats.
+func WithResponseStats(ctx context.Context) (context.Context, *ResponseStats) {
+	stats := &ResponseStats{}
+	return context.WithValue(ctx, responseStatsKey, stats), stats
+}
+
+// MeasureEncoder returns an encoder that records the time spent by enc in the
+// stats stored in ctx by WithResponseStats as well as the names of the
+// service and method set in ctx by the generated handlers. MeasureEncoder
+// returns enc unchanged if ctx does not contain stats. ResponseEncoder
+// measures the encoders it returns, custom response encoder functions given
+// to the generated servers may use MeasureEncoder to do the same:
+//
+//    func encoder(ctx context.Context, w http.ResponseWriter) goahttp.Encoder {
+//        return goahttp.MeasureEncoder(ctx, msgpack.NewEncoder(w))
+//    }
+//
+func MeasureEncoder(ctx context.Context, enc Encoder) Encoder {
+	stats, ok := ctx.Value(responseStatsKey).(*ResponseStats)
+	if !ok || enc == nil {
+		return enc
+	}
+	if s, ok := ctx.Value(goa.ServiceKey).(string); ok {
+		stats.Service = s
+	}
+	if m, ok := ctx.Value(goa.MethodKey).(string); ok {
+		stats.Method = m
+	}
+	return &measuredEncoder{enc: enc, stats: stats}
+}
+
+// Encode encodes v using the underlying encoder and records the time spent.
+func (e *measuredEncoder) Encode(v interface{}) error {
+	start := time.Now()
+	err := e.enc.Encode(v)
+	e.stats.EncodeDuration += time.Since(start)
+	return err
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type slowEncoder struct{ d time.Duration }
+
+func (e slowEncoder) Encode(interface{}) error {
+	time.Sleep(e.d)
+	return nil
+}
+
+func TestMeasureEncoder(t *testing.T) {
+	enc := slowEncoder{d: 10 * time.Millisecond}
+	if actual := MeasureEncoder(context.Background(), enc); actual != Encoder(enc) {
+		t.Errorf("got %T, expected encoder to be returned unchanged", actual)
+	}
+
+	ctx, stats := WithResponseStats(context.Background())
+	ctx = context.WithValue(ctx, goa.ServiceKey, "svc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "list")
+	if err := MeasureEncoder(ctx, enc).Encode(nil); err != nil {
+		t.Fatal(err)
+	}
+	if stats.Service != "svc" || stats.Method != "list" {
+		t.Errorf("got service %q and method %q, expected %q and %q", stats.Service, stats.Method, "svc", "list")
+	}
+	if stats.EncodeDuration < enc.d {
+		t.Errorf("got encode duration %s, expected at least %s", stats.EncodeDuration, enc.d)
+	}
+
+	var buf bytes.Buffer
+	if err := MeasureEncoder(ctx, newPooledJSONEncoder(&buf)).Encode("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "\"foo\"\n" {
+		t.Errorf("got %q, expected %q", buf.String(), "\"foo\"\n")
+	}
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DecodeStrict decodes the body of r into v using the decoder returned by
+// decoder. Decoders that support it (e.g. the encoding/json decoder) are
+// configured to reject fields that are not defined by v so that typos in
+// client requests do not go unnoticed. The generated server code calls
+// DecodeStrict for the methods whose design uses StrictPayload.
+//
+// If warn is true DecodeStrict does not reject the request and reports the
+// unknown fields to the handler stored in the request context with
+// WithUnknownFieldsHandler instead, the body is decoded as usual otherwise.
+func DecodeStrict(r *http.Request, decoder func(*http.Request) Decoder, v interface{}, warn bool) error {
+	dec := decoder(r)
+	if _, ok := dec.(unknownFieldsDisallower); !ok {
+		return dec.Decode(v)
+	}
+	if !warn {
+		dec.(unknownFieldsDisallower).DisallowUnknownFields()
+		return dec.Decode(v)
+	}
+	handler, _ := r.Context().Value(unknownFieldsKey).(func(context.Context, error))
+	if handler == nil || r.Body == nil {
+		return dec.Decode(v)
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	dec = decoder(r)
+	if d, ok := dec.(unknownFieldsDisallower); ok {
+		d.DisallowUnknownFields()
+	}
+	err = dec.Decode(v)
+	if err == nil || !IsUnknownFieldError(err) {
+		return err
+	}
+	handler(r.Context(), err)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return decoder(r).Decode(v)
+}
+
+// WithUnknownFieldsHandler returns a copy of ctx that holds the function
+// DecodeStrict calls when a request body contains unknown fields and the
+// design uses StrictPayload in warn mode. It is typically called by a
+// middleware that logs the errors:
+//
+//    func WarnUnknownFields(logger *log.Logger) func(http.Handler) http.Handler {
+//        return func(h http.Handler) http.Handler {
+//            return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//                ctx := goahttp.WithUnknownFieldsHandler(r.Context(), func(_ context.Context, err error) {
+//                    logger.Printf("%s %s: %s", r.Method, r.URL.Path, err)
+//                })
+//                h.ServeHTTP(w, r.WithContext(ctx))
+//            })
+//        }
+//    }
+func WithUnknownFieldsHandler(ctx context.Context, handler func(context.Context, error)) context.Context {
+	return context.WithValue(ctx, unknownFieldsKey, handler)
+}
+
+// IsUnknownFieldError returns true if err was returned by a decoder configured
+// to reject unknown fields because the decoded body contains such a field.
+func IsUnknownFieldError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "unknown field")
+}
+
+// unknownFieldsDisallower is implemented by decoders that can reject unknown
+// fields such as the encoding/json decoder.
+type unknownFieldsDisallower interface {
+	DisallowUnknownFields()
+}
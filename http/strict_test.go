@@ -0,0 +1,59 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeStrict(t *testing.T) {
+	type body struct {
+		Name string `json:"name"`
+	}
+	cases := []struct {
+		Name     string
+		Body     string
+		Warn     bool
+		Handler  bool
+		Error    bool
+		Warnings int
+	}{
+		{"known fields", `{"name":"joe"}`, false, false, false, 0},
+		{"unknown field", `{"name":"joe","nmae":"joe"}`, false, false, true, 0},
+		{"warn", `{"name":"joe","nmae":"joe"}`, true, true, false, 1},
+		{"warn known fields", `{"name":"joe"}`, true, true, false, 0},
+		{"warn without handler", `{"name":"joe","nmae":"joe"}`, true, false, false, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, _ := http.NewRequest("POST", "/", strings.NewReader(c.Body))
+			var warnings int
+			if c.Handler {
+				r = r.WithContext(WithUnknownFieldsHandler(r.Context(), func(_ context.Context, err error) {
+					if !IsUnknownFieldError(err) {
+						t.Errorf("got error %v, expected unknown field error", err)
+					}
+					warnings++
+				}))
+			}
+			var b body
+			err := DecodeStrict(r, RequestDecoder, &b, c.Warn)
+			if c.Error {
+				if !IsUnknownFieldError(err) {
+					t.Errorf("got error %v, expected unknown field error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if b.Name != "joe" {
+				t.Errorf("got name %q, expected %q", b.Name, "joe")
+			}
+			if warnings != c.Warnings {
+				t.Errorf("got %d warnings, expected %d", warnings, c.Warnings)
+			}
+		})
+	}
+}
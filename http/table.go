@@ -0,0 +1,209 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// CSVContentType is the content type of the CSV representation of
+	// collections.
+	CSVContentType = "text/csv"
+
+	// XLSXContentType is the content type of the Excel (Office Open XML
+	// workbook) representation of collections.
+	XLSXContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// Table is implemented by the response body types that can be rendered as
+// tables. The code generated for collection response bodies implements Table
+// when the design lists "text/csv" or the XLSX content type in Produces.
+type Table interface {
+	// TableHeader returns the names of the table columns.
+	TableHeader() []string
+	// TableRows returns the table rows. The values of a row are nil,
+	// strings, booleans or numbers and appear in the order of the columns.
+	TableRows() [][]interface{}
+}
+
+// TableEncoder returns the encoder used by the generated server code to write
+// the responses whose bodies implement Table. The encoder renders the bodies
+// as CSV or XLSX if the response content type set in the design or else the
+// request Accept header selects one of the given content types. TableEncoder
+// returns the encoder created with encoder otherwise.
+func TableEncoder(ctx context.Context, w http.ResponseWriter, encoder func(context.Context, http.ResponseWriter) Encoder, contentTypes ...string) Encoder {
+	switch ct := negotiateContentType(ctx, contentTypes...); ct {
+	case CSVContentType:
+		SetContentType(w, ct)
+		return EncodingFunc(func(v interface{}) error { return writeTable(v, w, writeCSV) })
+	case XLSXContentType:
+		SetContentType(w, ct)
+		return EncodingFunc(func(v interface{}) error { return writeTable(v, w, writeXLSX) })
+	}
+	return encoder(ctx, w)
+}
+
+// writeTable writes v with the given table writer.
+func writeTable(v interface{}, w io.Writer, write func(io.Writer, Table) error) error {
+	t, ok := v.(Table)
+	if !ok {
+		return fmt.Errorf("cannot render %T as a table", v)
+	}
+	return write(w, t)
+}
+
+// writeCSV writes the table in CSV.
+func writeCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.TableHeader()); err != nil {
+		return err
+	}
+	for _, row := range t.TableRows() {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = tableText(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// tableText returns the text of a table cell.
+func tableText(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// xlsxParts lists the parts of the XLSX package other than the worksheet.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// writeXLSX writes the table as a workbook with a single worksheet whose first
+// row contains the column names.
+func writeXLSX(w io.Writer, t Table) error {
+	zw := zip.NewWriter(w)
+	for _, p := range xlsxParts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, xml.Header+p.content); err != nil {
+			return err
+		}
+	}
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header := t.TableHeader()
+	cells := make([]interface{}, len(header))
+	for i, h := range header {
+		cells[i] = h
+	}
+	writeXLSXRow(&buf, 1, cells)
+	for i, row := range t.TableRows() {
+		writeXLSXRow(&buf, i+2, row)
+		if buf.Len() > 32*1024 {
+			if _, err := f.Write(buf.Bytes()); err != nil {
+				return err
+			}
+			buf.Reset()
+		}
+	}
+	buf.WriteString(`</sheetData></worksheet>`)
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeXLSXRow writes the worksheet row with the given number. Numbers and
+// booleans are written as such, the other values as inline strings.
+func writeXLSXRow(buf *bytes.Buffer, n int, row []interface{}) {
+	fmt.Fprintf(buf, `<row r="%d">`, n)
+	for i, v := range row {
+		if v == nil {
+			continue
+		}
+		ref := xlsxColumn(i) + strconv.Itoa(n)
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Bool:
+			b := "0"
+			if rv.Bool() {
+				b = "1"
+			}
+			fmt.Fprintf(buf, `<c r="%s" t="b"><v>%s</v></c>`, ref, b)
+			continue
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, tableText(v))
+			continue
+		case reflect.Float32, reflect.Float64:
+			if f := rv.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+				fmt.Fprintf(buf, `<c r="%s"><v>%s</v></c>`, ref, tableText(v))
+				continue
+			}
+		}
+		fmt.Fprintf(buf, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		xml.EscapeText(buf, []byte(tableText(v))) // nolint: errcheck
+		buf.WriteString(`</t></is></c>`)
+	}
+	buf.WriteString(`</row>`)
+}
+
+// xlsxColumn returns the name of the worksheet column with the given index,
+// e.g. "A" for 0 and "AA" for 26.
+func xlsxColumn(i int) string {
+	var name []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = append([]byte{byte('A' + (i-1)%26)}, name...)
+	}
+	return string(name)
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testTable [][]interface{}
+
+func (t testTable) TableHeader() []string { return []string{"name", "count", "ratio", "active"} }
+
+func (t testTable) TableRows() [][]interface{} { return t }
+
+func TestTableEncoder(t *testing.T) {
+	table := testTable{{"a, b", 1, 0.5, true}, {"c", nil, nil, false}}
+	cases := []struct {
+		Name        string
+		Accept      string
+		ContentType string
+		Expected    string
+	}{
+		{"json", "application/json", "", "application/json"},
+		{"csv", "text/csv", "", CSVContentType},
+		{"csv-list", "application/xml;q=0.9, text/csv; charset=utf-8", "", CSVContentType},
+		{"xlsx", XLSXContentType, "", XLSXContentType},
+		{"content-type", "application/json", "text/csv", CSVContentType},
+		{"not-produced", "application/x-unknown", "", "application/json"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), AcceptTypeKey, c.Accept)
+			if c.ContentType != "" {
+				ctx = context.WithValue(ctx, ContentTypeKey, c.ContentType)
+			}
+			w := httptest.NewRecorder()
+			enc := TableEncoder(ctx, w, ResponseEncoder, CSVContentType, XLSXContentType)
+			if ct := w.Header().Get("Content-Type"); ct != c.Expected {
+				t.Errorf("got content type %q, expected %q", ct, c.Expected)
+			}
+			if err := enc.Encode(table); err != nil {
+				t.Fatal(err)
+			}
+			switch c.Expected {
+			case CSVContentType:
+				expected := "name,count,ratio,active\n\"a, b\",1,0.5,true\nc,,,false\n"
+				if w.Body.String() != expected {
+					t.Errorf("got CSV %q, expected %q", w.Body.String(), expected)
+				}
+			case XLSXContentType:
+				sheet := xlsxSheet(t, w.Body.Bytes())
+				for _, cell := range []string{
+					`<c r="A1" t="inlineStr"><is><t xml:space="preserve">name</t></is></c>`,
+					`<c r="A2" t="inlineStr"><is><t xml:space="preserve">a, b</t></is></c>`,
+					`<c r="B2"><v>1</v></c>`,
+					`<c r="C2"><v>0.5</v></c>`,
+					`<c r="D2" t="b"><v>1</v></c>`,
+					`<row r="3"><c r="A3" t="inlineStr"><is><t xml:space="preserve">c</t></is></c><c r="D3" t="b"><v>0</v></c></row>`,
+				} {
+					if !strings.Contains(sheet, cell) {
+						t.Errorf("worksheet does not contain %s:\n%s", cell, sheet)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTableEncoderNotTable(t *testing.T) {
+	ctx := context.WithValue(context.Background(), AcceptTypeKey, CSVContentType)
+	enc := TableEncoder(ctx, httptest.NewRecorder(), ResponseEncoder, CSVContentType)
+	if err := enc.Encode("foo"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	cases := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for i, expected := range cases {
+		if actual := xlsxColumn(i); actual != expected {
+			t.Errorf("got %q for %d, expected %q", actual, i, expected)
+		}
+	}
+}
+
+// xlsxSheet returns the content of the worksheet of the given workbook.
+func xlsxSheet(t *testing.T, b []byte) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if len(names) != 5 {
+		t.Errorf("got parts %v, expected 5", names)
+	}
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	sheet, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(sheet)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// RequestTimeoutHeader is the name of the header clients may use to request
+// that the server stops processing a request after the given duration. The
+// value is either a Go duration such as "1.5s" or a number of seconds.
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+// WithRequestTimeout returns a copy of ctx whose deadline is set to the
+// shortest of timeout and the duration given by the request X-Request-Timeout
+// header if any. A zero timeout means no timeout. Invalid header values are
+// ignored. The generated HTTP handlers of the methods that define a Timeout
+// in the design use WithRequestTimeout to derive the context given to the
+// service method.
+func WithRequestTimeout(ctx context.Context, r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if d, ok := RequestTimeout(r); ok && (timeout == 0 || d < timeout) {
+		timeout = d
+	}
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// RequestTimeout returns the duration given by the request X-Request-Timeout
+// header. It returns false if the header is missing or invalid.
+func RequestTimeout(r *http.Request) (time.Duration, bool) {
+	v := r.Header.Get(RequestTimeoutHeader)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		if secs <= 0 {
+			return 0, false
+		}
+		return time.Duration(secs * float64(time.Second)), true
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// DeadlineError returns a timeout error if the deadline of ctx was exceeded
+// and nil otherwise. The generated HTTP handlers use DeadlineError to avoid
+// encoding the results of methods that complete after their deadline.
+func DeadlineError(ctx context.Context) error {
+	if ctx.Err() != context.DeadlineExceeded {
+		return nil
+	}
+	return goa.TemporaryTimeoutError("timeout", "request deadline exceeded")
+}
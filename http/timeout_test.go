@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestWithRequestTimeout(t *testing.T) {
+	cases := map[string]struct {
+		Header   string
+		Timeout  time.Duration
+		Expected time.Duration
+	}{
+		"none":            {"", 0, 0},
+		"design":          {"", time.Second, time.Second},
+		"header seconds":  {"2", 0, 2 * time.Second},
+		"header duration": {"500ms", 0, 500 * time.Millisecond},
+		"header shorter":  {"500ms", time.Second, 500 * time.Millisecond},
+		"design shorter":  {"5", time.Second, time.Second},
+		"invalid header":  {"soon", time.Second, time.Second},
+		"negative header": {"-1", 0, 0},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tc.Header != "" {
+				r.Header.Set(RequestTimeoutHeader, tc.Header)
+			}
+			start := time.Now()
+			ctx, cancel := WithRequestTimeout(context.Background(), r, tc.Timeout)
+			defer cancel()
+			deadline, ok := ctx.Deadline()
+			if tc.Expected == 0 {
+				if ok {
+					t.Errorf("got deadline %s, expected none", deadline)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("got no deadline")
+			}
+			if d := deadline.Sub(start); d < tc.Expected || d > tc.Expected+time.Second/2 {
+				t.Errorf("got deadline in %s, expected %s", d, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDeadlineError(t *testing.T) {
+	if err := DeadlineError(context.Background()); err != nil {
+		t.Errorf("got %v, expected nil", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := DeadlineError(ctx); err != nil {
+		t.Errorf("got %v, expected nil for canceled context", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	var serr *goa.ServiceError
+	if err := DeadlineError(ctx); !errors.As(err, &serr) || !serr.Timeout {
+		t.Errorf("got %v, expected timeout error", err)
+	}
+}
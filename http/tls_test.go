@@ -0,0 +1,119 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMutualTLS(t *testing.T) {
+	ca, caKey := newTestCert(t, "ca", nil, nil)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Leaf)
+	serverCert, _ := newTestCert(t, "server", ca.Leaf, caKey)
+	clientCert, _ := newTestCert(t, "client", ca.Leaf, caKey)
+	other, _ := newTestCert(t, "other", nil, nil)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName)) // nolint: errcheck
+	}))
+	srv.TLS = MutualTLSConfig(pool)
+	srv.TLS.Certificates = []tls.Certificate{serverCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	cases := map[string]struct {
+		cert  tls.Certificate
+		valid bool
+	}{
+		"valid certificate":   {clientCert, true},
+		"unknown certificate": {other, false},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			resp, err := NewMutualTLSClient(c.cert, pool).Get(srv.URL)
+			if !c.valid {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestLoadCertPool(t *testing.T) {
+	ca, _ := newTestCert(t, "ca", nil, nil)
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(valid, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Certificate[0]}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCertPool(valid); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := LoadCertPool(invalid); err == nil {
+		t.Error("expected an error for a file without certificate")
+	}
+	if _, err := LoadCertPool(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+// newTestCert creates a certificate for localhost with the given common name
+// signed by parent or self-signed if parent is nil.
+func newTestCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (tls.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, key
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// yamlEncoder writes values as YAML documents.
+	yamlEncoder struct {
+		w io.Writer
+	}
+
+	// yamlDecoder reads values from YAML documents.
+	yamlDecoder struct {
+		dec *yaml.Decoder
+	}
+)
+
+// NewYAMLEncoder returns an encoder that writes values to w as YAML documents.
+// The values are first marshaled to JSON so that the documents use the same
+// keys as the JSON representation, i.e. the "json" struct tags of the
+// generated types, and list the object keys in the same order.
+func NewYAMLEncoder(w io.Writer) Encoder {
+	return &yamlEncoder{w: w}
+}
+
+// NewYAMLDecoder returns a decoder that reads values from the YAML documents
+// read from r. The documents are converted to JSON before being unmarshaled so
+// that the keys match the "json" struct tags of the generated types. Decode
+// returns io.EOF if r does not contain any document.
+func NewYAMLDecoder(r io.Reader) Decoder {
+	return &yamlDecoder{dec: yaml.NewDecoder(r)}
+}
+
+// Encode writes v as a YAML document.
+func (e *yamlEncoder) Encode(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// JSON is YAML: parse it into a node tree so that the key order is
+	// preserved and reset the flow and quoting styles.
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	resetYAMLStyle(&n)
+	enc := yaml.NewEncoder(e.w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&n); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// Decode reads the next YAML document into v.
+func (d *yamlDecoder) Decode(v interface{}) error {
+	var raw interface{}
+	if err := d.dec.Decode(&raw); err != nil {
+		return err
+	}
+	b, err := json.Marshal(jsonValue(raw))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// isYAML returns true if the given media type is a YAML media type.
+func isYAML(mt string) bool {
+	switch mt {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+	return strings.HasSuffix(mt, "+yaml")
+}
+
+// resetYAMLStyle resets the style of n and its children so that the encoder
+// uses the block style and only quotes the strings that require it.
+func resetYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetYAMLStyle(c)
+	}
+}
+
+// jsonValue returns v with the maps whose keys are not strings converted to
+// maps with string keys so that v can be marshaled to JSON.
+func jsonValue(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		for k, e := range actual {
+			actual[k] = jsonValue(e)
+		}
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, e := range actual {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range actual {
+			actual[i] = jsonValue(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type yamlConfig struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version,omitempty"`
+	Enabled *bool             `json:"enabled,omitempty"`
+	Limits  map[string]int    `json:"limits,omitempty"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+func TestYAMLEncoder(t *testing.T) {
+	enabled := true
+	cfg := &yamlConfig{
+		Name:    "svc: <main>",
+		Version: "1.10",
+		Enabled: &enabled,
+		Limits:  map[string]int{"rps": 10},
+		Tags:    []string{"true", "b"},
+	}
+	var buf bytes.Buffer
+	if err := NewYAMLEncoder(&buf).Encode(cfg); err != nil {
+		t.Fatal(err)
+	}
+	expected := `name: 'svc: <main>'
+version: "1.10"
+enabled: true
+limits:
+  rps: 10
+tags:
+  - "true"
+  - b
+`
+	if buf.String() != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestYAMLDecoder(t *testing.T) {
+	enabled := false
+	cases := []struct {
+		Name     string
+		Body     string
+		Expected *yamlConfig
+		Err      error
+	}{
+		{"block", "name: svc\nenabled: false\nlimits:\n  rps: 10\ntags: [a, b]\n", &yamlConfig{Name: "svc", Enabled: &enabled, Limits: map[string]int{"rps": 10}, Tags: []string{"a", "b"}}, nil},
+		{"json", `{"name": "svc", "tags": []}`, &yamlConfig{Name: "svc", Tags: []string{}}, nil},
+		{"non-string-keys", "name: svc\nlabels:\n  1: one\n  true: yes\n", &yamlConfig{Name: "svc", Labels: map[string]string{"1": "one", "true": "yes"}}, nil},
+		{"empty", "", &yamlConfig{}, io.EOF},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(c.Body))
+			r.Header.Set("Content-Type", "application/yaml")
+			var cfg yamlConfig
+			err := RequestDecoder(r).Decode(&cfg)
+			if err != c.Err {
+				t.Fatalf("got error %v, expected %v", err, c.Err)
+			}
+			if !reflect.DeepEqual(&cfg, c.Expected) {
+				t.Errorf("got %+v, expected %+v", cfg, *c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+type (
+	// AuditEvent records a call made to an audited method.
+	AuditEvent struct {
+		// Time is the time the method was called.
+		Time time.Time `json:"time"`
+		// Duration is the time it took the method to complete.
+		Duration time.Duration `json:"duration"`
+		// RequestID is the ID of the request if any, see RequestID.
+		RequestID string `json:"request_id,omitempty"`
+		// Principal identifies the caller.
+		Principal string `json:"principal,omitempty"`
+		// Service is the name of the service as defined in the design.
+		Service string `json:"service"`
+		// Method is the name of the method as defined in the design.
+		Method string `json:"method"`
+		// Params lists the payload fields listed in the "audit:params"
+		// method metadata or returned by the AuditParams function.
+		Params map[string]interface{} `json:"params,omitempty"`
+		// Outcome is "success" or the name of the error returned by the
+		// method.
+		Outcome string `json:"outcome"`
+		// Error is the error message if the method failed.
+		Error string `json:"error,omitempty"`
+	}
+
+	// AuditSink records audit events. Implementations may write the events to
+	// a file, a database or a message queue.
+	AuditSink interface {
+		Record(ctx context.Context, e *AuditEvent) error
+	}
+
+	// AuditSinkFunc is an AuditSink implemented by a function.
+	AuditSinkFunc func(ctx context.Context, e *AuditEvent) error
+
+	// AuditOption configures the Audit middleware.
+	AuditOption func(*auditOptions)
+
+	auditOptions struct {
+		principal func(context.Context) string
+		params    func(ctx context.Context, method string, payload interface{}) map[string]interface{}
+		onError   func(context.Context, error)
+	}
+
+	// writerSink is an AuditSink that writes JSON lines.
+	writerSink struct {
+		mu  sync.Mutex
+		enc *json.Encoder
+	}
+)
+
+// Audit returns an endpoint middleware that records who-did-what events for
+// the methods whose "audit" metadata is set to "true" in the design. meta is
+// the MethodMeta variable generated in the service package:
+//
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.Audit(sink, svc.MethodMeta))
+//
+// Each event captures the caller principal, the service and method names, the
+// payload fields listed in the "audit:params" method metadata and the outcome
+// of the call. The principal is computed once the generated endpoint has
+// authenticated the caller, see goa.WithAuthHook, or from the request context
+// if authentication fails. It defaults to the ID of the API key validated with
+// security.APIKeyAuth, use AuditPrincipal to customize it. Errors returned by
+// the sink do not fail the request, use AuditOnError to handle them.
+func Audit(sink AuditSink, meta map[string]map[string][]string, opts ...AuditOption) func(goa.Endpoint) goa.Endpoint {
+	o := &auditOptions{principal: apiKeyPrincipal}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			m := meta[method]
+			if a := m["audit"]; len(a) == 0 || a[0] != "true" {
+				return e(ctx, req)
+			}
+			var (
+				principal     string
+				authenticated bool
+			)
+			ctx = goa.WithAuthHook(ctx, func(ctx context.Context, _ interface{}) (context.Context, error) {
+				principal, authenticated = o.principal(ctx), true
+				return ctx, nil
+			})
+			start := time.Now()
+			res, err := e(ctx, req)
+			if !authenticated {
+				principal = o.principal(ctx)
+			}
+			ev := &AuditEvent{
+				Time:      start,
+				Duration:  time.Since(start),
+				Principal: principal,
+				Method:    method,
+				Outcome:   "success",
+			}
+			ev.Service, _ = ctx.Value(goa.ServiceKey).(string)
+			ev.RequestID, _ = ctx.Value(RequestIDKey).(string)
+			if o.params != nil {
+				ev.Params = o.params(ctx, method, req)
+			} else {
+				ev.Params = PayloadFields(req, m["audit:params"]...)
+			}
+			if err != nil {
+				ev.Outcome = "error"
+				var serr *goa.ServiceError
+				if errors.As(err, &serr) {
+					ev.Outcome = serr.Name
+				}
+				ev.Error = err.Error()
+			}
+			if rerr := sink.Record(ctx, ev); rerr != nil && o.onError != nil {
+				o.onError(ctx, rerr)
+			}
+			return res, err
+		}
+	}
+}
+
+// AuditPrincipal sets the function used to compute the principal recorded in
+// audit events. The function is given the context returned by the security
+// functions if the caller is authenticated.
+func AuditPrincipal(fn func(context.Context) string) AuditOption {
+	return func(o *auditOptions) {
+		o.principal = fn
+	}
+}
+
+// AuditParams sets the function used to compute the parameters recorded in
+// audit events. It overrides the "audit:params" method metadata.
+func AuditParams(fn func(ctx context.Context, method string, payload interface{}) map[string]interface{}) AuditOption {
+	return func(o *auditOptions) {
+		o.params = fn
+	}
+}
+
+// AuditOnError sets the function called when the sink fails to record an
+// event.
+func AuditOnError(fn func(context.Context, error)) AuditOption {
+	return func(o *auditOptions) {
+		o.onError = fn
+	}
+}
+
+// Record calls f.
+func (f AuditSinkFunc) Record(ctx context.Context, e *AuditEvent) error {
+	return f(ctx, e)
+}
+
+// NewWriterAuditSink returns an AuditSink that writes events to w as JSON
+// lines, for example to an append-only file.
+func NewWriterAuditSink(w io.Writer) AuditSink {
+	return &writerSink{enc: json.NewEncoder(w)}
+}
+
+// Record writes the event to the underlying writer.
+func (s *writerSink) Record(_ context.Context, e *AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(e)
+}
+
+// PayloadFields returns the values of the payload struct fields that
+// correspond to the given design attribute names. Attribute names are matched
+// against field names ignoring case and underscores so that "account_id"
+// matches the generated field AccountID. Nil pointers are omitted and non-nil
+// pointers are dereferenced.
+func PayloadFields(payload interface{}, names ...string) map[string]interface{} {
+	if len(names) == 0 || payload == nil {
+		return nil
+	}
+	v := reflect.ValueOf(payload)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	norm := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+	fields := make(map[string]interface{}, len(names))
+	for _, n := range names {
+		f := v.FieldByNameFunc(func(fn string) bool { return norm(fn) == norm(n) })
+		if !f.IsValid() || !f.CanInterface() {
+			continue
+		}
+		if f.Kind() == reflect.Ptr {
+			if f.IsNil() {
+				continue
+			}
+			f = f.Elem()
+		}
+		fields[n] = f.Interface()
+	}
+	return fields
+}
+
+// apiKeyPrincipal returns the ID of the API key stored in the context by
+// security.APIKeyAuth if any.
+func apiKeyPrincipal(ctx context.Context) string {
+	if info := security.ContextAPIKeyInfo(ctx); info != nil {
+		return info.ID
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"goa.design/goa/v3/middleware/testdata/gen/docs"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestAudit(t *testing.T) {
+	type payload struct {
+		AccountID *string
+		Name      string
+		Secret    string
+	}
+	var (
+		id   = "acme"
+		meta = map[string]map[string][]string{
+			"delete": {"audit": {"true"}, "audit:params": {"account_id", "name"}},
+		}
+		failing = func(context.Context, interface{}) (interface{}, error) {
+			return nil, goa.PermanentError("not_found", "no such account")
+		}
+		succeeding = func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+	)
+	cases := map[string]struct {
+		method   string
+		endpoint goa.Endpoint
+		recorded bool
+		outcome  string
+	}{
+		"not audited": {"list", succeeding, false, ""},
+		"success":     {"delete", succeeding, true, "success"},
+		"failure":     {"delete", failing, true, "not_found"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var buf bytes.Buffer
+			ep := Audit(NewWriterAuditSink(&buf), meta, AuditPrincipal(func(context.Context) string { return "alice" }))(c.endpoint)
+			ctx := context.WithValue(context.Background(), goa.MethodKey, c.method)
+			ctx = context.WithValue(ctx, goa.ServiceKey, "accounts")
+			ep(ctx, &payload{AccountID: &id, Name: "main", Secret: "s3cr3t"}) // nolint: errcheck
+			if !c.recorded {
+				if buf.Len() != 0 {
+					t.Fatalf("unexpected event %s", buf.String())
+				}
+				return
+			}
+			var ev AuditEvent
+			if err := json.Unmarshal(buf.Bytes(), &ev); err != nil {
+				t.Fatalf("invalid event %q: %s", buf.String(), err)
+			}
+			if ev.Outcome != c.outcome {
+				t.Errorf("got outcome %q, expected %q", ev.Outcome, c.outcome)
+			}
+			if ev.Principal != "alice" || ev.Service != "accounts" || ev.Method != c.method {
+				t.Errorf("got principal %q, service %q, method %q", ev.Principal, ev.Service, ev.Method)
+			}
+			if len(ev.Params) != 2 || ev.Params["account_id"] != "acme" || ev.Params["name"] != "main" {
+				t.Errorf("got params %v, expected account_id and name", ev.Params)
+			}
+		})
+	}
+}
+
+func TestAuditGeneratedEndpoint(t *testing.T) {
+	var (
+		acmeKey    = "acme-key"
+		invalidKey = "invalid"
+	)
+	cases := map[string]struct {
+		key       *string
+		principal string
+		outcome   string
+	}{
+		"authenticated": {&acmeKey, "alice", "success"},
+		"invalid key":   {&invalidKey, "", "unauthorized"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var buf bytes.Buffer
+			callDocs(Audit(NewWriterAuditSink(&buf), docs.MethodMeta), &docs.ShowPayload{Key: c.key}) // nolint: errcheck
+			var ev AuditEvent
+			if err := json.Unmarshal(buf.Bytes(), &ev); err != nil {
+				t.Fatalf("invalid event %q: %s", buf.String(), err)
+			}
+			if ev.Principal != c.principal {
+				t.Errorf("got principal %q, expected %q", ev.Principal, c.principal)
+			}
+			if ev.Outcome != c.outcome {
+				t.Errorf("got outcome %q, expected %q", ev.Outcome, c.outcome)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Circuit breaker states.
+const (
+	// BreakerClosed is the state of a circuit breaker that lets requests
+	// through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen is the state of a circuit breaker that rejects requests.
+	BreakerOpen
+	// BreakerHalfOpen is the state of a circuit breaker that lets a single
+	// trial request through to probe whether the endpoint has recovered.
+	BreakerHalfOpen
+)
+
+type (
+	// BreakerState is the state of a circuit breaker.
+	BreakerState int
+
+	// BreakerOption configures the CircuitBreaker middleware.
+	BreakerOption func(*breaker)
+
+	// breaker implements the circuit breaker state machine.
+	breaker struct {
+		threshold int
+		reset     time.Duration
+		isFailure func(error) bool
+		onChange  func(from, to BreakerState)
+
+		mu       sync.Mutex
+		state    BreakerState
+		failures int
+		openedAt time.Time
+		trial    bool
+		// gen is incremented on each state change so that the outcome of
+		// calls admitted in a previous state can be ignored.
+		gen uint64
+	}
+)
+
+// String returns the name of the state.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker returns an endpoint middleware that stops calling the
+// endpoint after threshold consecutive failures. The breaker then rejects all
+// requests with a temporary "circuit_open" error until the reset timeout
+// elapses. It then lets a single trial request through: the breaker closes if
+// the request succeeds and opens again otherwise.
+//
+// Each call to CircuitBreaker creates a new breaker. Wrap the endpoints
+// returned by the generated transport clients to configure a breaker per
+// method:
+//
+//    c := calcc.NewClient(scheme, host, doer, enc, dec, false)
+//    svc := calc.NewClient(
+//        middleware.CircuitBreaker(5, 30*time.Second)(c.Add()),
+//        middleware.CircuitBreaker(10, time.Minute)(c.Div()),
+//    )
+//
+// By default service errors count as failures only if they are faults,
+// timeouts or temporary errors while all other errors count as failures, use
+// BreakerIsFailure to customize. Use BreakerStateChange to be notified of
+// state changes, for example to record metrics.
+func CircuitBreaker(threshold int, reset time.Duration, opts ...BreakerOption) func(goa.Endpoint) goa.Endpoint {
+	b := &breaker{threshold: threshold, reset: reset, isFailure: isFailure}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			gen, ok := b.allow()
+			if !ok {
+				return nil, goa.TemporaryError("circuit_open", "circuit breaker is open")
+			}
+			res, err := e(ctx, req)
+			b.record(gen, err != nil && b.isFailure(err))
+			return res, err
+		}
+	}
+}
+
+// MethodBreaker returns an endpoint middleware configured with the
+// "breaker:threshold", "breaker:reset" and "bulkhead" metadata of the given
+// method. meta is the MethodMeta variable generated in the service package:
+//
+//    c := calcc.NewClient(scheme, host, doer, enc, dec, false)
+//    svc := calc.NewClient(
+//        middleware.MethodBreaker(calc.MethodMeta, "add", opts...)(c.Add()),
+//        middleware.MethodBreaker(calc.MethodMeta, "div", opts...)(c.Div()),
+//    )
+//
+// The middleware wraps the endpoint with CircuitBreaker if "breaker:threshold"
+// is set, the reset timeout defaults to 30 seconds. It limits concurrent calls
+// with Bulkhead if "bulkhead" is set. The endpoint is returned unchanged if
+// the method defines neither. opts configure the circuit breaker.
+//
+// MethodBreaker panics if the metadata values are invalid.
+func MethodBreaker(meta map[string]map[string][]string, method string, opts ...BreakerOption) func(goa.Endpoint) goa.Endpoint {
+	m := meta[method]
+	var mws []func(goa.Endpoint) goa.Endpoint
+	if t := m["breaker:threshold"]; len(t) > 0 {
+		threshold, err := strconv.Atoi(t[0])
+		if err != nil || threshold < 1 {
+			panic(fmt.Sprintf("invalid breaker:threshold metadata for method %q: %q", method, t[0]))
+		}
+		reset := 30 * time.Second
+		if r := m["breaker:reset"]; len(r) > 0 {
+			d, err := time.ParseDuration(r[0])
+			if err != nil || d <= 0 {
+				panic(fmt.Sprintf("invalid breaker:reset metadata for method %q: %q", method, r[0]))
+			}
+			reset = d
+		}
+		mws = append(mws, CircuitBreaker(threshold, reset, opts...))
+	}
+	if c := m["bulkhead"]; len(c) > 0 {
+		max, err := strconv.Atoi(c[0])
+		if err != nil || max < 1 {
+			panic(fmt.Sprintf("invalid bulkhead metadata for method %q: %q", method, c[0]))
+		}
+		mws = append(mws, Bulkhead(max))
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		for i := len(mws) - 1; i >= 0; i-- {
+			e = mws[i](e)
+		}
+		return e
+	}
+}
+
+// BreakerIsFailure sets the function used to decide whether an error returned
+// by the endpoint counts as a failure.
+func BreakerIsFailure(fn func(error) bool) BreakerOption {
+	return func(b *breaker) {
+		b.isFailure = fn
+	}
+}
+
+// BreakerStateChange sets a function called each time the breaker changes
+// state. The function is called synchronously and must not block.
+func BreakerStateChange(fn func(from, to BreakerState)) BreakerOption {
+	return func(b *breaker) {
+		b.onChange = fn
+	}
+}
+
+// Bulkhead returns an endpoint middleware that limits the number of
+// concurrent calls to the endpoint to max. Calls made while the limit is
+// reached wait for a slot to free up or for the context to be done in which
+// case the context error is returned.
+func Bulkhead(max int) func(goa.Endpoint) goa.Endpoint {
+	slots := make(chan struct{}, max)
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			defer func() { <-slots }()
+			return e(ctx, req)
+		}
+	}
+}
+
+// allow returns true if the request may proceed together with the
+// generation of the breaker state the request is admitted in.
+func (b *breaker) allow() (uint64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.reset {
+			return 0, false
+		}
+		b.setState(BreakerHalfOpen)
+		b.trial = true
+	case BreakerHalfOpen:
+		if b.trial {
+			return 0, false
+		}
+		b.trial = true
+	}
+	return b.gen, true
+}
+
+// record updates the breaker state given the outcome of a request admitted in
+// generation gen. Outcomes of requests admitted before the last state change
+// are ignored so that only the half-open trial request may close or re-open
+// the breaker.
+func (b *breaker) record(gen uint64, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen != b.gen {
+		return
+	}
+	switch b.state {
+	case BreakerHalfOpen:
+		b.trial = false
+		if failed {
+			b.open()
+			return
+		}
+		b.failures = 0
+		b.setState(BreakerClosed)
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.threshold {
+			b.open()
+		}
+	}
+}
+
+// open opens the breaker.
+func (b *breaker) open() {
+	b.openedAt = time.Now()
+	b.setState(BreakerOpen)
+}
+
+// setState changes the breaker state and notifies the state change function.
+func (b *breaker) setState(s BreakerState) {
+	from := b.state
+	b.state = s
+	b.gen++
+	if b.onChange != nil {
+		b.onChange(from, s)
+	}
+}
+
+// isFailure is the default failure predicate.
+func isFailure(err error) bool {
+	var serr *goa.ServiceError
+	if errors.As(err, &serr) {
+		return serr.Fault || serr.Timeout || serr.Temporary
+	}
+	return true
+}
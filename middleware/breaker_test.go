@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		fail    = true
+		calls   int
+		changes []string
+	)
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		if fail {
+			return nil, errors.New("boom")
+		}
+		return "ok", nil
+	}
+	onChange := BreakerStateChange(func(from, to BreakerState) {
+		changes = append(changes, from.String()+"->"+to.String())
+	})
+	cb := CircuitBreaker(2, 20*time.Millisecond, onChange)(e)
+
+	for i := 0; i < 4; i++ {
+		cb(context.Background(), nil) // nolint: errcheck
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, expected 2", calls)
+	}
+	_, err := cb(context.Background(), nil)
+	var serr *goa.ServiceError
+	if !errors.As(err, &serr) || serr.Name != "circuit_open" {
+		t.Errorf("got error %v, expected circuit_open", err)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	cb(context.Background(), nil) // nolint: errcheck
+	if calls != 3 {
+		t.Errorf("got %d calls after reset timeout, expected 3", calls)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+	fail = false
+	res, err := cb(context.Background(), nil)
+	if err != nil || res != "ok" {
+		t.Errorf("got %v, %v after recovery, expected ok", res, err)
+	}
+	expected := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(expected) {
+		t.Fatalf("got state changes %v, expected %v", changes, expected)
+	}
+	for i, c := range changes {
+		if c != expected[i] {
+			t.Errorf("got state change %d %q, expected %q", i, c, expected[i])
+		}
+	}
+}
+
+func TestCircuitBreakerIsFailure(t *testing.T) {
+	var calls int
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return nil, goa.PermanentError("not_found", "not found")
+	}
+	cb := CircuitBreaker(1, time.Minute)(e)
+	for i := 0; i < 3; i++ {
+		cb(context.Background(), nil) // nolint: errcheck
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, expected 3", calls)
+	}
+	cb = CircuitBreaker(1, time.Minute, BreakerIsFailure(func(error) bool { return true }))(e)
+	calls = 0
+	for i := 0; i < 3; i++ {
+		cb(context.Background(), nil) // nolint: errcheck
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, expected 1", calls)
+	}
+}
+
+func TestCircuitBreakerStaleOutcome(t *testing.T) {
+	var (
+		stale   = make(chan struct{})
+		trial   = make(chan struct{})
+		changes []string
+	)
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		switch req {
+		case "stale":
+			<-stale
+			return nil, errors.New("boom")
+		case "trial":
+			<-trial
+			return "ok", nil
+		}
+		return nil, errors.New("boom")
+	}
+	onChange := BreakerStateChange(func(from, to BreakerState) {
+		changes = append(changes, from.String()+"->"+to.String())
+	})
+	cb := CircuitBreaker(1, 10*time.Millisecond, onChange)(e)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cb(context.Background(), "stale") // nolint: errcheck
+	}()
+	time.Sleep(5 * time.Millisecond)
+	cb(context.Background(), nil) // nolint: errcheck
+	time.Sleep(15 * time.Millisecond)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cb(context.Background(), "trial") // nolint: errcheck
+	}()
+	time.Sleep(5 * time.Millisecond)
+	close(stale)
+	time.Sleep(5 * time.Millisecond)
+	if _, err := cb(context.Background(), nil); err == nil || err.Error() != "circuit breaker is open" {
+		t.Errorf("got error %v while trial is in flight, expected circuit_open", err)
+	}
+	close(trial)
+	wg.Wait()
+	expected := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(expected) {
+		t.Fatalf("got state changes %v, expected %v", changes, expected)
+	}
+	for i, c := range changes {
+		if c != expected[i] {
+			t.Errorf("got state change %d %q, expected %q", i, c, expected[i])
+		}
+	}
+}
+
+func TestMethodBreaker(t *testing.T) {
+	meta := map[string]map[string][]string{
+		"charge": {"breaker:threshold": {"1"}, "breaker:reset": {"1m"}, "bulkhead": {"2"}},
+		"list":   {"other": {"value"}},
+	}
+	var calls int
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return nil, errors.New("boom")
+	}
+	charge := MethodBreaker(meta, "charge")(e)
+	for i := 0; i < 3; i++ {
+		charge(context.Background(), nil) // nolint: errcheck
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, expected 1", calls)
+	}
+	calls = 0
+	list := MethodBreaker(meta, "list")(e)
+	for i := 0; i < 3; i++ {
+		list(context.Background(), nil) // nolint: errcheck
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, expected 3", calls)
+	}
+
+	invalid := []map[string][]string{
+		{"breaker:threshold": {"0"}},
+		{"breaker:threshold": {"1"}, "breaker:reset": {"soon"}},
+		{"bulkhead": {"-1"}},
+	}
+	for _, m := range invalid {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for metadata %v", m)
+				}
+			}()
+			MethodBreaker(map[string]map[string][]string{"m": m}, "m")
+		}()
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		current int
+		max     int
+		wg      sync.WaitGroup
+	)
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		mu.Lock()
+		current++
+		if current > max {
+			max = current
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		current--
+		mu.Unlock()
+		return nil, nil
+	}
+	bh := Bulkhead(2)(e)
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bh(context.Background(), nil) // nolint: errcheck
+		}()
+	}
+	wg.Wait()
+	if max > 2 {
+		t.Errorf("got %d concurrent calls, expected at most 2", max)
+	}
+
+	block := make(chan struct{})
+	slow := Bulkhead(1)(func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-block
+		return nil, nil
+	})
+	go slow(context.Background(), nil) // nolint: errcheck
+	time.Sleep(5 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	if _, err := slow(ctx, nil); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+	}
+	close(block)
+}
@@ -21,4 +21,17 @@ const (
 	// TraceParentSpanIDKey is the request context key used to store the current
 	// trace parent span ID if any.
 	TraceParentSpanIDKey
+
+	// TenantKey is the request context key used to store the tenant
+	// resolved by the ScopeTenant middleware.
+	TenantKey
+
+	// RequestedVariantKey is the request context key used to store the name
+	// of the variant requested by the caller, typically read from a request
+	// header by the HTTP RequestVariant middleware.
+	RequestedVariantKey
+
+	// VariantKey is the request context key used to store the name of the
+	// variant selected by the Variants middleware.
+	VariantKey
 )
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+
+	"goa.design/goa/v3/middleware/testdata/gen/docs"
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+// docsService implements the service generated from testdata/design.
+type docsService struct {
+	// ctx is the context given to the last call to Show.
+	ctx context.Context
+}
+
+// docsKeys lists the API keys accepted by the docs service.
+var docsKeys = security.StaticKeys{
+	"acme-key":    {ID: "alice", Meta: map[string]string{"tenant": "acme", "roles": "reader"}},
+	"initech-key": {ID: "bob", Meta: map[string]string{"tenant": "initech"}},
+	"admin-key":   {ID: "root", Meta: map[string]string{"roles": "admin, reader"}},
+}
+
+func (s *docsService) APIKeyAuth(ctx context.Context, key string, scheme *security.APIKeyScheme) (context.Context, error) {
+	return security.APIKeyAuth(docsKeys)(ctx, key, scheme)
+}
+
+func (s *docsService) Show(ctx context.Context, _ *docs.ShowPayload) (string, error) {
+	s.ctx = ctx
+	return "ok", nil
+}
+
+// callDocs calls the generated docs "show" endpoint wrapped with m the way
+// the transport does. It returns the context given to the service method, nil
+// if the method was not called.
+func callDocs(m func(goa.Endpoint) goa.Endpoint, p *docs.ShowPayload) (context.Context, error) {
+	s := &docsService{}
+	endpoints := docs.NewEndpoints(s)
+	endpoints.Use(m)
+	ctx := context.WithValue(context.Background(), goa.MethodKey, "show")
+	ctx = context.WithValue(ctx, goa.ServiceKey, docs.ServiceName)
+	_, err := endpoints.Show(ctx, p)
+	return s.ctx, err
+}
+
+// keyTenant returns the tenant listed in the metadata of the API key that
+// authenticated the caller.
+func keyTenant(ctx context.Context) string {
+	if info := security.ContextAPIKeyInfo(ctx); info != nil {
+		return info.Meta["tenant"]
+	}
+	return ""
+}
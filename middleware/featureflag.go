@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// FlagProvider reports whether feature flags are on. Implementations
+	// typically query a feature management service or the runtime
+	// configuration and may take the request context into account to
+	// enable flags for a subset of the callers.
+	FlagProvider interface {
+		// Enabled returns true if the flag with the given name is on
+		// for the request with the given context.
+		Enabled(ctx context.Context, flag string) bool
+	}
+
+	// FlagProviderFunc is a FlagProvider implemented by a function.
+	FlagProviderFunc func(ctx context.Context, flag string) bool
+
+	// FeatureFlagOption configures the FeatureFlag middleware.
+	FeatureFlagOption func(*featureFlagOptions)
+
+	// featureFlagOptions holds the FeatureFlag middleware options.
+	featureFlagOptions struct {
+		name string
+	}
+)
+
+// FeatureFlag returns an endpoint middleware that rejects the calls made to
+// the methods gated by a feature flag in the design with the FeatureFlag DSL
+// while the flag is off. meta is the MethodMeta variable generated in the
+// service package:
+//
+//    flags := middleware.FlagProviderFunc(func(_ context.Context, flag string) bool {
+//        return conf.Bool("feature_"+flag, false)
+//    })
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.FeatureFlag(flags, svc.MethodMeta))
+//
+// Rejected calls return a goa.FeatureDisabled error that the HTTP transport
+// maps to a 404 Not Found response so that disabled methods cannot be told
+// apart from methods that do not exist. Use FeatureFlagForbidden to return
+// 403 Forbidden responses instead. Methods that are not gated are not
+// affected.
+func FeatureFlag(provider FlagProvider, meta map[string]map[string][]string, opts ...FeatureFlagOption) func(goa.Endpoint) goa.Endpoint {
+	o := &featureFlagOptions{name: goa.FeatureDisabled}
+	for _, opt := range opts {
+		opt(o)
+	}
+	flags := make(map[string][]string)
+	for method, m := range meta {
+		if f := m["feature:flag"]; len(f) > 0 {
+			flags[method] = f
+		}
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			for _, flag := range flags[method] {
+				if !provider.Enabled(ctx, flag) {
+					return nil, goa.PermanentError(o.name, "method %q is not available", method)
+				}
+			}
+			return e(ctx, req)
+		}
+	}
+}
+
+// FeatureFlagForbidden makes the FeatureFlag middleware reject calls with a
+// goa.FeatureForbidden error which the HTTP transport maps to a 403 Forbidden
+// response.
+func FeatureFlagForbidden() FeatureFlagOption {
+	return func(o *featureFlagOptions) {
+		o.name = goa.FeatureForbidden
+	}
+}
+
+// Enabled calls f.
+func (f FlagProviderFunc) Enabled(ctx context.Context, flag string) bool {
+	return f(ctx, flag)
+}
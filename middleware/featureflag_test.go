@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestFeatureFlag(t *testing.T) {
+	var (
+		meta = map[string]map[string][]string{
+			"checkout": {"feature:flag": {"new-checkout", "beta"}},
+			"list":     {"audit": {"true"}},
+		}
+		provider = FlagProviderFunc(func(_ context.Context, flag string) bool {
+			return flag == "beta"
+		})
+		endpoint = func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+	)
+	cases := map[string]struct {
+		method string
+		opts   []FeatureFlagOption
+		err    string
+	}{
+		"not gated": {"list", nil, ""},
+		"disabled":  {"checkout", nil, goa.FeatureDisabled},
+		"forbidden": {"checkout", []FeatureFlagOption{FeatureFlagForbidden()}, goa.FeatureForbidden},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ep := FeatureFlag(provider, meta, c.opts...)(endpoint)
+			ctx := context.WithValue(context.Background(), goa.MethodKey, c.method)
+			res, err := ep(ctx, nil)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if res != "ok" {
+					t.Errorf("got result %v, expected ok", res)
+				}
+				return
+			}
+			serr, ok := err.(*goa.ServiceError)
+			if !ok {
+				t.Fatalf("got error %v, expected a service error", err)
+			}
+			if serr.Name != c.err {
+				t.Errorf("got error name %q, expected %q", serr.Name, c.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// hedgeResult is the outcome of a single hedged call.
+	hedgeResult struct {
+		res interface{}
+		err error
+	}
+)
+
+// Hedge returns an endpoint middleware that sends hedged requests: if the
+// endpoint does not return within delay, Hedge calls it again concurrently up
+// to max times in total and returns the first successful response. The
+// contexts of the calls still in flight are canceled once a call succeeds. A
+// call that fails does not cause the next call to be sent before the delay
+// elapses. If all the calls fail Hedge returns the error of the last one to
+// complete.
+//
+// Hedged requests reduce tail latency at the cost of additional load and must
+// only be used with idempotent methods such as reads. Wrap the corresponding
+// endpoints returned by the generated transport clients:
+//
+//    c := storagec.NewClient(scheme, host, doer, enc, dec, false)
+//    svc := storage.NewClient(
+//        middleware.Hedge(50*time.Millisecond, 3)(c.Show()),
+//        c.Add(),
+//    )
+//
+func Hedge(delay time.Duration, max int) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if max < 2 {
+				return e(ctx, req)
+			}
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			results := make(chan hedgeResult, max)
+			call := func() {
+				res, err := e(ctx, req)
+				results <- hedgeResult{res, err}
+			}
+			go call()
+			var (
+				sent    = 1
+				pending = 1
+				timer   = time.NewTimer(delay)
+				last    error
+			)
+			defer timer.Stop()
+			for {
+				select {
+				case r := <-results:
+					pending--
+					if r.err == nil {
+						return r.res, nil
+					}
+					last = r.err
+					if pending == 0 && sent == max {
+						return nil, last
+					}
+				case <-timer.C:
+					if sent < max {
+						sent++
+						pending++
+						go call()
+						timer.Reset(delay)
+					}
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+		}
+	}
+}
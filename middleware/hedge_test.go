@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedge(t *testing.T) {
+	cases := []struct {
+		Name          string
+		Durations     []time.Duration
+		Errors        []error
+		Max           int
+		ExpectedCalls int32
+		ExpectedRes   interface{}
+		ExpectedErr   string
+	}{
+		{"fast", []time.Duration{0}, []error{nil}, 3, 1, 0, ""},
+		{"slow-first", []time.Duration{100 * time.Millisecond, 0}, []error{nil, nil}, 3, 2, 1, ""},
+		{"no-hedge", []time.Duration{20 * time.Millisecond}, []error{nil}, 1, 1, 0, ""},
+		{"all-fail", []time.Duration{0, 0}, []error{errors.New("a"), errors.New("b")}, 2, 2, nil, "b"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				calls     int32
+				durations = c.Durations
+				errs      = c.Errors
+			)
+			e := func(ctx context.Context, req interface{}) (interface{}, error) {
+				i := atomic.AddInt32(&calls, 1) - 1
+				select {
+				case <-time.After(durations[i]):
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+				if errs[i] != nil {
+					return nil, errs[i]
+				}
+				return int(i), nil
+			}
+			res, err := Hedge(10*time.Millisecond, c.Max)(e)(context.Background(), nil)
+			if c.ExpectedErr != "" {
+				if err == nil || err.Error() != c.ExpectedErr {
+					t.Fatalf("got error %v, expected %q", err, c.ExpectedErr)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if res != c.ExpectedRes {
+				t.Errorf("got result %v, expected %v", res, c.ExpectedRes)
+			}
+			if n := atomic.LoadInt32(&calls); n != c.ExpectedCalls {
+				t.Errorf("got %d calls, expected %d", n, c.ExpectedCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// PolicyInput is the input of the policy decisions made by the
+	// PolicyGuard middleware.
+	PolicyInput struct {
+		// Service is the name of the service as defined in the design.
+		Service string `json:"service"`
+		// Method is the name of the method as defined in the design.
+		Method string `json:"method"`
+		// Principal identifies the caller.
+		Principal string `json:"principal,omitempty"`
+		// Params lists the payload fields listed in the "policy:params"
+		// method metadata or returned by the PolicyParams function.
+		Params map[string]interface{} `json:"params,omitempty"`
+	}
+
+	// Evaluator evaluates policies written in a policy language such as
+	// Rego or the Casbin model language.
+	Evaluator interface {
+		// Evaluate returns true if the policy allows the call described
+		// by the input. Errors are reported to the caller as server
+		// faults.
+		Evaluate(ctx context.Context, in *PolicyInput) (bool, error)
+	}
+
+	// EvaluatorFunc is an Evaluator implemented by a function.
+	EvaluatorFunc func(ctx context.Context, in *PolicyInput) (bool, error)
+
+	// CasbinEnforcer is the subset of the Casbin enforcer API used by the
+	// Casbin evaluator. It is implemented by *casbin.Enforcer and
+	// *casbin.SyncedEnforcer.
+	CasbinEnforcer interface {
+		// Enforce decides whether a subject can access an object with
+		// an action.
+		Enforce(rvals ...interface{}) (bool, error)
+	}
+
+	// PolicyOption configures the PolicyGuard middleware.
+	PolicyOption func(*policyOptions)
+
+	// policyOptions holds the PolicyGuard middleware options.
+	policyOptions struct {
+		principal func(context.Context) string
+		params    func(ctx context.Context, method string, payload interface{}) map[string]interface{}
+	}
+
+	// opaEvaluator is an Evaluator that queries an Open Policy Agent
+	// server.
+	opaEvaluator struct {
+		url    string
+		client *http.Client
+	}
+
+	// casbinEvaluator is an Evaluator that enforces Casbin policies.
+	casbinEvaluator struct {
+		enforcer CasbinEnforcer
+	}
+)
+
+// PolicyGuard returns an endpoint middleware that evaluates a policy before
+// each call and rejects the calls that the policy denies with a
+// goa.PermissionDenied error which the HTTP transport maps to a 403 Forbidden
+// response. meta is the MethodMeta variable generated in the service package:
+//
+//    ev := middleware.OPAEvaluator("http://localhost:8181/v1/data/api/allow", nil)
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.PolicyGuard(ev, svc.MethodMeta))
+//
+// The policy input is assembled from the names of the service and method, the
+// caller principal and the payload fields listed in the "policy:params" method
+// metadata. The policy is evaluated once the generated endpoint has
+// authenticated the caller, see goa.WithAuthHook. The principal defaults to
+// the ID of the API key validated with security.APIKeyAuth, use
+// PolicyPrincipal to customize it.
+func PolicyGuard(ev Evaluator, meta map[string]map[string][]string, opts ...PolicyOption) func(goa.Endpoint) goa.Endpoint {
+	o := &policyOptions{principal: apiKeyPrincipal}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx = goa.WithAuthHook(ctx, func(ctx context.Context, payload interface{}) (context.Context, error) {
+				in := &PolicyInput{Principal: o.principal(ctx)}
+				in.Service, _ = ctx.Value(goa.ServiceKey).(string)
+				in.Method, _ = ctx.Value(goa.MethodKey).(string)
+				if o.params != nil {
+					in.Params = o.params(ctx, in.Method, payload)
+				} else {
+					in.Params = PayloadFields(payload, meta[in.Method]["policy:params"]...)
+				}
+				ok, err := ev.Evaluate(ctx, in)
+				if err != nil {
+					return ctx, goa.Fault("failed to evaluate policy: %s", err)
+				}
+				if !ok {
+					return ctx, goa.PermanentError(goa.PermissionDenied, "call to %q is not allowed", in.Method)
+				}
+				return ctx, nil
+			})
+			return e(ctx, req)
+		}
+	}
+}
+
+// PolicyPrincipal sets the function used to compute the principal of the
+// policy input. The function is given the context returned by the security
+// functions.
+func PolicyPrincipal(fn func(context.Context) string) PolicyOption {
+	return func(o *policyOptions) {
+		o.principal = fn
+	}
+}
+
+// PolicyParams sets the function used to compute the params of the policy
+// input. It overrides the "policy:params" method metadata.
+func PolicyParams(fn func(ctx context.Context, method string, payload interface{}) map[string]interface{}) PolicyOption {
+	return func(o *policyOptions) {
+		o.params = fn
+	}
+}
+
+// OPAEvaluator returns an Evaluator that queries the Open Policy Agent data
+// API at the given URL, e.g. "http://localhost:8181/v1/data/api/allow". The
+// query input is the JSON representation of the policy input and the Rego
+// policy decision must be a boolean:
+//
+//    package api
+//
+//    default allow = false
+//
+//    allow {
+//        input.method == "show"
+//        input.params.owner == input.principal
+//    }
+//
+// client defaults to http.DefaultClient if nil.
+func OPAEvaluator(url string, client *http.Client) Evaluator {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &opaEvaluator{url: url, client: client}
+}
+
+// CasbinEvaluator returns an Evaluator that enforces the policies loaded in
+// the given Casbin enforcer. The request is made of the principal as subject,
+// the service name as object and the method name as action, matching the
+// request definition "r = sub, obj, act" of the Casbin ACL and RBAC models:
+//
+//    e, err := casbin.NewEnforcer("model.conf", "policy.csv")
+//    if err != nil {
+//        return err
+//    }
+//    endpoints.Use(middleware.PolicyGuard(middleware.CasbinEvaluator(e), svc.MethodMeta))
+//
+func CasbinEvaluator(e CasbinEnforcer) Evaluator {
+	return &casbinEvaluator{enforcer: e}
+}
+
+// Evaluate calls f.
+func (f EvaluatorFunc) Evaluate(ctx context.Context, in *PolicyInput) (bool, error) {
+	return f(ctx, in)
+}
+
+// Evaluate queries the OPA server.
+func (ev *opaEvaluator) Evaluate(ctx context.Context, in *PolicyInput) (bool, error) {
+	return opaQuery(ctx, ev.client, ev.url, in)
+}
+
+// Evaluate calls the Casbin enforcer.
+func (ev *casbinEvaluator) Evaluate(_ context.Context, in *PolicyInput) (bool, error) {
+	return ev.enforcer.Enforce(in.Principal, in.Service, in.Method)
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"goa.design/goa/v3/middleware/testdata/gen/docs"
+	goa "goa.design/goa/v3/pkg"
+)
+
+type enforcerFunc func(rvals ...interface{}) (bool, error)
+
+func (f enforcerFunc) Enforce(rvals ...interface{}) (bool, error) { return f(rvals...) }
+
+func TestPolicyGuard(t *testing.T) {
+	type payload struct {
+		Owner *string
+		Note  string
+	}
+	var (
+		owner = "alice"
+		meta  = map[string]map[string][]string{
+			"show": {"policy:params": {"owner"}},
+		}
+		principal = func(context.Context) string { return "alice" }
+		endpoint  = func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, err := goa.RunAuthHooks(ctx, req); err != nil {
+				return nil, err
+			}
+			return "ok", nil
+		}
+	)
+	cases := map[string]struct {
+		method string
+		ev     Evaluator
+		input  *PolicyInput
+		err    string
+	}{
+		"allowed": {"show", EvaluatorFunc(func(_ context.Context, in *PolicyInput) (bool, error) {
+			return in.Params["owner"] == in.Principal, nil
+		}), &PolicyInput{Service: "docs", Method: "show", Principal: "alice", Params: map[string]interface{}{"owner": "alice"}}, ""},
+		"denied": {"list", EvaluatorFunc(func(context.Context, *PolicyInput) (bool, error) {
+			return false, nil
+		}), &PolicyInput{Service: "docs", Method: "list", Principal: "alice"}, goa.PermissionDenied},
+		"failure": {"show", EvaluatorFunc(func(context.Context, *PolicyInput) (bool, error) {
+			return false, errors.New("boom")
+		}), nil, "fault"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var input *PolicyInput
+			ev := EvaluatorFunc(func(ctx context.Context, in *PolicyInput) (bool, error) {
+				input = in
+				return c.ev.Evaluate(ctx, in)
+			})
+			ctx := context.WithValue(context.Background(), goa.ServiceKey, "docs")
+			ctx = context.WithValue(ctx, goa.MethodKey, c.method)
+			res, err := PolicyGuard(ev, meta, PolicyPrincipal(principal))(endpoint)(ctx, &payload{Owner: &owner, Note: "secret"})
+			if c.input != nil && !reflect.DeepEqual(input, c.input) {
+				t.Errorf("got input %+v, expected %+v", input, c.input)
+			}
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if res != "ok" {
+					t.Errorf("got result %v, expected ok", res)
+				}
+				return
+			}
+			serr, ok := err.(*goa.ServiceError)
+			if !ok {
+				t.Fatalf("got error %v, expected a service error", err)
+			}
+			if serr.Name != c.err {
+				t.Errorf("got error name %q, expected %q", serr.Name, c.err)
+			}
+		})
+	}
+}
+
+func TestPolicyGuardGeneratedEndpoint(t *testing.T) {
+	var (
+		acmeKey    = "acme-key"
+		initechKey = "initech-key"
+		invalidKey = "invalid"
+		id         = "1"
+		ev         = EvaluatorFunc(func(_ context.Context, in *PolicyInput) (bool, error) {
+			return in.Principal == "alice", nil
+		})
+	)
+	cases := map[string]struct {
+		key   *string
+		input *PolicyInput
+		err   string
+	}{
+		"allowed":     {&acmeKey, &PolicyInput{Service: "docs", Method: "show", Principal: "alice", Params: map[string]interface{}{"id": id}}, ""},
+		"denied":      {&initechKey, &PolicyInput{Service: "docs", Method: "show", Principal: "bob", Params: map[string]interface{}{"id": id}}, goa.PermissionDenied},
+		"invalid key": {&invalidKey, nil, "unauthorized"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var input *PolicyInput
+			record := EvaluatorFunc(func(ctx context.Context, in *PolicyInput) (bool, error) {
+				input = in
+				return ev.Evaluate(ctx, in)
+			})
+			ctx, err := callDocs(PolicyGuard(record, docs.MethodMeta), &docs.ShowPayload{Key: c.key, ID: &id})
+			if !reflect.DeepEqual(input, c.input) {
+				t.Errorf("got input %+v, expected %+v", input, c.input)
+			}
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if ctx == nil {
+					t.Error("service method not called")
+				}
+				return
+			}
+			var serr *goa.ServiceError
+			if !errors.As(err, &serr) || serr.Name != c.err {
+				t.Fatalf("got error %v, expected %q", err, c.err)
+			}
+			if ctx != nil {
+				t.Error("service method called")
+			}
+		})
+	}
+}
+
+func TestOPAEvaluator(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input *PolicyInput `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if body.Input.Method == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		allowed := body.Input.Service == "docs" && body.Input.Params["owner"] == body.Input.Principal
+		json.NewEncoder(w).Encode(map[string]bool{"result": allowed}) // nolint: errcheck
+	}))
+	defer srv.Close()
+	ev := OPAEvaluator(srv.URL, nil)
+	in := &PolicyInput{Service: "docs", Method: "show", Principal: "alice", Params: map[string]interface{}{"owner": "alice"}}
+	if ok, err := ev.Evaluate(context.Background(), in); err != nil || !ok {
+		t.Errorf("owner: got %v, %v, expected true", ok, err)
+	}
+	in.Principal = "bob"
+	if ok, err := ev.Evaluate(context.Background(), in); err != nil || ok {
+		t.Errorf("other: got %v, %v, expected false", ok, err)
+	}
+	in.Method = "fail"
+	if _, err := ev.Evaluate(context.Background(), in); err == nil {
+		t.Error("fail: expected an error")
+	}
+}
+
+func TestCasbinEvaluator(t *testing.T) {
+	var got []interface{}
+	ev := CasbinEvaluator(enforcerFunc(func(rvals ...interface{}) (bool, error) {
+		got = rvals
+		return true, nil
+	}))
+	ok, err := ev.Evaluate(context.Background(), &PolicyInput{Service: "docs", Method: "show", Principal: "alice"})
+	if err != nil || !ok {
+		t.Fatalf("got %v, %v, expected true", ok, err)
+	}
+	if expected := []interface{}{"alice", "docs", "show"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got request %v, expected %v", got, expected)
+	}
+}
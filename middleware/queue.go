@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// Overloaded is the name of the error returned by the Queue and WorkerPool
+// middlewares when a request is shed.
+const Overloaded = "overloaded"
+
+type (
+	// methodQueue limits the concurrent calls made to a single method.
+	methodQueue struct {
+		slots      chan struct{}
+		waiting    int64
+		size       int64
+		timeout    time.Duration
+		retryAfter time.Duration
+	}
+
+	// overloadedError is the error wrapped by the service error returned
+	// when a request is shed. It implements RetryAfter so that the HTTP
+	// error encoder sets the Retry-After response header.
+	overloadedError struct {
+		method     string
+		retryAfter time.Duration
+	}
+)
+
+// Queue returns an endpoint middleware that limits the number of concurrent
+// calls made to the methods whose "queue:concurrency" metadata is set in the
+// design. meta is the MethodMeta variable generated in the service package:
+//
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.Queue(svc.MethodMeta))
+//
+// Calls made while the limit is reached wait in a queue whose size is given
+// by the "queue:size" metadata (zero by default). Calls that cannot be queued
+// or that wait longer than the "queue:timeout" metadata duration are shed
+// with a temporary "overloaded" error. The HTTP transport maps the error to a
+// 503 Service Unavailable response with a Retry-After header set to the
+// "queue:retry-after" metadata duration (one second by default). Methods that
+// do not define the metadata are not limited.
+//
+// Queue panics if the metadata values are invalid.
+func Queue(meta map[string]map[string][]string) func(goa.Endpoint) goa.Endpoint {
+	queues := make(map[string]*methodQueue)
+	for method, m := range meta {
+		if q := newMethodQueue(method, m); q != nil {
+			queues[method] = q
+		}
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			q, ok := queues[method]
+			if !ok {
+				return e(ctx, req)
+			}
+			if err := q.acquire(ctx, method); err != nil {
+				return nil, err
+			}
+			defer q.release()
+			return e(ctx, req)
+		}
+	}
+}
+
+// newMethodQueue returns the queue configured by the given method metadata or
+// nil if the metadata does not define a concurrency limit.
+func newMethodQueue(method string, m map[string][]string) *methodQueue {
+	c := m["queue:concurrency"]
+	if len(c) == 0 {
+		return nil
+	}
+	concurrency, err := strconv.Atoi(c[0])
+	if err != nil || concurrency < 1 {
+		panic(fmt.Sprintf("invalid queue:concurrency metadata for method %q: %q", method, c[0]))
+	}
+	q := &methodQueue{slots: make(chan struct{}, concurrency), retryAfter: time.Second}
+	if s := m["queue:size"]; len(s) > 0 {
+		size, err := strconv.Atoi(s[0])
+		if err != nil || size < 0 {
+			panic(fmt.Sprintf("invalid queue:size metadata for method %q: %q", method, s[0]))
+		}
+		q.size = int64(size)
+	}
+	if t := m["queue:timeout"]; len(t) > 0 {
+		d, err := time.ParseDuration(t[0])
+		if err != nil || d <= 0 {
+			panic(fmt.Sprintf("invalid queue:timeout metadata for method %q: %q", method, t[0]))
+		}
+		q.timeout = d
+	}
+	if r := m["queue:retry-after"]; len(r) > 0 {
+		d, err := time.ParseDuration(r[0])
+		if err != nil || d < 0 {
+			panic(fmt.Sprintf("invalid queue:retry-after metadata for method %q: %q", method, r[0]))
+		}
+		q.retryAfter = d
+	}
+	return q
+}
+
+// acquire waits for a slot to free up. It returns an overloaded error if the
+// queue is full or if the queue timeout elapses and the context error if the
+// context is done first.
+func (q *methodQueue) acquire(ctx context.Context, method string) error {
+	select {
+	case q.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if atomic.AddInt64(&q.waiting, 1) > q.size {
+		atomic.AddInt64(&q.waiting, -1)
+		return q.overloaded(method)
+	}
+	defer atomic.AddInt64(&q.waiting, -1)
+	var timeout <-chan time.Time
+	if q.timeout > 0 {
+		timer := time.NewTimer(q.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case q.slots <- struct{}{}:
+		return nil
+	case <-timeout:
+		return q.overloaded(method)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot acquired by a call.
+func (q *methodQueue) release() {
+	<-q.slots
+}
+
+// overloaded returns the error used to shed a request.
+func (q *methodQueue) overloaded(method string) error {
+	return goa.NewServiceError(&overloadedError{method: method, retryAfter: q.retryAfter}, Overloaded, false, true, false)
+}
+
+// Error returns the error message.
+func (e *overloadedError) Error() string {
+	return fmt.Sprintf("method %q is overloaded, retry after %s", e.method, e.retryAfter)
+}
+
+// RetryAfter returns the duration after which the request may be retried.
+func (e *overloadedError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
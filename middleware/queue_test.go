@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestQueue(t *testing.T) {
+	meta := map[string]map[string][]string{
+		"add":  {"queue:concurrency": {"1"}, "queue:size": {"1"}, "queue:retry-after": {"5s"}},
+		"show": {"queue:concurrency": {"1"}, "queue:size": {"1"}, "queue:timeout": {"10ms"}},
+	}
+	cases := map[string]struct {
+		method  string
+		fill    bool
+		cancel  bool
+		wantErr func(error) bool
+	}{
+		"queued":     {"add", false, false, func(err error) bool { return err == nil }},
+		"queue full": {"add", true, false, isOverloaded(5 * time.Second)},
+		"timeout":    {"show", false, false, isOverloaded(time.Second)},
+		"canceled":   {"show", false, true, func(err error) bool { return err == context.Canceled }},
+	}
+	for k, c := range cases {
+		method, fill, cancel, wantErr := c.method, c.fill, c.cancel, c.wantErr
+		t.Run(k, func(t *testing.T) {
+			var (
+				release = make(chan struct{})
+				started = make(chan struct{}, 3)
+				e       = Queue(meta)(func(context.Context, interface{}) (interface{}, error) {
+					started <- struct{}{}
+					<-release
+					return "ok", nil
+				})
+				ctx  = context.WithValue(context.Background(), goa.MethodKey, method)
+				errs = make(chan error, 2)
+			)
+			// Occupy the only slot.
+			go func() { _, err := e(ctx, nil); errs <- err }()
+			<-started
+			if fill {
+				go func() { _, err := e(ctx, nil); errs <- err }()
+				time.Sleep(10 * time.Millisecond)
+			}
+			if cancel {
+				var cfn context.CancelFunc
+				ctx, cfn = context.WithCancel(ctx)
+				cfn()
+			}
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				close(release)
+			}()
+			if _, err := e(ctx, nil); !wantErr(err) {
+				t.Errorf("got error %v", err)
+			}
+			n := 1
+			if fill {
+				n = 2
+			}
+			for i := 0; i < n; i++ {
+				if err := <-errs; err != nil {
+					t.Errorf("got error %v, expected nil", err)
+				}
+			}
+		})
+	}
+}
+
+func TestQueueNotLimited(t *testing.T) {
+	meta := map[string]map[string][]string{"add": {"queue:concurrency": {"1"}}}
+	e := Queue(meta)(func(context.Context, interface{}) (interface{}, error) { return "ok", nil })
+	ctx := context.WithValue(context.Background(), goa.MethodKey, "list")
+	if res, err := e(ctx, nil); err != nil || res != "ok" {
+		t.Errorf("got %v, %v, expected ok", res, err)
+	}
+}
+
+func TestQueueInvalidMeta(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	Queue(map[string]map[string][]string{"add": {"queue:concurrency": {"zero"}}})
+}
+
+// isOverloaded returns a function that checks that the error is a temporary
+// overloaded error with the given retry after duration.
+func isOverloaded(retryAfter time.Duration) func(error) bool {
+	return func(err error) bool {
+		var serr *goa.ServiceError
+		if !errors.As(err, &serr) || serr.Name != Overloaded || !serr.Temporary {
+			return false
+		}
+		var ra interface{ RetryAfter() time.Duration }
+		return errors.As(err, &ra) && ra.RetryAfter() == retryAfter
+	}
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Policy decides whether roles grant permissions.
+	Policy interface {
+		// Allowed returns true if any of the given roles grants the
+		// permission. Errors are reported to the caller as server
+		// faults.
+		Allowed(ctx context.Context, roles []string, permission string) (bool, error)
+	}
+
+	// PolicyFunc is a Policy implemented by a function.
+	PolicyFunc func(ctx context.Context, roles []string, permission string) (bool, error)
+
+	// StaticPolicy is a Policy backed by a map of role names to the
+	// permissions they grant. The permission "*" grants all permissions
+	// and permissions ending with ":*" grant all the permissions that
+	// share their prefix, e.g. "bottles:*" grants "bottles:read" and
+	// "bottles:write".
+	StaticPolicy map[string][]string
+
+	// RBACOption configures the RBAC middleware.
+	RBACOption func(*rbacOptions)
+
+	// rbacOptions holds the RBAC middleware options.
+	rbacOptions struct {
+		roles func(context.Context) []string
+	}
+
+	// opaPolicy is a Policy that queries an Open Policy Agent server.
+	opaPolicy struct {
+		url    string
+		client *http.Client
+	}
+)
+
+// RBAC returns an endpoint middleware that enforces the permissions listed in
+// the design with the Permission DSL. meta is the MethodMeta variable
+// generated in the service package:
+//
+//    policy, err := middleware.LoadStaticPolicy(f)
+//    if err != nil {
+//        return err
+//    }
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.RBAC(policy, svc.MethodMeta))
+//
+// The permissions are checked once the generated endpoint has authenticated
+// the caller, see goa.WithAuthHook. The roles of the caller default to the
+// comma separated values of the "roles" metadata of the API key validated with
+// security.APIKeyAuth, use RBACRoles to customize them. Calls made by callers
+// whose roles do not grant all the method permissions are rejected with a
+// goa.PermissionDenied error which the HTTP transport maps to a 403 Forbidden
+// response. Methods that do not list permissions are not affected.
+func RBAC(policy Policy, meta map[string]map[string][]string, opts ...RBACOption) func(goa.Endpoint) goa.Endpoint {
+	o := &rbacOptions{roles: apiKeyRoles}
+	for _, opt := range opts {
+		opt(o)
+	}
+	perms := make(map[string][]string)
+	for method, m := range meta {
+		if p := m["rbac:permission"]; len(p) > 0 {
+			perms[method] = p
+		}
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			required := perms[method]
+			if len(required) == 0 {
+				return e(ctx, req)
+			}
+			ctx = goa.WithAuthHook(ctx, func(ctx context.Context, _ interface{}) (context.Context, error) {
+				roles := o.roles(ctx)
+				for _, p := range required {
+					ok, err := policy.Allowed(ctx, roles, p)
+					if err != nil {
+						return ctx, goa.Fault("failed to evaluate permission %q: %s", p, err)
+					}
+					if !ok {
+						return ctx, goa.PermanentError(goa.PermissionDenied, "permission %q is required", p)
+					}
+				}
+				return ctx, nil
+			})
+			return e(ctx, req)
+		}
+	}
+}
+
+// RBACRoles sets the function used to compute the roles of the caller, for
+// example from the claims of the JWT validated by the service JWTAuth
+// function. The function is given the context returned by the security
+// functions.
+func RBACRoles(fn func(context.Context) []string) RBACOption {
+	return func(o *rbacOptions) {
+		o.roles = fn
+	}
+}
+
+// LoadStaticPolicy reads a StaticPolicy from YAML. The document maps the role
+// names to the lists of permissions they grant:
+//
+//    admin:
+//      - "*"
+//    editor:
+//      - bottles:*
+//    viewer:
+//      - bottles:read
+//
+func LoadStaticPolicy(r io.Reader) (StaticPolicy, error) {
+	var p StaticPolicy
+	if err := yaml.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to load policy: %w", err)
+	}
+	return p, nil
+}
+
+// OPAPolicy returns a Policy that queries the Open Policy Agent data API at
+// the given URL, e.g. "http://localhost:8181/v1/data/rbac/allow". The query
+// input is a JSON object with the "roles" and "permission" fields and the
+// policy decision must be a boolean. client defaults to http.DefaultClient if
+// nil.
+func OPAPolicy(url string, client *http.Client) Policy {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &opaPolicy{url: url, client: client}
+}
+
+// Allowed calls f.
+func (f PolicyFunc) Allowed(ctx context.Context, roles []string, permission string) (bool, error) {
+	return f(ctx, roles, permission)
+}
+
+// Allowed returns true if one of the roles grants the permission.
+func (p StaticPolicy) Allowed(_ context.Context, roles []string, permission string) (bool, error) {
+	for _, r := range roles {
+		for _, granted := range p[r] {
+			if granted == "*" || granted == permission {
+				return true, nil
+			}
+			if strings.HasSuffix(granted, ":*") && strings.HasPrefix(permission, granted[:len(granted)-1]) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Allowed queries the OPA server.
+func (p *opaPolicy) Allowed(ctx context.Context, roles []string, permission string) (bool, error) {
+	return opaQuery(ctx, p.client, p.url, map[string]interface{}{
+		"roles":      roles,
+		"permission": permission,
+	})
+}
+
+// opaQuery evaluates the policy decision at the given OPA data API URL with
+// the given input.
+func opaQuery(ctx context.Context, client *http.Client, url string, input interface{}) (bool, error) {
+	body, err := json.Marshal(map[string]interface{}{"input": input})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("OPA server returned status %d", resp.StatusCode)
+	}
+	var res struct {
+		Result *bool `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return false, fmt.Errorf("invalid OPA response: %w", err)
+	}
+	if res.Result == nil {
+		return false, fmt.Errorf("OPA policy decision is undefined")
+	}
+	return *res.Result, nil
+}
+
+// apiKeyRoles returns the roles listed in the "roles" metadata of the API key
+// stored in the context by security.APIKeyAuth if any.
+func apiKeyRoles(ctx context.Context) []string {
+	info := security.ContextAPIKeyInfo(ctx)
+	if info == nil || info.Meta["roles"] == "" {
+		return nil
+	}
+	var roles []string
+	for _, r := range strings.Split(info.Meta["roles"], ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
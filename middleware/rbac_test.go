@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"goa.design/goa/v3/middleware/testdata/gen/docs"
+	goa "goa.design/goa/v3/pkg"
+	"goa.design/goa/v3/security"
+)
+
+func TestRBAC(t *testing.T) {
+	var (
+		meta = map[string]map[string][]string{
+			"create": {"rbac:permission": {"bottles:write"}},
+			"delete": {"rbac:permission": {"bottles:write", "bottles:delete"}},
+			"list":   {"audit": {"true"}},
+		}
+		policy = StaticPolicy{
+			"admin":  {"*"},
+			"editor": {"bottles:write"},
+		}
+		failing = PolicyFunc(func(context.Context, []string, string) (bool, error) {
+			return false, errors.New("boom")
+		})
+		endpoint = func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, err := goa.RunAuthHooks(ctx, req); err != nil {
+				return nil, err
+			}
+			return "ok", nil
+		}
+	)
+	cases := map[string]struct {
+		method string
+		roles  string
+		policy Policy
+		err    string
+	}{
+		"no permission":  {"list", "", policy, ""},
+		"granted":        {"create", "viewer, editor", policy, ""},
+		"wildcard":       {"delete", "admin", policy, ""},
+		"missing role":   {"create", "", policy, goa.PermissionDenied},
+		"partial":        {"delete", "editor", policy, goa.PermissionDenied},
+		"policy failure": {"create", "editor", failing, "fault"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), goa.MethodKey, c.method)
+			ctx = context.WithValue(ctx, security.APIKeyInfoKey, &security.APIKeyInfo{Meta: map[string]string{"roles": c.roles}})
+			res, err := RBAC(c.policy, meta)(endpoint)(ctx, nil)
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if res != "ok" {
+					t.Errorf("got result %v, expected ok", res)
+				}
+				return
+			}
+			serr, ok := err.(*goa.ServiceError)
+			if !ok {
+				t.Fatalf("got error %v, expected a service error", err)
+			}
+			if serr.Name != c.err {
+				t.Errorf("got error name %q, expected %q", serr.Name, c.err)
+			}
+		})
+	}
+}
+
+func TestRBACGeneratedEndpoint(t *testing.T) {
+	var (
+		acmeKey    = "acme-key"
+		initechKey = "initech-key"
+		invalidKey = "invalid"
+		policy     = StaticPolicy{"reader": {"docs:read"}}
+	)
+	cases := map[string]struct {
+		key *string
+		err string
+	}{
+		"granted":     {&acmeKey, ""},
+		"no role":     {&initechKey, goa.PermissionDenied},
+		"invalid key": {&invalidKey, "unauthorized"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx, err := callDocs(RBAC(policy, docs.MethodMeta), &docs.ShowPayload{Key: c.key})
+			if c.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %s", err)
+				}
+				if ctx == nil {
+					t.Error("service method not called")
+				}
+				return
+			}
+			var serr *goa.ServiceError
+			if !errors.As(err, &serr) || serr.Name != c.err {
+				t.Fatalf("got error %v, expected %q", err, c.err)
+			}
+			if ctx != nil {
+				t.Error("service method called")
+			}
+		})
+	}
+}
+
+func TestStaticPolicy(t *testing.T) {
+	p, err := LoadStaticPolicy(strings.NewReader("editor:\n  - bottles:*\nviewer:\n  - bottles:read\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		roles      []string
+		permission string
+		allowed    bool
+	}{
+		{[]string{"viewer"}, "bottles:read", true},
+		{[]string{"viewer"}, "bottles:write", false},
+		{[]string{"editor"}, "bottles:write", true},
+		{[]string{"editor"}, "accounts:write", false},
+		{[]string{"viewer", "editor"}, "bottles:write", true},
+		{nil, "bottles:read", false},
+	}
+	for _, c := range cases {
+		ok, err := p.Allowed(context.Background(), c.roles, c.permission)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != c.allowed {
+			t.Errorf("%v %s: got %v, expected %v", c.roles, c.permission, ok, c.allowed)
+		}
+	}
+	if _, err := LoadStaticPolicy(strings.NewReader("editor: [")); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestOPAPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input struct {
+				Roles      []string `json:"roles"`
+				Permission string   `json:"permission"`
+			} `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch body.Input.Permission {
+		case "undefined":
+			w.Write([]byte(`{}`)) // nolint: errcheck
+		default:
+			allowed := len(body.Input.Roles) == 1 && body.Input.Roles[0] == "admin" && r.URL.Path == "/v1/data/rbac/allow"
+			json.NewEncoder(w).Encode(map[string]bool{"result": allowed}) // nolint: errcheck
+		}
+	}))
+	defer srv.Close()
+	p := OPAPolicy(srv.URL+"/v1/data/rbac/allow", nil)
+	if ok, err := p.Allowed(context.Background(), []string{"admin"}, "bottles:write"); err != nil || !ok {
+		t.Errorf("admin: got %v, %v, expected true", ok, err)
+	}
+	if ok, err := p.Allowed(context.Background(), []string{"viewer"}, "bottles:write"); err != nil || ok {
+		t.Errorf("viewer: got %v, %v, expected false", ok, err)
+	}
+	if _, err := p.Allowed(context.Background(), []string{"admin"}, "undefined"); err == nil {
+		t.Error("undefined: expected an error")
+	}
+}
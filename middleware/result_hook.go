@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// ResultHook post-processes the result of a method before it is encoded by
+// the transport. A hook may modify the result in place or return a different
+// value of the same type. Errors returned by a hook are returned by the
+// endpoint in lieu of the result.
+type ResultHook func(ctx context.Context, res interface{}) (interface{}, error)
+
+// ResultHooks returns an endpoint middleware that passes the results of the
+// endpoints through the given hooks in order. Hooks make it possible to
+// implement cross-cutting concerns such as field level entitlement filtering
+// or currency conversion without modifying the service implementation. Hooks
+// may be applied to all the service endpoints:
+//
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.ResultHooks(filterEntitlements))
+//
+// or to a single endpoint:
+//
+//    endpoints.Show = middleware.ResultHooks(convertCurrency)(endpoints.Show)
+//
+// Hooks receive the values returned by the generated endpoints: the method
+// result or, for methods whose result type defines views, the viewed result
+// defined in the views package. Hooks are not called when the method returns
+// an error or no result.
+func ResultHooks(hooks ...ResultHook) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			res, err := e(ctx, req)
+			if err != nil || res == nil {
+				return res, err
+			}
+			for _, h := range hooks {
+				if res, err = h(ctx, res); err != nil {
+					return nil, err
+				}
+			}
+			return res, nil
+		}
+	}
+}
+
+// OnMethods returns a hook that only applies h to the results of the methods
+// with the given names as defined in the design.
+func (h ResultHook) OnMethods(methods ...string) ResultHook {
+	names := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		names[m] = struct{}{}
+	}
+	return func(ctx context.Context, res interface{}) (interface{}, error) {
+		method, _ := ctx.Value(goa.MethodKey).(string)
+		if _, ok := names[method]; !ok {
+			return res, nil
+		}
+		return h(ctx, res)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestResultHooks(t *testing.T) {
+	type price struct {
+		Amount   int
+		Currency string
+	}
+	var (
+		convert ResultHook = func(_ context.Context, res interface{}) (interface{}, error) {
+			p := res.(*price)
+			return &price{Amount: p.Amount * 2, Currency: "EUR"}, nil
+		}
+		round ResultHook = func(_ context.Context, res interface{}) (interface{}, error) {
+			res.(*price).Amount -= res.(*price).Amount % 10
+			return res, nil
+		}
+		failing ResultHook = func(context.Context, interface{}) (interface{}, error) {
+			return nil, errors.New("forbidden")
+		}
+		endpoint = func(context.Context, interface{}) (interface{}, error) {
+			return &price{Amount: 21, Currency: "USD"}, nil
+		}
+	)
+	cases := map[string]struct {
+		method   string
+		hooks    []ResultHook
+		expected *price
+		err      string
+	}{
+		"none":           {"show", nil, &price{21, "USD"}, ""},
+		"ordered":        {"show", []ResultHook{convert, round}, &price{40, "EUR"}, ""},
+		"other method":   {"list", []ResultHook{convert.OnMethods("show")}, &price{21, "USD"}, ""},
+		"matched method": {"show", []ResultHook{convert.OnMethods("show")}, &price{42, "EUR"}, ""},
+		"error":          {"show", []ResultHook{failing, convert}, nil, "forbidden"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), goa.MethodKey, c.method)
+			res, err := ResultHooks(c.hooks...)(endpoint)(ctx, nil)
+			if c.err != "" {
+				if err == nil || err.Error() != c.err {
+					t.Fatalf("got error %v, expected %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if *res.(*price) != *c.expected {
+				t.Errorf("got %+v, expected %+v", res, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// Tenant identifies the tenant a call is made on behalf of.
+	Tenant struct {
+		// ID is the tenant identifier.
+		ID string
+		// Info holds the application specific data loaded by the
+		// function given to TenantLoader if any.
+		Info interface{}
+	}
+
+	// TenantOption configures the ScopeTenant middleware.
+	TenantOption func(*tenantOptions)
+
+	// tenantOptions holds the ScopeTenant middleware options.
+	tenantOptions struct {
+		claim func(context.Context) string
+		load  func(context.Context, string) (interface{}, error)
+	}
+)
+
+// ScopeTenant returns an endpoint middleware that resolves the tenant of the
+// calls made to the methods scoped to a tenant in the design with the
+// TenantParam DSL. meta is the MethodMeta variable generated in the service
+// package:
+//
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.ScopeTenant(svc.MethodMeta, middleware.TenantClaim(accountClaim)))
+//
+// The tenant ID is read from the payload attribute named by TenantParam -
+// which the transport may load from the request path or a header - and from
+// the token claims if TenantClaim is used. The tenant is resolved once the
+// generated endpoint has authenticated the caller so that the claims stored in
+// the context by the security functions are available, see goa.WithAuthHook.
+// ScopeTenant stores the resolved tenant in the request context, see
+// ContextTenant. Calls that do not identify a tenant are rejected with a
+// goa.MissingTenant error. When TenantClaim is used calls made with a token
+// that does not identify a tenant or whose payload identifies a tenant other
+// than the one in the token claims are rejected with a goa.PermissionDenied
+// error. Methods that are not tenant-scoped are not affected.
+func ScopeTenant(meta map[string]map[string][]string, opts ...TenantOption) func(goa.Endpoint) goa.Endpoint {
+	o := new(tenantOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	params := make(map[string]string)
+	for method, m := range meta {
+		if p := m["tenant:param"]; len(p) > 0 && p[0] != "" {
+			params[method] = p[0]
+		}
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			param, ok := params[method]
+			if !ok {
+				return e(ctx, req)
+			}
+			ctx = goa.WithAuthHook(ctx, func(ctx context.Context, payload interface{}) (context.Context, error) {
+				var id string
+				if v, ok := PayloadFields(payload, param)[param]; ok {
+					id = fmt.Sprint(v)
+				}
+				if o.claim != nil {
+					claimed := o.claim(ctx)
+					if claimed == "" {
+						return ctx, goa.PermanentError(goa.PermissionDenied, "method %q requires a token that identifies a tenant", method)
+					}
+					if id == "" {
+						id = claimed
+					} else if claimed != id {
+						return ctx, goa.PermanentError(goa.PermissionDenied, "access to tenant %q is not allowed", id)
+					}
+				}
+				if id == "" {
+					return ctx, goa.PermanentError(goa.MissingTenant, "method %q requires a tenant, %q is missing", method, param)
+				}
+				t := &Tenant{ID: id}
+				if o.load != nil {
+					info, err := o.load(ctx, id)
+					if err != nil {
+						return ctx, err
+					}
+					t.Info = info
+				}
+				return ContextWithTenant(ctx, t), nil
+			})
+			return e(ctx, req)
+		}
+	}
+}
+
+// TenantClaim sets the function used by the ScopeTenant middleware to read
+// the tenant ID from the claims of the token that authenticated the caller,
+// for example the JWT claims stored in the context by the service JWTAuth
+// function. The function returns "" if the token does not identify a tenant
+// in which case the call is rejected. Calls whose payload identifies another
+// tenant are rejected as well.
+func TenantClaim(fn func(context.Context) string) TenantOption {
+	return func(o *tenantOptions) {
+		o.claim = fn
+	}
+}
+
+// TenantLoader sets the function used by the ScopeTenant middleware to load
+// the application specific data of the tenant with the given ID. The data is
+// stored in the Info field of the tenant. Errors returned by the function are
+// returned to the caller, typically a not found error if the tenant does not
+// exist.
+func TenantLoader(fn func(ctx context.Context, id string) (interface{}, error)) TenantOption {
+	return func(o *tenantOptions) {
+		o.load = fn
+	}
+}
+
+// ContextWithTenant returns a copy of ctx that holds the given tenant.
+func ContextWithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, TenantKey, t)
+}
+
+// ContextTenant returns the tenant stored in ctx by the ScopeTenant
+// middleware, nil if there is none.
+func ContextTenant(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(TenantKey).(*Tenant)
+	return t
+}
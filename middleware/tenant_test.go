@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"goa.design/goa/v3/middleware/testdata/gen/docs"
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestScopeTenant(t *testing.T) {
+	type (
+		payload struct {
+			AccountID *string
+		}
+		claimKey struct{}
+	)
+	var (
+		acme    = "acme"
+		initech = "initech"
+		meta    = map[string]map[string][]string{
+			"list":   {"tenant:param": {"account_id"}},
+			"status": {"audit": {"true"}},
+		}
+		claim = func(ctx context.Context) string {
+			s, _ := ctx.Value(claimKey{}).(string)
+			return s
+		}
+		loader = func(_ context.Context, id string) (interface{}, error) {
+			if id == initech {
+				return nil, errors.New("not found")
+			}
+			return "plan:" + id, nil
+		}
+	)
+	cases := map[string]struct {
+		method  string
+		payload interface{}
+		claim   string
+		opts    []TenantOption
+		tenant  *Tenant
+		err     string
+	}{
+		"not scoped":     {"status", nil, "", nil, nil, ""},
+		"payload":        {"list", &payload{&acme}, "", nil, &Tenant{ID: acme}, ""},
+		"claim":          {"list", &payload{}, acme, []TenantOption{TenantClaim(claim)}, &Tenant{ID: acme}, ""},
+		"matching claim": {"list", &payload{&acme}, acme, []TenantOption{TenantClaim(claim)}, &Tenant{ID: acme}, ""},
+		"loaded":         {"list", &payload{&acme}, "", []TenantOption{TenantLoader(loader)}, &Tenant{ID: acme, Info: "plan:acme"}, ""},
+		"missing":        {"list", &payload{}, "", nil, nil, goa.MissingTenant},
+		"unclaimed":      {"list", &payload{&acme}, "", []TenantOption{TenantClaim(claim)}, nil, goa.PermissionDenied},
+		"mismatch":       {"list", &payload{&initech}, acme, []TenantOption{TenantClaim(claim)}, nil, goa.PermissionDenied},
+		"load error":     {"list", &payload{&initech}, "", []TenantOption{TenantLoader(loader)}, nil, "not found"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			var tenant *Tenant
+			endpoint := func(ctx context.Context, req interface{}) (interface{}, error) {
+				ctx, err := goa.RunAuthHooks(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				tenant = ContextTenant(ctx)
+				return "ok", nil
+			}
+			ctx := context.WithValue(context.Background(), goa.MethodKey, c.method)
+			ctx = context.WithValue(ctx, claimKey{}, c.claim)
+			_, err := ScopeTenant(meta, c.opts...)(endpoint)(ctx, c.payload)
+			if c.err != "" {
+				if err == nil {
+					t.Fatalf("got no error, expected %q", c.err)
+				}
+				if serr, ok := err.(*goa.ServiceError); ok {
+					if serr.Name != c.err {
+						t.Errorf("got error name %q, expected %q", serr.Name, c.err)
+					}
+				} else if err.Error() != c.err {
+					t.Errorf("got error %q, expected %q", err, c.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if c.tenant == nil {
+				if tenant != nil {
+					t.Errorf("got tenant %+v, expected none", tenant)
+				}
+				return
+			}
+			if tenant == nil || *tenant != *c.tenant {
+				t.Errorf("got tenant %+v, expected %+v", tenant, c.tenant)
+			}
+		})
+	}
+}
+
+func TestScopeTenantGeneratedEndpoint(t *testing.T) {
+	var (
+		acmeKey    = "acme-key"
+		adminKey   = "admin-key"
+		invalidKey = "invalid"
+		acme       = "acme"
+		initech    = "initech"
+	)
+	cases := map[string]struct {
+		key    *string
+		tenant *string
+		id     string
+		err    string
+	}{
+		"claim":        {&acmeKey, nil, acme, ""},
+		"matching":     {&acmeKey, &acme, acme, ""},
+		"cross-tenant": {&acmeKey, &initech, "", goa.PermissionDenied},
+		"no claim":     {&adminKey, &initech, "", goa.PermissionDenied},
+		"invalid key":  {&invalidKey, &acme, "", "unauthorized"},
+		"missing key":  {nil, &acme, "", "unauthorized"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx, err := callDocs(ScopeTenant(docs.MethodMeta, TenantClaim(keyTenant)), &docs.ShowPayload{Key: c.key, Tenant: c.tenant})
+			if c.err != "" {
+				var serr *goa.ServiceError
+				if !errors.As(err, &serr) || serr.Name != c.err {
+					t.Fatalf("got error %v, expected %q", err, c.err)
+				}
+				if ctx != nil {
+					t.Error("service method called")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if tenant := ContextTenant(ctx); tenant == nil || tenant.ID != c.id {
+				t.Errorf("got tenant %+v, expected %q", tenant, c.id)
+			}
+		})
+	}
+}
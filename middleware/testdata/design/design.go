@@ -0,0 +1,32 @@
+// Package design describes the service used to test the endpoint middlewares
+// against generated endpoints. The generated service package is in
+// ../gen/docs, regenerate it with:
+//
+//    goa gen goa.design/goa/v3/middleware/testdata/design -o middleware/testdata
+//
+package design
+
+import (
+	. "goa.design/goa/v3/dsl"
+)
+
+var _ = API("docs", func() {
+	TenantParam("tenant")
+})
+
+var APIKeyAuth = APIKeySecurity("api_key")
+
+var _ = Service("docs", func() {
+	Security(APIKeyAuth)
+	Method("show", func() {
+		Payload(func() {
+			APIKey("api_key", "key", String)
+			Attribute("tenant", String)
+			Attribute("id", String)
+		})
+		Result(String)
+		Permission("docs:read")
+		Meta("audit", "true")
+		Meta("policy:params", "id")
+	})
+})
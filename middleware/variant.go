@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+// PrimaryVariant is the name of the variant implemented by the endpoint
+// wrapped by the Variants middleware.
+const PrimaryVariant = "primary"
+
+type (
+	// VariantRule selects the variant that handles a call. It returns the
+	// name of the variant or "" to let the next rule, or the primary
+	// endpoint, handle the call.
+	VariantRule func(ctx context.Context) string
+
+	// VariantStats describes a call handled by the Variants middleware.
+	VariantStats struct {
+		// Service is the name of the service.
+		Service string
+		// Method is the name of the method.
+		Method string
+		// Variant is the name of the variant that handled the call.
+		Variant string
+		// Duration is the time spent in the variant endpoint.
+		Duration time.Duration
+		// Err is the error returned by the variant endpoint if any.
+		Err error
+	}
+
+	// VariantOption configures the Variants middleware.
+	VariantOption func(*variantOptions)
+
+	// variantOptions holds the Variants middleware options.
+	variantOptions struct {
+		report func(context.Context, *VariantStats)
+	}
+)
+
+// Variants returns an endpoint middleware that routes the calls made to the
+// wrapped endpoint to alternate implementations of the same method, for
+// example to canary a rewritten implementation or to run blue/green
+// deployments within a single process. variants maps the variant names to
+// the endpoints built from the alternate implementations and rules select the
+// variant of each call, the first rule returning a name wins. Calls for which
+// no rule returns the name of a variant are handled by the wrapped endpoint:
+//
+//    blue := svc.NewEndpoints(svcv1)
+//    green := svc.NewEndpoints(svcv2)
+//    blue.Show = middleware.Variants(
+//        map[string]goa.Endpoint{"green": green.Show},
+//        []middleware.VariantRule{
+//            middleware.RequestedVariant(),         // e.g. "X-Variant: green"
+//            middleware.VariantPercent("green", 5), // 5% of the other calls
+//        },
+//        middleware.VariantReport(func(ctx context.Context, s *middleware.VariantStats) {
+//            calls.WithLabelValues(s.Method, s.Variant, errLabel(s.Err)).Inc()
+//            latency.WithLabelValues(s.Method, s.Variant).Observe(s.Duration.Seconds())
+//        }),
+//    )(blue.Show)
+//
+// The name of the selected variant, PrimaryVariant for the wrapped endpoint,
+// is stored in the context given to the endpoint (see ContextVariant) and in
+// the stats given to the function set with VariantReport so that the logs and
+// metrics can be separated per variant.
+func Variants(variants map[string]goa.Endpoint, rules []VariantRule, opts ...VariantOption) func(goa.Endpoint) goa.Endpoint {
+	o := new(variantOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			name, endpoint := PrimaryVariant, e
+			for _, rule := range rules {
+				if n := rule(ctx); n != "" {
+					if v, ok := variants[n]; ok {
+						name, endpoint = n, v
+						break
+					}
+				}
+			}
+			ctx = context.WithValue(ctx, VariantKey, name)
+			if o.report == nil {
+				return endpoint(ctx, req)
+			}
+			start := time.Now()
+			res, err := endpoint(ctx, req)
+			stats := &VariantStats{Variant: name, Duration: time.Since(start), Err: err}
+			stats.Service, _ = ctx.Value(goa.ServiceKey).(string)
+			stats.Method, _ = ctx.Value(goa.MethodKey).(string)
+			o.report(ctx, stats)
+			return res, err
+		}
+	}
+}
+
+// VariantReport sets the function called by the Variants middleware with the
+// statistics of each call once it returns.
+func VariantReport(fn func(context.Context, *VariantStats)) VariantOption {
+	return func(o *variantOptions) {
+		o.report = fn
+	}
+}
+
+// RequestedVariant returns a rule that selects the variant requested by the
+// caller, see the HTTP RequestVariant middleware.
+func RequestedVariant() VariantRule {
+	return func(ctx context.Context) string {
+		v, _ := ctx.Value(RequestedVariantKey).(string)
+		return v
+	}
+}
+
+// VariantPercent returns a rule that selects the variant with the given name
+// for percent percent of the calls picked at random.
+func VariantPercent(name string, percent int) VariantRule {
+	return func(context.Context) string {
+		if percent > 0 && (percent >= 100 || rand.Intn(100) < percent) {
+			return name
+		}
+		return ""
+	}
+}
+
+// VariantStickyPercent returns a rule that selects the variant with the given
+// name for percent percent of the callers. key returns the identifier of the
+// caller, e.g. the user ID, so that all the calls made by the same caller are
+// handled by the same variant. Calls for which key returns "" are not
+// selected.
+func VariantStickyPercent(name string, percent int, key func(context.Context) string) VariantRule {
+	return func(ctx context.Context) string {
+		k := key(ctx)
+		if k == "" || percent <= 0 {
+			return ""
+		}
+		h := fnv.New32a()
+		h.Write([]byte(k)) // nolint: errcheck
+		if h.Sum32()%100 < uint32(percent) {
+			return name
+		}
+		return ""
+	}
+}
+
+// ContextVariant returns the name of the variant selected by the Variants
+// middleware stored in ctx, "" if there is none.
+func ContextVariant(ctx context.Context) string {
+	v, _ := ctx.Value(VariantKey).(string)
+	return v
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestVariants(t *testing.T) {
+	endpoint := func(name string) goa.Endpoint {
+		return func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return name + ":" + ContextVariant(ctx), nil
+		}
+	}
+	var reported []string
+	ep := Variants(
+		map[string]goa.Endpoint{"green": endpoint("green"), "red": endpoint("red")},
+		[]VariantRule{RequestedVariant(), VariantPercent("red", 0)},
+		VariantReport(func(_ context.Context, s *VariantStats) {
+			reported = append(reported, s.Method+":"+s.Variant)
+		}),
+	)(endpoint("blue"))
+	cases := map[string]struct {
+		requested string
+		expected  string
+		report    string
+	}{
+		"primary":   {"", "blue:primary", "show:primary"},
+		"requested": {"green", "green:green", "show:green"},
+		"unknown":   {"yellow", "blue:primary", "show:primary"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			reported = nil
+			ctx := context.WithValue(context.Background(), goa.MethodKey, "show")
+			if c.requested != "" {
+				ctx = context.WithValue(ctx, RequestedVariantKey, c.requested)
+			}
+			res, err := ep(ctx, nil)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if res != c.expected {
+				t.Errorf("got %v, expected %s", res, c.expected)
+			}
+			if len(reported) != 1 || reported[0] != c.report {
+				t.Errorf("got reports %v, expected [%s]", reported, c.report)
+			}
+		})
+	}
+}
+
+func TestVariantPercent(t *testing.T) {
+	if VariantPercent("green", 100)(context.Background()) != "green" {
+		t.Error("expected 100% rule to always select the variant")
+	}
+	if VariantPercent("green", 0)(context.Background()) != "" {
+		t.Error("expected 0% rule to never select the variant")
+	}
+}
+
+func TestVariantStickyPercent(t *testing.T) {
+	var user string
+	rule := VariantStickyPercent("green", 30, func(context.Context) string { return user })
+	if rule(context.Background()) != "" {
+		t.Error("expected calls without key not to be selected")
+	}
+	selected := 0
+	for i := 0; i < 1000; i++ {
+		user = fmt.Sprintf("user-%d", i)
+		first := rule(context.Background())
+		if rule(context.Background()) != first {
+			t.Fatalf("got different variants for %s", user)
+		}
+		if first == "green" {
+			selected++
+		}
+	}
+	if selected < 200 || selected > 400 {
+		t.Errorf("got %d selected callers out of 1000, expected about 300", selected)
+	}
+}
@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// WorkerPoolOption configures the WorkerPool middleware.
+	WorkerPoolOption func(*workerPoolOptions)
+
+	// WorkerPoolStats describes a call dispatched to a worker pool.
+	WorkerPoolStats struct {
+		// Method is the name of the method.
+		Method string
+		// Workers is the number of workers of the method pool.
+		Workers int
+		// Busy is the number of workers running a call when the call
+		// completed including the worker that ran the call.
+		Busy int
+		// Queued is the number of calls waiting for a worker when the
+		// call completed.
+		Queued int
+		// Wait is the time the call spent waiting for a worker.
+		Wait time.Duration
+		// Run is the time spent running the call.
+		Run time.Duration
+	}
+
+	workerPoolOptions struct {
+		queue  int
+		report func(context.Context, *WorkerPoolStats)
+	}
+
+	// workerPool runs the calls made to a method with a bounded number of
+	// goroutines.
+	workerPool struct {
+		method  string
+		workers int
+		jobs    chan *poolJob
+		report  func(context.Context, *WorkerPoolStats)
+		mu      sync.Mutex
+		running int
+		busy    int64
+	}
+
+	// poolJob is a call waiting for a worker.
+	poolJob struct {
+		ctx     context.Context
+		req     interface{}
+		e       goa.Endpoint
+		queued  time.Time
+		results chan poolResult
+	}
+
+	// poolResult is the result of a call run by a worker.
+	poolResult struct {
+		res interface{}
+		err error
+	}
+)
+
+// WorkerPool returns an endpoint middleware that runs the calls made to the
+// methods whose "concurrency" metadata is set in the design with a bounded
+// pool of goroutines. meta is the MethodMeta variable generated in the service
+// package:
+//
+//    endpoints := svc.NewEndpoints(s)
+//    endpoints.Use(middleware.WorkerPool(svc.MethodMeta, middleware.WorkerPoolReport(report)))
+//
+// The metadata value is the number of workers of the method pool. Calls made
+// while all the workers are busy wait in a queue whose size is given by the
+// "concurrency:queue" metadata (100 by default, see WorkerPoolQueueSize). The
+// queue must hold at least one call. Calls that cannot be queued are rejected
+// with the temporary "overloaded" error also returned by the Queue middleware.
+// Calls whose context is canceled while they wait are not run. Workers are
+// started on demand and exit when the queue is empty so that idle pools do not
+// hold any goroutine. A panic in a call run by a worker is recovered and
+// returned to the caller as a goa.Fault error so that it does not crash the
+// process nor stop the worker. Methods that do not define the metadata are not
+// affected.
+//
+// WorkerPool panics if the metadata values or the default queue size are
+// invalid.
+func WorkerPool(meta map[string]map[string][]string, opts ...WorkerPoolOption) func(goa.Endpoint) goa.Endpoint {
+	o := &workerPoolOptions{queue: 100}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.queue < 1 {
+		panic(fmt.Sprintf("invalid worker pool queue size: %d", o.queue))
+	}
+	pools := make(map[string]*workerPool)
+	for method, m := range meta {
+		if p := newWorkerPool(method, m, o); p != nil {
+			pools[method] = p
+		}
+	}
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := ctx.Value(goa.MethodKey).(string)
+			p, ok := pools[method]
+			if !ok {
+				return e(ctx, req)
+			}
+			return p.dispatch(ctx, req, e)
+		}
+	}
+}
+
+// WorkerPoolQueueSize sets the default number of calls that may wait for a
+// worker. The "concurrency:queue" metadata overrides the default for a method.
+// The default is 100, n must be at least 1.
+func WorkerPoolQueueSize(n int) WorkerPoolOption {
+	return func(o *workerPoolOptions) {
+		o.queue = n
+	}
+}
+
+// WorkerPoolReport sets a function called with the statistics of each call
+// run by a worker pool, e.g. to record the queue length and wait time with a
+// metrics library.
+func WorkerPoolReport(fn func(context.Context, *WorkerPoolStats)) WorkerPoolOption {
+	return func(o *workerPoolOptions) {
+		o.report = fn
+	}
+}
+
+// newWorkerPool returns the pool configured by the given method metadata or
+// nil if the metadata does not define a number of workers.
+func newWorkerPool(method string, m map[string][]string, o *workerPoolOptions) *workerPool {
+	c := m["concurrency"]
+	if len(c) == 0 {
+		return nil
+	}
+	workers, err := strconv.Atoi(c[0])
+	if err != nil || workers < 1 {
+		panic(fmt.Sprintf("invalid concurrency metadata for method %q: %q", method, c[0]))
+	}
+	queue := o.queue
+	if q := m["concurrency:queue"]; len(q) > 0 {
+		size, err := strconv.Atoi(q[0])
+		if err != nil || size < 1 {
+			panic(fmt.Sprintf("invalid concurrency:queue metadata for method %q: %q", method, q[0]))
+		}
+		queue = size
+	}
+	return &workerPool{
+		method:  method,
+		workers: workers,
+		jobs:    make(chan *poolJob, queue),
+		report:  o.report,
+	}
+}
+
+// dispatch queues the call, starts a worker if the pool is not full and waits
+// for the result.
+func (p *workerPool) dispatch(ctx context.Context, req interface{}, e goa.Endpoint) (interface{}, error) {
+	job := &poolJob{ctx: ctx, req: req, e: e, queued: time.Now(), results: make(chan poolResult, 1)}
+	select {
+	case p.jobs <- job:
+	default:
+		return nil, goa.NewServiceError(&overloadedError{method: p.method, retryAfter: time.Second}, Overloaded, false, true, false)
+	}
+	p.mu.Lock()
+	if p.running < p.workers {
+		p.running++
+		go p.work()
+	}
+	p.mu.Unlock()
+	select {
+	case r := <-job.results:
+		return r.res, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// work runs the queued calls until the queue is empty.
+func (p *workerPool) work() {
+	for {
+		select {
+		case job := <-p.jobs:
+			p.run(job)
+		default:
+			p.mu.Lock()
+			if len(p.jobs) == 0 {
+				p.running--
+				p.mu.Unlock()
+				return
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// run runs a call unless its context is done.
+func (p *workerPool) run(job *poolJob) {
+	if job.ctx.Err() != nil {
+		return
+	}
+	started := time.Now()
+	busy := atomic.AddInt64(&p.busy, 1)
+	defer atomic.AddInt64(&p.busy, -1)
+	res, err := p.call(job)
+	job.results <- poolResult{res: res, err: err}
+	if p.report != nil {
+		p.report(job.ctx, &WorkerPoolStats{
+			Method:  p.method,
+			Workers: p.workers,
+			Busy:    int(busy),
+			Queued:  len(p.jobs),
+			Wait:    started.Sub(job.queued),
+			Run:     time.Since(started),
+		})
+	}
+}
+
+// call calls the endpoint of the job and returns panics as fault errors.
+func (p *workerPool) call(job *poolJob) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, goa.Fault("method %q panicked: %v", p.method, r)
+		}
+	}()
+	return job.e(job.ctx, job.req)
+}
@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestWorkerPool(t *testing.T) {
+	var (
+		meta    = map[string]map[string][]string{"render": {"concurrency": {"2"}, "concurrency:queue": {"10"}}}
+		active  int64
+		maxSeen int64
+		mu      sync.Mutex
+		stats   []*WorkerPoolStats
+		report  = func(_ context.Context, s *WorkerPoolStats) { mu.Lock(); stats = append(stats, s); mu.Unlock() }
+		e       = WorkerPool(meta, WorkerPoolReport(report))(func(context.Context, interface{}) (interface{}, error) {
+			n := atomic.AddInt64(&active, 1)
+			for {
+				m := atomic.LoadInt64(&maxSeen)
+				if n <= m || atomic.CompareAndSwapInt64(&maxSeen, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt64(&active, -1)
+			return "ok", nil
+		})
+		ctx = context.WithValue(context.Background(), goa.MethodKey, "render")
+		wg  sync.WaitGroup
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if res, err := e(ctx, nil); err != nil || res != "ok" {
+				t.Errorf("got %v, %v, expected ok", res, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxSeen > 2 {
+		t.Errorf("got %d concurrent calls, expected at most 2", maxSeen)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(stats) != 8 {
+		t.Fatalf("got %d reports, expected 8", len(stats))
+	}
+	for _, s := range stats {
+		if s.Method != "render" || s.Workers != 2 || s.Busy < 1 || s.Busy > 2 {
+			t.Errorf("got invalid stats %+v", s)
+		}
+	}
+}
+
+func TestWorkerPoolQueueFull(t *testing.T) {
+	var (
+		meta    = map[string]map[string][]string{"render": {"concurrency": {"1"}, "concurrency:queue": {"1"}}}
+		release = make(chan struct{})
+		started = make(chan struct{}, 2)
+		e       = WorkerPool(meta)(func(context.Context, interface{}) (interface{}, error) {
+			started <- struct{}{}
+			<-release
+			return "ok", nil
+		})
+		ctx  = context.WithValue(context.Background(), goa.MethodKey, "render")
+		errs = make(chan error, 2)
+	)
+	// Occupy the only worker then the only queue slot.
+	go func() { _, err := e(ctx, nil); errs <- err }()
+	<-started
+	go func() { _, err := e(ctx, nil); errs <- err }()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := e(ctx, nil); !isOverloaded(time.Second)(err) {
+		t.Errorf("got error %v, expected overloaded", err)
+	}
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("got error %v, expected nil", err)
+		}
+	}
+}
+
+func TestWorkerPoolCanceled(t *testing.T) {
+	var (
+		meta    = map[string]map[string][]string{"render": {"concurrency": {"1"}}}
+		release = make(chan struct{})
+		started = make(chan struct{}, 2)
+		e       = WorkerPool(meta)(func(context.Context, interface{}) (interface{}, error) {
+			started <- struct{}{}
+			<-release
+			return "ok", nil
+		})
+		ctx = context.WithValue(context.Background(), goa.MethodKey, "render")
+	)
+	go e(ctx, nil) // nolint: errcheck
+	<-started
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := e(cctx, nil); err != context.Canceled {
+		t.Errorf("got error %v, expected %v", err, context.Canceled)
+	}
+	close(release)
+	select {
+	case <-started:
+		t.Error("canceled call was run")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestWorkerPoolPanic(t *testing.T) {
+	var (
+		meta = map[string]map[string][]string{"render": {"concurrency": {"1"}}}
+		e    = WorkerPool(meta)(func(_ context.Context, req interface{}) (interface{}, error) {
+			if req == "boom" {
+				panic("boom")
+			}
+			return "ok", nil
+		})
+		ctx = context.WithValue(context.Background(), goa.MethodKey, "render")
+	)
+	_, err := e(ctx, "boom")
+	var serr *goa.ServiceError
+	if !errors.As(err, &serr) || serr.Name != "fault" {
+		t.Fatalf("got error %v, expected fault", err)
+	}
+	if res, err := e(ctx, nil); err != nil || res != "ok" {
+		t.Errorf("got %v, %v, expected ok", res, err)
+	}
+}
+
+func TestWorkerPoolNotConfigured(t *testing.T) {
+	meta := map[string]map[string][]string{"render": {"concurrency": {"1"}}}
+	e := WorkerPool(meta)(func(context.Context, interface{}) (interface{}, error) { return "ok", nil })
+	ctx := context.WithValue(context.Background(), goa.MethodKey, "list")
+	if res, err := e(ctx, nil); err != nil || res != "ok" {
+		t.Errorf("got %v, %v, expected ok", res, err)
+	}
+}
+
+func TestWorkerPoolInvalidMeta(t *testing.T) {
+	cases := map[string]struct {
+		meta map[string][]string
+		opts []WorkerPoolOption
+	}{
+		"no worker":           {map[string][]string{"concurrency": {"0"}}, nil},
+		"empty queue":         {map[string][]string{"concurrency": {"1"}, "concurrency:queue": {"0"}}, nil},
+		"empty default queue": {map[string][]string{"concurrency": {"1"}}, []WorkerPoolOption{WorkerPoolQueueSize(0)}},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			WorkerPool(map[string]map[string][]string{"render": c.meta}, c.opts...)
+		})
+	}
+}
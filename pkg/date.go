@@ -0,0 +1,84 @@
+package goa
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayout is the RFC3339 full-date layout.
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date without time of day or time zone. It is the Go type
+// of the attributes defined with the Date design type. Date values are
+// encoded as RFC3339 full-date strings (e.g. "2006-01-02"). The zero value is
+// January 1 of year 1. Dates can be compared with ==.
+type Date struct {
+	// t is midnight UTC on the date.
+	t time.Time
+}
+
+// NewDate returns the date with the given year, month and day. The values are
+// normalized the same way as time.Date, e.g. October 32 is November 1.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// DateOf returns the date of t in the location of t.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Date())
+}
+
+// ParseDate parses a RFC3339 full-date such as "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q", s)
+	}
+	return Date{t: t}, nil
+}
+
+// Year returns the year of d.
+func (d Date) Year() int {
+	return d.t.Year()
+}
+
+// Month returns the month of the year of d.
+func (d Date) Month() time.Month {
+	return d.t.Month()
+}
+
+// Day returns the day of the month of d.
+func (d Date) Day() int {
+	return d.t.Day()
+}
+
+// String returns the RFC3339 full-date representation of d, e.g.
+// "2006-01-02".
+func (d Date) String() string {
+	return d.t.Format(dateLayout)
+}
+
+// In returns the time at midnight on date d in the given location.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// IsZero returns true if d is the zero value.
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Date) UnmarshalText(b []byte) error {
+	v, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
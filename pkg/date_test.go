@@ -0,0 +1,67 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected Date
+		Error    bool
+	}{
+		{"date", "2006-01-02", NewDate(2006, time.January, 2), false},
+		{"leap day", "2024-02-29", NewDate(2024, time.February, 29), false},
+		{"invalid day", "2023-02-29", Date{}, true},
+		{"date-time", "2006-01-02T15:04:05Z", Date{}, true},
+		{"empty", "", Date{}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDate(c.Value)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected error for %q", c.Value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if d != c.Expected {
+				t.Errorf("got %v, expected %v", d, c.Expected)
+			}
+			if d.String() != c.Value {
+				t.Errorf("got string %q, expected %q", d.String(), c.Value)
+			}
+		})
+	}
+}
+
+func TestDateJSON(t *testing.T) {
+	var v struct{ Due Date }
+	if err := json.Unmarshal([]byte(`{"Due":"2006-01-02"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Due != NewDate(2006, time.January, 2) {
+		t.Errorf("got %v, expected 2006-01-02", v.Due)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"Due":"2006-01-02"}` {
+		t.Errorf("got %s, expected %s", b, `{"Due":"2006-01-02"}`)
+	}
+	if err := json.Unmarshal([]byte(`{"Due":"2006-01-02T15:04:05Z"}`), &v); err == nil {
+		t.Error("got no error for a date-time")
+	}
+	var zero Date
+	b, _ = zero.MarshalText()
+	if err := zero.UnmarshalText(b); err != nil || !zero.IsZero() {
+		t.Errorf("zero value %q does not round trip: %v", b, err)
+	}
+}
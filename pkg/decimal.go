@@ -0,0 +1,159 @@
+package goa
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an arbitrary precision decimal number. It is the Go type of the
+// attributes defined with the Decimal design type. Decimal values are encoded
+// as JSON strings (e.g. "12.30") so that no precision is lost when decoding
+// them in languages that represent JSON numbers as floating point numbers.
+// The zero value is 0.
+type Decimal struct {
+	// unscaled is the value multiplied by 10^scale, nil means 0.
+	unscaled *big.Int
+	// scale is the number of digits after the decimal point.
+	scale int32
+}
+
+// maxDecimalScale is the maximum absolute value of the scale of parsed
+// decimals. It prevents inputs such as "1e999999999" from allocating large
+// amounts of memory.
+const maxDecimalScale = 1000
+
+// NewDecimal returns the decimal unscaled * 10^-scale, e.g. NewDecimal(1230, 2)
+// is 12.30.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		u := new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale))
+		return Decimal{unscaled: u}
+	}
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}
+}
+
+// ParseDecimal parses a decimal number such as "-12.30" or "1.5e3".
+func ParseDecimal(s string) (Decimal, error) {
+	invalid := fmt.Errorf("invalid decimal %q", s)
+	str := s
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, invalid
+		}
+		exp = e
+		str = str[:i]
+	}
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	digits := strings.TrimLeft(intPart, "+-")
+	if len(intPart)-len(digits) > 1 || digits+fracPart == "" || !isDigits(digits) || !isDigits(fracPart) {
+		return Decimal{}, invalid
+	}
+	u, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, invalid
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, invalid
+	}
+	if scale < 0 {
+		return Decimal{unscaled: u.Mul(u, pow10(int32(-scale)))}, nil
+	}
+	return Decimal{unscaled: u, scale: int32(scale)}, nil
+}
+
+// String returns the decimal representation of d without exponent, e.g.
+// "-12.30".
+func (d Decimal) String() string {
+	if d.unscaled == nil {
+		return "0"
+	}
+	s := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if pad := int(d.scale) - len(s) + 1; pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if d.unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Cmp compares d and o and returns -1 if d < o, 0 if d == o and +1 if d > o.
+func (d Decimal) Cmp(o Decimal) int {
+	return d.Rat().Cmp(o.Rat())
+}
+
+// Rat returns d as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	if d.unscaled == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(d.unscaled, pow10(d.scale))
+}
+
+// Float64 returns the float64 value nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Decimal) UnmarshalText(b []byte) error {
+	v, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes d from a JSON string or number.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if len(b) > 1 && b[0] == '"' {
+		s, err := strconv.Unquote(string(b))
+		if err != nil {
+			return err
+		}
+		b = []byte(s)
+	}
+	return d.UnmarshalText(b)
+}
+
+// pow10 returns 10^n.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isDigits returns true if s only contains ASCII digits.
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package goa
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected string
+		Error    bool
+	}{
+		{"integer", "42", "42", false},
+		{"fraction", "12.30", "12.30", false},
+		{"negative", "-0.05", "-0.05", false},
+		{"plus sign", "+1.5", "1.5", false},
+		{"leading dot", ".5", "0.5", false},
+		{"exponent", "1.5e3", "1500", false},
+		{"negative exponent", "15E-3", "0.015", false},
+		{"empty", "", "", true},
+		{"sign only", "-", "", true},
+		{"double sign", "--1", "", true},
+		{"letters", "1.2a", "", true},
+		{"bad exponent", "1e", "", true},
+		{"large exponent", "1e999999999", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			d, err := ParseDecimal(c.Value)
+			if c.Error {
+				if err == nil {
+					t.Errorf("got no error, expected error for %q", c.Value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if d.String() != c.Expected {
+				t.Errorf("got %q, expected %q", d.String(), c.Expected)
+			}
+		})
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct{ Amount Decimal }
+	if err := json.Unmarshal([]byte(`{"Amount":"0.10"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Amount.Cmp(NewDecimal(1, 1)) != 0 {
+		t.Errorf("got %s, expected 0.1", v.Amount)
+	}
+	if err := json.Unmarshal([]byte(`{"Amount":19.99}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"Amount":"19.99"}` {
+		t.Errorf("got %s, expected %s", b, `{"Amount":"19.99"}`)
+	}
+}
@@ -12,13 +12,57 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// authHooksKey is the request context key used to store the hooks
+	// added with WithAuthHook.
+	authHooksKey
 )
 
 type (
 	// private type used to define context keys.
 	contextKey int
+
+	// AuthHook is a function run by the generated endpoints once the
+	// security requirements of the method have been enforced. The hook
+	// receives the context returned by the security functions and the
+	// method payload if any. It returns the context used to call the
+	// service method or an error to reject the call.
+	AuthHook func(ctx context.Context, payload interface{}) (context.Context, error)
 )
 
 // Endpoint exposes service methods to remote clients independently of the
 // underlying transport.
 type Endpoint func(ctx context.Context, request interface{}) (response interface{}, err error)
+
+// WithAuthHook returns a copy of ctx that causes the generated endpoint to run
+// hook once the caller has been authenticated. Endpoint middlewares wrap the
+// generated endpoints and thus run before the security functions: they use
+// auth hooks to make decisions that depend on the values these functions store
+// in the context, for example the API key info stored by security.APIKeyAuth.
+func WithAuthHook(ctx context.Context, hook AuthHook) context.Context {
+	hooks, _ := ctx.Value(authHooksKey).([]AuthHook)
+	all := make([]AuthHook, len(hooks), len(hooks)+1)
+	copy(all, hooks)
+	return context.WithValue(ctx, authHooksKey, append(all, hook))
+}
+
+// RunAuthHooks runs the hooks added to ctx with WithAuthHook in the order they
+// were added and returns the resulting context. It stops and returns the
+// error of the first hook that fails. The generated endpoints call
+// RunAuthHooks after enforcing the method security requirements and before
+// calling the service method. The returned context does not hold the hooks so
+// that endpoints called by the service method do not run them again.
+func RunAuthHooks(ctx context.Context, payload interface{}) (context.Context, error) {
+	hooks, _ := ctx.Value(authHooksKey).([]AuthHook)
+	if len(hooks) == 0 {
+		return ctx, nil
+	}
+	ctx = context.WithValue(ctx, authHooksKey, []AuthHook(nil))
+	for _, h := range hooks {
+		var err error
+		if ctx, err = h(ctx, payload); err != nil {
+			return ctx, err
+		}
+	}
+	return ctx, nil
+}
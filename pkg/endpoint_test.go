@@ -0,0 +1,54 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunAuthHooks(t *testing.T) {
+	type key struct{}
+	var calls []string
+	hook := func(name string, err error) AuthHook {
+		return func(ctx context.Context, payload interface{}) (context.Context, error) {
+			calls = append(calls, name+":"+payload.(string))
+			return context.WithValue(ctx, key{}, name), err
+		}
+	}
+	errDenied := errors.New("denied")
+	cases := []struct {
+		Name          string
+		Hooks         []AuthHook
+		ExpectedCalls []string
+		ExpectedValue interface{}
+		ExpectedErr   error
+	}{
+		{"none", nil, nil, nil, nil},
+		{"in order", []AuthHook{hook("a", nil), hook("b", nil)}, []string{"a:p", "b:p"}, "b", nil},
+		{"stop on error", []AuthHook{hook("a", errDenied), hook("b", nil)}, []string{"a:p"}, "a", errDenied},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			calls = nil
+			ctx := context.Background()
+			for _, h := range c.Hooks {
+				ctx = WithAuthHook(ctx, h)
+			}
+			ctx, err := RunAuthHooks(ctx, "p")
+			if err != c.ExpectedErr {
+				t.Errorf("got error %v, expected %v", err, c.ExpectedErr)
+			}
+			if !reflect.DeepEqual(calls, c.ExpectedCalls) {
+				t.Errorf("got calls %v, expected %v", calls, c.ExpectedCalls)
+			}
+			if v := ctx.Value(key{}); v != c.ExpectedValue {
+				t.Errorf("got value %v, expected %v", v, c.ExpectedValue)
+			}
+			calls = nil
+			if _, err := RunAuthHooks(ctx, "p"); err != nil || len(calls) > 0 {
+				t.Errorf("hooks ran again: calls %v, error %v", calls, err)
+			}
+		})
+	}
+}
@@ -51,6 +51,34 @@ const (
 	InvalidRange = "invalid_range"
 	// InvalidLength is the error name for invalid length errors.
 	InvalidLength = "invalid_length"
+	// InvalidUniqueItems is the error name for arrays with duplicate
+	// elements.
+	InvalidUniqueItems = "invalid_unique_items"
+	// UnsupportedMediaType is the error name for requests whose body
+	// content type is not supported.
+	UnsupportedMediaType = "unsupported_media_type"
+	// MissingFieldGroup is the error name for payloads that only set some
+	// of the fields that must be set together.
+	MissingFieldGroup = "missing_field_group"
+	// ConflictingFields is the error name for payloads that set more than
+	// one of mutually exclusive fields.
+	ConflictingFields = "conflicting_fields"
+	// InvalidValue is the error name for values rejected by user provided
+	// validation functions.
+	InvalidValue = "invalid_value"
+	// FeatureDisabled is the error name for requests made to methods gated
+	// by a feature flag that is off.
+	FeatureDisabled = "feature_disabled"
+	// FeatureForbidden is the error name for requests made to methods gated
+	// by a feature flag that is off for the caller when the method existence
+	// need not be hidden.
+	FeatureForbidden = "feature_forbidden"
+	// MissingTenant is the error name for requests made to tenant-scoped
+	// methods that do not identify a tenant.
+	MissingTenant = "missing_tenant"
+	// PermissionDenied is the error name for requests made by callers that
+	// are not allowed to perform the operation.
+	PermissionDenied = "permission_denied"
 )
 
 // NewServiceError creates an error.
@@ -111,6 +139,12 @@ func DecodePayloadError(msg string) error {
 	return PermanentError("decode_payload", msg)
 }
 
+// UnsupportedMediaTypeError is the error produced by the generated code when
+// the content type of a request body is not one of the supported mime types.
+func UnsupportedMediaTypeError(contentType string, supported []string) error {
+	return PermanentError(UnsupportedMediaType, "unsupported content type %q, must be one of %s", contentType, strings.Join(supported, ", "))
+}
+
 // InvalidFieldTypeError is the error produced by the generated code when the
 // type of a payload field does not match the type defined in the design.
 func InvalidFieldTypeError(name string, val interface{}, expected string) error {
@@ -177,6 +211,33 @@ func InvalidLengthError(name string, target interface{}, ln, value int, min bool
 		InvalidLength, "length of %s must be %s than %d but got value %#v (len=%d)", name, comp, value, target, ln))
 }
 
+// InvalidUniqueItemsError is the error produced by the generated code when an
+// array that must contain unique elements contains duplicates.
+func InvalidUniqueItemsError(name string, dup interface{}) error {
+	return withField(name, PermanentError(
+		InvalidUniqueItems, "elements of %s must be unique but got duplicate value %#v", name, dup))
+}
+
+// MissingFieldGroupError is the error produced by the generated code when a
+// payload only sets some of the fields that must be set together.
+func MissingFieldGroupError(names []string, context string) error {
+	return PermanentError(MissingFieldGroup, "%s must be set together in %s", quoteNames(names), context)
+}
+
+// ConflictingFieldsError is the error produced by the generated code when a
+// payload sets more than one of mutually exclusive fields.
+func ConflictingFieldsError(names []string, context string) error {
+	return PermanentError(ConflictingFields, "at most one of %s may be set in %s", quoteNames(names), context)
+}
+
+// InvalidValueError is the error produced by the generated code when a user
+// provided validation function rejects the value of a payload field. cause is
+// the error returned by the function.
+func InvalidValueError(name string, val interface{}, cause error) error {
+	return withField(name, PermanentError(
+		InvalidValue, "invalid value %#v for %s: %s", val, name, cause.Error()))
+}
+
 // NewErrorID creates a unique 8 character ID that is well suited to use as an
 // error identifier.
 func NewErrorID() string {
@@ -249,6 +310,15 @@ func (e *ServiceError) ErrorName() string { return e.Name }
 
 func (e *ServiceError) Unwrap() error { return e.err }
 
+// quoteNames returns the quoted names separated with commas.
+func quoteNames(names []string) string {
+	elems := make([]string, len(names))
+	for i, n := range names {
+		elems[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(elems, ", ")
+}
+
 func withField(field string, err *ServiceError) *ServiceError {
 	err.Field = &field
 	return err
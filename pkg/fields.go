@@ -0,0 +1,54 @@
+package goa
+
+import "context"
+
+type (
+	// setFields is the set of payload attributes explicitly set by the
+	// client.
+	setFields map[string]struct{}
+
+	// setFieldsKeyType is the type of the context key used to store the
+	// set of payload attributes explicitly set by the client.
+	setFieldsKeyType struct{}
+)
+
+// setFieldsKey is the context key used to store the set of payload attributes
+// explicitly set by the client.
+var setFieldsKey = setFieldsKeyType{}
+
+// WithSetFields returns a copy of ctx that records the names of the payload
+// attributes explicitly set by the client. The generated transport code calls
+// WithSetFields prior to decoding the requests of methods whose payload define
+// nullable attributes.
+func WithSetFields(ctx context.Context) context.Context {
+	return context.WithValue(ctx, setFieldsKey, make(setFields))
+}
+
+// MarkSet records that the client explicitly set the payload attributes with
+// the given names. MarkSet does nothing if ctx was not initialized with
+// WithSetFields.
+func MarkSet(ctx context.Context, names ...string) {
+	fields, ok := ctx.Value(setFieldsKey).(setFields)
+	if !ok {
+		return
+	}
+	for _, n := range names {
+		fields[n] = struct{}{}
+	}
+}
+
+// WasSet returns true if the client explicitly set the payload attribute with
+// the given name, possibly to null. WasSet makes it possible for methods such
+// as PATCH handlers to tell attributes omitted from the request (nil field and
+// WasSet returns false) apart from attributes set to null (nil field and
+// WasSet returns true). The generated HTTP server code records the nullable
+// attributes present in JSON request bodies, WasSet always returns false for
+// other attributes.
+func WasSet(ctx context.Context, name string) bool {
+	fields, ok := ctx.Value(setFieldsKey).(setFields)
+	if !ok {
+		return false
+	}
+	_, ok = fields[name]
+	return ok
+}
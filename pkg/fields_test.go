@@ -0,0 +1,29 @@
+package goa
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWasSet(t *testing.T) {
+	ctx := WithSetFields(context.Background())
+	MarkSet(ctx, "a", "b")
+	cases := []struct {
+		Name     string
+		Ctx      context.Context
+		Field    string
+		Expected bool
+	}{
+		{"set", ctx, "a", true},
+		{"other set", ctx, "b", true},
+		{"not set", ctx, "c", false},
+		{"not recorded", context.Background(), "a", false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := WasSet(c.Ctx, c.Field); got != c.Expected {
+				t.Errorf("got %v, expected %v", got, c.Expected)
+			}
+		})
+	}
+}
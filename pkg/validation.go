@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/mail"
 	"net/url"
+	"reflect"
 	"regexp"
 	"sync"
 	"time"
@@ -163,6 +164,68 @@ func ValidatePattern(name, val, p string) error {
 	return nil
 }
 
+// ValidateUniqueItems returns an error if the slice val contains the same
+// element more than once. Elements are compared with reflect.DeepEqual so that
+// pointers to equal structs are considered duplicates. name is the name of the
+// variable used in error messages.
+func ValidateUniqueItems(name string, val interface{}) error {
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil
+	}
+	n := v.Len()
+	if et := v.Type().Elem(); et.Comparable() && et.Kind() != reflect.Ptr && et.Kind() != reflect.Interface {
+		seen := make(map[interface{}]struct{}, n)
+		for i := 0; i < n; i++ {
+			e := v.Index(i).Interface()
+			if _, ok := seen[e]; ok {
+				return InvalidUniqueItemsError(name, e)
+			}
+			seen[e] = struct{}{}
+		}
+		return nil
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if reflect.DeepEqual(v.Index(i).Interface(), v.Index(j).Interface()) {
+				return InvalidUniqueItemsError(name, v.Index(i).Interface())
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRequiredTogether returns an error if some but not all the fields with
+// the given names are set. set indicates whether each field is set. context is
+// used in error messages.
+func ValidateRequiredTogether(context string, names []string, set ...bool) error {
+	if n := countSet(set); n > 0 && n < len(set) {
+		return MissingFieldGroupError(names, context)
+	}
+	return nil
+}
+
+// ValidateMutex returns an error if more than one of the fields with the given
+// names is set. set indicates whether each field is set. context is used in
+// error messages.
+func ValidateMutex(context string, names []string, set ...bool) error {
+	if countSet(set) > 1 {
+		return ConflictingFieldsError(names, context)
+	}
+	return nil
+}
+
+// countSet returns the number of true values in set.
+func countSet(set []bool) int {
+	n := 0
+	for _, s := range set {
+		if s {
+			n++
+		}
+	}
+	return n
+}
+
 // The following formats are supported:
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
 // "6ba7b8109dad11d180b400c04fd430c8",
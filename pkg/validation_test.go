@@ -132,3 +132,71 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateUniqueItems(t *testing.T) {
+	type item struct{ A string }
+	var (
+		name = "foo"
+		foo  = "foo"
+	)
+	cases := map[string]struct {
+		val      interface{}
+		expected error
+	}{
+		"unique strings":      {[]string{"a", "b"}, nil},
+		"duplicate strings":   {[]string{"a", "b", "a"}, InvalidUniqueItemsError(name, "a")},
+		"unique pointers":     {[]*item{{"a"}, {"b"}}, nil},
+		"duplicate pointers":  {[]*item{{"a"}, {"a"}}, InvalidUniqueItemsError(name, &item{"a"})},
+		"duplicate pointees":  {[]*string{&foo, &foo}, InvalidUniqueItemsError(name, &foo)},
+		"unique interfaces":   {[]interface{}{1, "1"}, nil},
+		"duplicate structs":   {[]item{{"a"}, {"a"}}, InvalidUniqueItemsError(name, item{"a"})},
+		"nil slice":           {[]string(nil), nil},
+		"not a slice":         {"foo", nil},
+		"duplicate slices":    {[][]string{{"a"}, {"a"}}, InvalidUniqueItemsError(name, []string{"a"})},
+		"unique nested slice": {[][]string{{"a"}, {"b"}}, nil},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateUniqueItems(name, tc.val)
+		if (actual == nil) != (tc.expected == nil) {
+			t.Errorf("%s: got %v, expected %v", k, actual, tc.expected)
+			continue
+		}
+		if actual != nil && actual.Error() != tc.expected.Error() {
+			t.Errorf("%s: got %q, expected %q", k, actual.Error(), tc.expected.Error())
+		}
+	}
+}
+
+func TestValidateFieldGroups(t *testing.T) {
+	var (
+		context = "body"
+		names   = []string{"a", "b", "c"}
+	)
+	cases := map[string]struct {
+		set              []bool
+		requiredTogether error
+		mutex            error
+	}{
+		"none set": {[]bool{false, false, false}, nil, nil},
+		"one set":  {[]bool{true, false, false}, MissingFieldGroupError(names, context), nil},
+		"two set":  {[]bool{true, false, true}, MissingFieldGroupError(names, context), ConflictingFieldsError(names, context)},
+		"all set":  {[]bool{true, true, true}, nil, ConflictingFieldsError(names, context)},
+	}
+
+	for k, tc := range cases {
+		if actual := ValidateRequiredTogether(context, names, tc.set...); !sameError(actual, tc.requiredTogether) {
+			t.Errorf("%s: ValidateRequiredTogether: got %v, expected %v", k, actual, tc.requiredTogether)
+		}
+		if actual := ValidateMutex(context, names, tc.set...); !sameError(actual, tc.mutex) {
+			t.Errorf("%s: ValidateMutex: got %v, expected %v", k, actual, tc.mutex)
+		}
+	}
+}
+
+func sameError(actual, expected error) bool {
+	if actual == nil || expected == nil {
+		return actual == expected
+	}
+	return actual.Error() == expected.Error()
+}
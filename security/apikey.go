@@ -0,0 +1,203 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+type (
+	// APIKeyInfo describes the principal associated with a valid API key.
+	// It is stored in the request context by the function returned by
+	// APIKeyAuth.
+	APIKeyInfo struct {
+		// ID identifies the key owner, it never contains the key itself.
+		ID string
+		// Scopes lists the scopes granted to the key.
+		Scopes []string
+		// Meta contains arbitrary key metadata (tenant, plan etc.).
+		Meta map[string]string
+	}
+
+	// KeyValidator validates API keys. ValidateKey returns the information
+	// associated with the key or ErrInvalidKey if the key is unknown. Other
+	// errors are considered server faults.
+	KeyValidator interface {
+		ValidateKey(ctx context.Context, key string) (*APIKeyInfo, error)
+	}
+
+	// KeyValidatorFunc is a KeyValidator implemented by a function. It makes
+	// it possible to back the validation with an external store such as a
+	// database.
+	KeyValidatorFunc func(ctx context.Context, key string) (*APIKeyInfo, error)
+
+	// StaticKeys is a KeyValidator backed by a map of keys to key
+	// information.
+	StaticKeys map[string]*APIKeyInfo
+
+	// RedisKeys is a KeyValidator backed by Redis. Each key is stored in a
+	// hash whose name is Prefix followed by the hex encoded SHA-256 digest of
+	// the key so that the keys themselves are never stored. The hash field
+	// "id" contains the key ID, "scopes" the space separated list of scopes
+	// and the other fields the key metadata:
+	//
+	//    HSET apikey:<sha256 of key> id billing scopes "api:read api:write" tenant acme
+	RedisKeys struct {
+		// Client is the Redis client.
+		Client RedisHashGetter
+		// Prefix is prepended to the key digests to build the hash names.
+		Prefix string
+	}
+
+	// RedisHashGetter is the Redis client used by RedisKeys. HGetAll returns
+	// the fields of the hash with the given name, an empty map if the hash
+	// does not exist. Adapting a go-redis client only requires:
+	//
+	//    type redisClient struct{ *redis.Client }
+	//
+	//    func (c redisClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	//        return c.Client.HGetAll(ctx, key).Result()
+	//    }
+	RedisHashGetter interface {
+		HGetAll(ctx context.Context, key string) (map[string]string, error)
+	}
+
+	// private type used to define context keys
+	ctxKey int
+)
+
+const (
+	// APIKeyInfoKey is the context key used to store the APIKeyInfo of the
+	// authenticated request.
+	APIKeyInfoKey ctxKey = iota + 1
+)
+
+// ErrInvalidKey is the error returned by KeyValidator implementations when the
+// key is not recognized.
+var ErrInvalidKey = errors.New("invalid API key")
+
+// ValidateKey calls f.
+func (f KeyValidatorFunc) ValidateKey(ctx context.Context, key string) (*APIKeyInfo, error) {
+	return f(ctx, key)
+}
+
+// ValidateKey compares the key with all the keys in the map in constant time
+// so that the time it takes does not reveal how much of a key was matched.
+func (k StaticKeys) ValidateKey(_ context.Context, key string) (*APIKeyInfo, error) {
+	if key == "" {
+		return nil, ErrInvalidKey
+	}
+	var (
+		sum   = sha256.Sum256([]byte(key))
+		found bool
+		info  *APIKeyInfo
+	)
+	for candidate, i := range k {
+		csum := sha256.Sum256([]byte(candidate))
+		if subtle.ConstantTimeCompare(sum[:], csum[:]) == 1 {
+			found, info = true, i
+		}
+	}
+	if !found {
+		return nil, ErrInvalidKey
+	}
+	return info, nil
+}
+
+// ValidateKey looks up the hash named after the key digest.
+func (r *RedisKeys) ValidateKey(ctx context.Context, key string) (*APIKeyInfo, error) {
+	if key == "" {
+		return nil, ErrInvalidKey
+	}
+	sum := sha256.Sum256([]byte(key))
+	fields, err := r.Client.HGetAll(ctx, r.Prefix+hex.EncodeToString(sum[:]))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrInvalidKey
+	}
+	info := &APIKeyInfo{ID: fields["id"], Scopes: strings.Fields(fields["scopes"])}
+	for name, val := range fields {
+		if name == "id" || name == "scopes" {
+			continue
+		}
+		if info.Meta == nil {
+			info.Meta = make(map[string]string)
+		}
+		info.Meta[name] = val
+	}
+	return info, nil
+}
+
+// EnvKeys returns a StaticKeys validator initialized from the environment
+// variables whose names start with prefix. The remainder of the variable name
+// is used as the key ID and the variable value as the key. For example with
+// the prefix "API_KEY_" the variable "API_KEY_BILLING=s3cr3t" defines a key
+// "s3cr3t" with ID "billing".
+func EnvKeys(prefix string) StaticKeys {
+	keys := make(StaticKeys)
+	for _, kv := range os.Environ() {
+		elems := strings.SplitN(kv, "=", 2)
+		if len(elems) != 2 || elems[1] == "" || !strings.HasPrefix(elems[0], prefix) {
+			continue
+		}
+		id := strings.ToLower(strings.TrimPrefix(elems[0], prefix))
+		keys[elems[1]] = &APIKeyInfo{ID: id}
+	}
+	return keys
+}
+
+// APIKeyAuth returns an API key auth function suitable for implementing the
+// APIKeyAuth method of the generated Auther interfaces. The returned function
+// validates the key extracted by the generated transport code (from the header
+// or query string defined in the design) with v and checks the scopes required
+// by the scheme against the key scopes.
+//
+// The function returns a "unauthorized" error if the key is missing or invalid
+// and a "forbidden" error if the key lacks required scopes. Map these errors to
+// 401 and 403 responses in the design to render them with the error type:
+//
+//    Error("unauthorized")
+//    Error("forbidden")
+//    HTTP(func() {
+//        Response("unauthorized", StatusUnauthorized)
+//        Response("forbidden", StatusForbidden)
+//    })
+//
+// On success the key information is stored in the context and can be
+// retrieved with ContextAPIKeyInfo.
+func APIKeyAuth(v KeyValidator) AuthAPIKeyFunc {
+	return func(ctx context.Context, key string, s *APIKeyScheme) (context.Context, error) {
+		if key == "" {
+			return ctx, goa.PermanentError("unauthorized", "missing API key")
+		}
+		info, err := v.ValidateKey(ctx, key)
+		if err != nil {
+			if errors.Is(err, ErrInvalidKey) {
+				return ctx, goa.PermanentError("unauthorized", "invalid API key")
+			}
+			return ctx, goa.Fault("failed to validate API key: %s", err)
+		}
+		if info == nil {
+			info = &APIKeyInfo{}
+		}
+		if err := s.Validate(info.Scopes); err != nil {
+			return ctx, goa.PermanentError("forbidden", err.Error())
+		}
+		return context.WithValue(ctx, APIKeyInfoKey, info), nil
+	}
+}
+
+// ContextAPIKeyInfo returns the key information stored in ctx by the function
+// returned by APIKeyAuth, nil if there is none.
+func ContextAPIKeyInfo(ctx context.Context) *APIKeyInfo {
+	info, _ := ctx.Value(APIKeyInfoKey).(*APIKeyInfo)
+	return info
+}
@@ -0,0 +1,111 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	goa "goa.design/goa/v3/pkg"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	var (
+		keys = StaticKeys{
+			"reader": {ID: "reader", Scopes: []string{"api:read"}},
+			"writer": {ID: "writer", Scopes: []string{"api:read", "api:write"}},
+		}
+		failing = KeyValidatorFunc(func(context.Context, string) (*APIKeyInfo, error) {
+			return nil, errors.New("connection refused")
+		})
+		scheme = &APIKeyScheme{Name: "api_key", RequiredScopes: []string{"api:write"}}
+	)
+	cases := map[string]struct {
+		validator KeyValidator
+		key       string
+		errName   string
+		id        string
+	}{
+		"missing key":     {keys, "", "unauthorized", ""},
+		"invalid key":     {keys, "foo", "unauthorized", ""},
+		"missing scope":   {keys, "reader", "forbidden", ""},
+		"validator error": {failing, "writer", "fault", ""},
+		"valid key":       {keys, "writer", "", "writer"},
+	}
+	for k, c := range cases {
+		t.Run(k, func(t *testing.T) {
+			ctx, err := APIKeyAuth(c.validator)(context.Background(), c.key, scheme)
+			if c.errName != "" {
+				var serr *goa.ServiceError
+				if !errors.As(err, &serr) {
+					t.Fatalf("got error %v, expected service error %q", err, c.errName)
+				}
+				if serr.Name != c.errName {
+					t.Errorf("got error name %q, expected %q", serr.Name, c.errName)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			info := ContextAPIKeyInfo(ctx)
+			if info == nil || info.ID != c.id {
+				t.Errorf("got key info %+v, expected ID %q", info, c.id)
+			}
+		})
+	}
+}
+
+func TestEnvKeys(t *testing.T) {
+	t.Setenv("TEST_API_KEY_BILLING", "s3cr3t")
+	t.Setenv("TEST_API_KEY_EMPTY", "")
+	keys := EnvKeys("TEST_API_KEY_")
+	if len(keys) != 1 {
+		t.Fatalf("got %d keys, expected 1", len(keys))
+	}
+	info, err := keys.ValidateKey(context.Background(), "s3cr3t")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if info.ID != "billing" {
+		t.Errorf("got ID %q, expected %q", info.ID, "billing")
+	}
+}
+
+type (
+	redisHashes map[string]map[string]string
+	redisDown   struct{}
+)
+
+func (r redisHashes) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	return r[key], nil
+}
+
+func (redisDown) HGetAll(context.Context, string) (map[string]string, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRedisKeys(t *testing.T) {
+	sum := sha256.Sum256([]byte("s3cr3t"))
+	keys := &RedisKeys{
+		Client: redisHashes{
+			"apikey:" + hex.EncodeToString(sum[:]): {"id": "billing", "scopes": "api:read api:write", "tenant": "acme"},
+		},
+		Prefix: "apikey:",
+	}
+	info, err := keys.ValidateKey(context.Background(), "s3cr3t")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if info.ID != "billing" || len(info.Scopes) != 2 || info.Meta["tenant"] != "acme" {
+		t.Errorf("got key info %+v, expected ID billing, 2 scopes and tenant acme", info)
+	}
+	if _, err := keys.ValidateKey(context.Background(), "unknown"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("got error %v for unknown key, expected ErrInvalidKey", err)
+	}
+	down := &RedisKeys{Client: redisDown{}, Prefix: "apikey:"}
+	if _, err := down.ValidateKey(context.Background(), "s3cr3t"); err == nil || errors.Is(err, ErrInvalidKey) {
+		t.Errorf("got error %v, expected the client error", err)
+	}
+}
@@ -5,6 +5,9 @@ secure goa endpoint. It supports the following security schemes:
   * API key security using keys.
   * JWT security using JWT tokens.
   * OAuth2 security using OAuth2 tokens.
+
+The package also provides helpers that implement the generated auth functions,
+such as APIKeyAuth which validates API keys against a pluggable KeyValidator.
 */
 package security
 
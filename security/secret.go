@@ -0,0 +1,86 @@
+package security
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SecretLoader loads secrets from environment variables or files. The value of
+// a secret named NAME is read from the environment variable NAME or, if NAME
+// is not set, from the file whose path is given by the environment variable
+// NAME_FILE. The latter makes it possible to use Docker and Kubernetes secrets
+// mounted as files. The LoadSecurityConfig functions generated in the service
+// packages use a SecretLoader to initialize the service security
+// configuration:
+//
+//    var l security.SecretLoader
+//    key := l.Required("JWT_KEY")
+//    if err := l.Err(); err != nil {
+//        return err
+//    }
+//
+// SecretLoader records the missing secrets and the errors so that all the
+// issues are reported at once by Err.
+type SecretLoader struct {
+	missing []string
+	errs    []string
+}
+
+// Required returns the value of the secret with the given name and records an
+// error if the secret is not set or is empty.
+func (l *SecretLoader) Required(name string) string {
+	val := l.Optional(name)
+	if val == "" {
+		l.missing = append(l.missing, name)
+	}
+	return val
+}
+
+// Optional returns the value of the secret with the given name, "" if the
+// secret is not set.
+func (l *SecretLoader) Optional(name string) string {
+	if val := os.Getenv(name); val != "" {
+		return val
+	}
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return ""
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Sprintf("failed to read secret %s from %s: %s", name, path, err))
+		return ""
+	}
+	return strings.TrimRight(string(b), "\r\n")
+}
+
+// List returns the comma separated values of the secret with the given name
+// and records an error if the secret is not set or is empty.
+func (l *SecretLoader) List(name string) []string {
+	val := l.Required(name)
+	if val == "" {
+		return nil
+	}
+	elems := strings.Split(val, ",")
+	vals := make([]string, 0, len(elems))
+	for _, e := range elems {
+		if e = strings.TrimSpace(e); e != "" {
+			vals = append(vals, e)
+		}
+	}
+	return vals
+}
+
+// Err returns an error listing the missing secrets and the secrets that could
+// not be read, nil if all the secrets were loaded successfully.
+func (l *SecretLoader) Err() error {
+	errs := l.errs
+	if len(l.missing) > 0 {
+		errs = append([]string{"missing secrets " + strings.Join(l.missing, ", ") + " (set the environment variables or the corresponding _FILE variables)"}, errs...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid security configuration: %s", strings.Join(errs, "; "))
+}
@@ -0,0 +1,50 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSecretLoader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "key")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_SECRET_ENV", "from-env")
+	t.Setenv("TEST_SECRET_FILE_FILE", path)
+	t.Setenv("TEST_SECRET_LIST", "a, b,,c")
+	t.Setenv("TEST_SECRET_BAD_FILE", filepath.Join(dir, "missing"))
+
+	var l SecretLoader
+	if v := l.Required("TEST_SECRET_ENV"); v != "from-env" {
+		t.Errorf("got %q, expected %q", v, "from-env")
+	}
+	if v := l.Required("TEST_SECRET_FILE"); v != "from-file" {
+		t.Errorf("got %q, expected %q", v, "from-file")
+	}
+	if v := l.List("TEST_SECRET_LIST"); !reflect.DeepEqual(v, []string{"a", "b", "c"}) {
+		t.Errorf("got %v, expected [a b c]", v)
+	}
+	if v := l.Optional("TEST_SECRET_OPTIONAL"); v != "" {
+		t.Errorf("got %q, expected empty value", v)
+	}
+	if err := l.Err(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	l.Required("TEST_SECRET_MISSING")
+	l.Required("TEST_SECRET_BAD")
+	err := l.Err()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, s := range []string{"TEST_SECRET_MISSING, TEST_SECRET_BAD", "failed to read secret TEST_SECRET_BAD"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q does not contain %q", err, s)
+		}
+	}
+}